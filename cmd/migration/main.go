@@ -10,6 +10,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/Zughayyar/agora-server/internal/config"
 	"github.com/Zughayyar/agora-server/internal/database"
 	"github.com/Zughayyar/agora-server/internal/database/migrations"
 	"github.com/joho/godotenv"
@@ -18,8 +19,9 @@ import (
 func main() {
 	// Command line flags
 	var (
-		action  = flag.String("action", "migrate", "Action to perform: migrate, rollback, status")
-		envFile = flag.String("env", ".env", "Environment file to load")
+		action     = flag.String("action", "migrate", "Action to perform: migrate, rollback, status")
+		envFile    = flag.String("env", ".env", "Environment file to load")
+		configFile = flag.String("config", "", "Optional YAML or TOML config file (environment variables take precedence)")
 	)
 	flag.Parse()
 
@@ -34,11 +36,14 @@ func main() {
 	}))
 	slog.SetDefault(logger)
 
-	// Load database configuration
-	config := database.LoadConfig()
+	// Load and validate configuration
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
 
 	// Create database connection
-	db, err := database.NewConnection(config)
+	db, err := database.NewConnection(cfg.Database)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
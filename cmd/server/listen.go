@@ -0,0 +1,54 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/Zughayyar/agora-server/internal/config"
+)
+
+// listen opens the public listener: a TCP address or a unix domain socket
+func listen(cfg *config.Config) (net.Listener, error) {
+	if cfg.ListenNetwork != "unix" {
+		return net.Listen(cfg.ListenNetwork, cfg.ListenAddr)
+	}
+
+	// Remove a socket left behind by a previous run, but never a regular file
+	if info, err := os.Lstat(cfg.ListenAddr); err == nil {
+		if info.Mode().Type() != fs.ModeSocket {
+			return nil, fmt.Errorf("%s exists and is not a socket", cfg.ListenAddr)
+		}
+		if err := os.Remove(cfg.ListenAddr); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	ln, err := net.Listen("unix", cfg.ListenAddr)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(cfg.ListenAddr, cfg.SocketMode); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+	return ln, nil
+}
+
+// serve runs server on ln over HTTPS when TLS is configured, plain HTTP otherwise
+func serve(cfg *config.Config, server *http.Server, ln net.Listener) error {
+	if !cfg.TLSEnabled() {
+		return server.Serve(ln)
+	}
+	if server.TLSConfig == nil {
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	// With autocert the certificate comes from TLSConfig.GetCertificate and both paths are empty
+	return server.ServeTLS(ln, cfg.TLSCert, cfg.TLSKey)
+}
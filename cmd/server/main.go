@@ -3,28 +3,47 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // RESTAURANT_TIMEZONE works without zoneinfo in the image
 
+	"github.com/Zughayyar/agora-server/internal/broker"
+	"github.com/Zughayyar/agora-server/internal/config"
 	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/featureflags"
+	"github.com/Zughayyar/agora-server/internal/grpcapi"
+	"github.com/Zughayyar/agora-server/internal/jobs"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/metrics"
 	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/realtime"
+	"github.com/Zughayyar/agora-server/internal/restaurants"
 	router "github.com/Zughayyar/agora-server/internal/routers"
-
-	// Swagger imports
-	_ "github.com/Zughayyar/agora-server/docs" // This will be generated
+	"github.com/Zughayyar/agora-server/internal/scheduler"
+	"github.com/Zughayyar/agora-server/internal/services"
+	"github.com/Zughayyar/agora-server/internal/storage"
+	"github.com/Zughayyar/agora-server/internal/tracing"
+	"github.com/Zughayyar/agora-server/internal/version"
 
 	"github.com/joho/godotenv"
+	"github.com/shopspring/decimal"
 	"github.com/uptrace/bun"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
 )
 
 // @title Agora Restaurant Management API
 // @version 1.0
-// @description A RESTful API for restaurant menu management
+// @description A RESTful API for restaurant menu management. Requests are limited to the restaurant named by their X-Restaurant-ID header, restaurant 1 when it is omitted.
 // @termsOfService https://agora-restaurant.com/terms
 // @contact.name API Support
 // @contact.url https://agora-restaurant.com/support
@@ -32,29 +51,125 @@ import (
 // @license.name MIT
 // @license.url https://opensource.org/licenses/MIT
 // @host localhost:3000
-// @BasePath /api/v1
+// @BasePath /
 // @schemes http https
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description Admin bearer token, e.g. "Bearer <ADMIN_TOKEN>"
 func main() {
+	configFile := flag.String("config", "", "Optional YAML or TOML config file (environment variables take precedence)")
+	flag.Parse()
+
 	if err := godotenv.Load(); err != nil {
 		slog.Warn("No .env file found, using system environment variables")
 	}
 
-	// Setup structured logger
+	// Load and validate all settings up front so misconfiguration fails fast
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		slog.Error("Failed to load configuration", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	// Setup structured logger (level can be changed at runtime via PUT /admin/log-level)
 	var logger *slog.Logger
-	if os.Getenv("APP_ENV") == "development" {
+	logging.Level.Set(cfg.LogLevel)
+	if cfg.IsDevelopment() {
 		logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelDebug,
+			Level: logging.Level,
 		}))
 	} else {
 		logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelInfo,
+			Level: logging.Level,
 		}))
 	}
 
 	slog.SetDefault(logger)
 
+	// Timestamps are returned in, and read without an offset in, the default restaurant's
+	// timezone, where reports split sales into business days. Order tax is added to prices
+	// unless they already include it. Both are business settings of each restaurant, whose
+	// stored values replace these defaults once the database is open.
+	taxMode := services.TaxModeExclusive
+	if cfg.TaxIncludedInPrices {
+		taxMode = services.TaxModeInclusive
+	}
+	services.SetDefaultSettings(services.Settings{
+		Timezone: cfg.Timezone.String(),
+		Currency: "USD",
+		TaxMode:  taxMode,
+	})
+	services.SetBusinessDayStart(cfg.BusinessDayStart)
+
+	// Customers earn loyalty points on paid orders and redeem them on new ones
+	services.SetLoyaltyProgram(services.LoyaltyProgram{
+		PointsPerUnit: cfg.LoyaltyPointsPerUnit,
+		MinOrder:      cfg.LoyaltyMinOrder,
+		PointValue:    cfg.LoyaltyPointValue,
+	})
+
+	// Order lines are routed to the kitchen station preparing them, and orders are
+	// estimated to be ready by the stations' queues
+	services.SetKitchenStations(services.KitchenStations{
+		Stations:   cfg.KitchenStations,
+		Categories: cfg.KitchenCategoryStations,
+		Default:    cfg.KitchenDefaultStation,
+		PrepTime:   cfg.KitchenDefaultPrepTime,
+		QueueDelay: cfg.KitchenQueueDelay,
+	})
+
+	// Online orders are throttled per time slot
+	services.SetOrderSlots(services.OrderSlots{Length: cfg.OrderSlotLength, Capacity: cfg.OrderSlotCapacity})
+
+	// Staff clock in for their shifts, and timesheets cover the pay periods
+	services.SetTimeClock(services.TimeClock{
+		EarlyClockIn:   cfg.ClockInEarly,
+		PayPeriodDays:  cfg.PayPeriodDays,
+		PayPeriodStart: cfg.PayPeriodStart,
+		OvertimeDaily:  cfg.OvertimeDaily,
+		OvertimeWeekly: cfg.OvertimeWeekly,
+	})
+
+	// Reviews tripping the spam and abuse checks wait for a manager
+	services.SetReviewModeration(services.ReviewModeration{BlockedWords: cfg.ReviewBlockedWords})
+
+	// Guests order from the QR codes on the tables
+	services.SetGuestOrdering(services.GuestOrdering{SessionTTL: cfg.GuestSessionTTL, OrderURL: cfg.GuestOrderURL})
+
+	// Customers follow their orders on public status pages with signed tracking tokens
+	services.SetOrderTrackingSecret(cfg.OrderTrackingSecret)
+
+	// Prices are JSON strings unless the legacy number format is configured
+	decimal.MarshalJSONWithoutQuotes = cfg.PriceFormat == "number"
+
+	appVersion := version.Get().Version
+
+	// Setup distributed tracing (no-op unless an OTLP endpoint is configured)
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Enabled:     cfg.TracingEnabled,
+		Version:     appVersion,
+		Environment: cfg.Env,
+	})
+	if err != nil {
+		logger.Error("Failed to initialize tracing", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error("Failed to flush traces", slog.String("error", err.Error()))
+		}
+	}()
+
+	// Application lifetime context for background goroutines, cancelled on shutdown
+	appCtx, stopApp := context.WithCancel(context.Background())
+	defer stopApp()
+
 	// Initialize database with connection pooling
-	db, err := initDatabase()
+	dbConfig := cfg.Database
+	db, err := initDatabase(dbConfig)
 	if err != nil {
 		logger.Error("Failed to initialize database", slog.String("error", err.Error()))
 		os.Exit(1)
@@ -65,68 +180,290 @@ func main() {
 		}
 	}()
 
-	appName := "Agora Restaurant Management API"
-	appVersion := os.Getenv("APP_VERSION")
-	appPort := os.Getenv("APP_PORT")
-	if appPort == "" {
-		appPort = "3000" // Updated to match actual usage
+	// Pick up rotated credentials from the secrets manager, if one is configured
+	database.WatchCredentials(appCtx, db, dbConfig)
+
+	// Route reads to the replica when one is configured
+	if dbConfig.ReplicaDSN != "" {
+		replica, err := database.NewReplicaConnection(dbConfig)
+		if err != nil {
+			logger.Error("Failed to open read replica", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		replica.AddQueryHook(metrics.NewQueryHook())
+		database.RegisterReplica(appCtx, db, replica, 10*time.Second)
+		defer func() {
+			if err := database.Close(replica); err != nil {
+				logger.Error("Failed to close replica connection", slog.String("error", err.Error()))
+			}
+		}()
+		logger.Info("Read replica configured")
+	}
+
+	// Business settings stored through /api/v1/settings, reloaded every minute so changes
+	// made through another instance apply here too
+	settingsRepo := models.NewSettingQuery(db)
+	if _, err := services.LoadSettings(appCtx, settingsRepo); err != nil {
+		logger.Warn("Failed to load settings, using the defaults from the environment", slog.String("error", err.Error()))
 	}
-	appEnv := os.Getenv("APP_ENV")
+	services.WatchSettings(appCtx, settingsRepo, time.Minute)
+
+	// Expose connection pool statistics and query metrics through the metrics endpoint
+	metrics.RegisterDBStats(db)
+	db.AddQueryHook(metrics.NewQueryHook())
+
+	appName := "Agora Restaurant Management API"
 
 	// Create a new ServeMux for routing
 	mux := http.NewServeMux()
 
-	// Setup routes with database dependency
-	router.SetupRoutes(mux, db)
+	// Server write timeout; API requests time out slightly earlier so a 504
+	// can still be written (imports and exports extend it per request)
+	writeTimeout := cfg.RequestTimeout + time.Second
 
-	// Add catch-all 404 handler for unmatched routes (except root)
-	mux.HandleFunc("/{path...}", middlewares.NotFoundHandler())
+	// Registry of real-time (SSE) connections, drained before shutdown
+	hub := realtime.NewHub()
+
+	// Background job queue, stopped before shutdown
+	queue := jobs.NewQueue(db, jobs.Options{Workers: cfg.JobWorkers, MaxAttempts: cfg.JobMaxAttempts})
+
+	exportStorage, err := storage.NewLocal(cfg.ExportDir)
+	if err != nil {
+		logger.Error("Failed to initialize export storage", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	exports := services.NewExportJobs(queue, services.NewMenuItemExporter(models.NewMenuItemQuery(db)), exportStorage)
+
+	// Change events go to real-time clients and, through the outbox, to the message broker.
+	// On Postgres they reach the clients of every replica through LISTEN/NOTIFY.
+	events := services.EventPublishers{hub}
+	var feed *realtime.PGFeed
+	if cfg.RealtimeNotifyChannel != "off" && !database.IsMySQL(db) && !cfg.Database.PgBouncerMode {
+		feed = realtime.NewPGFeed(db, hub, cfg.RealtimeNotifyChannel)
+		feed.Start(appCtx)
+		events = services.EventPublishers{feed}
+	}
+	events = append(events, services.NewWebhookDispatcher(db, queue))
+	var producer broker.Producer
+	if cfg.Broker != "" {
+		producer, err = broker.New(cfg.Broker, cfg.BrokerURLs, cfg.BrokerClientID)
+		if err != nil {
+			logger.Error("Failed to connect to the message broker", slog.String("broker", cfg.Broker), slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		events = append(events, services.NewEventOutbox(queue, producer, cfg.BrokerTopicPrefix))
+		logger.Info("Publishing change events to the message broker", slog.String("broker", cfg.Broker))
+	}
+	queue.Start()
+
+	// Periodic tasks, stopped before shutdown
+	purger := services.NewSoftDeletePurger(cfg.PurgeDeletedAfterDays, map[string]services.DeletedPurger{
+		"menu_items": models.NewMenuItemQuery(db),
+	})
+	sched := scheduler.New(db)
+	if err := registerTasks(sched, db, cfg, purger); err != nil {
+		logger.Error("Failed to register scheduled tasks", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	sched.Start()
+
+	// Feature flags of endpoints being rolled out, per environment and tenant
+	flags := featureflags.New(db, cfg.Env, cfg.FeatureFlags)
+
+	// Setup routes with database dependency
+	routes := router.SetupRoutes(mux, db, cfg, hub, events, exports, sched, purger, flags)
 
 	// Apply global middleware stack
+	// Settings that can be changed without a restart
+	corsOrigins := middlewares.NewOriginList(cfg.CORSAllowedOrigins)
+	rateLimiter := middlewares.NewRateLimiter(cfg.RateLimitRPS, cfg.RateLimitBurst)
+	middlewares.ReadOnly.Store(cfg.ReadOnly)
+	if cfg.ReadOnly {
+		logger.Warn("Read-only mode is enabled, mutating requests will be rejected")
+	}
+
 	var handler http.Handler = mux
+	handler = middlewares.NormalizePathMiddleware(handler)
 	handler = middlewares.RecoveryMiddleware(handler)
+	if cfg.CompressionEnabled {
+		handler = middlewares.CompressionMiddleware(cfg.CompressionMinSize)(handler)
+	}
+	handler = rateLimiter.Middleware(handler)
 	handler = middlewares.LoggingMiddleware(handler)
-	handler = middlewares.CORSMiddleware(handler)
+	handler = middlewares.MetricsMiddleware(handler)
+	handler = middlewares.IPFilterMiddleware(cfg.AllowedIPs, cfg.DeniedIPs)(handler)
+	handler = middlewares.ClientIPMiddleware(cfg.TrustedProxies)(handler)
+	handler = middlewares.RequestContextMiddleware(handler)
+	handler = middlewares.TracingMiddleware(handler)
+	handler = middlewares.CORSMiddleware(corsOrigins)(handler)
+
+	// Reload dynamic settings on SIGHUP or when the config file changes
+	go config.Watch(appCtx, *configFile, func(next *config.Config) {
+		logging.Level.Set(next.LogLevel)
+		corsOrigins.Set(next.CORSAllowedOrigins)
+		rateLimiter.SetLimit(next.RateLimitRPS, next.RateLimitBurst)
+		middlewares.ReadOnly.Store(next.ReadOnly)
+		flags.SetOverrides(next.FeatureFlags)
+		logger.Info("Configuration reloaded",
+			slog.String("log_level", next.LogLevel.String()),
+			slog.Any("cors_allowed_origins", next.CORSAllowedOrigins),
+			slog.Float64("rate_limit_rps", next.RateLimitRPS),
+			slog.Int("rate_limit_burst", next.RateLimitBurst),
+			slog.Bool("read_only", next.ReadOnly),
+			slog.Any("feature_flags", next.FeatureFlags),
+		)
+	})
+
+	// Accept HTTP/2 prior-knowledge and upgrade connections without TLS
+	if cfg.H2C {
+		handler = h2c.NewHandler(handler, &http2.Server{})
+	}
 
 	// Create server with production-ready timeouts
 	server := &http.Server{
-		Addr:         ":" + appPort,
 		Handler:      handler,
 		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
+		WriteTimeout: writeTimeout,
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Let's Encrypt certificates need a plain HTTP listener for the ACME challenge
+	var challengeServer *http.Server
+	if len(cfg.AutocertDomains) > 0 {
+		challengeServer = setupAutocert(cfg, server)
+		go func() {
+			if err := challengeServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("ACME challenge server failed to start", slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+		}()
+	}
+
+	// Open the TCP port or unix socket up front so a bad address fails immediately
+	ln, err := listen(cfg)
+	if err != nil {
+		logger.Error("Failed to listen", slog.String("address", cfg.ListenAddr), slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
 	// Start server in a goroutine for graceful shutdown
 	go func() {
 		logger.Info("🚀 Agora Server starting",
 			slog.String("app", appName),
 			slog.String("version", appVersion),
-			slog.String("port", appPort),
-			slog.String("env", appEnv),
-		)
-		logger.Info("🏥 Health endpoints available:",
-			slog.String("root", fmt.Sprintf("http://localhost:%s/health", appPort)),
-			slog.String("api", fmt.Sprintf("http://localhost:%s/api/v1/health", appPort)),
+			slog.String("commit", version.Get().Commit),
+			slog.String("listen", cfg.ListenNetwork+":"+cfg.ListenAddr),
+			slog.String("env", cfg.Env),
+			slog.Bool("tls", cfg.TLSEnabled()),
+			slog.Bool("h2c", cfg.H2C),
 		)
+		if addr, ok := ln.Addr().(*net.TCPAddr); ok {
+			scheme := "http"
+			if cfg.TLSEnabled() {
+				scheme = "https"
+			}
+			logger.Info("🏥 Health endpoints available:",
+				slog.String("root", fmt.Sprintf("%s://localhost:%d/health", scheme, addr.Port)),
+				slog.String("api", fmt.Sprintf("%s://localhost:%d/api/v1/health", scheme, addr.Port)),
+			)
+		}
 
-		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		if err := serve(cfg, server, ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logger.Error("Server failed to start", slog.String("error", err.Error()))
 			os.Exit(1)
 		}
 	}()
 
+	// Serve operational endpoints on their own listener when configured
+	var adminServer *http.Server
+	if cfg.AdminAddr != "" {
+		adminServer = newAdminServer(cfg, db, sched, purger, flags, routes, writeTimeout)
+		go func() {
+			logger.Info("🔧 Admin listener starting", slog.String("addr", cfg.AdminAddr))
+			if err := adminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("Admin server failed to start", slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+		}()
+	}
+
+	// Serve the gRPC API for internal consumers when configured
+	var grpcServer *grpc.Server
+	if cfg.GRPCAddr != "" {
+		grpcLn, err := net.Listen("tcp", cfg.GRPCAddr)
+		if err != nil {
+			logger.Error("Failed to listen for gRPC", slog.String("addr", cfg.GRPCAddr), slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		grpcServer = grpcapi.NewServer(
+			services.NewMenuItemService(models.NewMenuItemQuery(db), models.NewPricingRuleQuery(db), models.NewLocationQuery(db), events),
+			services.NewOrderService(models.NewOrderQuery(db), models.NewMenuItemQuery(db), models.NewTaxRateQuery(db),
+				models.NewPricingRuleQuery(db), models.NewCouponQuery(db), models.NewPromotionQuery(db),
+				models.NewLoyaltyQuery(db), models.NewStoreCreditQuery(db), models.NewLocationQuery(db), events),
+			restaurants.New(db),
+			cfg.RequestTimeout)
+		go func() {
+			logger.Info("📡 gRPC listener starting", slog.String("addr", cfg.GRPCAddr))
+			if err := grpcServer.Serve(grpcLn); err != nil {
+				logger.Error("gRPC server failed", slog.String("error", err.Error()))
+				os.Exit(1)
+			}
+		}()
+	}
+
 	// Wait for interrupt signal for graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	logger.Info("Shutting down server...")
+	stopApp()
 
 	// Give outstanding requests 30 seconds to complete
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Tell streaming clients to reconnect; http.Server.Shutdown does not wait for them
+	if feed != nil {
+		if err := feed.Close(); err != nil {
+			logger.Warn("Failed to stop the event notification listener", slog.String("error", err.Error()))
+		}
+	}
+	if err := hub.Shutdown(ctx); err != nil {
+		logger.Warn("Real-time connections did not close in time", slog.String("error", err.Error()))
+	}
+
+	// Stop scheduling; running tasks are cancelled
+	if err := sched.Shutdown(ctx); err != nil {
+		logger.Warn("Scheduled tasks did not stop in time", slog.String("error", err.Error()))
+	}
+
+	// Stop the job workers; interrupted jobs run again after the restart
+	if err := queue.Shutdown(ctx); err != nil {
+		logger.Warn("Background jobs did not stop in time", slog.String("error", err.Error()))
+	}
+	if producer != nil {
+		if err := producer.Close(); err != nil {
+			logger.Warn("Failed to close the message broker connection", slog.String("error", err.Error()))
+		}
+	}
+
+	if challengeServer != nil {
+		if err := challengeServer.Shutdown(ctx); err != nil {
+			logger.Error("ACME challenge server forced to shutdown", slog.String("error", err.Error()))
+		}
+	}
+
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			logger.Error("Admin server forced to shutdown", slog.String("error", err.Error()))
+		}
+	}
+
+	if grpcServer != nil {
+		stopGRPC(ctx, grpcServer)
+	}
+
 	if err := server.Shutdown(ctx); err != nil {
 		logger.Error("Server forced to shutdown", slog.String("error", err.Error()))
 		os.Exit(1)
@@ -135,16 +472,51 @@ func main() {
 	logger.Info("Server exited gracefully")
 }
 
-// initDatabase initializes the database connection
-func initDatabase() (*bun.DB, error) {
-	// Load database configuration from environment
-	config := database.LoadConfig()
+// newAdminServer creates the server for the dedicated admin listener
+func newAdminServer(cfg *config.Config, db *bun.DB, sched *scheduler.Scheduler, purger *services.SoftDeletePurger, flags *featureflags.Flags, routes *router.Routes, writeTimeout time.Duration) *http.Server {
+	mux := http.NewServeMux()
+	router.SetupAdminServerRoutes(routes.Listener(mux, router.ListenerAdmin), db, sched, purger, flags, cfg.AdminToken, cfg.AdminAllowedIPs)
+
+	var handler http.Handler = mux
+	handler = middlewares.RecoveryMiddleware(handler)
+	handler = middlewares.LoggingMiddleware(handler)
+	handler = middlewares.ClientIPMiddleware(cfg.TrustedProxies)(handler)
+	handler = middlewares.RequestContextMiddleware(handler)
 
+	return &http.Server{
+		Addr:         cfg.AdminAddr,
+		Handler:      handler,
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: writeTimeout,
+		IdleTimeout:  60 * time.Second,
+	}
+}
+
+// stopGRPC stops the gRPC server gracefully, cancelling the calls still running
+// when ctx is done
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
+
+// initDatabase initializes the database connection
+func initDatabase(config *database.Config) (*bun.DB, error) {
 	// Create database connection with optimized connection pooling
 	db, err := database.NewConnection(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// Fail fast with 503s instead of piling requests onto an unhealthy database
+	services.ConfigureCircuitBreaker(config.BreakerFailureThreshold, config.BreakerOpenTimeout)
+
 	return db, nil
 }
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/config"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/jobs"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/scheduler"
+	"github.com/Zughayyar/agora-server/internal/services"
+	"github.com/Zughayyar/agora-server/internal/webhooks"
+)
+
+// Retention of the background job, task run and webhook delivery history
+const (
+	succeededJobRetention    = 7 * 24 * time.Hour
+	taskRunRetention         = 30 * 24 * time.Hour
+	webhookDeliveryRetention = 30 * 24 * time.Hour
+)
+
+// registerTasks registers the periodic tasks on their configured schedules
+func registerTasks(sched *scheduler.Scheduler, db *bun.DB, cfg *config.Config, purger *services.SoftDeletePurger) error {
+	err := sched.Register("purge_deleted", cfg.Schedules["purge_deleted"], func(ctx context.Context) (any, error) {
+		if middlewares.ReadOnly.Load() {
+			return nil, errors.New("skipped: read-only mode is enabled")
+		}
+		return purger.Purge(ctx, false)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Anonymizing orders is opt-in, as it can't be undone
+	anonymizeSpec := cfg.Schedules["anonymize_orders"]
+	if cfg.AnonymizeOrdersAfterDays == 0 {
+		anonymizeSpec = "off"
+	}
+	err = sched.Register("anonymize_orders", anonymizeSpec, func(ctx context.Context) (any, error) {
+		if middlewares.ReadOnly.Load() {
+			return nil, errors.New("skipped: read-only mode is enabled")
+		}
+		return services.NewOrderAnonymizer(models.NewAnonymizationQuery(db)).Anonymize(ctx, cfg.AnonymizeOrdersAfterDays)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = sched.Register("refresh_reports", cfg.Schedules["refresh_reports"], func(ctx context.Context) (any, error) {
+		if middlewares.ReadOnly.Load() {
+			return nil, errors.New("skipped: read-only mode is enabled")
+		}
+		return services.NewReportViewRefresher(models.NewReportViewQuery(db)).Refresh(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	return sched.Register("prune_history", cfg.Schedules["prune_history"], func(ctx context.Context) (any, error) {
+		now := time.Now()
+		prunedJobs, err := jobs.PruneSucceeded(ctx, db, now.Add(-succeededJobRetention))
+		if err != nil {
+			return nil, err
+		}
+		prunedRuns, err := scheduler.PruneRuns(ctx, db, now.Add(-taskRunRetention))
+		if err != nil {
+			return nil, err
+		}
+		prunedDeliveries, err := webhooks.PruneDeliveries(ctx, db, now.Add(-webhookDeliveryRetention))
+		if err != nil {
+			return nil, err
+		}
+		return map[string]int64{"jobs": prunedJobs, "task_runs": prunedRuns, "webhook_deliveries": prunedDeliveries}, nil
+	})
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"crypto/tls"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/Zughayyar/agora-server/internal/config"
+)
+
+// setupAutocert configures server to obtain certificates from Let's Encrypt for the
+// configured domains. It returns the plain HTTP server that answers ACME HTTP-01
+// challenges and redirects all other requests to HTTPS; the caller must run it.
+func setupAutocert(cfg *config.Config, server *http.Server) *http.Server {
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(cfg.AutocertDomains...),
+		Cache:      autocert.DirCache(cfg.AutocertCacheDir),
+		Email:      cfg.AutocertEmail,
+	}
+
+	server.TLSConfig = manager.TLSConfig()
+	server.TLSConfig.MinVersion = tls.VersionTLS12
+
+	slog.Info("Autocert enabled",
+		slog.Any("domains", cfg.AutocertDomains),
+		slog.String("cache_dir", cfg.AutocertCacheDir),
+		slog.String("challenge_addr", cfg.AutocertChallengeAddr),
+	)
+
+	return &http.Server{
+		Addr:         cfg.AutocertChallengeAddr,
+		Handler:      manager.HTTPHandler(nil),
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 5 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+}
@@ -24,94 +24,121 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/health": {
+        "/admin/anonymization-runs": {
             "get": {
-                "description": "Returns the health status of the service including database connectivity",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns the audit entries of the anonymize_orders task, newest first: the cutoff before which orders were anonymized, the orders, coupon redemptions, carts and reviews whose contact details were cleared, and the error a run stopped on",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Health"
+                    "Admin"
+                ],
+                "summary": "Anonymization runs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of runs (default 100, at most 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Comprehensive health check",
                 "responses": {
                     "200": {
-                        "description": "Service and database are healthy",
+                        "description": "Anonymization runs",
                         "schema": {
-                            "$ref": "#/definitions/handlers.HealthResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.AnonymizationRunResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "503": {
-                        "description": "Service is degraded (database issues)",
+                    "400": {
+                        "description": "Invalid limit",
                         "schema": {
-                            "$ref": "#/definitions/handlers.HealthResponse"
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/health": {
+        "/admin/db/stats": {
             "get": {
-                "description": "Returns the basic health status of the service",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns connection pool statistics for tuning DB_MAX_OPEN_CONNS and related settings",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Health"
+                    "Admin"
                 ],
-                "summary": "Basic health check",
+                "summary": "Database pool statistics",
                 "responses": {
                     "200": {
-                        "description": "Service is healthy",
+                        "description": "Connection pool statistics",
                         "schema": {
-                            "$ref": "#/definitions/handlers.HealthResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.DatabaseStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/menu-items": {
+        "/admin/feature-flags": {
             "get": {
-                "description": "Retrieves all menu items with optional filtering by category, availability, or search term",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
                 ],
+                "description": "Lists the feature flags by name, with their FEATURE_FLAGS override and whether they are active in this instance's environment. Flags limited to tenants are active only for requests whose X-Tenant-ID header names one of them.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Menu Items"
-                ],
-                "summary": "Get all menu items",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter by category (appetizer, main, dessert, drink, side, fast food)",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by availability (true/false)",
-                        "name": "available",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Include soft-deleted items (true/false)",
-                        "name": "include_deleted",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search term to filter menu items",
-                        "name": "search",
-                        "in": "query"
-                    }
+                    "Admin"
                 ],
+                "summary": "Feature flags",
                 "responses": {
                     "200": {
-                        "description": "Menu items retrieved successfully",
+                        "description": "Feature flags",
                         "schema": {
                             "allOf": [
                                 {
@@ -123,7 +150,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/services.MenuItemResponse"
+                                                "$ref": "#/definitions/featureflags.Status"
                                             }
                                         }
                                     }
@@ -138,9 +165,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Creates a new menu item with the provided details",
+            }
+        },
+        "/admin/feature-flags/{name}": {
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Creates or replaces a feature flag. It is on while enabled, in the listed environments (development, staging, production, test) and for the listed tenants; empty lists mean all of them. Other instances pick the change up within 30 seconds.",
                 "consumes": [
                     "application/json"
                 ],
@@ -148,23 +182,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Menu Items"
+                    "Admin"
                 ],
-                "summary": "Create a new menu item",
+                "summary": "Set a feature flag",
                 "parameters": [
                     {
-                        "description": "Menu item details",
-                        "name": "item",
+                        "type": "string",
+                        "description": "Flag name (lowercase letters, digits, '.', '_' or '-')",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Flag",
+                        "name": "flag",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/services.CreateMenuItemRequest"
+                            "$ref": "#/definitions/featureflags.SetRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Menu item created successfully",
+                    "200": {
+                        "description": "Feature flag updated",
                         "schema": {
                             "allOf": [
                                 {
@@ -174,7 +215,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/services.MenuItemResponse"
+                                            "$ref": "#/definitions/featureflags.Flag"
                                         }
                                     }
                                 }
@@ -182,7 +223,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request format",
+                        "description": "Invalid feature flag",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -194,57 +235,39 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/menu-items/{id}": {
-            "get": {
-                "description": "Retrieves a specific menu item by its ID",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
                 ],
+                "description": "Removes a feature flag, which turns it off unless FEATURE_FLAGS forces it on",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Menu Items"
+                    "Admin"
                 ],
-                "summary": "Get menu item by ID",
+                "summary": "Delete a feature flag",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Menu item ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "Flag name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Menu item retrieved successfully",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handlers.SuccessResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/services.MenuItemResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid menu item ID",
+                        "description": "Feature flag deleted",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/handlers.SuccessResponse"
                         }
                     },
                     "404": {
-                        "description": "Menu item not found",
+                        "description": "Feature flag not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -256,40 +279,46 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Updates an existing menu item with the provided details",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admin/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
                 ],
+                "description": "Lists background jobs, newest first. Filter by status=dead to inspect dead-lettered jobs.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Menu Items"
+                    "Admin"
                 ],
-                "summary": "Update menu item",
+                "summary": "Background jobs",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Menu item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Job status (pending, running, succeeded, dead)",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
-                        "description": "Updated menu item details",
-                        "name": "item",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/services.UpdateMenuItemRequest"
-                        }
+                        "type": "string",
+                        "description": "Job kind, e.g. export",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of jobs (default 100, at most 500)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Menu item updated successfully",
+                        "description": "Jobs retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -299,7 +328,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/services.MenuItemResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/jobs.Job"
+                                            }
                                         }
                                     }
                                 }
@@ -307,13 +339,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request format or menu item ID",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Menu item not found",
+                        "description": "Invalid filter",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -325,49 +351,59 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Soft deletes a menu item (can be restored) or permanently deletes with force=true",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admin/jobs/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
                 ],
+                "description": "Puts a dead-lettered job back in the queue with a fresh set of attempts",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Menu Items"
+                    "Admin"
                 ],
-                "summary": "Delete menu item",
+                "summary": "Retry a dead job",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Menu item ID",
+                        "description": "Job ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Permanently delete the item (true/false)",
-                        "name": "force",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Menu item deleted successfully",
+                        "description": "Job requeued",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/jobs.Job"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid menu item ID",
+                    "404": {
+                        "description": "Job not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Menu item not found",
+                    "409": {
+                        "description": "Job is not dead",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -380,70 +416,13985 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.DatabaseHealthStatus": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string"
-                },
-                "response_time_ms": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "string"
-                }
-            }
         },
-        "handlers.ErrorResponse": {
+        "/admin/log-level": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns the current minimum log level",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get log level",
+                "responses": {
+                    "200": {
+                        "description": "Current log level",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.LogLevelResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Changes the minimum log level of the running instance without a restart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Set log level",
+                "parameters": [
+                    {
+                        "description": "New log level (debug, info, warn, error)",
+                        "name": "level",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LogLevelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Log level updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.LogLevelResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid log level",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/migrations": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Lists all schema migrations and whether they have been applied",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Migration status",
+                "responses": {
+                    "200": {
+                        "description": "Migration status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.MigrationStatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Migration status unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/purge-deleted": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Permanently deletes rows soft-deleted more than PURGE_DELETED_AFTER_DAYS days ago. With dry_run=true only counts them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Purge soft-deleted rows",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only count the rows that would be deleted",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Purge result",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.PurgeResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid dry_run",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Read-only mode is enabled",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/read-only": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Reports whether mutating API requests are currently rejected",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get read-only mode",
+                "responses": {
+                    "200": {
+                        "description": "Current read-only mode",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.ReadOnlyResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Switches read-only mode on or off without a restart. While enabled, mutating API requests return 503 and reads keep working.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Set read-only mode",
+                "parameters": [
+                    {
+                        "description": "Whether read-only mode is enabled",
+                        "name": "mode",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReadOnlyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Read-only mode updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.ReadOnlyResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reports/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Recomputes the materialized views of pre-aggregated sales (report_daily_sales, report_item_sales) now rather than at the next refresh_reports run",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Refresh report views",
+                "responses": {
+                    "200": {
+                        "description": "Report views refreshed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.ViewRefreshResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Read-only mode is enabled",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/restaurants": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Lists the restaurants served by the deployment. API requests name theirs with the X-Restaurant-ID header, and default to restaurant 1.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Restaurants",
+                "responses": {
+                    "200": {
+                        "description": "Restaurants",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/restaurants.Restaurant"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Adds a restaurant with its own menu, orders, tables, staff and settings. It starts empty, with the default settings.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create a restaurant",
+                "parameters": [
+                    {
+                        "description": "Restaurant",
+                        "name": "restaurant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/restaurants.CreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Restaurant created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/restaurants.Restaurant"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid restaurant",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Slug already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/routes": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Lists every registered route with its method, path pattern, handler and listener",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Registered routes",
+                "responses": {
+                    "200": {
+                        "description": "Registered routes",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.RouteInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Lists the periodic tasks with their schedule, next run and last run",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Scheduled tasks",
+                "responses": {
+                    "200": {
+                        "description": "Scheduled tasks",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/scheduler.TaskInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/runs": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns the run history of the periodic tasks, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Scheduled task runs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only runs of this task",
+                        "name": "task",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of runs (default 100, at most 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task runs",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/scheduler.Run"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Lists the outbound webhook subscriptions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "Webhook subscriptions",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/webhooks.Subscription"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Registers a URL receiving the given event types (exact types, prefixes such as menu_item.*, or * for all). Events are POSTed as JSON and signed: X-Agora-Signature is \"sha256=\" followed by the hex HMAC-SHA256 of \"\u003cX-Agora-Timestamp\u003e.\u003cbody\u003e\" keyed with the secret returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create a webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.CreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Webhook subscription created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.CreatedWebhook"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Removes a subscription and its delivery history; queued deliveries are dropped",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook subscription deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Returns the delivery attempts of a subscription, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Webhook delivery attempts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of attempts (default 100, at most 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery attempts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/webhooks.Delivery"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/carts": {
+            "post": {
+                "description": "Starts an empty cart for an online order. Its ID is the only key to the cart, so clients keep it private.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Create cart",
+                "parameters": [
+                    {
+                        "description": "Cart",
+                        "name": "cart",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Cart created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid cart",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/carts/{id}": {
+            "get": {
+                "description": "Retrieves a cart with its lines at the prices the customer was shown",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Get cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cart retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Cart not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Changes the channel and customer details of a cart. Its lines are repriced when the channel changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Update cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cart",
+                        "name": "cart",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cart updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid cart",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cart not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cart already checked out",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/carts/{id}/checkout": {
+            "post": {
+                "description": "Converts a cart into an online order after checking its items are still available at the prices the customer was shown. When they aren't, the cart's prices are updated and 409 lists what changed, for the customer to review before checking out again. Fails with 422 when the cart's location is closed at the order's time, and with 429 and Retry-After when the order's time slot is full.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Check out cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order placed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Empty cart",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cart not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cart already checked out, or its items or prices changed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Coupon or loyalty points can't be redeemed on the cart, or the location is closed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Order slot is full",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/carts/{id}/coupon": {
+            "put": {
+                "description": "Applies a coupon to a cart after checking it can be redeemed on the cart's items. Fails with 422 and the reason when it can't. The coupon is only redeemed at checkout.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Apply coupon to cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Coupon",
+                        "name": "coupon",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CartCouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Coupon applied successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid coupon or empty cart",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cart not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cart already checked out",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Coupon can't be redeemed on the cart",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the coupon of a cart",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Remove coupon from cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Coupon removed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Cart not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cart already checked out",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/carts/{id}/items": {
+            "post": {
+                "description": "Adds an available menu item to a cart at its current price on the cart's channel",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Add cart item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cart line",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CartItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Item added successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or unavailable item",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cart not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cart already checked out",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/carts/{id}/items/{itemId}": {
+            "put": {
+                "description": "Changes the quantity and notes of a cart line",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Update cart item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cart line ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cart line",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CartItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cart or line not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cart already checked out",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a line from a cart",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Remove cart item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cart line ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item removed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cart or line not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cart already checked out",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/carts/{id}/preview": {
+            "get": {
+                "description": "Prices a cart as checking it out now would, with its promotions, coupon, tax and tip, without creating an order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Preview cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cart priced successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Empty cart",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cart not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Coupon can't be redeemed on the cart",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/carts/{id}/tip": {
+            "put": {
+                "description": "Sets the tip of a cart as an amount or a percentage of its total; setting neither removes it. The tip is paid on top of the order's total.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Carts"
+                ],
+                "summary": "Set cart tip",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tip",
+                        "name": "tip",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CartTipRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tip set successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tip",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Cart not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cart already checked out",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/coupons": {
+            "get": {
+                "description": "Retrieves the coupons with their usage counts",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "List coupons",
+                "responses": {
+                    "200": {
+                        "description": "Coupons retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.CouponResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a coupon code giving a percentage or fixed discount on one menu item, a category or the whole order, with optional usage limits and validity window",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Create coupon",
+                "parameters": [
+                    {
+                        "description": "Coupon",
+                        "name": "coupon",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Coupon created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CouponResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid coupon",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The code is already taken",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/coupons/validate": {
+            "post": {
+                "description": "Prices an order with its coupon_code without creating it, returning the quote with the discount applied. Fails with 422 and the reason when the coupon can't be redeemed on the order. The coupon is only redeemed when the order is created.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Validate coupon",
+                "parameters": [
+                    {
+                        "description": "Order to price, with coupon_code",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Coupon is valid",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Coupon can't be redeemed on the order",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/coupons/{id}": {
+            "put": {
+                "description": "Replaces a coupon, keeping its usage count. Existing orders keep their discount.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Update coupon",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Coupon ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Coupon",
+                        "name": "coupon",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CouponRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Coupon updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CouponResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid coupon",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Coupon not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The code is already taken",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a coupon and its redemption history. Existing orders keep their discount.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Delete coupon",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Coupon ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Coupon deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid coupon ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Coupon not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/coupons/{id}/redemptions": {
+            "get": {
+                "description": "Lists the orders a coupon was redeemed on, newest first",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "List coupon redemptions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Coupon ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Redemptions retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.CouponRedemptionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid coupon ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Coupon not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customers/{id}/erase": {
+            "delete": {
+                "description": "Anonymizes the personal data stored about a customer, for a right-to-erasure request. Their name, phone number and notes are cleared from their orders and carts, and their name and IP address from their reviews. Orders keep their lines, payments and totals, so sales reports don't change. Their loyalty points and store credit, still owed, move to a random pseudonym returned as erased_as. It can't be undone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Customers"
+                ],
+                "summary": "Erase a customer's personal data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer phone number",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Customer data erased successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CustomerErasureResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "No data is stored about the customer",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customers/{id}/export": {
+            "get": {
+                "description": "Retrieves all the personal data stored about a customer, for a data access request: their orders with their lines and payments, their carts, their reviews of items of their orders with the IP address they were written from, the coupons redeemed on their orders, and their loyalty points and store credit with their ledgers, oldest first. Customers are identified by their phone number.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Customers"
+                ],
+                "summary": "Export a customer's personal data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer phone number",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Customer data exported successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.CustomerExportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "No data is stored about the customer",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customers/{id}/loyalty": {
+            "get": {
+                "description": "Retrieves a customer's loyalty points balance, the discount it is worth and the ledger of points earned on paid orders and redeemed on new ones, newest first. Customers are identified by their phone number; customers who never earned points have none.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Loyalty"
+                ],
+                "summary": "Get customer loyalty points",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer phone number",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Loyalty points retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.LoyaltyResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customers/{id}/store-credit": {
+            "get": {
+                "description": "Retrieves a customer's store credit balance and the ledger of its credits and debits, newest first, each with the order it concerns and the ID of the request that made it. Customers are identified by their phone number; customers who were never credited have none.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Store Credit"
+                ],
+                "summary": "Get customer store credit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer phone number",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Store credit retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.StoreCreditResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customers/{id}/store-credit/credits": {
+            "post": {
+                "description": "Adds to a customer's store credit, refunding one of their orders (reason refund, with its order_id) or as a goodwill gesture. A refund may return at most what was paid for the order, less what was already refunded to credit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store Credit"
+                ],
+                "summary": "Credit store credit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer phone number",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credit",
+                        "name": "credit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.StoreCreditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Store credit credited successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.StoreCreditEntryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid credit",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/customers/{id}/store-credit/debits": {
+            "post": {
+                "description": "Takes an adjustment off a customer's store credit. Orders are paid with store credit through POST /orders/{id}/payments instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store Credit"
+                ],
+                "summary": "Debit store credit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Customer phone number",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Debit",
+                        "name": "debit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.StoreCreditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Store credit debited successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.StoreCreditEntryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid debit",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The customer's store credit is too low",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/delivery-zones": {
+            "get": {
+                "description": "Retrieves the delivery zones by name, with their fees and minimum order amounts",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Delivery Zones"
+                ],
+                "summary": "List delivery zones",
+                "responses": {
+                    "200": {
+                        "description": "Delivery zones retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.DeliveryZoneResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a delivery zone: a circle of radius_meters around its center, or a polygon of at least three points",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delivery Zones"
+                ],
+                "summary": "Create delivery zone",
+                "parameters": [
+                    {
+                        "description": "Delivery zone",
+                        "name": "zone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.DeliveryZoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Delivery zone created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.DeliveryZoneResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid delivery zone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another zone has the name",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/delivery-zones/check": {
+            "post": {
+                "description": "Finds the active delivery zone of an address, given by the coordinates it was geocoded to, and returns its delivery fee and minimum order. With order_total, also reports whether the order reaches the minimum. Where zones overlap, the one with the lowest fee applies. Fails with 422 outside every zone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delivery Zones"
+                ],
+                "summary": "Check delivery address",
+                "parameters": [
+                    {
+                        "description": "Delivery location",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.DeliveryCheckRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Address is deliverable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.DeliveryCheckResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid coordinates or order total",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Address is outside the delivery zones",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/delivery-zones/{id}": {
+            "put": {
+                "description": "Replaces a delivery zone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delivery Zones"
+                ],
+                "summary": "Update delivery zone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery zone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delivery zone",
+                        "name": "zone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.DeliveryZoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery zone updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.DeliveryZoneResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid delivery zone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery zone not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another zone has the name",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a delivery zone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delivery Zones"
+                ],
+                "summary": "Delete delivery zone",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery zone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery zone deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid delivery zone ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery zone not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/drivers": {
+            "get": {
+                "description": "Retrieves the delivery drivers by name",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "List drivers",
+                "responses": {
+                    "200": {
+                        "description": "Drivers retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.DriverResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a delivery driver",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Create driver",
+                "parameters": [
+                    {
+                        "description": "Driver",
+                        "name": "driver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.DriverRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Driver created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.DriverResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid driver",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/drivers/{id}": {
+            "put": {
+                "description": "Replaces a delivery driver. Orders already assigned to a driver made inactive stay with them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Update driver",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Driver ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Driver",
+                        "name": "driver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.DriverRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Driver updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.DriverResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid driver",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Driver not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a delivery driver with no orders left to deliver. Their past deliveries keep no driver.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Delete driver",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Driver ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Driver deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid driver ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Driver not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Driver has orders to deliver",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/drivers/{id}/orders": {
+            "get": {
+                "description": "Retrieves the orders a driver has yet to deliver, in the order they were assigned",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "List driver orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Driver ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Driver orders retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.OrderResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid driver ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Driver not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/events": {
+            "get": {
+                "description": "Server-Sent Events stream of menu changes (menu_item.created, menu_item.updated, menu_item.deleted, menu_item.restored, menu_item.purged), new orders (order.created), their kitchen tickets (ticket.created, one per station, and ticket.prepared), ready estimates (order.eta_updated), deliveries (order.delivery_updated) and table statuses (table.status_updated). Streams only receive the events of the restaurant of the request; browsers' EventSource, which can't set the X-Restaurant-ID header, passes ?restaurant_id= instead. Kitchen displays pass ?station= to receive only their station's tickets. A server.shutdown event is sent before the server restarts; clients should reconnect.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Real-time event stream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kitchen station whose tickets to receive, e.g. grill; every event when omitted",
+                        "name": "station",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Restaurant whose events to receive when the X-Restaurant-ID header can't be set",
+                        "name": "restaurant_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "$ref": "#/definitions/realtime.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown station",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Server is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exports": {
+            "post": {
+                "description": "Starts exporting all menu items in the background and returns the job. Poll GET /exports/{id} until its status is \"completed\", then download the file from GET /exports/{id}/download. Finished exports are kept for 24 hours.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exports"
+                ],
+                "summary": "Start an export",
+                "parameters": [
+                    {
+                        "description": "Export options (format defaults to ndjson)",
+                        "name": "export",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateExportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Export started",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.ExportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many exports in progress",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exports/{id}": {
+            "get": {
+                "description": "Reports the status of an export: pending (also while waiting for a retry), running, completed or failed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exports"
+                ],
+                "summary": "Get export status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.ExportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Export not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exports/{id}/download": {
+            "get": {
+                "description": "Downloads the file of a completed export. Range requests are supported.",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Exports"
+                ],
+                "summary": "Download an export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Export file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Export not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Export is not completed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/floor-plan": {
+            "get": {
+                "description": "Retrieves the restaurant's seating map: its sections and where each table stands, with the table's current name. Tables deleted since the plan was saved are left out.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Get floor plan",
+                "responses": {
+                    "200": {
+                        "description": "Floor plan retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.FloorPlanResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the floor plan. Each table is placed at most once, within the plan, and in one of its sections when it has one. Pass the version the changes were made from to fail with 409 if someone else saved the plan since.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Update floor plan",
+                "parameters": [
+                    {
+                        "description": "Floor plan",
+                        "name": "plan",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.FloorPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Floor plan updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.FloorPlanResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid floor plan",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Floor plan was saved since the version",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gift-cards": {
+            "post": {
+                "description": "Issues a gift card loaded with an amount. Without a code, a random code such as K7QM-4XPB-9TRC-HW2D is generated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Cards"
+                ],
+                "summary": "Issue gift card",
+                "parameters": [
+                    {
+                        "description": "Gift card",
+                        "name": "giftCard",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.IssueGiftCardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Gift card issued successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.GiftCardResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid gift card",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The code is already taken",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gift-cards/{code}": {
+            "get": {
+                "description": "Retrieves a gift card by its code, case-insensitively, with its balance and transactions, newest first",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Gift Cards"
+                ],
+                "summary": "Check gift card balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gift card code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Gift card retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.GiftCardResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Gift card not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gift-cards/{code}/redeem": {
+            "post": {
+                "description": "Takes an amount off a gift card's balance. With an order_id, the amount pays part or all of the order, adding to its amount_paid; the amount then defaults to as much of the order's amount_due as the balance covers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift Cards"
+                ],
+                "summary": "Redeem gift card",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Gift card code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Redemption",
+                        "name": "redemption",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.RedeemGiftCardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Gift card redeemed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.GiftCardRedemptionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid amount",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Gift card or order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The gift card is inactive, expired or its balance too low, or the order is cancelled or has less left to pay",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/guest/menu": {
+            "get": {
+                "description": "Retrieves the menu items guests may order, at their dine-in prices. Items of a running price experiment show the price of the session's variant instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guest Ordering"
+                ],
+                "summary": "Guest menu",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer session token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Menu retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.MenuItemResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, unknown or expired session token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/guest/orders": {
+            "get": {
+                "description": "Retrieves the orders placed in the guest session, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guest Ordering"
+                ],
+                "summary": "Guest orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer session token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Orders retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.OrderResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, unknown or expired session token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Places a dine-in order for the session's table. Guests only order available menu items, at their dine-in prices, or the price experiment prices the session was shown. The order is sent to the kitchen like any other.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guest Ordering"
+                ],
+                "summary": "Place guest order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer session token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Order",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.GuestOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Order placed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, unknown or expired session token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/guest/session": {
+            "get": {
+                "description": "Retrieves the guest session of the bearer token: its table and when it expires",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guest Ordering"
+                ],
+                "summary": "Get guest session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer session token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Guest session retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.GuestSessionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, unknown or expired session token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/guest/sessions": {
+            "post": {
+                "description": "Starts a guest session at the table whose QR code carries table_token, as when a guest scans it. The session's token, sent as a bearer token, lets the guest browse the menu and order for the table until it expires (GUEST_SESSION_MINUTES).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Guest Ordering"
+                ],
+                "summary": "Start guest session",
+                "parameters": [
+                    {
+                        "description": "Table token",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StartGuestSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Guest session started successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.GuestSessionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JSON format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No table has the token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/health": {
+            "get": {
+                "description": "Returns the health status of the service including database connectivity",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Comprehensive health check",
+                "responses": {
+                    "200": {
+                        "description": "Service and database are healthy",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service is degraded (database issues)",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/items": {
+            "get": {
+                "description": "Retrieves all menu items with optional filtering by category, availability, or search term. With location, items show the availability and price a location overrides them with, so available=true\u0026location= is the menu of the location.\nRetrieves all menu items with optional filtering by category, availability, or search term",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by category (appetizer, main, dessert, drink, side, fast food)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by availability (true/false)",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted items (true/false)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term to filter menu items",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related resources to embed (prices)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sub-resources to embed (category)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price the items for an order channel (dine_in, takeaway, delivery)",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Apply the availability and price overrides of a location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only items whose approved reviews average at least this rating (1-5)",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,name,price",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Menu items retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.MenuItemResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown field, include or expand, or invalid min_rating or location",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new menu item with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "summary": "Create a new menu item",
+                "parameters": [
+                    {
+                        "description": "Menu item details",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateMenuItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Menu item created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.MenuItemResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/items/category/{category}": {
+            "get": {
+                "description": "Retrieves the active menu items of a category",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "summary": "Get menu items by category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category (appetizer, main, dessert, drink, side, fast food)",
+                        "name": "category",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related resources to embed (prices)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sub-resources to embed (category)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price the items for an order channel (dine_in, takeaway, delivery)",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Apply the availability and price overrides of a location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only items whose approved reviews average at least this rating (1-5)",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,name,price",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Menu items retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.MenuItemResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category, field, include, expand, min_rating or location",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/items/deleted": {
+            "get": {
+                "description": "Retrieves the soft-deleted menu items, which can be restored",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "summary": "Get deleted menu items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related resources to embed (prices)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sub-resources to embed (category)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price the items for an order channel (dine_in, takeaway, delivery)",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Apply the availability and price overrides of a location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only items whose approved reviews average at least this rating (1-5)",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,name,price",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted menu items retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.MenuItemResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown field, include or expand, or invalid min_rating or location",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/items/export.ndjson": {
+            "get": {
+                "description": "Streams all non-deleted menu items as newline-delimited JSON, one item per line, ordered by ID. Rows are read from a database cursor, so large exports are not buffered in memory. An error after streaming has started ends the response early.",
+                "produces": [
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "summary": "Export menu items as NDJSON",
+                "responses": {
+                    "200": {
+                        "description": "One menu item per line",
+                        "schema": {
+                            "$ref": "#/definitions/services.MenuItemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/items/import": {
+            "post": {
+                "description": "Imports menu items from a CSV file (columns: name, price, category, and optionally description, is_available) using batched inserts. The import is all-or-nothing.",
+                "consumes": [
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "summary": "Bulk import menu items",
+                "parameters": [
+                    {
+                        "description": "CSV file with a header row",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Menu items imported successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.ImportResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid CSV file",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.ImportRowError"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "413": {
+                        "description": "CSV file too large",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/items/{id}": {
+            "get": {
+                "description": "Retrieves a specific menu item by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "summary": "Get menu item by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related resources to embed (prices)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sub-resources to embed (category)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price the items for an order channel (dine_in, takeaway, delivery)",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Apply the availability and price overrides of a location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,name,price",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Menu item retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.MenuItemResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid menu item ID, field, include, expand or location",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates an existing menu item with the provided details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "summary": "Update menu item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated menu item details",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.UpdateMenuItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Menu item updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.MenuItemResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or menu item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft deletes a menu item (can be restored) or permanently deletes with force=true",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "summary": "Delete menu item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Permanently delete the item (true/false)",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Menu item deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid menu item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/items/{id}/prices/{channel}": {
+            "put": {
+                "description": "Sets the price of a menu item on an order channel (dine_in, takeaway or delivery). Menus read with ?channel= and new orders on the channel use it instead of the item's price.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "summary": "Set channel price",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "dine_in",
+                            "takeaway",
+                            "delivery"
+                        ],
+                        "type": "string",
+                        "description": "Order channel",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Channel price",
+                        "name": "price",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.MenuItemPriceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price set successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.MenuItemResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid channel or price",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the price of a menu item on an order channel, which then uses the item's price again. Existing orders keep their prices.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "summary": "Remove channel price",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "dine_in",
+                            "takeaway",
+                            "delivery"
+                        ],
+                        "type": "string",
+                        "description": "Order channel",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid channel",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "The menu item has no price on the channel",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/items/{id}/restore": {
+            "post": {
+                "description": "Restores a soft-deleted menu item",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "summary": "Restore menu item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Menu item restored successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.MenuItemResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid menu item ID or menu item is not deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/items/{id}/reviews": {
+            "post": {
+                "description": "Rates a menu item from 1 to 5, with an optional comment. With order_id, the order must include the item, and the review is shown as verified; each item of an order can be reviewed once. Reviews tripping a spam or abuse check (link, blocked_word, shouting, repeated_characters, repeated) are flagged and wait for a manager as pending; the others are approved right away.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Review menu item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Review created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.ReviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid review",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Item already reviewed for the order",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/locations": {
+            "get": {
+                "description": "Retrieves the restaurant's locations (branches) by name",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "List locations",
+                "responses": {
+                    "200": {
+                        "description": "Locations retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.LocationResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a location of the restaurant. It serves the restaurant's menu until items are overridden there.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Create location",
+                "parameters": [
+                    {
+                        "description": "Location",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.LocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Location created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.LocationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid location",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another location has the name",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/locations/{id}": {
+            "put": {
+                "description": "Replaces the name, address and coordinates of a location. A location without coordinates isn't found by nearby searches.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Update location",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location",
+                        "name": "location",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.LocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.LocationResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid location",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another location has the name",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a location along with its menu overrides",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Delete location",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Location deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid location ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/locations/{id}/hours": {
+            "get": {
+                "description": "Retrieves the weekly opening hours of a location, its hour exceptions from today on, and whether it is open now. A location without weekly hours is always open.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Get opening hours of a location",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Opening hours retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.LocationHoursResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid location ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the weekly opening hours of a location. Each period opens on a day (sun, mon, ...) from opens_at to closes_at (HH:MM, restaurant time), running past midnight when closes_at is earlier. An empty list leaves the location always open. Online orders are only placed while their location is open.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Set opening hours of a location",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Weekly opening hours",
+                        "name": "hours",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.LocationHoursRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Opening hours set successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.LocationHoursResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid location ID or hours",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/locations/{id}/hours/exceptions/{date}": {
+            "put": {
+                "description": "Replaces the weekly hours of a location on a date (YYYY-MM-DD), e.g. a holiday. Without opens_at the location is closed all day; otherwise it opens once, from opens_at to closes_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Set an hour exception of a location",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Hours on the date",
+                        "name": "exception",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.HoursExceptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hour exception set successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.HoursExceptionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid location ID, date or hours",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the hour exception of a location on a date (YYYY-MM-DD), which then follows the weekly hours again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Remove an hour exception of a location",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Hour exception removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid location ID or date",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found, or it has no exception on the date",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/locations/{id}/items": {
+            "get": {
+                "description": "Retrieves the menu items a location overrides, by menu item ID, with the availability and price it sets for them",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "List menu overrides of a location",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Overrides retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.LocationOverrideResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid location ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/locations/{id}/items/{item_id}": {
+            "put": {
+                "description": "Sets the availability, the price or both of a menu item at a location, replacing any override it had. Menus read with ?location= show them instead of the item's; omitted fields follow the item.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Override a menu item at a location",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Override",
+                        "name": "override",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.LocationOverrideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Override set successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.LocationOverrideResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or override",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Location or menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the override of a menu item at a location, which then follows the menu again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Remove the override of a menu item at a location",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Override removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Location not found, or it doesn't override the item",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/order-slots": {
+            "get": {
+                "description": "Lists the time slots online orders are placed in, with how many orders each has and whether it takes more. Slots are ORDER_SLOT_MINUTES long from the start of the business day and take at most ORDER_SLOT_CAPACITY online orders; point of sale orders aren't counted.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "List order slots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "List from the slot this time falls in (RFC 3339, date, or Unix seconds; default now)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of slots (default 8, at most 96)",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order slots retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.OrderSlotResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid count or timestamp",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders": {
+            "get": {
+                "description": "Retrieves orders with their items, newest first",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "List orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (pending, accepted, preparing, ready, completed, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by source (pos, ubereats, doordash)",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only orders created at or after this time (RFC 3339, date, or Unix seconds)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only orders created before this time (RFC 3339, date, or Unix seconds)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, at most 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of orders to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only the order with this number of the day, today's unless day is given",
+                        "name": "number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only orders numbered on this business day (date)",
+                        "name": "day",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Orders retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.OrderResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid paging parameters or timestamps",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}": {
+            "get": {
+                "description": "Retrieves an order with its items",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}/delivery-status": {
+            "put": {
+                "description": "Moves a delivery order from assigned to picked_up, or from picked_up to delivered, which completes the order. Publishes order.delivery_updated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Update delivery status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.DeliveryStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery status updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Not a delivery order, or unknown status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not at the previous step or is closed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}/driver": {
+            "put": {
+                "description": "Gives an open delivery order to an active driver, or to another driver until it is picked up. Publishes order.delivery_updated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Drivers"
+                ],
+                "summary": "Assign order to driver",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Driver",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.AssignDriverRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Driver assigned successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Not a delivery order, or unknown or inactive driver",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Order already picked up or closed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}/payments": {
+            "post": {
+                "description": "Records a cash, card or store_credit payment towards an order, by default of its amount_due or, with store_credit, as much of it as the customer's store credit covers. The payment completing the order earns its customer loyalty points. Gift cards pay orders through /gift-cards/{code}/redeem.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Pay order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.PayOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order paid successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payment",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The order is cancelled or has less left to pay, or the customer's store credit is too low",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}/receipt": {
+            "get": {
+                "description": "Plain-text receipt of an order, sized for 80 mm receipt printers, with its lines, subtotal, tax per rate and total, followed by the receipt_footer setting",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Order receipt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipt",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}/tickets": {
+            "get": {
+                "description": "Splits an order into kitchen tickets, one per station (e.g. grill, fry, bar) preparing some of its items. Menu items are routed to their own station or their category's.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Order kitchen tickets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only the ticket of this station",
+                        "name": "station",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tickets retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.KitchenTicketResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown station",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}/tickets/{station}": {
+            "get": {
+                "description": "Plain-text kitchen ticket of the items of an order a station prepares, sized for 80 mm printers. Stations without items on the order get 404.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Print kitchen ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kitchen station, e.g. grill",
+                        "name": "station",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown station",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found or nothing for the station",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/orders/{id}/tickets/{station}/prepared": {
+            "post": {
+                "description": "Marks a station's items of an order as prepared, as when a cook bumps the ticket off the kitchen display. The order and the orders queued behind it at the station are estimated again: ticket.prepared is published to the station's stream and order.eta_updated for every estimate that moved earlier.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Mark kitchen ticket prepared",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kitchen station, e.g. grill",
+                        "name": "station",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket prepared successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.KitchenTicketResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown station",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found or nothing for the station",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ticket already prepared",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/price-experiments": {
+            "get": {
+                "description": "Retrieves the price experiments, latest start first, with their variants and whether they are scheduled, running or ended",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Price Experiments"
+                ],
+                "summary": "List price experiments",
+                "responses": {
+                    "200": {
+                        "description": "Price experiments retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.PriceExperimentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Tests variant prices of a menu item from starts_at (default now) until ends_at. While it runs, each guest session viewing the guest menu is assigned a variant by the variants' weights, which add up to 100, and is shown and charged its price. A session keeps its variant on every request. A menu item runs one experiment at a time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price Experiments"
+                ],
+                "summary": "Create price experiment",
+                "parameters": [
+                    {
+                        "description": "Price experiment",
+                        "name": "experiment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.PriceExperimentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Price experiment created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.PriceExperimentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid price experiment",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The menu item has an experiment in that period",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/price-experiments/{id}": {
+            "delete": {
+                "description": "Deletes a price experiment no guest was shown yet. Experiments with results can only be ended.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price Experiments"
+                ],
+                "summary": "Delete price experiment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Price experiment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price experiment deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid price experiment ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Price experiment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Price experiment was shown to guests",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/price-experiments/{id}/end": {
+            "post": {
+                "description": "Ends a running price experiment now, or cancels a scheduled one. Guest sessions already shown a variant keep being charged its price.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Price Experiments"
+                ],
+                "summary": "End price experiment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Price experiment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price experiment ended successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.PriceExperimentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid price experiment ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Price experiment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Price experiment already ended",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/price-experiments/{id}/results": {
+            "get": {
+                "description": "Compares the variants of a price experiment: for each, the guest sessions shown it, how many of them ordered the menu item in non-cancelled orders placed after seeing it, the quantity ordered and the revenue of those lines",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Price Experiments"
+                ],
+                "summary": "Price experiment results",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Price experiment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Price experiment results retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.PriceExperimentResults"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid price experiment ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Price experiment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pricing-rules": {
+            "get": {
+                "description": "Retrieves the pricing rules, each reporting whether it is in effect now. A rule discounts one menu item, a category or, with neither set, every item during its time window.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Pricing Rules"
+                ],
+                "summary": "List pricing rules",
+                "responses": {
+                    "200": {
+                        "description": "Pricing rules retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.PricingRuleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a pricing rule, e.g. a happy hour. While it is in effect, menus are served and new orders priced at the discounted prices.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pricing Rules"
+                ],
+                "summary": "Create pricing rule",
+                "parameters": [
+                    {
+                        "description": "Pricing rule",
+                        "name": "pricingRule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.PricingRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Pricing rule created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.PricingRuleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pricing rule",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/pricing-rules/{id}": {
+            "put": {
+                "description": "Replaces a pricing rule. Existing orders keep their prices.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pricing Rules"
+                ],
+                "summary": "Update pricing rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Pricing rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pricing rule",
+                        "name": "pricingRule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.PricingRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pricing rule updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.PricingRuleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pricing rule",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pricing rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a pricing rule. Existing orders keep their prices.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Pricing Rules"
+                ],
+                "summary": "Delete pricing rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Pricing rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pricing rule deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pricing rule ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Pricing rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/promotions": {
+            "get": {
+                "description": "Retrieves the promotions. A buy_get promotion discounts get_quantity of every buy_quantity + get_quantity eligible units of an order, cheapest first; a spend_get promotion discounts the eligible lines once they total min_spend. A promotion applies to one menu item, a category or, with neither set, every item.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "List promotions",
+                "responses": {
+                    "200": {
+                        "description": "Promotions retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.PromotionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a promotion, e.g. buy one get one free. Active promotions within their validity window are applied to new orders, which explain them in their promotions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Create promotion",
+                "parameters": [
+                    {
+                        "description": "Promotion",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Promotion created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.PromotionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/promotions/{id}": {
+            "put": {
+                "description": "Replaces a promotion. Existing orders keep their discounts.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Update promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promotion",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.PromotionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a promotion. Existing orders keep their discounts and promotions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Promotions"
+                ],
+                "summary": "Delete promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Promotion deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promotion ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Promotion not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/recommendations/items/{id}": {
+            "get": {
+                "description": "Lists the available menu items most often ordered along with a menu item, for upselling pairings. Items are ranked by the number of non-cancelled orders of the lookback period that included both, with that number's share of the item's orders. Items never ordered with it aren't listed.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Menu Items"
+                ],
+                "summary": "Items ordered together",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of recommendations (default 5, at most 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days of orders to look back over (default 90, at most 365)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recommendations retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.RecommendationsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid menu item ID, limit or days",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/accounting-export": {
+            "get": {
+                "description": "Journal-style CSV of the sales of a period, one balanced journal entry per business day with sales: the takings are debited to ACCOUNTING_DEPOSIT_ACCOUNT, their tax credited to ACCOUNTING_TAX_ACCOUNT and the rest to ACCOUNTING_SALES_ACCOUNT. The quickbooks format matches the QuickBooks Online journal entry import, the xero format the Xero manual journal import. Cancelled orders are not counted.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Accounting export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the period, inclusive (RFC 3339, date, or Unix seconds; a bare date starts at the start of that business day; default: the start of the business day six days before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period, exclusive; a bare date includes that business day (default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "File format: quickbooks (default) or xero",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Journal CSV",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period or format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/sales": {
+            "get": {
+                "description": "Revenue, order count and average ticket of a period, grouped by day, category or item. Cancelled orders are not counted. Days are business days in the restaurant's timezone (see BUSINESS_DAY_START).",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Sales summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the period, inclusive (RFC 3339, date, or Unix seconds; a bare date starts at the start of that business day; default: the start of the business day six days before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period, exclusive; a bare date includes that business day (default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Grouping: day (default), category or item",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sales report generated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.SalesReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period or grouping",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/timesheets": {
+            "get": {
+                "description": "The hours each staff member worked in a period, from the punches clocked in during it, split into regular hours and overtime, for import into payroll systems. Weeks are counted from from; a week's overtime is the time beyond OVERTIME_WEEKLY_HOURS (default 40) or, when larger, the sum of the time beyond OVERTIME_DAILY_HOURS (default off) in each business day. Punches not clocked out count no hours and are reported as open_punches.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Payroll export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the period, inclusive (RFC 3339, date, or Unix seconds; a bare date starts at the start of that business day; default: the start of the pay period)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period, exclusive; a bare date includes that business day (default: the end of the pay period of from)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "File format: json (default) or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payroll export generated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.PayrollExport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period or format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/top-items": {
+            "get": {
+                "description": "Ranks the current menu items by revenue, quantity sold or number of orders over a period. Items without sales are ranked too, so order=asc lists the dishes that sell least. Cancelled orders are not counted.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Top menu items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the period, inclusive (RFC 3339, date, or Unix seconds; a bare date starts at the start of that business day; default: the start of the business day six days before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period, exclusive; a bare date includes that business day (default: now)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items of this category (appetizer, main, dessert, drink, side, fast food)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ranking: revenue (default), quantity or orders",
+                        "name": "rank_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "desc (default) for best sellers first, asc for the weakest first",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items (default 10, at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Top items report generated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.TopItemsReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period, category, ranking or limit",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reviews": {
+            "get": {
+                "description": "Retrieves reviews of any status, newest first, with their flags and order",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "List reviews for moderation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (pending, approved, rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only reviews with flags",
+                        "name": "flagged",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only the reviews of this menu item",
+                        "name": "item_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, at most 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of reviews to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviews retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.ReviewResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter or paging parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reviews/items/{id}": {
+            "get": {
+                "description": "Retrieves a menu item's approved reviews, newest first, with their average rating and the number of reviews per rating from 1 to 5",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Menu item reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, at most 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of reviews to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviews retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.ItemReviewsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid menu item ID or paging parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reviews/{id}": {
+            "delete": {
+                "description": "Deletes a review",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Delete review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid review ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Approves or rejects a review. Approved reviews are shown on their menu item whatever their flags; rejected ones are hidden.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reviews"
+                ],
+                "summary": "Moderate review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "moderation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.ModerateReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review moderated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.ReviewResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid review ID or status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Review not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/week": {
+            "get": {
+                "description": "The staff schedule of a week, Monday to Sunday in business days: the shifts of each day, by the business day they start in, and the scheduled hours of each day, each staff member and the week. Active staff without shifts are listed with none.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Weekly schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Any day of the week, as a date or timestamp (default: today)",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.WeeklyScheduleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/settings": {
+            "get": {
+                "description": "Retrieves the business settings of the request's restaurant: timezone, currency, tax mode, service charge and receipt footer. Settings never changed through the API keep their defaults from the server's environment.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Get settings",
+                "responses": {
+                    "200": {
+                        "description": "Settings retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.Settings"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes the settings set in the request and leaves the others. The timezone and tax mode apply to the next requests; orders keep the tax mode they were placed with. Timestamps and business days of every restaurant use the default restaurant's timezone, so only it can change the timezone; business days start at BUSINESS_DAY_START for every restaurant, so business_day_start is rejected. Other instances pick changes up within a minute.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Update settings",
+                "parameters": [
+                    {
+                        "description": "Settings to change",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.SettingsPatch"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Settings updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.Settings"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid settings",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shifts": {
+            "get": {
+                "description": "Retrieves the shifts starting in a period, by start time, at most 62 days at once",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "List shifts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the period, inclusive (RFC 3339, date, or Unix seconds; a bare date starts at the start of that business day; default: the start of today's business day)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the period, exclusive; a bare date includes that business day (default: seven business days after from)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only the shifts of this staff member",
+                        "name": "staff_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shifts retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.ShiftResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period or staff member ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Schedules an active staff member for a shift of at most 16 hours, in their role unless role is given. Fails with 409 if it overlaps another of their shifts; back-to-back shifts are fine.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Create shift",
+                "parameters": [
+                    {
+                        "description": "Shift",
+                        "name": "shift",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.ShiftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Shift created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.ShiftResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid shift",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Shift overlaps another shift of the staff member",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shifts/{id}": {
+            "put": {
+                "description": "Replaces a shift, which may move it to another staff member",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Update shift",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Shift",
+                        "name": "shift",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.ShiftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shift updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.ShiftResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid shift",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Shift not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Shift overlaps another shift of the staff member",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a shift",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Delete shift",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Shift ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Shift deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid shift ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Shift not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/staff": {
+            "get": {
+                "description": "Retrieves the restaurant's staff by name",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "List staff",
+                "responses": {
+                    "200": {
+                        "description": "Staff retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.StaffResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a staff member with the role they are scheduled in by default, e.g. server or cook",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Create staff member",
+                "parameters": [
+                    {
+                        "description": "Staff member",
+                        "name": "staff",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.StaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Staff member created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.StaffResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid staff member",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/staff/{id}": {
+            "put": {
+                "description": "Replaces a staff member. Their shifts keep the role they were scheduled in; inactive staff can't be scheduled.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Update staff member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Staff member",
+                        "name": "staff",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.StaffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Staff member updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.StaffResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid staff member",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Staff member not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a staff member and their shifts. Fails with 409 once they clocked in, as their punches are payroll records; make staff who left inactive instead, which also keeps their past shifts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Delete staff member",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Staff member deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid staff member ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Staff member not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Staff member has time punches",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/staff/{id}/clock-in": {
+            "post": {
+                "description": "Clocks an active staff member in for their shift under way or starting within CLOCK_IN_EARLY_MINUTES (default 15). Without such a shift it fails with 422 unless unscheduled is set. Fails with 409 if they are clocked in already.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Clock in",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clock-in options",
+                        "name": "punch",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/services.ClockInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Clocked in successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.TimePunchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid staff member ID or inactive staff member",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Staff member not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Staff member is clocked in already",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "No shift under way or starting soon",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/staff/{id}/clock-out": {
+            "post": {
+                "description": "Clocks a staff member out. Fails with 409 if they aren't clocked in.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Clock out",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Staff member ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Clocked out successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.TimePunchResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid staff member ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Staff member not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Staff member is not clocked in",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/stats/dashboard": {
+            "get": {
+                "description": "Active menu item counts, overall and per category, and the order count, revenue and average ticket of the current business day (see BUSINESS_DAY_START). Cancelled orders are not counted.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Dashboard statistics",
+                "responses": {
+                    "200": {
+                        "description": "Dashboard statistics retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.DashboardStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tables": {
+            "get": {
+                "description": "Retrieves the restaurant's tables by name, with the token their QR code carries and, when GUEST_ORDER_URL is set, the link to encode in it",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "List tables",
+                "responses": {
+                    "200": {
+                        "description": "Tables retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.TableResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a table with a new QR code token. Guests scanning the code order for the table.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Create table",
+                "parameters": [
+                    {
+                        "description": "Table",
+                        "name": "table",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.TableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Table created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.TableResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid table",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another table has the name",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tables/{id}": {
+            "put": {
+                "description": "Renames a table. Its QR code keeps working; orders keep the name the table had when they were placed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Rename table",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Table ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Table",
+                        "name": "table",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.TableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Table updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.TableResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid table",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Table not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another table has the name",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a table and ends its guest sessions. Orders keep the table's name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Delete table",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Table ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Table deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid table ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Table not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tables/{id}/status": {
+            "patch": {
+                "description": "Sets where a table is in its service: free, seated, ordered, check_dropped or needs_cleaning. Any status can follow any other, to correct a mistake. A change is published to real-time clients as table.status_updated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Update table status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Table ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Table status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.TableStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Table status updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.TableResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid table ID or status",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Table not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tables/{id}/token": {
+            "post": {
+                "description": "Gives a table a new QR code token, as when its code was copied. The old code stops working and the table's open guest sessions end.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tables"
+                ],
+                "summary": "Replace table QR code",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Table ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Table token replaced successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.TableResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid table ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Table not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tax-rates": {
+            "get": {
+                "description": "Retrieves the tax rates. A rate applies to one menu item, to a category, or, with neither set, to every other item.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Tax Rates"
+                ],
+                "summary": "List tax rates",
+                "responses": {
+                    "200": {
+                        "description": "Tax rates retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.TaxRateResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a tax rate for a menu item, a category, or the default rate. New orders are taxed with it; existing orders keep their tax.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax Rates"
+                ],
+                "summary": "Create tax rate",
+                "parameters": [
+                    {
+                        "description": "Tax rate",
+                        "name": "taxRate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.TaxRateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Tax rate created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.TaxRateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tax rate",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The menu item, category or default already has a rate",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tax-rates/{id}": {
+            "put": {
+                "description": "Replaces a tax rate. New orders are taxed with it; existing orders keep their tax.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax Rates"
+                ],
+                "summary": "Update tax rate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax rate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tax rate",
+                        "name": "taxRate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.TaxRateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tax rate updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.TaxRateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tax rate",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tax rate not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The menu item, category or default already has a rate",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a tax rate. Existing orders keep their tax.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tax Rates"
+                ],
+                "summary": "Delete tax rate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax rate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tax rate deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tax rate ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Tax rate not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/timesheets": {
+            "get": {
+                "description": "The timesheet of a pay period, PAY_PERIOD_DAYS business days long (default 14): the hours each staff member was scheduled for and worked, with their punches by clock-in time. Staff are listed by name when they had a shift or punch in the period. Punches still clocked in count no hours yet.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Timesheet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Any day of the pay period, as a date or timestamp (default: today)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only this staff member, listed even without shifts or punches",
+                        "name": "staff_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timesheet retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.TimesheetResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date or staff member ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Staff member not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/track/{token}": {
+            "get": {
+                "description": "Retrieves the status of an order and when it should be ready, for the customer's status page. The token is the order's tracking_token, signed with ORDER_TRACKING_SECRET, so this needs no other authorization; it shows no prices or customer details.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Track order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tracking token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.OrderTrackingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown tracking token",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/version": {
+            "get": {
+                "description": "Returns the version, git commit and build time of the running server",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Build information",
+                "responses": {
+                    "200": {
+                        "description": "Build information",
+                        "schema": {
+                            "$ref": "#/definitions/version.Info"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/health": {
+            "get": {
+                "description": "Reports the service and database health. A degraded service answers 503 with the report as data and an unavailable error.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Health check (v2)",
+                "responses": {
+                    "200": {
+                        "description": "Service is healthy",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.V2Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.HealthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service is degraded",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.V2Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.HealthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/items": {
+            "get": {
+                "description": "Retrieves a page of menu items, optionally filtered by category, availability or search term",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items v2"
+                ],
+                "summary": "List menu items (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by category (appetizer, main, dessert, drink, side, fast food)",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only available items (true/false)",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted items (true/false)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related resources to embed (prices)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sub-resources to embed (category)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price the items for an order channel (dine_in, takeaway, delivery)",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only items whose approved reviews average at least this rating (1-5)",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,name,price",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, at most 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of menu items",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.V2Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.MenuItemResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter, pagination parameters or fields",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a menu item. Invalid fields are listed in the error details.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items v2"
+                ],
+                "summary": "Create a menu item (v2)",
+                "parameters": [
+                    {
+                        "description": "Menu item details",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.CreateMenuItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The created menu item",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.V2Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.MenuItemResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/items/deleted": {
+            "get": {
+                "description": "Retrieves a page of the soft-deleted menu items, which can be restored",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items v2"
+                ],
+                "summary": "List deleted menu items (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related resources to embed (prices)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sub-resources to embed (category)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price the items for an order channel (dine_in, takeaway, delivery)",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only items whose approved reviews average at least this rating (1-5)",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,name,price",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, at most 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of deleted menu items",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.V2Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.MenuItemResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination parameters or fields",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/items/{id}": {
+            "get": {
+                "description": "Retrieves a menu item by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items v2"
+                ],
+                "summary": "Get a menu item (v2)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated related resources to embed (prices)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated sub-resources to embed (category)",
+                        "name": "expand",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price the items for an order channel (dine_in, takeaway, delivery)",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. id,name,price",
+                        "name": "fields",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The menu item",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.V2Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.MenuItemResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid menu item ID or fields",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Updates the provided fields of a menu item. Invalid fields are listed in the error details.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items v2"
+                ],
+                "summary": "Update a menu item (v2)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/services.UpdateMenuItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The updated menu item",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.V2Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.MenuItemResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or menu item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Soft deletes a menu item, or permanently deletes it with force=true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items v2"
+                ],
+                "summary": "Delete a menu item (v2)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Permanently delete the item (true/false)",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Menu item deleted"
+                    },
+                    "400": {
+                        "description": "Invalid menu item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/items/{id}/restore": {
+            "post": {
+                "description": "Restores a soft-deleted menu item",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Menu Items v2"
+                ],
+                "summary": "Restore a menu item (v2)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Menu item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The restored menu item",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.V2Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.MenuItemResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid menu item ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Menu item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Menu item is not deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/orders": {
+            "get": {
+                "description": "Retrieves a page of orders with their items, newest first. The total isn't counted; has_more tells whether another page follows.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders v2"
+                ],
+                "summary": "List orders (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (pending, accepted, preparing, ready, completed, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by source (pos, ubereats, doordash)",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only orders created at or after this time (RFC 3339, date, or Unix seconds)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only orders created before this time (RFC 3339, date, or Unix seconds)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, at most 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of orders to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of orders",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.V2Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.OrderResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination parameters or timestamps",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/orders/{id}": {
+            "get": {
+                "description": "Retrieves an order with its items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders v2"
+                ],
+                "summary": "Get an order (v2)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The order",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.V2Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.V2Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/version": {
+            "get": {
+                "description": "Returns the version, commit and build time of the running binary",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Build information (v2)",
+                "responses": {
+                    "200": {
+                        "description": "Build information",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.V2Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/version.Info"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/graphql": {
+            "post": {
+                "description": "Runs a read-only GraphQL query over menu items, categories and orders; the schema is in internal/graph/schema.graphqls and can be explored at /graphql/playground. Errors are returned in the \"errors\" array with status 200.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GraphQL"
+                ],
+                "summary": "GraphQL query",
+                "parameters": [
+                    {
+                        "description": "GraphQL request",
+                        "name": "query",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GraphQLRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "GraphQL response with data and errors",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Returns the basic health status of the service",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Basic health check",
+                "responses": {
+                    "200": {
+                        "description": "Service is healthy",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/public/locations/nearby": {
+            "get": {
+                "description": "Lists the restaurant's locations within radius meters of a point, nearest first, for ordering apps to pick the closest branch. Each has its distance in meters and whether it is open now. Locations without coordinates aren't listed. At most 20 are returned.",
+                "produces": [
+                    "application/json",
+                    "text/xml",
+                    "application/msgpack"
+                ],
+                "tags": [
+                    "Locations"
+                ],
+                "summary": "Find nearby locations",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Latitude of the point",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of the point",
+                        "name": "lon",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Radius in meters (default 10000, at most 100000)",
+                        "name": "radius",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Nearby locations retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.NearbyLocationResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid lat, lon or radius",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown restaurant",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "description": "Returns the version, git commit and build time of the running server",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Build information",
+                "responses": {
+                    "200": {
+                        "description": "Build information",
+                        "schema": {
+                            "$ref": "#/definitions/version.Info"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{provider}": {
+            "post": {
+                "description": "Verifies the provider's signature and creates an order from the payload at the restaurant its store is registered with on the platform. Events signed more than 5 minutes away from the server's time are rejected. Orders received again (same provider order ID) are acknowledged without being created twice; events other than new orders are acknowledged and ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Receive a delivery platform order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider (ubereats, doordash)",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order already received, or event ignored",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Order created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/services.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload, or a store ID that is not a restaurant ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid signature, or a stale event",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown or disabled provider, or unknown restaurant",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "The order's location is closed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "The current order slot is full; Retry-After is the wait for the next available one",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Read-only mode is enabled",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "featureflags.Flag": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Card payments of orders"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "environments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staging"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "payments"
+                },
+                "tenants": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "downtown"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "featureflags.SetRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Card payments of orders"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "environments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staging"
+                    ]
+                },
+                "tenants": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "downtown"
+                    ]
+                }
+            }
+        },
+        "featureflags.Status": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Card payments of orders"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "environments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "staging"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "payments"
+                },
+                "override": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "tenants": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "downtown"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreatedWebhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "menu_item.*"
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "whsec_3f9a..."
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/agora"
+                }
+            }
+        },
+        "handlers.DatabaseHealthStatus": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "response_time_ms": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.DatabaseStatsResponse": {
+            "type": "object",
+            "properties": {
+                "idle": {
+                    "type": "integer"
+                },
+                "in_use": {
+                    "type": "integer"
+                },
+                "max_idle_closed": {
+                    "type": "integer"
+                },
+                "max_idle_time_closed": {
+                    "type": "integer"
+                },
+                "max_lifetime_closed": {
+                    "type": "integer"
+                },
+                "max_open_connections": {
+                    "type": "integer"
+                },
+                "open_connections": {
+                    "type": "integer"
+                },
+                "wait_count": {
+                    "type": "integer"
+                },
+                "wait_duration_ms": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "details": {},
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.GraphQLRequest": {
+            "type": "object",
+            "properties": {
+                "operationName": {
+                    "type": "string"
+                },
+                "query": {
+                    "type": "string",
+                    "example": "{ menuItems(limit: 10) { items { id name price } } }"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "handlers.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "database": {
+                    "$ref": "#/definitions/handlers.DatabaseHealthStatus"
+                },
+                "service": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "version": {
+                    "$ref": "#/definitions/version.Info"
+                }
+            }
+        },
+        "handlers.LogLevelRequest": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string",
+                    "example": "debug"
+                }
+            }
+        },
+        "handlers.LogLevelResponse": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "string",
+                    "example": "info"
+                }
+            }
+        },
+        "handlers.MigrationInfo": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "group_id": {
+                    "type": "integer"
+                },
+                "migrated_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "20250628_001"
+                }
+            }
+        },
+        "handlers.MigrationStatusResponse": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "integer"
+                },
+                "migrations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.MigrationInfo"
+                    }
+                },
+                "pending": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ReadOnlyRequest": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "handlers.ReadOnlyResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "handlers.RouteInfo": {
+            "type": "object",
+            "properties": {
+                "handler": {
+                    "type": "string",
+                    "example": "handlers.(*MenuItemHandlers).GetMenuItemByID"
+                },
+                "listener": {
+                    "type": "string",
+                    "example": "public"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "GET"
+                },
+                "pattern": {
+                    "type": "string",
+                    "example": "/api/v1/items/{id}"
+                }
+            }
+        },
+        "handlers.StartGuestSessionRequest": {
+            "type": "object",
+            "properties": {
+                "table_token": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015"
+                }
+            }
+        },
+        "handlers.SuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.V2Error": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "invalid_argument"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.V2FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string",
+                    "example": "price must be greater than 0"
+                }
+            }
+        },
+        "handlers.V2FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "price"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must be greater than 0"
+                }
+            }
+        },
+        "handlers.V2Meta": {
+            "type": "object",
+            "properties": {
+                "pagination": {
+                    "$ref": "#/definitions/handlers.V2Pagination"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "3f9a1c0e5b7d4e2a8c6b0d1f2e3a4b5c"
+                }
+            }
+        },
+        "handlers.V2Pagination": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "offset": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "handlers.V2Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "$ref": "#/definitions/handlers.V2Error"
+                },
+                "meta": {
+                    "$ref": "#/definitions/handlers.V2Meta"
+                }
+            }
+        },
+        "jobs.Job": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "export"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "locked_at": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "result": {
+                    "type": "object"
+                },
+                "run_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FloorPlanSection": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#4caf50"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Terrace"
+                }
+            }
+        },
+        "models.FloorPlanTable": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "rotation": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "seats": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "section": {
+                    "type": "string",
+                    "example": "Terrace"
+                },
+                "shape": {
+                    "type": "string",
+                    "enum": [
+                        "rectangle",
+                        "circle"
+                    ],
+                    "example": "rectangle"
+                },
+                "table_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "x": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "y": {
+                    "type": "integer",
+                    "example": 80
+                }
+            }
+        },
+        "models.GeoPoint": {
+            "type": "object",
+            "properties": {
+                "lat": {
+                    "type": "number",
+                    "example": 31.9539
+                },
+                "lng": {
+                    "type": "number",
+                    "example": 35.9106
+                }
+            }
+        },
+        "realtime.Event": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "restaurant_id": {
+                    "description": "Restaurant the event happened at; streams only receive their restaurant's\nevents, and events without one, such as the shutdown notice",
+                    "type": "integer",
+                    "example": 1
+                },
+                "station": {
+                    "description": "Kitchen station the event is for; streams following a station only receive\nthat station's events",
+                    "type": "string",
+                    "example": "grill"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "menu_item.updated"
+                }
+            }
+        },
+        "restaurants.CreateRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Downtown"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "downtown"
+                }
+            }
+        },
+        "restaurants.Restaurant": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Downtown"
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "downtown"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "scheduler.Run": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "result": {
+                    "type": "object"
+                },
+                "scheduled_at": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "succeeded"
+                },
+                "task": {
+                    "type": "string",
+                    "example": "purge_deleted"
+                }
+            }
+        },
+        "scheduler.TaskInfo": {
+            "type": "object",
+            "properties": {
+                "last_run": {
+                    "$ref": "#/definitions/scheduler.Run"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "purge_deleted"
+                },
+                "next_run": {
+                    "type": "string"
+                },
+                "schedule": {
+                    "type": "string",
+                    "example": "0 3 * * *"
+                }
+            }
+        },
+        "services.AnonymizationRunResponse": {
+            "type": "object",
+            "properties": {
+                "carts": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "coupon_redemptions": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "cutoff": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 350
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "orders": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "retention_days": {
+                    "type": "integer",
+                    "example": 365
+                },
+                "reviews": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "started_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.AssignDriverRequest": {
+            "type": "object",
+            "properties": {
+                "driver_id": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "services.CartCouponRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "SUMMER10"
+                }
+            }
+        },
+        "services.CartItemRequest": {
+            "type": "object",
+            "properties": {
+                "menu_item_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "No onions"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "services.CartItemResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "line_total": {
+                    "type": "string",
+                    "example": "25.00"
+                },
+                "menu_item_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Mansaf"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "unit_price": {
+                    "type": "string",
+                    "example": "12.50"
+                }
+            }
+        },
+        "services.CartRequest": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "example": "takeaway"
+                },
+                "customer_name": {
+                    "type": "string",
+                    "example": "Lina"
+                },
+                "customer_phone": {
+                    "type": "string",
+                    "example": "+962790000000"
+                },
+                "location_id": {
+                    "description": "Location of the restaurant to order from; it must be open at checkout",
+                    "type": "integer",
+                    "example": 2
+                },
+                "notes": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CartResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "example": "takeaway"
+                },
+                "coupon_code": {
+                    "type": "string",
+                    "example": "SUMMER10"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "4c1f1d8e-3a4b-4b7f-9a56-2f7c1e0d9b21"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CartItemResponse"
+                    }
+                },
+                "location_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "description": "Order the cart was checked out into",
+                    "type": "string"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "25.00"
+                },
+                "tip": {
+                    "type": "string",
+                    "example": "3.00"
+                },
+                "tip_percent": {
+                    "type": "string",
+                    "example": "10"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CartTipRequest": {
+            "type": "object",
+            "properties": {
+                "tip": {
+                    "type": "string",
+                    "example": "3.00"
+                },
+                "tip_percent": {
+                    "type": "string",
+                    "example": "10"
+                }
+            }
+        },
+        "services.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string",
+                    "example": "Fast Food"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "fast food"
+                }
+            }
+        },
+        "services.CategoryStats": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "available": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "category": {
+                    "type": "string",
+                    "example": "main"
+                }
+            }
+        },
+        "services.ClockInRequest": {
+            "type": "object",
+            "properties": {
+                "unscheduled": {
+                    "description": "Clock in without a shift, e.g. when called in to help out; otherwise a shift of\ntheirs must be under way or start within CLOCK_IN_EARLY_MINUTES",
+                    "type": "boolean"
+                }
+            }
+        },
+        "services.CouponRedemptionResponse": {
+            "type": "object",
+            "properties": {
+                "customer_phone": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "2.50"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"
+                },
+                "redeemed_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CouponRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "main"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SUMMER10"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "10"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "amount"
+                    ],
+                    "example": "percent"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_uses": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "max_uses_per_customer": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CouponResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "main"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "SUMMER10"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "10"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percent"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_uses": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "max_uses_per_customer": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "times_used": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.CreateExportRequest": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "string",
+                    "example": "ndjson"
+                }
+            }
+        },
+        "services.CreateMenuItemRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "name",
+                "price"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "appetizer",
+                        "main",
+                        "dessert",
+                        "drink",
+                        "side",
+                        "fast food"
+                    ]
+                },
+                "description": {
+                    "type": "string"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "prep_minutes": {
+                    "description": "Minutes the item takes to prepare; the kitchen's default when omitted",
+                    "type": "integer",
+                    "example": 12
+                },
+                "price": {
+                    "type": "string",
+                    "example": "12.50"
+                },
+                "station": {
+                    "description": "Kitchen station preparing the item; its category's station when omitted",
+                    "type": "string",
+                    "example": "grill"
+                }
+            }
+        },
+        "services.CreateOrderItemRequest": {
+            "type": "object",
+            "properties": {
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "type": "string",
+                    "example": "12.50"
+                }
+            }
+        },
+        "services.CreateOrderRequest": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "coupon_code": {
+                    "description": "Coupon to redeem, matched case-insensitively",
+                    "type": "string",
+                    "example": "SUMMER10"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CreateOrderItemRequest"
+                    }
+                },
+                "location_id": {
+                    "description": "Location of the restaurant to place the order at. Online orders are only taken\nwhile it is open.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "loyalty_points": {
+                    "description": "Loyalty points of the customer to redeem as a discount, at most as many as the\norder needs",
+                    "type": "integer",
+                    "example": 200
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "slot_at": {
+                    "description": "Time slot to place an online order in, the current one when omitted",
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "tip": {
+                    "description": "Tip paid on top of the total, as an amount or a percentage of the total; set at\nmost one",
+                    "type": "string",
+                    "example": "3.00"
+                },
+                "tip_percent": {
+                    "type": "string",
+                    "example": "10"
+                }
+            }
+        },
+        "services.CreateReviewRequest": {
+            "type": "object",
+            "properties": {
+                "author_name": {
+                    "type": "string",
+                    "example": "Omar"
+                },
+                "comment": {
+                    "type": "string",
+                    "example": "Best mansaf in town"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"
+                },
+                "rating": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 5
+                }
+            }
+        },
+        "services.CustomerErasureResponse": {
+            "type": "object",
+            "properties": {
+                "carts": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "coupon_redemptions": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "customer_id": {
+                    "type": "string",
+                    "example": "+962791234567"
+                },
+                "erased_as": {
+                    "type": "string",
+                    "example": "erased-5f0c2a9e7d1b"
+                },
+                "orders": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "reviews": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "services.CustomerExportResponse": {
+            "type": "object",
+            "properties": {
+                "carts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CartResponse"
+                    }
+                },
+                "coupon_redemptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CouponRedemptionResponse"
+                    }
+                },
+                "customer_id": {
+                    "type": "string",
+                    "example": "+962791234567"
+                },
+                "exported_at": {
+                    "type": "string"
+                },
+                "loyalty": {
+                    "$ref": "#/definitions/services.LoyaltyResponse"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.OrderResponse"
+                    }
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CustomerReviewResponse"
+                    }
+                },
+                "store_credit": {
+                    "$ref": "#/definitions/services.StoreCreditResponse"
+                }
+            }
+        },
+        "services.CustomerReviewResponse": {
+            "type": "object",
+            "properties": {
+                "author_name": {
+                    "type": "string",
+                    "example": "Omar"
+                },
+                "client_ip": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "comment": {
+                    "type": "string",
+                    "example": "Best mansaf in town"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "link"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "menu_item": {
+                    "type": "string",
+                    "example": "Mansaf"
+                },
+                "menu_item_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"
+                },
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "approved"
+                },
+                "verified": {
+                    "description": "Whether the review references an order that included the item",
+                    "type": "boolean"
+                }
+            }
+        },
+        "services.DashboardStats": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.CategoryStats"
+                    }
+                },
+                "items": {
+                    "$ref": "#/definitions/services.ItemStats"
+                },
+                "today": {
+                    "$ref": "#/definitions/services.TodayStats"
+                }
+            }
+        },
+        "services.DeliveryCheckRequest": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number",
+                    "example": 31.9539
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 35.9106
+                },
+                "order_total": {
+                    "type": "string",
+                    "example": "18.00"
+                }
+            }
+        },
+        "services.DeliveryCheckResponse": {
+            "type": "object",
+            "properties": {
+                "distance_meters": {
+                    "description": "Distance from the center of a circle zone",
+                    "type": "integer",
+                    "example": 1840
+                },
+                "fee": {
+                    "type": "string",
+                    "example": "2.50"
+                },
+                "meets_minimum": {
+                    "description": "Whether the order reaches the zone's minimum, and how much it is short",
+                    "type": "boolean"
+                },
+                "minimum_order": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "shortfall": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "zone": {
+                    "type": "string",
+                    "example": "Downtown"
+                },
+                "zone_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "services.DeliveryStatusRequest": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "picked_up",
+                        "delivered"
+                    ],
+                    "example": "picked_up"
+                }
+            }
+        },
+        "services.DeliveryZoneRequest": {
+            "type": "object",
+            "properties": {
+                "center": {
+                    "$ref": "#/definitions/models.GeoPoint"
+                },
+                "fee": {
+                    "type": "string",
+                    "example": "2.50"
+                },
+                "is_active": {
+                    "description": "Defaults to true",
+                    "type": "boolean"
+                },
+                "minimum_order": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Downtown"
+                },
+                "polygon": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GeoPoint"
+                    }
+                },
+                "radius_meters": {
+                    "type": "integer",
+                    "example": 3000
+                }
+            }
+        },
+        "services.DeliveryZoneResponse": {
+            "type": "object",
+            "properties": {
+                "center": {
+                    "$ref": "#/definitions/models.GeoPoint"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "fee": {
+                    "type": "string",
+                    "example": "2.50"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "minimum_order": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Downtown"
+                },
+                "polygon": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GeoPoint"
+                    }
+                },
+                "radius_meters": {
+                    "type": "integer",
+                    "example": 3000
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.DriverRequest": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "description": "Defaults to true; inactive drivers aren't assigned orders",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Omar"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+962791234567"
+                }
+            }
+        },
+        "services.DriverResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Omar"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+962791234567"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.ExportJob": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string",
+                    "example": "ndjson"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "42"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "completed"
+                }
+            }
+        },
+        "services.FloorPlanRequest": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer",
+                    "example": 800
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FloorPlanSection"
+                    }
+                },
+                "tables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FloorPlanTable"
+                    }
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "services.FloorPlanResponse": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer",
+                    "example": 800
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FloorPlanSection"
+                    }
+                },
+                "tables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.FloorPlanTableResponse"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "services.FloorPlanTableResponse": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "name": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "rotation": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "seats": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "section": {
+                    "type": "string",
+                    "example": "Terrace"
+                },
+                "shape": {
+                    "type": "string",
+                    "enum": [
+                        "rectangle",
+                        "circle"
+                    ],
+                    "example": "rectangle"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "seated"
+                },
+                "table_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 90
+                },
+                "x": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "y": {
+                    "type": "integer",
+                    "example": 80
+                }
+            }
+        },
+        "services.GiftCardRedemptionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "20.00"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "30.00"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "K7QM-4XPB-9TRC-HW2D"
+                },
+                "order": {
+                    "$ref": "#/definitions/services.OrderResponse"
+                }
+            }
+        },
+        "services.GiftCardResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string",
+                    "example": "30.00"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "K7QM-4XPB-9TRC-HW2D"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "initial_balance": {
+                    "type": "string",
+                    "example": "50.00"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "recipient_name": {
+                    "type": "string",
+                    "example": "Anna"
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.GiftCardTransactionResponse"
+                    }
+                }
+            }
+        },
+        "services.GiftCardTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "-20.00"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "30.00"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "issue",
+                        "redeem"
+                    ],
+                    "example": "redeem"
+                }
+            }
+        },
+        "services.GuestOrderItemRequest": {
+            "type": "object",
+            "properties": {
+                "menu_item_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "No onions"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "services.GuestOrderRequest": {
+            "type": "object",
+            "properties": {
+                "customer_name": {
+                    "type": "string",
+                    "example": "Lina"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.GuestOrderItemRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Birthday at this table"
+                }
+            }
+        },
+        "services.GuestSessionResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "table": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "3c59dc048e8850243be8079a5c74d079"
+                }
+            }
+        },
+        "services.HoursExceptionRequest": {
+            "type": "object",
+            "properties": {
+                "closes_at": {
+                    "type": "string",
+                    "example": "18:00"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "New Year's Day"
+                },
+                "opens_at": {
+                    "type": "string",
+                    "example": "12:00"
+                }
+            }
+        },
+        "services.HoursExceptionResponse": {
+            "type": "object",
+            "properties": {
+                "closed": {
+                    "type": "boolean"
+                },
+                "closes_at": {
+                    "type": "string",
+                    "example": "18:00"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-12-25"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Christmas"
+                },
+                "opens_at": {
+                    "type": "string",
+                    "example": "12:00"
+                }
+            }
+        },
+        "services.ImportResult": {
+            "type": "object",
+            "properties": {
+                "batches": {
+                    "type": "integer"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ImportRowError"
+                    }
+                },
+                "imported": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "line": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.IssueGiftCardRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "50.00"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "GIFT-2026-ANNA"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "recipient_name": {
+                    "type": "string",
+                    "example": "Anna"
+                }
+            }
+        },
+        "services.ItemReviewsResponse": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "type": "number",
+                    "example": 4.6
+                },
+                "menu_item": {
+                    "type": "string",
+                    "example": "Mansaf"
+                },
+                "menu_item_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "ratings": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "review_count": {
+                    "type": "integer",
+                    "example": 38
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ReviewResponse"
+                    }
+                }
+            }
+        },
+        "services.ItemStats": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "available": {
+                    "type": "integer",
+                    "example": 38
+                },
+                "unavailable": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "services.KitchenTicketItemResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Mixed grill"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "No onions"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "services.KitchenTicketResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "example": "dine_in"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "estimated_ready_at": {
+                    "description": "When the order is expected to be ready, and when the station prepared its items",
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.KitchenTicketItemResponse"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"
+                },
+                "order_number": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "prepared_at": {
+                    "type": "string"
+                },
+                "station": {
+                    "type": "string",
+                    "example": "grill"
+                },
+                "table": {
+                    "type": "string",
+                    "example": "12"
+                }
+            }
+        },
+        "services.LocationHoursRequest": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.OpeningPeriod"
+                    }
+                }
+            }
+        },
+        "services.LocationHoursResponse": {
+            "type": "object",
+            "properties": {
+                "exceptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.HoursExceptionResponse"
+                    }
+                },
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.OpeningPeriod"
+                    }
+                },
+                "is_open_now": {
+                    "type": "boolean"
+                },
+                "location_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Amman"
+                }
+            }
+        },
+        "services.LocationOverrideRequest": {
+            "type": "object",
+            "properties": {
+                "is_available": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "price": {
+                    "type": "string",
+                    "example": "13.50"
+                }
+            }
+        },
+        "services.LocationOverrideResponse": {
+            "type": "object",
+            "properties": {
+                "is_available": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "menu_item_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "price": {
+                    "type": "string",
+                    "example": "13.50"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.LocationRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "12 Rainbow St, Amman"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 31.9539
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 35.9106
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Downtown"
+                }
+            }
+        },
+        "services.LocationResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "12 Rainbow St, Amman"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 31.9539
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 35.9106
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Downtown"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.LoyaltyResponse": {
+            "type": "object",
+            "properties": {
+                "customer_id": {
+                    "type": "string",
+                    "example": "+962791234567"
+                },
+                "points": {
+                    "type": "integer",
+                    "example": 240
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.LoyaltyTransactionResponse"
+                    }
+                },
+                "value": {
+                    "type": "string",
+                    "example": "2.40"
+                }
+            }
+        },
+        "services.LoyaltyTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "integer",
+                    "example": 240
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "earn",
+                        "redeem"
+                    ],
+                    "example": "earn"
+                }
+            }
+        },
+        "services.MenuItemExpanded": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/services.CategoryResponse"
+                }
+            }
+        },
+        "services.MenuItemPriceRequest": {
+            "type": "object",
+            "required": [
+                "price"
+            ],
+            "properties": {
+                "price": {
+                    "type": "string",
+                    "example": "14.00"
+                }
+            }
+        },
+        "services.MenuItemPriceResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "example": "delivery"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "14.00"
+                }
+            }
+        },
+        "services.MenuItemResponse": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "description": "Mean rating of the item's approved reviews, to one decimal and 0 without\nreviews, and their number",
+                    "type": "number",
+                    "example": 4.6
+                },
+                "category": {
+                    "type": "string"
+                },
+                "channel": {
+                    "description": "Channel Price applies to when the items were requested for one with ?channel=",
+                    "type": "string",
+                    "example": "delivery"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expanded": {
+                    "description": "Sub-resources requested with ?expand=, omitted otherwise",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/services.MenuItemExpanded"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "location": {
+                    "description": "Location whose overrides Price and IsAvailable reflect, when the items were\nrequested for one with ?location=",
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prep_minutes": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "price": {
+                    "type": "string",
+                    "example": "12.50"
+                },
+                "price_includes_tax": {
+                    "description": "Whether Price includes tax, per the restaurant's pricing mode",
+                    "type": "boolean"
+                },
+                "prices": {
+                    "description": "Prices on specific order channels, loaded with ?include=prices",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.MenuItemPriceResponse"
+                    }
+                },
+                "pricing_rule": {
+                    "type": "string",
+                    "example": "Happy hour"
+                },
+                "regular_price": {
+                    "description": "Price before the pricing rule in effect, set along with the rule's name when\none discounts the item",
+                    "type": "string",
+                    "example": "15.00"
+                },
+                "review_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "station": {
+                    "description": "Kitchen station preparing the item, its own or its category's, and the\nminutes it takes to prepare",
+                    "type": "string",
+                    "example": "grill"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.ModerateReviewRequest": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "approved"
+                }
+            }
+        },
+        "services.NearbyLocationResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "12 Rainbow St, Amman"
+                },
+                "distance_meters": {
+                    "type": "integer",
+                    "example": 850
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_open_now": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 31.9539
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 35.9106
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Downtown"
+                }
+            }
+        },
+        "services.OpeningPeriod": {
+            "type": "object",
+            "properties": {
+                "closes_at": {
+                    "type": "string",
+                    "example": "23:00"
+                },
+                "day": {
+                    "type": "string",
+                    "example": "mon"
+                },
+                "opens_at": {
+                    "type": "string",
+                    "example": "11:00"
+                }
+            }
+        },
+        "services.OrderItemResponse": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "description": "Part of the order's discount taken off LineTotal",
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "line_total": {
+                    "type": "string",
+                    "example": "25.00"
+                },
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "prep_minutes": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "prepared_at": {
+                    "type": "string"
+                },
+                "pricing_rule": {
+                    "description": "Pricing rule that discounted UnitPrice, e.g. a happy hour",
+                    "type": "string",
+                    "example": "Happy hour"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "station": {
+                    "description": "Kitchen station preparing the line, the minutes it takes and when the station\nmarked it prepared",
+                    "type": "string",
+                    "example": "grill"
+                },
+                "tax_amount": {
+                    "type": "string",
+                    "example": "4.00"
+                },
+                "tax_name": {
+                    "type": "string",
+                    "example": "VAT"
+                },
+                "tax_rate": {
+                    "type": "string",
+                    "example": "16"
+                },
+                "unit_price": {
+                    "type": "string",
+                    "example": "12.50"
+                }
+            }
+        },
+        "services.OrderPaymentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "29.00"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "card"
+                },
+                "paid_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.OrderPromotionResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Buy 1 get 1 free: 1 of 2 eligible items free"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "12.50"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pizza BOGO"
+                }
+            }
+        },
+        "services.OrderResponse": {
+            "type": "object",
+            "properties": {
+                "amount_due": {
+                    "type": "string",
+                    "example": "29.00"
+                },
+                "amount_paid": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "assigned_at": {
+                    "type": "string"
+                },
+                "channel": {
+                    "type": "string",
+                    "example": "dine_in"
+                },
+                "coupon_code": {
+                    "type": "string",
+                    "example": "SUMMER10"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "customer_name": {
+                    "type": "string"
+                },
+                "customer_phone": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "delivery_status": {
+                    "type": "string",
+                    "example": "picked_up"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "driver": {
+                    "type": "string",
+                    "example": "Omar"
+                },
+                "driver_id": {
+                    "description": "Driver of a delivery order, by name, the step its delivery reached and when\neach step happened",
+                    "type": "integer",
+                    "example": 4
+                },
+                "estimated_ready_at": {
+                    "description": "Orders placed before kitchen estimates were introduced have none",
+                    "type": "string"
+                },
+                "external_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.OrderItemResponse"
+                    }
+                },
+                "location_id": {
+                    "description": "Location of the restaurant the order was placed at",
+                    "type": "integer",
+                    "example": 2
+                },
+                "loyalty_discount": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "loyalty_points": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "net": {
+                    "type": "string",
+                    "example": "25.00"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "number": {
+                    "description": "Number of the order among those of its business day, called out to customers",
+                    "type": "integer",
+                    "example": 42
+                },
+                "payments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.OrderPaymentResponse"
+                    }
+                },
+                "picked_up_at": {
+                    "type": "string"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.OrderPromotionResponse"
+                    }
+                },
+                "slot_at": {
+                    "description": "Start of the time slot of an online order",
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "pos"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "subtotal": {
+                    "type": "string",
+                    "example": "25.00"
+                },
+                "table": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "table_id": {
+                    "description": "Table the order is served at, by its name when ordered",
+                    "type": "integer",
+                    "example": 3
+                },
+                "tax": {
+                    "type": "string",
+                    "example": "4.00"
+                },
+                "tax_included": {
+                    "type": "boolean"
+                },
+                "taxes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.OrderTaxResponse"
+                    }
+                },
+                "tip": {
+                    "type": "string",
+                    "example": "0.00"
+                },
+                "total": {
+                    "type": "string",
+                    "example": "29.00"
+                },
+                "tracking_token": {
+                    "description": "Token of the order's public status page, /track/{token}, when tracking is set up",
+                    "type": "string",
+                    "example": "C34vPG96SkOfDl0bLDpOX3Xq0n2mQb8e4iJ1cYqg9zY"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.OrderSlotResponse": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "boolean"
+                },
+                "capacity": {
+                    "description": "Most orders the slot takes, omitted when unlimited",
+                    "type": "integer",
+                    "example": 10
+                },
+                "orders": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "slot_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.OrderTaxResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "4.00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "VAT"
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "16"
+                },
+                "taxable": {
+                    "type": "string",
+                    "example": "25.00"
+                }
+            }
+        },
+        "services.OrderTrackingItemResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Mansaf"
+                },
+                "prepared": {
+                    "type": "boolean"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "services.OrderTrackingResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "example": "takeaway"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "delivery_status": {
+                    "description": "Where a delivery order's delivery is, by the driver's first name",
+                    "type": "string",
+                    "example": "picked_up"
+                },
+                "driver": {
+                    "type": "string",
+                    "example": "Omar"
+                },
+                "estimated_ready_at": {
+                    "description": "Orders placed before kitchen estimates were introduced have none",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "Lines of the order and whether the kitchen has prepared them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.OrderTrackingItemResponse"
+                    }
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "picked_up_at": {
+                    "type": "string"
+                },
+                "slot_at": {
+                    "description": "Start of the time slot of an online order",
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "table": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.PayOrderRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "29.00"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "card",
+                        "store_credit"
+                    ],
+                    "example": "cash"
+                }
+            }
+        },
+        "services.PayrollExport": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "overtime_hours": {
+                    "type": "number",
+                    "example": 25.25
+                },
+                "regular_hours": {
+                    "description": "Hours of all staff in the period",
+                    "type": "number",
+                    "example": 380
+                },
+                "staff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PayrollStaffSummary"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.PayrollStaffSummary": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Lina"
+                },
+                "open_punches": {
+                    "description": "Punches not clocked out yet, which count no hours; a staff member who forgot to\nclock out needs their punch fixed before payroll is run",
+                    "type": "integer",
+                    "example": 0
+                },
+                "overtime": {
+                    "description": "Whether any time counts as overtime",
+                    "type": "boolean"
+                },
+                "overtime_hours": {
+                    "type": "number",
+                    "example": 2.5
+                },
+                "punches": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "regular_hours": {
+                    "type": "number",
+                    "example": 76
+                },
+                "role": {
+                    "type": "string",
+                    "example": "server"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "total_hours": {
+                    "type": "number",
+                    "example": 78.5
+                }
+            }
+        },
+        "services.PriceExperimentRequest": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string"
+                },
+                "menu_item_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Burger price test"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PriceVariantRequest"
+                    }
+                }
+            }
+        },
+        "services.PriceExperimentResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "menu_item_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Burger price test"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "running",
+                        "ended"
+                    ],
+                    "example": "running"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.PriceVariantResponse"
+                    }
+                }
+            }
+        },
+        "services.PriceExperimentResults": {
+            "type": "object",
+            "properties": {
+                "experiment": {
+                    "$ref": "#/definitions/services.PriceExperimentResponse"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.VariantResultResponse"
+                    }
+                }
+            }
+        },
+        "services.PriceVariantRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "control"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "12.50"
+                },
+                "weight": {
+                    "type": "integer",
+                    "example": 50
+                }
+            }
+        },
+        "services.PriceVariantResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "control"
+                },
+                "price": {
+                    "type": "string",
+                    "example": "12.50"
+                },
+                "weight": {
+                    "type": "integer",
+                    "example": 50
+                }
+            }
+        },
+        "services.PricingRuleRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "drink"
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "mon",
+                        "tue",
+                        "wed",
+                        "thu",
+                        "fri"
+                    ]
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "25"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "amount"
+                    ],
+                    "example": "percent"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "19:00"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Happy hour"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "17:00"
+                }
+            }
+        },
+        "services.PricingRuleResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "drink"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "mon",
+                        "tue",
+                        "wed",
+                        "thu",
+                        "fri"
+                    ]
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "25"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percent"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "19:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "in_effect": {
+                    "description": "Whether the rule currently discounts prices",
+                    "type": "boolean"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Happy hour"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "17:00"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.PromotionRequest": {
+            "type": "object",
+            "properties": {
+                "buy_quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category": {
+                    "type": "string",
+                    "example": "main"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "100"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "amount"
+                    ],
+                    "example": "percent"
+                },
+                "get_quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "min_spend": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pizza BOGO"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "buy_get",
+                        "spend_get"
+                    ],
+                    "example": "buy_get"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "buy_quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "category": {
+                    "type": "string",
+                    "example": "main"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "string",
+                    "example": "100"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percent"
+                },
+                "get_quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "min_spend": {
+                    "type": "string",
+                    "example": "0"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pizza BOGO"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "buy_get"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.PurgeResult": {
+            "type": "object",
+            "properties": {
+                "cutoff": {
+                    "type": "string"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "retention_days": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "tables": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "services.RecommendationResponse": {
+            "type": "object",
+            "properties": {
+                "item": {
+                    "$ref": "#/definitions/services.MenuItemResponse"
+                },
+                "orders_together": {
+                    "description": "Orders of the other item that included this one too, and their share of all the\nother item's orders",
+                    "type": "integer",
+                    "example": 54
+                },
+                "share": {
+                    "type": "number",
+                    "example": 0.45
+                }
+            }
+        },
+        "services.RecommendationsResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "menu_item": {
+                    "type": "string",
+                    "example": "Burger"
+                },
+                "menu_item_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "orders": {
+                    "description": "Orders of the period that included the item",
+                    "type": "integer",
+                    "example": 120
+                },
+                "recommendations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.RecommendationResponse"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.RedeemGiftCardRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "20.00"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"
+                }
+            }
+        },
+        "services.ReviewResponse": {
+            "type": "object",
+            "properties": {
+                "author_name": {
+                    "type": "string",
+                    "example": "Omar"
+                },
+                "comment": {
+                    "type": "string",
+                    "example": "Best mansaf in town"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "link"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "menu_item": {
+                    "type": "string",
+                    "example": "Mansaf"
+                },
+                "menu_item_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "moderated_at": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"
+                },
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ],
+                    "example": "approved"
+                },
+                "verified": {
+                    "description": "Whether the review references an order that included the item",
+                    "type": "boolean"
+                }
+            }
+        },
+        "services.SalesFigures": {
+            "type": "object",
+            "properties": {
+                "average_ticket": {
+                    "type": "string",
+                    "example": "26.04"
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "revenue": {
+                    "type": "string",
+                    "example": "1250.00"
+                }
+            }
+        },
+        "services.SalesGroup": {
+            "type": "object",
+            "properties": {
+                "average_ticket": {
+                    "type": "string",
+                    "example": "26.04"
+                },
+                "items_sold": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "2024-05-01"
+                },
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "revenue": {
+                    "type": "string",
+                    "example": "1250.00"
+                }
+            }
+        },
+        "services.SalesReport": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "type": "string",
+                    "example": "day"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.SalesGroup"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "totals": {
+                    "$ref": "#/definitions/services.SalesFigures"
+                }
+            }
+        },
+        "services.ScheduleDayResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-12"
+                },
+                "hours": {
+                    "type": "number",
+                    "example": 31
+                },
+                "shifts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ShiftResponse"
+                    }
+                },
+                "weekday": {
+                    "type": "string",
+                    "example": "mon"
+                }
+            }
+        },
+        "services.ScheduleStaffResponse": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "number",
+                    "example": 38.5
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Lina"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "server"
+                },
+                "shifts": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "services.Settings": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "ISO 4217 code of the currency prices are in",
+                    "type": "string",
+                    "example": "JOD"
+                },
+                "receipt_footer": {
+                    "description": "Text printed at the bottom of receipts",
+                    "type": "string",
+                    "example": "Thank you for dining with us!"
+                },
+                "service_charge": {
+                    "description": "Service charge percentage, for point-of-sale clients adding it to dine-in bills",
+                    "type": "string",
+                    "example": "10"
+                },
+                "tax_mode": {
+                    "description": "Whether tax is added to prices (exclusive) or included in them (inclusive)",
+                    "type": "string",
+                    "enum": [
+                        "exclusive",
+                        "inclusive"
+                    ],
+                    "example": "exclusive"
+                },
+                "timezone": {
+                    "description": "IANA timezone timestamps are returned in, and read in when they have no offset.\nThe default restaurant's applies to every restaurant.",
+                    "type": "string",
+                    "example": "Asia/Amman"
+                }
+            }
+        },
+        "services.SettingsPatch": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "JOD"
+                },
+                "receipt_footer": {
+                    "type": "string",
+                    "example": "Thank you for dining with us!"
+                },
+                "service_charge": {
+                    "type": "string",
+                    "example": "12.5"
+                },
+                "tax_mode": {
+                    "type": "string",
+                    "enum": [
+                        "exclusive",
+                        "inclusive"
+                    ],
+                    "example": "inclusive"
+                },
+                "timezone": {
+                    "description": "Shared by every restaurant, so only the default restaurant may change it",
+                    "type": "string",
+                    "example": "Asia/Amman"
+                }
+            }
+        },
+        "services.ShiftRequest": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-10-16T23:30:00+03:00"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Covers the terrace"
+                },
+                "role": {
+                    "description": "Defaults to the staff member's role",
+                    "type": "string",
+                    "example": "server"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-10-16T17:00:00+03:00"
+                }
+            }
+        },
+        "services.ShiftResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "hours": {
+                    "description": "Length of the shift, to the hundredth of an hour",
+                    "type": "number",
+                    "example": 6.5
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Covers the terrace"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "server"
+                },
+                "staff": {
+                    "type": "string",
+                    "example": "Lina"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.StaffRequest": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "description": "Defaults to true; inactive staff aren't scheduled",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Lina"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+962791234567"
+                },
+                "role": {
+                    "description": "Role they are scheduled in unless a shift says otherwise, e.g. server or cook",
+                    "type": "string",
+                    "example": "server"
+                }
+            }
+        },
+        "services.StaffResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Lina"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+962791234567"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "server"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.StoreCreditEntryResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "note": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "refund",
+                        "goodwill",
+                        "payment",
+                        "adjustment"
+                    ],
+                    "example": "refund"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "credit",
+                        "debit"
+                    ],
+                    "example": "credit"
+                }
+            }
+        },
+        "services.StoreCreditRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Cold pizza"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "refund",
+                        "goodwill"
+                    ],
+                    "example": "refund"
+                }
+            }
+        },
+        "services.StoreCreditResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string",
+                    "example": "10.00"
+                },
+                "customer_id": {
+                    "type": "string",
+                    "example": "+962791234567"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.StoreCreditEntryResponse"
+                    }
+                }
+            }
+        },
+        "services.TableRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "12"
+                }
+            }
+        },
+        "services.TableResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "order_url": {
+                    "type": "string",
+                    "example": "https://order.example.com/?table=9f86d081884c7d659a2feaa0c55ad015"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "seated"
+                },
+                "status_changed_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.TableStatusRequest": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "free",
+                        "seated",
+                        "ordered",
+                        "check_dropped",
+                        "needs_cleaning"
+                    ],
+                    "example": "seated"
+                }
+            }
+        },
+        "services.TaxRateRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "drink"
+                },
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "VAT"
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "16"
+                }
+            }
+        },
+        "services.TaxRateResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "category": {
+                    "type": "string",
+                    "example": "drink"
                 },
-                "error": {
+                "created_at": {
                     "type": "string"
                 },
-                "message": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "menu_item_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "VAT"
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "16"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "handlers.HealthResponse": {
+        "services.TimePunchResponse": {
             "type": "object",
             "properties": {
-                "database": {
-                    "$ref": "#/definitions/handlers.DatabaseHealthStatus"
+                "clock_in_at": {
+                    "type": "string"
                 },
-                "service": {
+                "clock_out_at": {
+                    "description": "Unset while clocked in",
                     "type": "string"
                 },
-                "status": {
+                "clocked_in": {
+                    "type": "boolean"
+                },
+                "hours": {
+                    "description": "Time worked, to the hundredth of an hour; 0 while clocked in",
+                    "type": "number",
+                    "example": 6.75
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 31
+                },
+                "late_minutes": {
+                    "description": "Minutes clocked in after the shift started",
+                    "type": "integer",
+                    "example": 4
+                },
+                "overtime_minutes": {
+                    "description": "Minutes clocked out after the shift ended",
+                    "type": "integer",
+                    "example": 20
+                },
+                "shift_ends_at": {
                     "type": "string"
                 },
-                "timestamp": {
+                "shift_id": {
+                    "description": "Shift clocked in for; unset when working unscheduled",
+                    "type": "integer",
+                    "example": 14
+                },
+                "shift_starts_at": {
                     "type": "string"
+                },
+                "staff": {
+                    "type": "string",
+                    "example": "Lina"
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "unscheduled": {
+                    "type": "boolean"
                 }
             }
         },
-        "handlers.SuccessResponse": {
+        "services.TimesheetResponse": {
             "type": "object",
             "properties": {
-                "data": {},
-                "message": {
+                "ends_at": {
                     "type": "string"
+                },
+                "period_end": {
+                    "type": "string",
+                    "example": "2026-10-18"
+                },
+                "period_start": {
+                    "description": "First and last business day of the pay period",
+                    "type": "string",
+                    "example": "2026-10-05"
+                },
+                "scheduled_hours": {
+                    "description": "Hours of all staff in the period",
+                    "type": "number",
+                    "example": 412
+                },
+                "staff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TimesheetStaffResponse"
+                    }
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "worked_hours": {
+                    "type": "number",
+                    "example": 405.25
                 }
             }
         },
-        "services.CreateMenuItemRequest": {
+        "services.TimesheetStaffResponse": {
+            "type": "object",
+            "properties": {
+                "clocked_in": {
+                    "type": "boolean"
+                },
+                "late_punches": {
+                    "description": "Punches clocked in after their shift started",
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Lina"
+                },
+                "punches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TimePunchResponse"
+                    }
+                },
+                "role": {
+                    "type": "string",
+                    "example": "server"
+                },
+                "scheduled_hours": {
+                    "description": "Hours of the shifts starting in the period",
+                    "type": "number",
+                    "example": 76
+                },
+                "staff_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "unscheduled_hours": {
+                    "description": "Part of WorkedHours worked without a shift",
+                    "type": "number",
+                    "example": 4
+                },
+                "worked_hours": {
+                    "description": "Hours of the punches clocked in and out in the period",
+                    "type": "number",
+                    "example": 78.5
+                }
+            }
+        },
+        "services.TodayStats": {
+            "type": "object",
+            "properties": {
+                "average_ticket": {
+                    "type": "string",
+                    "example": "26.04"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2024-05-01"
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "revenue": {
+                    "type": "string",
+                    "example": "1250.00"
+                }
+            }
+        },
+        "services.TopItem": {
+            "type": "object",
+            "properties": {
+                "average_ticket": {
+                    "type": "string",
+                    "example": "26.04"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "main"
+                },
+                "items_sold": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "menu_item_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Falafel Wrap"
+                },
+                "order_count": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "revenue": {
+                    "type": "string",
+                    "example": "1250.00"
+                }
+            }
+        },
+        "services.TopItemsReport": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "main"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.TopItem"
+                    }
+                },
+                "order": {
+                    "type": "string",
+                    "example": "desc"
+                },
+                "rank_by": {
+                    "type": "string",
+                    "example": "revenue"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "services.UpdateMenuItemRequest": {
             "type": "object",
-            "required": [
-                "category",
-                "name",
-                "price"
-            ],
             "properties": {
                 "category": {
                     "type": "string",
@@ -467,73 +14418,246 @@ const docTemplate = `{
                     "maxLength": 100,
                     "minLength": 1
                 },
+                "prep_minutes": {
+                    "description": "Minutes the item takes to prepare; 0 uses the kitchen's default again",
+                    "type": "integer",
+                    "example": 12
+                },
                 "price": {
-                    "type": "number"
+                    "type": "string",
+                    "example": "12.50"
+                },
+                "station": {
+                    "description": "Kitchen station preparing the item; an empty string routes it to its\ncategory's station again",
+                    "type": "string",
+                    "example": "grill"
                 }
             }
         },
-        "services.MenuItemResponse": {
+        "services.VariantResultResponse": {
             "type": "object",
             "properties": {
-                "category": {
+                "conversion": {
+                    "description": "Share of the sessions that ordered the item",
+                    "type": "number",
+                    "example": 0.275
+                },
+                "name": {
+                    "type": "string",
+                    "example": "control"
+                },
+                "ordering_sessions": {
+                    "type": "integer",
+                    "example": 66
+                },
+                "price": {
+                    "type": "string",
+                    "example": "12.50"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "example": 81
+                },
+                "revenue": {
+                    "description": "Revenue of the item's lines, net of line discounts, overall and per session shown\nthe variant",
+                    "type": "string",
+                    "example": "1012.50"
+                },
+                "revenue_per_session": {
+                    "type": "string",
+                    "example": "4.22"
+                },
+                "sessions": {
+                    "description": "Sessions shown the variant, and those of them that ordered the item",
+                    "type": "integer",
+                    "example": 240
+                },
+                "variant_id": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "services.ViewRefresh": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 365
+                },
+                "view": {
+                    "type": "string",
+                    "example": "report_daily_sales"
+                }
+            }
+        },
+        "services.ViewRefreshResult": {
+            "type": "object",
+            "properties": {
+                "refreshed_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "views": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ViewRefresh"
+                    }
+                }
+            }
+        },
+        "services.WeeklyScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ScheduleDayResponse"
+                    }
+                },
+                "ends_at": {
                     "type": "string"
                 },
-                "deleted_at": {
+                "staff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.ScheduleStaffResponse"
+                    }
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "total_hours": {
+                    "description": "Scheduled hours of the whole week",
+                    "type": "number",
+                    "example": 212.5
+                },
+                "week_start": {
+                    "type": "string",
+                    "example": "2026-10-12"
+                }
+            }
+        },
+        "version.Info": {
+            "type": "object",
+            "properties": {
+                "build_time": {
+                    "type": "string"
+                },
+                "commit": {
+                    "type": "string"
+                },
+                "go_version": {
                     "type": "string"
                 },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "webhooks.CreateRequest": {
+            "type": "object",
+            "properties": {
                 "description": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "menu_item.*"
+                    ]
                 },
-                "is_available": {
-                    "type": "boolean"
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/agora"
+                }
+            }
+        },
+        "webhooks.Delivery": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
                 },
-                "name": {
+                "created_at": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "duration_ms": {
+                    "type": "integer"
                 },
-                "updated_at": {
+                "error": {
                     "type": "string"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "evt_5f1c0e2a9b7d4c3e"
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "menu_item.updated"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "subscription_id": {
+                    "type": "integer"
+                },
+                "succeeded": {
+                    "type": "boolean"
                 }
             }
         },
-        "services.UpdateMenuItemRequest": {
+        "webhooks.Subscription": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string",
-                    "enum": [
-                        "appetizer",
-                        "main",
-                        "dessert",
-                        "drink",
-                        "side",
-                        "fast food"
-                    ]
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
                 },
                 "description": {
                     "type": "string"
                 },
-                "is_available": {
-                    "type": "boolean"
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "menu_item.*"
+                    ]
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                "id": {
+                    "type": "integer"
                 },
-                "price": {
-                    "type": "number"
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/agora"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "Admin bearer token, e.g. \"Bearer \u003cADMIN_TOKEN\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -541,10 +14665,10 @@ const docTemplate = `{
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
 	Host:             "localhost:3000",
-	BasePath:         "/api/v1",
+	BasePath:         "/",
 	Schemes:          []string{"http", "https"},
 	Title:            "Agora Restaurant Management API",
-	Description:      "A RESTful API for restaurant menu management",
+	Description:      "A RESTful API for restaurant menu management. Requests are limited to the restaurant named by their X-Restaurant-ID header, restaurant 1 when it is omitted.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
@@ -0,0 +1,42 @@
+// Package broker publishes messages to an external message broker (Kafka or NATS)
+// for downstream consumers such as analytics and loyalty systems.
+package broker
+
+import (
+	"context"
+	"fmt"
+)
+
+// Supported brokers
+const (
+	Kafka = "kafka"
+	NATS  = "nats"
+)
+
+// Message is a single message for a topic (a Kafka topic or a NATS subject)
+type Message struct {
+	Topic string
+	// ID identifies the message so consumers can drop redeliveries
+	ID string
+	// Key keeps related messages in order (the Kafka partition key)
+	Key   []byte
+	Value []byte
+}
+
+// Producer publishes messages. Publish returns once the broker has accepted the
+// message, so a failed publish can be retried.
+type Producer interface {
+	Publish(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// New connects a producer of the given kind to the broker addresses
+func New(kind string, addrs []string, clientID string) (Producer, error) {
+	switch kind {
+	case Kafka:
+		return newKafkaProducer(addrs, clientID), nil
+	case NATS:
+		return newNATSProducer(addrs, clientID)
+	}
+	return nil, fmt.Errorf("unsupported broker %q", kind)
+}
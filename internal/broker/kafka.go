@@ -0,0 +1,37 @@
+package broker
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaProducer publishes to Kafka, hashing the key to pick the partition
+type kafkaProducer struct {
+	writer *kafka.Writer
+}
+
+func newKafkaProducer(addrs []string, clientID string) *kafkaProducer {
+	return &kafkaProducer{writer: &kafka.Writer{
+		Addr:                   kafka.TCP(addrs...),
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+		BatchTimeout:           10 * time.Millisecond,
+		Transport:              &kafka.Transport{ClientID: clientID},
+	}}
+}
+
+func (p *kafkaProducer) Publish(ctx context.Context, msg Message) error {
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Topic:   msg.Topic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: []kafka.Header{{Key: "message-id", Value: []byte(msg.ID)}},
+	})
+}
+
+func (p *kafkaProducer) Close() error {
+	return p.writer.Close()
+}
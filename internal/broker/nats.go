@@ -0,0 +1,43 @@
+package broker
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsProducer publishes to NATS subjects. The message ID is sent in the
+// Nats-Msg-Id header, which JetStream streams use for deduplication.
+type natsProducer struct {
+	conn *nats.Conn
+}
+
+func newNATSProducer(addrs []string, clientID string) (*natsProducer, error) {
+	conn, err := nats.Connect(strings.Join(addrs, ","),
+		nats.Name(clientID),
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true))
+	if err != nil {
+		return nil, err
+	}
+	return &natsProducer{conn: conn}, nil
+}
+
+func (p *natsProducer) Publish(ctx context.Context, msg Message) error {
+	m := nats.NewMsg(msg.Topic)
+	m.Data = msg.Value
+	m.Header.Set(nats.MsgIdHdr, msg.ID)
+	if len(msg.Key) > 0 {
+		m.Header.Set("Key", string(msg.Key))
+	}
+	if err := p.conn.PublishMsg(m); err != nil {
+		return err
+	}
+	// Wait for the server to acknowledge, so a lost connection fails the publish
+	return p.conn.FlushWithContext(ctx)
+}
+
+func (p *natsProducer) Close() error {
+	return p.conn.Drain()
+}
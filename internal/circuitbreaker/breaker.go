@@ -0,0 +1,173 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned without calling the protected function while the breaker is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State represents the breaker state
+type State int
+
+const (
+	// StateClosed lets all calls through and counts consecutive failures
+	StateClosed State = iota
+	// StateOpen rejects all calls until the open timeout elapses
+	StateOpen
+	// StateHalfOpen lets a single probe call through to test recovery
+	StateHalfOpen
+)
+
+// String returns a human-readable state name
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Settings configures a Breaker
+type Settings struct {
+	Name             string           // Used in log messages
+	FailureThreshold int              // Consecutive failures before opening
+	OpenTimeout      time.Duration    // How long to stay open before probing
+	IsFailure        func(error) bool // Decides which errors count as failures (default: any non-nil error)
+	Now              func() time.Time // Clock, overridable for testing
+}
+
+// Breaker is a consecutive-failure circuit breaker safe for concurrent use
+type Breaker struct {
+	settings Settings
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// New creates a breaker with the given settings
+func New(settings Settings) *Breaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = 5
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = 30 * time.Second
+	}
+	if settings.IsFailure == nil {
+		settings.IsFailure = func(err error) bool { return err != nil }
+	}
+	if settings.Now == nil {
+		settings.Now = time.Now
+	}
+
+	return &Breaker{settings: settings}
+}
+
+// Execute runs fn if the breaker allows it and records the outcome. A panic in fn
+// counts as a failure and is passed on to the caller.
+func (b *Breaker) Execute(fn func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	failed := true
+	defer func() { b.record(failed) }()
+
+	err := fn()
+	failed = b.settings.IsFailure(err)
+	return err
+}
+
+// State returns the current breaker state
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen && b.settings.Now().Sub(b.openedAt) >= b.settings.OpenTimeout {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// allow decides whether a call may proceed, transitioning open -> half-open when due
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.settings.Now().Sub(b.openedAt) < b.settings.OpenTimeout {
+			return ErrOpen
+		}
+		b.setState(StateHalfOpen)
+		b.probing = true
+		return nil
+	case StateHalfOpen:
+		// Only one probe at a time; everyone else fails fast
+		if b.probing {
+			return ErrOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record updates the breaker state with the outcome of a call
+func (b *Breaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateHalfOpen:
+		b.probing = false
+		if failed {
+			b.trip()
+		} else {
+			b.failures = 0
+			b.setState(StateClosed)
+		}
+	case StateClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.settings.FailureThreshold {
+			b.trip()
+		}
+	}
+}
+
+// trip opens the breaker
+func (b *Breaker) trip() {
+	b.openedAt = b.settings.Now()
+	b.setState(StateOpen)
+}
+
+// setState transitions to a new state and logs the change
+func (b *Breaker) setState(state State) {
+	if b.state == state {
+		return
+	}
+
+	slog.Warn("Circuit breaker state changed",
+		slog.String("breaker", b.settings.Name),
+		slog.String("from", b.state.String()),
+		slog.String("to", state.String()),
+		slog.Int("consecutive_failures", b.failures),
+	)
+	b.state = state
+}
@@ -0,0 +1,44 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPanickingProbeReopensBreaker(t *testing.T) {
+	now := time.Now()
+	b := New(Settings{
+		Name:             "test",
+		FailureThreshold: 1,
+		OpenTimeout:      time.Minute,
+		Now:              func() time.Time { return now },
+	})
+
+	_ = b.Execute(func() error { return errors.New("connection refused") })
+	if state := b.State(); state != StateOpen {
+		t.Fatalf("breaker %s after a failure, want open", state)
+	}
+
+	now = now.Add(time.Minute)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("Execute() swallowed the probe's panic")
+			}
+		}()
+		_ = b.Execute(func() error { panic("probe panicked") })
+	}()
+	if state := b.State(); state != StateOpen {
+		t.Fatalf("breaker %s after a panicking probe, want open", state)
+	}
+
+	// The next probe is let through once the breaker is due again
+	now = now.Add(time.Minute)
+	if err := b.Execute(func() error { return nil }); err != nil {
+		t.Fatalf("Execute() = %v, want the probe to run", err)
+	}
+	if state := b.State(); state != StateClosed {
+		t.Fatalf("breaker %s after a successful probe, want closed", state)
+	}
+}
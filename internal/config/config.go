@@ -0,0 +1,555 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/broker"
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/secrets"
+)
+
+// Environments accepted in APP_ENV
+const (
+	EnvDevelopment = "development"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+	EnvTest        = "test"
+)
+
+// Config holds all application settings, loaded once at startup
+type Config struct {
+	Env  string // APP_ENV
+	Port int    // APP_PORT
+
+	// APP_LISTEN overrides APP_PORT: a TCP address (host:port) or unix:/path/to.sock
+	ListenNetwork string      // "tcp" or "unix"
+	ListenAddr    string      // Address or socket path
+	SocketMode    os.FileMode // Permissions of the unix socket (APP_SOCKET_MODE, octal)
+
+	// Native HTTPS: either a certificate/key pair or Let's Encrypt via autocert
+	TLSCert               string   // APP_TLS_CERT
+	TLSKey                string   // APP_TLS_KEY
+	AutocertDomains       []string // APP_AUTOCERT_DOMAINS; enables autocert when set
+	AutocertEmail         string   // Contact address for the ACME account
+	AutocertCacheDir      string   // Where issued certificates are stored
+	AutocertChallengeAddr string   // Plain HTTP listener for ACME challenges and redirects
+
+	// Serve HTTP/2 without TLS (h2c) alongside HTTP/1.1, for load balancers and gRPC-gateway
+	H2C bool
+
+	// Compress textual responses of at least CompressionMinSize bytes (gzip/zstd)
+	CompressionEnabled bool // COMPRESSION_ENABLED
+	CompressionMinSize int  // COMPRESSION_MIN_BYTES
+
+	// Default field naming of JSON responses, "snake" or "camel"; clients can pick
+	// one per request with the X-Response-Case header
+	ResponseCase string // RESPONSE_CASE
+
+	// Timezone of the restaurant: timestamps are returned in it and timestamps without
+	// a UTC offset are read in it
+	Timezone *time.Location // RESTAURANT_TIMEZONE
+
+	// Time of day at which the business day starts, so sales after midnight count
+	// towards the previous day in reports
+	BusinessDayStart time.Duration // BUSINESS_DAY_START
+
+	// JSON encoding of prices: "string" keeps them exact, "number" is the legacy
+	// format; both are accepted on input
+	PriceFormat string // PRICE_FORMAT
+
+	// Time limits for API requests; imports and exports get a longer one
+	RequestTimeout     time.Duration // REQUEST_TIMEOUT_SECONDS
+	BulkRequestTimeout time.Duration // BULK_REQUEST_TIMEOUT_SECONDS
+
+	// Request body size limits in bytes; bulk imports get a larger one (0 disables)
+	MaxBodyBytes       int64 // MAX_BODY_BYTES
+	MaxImportBodyBytes int64 // MAX_IMPORT_BODY_BYTES
+
+	// Directory where finished asynchronous exports are stored until downloaded
+	ExportDir string // EXPORT_DIR
+
+	// Background job queue: concurrent workers and attempts before a job is dead-lettered
+	JobWorkers     int // JOB_WORKERS
+	JobMaxAttempts int // JOB_MAX_ATTEMPTS
+
+	// Postgres NOTIFY channel relaying real-time events between replicas ("off" disables)
+	RealtimeNotifyChannel string // REALTIME_NOTIFY_CHANNEL
+
+	// Message broker receiving change events from the outbox; disabled when Broker is empty
+	Broker            string   // BROKER (kafka or nats)
+	BrokerURLs        []string // BROKER_URLS
+	BrokerTopicPrefix string   // BROKER_TOPIC_PREFIX
+	BrokerClientID    string   // BROKER_CLIENT_ID
+
+	// Signing secrets of the delivery platform order webhooks; a platform's
+	// /webhooks/{provider} endpoint is enabled when its secret is set
+	UberEatsWebhookSecret string // WEBHOOK_UBEREATS_SECRET
+	DoorDashWebhookSecret string // WEBHOOK_DOORDASH_SECRET
+
+	// Whether menu and order prices include tax; otherwise tax is added to orders
+	TaxIncludedInPrices bool // TAX_INCLUDED_IN_PRICES
+
+	// Ledger accounts of the accounting export (account names for QuickBooks, codes for Xero)
+	AccountingSalesAccount   string // ACCOUNTING_SALES_ACCOUNT
+	AccountingDepositAccount string // ACCOUNTING_DEPOSIT_ACCOUNT
+	AccountingTaxAccount     string // ACCOUNTING_TAX_ACCOUNT
+
+	// Loyalty program: points earned per currency unit of a paid order (0 disables
+	// earning), the minimum order total earning points and the discount a point is
+	// worth when redeemed (0 disables redeeming)
+	LoyaltyPointsPerUnit decimal.Decimal // LOYALTY_POINTS_PER_UNIT
+	LoyaltyMinOrder      decimal.Decimal // LOYALTY_MIN_ORDER
+	LoyaltyPointValue    decimal.Decimal // LOYALTY_POINT_VALUE
+
+	// Kitchen stations order tickets are split between, the station of each menu
+	// category (e.g. main=grill) and the station of everything else
+	KitchenStations         []string          // KITCHEN_STATIONS
+	KitchenCategoryStations map[string]string // KITCHEN_CATEGORY_STATIONS
+	KitchenDefaultStation   string            // KITCHEN_DEFAULT_STATION
+
+	// Prep time of menu items without one of their own, and the time each order
+	// waiting at a station adds to the estimates of the orders behind it
+	KitchenDefaultPrepTime time.Duration // KITCHEN_DEFAULT_PREP_MINUTES
+	KitchenQueueDelay      time.Duration // KITCHEN_QUEUE_DELAY_MINUTES
+
+	// Length of the time slots online orders are placed in, and the most online
+	// orders a slot takes (0 for no limit)
+	OrderSlotLength   time.Duration // ORDER_SLOT_MINUTES
+	OrderSlotCapacity int           // ORDER_SLOT_CAPACITY
+
+	// How long before a shift staff may clock in for it, and the pay periods
+	// timesheets cover: PayPeriodDays long, one of them starting on PayPeriodStart
+	ClockInEarly   time.Duration // CLOCK_IN_EARLY_MINUTES
+	PayPeriodDays  int           // PAY_PERIOD_DAYS
+	PayPeriodStart time.Time     // PAY_PERIOD_START
+
+	// Hours worked in a day or a week beyond which payroll exports count overtime
+	// (0 disables either)
+	OvertimeDaily  time.Duration // OVERTIME_DAILY_HOURS
+	OvertimeWeekly time.Duration // OVERTIME_WEEKLY_HOURS
+
+	// Words flagging the customer reviews containing them for a manager's review
+	ReviewBlockedWords []string // REVIEW_BLOCKED_WORDS
+
+	// How long guest sessions started from a table's QR code last, and the page of the
+	// guest ordering app the codes link to
+	GuestSessionTTL time.Duration // GUEST_SESSION_MINUTES
+	GuestOrderURL   string        // GUEST_ORDER_URL
+
+	// Secret signing the tracking tokens of orders' public status pages; orders get
+	// none while it is empty
+	OrderTrackingSecret string // ORDER_TRACKING_SECRET
+
+	// Soft-deleted rows are permanently removed after this many days (PURGE_DELETED_AFTER_DAYS)
+	PurgeDeletedAfterDays int
+
+	// Customer names, phone numbers and notes of orders, their carts and reviews are
+	// anonymized after this many days (ANONYMIZE_ORDERS_AFTER_DAYS); 0 keeps them
+	AnonymizeOrdersAfterDays int
+
+	// Schedules of the periodic tasks by task name (SCHEDULE_<TASK>; "off" disables a task)
+	Schedules map[string]string
+
+	// Optional address (e.g. 127.0.0.1:9090) of a separate listener for the
+	// operational endpoints; when empty they are served on APP_PORT
+	AdminAddr string
+
+	// Optional address (e.g. :9000) of the gRPC listener serving MenuItemService and
+	// OrderService; gRPC is disabled when empty (GRPC_ADDR)
+	GRPCAddr string
+
+	// Bearer token for the admin endpoints; they are disabled when empty
+	// (on the separate admin listener they are open instead)
+	AdminToken string
+
+	// Reverse proxies whose X-Forwarded-For/X-Real-IP headers are trusted (TRUSTED_PROXIES)
+	TrustedProxies []netip.Prefix
+
+	// Client IP restrictions: the allowlists are ignored when empty
+	AllowedIPs      []netip.Prefix // IP_ALLOWLIST: the whole server
+	DeniedIPs       []netip.Prefix // IP_DENYLIST: the whole server
+	AdminAllowedIPs []netip.Prefix // ADMIN_IP_ALLOWLIST: profiling and /admin endpoints
+
+	// Tracing is enabled when an OTLP endpoint is configured
+	TracingEnabled bool
+
+	// Dynamic settings, re-applied when the configuration is reloaded
+	LogLevel           slog.Level // LOG_LEVEL
+	CORSAllowedOrigins []string   // Origins allowed to make cross-origin requests ("*" allows any)
+	RateLimitRPS       float64    // Requests per second per client IP (0 disables)
+	RateLimitBurst     int        // Requests a client may make at once
+	ReadOnly           bool       // Reject mutating API requests (READ_ONLY)
+	FeatureFlags       []string   // Flags forced on, or off when prefixed with "-" (FEATURE_FLAGS)
+
+	Database *database.Config
+}
+
+// TLSEnabled reports whether the server terminates TLS itself
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCert != "" || len(c.AutocertDomains) > 0
+}
+
+// IsDevelopment reports whether the server runs in development mode
+func (c *Config) IsDevelopment() bool {
+	return c.Env == EnvDevelopment
+}
+
+// Load reads the configuration, applies defaults and validates it. Settings come
+// from the environment, then from the optional YAML/TOML file at path, then defaults.
+// All problems are reported together in a *ValidationError.
+func Load(path string) (*Config, error) {
+	cfg, l, err := parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Credentials from a secrets manager take precedence over the environment
+	if err := loadSecrets(l, cfg.Database); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// parse loads and validates every setting except those fetched from a secrets manager
+func parse(path string) (*Config, *envLoader, error) {
+	l := &envLoader{}
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		l.file = values
+	}
+
+	cfg := &Config{
+		Env:                   l.string("APP_ENV", EnvProduction),
+		Port:                  l.int("APP_PORT", 3000),
+		TLSCert:               l.string("APP_TLS_CERT", ""),
+		TLSKey:                l.string("APP_TLS_KEY", ""),
+		AutocertDomains:       l.list("APP_AUTOCERT_DOMAINS", nil),
+		AutocertEmail:         l.string("APP_AUTOCERT_EMAIL", ""),
+		AutocertCacheDir:      l.string("APP_AUTOCERT_CACHE_DIR", "certs"),
+		AutocertChallengeAddr: l.string("APP_AUTOCERT_HTTP_ADDR", ":80"),
+
+		SocketMode: os.FileMode(l.octal("APP_SOCKET_MODE", 0o660)),
+
+		H2C: l.bool("APP_H2C", false),
+
+		CompressionEnabled: l.bool("COMPRESSION_ENABLED", true),
+		CompressionMinSize: l.int("COMPRESSION_MIN_BYTES", 1024),
+
+		ResponseCase: l.string("RESPONSE_CASE", "snake"),
+		Timezone:     l.location("RESTAURANT_TIMEZONE", time.UTC),
+		PriceFormat:  l.string("PRICE_FORMAT", "string"),
+
+		BusinessDayStart: l.clock("BUSINESS_DAY_START", 0),
+
+		RequestTimeout:     l.duration("REQUEST_TIMEOUT_SECONDS", 14, time.Second),
+		BulkRequestTimeout: l.duration("BULK_REQUEST_TIMEOUT_SECONDS", 300, time.Second),
+
+		MaxBodyBytes:       int64(l.int("MAX_BODY_BYTES", 1<<20)),
+		MaxImportBodyBytes: int64(l.int("MAX_IMPORT_BODY_BYTES", 10<<20)),
+
+		ExportDir: l.string("EXPORT_DIR", filepath.Join(os.TempDir(), "agora-exports")),
+
+		JobWorkers:     l.int("JOB_WORKERS", 2),
+		JobMaxAttempts: l.int("JOB_MAX_ATTEMPTS", 5),
+
+		RealtimeNotifyChannel: l.string("REALTIME_NOTIFY_CHANNEL", "agora_events"),
+
+		Broker:            l.string("BROKER", ""),
+		BrokerURLs:        l.list("BROKER_URLS", nil),
+		BrokerTopicPrefix: l.string("BROKER_TOPIC_PREFIX", "agora."),
+		BrokerClientID:    l.string("BROKER_CLIENT_ID", "agora-server"),
+
+		UberEatsWebhookSecret: l.string("WEBHOOK_UBEREATS_SECRET", ""),
+		DoorDashWebhookSecret: l.string("WEBHOOK_DOORDASH_SECRET", ""),
+
+		TaxIncludedInPrices: l.bool("TAX_INCLUDED_IN_PRICES", false),
+
+		AccountingSalesAccount:   l.string("ACCOUNTING_SALES_ACCOUNT", "Sales"),
+		AccountingDepositAccount: l.string("ACCOUNTING_DEPOSIT_ACCOUNT", "Undeposited Funds"),
+		AccountingTaxAccount:     l.string("ACCOUNTING_TAX_ACCOUNT", "Sales Tax Payable"),
+
+		LoyaltyPointsPerUnit: l.decimal("LOYALTY_POINTS_PER_UNIT", decimal.NewFromInt(1)),
+		LoyaltyMinOrder:      l.decimal("LOYALTY_MIN_ORDER", decimal.Zero),
+		LoyaltyPointValue:    l.decimal("LOYALTY_POINT_VALUE", decimal.RequireFromString("0.01")),
+
+		KitchenStations:         l.list("KITCHEN_STATIONS", []string{"grill", "fry", "bar"}),
+		KitchenCategoryStations: l.pairs("KITCHEN_CATEGORY_STATIONS"),
+		KitchenDefaultStation:   l.string("KITCHEN_DEFAULT_STATION", "kitchen"),
+		KitchenDefaultPrepTime:  l.duration("KITCHEN_DEFAULT_PREP_MINUTES", 10, time.Minute),
+		KitchenQueueDelay:       l.duration("KITCHEN_QUEUE_DELAY_MINUTES", 3, time.Minute),
+		OrderSlotLength:         l.duration("ORDER_SLOT_MINUTES", 15, time.Minute),
+		OrderSlotCapacity:       l.int("ORDER_SLOT_CAPACITY", 0),
+		ClockInEarly:            l.duration("CLOCK_IN_EARLY_MINUTES", 15, time.Minute),
+		PayPeriodDays:           l.int("PAY_PERIOD_DAYS", 14),
+		PayPeriodStart:          l.date("PAY_PERIOD_START", time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC)),
+		OvertimeDaily:           l.duration("OVERTIME_DAILY_HOURS", 0, time.Hour),
+		OvertimeWeekly:          l.duration("OVERTIME_WEEKLY_HOURS", 40, time.Hour),
+		ReviewBlockedWords:      l.list("REVIEW_BLOCKED_WORDS", nil),
+		GuestSessionTTL:         l.duration("GUEST_SESSION_MINUTES", 120, time.Minute),
+		GuestOrderURL:           l.string("GUEST_ORDER_URL", ""),
+		OrderTrackingSecret:     l.string("ORDER_TRACKING_SECRET", ""),
+
+		PurgeDeletedAfterDays:    l.int("PURGE_DELETED_AFTER_DAYS", 30),
+		AnonymizeOrdersAfterDays: l.int("ANONYMIZE_ORDERS_AFTER_DAYS", 0),
+
+		Schedules: map[string]string{
+			"purge_deleted":    l.schedule("SCHEDULE_PURGE_DELETED", "0 3 * * *"),
+			"anonymize_orders": l.schedule("SCHEDULE_ANONYMIZE_ORDERS", "45 3 * * *"),
+			"prune_history":    l.schedule("SCHEDULE_PRUNE_HISTORY", "30 4 * * *"),
+			"refresh_reports":  l.schedule("SCHEDULE_REFRESH_REPORTS", "*/15 * * * *"),
+		},
+
+		AdminAddr:  l.string("ADMIN_ADDR", ""),
+		AdminToken: l.string("ADMIN_TOKEN", ""),
+		GRPCAddr:   l.string("GRPC_ADDR", ""),
+
+		CORSAllowedOrigins: l.list("CORS_ALLOWED_ORIGINS", []string{"*"}),
+		RateLimitRPS:       l.float("RATE_LIMIT_RPS", 0),
+		RateLimitBurst:     l.int("RATE_LIMIT_BURST", 20),
+		ReadOnly:           l.bool("READ_ONLY", false),
+		FeatureFlags:       l.list("FEATURE_FLAGS", nil),
+
+		// The OTLP exporter reads its OTEL_* settings from the environment only
+		TracingEnabled: os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "",
+	}
+	l.oneOf("APP_ENV", cfg.Env, EnvDevelopment, EnvStaging, EnvProduction, EnvTest)
+	if cfg.Port < 1 || cfg.Port > 65535 {
+		l.invalid("APP_PORT", "must be between 1 and 65535, got %d", cfg.Port)
+	}
+
+	cfg.ListenNetwork, cfg.ListenAddr = "tcp", ":"+strconv.Itoa(cfg.Port)
+	if listen := l.string("APP_LISTEN", ""); listen != "" {
+		if path, ok := strings.CutPrefix(listen, "unix:"); ok {
+			if path == "" {
+				l.invalid("APP_LISTEN", "unix socket path is empty")
+			}
+			cfg.ListenNetwork, cfg.ListenAddr = "unix", path
+		} else if _, _, err := net.SplitHostPort(listen); err != nil {
+			l.invalid("APP_LISTEN", "must be host:port or unix:/path, got %q", listen)
+		} else {
+			cfg.ListenAddr = listen
+		}
+	}
+
+	if (cfg.TLSCert == "") != (cfg.TLSKey == "") {
+		l.invalid("APP_TLS_CERT", "APP_TLS_CERT and APP_TLS_KEY must be set together")
+	}
+	l.fileExists("APP_TLS_CERT", cfg.TLSCert)
+	l.fileExists("APP_TLS_KEY", cfg.TLSKey)
+	if cfg.TLSCert != "" && len(cfg.AutocertDomains) > 0 {
+		l.invalid("APP_AUTOCERT_DOMAINS", "cannot be combined with APP_TLS_CERT")
+	}
+
+	if cfg.H2C && cfg.TLSEnabled() {
+		l.invalid("APP_H2C", "cannot be combined with TLS (HTTP/2 is negotiated automatically over TLS)")
+	}
+
+	l.atLeast("COMPRESSION_MIN_BYTES", cfg.CompressionMinSize, 0)
+	l.oneOf("RESPONSE_CASE", cfg.ResponseCase, "snake", "camel")
+	l.oneOf("PRICE_FORMAT", cfg.PriceFormat, "string", "number")
+	l.atLeast("JOB_WORKERS", cfg.JobWorkers, 1)
+	l.atLeast("JOB_MAX_ATTEMPTS", cfg.JobMaxAttempts, 1)
+	if cfg.Broker != "" {
+		l.oneOf("BROKER", cfg.Broker, broker.Kafka, broker.NATS)
+		if len(cfg.BrokerURLs) == 0 {
+			l.invalid("BROKER_URLS", "must be set when BROKER is %q", cfg.Broker)
+		}
+	}
+	for category, station := range cfg.KitchenCategoryStations {
+		if station != cfg.KitchenDefaultStation && !slices.Contains(cfg.KitchenStations, station) {
+			l.invalid("KITCHEN_CATEGORY_STATIONS", "station %q of %s is not in KITCHEN_STATIONS", station, category)
+		}
+	}
+	if cfg.KitchenDefaultPrepTime < 0 || cfg.KitchenQueueDelay < 0 {
+		l.invalid("KITCHEN_DEFAULT_PREP_MINUTES", "kitchen times must not be negative")
+	}
+	if cfg.OrderSlotLength < time.Minute {
+		l.invalid("ORDER_SLOT_MINUTES", "must be at least 1")
+	}
+	l.atLeast("ORDER_SLOT_CAPACITY", cfg.OrderSlotCapacity, 0)
+	if cfg.ClockInEarly < 0 {
+		l.invalid("CLOCK_IN_EARLY_MINUTES", "must not be negative")
+	}
+	l.atLeast("PAY_PERIOD_DAYS", cfg.PayPeriodDays, 1)
+	if cfg.OvertimeDaily < 0 || cfg.OvertimeWeekly < 0 {
+		l.invalid("OVERTIME_WEEKLY_HOURS", "overtime thresholds must not be negative")
+	}
+	if cfg.GuestSessionTTL < time.Minute {
+		l.invalid("GUEST_SESSION_MINUTES", "must be at least 1")
+	}
+	if cfg.GuestOrderURL != "" {
+		if u, err := url.Parse(cfg.GuestOrderURL); err != nil || u.Scheme == "" || u.Host == "" {
+			l.invalid("GUEST_ORDER_URL", "must be an absolute URL")
+		}
+	}
+	if cfg.OrderTrackingSecret != "" && len(cfg.OrderTrackingSecret) < 32 {
+		l.invalid("ORDER_TRACKING_SECRET", "must be at least 32 characters")
+	}
+	l.atLeast("PURGE_DELETED_AFTER_DAYS", cfg.PurgeDeletedAfterDays, 1)
+	l.atLeast("ANONYMIZE_ORDERS_AFTER_DAYS", cfg.AnonymizeOrdersAfterDays, 0)
+	cfg.TrustedProxies = l.prefixes("TRUSTED_PROXIES")
+	cfg.AllowedIPs = l.prefixes("IP_ALLOWLIST")
+	cfg.DeniedIPs = l.prefixes("IP_DENYLIST")
+	cfg.AdminAllowedIPs = l.prefixes("ADMIN_IP_ALLOWLIST")
+	if cfg.RequestTimeout < time.Second {
+		l.invalid("REQUEST_TIMEOUT_SECONDS", "must be at least 1")
+	}
+	if cfg.BulkRequestTimeout < cfg.RequestTimeout {
+		l.invalid("BULK_REQUEST_TIMEOUT_SECONDS", "must not be shorter than REQUEST_TIMEOUT_SECONDS")
+	}
+	if cfg.MaxBodyBytes < 0 || cfg.MaxImportBodyBytes < 0 {
+		l.invalid("MAX_BODY_BYTES", "body size limits must not be negative")
+	}
+
+	if cfg.AdminAddr != "" {
+		if _, port, err := net.SplitHostPort(cfg.AdminAddr); err != nil {
+			l.invalid("ADMIN_ADDR", "must be host:port, got %q", cfg.AdminAddr)
+		} else if port == strconv.Itoa(cfg.Port) {
+			l.invalid("ADMIN_ADDR", "must use a different port than APP_PORT")
+		}
+	}
+
+	defaultLevel := slog.LevelInfo
+	if cfg.IsDevelopment() {
+		defaultLevel = slog.LevelDebug
+	}
+	cfg.LogLevel = l.level("LOG_LEVEL", defaultLevel)
+
+	if cfg.RateLimitRPS < 0 {
+		l.invalid("RATE_LIMIT_RPS", "must not be negative")
+	}
+	l.atLeast("RATE_LIMIT_BURST", cfg.RateLimitBurst, 1)
+
+	cfg.Database = loadDatabase(l, cfg.Env)
+
+	if err := l.err(); err != nil {
+		return nil, nil, err
+	}
+	return cfg, l, nil
+}
+
+// loadDatabase reads the DB_* settings. When DATABASE_URL is set it takes
+// precedence over the discrete connection variables.
+func loadDatabase(l *envLoader, env string) *database.Config {
+	driver, err := database.ParseDriver(l.string("DB_DRIVER", database.DriverPostgres))
+	if err != nil {
+		l.invalid("DB_DRIVER", "%v", err)
+		driver = database.DriverPostgres
+	}
+
+	db := &database.Config{
+		Driver:   driver,
+		Host:     l.string("DB_HOST", "localhost"),
+		Port:     l.int("DB_PORT", database.DefaultPort(driver)),
+		Database: l.string("DB_NAME", "agora_db"),
+		User:     l.string("DB_USER", "agora_user"),
+		Password: l.string("DB_PASSWORD", "agora_password"),
+		SSLMode:  l.string("DB_SSL_MODE", "disable"),
+		TLS: database.TLSFiles{
+			RootCert:   l.string("DB_SSL_ROOT_CERT", ""),
+			ClientCert: l.string("DB_SSL_CERT", ""),
+			ClientKey:  l.string("DB_SSL_KEY", ""),
+		},
+
+		ReplicaDSN: l.string("DB_REPLICA_DSN", ""),
+
+		// Connection pool configuration
+		MaxOpenConns:    l.int("DB_MAX_OPEN_CONNS", 25),
+		MaxIdleConns:    l.int("DB_MAX_IDLE_CONNS", 5),
+		ConnMaxLifetime: l.duration("DB_CONN_MAX_LIFETIME_MINUTES", 15, time.Minute),
+		ConnMaxIdleTime: l.duration("DB_CONN_MAX_IDLE_TIME_MINUTES", 5, time.Minute),
+
+		// Startup retry settings
+		ConnectRetries:      l.int("DB_CONNECT_RETRIES", 10),
+		ConnectRetryInitial: l.duration("DB_CONNECT_RETRY_INITIAL_MS", 500, time.Millisecond),
+		ConnectRetryMax:     l.duration("DB_CONNECT_RETRY_MAX_MS", 30000, time.Millisecond),
+
+		// Circuit breaker settings
+		BreakerFailureThreshold: l.int("DB_BREAKER_FAILURE_THRESHOLD", 5),
+		BreakerOpenTimeout:      l.duration("DB_BREAKER_OPEN_SECONDS", 30, time.Second),
+
+		StatementTimeout: l.duration("DB_STATEMENT_TIMEOUT_MS", 0, time.Millisecond),
+		PgBouncerMode:    l.bool("DB_PGBOUNCER_MODE", false),
+
+		SlowQueryThreshold: l.duration("DB_SLOW_QUERY_MS", 500, time.Millisecond),
+		LogQueries:         env == EnvDevelopment && l.bool("DB_LOG_QUERIES", true),
+
+		SecretsInterval: l.duration("DB_SECRET_REFRESH_SECONDS", 300, time.Second),
+	}
+
+	if databaseURL := l.string("DATABASE_URL", ""); databaseURL != "" {
+		if err := db.ApplyURL(databaseURL); err != nil {
+			l.invalid("DATABASE_URL", "%v", err)
+		}
+	}
+
+	if db.Port < 1 || db.Port > 65535 {
+		l.invalid("DB_PORT", "must be between 1 and 65535, got %d", db.Port)
+	}
+	l.oneOf("DB_SSL_MODE", db.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full")
+	l.atLeast("DB_MAX_OPEN_CONNS", db.MaxOpenConns, 0)
+	l.atLeast("DB_MAX_IDLE_CONNS", db.MaxIdleConns, 0)
+	if db.MaxOpenConns > 0 && db.MaxIdleConns > db.MaxOpenConns {
+		l.invalid("DB_MAX_IDLE_CONNS", "must not exceed DB_MAX_OPEN_CONNS (%d), got %d", db.MaxOpenConns, db.MaxIdleConns)
+	}
+	l.atLeast("DB_CONNECT_RETRIES", db.ConnectRetries, 1)
+	l.atLeast("DB_BREAKER_FAILURE_THRESHOLD", db.BreakerFailureThreshold, 1)
+	if db.StatementTimeout < 0 {
+		l.invalid("DB_STATEMENT_TIMEOUT_MS", "must not be negative")
+	}
+	l.fileExists("DB_SSL_ROOT_CERT", db.TLS.RootCert)
+	l.fileExists("DB_SSL_CERT", db.TLS.ClientCert)
+	l.fileExists("DB_SSL_KEY", db.TLS.ClientKey)
+	if (db.TLS.ClientCert == "") != (db.TLS.ClientKey == "") {
+		l.invalid("DB_SSL_CERT", "DB_SSL_CERT and DB_SSL_KEY must be set together")
+	}
+
+	return db
+}
+
+// loadSecrets applies the credentials of the secrets provider selected by SECRETS_PROVIDER
+func loadSecrets(l *envLoader, db *database.Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	provider, err := secrets.New(ctx, secrets.Settings{
+		Provider:    l.string("SECRETS_PROVIDER", ""),
+		VaultAddr:   l.string("VAULT_ADDR", ""),
+		VaultToken:  l.string("VAULT_TOKEN", ""),
+		VaultPath:   l.string("DB_SECRET_PATH", ""),
+		AWSSecretID: l.string("DB_SECRET_ID", ""),
+	})
+	if err != nil {
+		return &ValidationError{Problems: []string{"SECRETS_PROVIDER: " + err.Error()}}
+	}
+	if provider == nil {
+		return nil
+	}
+
+	creds, err := provider.Credentials(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load database credentials from %s: %w", provider.Name(), err)
+	}
+
+	db.Secrets = provider
+	db.ApplyCredentials(creds)
+	slog.Info("Loaded database credentials from secrets manager", slog.String("provider", provider.Name()))
+	return nil
+}
@@ -0,0 +1,281 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/scheduler"
+)
+
+// ValidationError lists every misconfigured setting found while loading
+type ValidationError struct {
+	Problems []string
+}
+
+// Error joins the problems into a single message
+func (e *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+// envLoader reads typed values from the environment, falling back to the values
+// of the config file, and collects parse errors instead of stopping at the first one
+type envLoader struct {
+	file     map[string]string
+	problems []string
+}
+
+// lookup returns the raw value of key with precedence env > file
+func (l *envLoader) lookup(key string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return l.file[key]
+}
+
+// invalid records a problem with key
+func (l *envLoader) invalid(key, format string, args ...interface{}) {
+	l.problems = append(l.problems, key+": "+fmt.Sprintf(format, args...))
+}
+
+// err returns the collected problems as a ValidationError, or nil
+func (l *envLoader) err() error {
+	if len(l.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: l.problems}
+}
+
+// string returns the value of key or def when unset
+func (l *envLoader) string(key, def string) string {
+	if value := l.lookup(key); value != "" {
+		return value
+	}
+	return def
+}
+
+// list returns the comma-separated values of key or def when unset
+func (l *envLoader) list(key string, def []string) []string {
+	value := l.lookup(key)
+	if value == "" {
+		return def
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// int returns the integer value of key or def when unset
+func (l *envLoader) int(key string, def int) int {
+	value := l.lookup(key)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		l.invalid(key, "must be an integer, got %q", value)
+		return def
+	}
+	return n
+}
+
+// bool returns the boolean value of key or def when unset
+func (l *envLoader) bool(key string, def bool) bool {
+	value := l.lookup(key)
+	if value == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		l.invalid(key, "must be a boolean, got %q", value)
+		return def
+	}
+	return b
+}
+
+// octal returns the octal integer value of key (e.g. 0660) or def when unset
+func (l *envLoader) octal(key string, def uint32) uint32 {
+	value := l.lookup(key)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.ParseUint(value, 8, 32)
+	if err != nil {
+		l.invalid(key, "must be an octal number, got %q", value)
+		return def
+	}
+	return uint32(n)
+}
+
+// float returns the floating point value of key or def when unset
+func (l *envLoader) float(key string, def float64) float64 {
+	value := l.lookup(key)
+	if value == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		l.invalid(key, "must be a number, got %q", value)
+		return def
+	}
+	return f
+}
+
+// decimal returns the exact, non-negative decimal value of key or def when unset
+func (l *envLoader) decimal(key string, def decimal.Decimal) decimal.Decimal {
+	value := l.lookup(key)
+	if value == "" {
+		return def
+	}
+	d, err := decimal.NewFromString(value)
+	if err != nil || d.IsNegative() {
+		l.invalid(key, "must be a non-negative number, got %q", value)
+		return def
+	}
+	return d
+}
+
+// level returns the log level named by key (debug, info, warn, error) or def when unset
+func (l *envLoader) level(key string, def slog.Level) slog.Level {
+	value := l.lookup(key)
+	if value == "" {
+		return def
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		l.invalid(key, "must be one of debug, info, warn, error, got %q", value)
+		return def
+	}
+	return level
+}
+
+// location returns the timezone named by key, an IANA name such as Asia/Amman, or
+// def when unset
+func (l *envLoader) location(key string, def *time.Location) *time.Location {
+	value := l.lookup(key)
+	if value == "" {
+		return def
+	}
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		l.invalid(key, "must be an IANA timezone such as Asia/Amman, got %q", value)
+		return def
+	}
+	return loc
+}
+
+// clock returns key, a time of day as HH:MM, as the duration since midnight
+func (l *envLoader) clock(key string, def time.Duration) time.Duration {
+	value := l.lookup(key)
+	if value == "" {
+		return def
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		l.invalid(key, "must be a time of day as HH:MM, got %q", value)
+		return def
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
+// date returns key, a date as YYYY-MM-DD, at midnight UTC
+func (l *envLoader) date(key string, def time.Time) time.Time {
+	value := l.lookup(key)
+	if value == "" {
+		return def
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		l.invalid(key, "must be a date as YYYY-MM-DD, got %q", value)
+		return def
+	}
+	return t
+}
+
+// duration returns key, an integer count of unit, as a duration
+func (l *envLoader) duration(key string, def int, unit time.Duration) time.Duration {
+	return time.Duration(l.int(key, def)) * unit
+}
+
+// prefixes reads a comma-separated list of CIDR ranges; bare IPs match a single address
+func (l *envLoader) prefixes(key string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, value := range l.list(key, nil) {
+		if addr, err := netip.ParseAddr(value); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			l.invalid(key, "%q is not an IP address or CIDR range", value)
+			continue
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
+}
+
+// pairs reads a comma-separated list of key=value pairs, e.g. main=grill,drink=bar
+func (l *envLoader) pairs(key string) map[string]string {
+	pairs := make(map[string]string)
+	for _, item := range l.list(key, nil) {
+		name, value, ok := strings.Cut(item, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" || value == "" {
+			l.invalid(key, "%q is not a name=value pair", item)
+			continue
+		}
+		pairs[name] = value
+	}
+	return pairs
+}
+
+// schedule reads a periodic task schedule (see scheduler.Parse); "off" disables the task
+func (l *envLoader) schedule(key, def string) string {
+	value := l.string(key, def)
+	if value == "off" {
+		return value
+	}
+	if _, err := scheduler.Parse(value); err != nil {
+		l.invalid(key, "%v", err)
+	}
+	return value
+}
+
+// atLeast records a problem when value is below min
+func (l *envLoader) atLeast(key string, value, min int) {
+	if value < min {
+		l.invalid(key, "must be at least %d, got %d", min, value)
+	}
+}
+
+// oneOf records a problem when value is not among allowed
+func (l *envLoader) oneOf(key, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	l.invalid(key, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+// fileExists records a problem when path is set but cannot be read
+func (l *envLoader) fileExists(key, path string) {
+	if path == "" {
+		return
+	}
+	if _, err := os.Stat(path); err != nil {
+		l.invalid(key, "%v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// readFile loads a YAML or TOML config file and flattens it into the same keys
+// as the environment variables: nested sections are joined with underscores and
+// upper-cased, so
+//
+//	db:
+//	  host: postgres
+//	cors:
+//	  allowed_origins: [https://a.example, https://b.example]
+//
+// yields DB_HOST=postgres and CORS_ALLOWED_ORIGINS=https://a.example,https://b.example.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]interface{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported config file type %q (use .yaml, .yml or .toml)", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flatten(values, "", raw)
+	return values, nil
+}
+
+// flatten copies the leaves of node into values under upper-cased, underscore-joined keys
+func flatten(values map[string]string, prefix string, node interface{}) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			name := strings.ToUpper(key)
+			if prefix != "" {
+				name = prefix + "_" + name
+			}
+			flatten(values, name, child)
+		}
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		values[prefix] = strings.Join(items, ",")
+	case nil:
+	default:
+		values[prefix] = fmt.Sprint(v)
+	}
+}
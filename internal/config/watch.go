@@ -0,0 +1,64 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// watchInterval is how often the config file is checked for changes
+const watchInterval = 5 * time.Second
+
+// Watch reloads the configuration on SIGHUP and whenever the file at path changes,
+// passing each successfully validated result to apply. Only settings that can be
+// changed safely at runtime should be taken from it; an invalid reload is logged
+// and the running configuration is kept. Watch returns when ctx is cancelled.
+func Watch(ctx context.Context, path string, apply func(*Config)) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	var ticks <-chan time.Time
+	var lastMod time.Time
+	if path != "" {
+		ticker := time.NewTicker(watchInterval)
+		defer ticker.Stop()
+		ticks = ticker.C
+		lastMod = modTime(path)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+			slog.Info("Received SIGHUP, reloading configuration")
+		case <-ticks:
+			mod := modTime(path)
+			if mod.Equal(lastMod) {
+				continue
+			}
+			lastMod = mod
+			slog.Info("Config file changed, reloading configuration", slog.String("path", path))
+		}
+
+		cfg, _, err := parse(path)
+		if err != nil {
+			slog.Error("Failed to reload configuration, keeping current settings", slog.String("error", err.Error()))
+			continue
+		}
+		apply(cfg)
+	}
+}
+
+// modTime returns the modification time of path, or the zero time if it cannot be read
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -3,70 +3,299 @@ package database
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log/slog"
-	"os"
+	"math/rand/v2"
+	"net"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/mysqldialect"
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/driver/pgdriver"
 	"github.com/uptrace/bun/extra/bundebug"
+	"github.com/uptrace/bun/extra/bunotel"
+
+	"github.com/Zughayyar/agora-server/internal/secrets"
 )
 
 // Config holds database configuration with connection pool settings
 type Config struct {
+	Driver   string // DriverPostgres or DriverMySQL
 	Host     string
 	Port     int
 	Database string
 	User     string
 	Password string
 	SSLMode  string
+	TLS      TLSFiles // Optional certificates for verified TLS
+
+	// Extra connection parameters taken from DATABASE_URL's query string
+	Params map[string]string
+
+	// Optional read replica DSN; reads are routed there when it is healthy
+	ReplicaDSN string
 
 	// Connection Pool Settings
 	MaxOpenConns    int           // Maximum number of open connections
 	MaxIdleConns    int           // Maximum number of idle connections
 	ConnMaxLifetime time.Duration // Maximum connection lifetime
 	ConnMaxIdleTime time.Duration // Maximum connection idle time
+
+	// Startup retry settings (wait for the database to come up)
+	ConnectRetries      int           // Maximum connection attempts (1 disables retrying)
+	ConnectRetryInitial time.Duration // Delay before the first retry
+	ConnectRetryMax     time.Duration // Upper bound for the backoff delay
+
+	// Circuit breaker settings for service-level database calls
+	BreakerFailureThreshold int           // Consecutive failures before the breaker opens
+	BreakerOpenTimeout      time.Duration // How long the breaker stays open before probing
+
+	// Server-side limit applied to every statement (0 disables)
+	StatementTimeout time.Duration
+
+	// PgBouncer transaction pooling compatibility: no session-level startup parameters
+	PgBouncerMode bool
+
+	// Diagnostics
+	SlowQueryThreshold time.Duration // Log queries slower than this (0 disables)
+	LogQueries         bool          // Log every statement with bundebug
+
+	// Optional secrets manager holding the credentials, and how often to check it for rotation
+	Secrets         secrets.Provider
+	SecretsInterval time.Duration
+}
+
+// ApplyCredentials overrides the connection settings present in creds
+func (c *Config) ApplyCredentials(creds secrets.Credentials) {
+	c.User = creds.Username
+	c.Password = creds.Password
+	if creds.Host != "" {
+		c.Host = creds.Host
+	}
+	if creds.Port != 0 {
+		c.Port = creds.Port
+	}
+	if creds.Database != "" {
+		c.Database = creds.Database
+	}
+}
+
+// ApplyURL overrides the connection settings with those of a postgres:// or mysql:// URL
+// (as provided by Heroku/Render/Fly in DATABASE_URL)
+func (c *Config) ApplyURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	driver, err := ParseDriver(u.Scheme)
+	if err != nil {
+		return err
+	}
+	c.Driver = driver
+
+	if host := u.Hostname(); host != "" {
+		c.Host = host
+	}
+	if port := u.Port(); port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return fmt.Errorf("invalid port %q", port)
+		}
+		c.Port = p
+	} else {
+		c.Port = DefaultPort(driver)
+	}
+	if name := strings.TrimPrefix(u.Path, "/"); name != "" {
+		c.Database = name
+	}
+	if u.User != nil {
+		c.User = u.User.Username()
+		if password, ok := u.User.Password(); ok {
+			c.Password = password
+		}
+	}
+
+	// sslmode maps onto its own field; any other option is passed through to the driver
+	query := u.Query()
+	if sslMode := query.Get("sslmode"); sslMode != "" {
+		c.SSLMode = sslMode
+		query.Del("sslmode")
+	}
+	if len(query) > 0 {
+		c.Params = make(map[string]string, len(query))
+		for key := range query {
+			c.Params[key] = query.Get(key)
+		}
+	}
+
+	return nil
 }
 
-// LoadConfig loads database configuration from environment variables
-func LoadConfig() *Config {
-	port, _ := strconv.Atoi(getEnv("DB_PORT", "5432"))
+// DSN returns the connection string for the primary database in the driver's format
+func (c *Config) DSN() string {
+	if c.Driver == DriverMySQL {
+		return c.mysqlDSN()
+	}
+
+	query := url.Values{}
+	for key, value := range c.Params {
+		query.Set(key, value)
+	}
+	query.Set("sslmode", c.SSLMode)
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     fmt.Sprintf("%s:%d", c.Host, c.Port),
+		Path:     "/" + c.Database,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
 
-	// Connection pool settings with sensible defaults
-	maxOpen, _ := strconv.Atoi(getEnv("DB_MAX_OPEN_CONNS", "25"))
-	maxIdle, _ := strconv.Atoi(getEnv("DB_MAX_IDLE_CONNS", "5"))
-	maxLifetimeMin, _ := strconv.Atoi(getEnv("DB_CONN_MAX_LIFETIME_MINUTES", "15"))
-	maxIdleTimeMin, _ := strconv.Atoi(getEnv("DB_CONN_MAX_IDLE_TIME_MINUTES", "5"))
+// mysqlDSN builds a go-sql-driver/mysql DSN, mapping SSLMode onto its tls option
+func (c *Config) mysqlDSN() string {
+	cfg := mysql.NewConfig()
+	cfg.User = c.User
+	cfg.Passwd = c.Password
+	cfg.Net = "tcp"
+	cfg.Addr = fmt.Sprintf("%s:%d", c.Host, c.Port)
+	cfg.DBName = c.Database
+	cfg.ParseTime = true
+	cfg.Loc = time.UTC
 
-	return &Config{
-		Host:     getEnv("DB_HOST", "localhost"),
-		Port:     port,
-		Database: getEnv("DB_NAME", "agora_db"),
-		User:     getEnv("DB_USER", "agora_user"),
-		Password: getEnv("DB_PASSWORD", "agora_password"),
-		SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+	switch c.SSLMode {
+	case "", "disable":
+	case "verify-ca", "verify-full":
+		cfg.TLSConfig = "true"
+	default:
+		cfg.TLSConfig = "skip-verify"
+	}
 
-		// Connection pool configuration
-		MaxOpenConns:    maxOpen,
-		MaxIdleConns:    maxIdle,
-		ConnMaxLifetime: time.Duration(maxLifetimeMin) * time.Minute,
-		ConnMaxIdleTime: time.Duration(maxIdleTimeMin) * time.Minute,
+	cfg.Params = make(map[string]string, len(c.Params)+1)
+	for key, value := range c.Params {
+		cfg.Params[key] = value
+	}
+	if c.StatementTimeout > 0 {
+		// MySQL's closest equivalent of statement_timeout (applies to SELECTs only)
+		cfg.Params["max_execution_time"] = strconv.FormatInt(c.StatementTimeout.Milliseconds(), 10)
 	}
+
+	return cfg.FormatDSN()
 }
 
 // NewConnection creates a new Bun database connection with optimized pool settings
 func NewConnection(config *Config) (*bun.DB, error) {
-	// Build PostgreSQL DSN (Data Source Name)
-	dsn := fmt.Sprintf(
-		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		config.User, config.Password, config.Host, config.Port, config.Database, config.SSLMode,
+	if config.PgBouncerMode && config.StatementTimeout > 0 {
+		slog.Warn("DB_STATEMENT_TIMEOUT_MS is ignored in PgBouncer mode; set statement_timeout on the database role instead")
+	}
+
+	connector, err := newConnector(config.DSN(), config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Allow the credentials to be swapped when the secret is rotated
+	var rotating *rotatingConnector
+	if config.Secrets != nil {
+		rotating = &rotatingConnector{current: connector}
+		connector = rotating
+	}
+
+	sqldb := openSQLDB(connector, config)
+	if rotating != nil {
+		registerRotatingConnector(sqldb, rotating)
+	}
+
+	// Test the connection, retrying while the database starts up
+	if err := pingWithRetry(sqldb, config); err != nil {
+		if closeErr := sqldb.Close(); closeErr != nil {
+			return nil, closeErr
+		}
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	db := newBunDB(sqldb, config)
+
+	slog.Info("Database connected successfully",
+		slog.String("driver", config.Driver),
+		slog.String("host", config.Host),
+		slog.Int("port", config.Port),
+		slog.String("database", config.Database),
+		slog.Bool("pgbouncer_mode", config.PgBouncerMode),
+		slog.Int("max_open_conns", config.MaxOpenConns),
+		slog.Int("max_idle_conns", config.MaxIdleConns),
+		slog.Duration("conn_max_lifetime", config.ConnMaxLifetime),
 	)
 
-	// Create underlying SQL connection with pgdriver (Bun's optimized driver)
-	sqldb := sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN(dsn)))
+	return db, nil
+}
+
+// newConnector creates the driver connector for a DSN
+func newConnector(dsn string, config *Config) (driver.Connector, error) {
+	switch config.Driver {
+	case DriverMySQL:
+		mysqlConfig, err := mysql.ParseDSN(dsn)
+		if err != nil {
+			return nil, err
+		}
+		if !config.TLS.IsZero() {
+			host, _, _ := net.SplitHostPort(mysqlConfig.Addr)
+			tlsConfig, err := buildTLSConfig(config.SSLMode, config.TLS, host)
+			if err != nil {
+				return nil, err
+			}
+			mysqlConfig.TLS = tlsConfig
+		}
+		return mysql.NewConnector(mysqlConfig)
+	default:
+		options := []pgdriver.Option{pgdriver.WithDSN(dsn)}
+
+		// Replace the DSN-derived TLS settings when certificate files are supplied
+		if !config.TLS.IsZero() {
+			u, err := url.Parse(dsn)
+			if err != nil {
+				return nil, err
+			}
+			sslMode := u.Query().Get("sslmode")
+			if sslMode == "" {
+				sslMode = config.SSLMode
+			}
+			tlsConfig, err := buildTLSConfig(sslMode, config.TLS, u.Hostname())
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, pgdriver.WithTLSConfig(tlsConfig))
+		}
+
+		switch {
+		case config.PgBouncerMode:
+			// In transaction pooling mode server connections are shared between clients, so
+			// session state must not be relied on. PgBouncer also rejects unknown startup
+			// parameters, so drop any (including statement_timeout) taken from the DSN.
+			// pgdriver formats arguments client-side and sends queries over the simple
+			// protocol, so no prepared statements are created as long as callers do not
+			// use sql.DB.Prepare themselves.
+			options = append(options, withoutConnParams())
+		case config.StatementTimeout > 0:
+			// Enforce a per-connection statement_timeout so runaway queries are killed server-side
+			options = append(options, withConnParam("statement_timeout", config.StatementTimeout.Milliseconds()))
+		}
+
+		// Create underlying SQL connection with pgdriver (Bun's optimized driver)
+		return pgdriver.NewConnector(options...), nil
+	}
+}
+
+// openSQLDB creates the connection pool for a connector
+func openSQLDB(connector driver.Connector, config *Config) *sql.DB {
+	sqldb := sql.OpenDB(connector)
 
 	// Configure connection pool for optimal performance
 	sqldb.SetMaxOpenConns(config.MaxOpenConns)       // Limit concurrent connections
@@ -74,39 +303,99 @@ func NewConnection(config *Config) (*bun.DB, error) {
 	sqldb.SetConnMaxLifetime(config.ConnMaxLifetime) // Rotate old connections
 	sqldb.SetConnMaxIdleTime(config.ConnMaxIdleTime) // Close unused connections
 
-	// Test the connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	return sqldb
+}
 
-	if err := sqldb.PingContext(ctx); err != nil {
-		err := sqldb.Close()
-		if err != nil {
-			return nil, err
+// withConnParam adds a single startup parameter without discarding those parsed from the DSN
+func withConnParam(key string, value interface{}) pgdriver.Option {
+	return func(c *pgdriver.Config) {
+		if c.ConnParams == nil {
+			c.ConnParams = make(map[string]interface{})
 		}
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+		c.ConnParams[key] = value
+	}
+}
+
+// withoutConnParams clears all extra startup parameters
+func withoutConnParams() pgdriver.Option {
+	return func(c *pgdriver.Config) {
+		c.ConnParams = nil
+	}
+}
+
+// newBunDB wraps a connection pool in a Bun instance with the standard query hooks
+func newBunDB(sqldb *sql.DB, config *Config) *bun.DB {
+	// Create Bun database instance with the dialect matching the driver
+	var db *bun.DB
+	switch config.Driver {
+	case DriverMySQL:
+		db = bun.NewDB(sqldb, mysqldialect.New())
+	default:
+		db = bun.NewDB(sqldb, pgdialect.New())
 	}
 
-	// Create Bun database instance with PostgreSQL dialect
-	db := bun.NewDB(sqldb, pgdialect.New())
+	// Emit a span for every query (no-op unless tracing is enabled)
+	db.AddQueryHook(bunotel.NewQueryHook(bunotel.WithDBName(config.Database)))
+
+	// Warn about slow statements
+	if config.SlowQueryThreshold > 0 {
+		db.AddQueryHook(NewSlowQueryHook(config.SlowQueryThreshold))
+	}
 
 	// Add debug logging in development mode
-	if os.Getenv("APP_ENV") == "development" && os.Getenv("DB_LOG_QUERIES") != "false" {
+	if config.LogQueries {
 		db.AddQueryHook(bundebug.NewQueryHook(
 			bundebug.WithVerbose(true), // Show full queries
 			bundebug.WithEnabled(true), // Enable debugging
 		))
 	}
 
-	slog.Info("Database connected successfully",
-		slog.String("host", config.Host),
-		slog.Int("port", config.Port),
-		slog.String("database", config.Database),
-		slog.Int("max_open_conns", config.MaxOpenConns),
-		slog.Int("max_idle_conns", config.MaxIdleConns),
-		slog.Duration("conn_max_lifetime", config.ConnMaxLifetime),
-	)
+	return db
+}
 
-	return db, nil
+// pingWithRetry pings the database using exponential backoff with jitter
+func pingWithRetry(sqldb *sql.DB, config *Config) error {
+	attempts := max(config.ConnectRetries, 1)
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err = sqldb.PingContext(ctx)
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+		if attempt == attempts {
+			break
+		}
+
+		delay := backoffDelay(attempt, config.ConnectRetryInitial, config.ConnectRetryMax)
+		slog.Warn("Database not ready, retrying",
+			slog.Int("attempt", attempt),
+			slog.Int("max_attempts", attempts),
+			slog.Duration("retry_in", delay),
+			slog.String("error", err.Error()),
+		)
+		time.Sleep(delay)
+	}
+
+	return fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+}
+
+// backoffDelay returns the exponential delay for the given attempt with equal jitter
+func backoffDelay(attempt int, initial, maxDelay time.Duration) time.Duration {
+	delay := initial << (attempt - 1)
+	if delay <= 0 || delay > maxDelay {
+		delay = maxDelay
+	}
+
+	// Randomize the second half of the delay so restarting replicas don't retry in lockstep
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + rand.N(half)
 }
 
 // HealthCheck performs a database health check
@@ -124,11 +413,3 @@ func Close(db *bun.DB) error {
 func GetStats(db *bun.DB) sql.DBStats {
 	return db.DB.Stats()
 }
-
-// getEnv gets environment variable with fallback default
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// rotatingConnector is a driver.Connector whose underlying connector (and with it
+// the credentials used for new connections) can be replaced at runtime
+type rotatingConnector struct {
+	mu      sync.RWMutex
+	current driver.Connector
+}
+
+var (
+	rotatingMu         sync.Mutex
+	rotatingConnectors = map[*sql.DB]*rotatingConnector{}
+)
+
+// Connect opens a connection with the current credentials
+func (c *rotatingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	c.mu.RLock()
+	connector := c.current
+	c.mu.RUnlock()
+	return connector.Connect(ctx)
+}
+
+// Driver returns the underlying driver
+func (c *rotatingConnector) Driver() driver.Driver {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.current.Driver()
+}
+
+// swap replaces the connector used for new connections
+func (c *rotatingConnector) swap(connector driver.Connector) {
+	c.mu.Lock()
+	c.current = connector
+	c.mu.Unlock()
+}
+
+// registerRotatingConnector remembers the rotating connector behind a pool
+func registerRotatingConnector(sqldb *sql.DB, connector *rotatingConnector) {
+	rotatingMu.Lock()
+	rotatingConnectors[sqldb] = connector
+	rotatingMu.Unlock()
+}
+
+// WatchCredentials polls the secrets provider of config every config.SecretsInterval
+// until ctx is cancelled. When the secret changes, new connections use the rotated
+// credentials and idle connections are closed so the pool is rebuilt with them;
+// busy connections are replaced as they reach their maximum lifetime.
+// It does nothing when no secrets provider is configured.
+func WatchCredentials(ctx context.Context, db *bun.DB, config *Config) {
+	rotatingMu.Lock()
+	connector, ok := rotatingConnectors[db.DB]
+	rotatingMu.Unlock()
+
+	if !ok || config.Secrets == nil || config.SecretsInterval <= 0 {
+		return
+	}
+
+	// Work on a copy so the caller's config is never mutated concurrently
+	current := *config
+
+	go func() {
+		ticker := time.NewTicker(current.SecretsInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				rotateCredentials(ctx, db, connector, &current)
+			}
+		}
+	}()
+}
+
+// rotateCredentials swaps in the provider's credentials when they differ from config's
+func rotateCredentials(ctx context.Context, db *bun.DB, connector *rotatingConnector, config *Config) {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	creds, err := config.Secrets.Credentials(ctx)
+	if err != nil {
+		slog.Warn("Failed to refresh database credentials",
+			slog.String("provider", config.Secrets.Name()),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	updated := *config
+	updated.ApplyCredentials(creds)
+	if updated.DSN() == config.DSN() {
+		return
+	}
+
+	next, err := newConnector(updated.DSN(), &updated)
+	if err != nil {
+		slog.Error("Failed to apply rotated database credentials", slog.String("error", err.Error()))
+		return
+	}
+
+	connector.swap(next)
+	*config = updated
+
+	// Dropping the idle connections forces the pool to reconnect with the new credentials
+	db.DB.SetMaxIdleConns(0)
+	db.DB.SetMaxIdleConns(config.MaxIdleConns)
+
+	slog.Info("Database credentials rotated", slog.String("provider", config.Secrets.Name()))
+}
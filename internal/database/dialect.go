@@ -0,0 +1,62 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+// Supported values for DB_DRIVER
+const (
+	DriverPostgres = "postgres"
+	DriverMySQL    = "mysql"
+)
+
+// ParseDriver normalizes a DB_DRIVER value or URL scheme
+func ParseDriver(name string) (string, error) {
+	switch name {
+	case "postgres", "postgresql", "pg":
+		return DriverPostgres, nil
+	case "mysql", "mariadb":
+		return DriverMySQL, nil
+	default:
+		return "", fmt.Errorf("unsupported database driver %q", name)
+	}
+}
+
+// DefaultPort returns the standard server port for a driver
+func DefaultPort(driver string) int {
+	if driver == DriverMySQL {
+		return 3306
+	}
+	return 5432
+}
+
+// IsMySQL reports whether db talks to MySQL/MariaDB
+func IsMySQL(db bun.IDB) bool {
+	return db.Dialect().Name() == dialect.MySQL
+}
+
+// ILike returns the case-insensitive LIKE operator for the database's dialect.
+// MySQL has no ILIKE, but its default collations already compare case-insensitively.
+func ILike(db bun.IDB) string {
+	if IsMySQL(db) {
+		return "LIKE"
+	}
+	return "ILIKE"
+}
+
+// IsUniqueViolation reports whether err is a duplicate key error of PostgreSQL or
+// MySQL
+func IsUniqueViolation(err error) bool {
+	var pgErr pgdriver.Error
+	if errors.As(err, &pgErr) {
+		return pgErr.Field('C') == "23505"
+	}
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
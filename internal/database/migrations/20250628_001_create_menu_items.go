@@ -5,12 +5,42 @@ import (
 	"fmt"
 
 	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
 )
 
+// createMenuItemsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below.
+// MySQL has no CREATE INDEX IF NOT EXISTS, so the indexes are declared inline.
+var createMenuItemsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS menu_items (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		description TEXT,
+		price DECIMAL(10,2) NOT NULL CHECK (price > 0),
+		category VARCHAR(50) NOT NULL DEFAULT 'main',
+		is_available BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		deleted_at DATETIME(6) NULL,
+		INDEX idx_menu_items_category (category),
+		INDEX idx_menu_items_is_available (is_available),
+		INDEX idx_menu_items_created_at (created_at),
+		INDEX idx_menu_items_deleted_at (deleted_at)
+	)`,
+}
+
 func init() {
 	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
 		fmt.Print(" [UP] creating menu_items table...")
 
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createMenuItemsMySQL); err != nil {
+				return fmt.Errorf("failed to create menu_items table: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
 		// Create the menu_items table with specified schema
 		_, err := db.ExecContext(ctx, `
 			CREATE TABLE IF NOT EXISTS menu_items (
@@ -0,0 +1,87 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createJobsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createJobsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS jobs (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		kind VARCHAR(100) NOT NULL,
+		payload TEXT NOT NULL,
+		result TEXT NULL,
+		status VARCHAR(20) NOT NULL DEFAULT 'pending',
+		attempts INT NOT NULL DEFAULT 0,
+		max_attempts INT NOT NULL DEFAULT 5,
+		last_error TEXT NULL,
+		run_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		locked_at DATETIME(6) NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		finished_at DATETIME(6) NULL,
+		INDEX idx_jobs_status_run_at (status, run_at),
+		INDEX idx_jobs_kind (kind)
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating jobs table...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createJobsMySQL); err != nil {
+				return fmt.Errorf("failed to create jobs table: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS jobs (
+				id BIGSERIAL PRIMARY KEY,
+				kind VARCHAR(100) NOT NULL,
+				payload TEXT NOT NULL,
+				result TEXT NULL,
+				status VARCHAR(20) NOT NULL DEFAULT 'pending',
+				attempts INT NOT NULL DEFAULT 0,
+				max_attempts INT NOT NULL DEFAULT 5,
+				last_error TEXT NULL,
+				run_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				locked_at TIMESTAMP WITH TIME ZONE NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				finished_at TIMESTAMP WITH TIME ZONE NULL
+			);
+
+			-- Workers look up due jobs by status and run_at
+			CREATE INDEX IF NOT EXISTS idx_jobs_status_run_at ON jobs(status, run_at);
+			CREATE INDEX IF NOT EXISTS idx_jobs_kind ON jobs(kind);
+		`)
+
+		if err != nil {
+			return fmt.Errorf("failed to create jobs table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping jobs table...")
+
+		_, err := db.ExecContext(ctx, `
+			DROP TABLE IF EXISTS jobs;
+		`)
+
+		if err != nil {
+			return fmt.Errorf("failed to drop jobs table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
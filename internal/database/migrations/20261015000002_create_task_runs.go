@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createTaskRunsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createTaskRunsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS task_runs (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		task VARCHAR(100) NOT NULL,
+		scheduled_at DATETIME(6) NOT NULL,
+		started_at DATETIME(6) NOT NULL,
+		finished_at DATETIME(6) NULL,
+		duration_ms BIGINT NOT NULL DEFAULT 0,
+		status VARCHAR(20) NOT NULL,
+		result TEXT NULL,
+		error TEXT NULL,
+		UNIQUE INDEX idx_task_runs_task_scheduled_at (task, scheduled_at)
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating task_runs table...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createTaskRunsMySQL); err != nil {
+				return fmt.Errorf("failed to create task_runs table: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// The unique index lets only one instance claim each scheduled run
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS task_runs (
+				id BIGSERIAL PRIMARY KEY,
+				task VARCHAR(100) NOT NULL,
+				scheduled_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				finished_at TIMESTAMP WITH TIME ZONE NULL,
+				duration_ms BIGINT NOT NULL DEFAULT 0,
+				status VARCHAR(20) NOT NULL,
+				result TEXT NULL,
+				error TEXT NULL
+			);
+
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_task_runs_task_scheduled_at ON task_runs(task, scheduled_at);
+		`)
+
+		if err != nil {
+			return fmt.Errorf("failed to create task_runs table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping task_runs table...")
+
+		_, err := db.ExecContext(ctx, `
+			DROP TABLE IF EXISTS task_runs;
+		`)
+
+		if err != nil {
+			return fmt.Errorf("failed to drop task_runs table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,105 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createWebhooksMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createWebhooksMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		url VARCHAR(2048) NOT NULL,
+		events TEXT NOT NULL,
+		description VARCHAR(255) NOT NULL DEFAULT '',
+		secret VARCHAR(100) NOT NULL,
+		active BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at DATETIME(6) NOT NULL,
+		updated_at DATETIME(6) NOT NULL
+	)`, `
+	CREATE TABLE IF NOT EXISTS webhook_deliveries (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		subscription_id BIGINT NOT NULL,
+		event_id VARCHAR(64) NOT NULL,
+		event_type VARCHAR(100) NOT NULL,
+		attempt INT NOT NULL,
+		succeeded BOOLEAN NOT NULL,
+		status_code INT NULL,
+		error TEXT NULL,
+		duration_ms BIGINT NOT NULL DEFAULT 0,
+		created_at DATETIME(6) NOT NULL,
+		INDEX idx_webhook_deliveries_subscription_id (subscription_id),
+		INDEX idx_webhook_deliveries_created_at (created_at),
+		CONSTRAINT fk_webhook_deliveries_subscription FOREIGN KEY (subscription_id)
+			REFERENCES webhook_subscriptions(id) ON DELETE CASCADE
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating webhook_subscriptions and webhook_deliveries tables...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createWebhooksMySQL); err != nil {
+				return fmt.Errorf("failed to create webhook tables: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Events are stored as a JSON array of event type patterns
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+				id BIGSERIAL PRIMARY KEY,
+				url VARCHAR(2048) NOT NULL,
+				events TEXT NOT NULL,
+				description VARCHAR(255) NOT NULL DEFAULT '',
+				secret VARCHAR(100) NOT NULL,
+				active BOOLEAN NOT NULL DEFAULT TRUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+			);
+
+			CREATE TABLE IF NOT EXISTS webhook_deliveries (
+				id BIGSERIAL PRIMARY KEY,
+				subscription_id BIGINT NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+				event_id VARCHAR(64) NOT NULL,
+				event_type VARCHAR(100) NOT NULL,
+				attempt INTEGER NOT NULL,
+				succeeded BOOLEAN NOT NULL,
+				status_code INTEGER NULL,
+				error TEXT NULL,
+				duration_ms BIGINT NOT NULL DEFAULT 0,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries(subscription_id);
+			CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON webhook_deliveries(created_at);
+		`)
+
+		if err != nil {
+			return fmt.Errorf("failed to create webhook tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping webhook_deliveries and webhook_subscriptions tables...")
+
+		// Separate statements, as MySQL runs one per Exec
+		err := execAll(ctx, db, []string{
+			`DROP TABLE IF EXISTS webhook_deliveries`,
+			`DROP TABLE IF EXISTS webhook_subscriptions`,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop webhook tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
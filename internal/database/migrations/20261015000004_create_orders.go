@@ -0,0 +1,111 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createOrdersMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createOrdersMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS orders (
+		id CHAR(36) PRIMARY KEY,
+		source VARCHAR(50) NOT NULL,
+		external_id VARCHAR(100) NULL,
+		channel VARCHAR(20) NOT NULL,
+		status VARCHAR(20) NOT NULL,
+		total DECIMAL(10,2) NOT NULL,
+		customer_name VARCHAR(200) NULL,
+		customer_phone VARCHAR(50) NULL,
+		notes TEXT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE INDEX idx_orders_source_external_id (source, external_id),
+		INDEX idx_orders_status (status),
+		INDEX idx_orders_created_at (created_at)
+	)`, `
+	CREATE TABLE IF NOT EXISTS order_items (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		order_id CHAR(36) NOT NULL,
+		menu_item_id INT NULL,
+		name VARCHAR(200) NOT NULL,
+		quantity INT NOT NULL CHECK (quantity > 0),
+		unit_price DECIMAL(10,2) NOT NULL,
+		notes TEXT NULL,
+		INDEX idx_order_items_order_id (order_id),
+		INDEX idx_order_items_menu_item_id (menu_item_id),
+		CONSTRAINT fk_order_items_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating orders and order_items tables...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createOrdersMySQL); err != nil {
+				return fmt.Errorf("failed to create orders tables: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// The unique (source, external_id) index rejects a delivery platform order
+		// received twice; NULL external IDs (in-house orders) never conflict
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS orders (
+				id UUID PRIMARY KEY,
+				source VARCHAR(50) NOT NULL,
+				external_id VARCHAR(100) NULL,
+				channel VARCHAR(20) NOT NULL,
+				status VARCHAR(20) NOT NULL,
+				total DECIMAL(10,2) NOT NULL,
+				customer_name VARCHAR(200) NULL,
+				customer_phone VARCHAR(50) NULL,
+				notes TEXT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE IF NOT EXISTS order_items (
+				id SERIAL PRIMARY KEY,
+				order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+				menu_item_id INTEGER NULL,
+				name VARCHAR(200) NOT NULL,
+				quantity INTEGER NOT NULL CHECK (quantity > 0),
+				unit_price DECIMAL(10,2) NOT NULL,
+				notes TEXT NULL
+			);
+
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_source_external_id ON orders(source, external_id);
+			CREATE INDEX IF NOT EXISTS idx_orders_status ON orders(status);
+			CREATE INDEX IF NOT EXISTS idx_orders_created_at ON orders(created_at);
+			CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items(order_id);
+			CREATE INDEX IF NOT EXISTS idx_order_items_menu_item_id ON order_items(menu_item_id);
+		`)
+
+		if err != nil {
+			return fmt.Errorf("failed to create orders tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping order_items and orders tables...")
+
+		// Separate statements, as MySQL runs one per Exec
+		err := execAll(ctx, db, []string{
+			`DROP TABLE IF EXISTS order_items`,
+			`DROP TABLE IF EXISTS orders`,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop orders tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
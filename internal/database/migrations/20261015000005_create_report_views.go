@@ -0,0 +1,102 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Sales per day and per menu item and day, days being those of created_at in the
+// database's time zone (UTC unless the server is configured otherwise). Cancelled
+// orders are not sales; order lines not matched to the menu have menu_item_id 0.
+const (
+	dailySalesQuery = `
+		SELECT CAST(o.created_at AS DATE) AS day,
+			COUNT(*) AS orders,
+			SUM(o.total) AS revenue
+		FROM orders AS o
+		WHERE o.status <> 'cancelled'
+		GROUP BY CAST(o.created_at AS DATE)`
+
+	itemSalesQuery = `
+		SELECT CAST(o.created_at AS DATE) AS day,
+			COALESCE(oi.menu_item_id, 0) AS menu_item_id,
+			oi.name,
+			COALESCE(mi.category, 'uncategorized') AS category,
+			COUNT(DISTINCT o.id) AS orders,
+			SUM(oi.quantity) AS quantity,
+			SUM(oi.quantity * oi.unit_price) AS revenue
+		FROM orders AS o
+		JOIN order_items AS oi ON oi.order_id = o.id
+		LEFT JOIN menu_items AS mi ON mi.id = oi.menu_item_id
+		WHERE o.status <> 'cancelled'
+		GROUP BY CAST(o.created_at AS DATE), COALESCE(oi.menu_item_id, 0), oi.name, COALESCE(mi.category, 'uncategorized')`
+)
+
+// createReportViewsMySQL stores the aggregations in plain tables, as MySQL has no
+// materialized views; refreshing replaces their rows
+var createReportViewsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS report_daily_sales (
+		day DATE PRIMARY KEY,
+		orders INT NOT NULL,
+		revenue DECIMAL(12,2) NOT NULL
+	)`, `
+	CREATE TABLE IF NOT EXISTS report_item_sales (
+		day DATE NOT NULL,
+		menu_item_id INT NOT NULL,
+		name VARCHAR(200) NOT NULL,
+		category VARCHAR(50) NOT NULL,
+		orders INT NOT NULL,
+		quantity INT NOT NULL,
+		revenue DECIMAL(12,2) NOT NULL,
+		PRIMARY KEY (day, menu_item_id, name, category)
+	)`,
+	`INSERT INTO report_daily_sales (day, orders, revenue)` + dailySalesQuery,
+	`INSERT INTO report_item_sales (day, menu_item_id, name, category, orders, quantity, revenue)` + itemSalesQuery,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating report views...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createReportViewsMySQL); err != nil {
+				return fmt.Errorf("failed to create report views: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// The unique indexes allow REFRESH MATERIALIZED VIEW CONCURRENTLY, which
+		// doesn't block readers
+		_, err := db.ExecContext(ctx, `
+			CREATE MATERIALIZED VIEW IF NOT EXISTS report_daily_sales AS`+dailySalesQuery+`;
+			CREATE MATERIALIZED VIEW IF NOT EXISTS report_item_sales AS`+itemSalesQuery+`;
+
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_report_daily_sales_day ON report_daily_sales(day);
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_report_item_sales_key ON report_item_sales(day, menu_item_id, name, category);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create report views: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping report views...")
+
+		drop := "DROP MATERIALIZED VIEW IF EXISTS"
+		if database.IsMySQL(db) {
+			drop = "DROP TABLE IF EXISTS"
+		}
+		if err := execAll(ctx, db, []string{drop + " report_item_sales", drop + " report_daily_sales"}); err != nil {
+			return fmt.Errorf("failed to drop report views: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,100 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createTaxRatesMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below.
+// scope_key makes each menu item, category and the default rate unique, as MySQL has
+// no partial indexes.
+var createTaxRatesMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS tax_rates (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		rate DECIMAL(6,3) NOT NULL,
+		category VARCHAR(50) NULL,
+		menu_item_id INT NULL,
+		scope_key VARCHAR(100) AS (COALESCE(CONCAT('item:', menu_item_id), CONCAT('category:', category), 'default')) STORED,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE INDEX idx_tax_rates_scope (scope_key)
+	)`,
+	`ALTER TABLE orders
+		ADD COLUMN subtotal DECIMAL(10,2) NOT NULL DEFAULT 0,
+		ADD COLUMN tax DECIMAL(10,2) NOT NULL DEFAULT 0,
+		ADD COLUMN tax_included BOOLEAN NOT NULL DEFAULT FALSE`,
+	`UPDATE orders SET subtotal = total`,
+	`ALTER TABLE order_items
+		ADD COLUMN tax_name VARCHAR(100) NULL,
+		ADD COLUMN tax_rate DECIMAL(6,3) NOT NULL DEFAULT 0,
+		ADD COLUMN tax_amount DECIMAL(10,2) NOT NULL DEFAULT 0`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating tax_rates table and order tax columns...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createTaxRatesMySQL); err != nil {
+				return fmt.Errorf("failed to create tax rates: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// A rate applies to one menu item, to a category, or, with neither set, to
+		// every other item; each of them has at most one rate. Existing orders had no
+		// tax, so their subtotal is their total.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS tax_rates (
+				id SERIAL PRIMARY KEY,
+				name VARCHAR(100) NOT NULL,
+				rate DECIMAL(6,3) NOT NULL,
+				category VARCHAR(50) NULL,
+				menu_item_id INTEGER NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rates_menu_item_id ON tax_rates(menu_item_id) WHERE menu_item_id IS NOT NULL;
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rates_category ON tax_rates(category) WHERE category IS NOT NULL AND menu_item_id IS NULL;
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rates_default ON tax_rates((true)) WHERE category IS NULL AND menu_item_id IS NULL;
+
+			ALTER TABLE orders
+				ADD COLUMN IF NOT EXISTS subtotal DECIMAL(10,2) NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS tax DECIMAL(10,2) NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS tax_included BOOLEAN NOT NULL DEFAULT FALSE;
+			UPDATE orders SET subtotal = total;
+
+			ALTER TABLE order_items
+				ADD COLUMN IF NOT EXISTS tax_name VARCHAR(100) NULL,
+				ADD COLUMN IF NOT EXISTS tax_rate DECIMAL(6,3) NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS tax_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create tax rates: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping tax_rates table and order tax columns...")
+
+		err := execAll(ctx, db, []string{
+			`ALTER TABLE order_items DROP COLUMN tax_name, DROP COLUMN tax_rate, DROP COLUMN tax_amount`,
+			`ALTER TABLE orders DROP COLUMN subtotal, DROP COLUMN tax, DROP COLUMN tax_included`,
+			`DROP TABLE IF EXISTS tax_rates`,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop tax rates: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createMenuItemPricesMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createMenuItemPricesMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS menu_item_prices (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		menu_item_id INT NOT NULL,
+		channel VARCHAR(20) NOT NULL,
+		price DECIMAL(10,2) NOT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE INDEX idx_menu_item_prices_item_channel (menu_item_id, channel),
+		CONSTRAINT fk_menu_item_prices_item FOREIGN KEY (menu_item_id) REFERENCES menu_items(id) ON DELETE CASCADE
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating menu_item_prices table...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createMenuItemPricesMySQL); err != nil {
+				return fmt.Errorf("failed to create menu_item_prices table: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// A menu item has at most one price per order channel; the menu item's own
+		// price applies to channels without one
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS menu_item_prices (
+				id SERIAL PRIMARY KEY,
+				menu_item_id INTEGER NOT NULL REFERENCES menu_items(id) ON DELETE CASCADE,
+				channel VARCHAR(20) NOT NULL,
+				price DECIMAL(10,2) NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_menu_item_prices_item_channel ON menu_item_prices(menu_item_id, channel);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create menu_item_prices table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping menu_item_prices table...")
+
+		if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS menu_item_prices`); err != nil {
+			return fmt.Errorf("failed to drop menu_item_prices table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,84 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createPricingRulesMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createPricingRulesMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS pricing_rules (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		category VARCHAR(50) NULL,
+		menu_item_id INT NULL,
+		days VARCHAR(30) NOT NULL DEFAULT '',
+		start_time CHAR(5) NOT NULL,
+		end_time CHAR(5) NOT NULL,
+		discount_type VARCHAR(10) NOT NULL,
+		discount DECIMAL(10,2) NOT NULL,
+		is_active BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+	)`,
+	`ALTER TABLE order_items ADD COLUMN pricing_rule VARCHAR(100) NULL`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating pricing_rules table...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createPricingRulesMySQL); err != nil {
+				return fmt.Errorf("failed to create pricing rules: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// A rule discounts one menu item, a category or, with neither set, every item
+		// between start_time and end_time on the listed days (every day when empty).
+		// Order lines record the rule they were discounted by.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS pricing_rules (
+				id SERIAL PRIMARY KEY,
+				name VARCHAR(100) NOT NULL,
+				category VARCHAR(50) NULL,
+				menu_item_id INTEGER NULL,
+				days VARCHAR(30) NOT NULL DEFAULT '',
+				start_time CHAR(5) NOT NULL,
+				end_time CHAR(5) NOT NULL,
+				discount_type VARCHAR(10) NOT NULL,
+				discount DECIMAL(10,2) NOT NULL,
+				is_active BOOLEAN NOT NULL DEFAULT TRUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			ALTER TABLE order_items ADD COLUMN IF NOT EXISTS pricing_rule VARCHAR(100) NULL;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create pricing rules: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping pricing_rules table...")
+
+		err := execAll(ctx, db, []string{
+			`ALTER TABLE order_items DROP COLUMN pricing_rule`,
+			`DROP TABLE IF EXISTS pricing_rules`,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop pricing rules: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
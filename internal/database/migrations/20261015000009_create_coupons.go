@@ -0,0 +1,138 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// itemSalesNetQuery is itemSalesQuery with item revenue net of line discounts
+var itemSalesNetQuery = strings.Replace(itemSalesQuery,
+	"SUM(oi.quantity * oi.unit_price) AS revenue", "SUM(oi.quantity * oi.unit_price - oi.discount) AS revenue", 1)
+
+// createCouponsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below.
+// report_item_sales is a plain table on MySQL; its refresh query is updated in code.
+var createCouponsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS coupons (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		code VARCHAR(50) NOT NULL,
+		description TEXT NULL,
+		discount_type VARCHAR(10) NOT NULL,
+		discount DECIMAL(10,2) NOT NULL,
+		category VARCHAR(50) NULL,
+		menu_item_id INT NULL,
+		max_uses INT NULL,
+		max_uses_per_customer INT NULL,
+		times_used INT NOT NULL DEFAULT 0,
+		valid_from DATETIME(6) NULL,
+		valid_until DATETIME(6) NULL,
+		is_active BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE INDEX idx_coupons_code (code)
+	)`, `
+	CREATE TABLE IF NOT EXISTS coupon_redemptions (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		coupon_id INT NOT NULL,
+		order_id CHAR(36) NOT NULL,
+		customer_phone VARCHAR(50) NULL,
+		discount DECIMAL(10,2) NOT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE INDEX idx_coupon_redemptions_order (order_id),
+		INDEX idx_coupon_redemptions_customer (coupon_id, customer_phone),
+		CONSTRAINT fk_coupon_redemptions_coupon FOREIGN KEY (coupon_id) REFERENCES coupons(id) ON DELETE CASCADE,
+		CONSTRAINT fk_coupon_redemptions_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+	)`,
+	`ALTER TABLE orders
+		ADD COLUMN discount DECIMAL(10,2) NOT NULL DEFAULT 0,
+		ADD COLUMN coupon_code VARCHAR(50) NULL`,
+	`ALTER TABLE order_items ADD COLUMN discount DECIMAL(10,2) NOT NULL DEFAULT 0`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating coupons tables and order discount columns...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createCouponsMySQL); err != nil {
+				return fmt.Errorf("failed to create coupons: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Codes are stored upper-case. Each order redeems at most one coupon; the
+		// discount is recorded on the order and spread over its eligible lines, whose
+		// revenue in report_item_sales becomes net of it.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS coupons (
+				id SERIAL PRIMARY KEY,
+				code VARCHAR(50) NOT NULL UNIQUE,
+				description TEXT NULL,
+				discount_type VARCHAR(10) NOT NULL,
+				discount DECIMAL(10,2) NOT NULL,
+				category VARCHAR(50) NULL,
+				menu_item_id INTEGER NULL,
+				max_uses INTEGER NULL,
+				max_uses_per_customer INTEGER NULL,
+				times_used INTEGER NOT NULL DEFAULT 0,
+				valid_from TIMESTAMP WITH TIME ZONE NULL,
+				valid_until TIMESTAMP WITH TIME ZONE NULL,
+				is_active BOOLEAN NOT NULL DEFAULT TRUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE IF NOT EXISTS coupon_redemptions (
+				id SERIAL PRIMARY KEY,
+				coupon_id INTEGER NOT NULL REFERENCES coupons(id) ON DELETE CASCADE,
+				order_id UUID NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+				customer_phone VARCHAR(50) NULL,
+				discount DECIMAL(10,2) NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_coupon_redemptions_customer ON coupon_redemptions(coupon_id, customer_phone);
+
+			ALTER TABLE orders
+				ADD COLUMN IF NOT EXISTS discount DECIMAL(10,2) NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS coupon_code VARCHAR(50) NULL;
+			ALTER TABLE order_items ADD COLUMN IF NOT EXISTS discount DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+			DROP MATERIALIZED VIEW IF EXISTS report_item_sales;
+			CREATE MATERIALIZED VIEW report_item_sales AS`+itemSalesNetQuery+`;
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_report_item_sales_key ON report_item_sales(day, menu_item_id, name, category);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create coupons: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping coupons tables and order discount columns...")
+
+		var statements []string
+		if !database.IsMySQL(db) {
+			statements = append(statements,
+				`DROP MATERIALIZED VIEW IF EXISTS report_item_sales`,
+				`CREATE MATERIALIZED VIEW report_item_sales AS`+itemSalesQuery,
+				`CREATE UNIQUE INDEX IF NOT EXISTS idx_report_item_sales_key ON report_item_sales(day, menu_item_id, name, category)`)
+		}
+		statements = append(statements,
+			`ALTER TABLE order_items DROP COLUMN discount`,
+			`ALTER TABLE orders DROP COLUMN discount, DROP COLUMN coupon_code`,
+			`DROP TABLE IF EXISTS coupon_redemptions`,
+			`DROP TABLE IF EXISTS coupons`)
+		if err := execAll(ctx, db, statements); err != nil {
+			return fmt.Errorf("failed to drop coupons: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
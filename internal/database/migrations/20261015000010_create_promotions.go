@@ -0,0 +1,107 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createPromotionsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createPromotionsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS promotions (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		type VARCHAR(20) NOT NULL,
+		category VARCHAR(50) NULL,
+		menu_item_id INT NULL,
+		buy_quantity INT NOT NULL DEFAULT 0,
+		get_quantity INT NOT NULL DEFAULT 0,
+		min_spend DECIMAL(10,2) NOT NULL DEFAULT 0,
+		discount_type VARCHAR(10) NOT NULL,
+		discount DECIMAL(10,2) NOT NULL,
+		valid_from DATETIME(6) NULL,
+		valid_until DATETIME(6) NULL,
+		is_active BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+	)`, `
+	CREATE TABLE IF NOT EXISTS order_promotions (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		order_id CHAR(36) NOT NULL,
+		promotion_id INT NULL,
+		name VARCHAR(100) NOT NULL,
+		description VARCHAR(255) NOT NULL,
+		discount DECIMAL(10,2) NOT NULL,
+		INDEX idx_order_promotions_order_id (order_id),
+		CONSTRAINT fk_order_promotions_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating promotions tables...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createPromotionsMySQL); err != nil {
+				return fmt.Errorf("failed to create promotions: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// order_promotions keeps the name and explanation of each promotion applied to
+		// an order, so they survive the promotion being changed or deleted
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS promotions (
+				id SERIAL PRIMARY KEY,
+				name VARCHAR(100) NOT NULL,
+				type VARCHAR(20) NOT NULL,
+				category VARCHAR(50) NULL,
+				menu_item_id INTEGER NULL,
+				buy_quantity INTEGER NOT NULL DEFAULT 0,
+				get_quantity INTEGER NOT NULL DEFAULT 0,
+				min_spend DECIMAL(10,2) NOT NULL DEFAULT 0,
+				discount_type VARCHAR(10) NOT NULL,
+				discount DECIMAL(10,2) NOT NULL,
+				valid_from TIMESTAMP WITH TIME ZONE NULL,
+				valid_until TIMESTAMP WITH TIME ZONE NULL,
+				is_active BOOLEAN NOT NULL DEFAULT TRUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE IF NOT EXISTS order_promotions (
+				id SERIAL PRIMARY KEY,
+				order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+				promotion_id INTEGER NULL,
+				name VARCHAR(100) NOT NULL,
+				description VARCHAR(255) NOT NULL,
+				discount DECIMAL(10,2) NOT NULL
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_order_promotions_order_id ON order_promotions(order_id);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create promotions: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping promotions tables...")
+
+		err := execAll(ctx, db, []string{
+			`DROP TABLE IF EXISTS order_promotions`,
+			`DROP TABLE IF EXISTS promotions`,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop promotions: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,106 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createGiftCardsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createGiftCardsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS gift_cards (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		code VARCHAR(50) NOT NULL,
+		initial_balance DECIMAL(10,2) NOT NULL,
+		balance DECIMAL(10,2) NOT NULL,
+		recipient_name VARCHAR(100) NULL,
+		expires_at DATETIME(6) NULL,
+		is_active BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE INDEX idx_gift_cards_code (code),
+		CONSTRAINT chk_gift_cards_balance CHECK (balance >= 0)
+	)`, `
+	CREATE TABLE IF NOT EXISTS gift_card_transactions (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		gift_card_id INT NOT NULL,
+		order_id CHAR(36) NULL,
+		type VARCHAR(10) NOT NULL,
+		amount DECIMAL(10,2) NOT NULL,
+		balance DECIMAL(10,2) NOT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		INDEX idx_gift_card_transactions_card (gift_card_id, created_at),
+		INDEX idx_gift_card_transactions_order (order_id),
+		CONSTRAINT fk_gift_card_transactions_card FOREIGN KEY (gift_card_id) REFERENCES gift_cards(id) ON DELETE CASCADE,
+		CONSTRAINT fk_gift_card_transactions_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE SET NULL
+	)`,
+	`ALTER TABLE orders ADD COLUMN amount_paid DECIMAL(10,2) NOT NULL DEFAULT 0`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating gift cards tables and order amount paid column...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createGiftCardsMySQL); err != nil {
+				return fmt.Errorf("failed to create gift cards: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Every change to a balance is recorded as a transaction with the balance after
+		// it; redemptions paying an order add to its amount_paid, which may not exceed
+		// its total
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS gift_cards (
+				id SERIAL PRIMARY KEY,
+				code VARCHAR(50) NOT NULL UNIQUE,
+				initial_balance DECIMAL(10,2) NOT NULL,
+				balance DECIMAL(10,2) NOT NULL CHECK (balance >= 0),
+				recipient_name VARCHAR(100) NULL,
+				expires_at TIMESTAMP WITH TIME ZONE NULL,
+				is_active BOOLEAN NOT NULL DEFAULT TRUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE IF NOT EXISTS gift_card_transactions (
+				id SERIAL PRIMARY KEY,
+				gift_card_id INTEGER NOT NULL REFERENCES gift_cards(id) ON DELETE CASCADE,
+				order_id UUID NULL REFERENCES orders(id) ON DELETE SET NULL,
+				type VARCHAR(10) NOT NULL,
+				amount DECIMAL(10,2) NOT NULL,
+				balance DECIMAL(10,2) NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_gift_card_transactions_card ON gift_card_transactions(gift_card_id, created_at);
+			CREATE INDEX IF NOT EXISTS idx_gift_card_transactions_order ON gift_card_transactions(order_id);
+
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS amount_paid DECIMAL(10,2) NOT NULL DEFAULT 0;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create gift cards: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping gift cards tables and order amount paid column...")
+
+		if err := execAll(ctx, db, []string{
+			`ALTER TABLE orders DROP COLUMN amount_paid`,
+			`DROP TABLE IF EXISTS gift_card_transactions`,
+			`DROP TABLE IF EXISTS gift_cards`,
+		}); err != nil {
+			return fmt.Errorf("failed to drop gift cards: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
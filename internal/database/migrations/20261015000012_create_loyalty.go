@@ -0,0 +1,127 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createLoyaltyMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createLoyaltyMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS order_payments (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		order_id CHAR(36) NOT NULL,
+		method VARCHAR(20) NOT NULL,
+		amount DECIMAL(10,2) NOT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		INDEX idx_order_payments_order (order_id),
+		CONSTRAINT fk_order_payments_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+	)`, `
+	CREATE TABLE IF NOT EXISTS loyalty_accounts (
+		customer_phone VARCHAR(50) PRIMARY KEY,
+		points INT NOT NULL DEFAULT 0,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		CONSTRAINT chk_loyalty_accounts_points CHECK (points >= 0)
+	)`, `
+	CREATE TABLE IF NOT EXISTS loyalty_transactions (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		customer_phone VARCHAR(50) NOT NULL,
+		order_id CHAR(36) NULL,
+		type VARCHAR(10) NOT NULL,
+		points INT NOT NULL,
+		balance INT NOT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		INDEX idx_loyalty_transactions_customer (customer_phone, created_at),
+		UNIQUE INDEX idx_loyalty_transactions_order (order_id, type),
+		CONSTRAINT fk_loyalty_transactions_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE SET NULL
+	)`,
+	`ALTER TABLE orders
+		ADD COLUMN loyalty_points INT NOT NULL DEFAULT 0,
+		ADD COLUMN loyalty_discount DECIMAL(10,2) NOT NULL DEFAULT 0`,
+	backfillGiftCardPayments,
+}
+
+// backfillGiftCardPayments records the gift card redemptions that paid orders as
+// order payments
+const backfillGiftCardPayments = `
+	INSERT INTO order_payments (order_id, method, amount, created_at)
+	SELECT order_id, 'gift_card', -amount, created_at
+	FROM gift_card_transactions
+	WHERE order_id IS NOT NULL AND type = 'redeem'`
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating order payments and loyalty tables...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createLoyaltyMySQL); err != nil {
+				return fmt.Errorf("failed to create loyalty tables: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Customers are told apart by phone number. Points are earned once per order,
+		// when it is fully paid, and redeemed at most once per order, as a discount.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS order_payments (
+				id SERIAL PRIMARY KEY,
+				order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+				method VARCHAR(20) NOT NULL,
+				amount DECIMAL(10,2) NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_order_payments_order ON order_payments(order_id);
+
+			CREATE TABLE IF NOT EXISTS loyalty_accounts (
+				customer_phone VARCHAR(50) PRIMARY KEY,
+				points INTEGER NOT NULL DEFAULT 0 CHECK (points >= 0),
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE IF NOT EXISTS loyalty_transactions (
+				id SERIAL PRIMARY KEY,
+				customer_phone VARCHAR(50) NOT NULL,
+				order_id UUID NULL REFERENCES orders(id) ON DELETE SET NULL,
+				type VARCHAR(10) NOT NULL,
+				points INTEGER NOT NULL,
+				balance INTEGER NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				UNIQUE (order_id, type)
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_loyalty_transactions_customer ON loyalty_transactions(customer_phone, created_at);
+
+			ALTER TABLE orders
+				ADD COLUMN IF NOT EXISTS loyalty_points INTEGER NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS loyalty_discount DECIMAL(10,2) NOT NULL DEFAULT 0;
+		`+backfillGiftCardPayments+`;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create loyalty tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping order payments and loyalty tables...")
+
+		if err := execAll(ctx, db, []string{
+			`ALTER TABLE orders DROP COLUMN loyalty_points, DROP COLUMN loyalty_discount`,
+			`DROP TABLE IF EXISTS loyalty_transactions`,
+			`DROP TABLE IF EXISTS loyalty_accounts`,
+			`DROP TABLE IF EXISTS order_payments`,
+		}); err != nil {
+			return fmt.Errorf("failed to drop loyalty tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,96 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createStoreCreditMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createStoreCreditMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS store_credit_accounts (
+		customer_phone VARCHAR(50) PRIMARY KEY,
+		balance DECIMAL(10,2) NOT NULL DEFAULT 0,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		CONSTRAINT chk_store_credit_accounts_balance CHECK (balance >= 0)
+	)`, `
+	CREATE TABLE IF NOT EXISTS store_credit_entries (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		customer_phone VARCHAR(50) NOT NULL,
+		type VARCHAR(10) NOT NULL,
+		reason VARCHAR(20) NOT NULL,
+		amount DECIMAL(10,2) NOT NULL,
+		balance DECIMAL(10,2) NOT NULL,
+		order_id CHAR(36) NULL,
+		note TEXT NULL,
+		request_id VARCHAR(64) NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		INDEX idx_store_credit_entries_customer (customer_phone, created_at),
+		INDEX idx_store_credit_entries_order (order_id),
+		CONSTRAINT fk_store_credit_entries_account FOREIGN KEY (customer_phone) REFERENCES store_credit_accounts(customer_phone),
+		CONSTRAINT fk_store_credit_entries_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE SET NULL
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating store credit tables...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createStoreCreditMySQL); err != nil {
+				return fmt.Errorf("failed to create store credit tables: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Entries are never updated or deleted: every change to a balance is an entry
+		// with the balance after it, the order it concerns and the request that made it
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS store_credit_accounts (
+				customer_phone VARCHAR(50) PRIMARY KEY,
+				balance DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (balance >= 0),
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE IF NOT EXISTS store_credit_entries (
+				id SERIAL PRIMARY KEY,
+				customer_phone VARCHAR(50) NOT NULL REFERENCES store_credit_accounts(customer_phone),
+				type VARCHAR(10) NOT NULL,
+				reason VARCHAR(20) NOT NULL,
+				amount DECIMAL(10,2) NOT NULL,
+				balance DECIMAL(10,2) NOT NULL,
+				order_id UUID NULL REFERENCES orders(id) ON DELETE SET NULL,
+				note TEXT NULL,
+				request_id VARCHAR(64) NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_store_credit_entries_customer ON store_credit_entries(customer_phone, created_at);
+			CREATE INDEX IF NOT EXISTS idx_store_credit_entries_order ON store_credit_entries(order_id);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create store credit tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping store credit tables...")
+
+		if err := execAll(ctx, db, []string{
+			`DROP TABLE IF EXISTS store_credit_entries`,
+			`DROP TABLE IF EXISTS store_credit_accounts`,
+		}); err != nil {
+			return fmt.Errorf("failed to drop store credit tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
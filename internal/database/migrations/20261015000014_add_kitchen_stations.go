@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// addKitchenStationsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var addKitchenStationsMySQL = []string{
+	`ALTER TABLE menu_items ADD COLUMN station VARCHAR(30) NULL`,
+	`ALTER TABLE order_items ADD COLUMN station VARCHAR(30) NULL`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] adding kitchen station columns...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, addKitchenStationsMySQL); err != nil {
+				return fmt.Errorf("failed to add kitchen stations: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Menu items without a station are prepared at their category's station. Order
+		// lines keep the station they were routed to when the order was placed; older
+		// lines have none and are shown on the default station's ticket.
+		_, err := db.ExecContext(ctx, `
+			ALTER TABLE menu_items ADD COLUMN IF NOT EXISTS station VARCHAR(30) NULL;
+			ALTER TABLE order_items ADD COLUMN IF NOT EXISTS station VARCHAR(30) NULL;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to add kitchen stations: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping kitchen station columns...")
+
+		err := execAll(ctx, db, []string{
+			`ALTER TABLE order_items DROP COLUMN station`,
+			`ALTER TABLE menu_items DROP COLUMN station`,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop kitchen stations: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// addPrepTimesMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var addPrepTimesMySQL = []string{
+	`ALTER TABLE menu_items ADD COLUMN prep_minutes INT NULL`,
+	`ALTER TABLE order_items
+		ADD COLUMN prep_minutes INT NOT NULL DEFAULT 0,
+		ADD COLUMN prepared_at DATETIME(6) NULL`,
+	`ALTER TABLE orders ADD COLUMN estimated_ready_at DATETIME(6) NULL`,
+	`CREATE INDEX idx_orders_estimated_ready_at ON orders(estimated_ready_at)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] adding prep time and ready estimate columns...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, addPrepTimesMySQL); err != nil {
+				return fmt.Errorf("failed to add prep times: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Existing orders have no estimate, so they are not counted as queued in the
+		// kitchen
+		_, err := db.ExecContext(ctx, `
+			ALTER TABLE menu_items ADD COLUMN IF NOT EXISTS prep_minutes INTEGER NULL;
+
+			ALTER TABLE order_items
+				ADD COLUMN IF NOT EXISTS prep_minutes INTEGER NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS prepared_at TIMESTAMP WITH TIME ZONE NULL;
+
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS estimated_ready_at TIMESTAMP WITH TIME ZONE NULL;
+			CREATE INDEX IF NOT EXISTS idx_orders_estimated_ready_at ON orders(estimated_ready_at);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to add prep times: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping prep time and ready estimate columns...")
+
+		err := execAll(ctx, db, []string{
+			`ALTER TABLE orders DROP COLUMN estimated_ready_at`,
+			`ALTER TABLE order_items DROP COLUMN prep_minutes, DROP COLUMN prepared_at`,
+			`ALTER TABLE menu_items DROP COLUMN prep_minutes`,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop prep times: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
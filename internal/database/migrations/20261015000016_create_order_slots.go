@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createOrderSlotsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createOrderSlotsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS order_slots (
+		slot_at DATETIME(6) PRIMARY KEY,
+		orders INT NOT NULL DEFAULT 0,
+		CONSTRAINT chk_order_slots_orders CHECK (orders >= 0)
+	)`,
+	`ALTER TABLE orders ADD COLUMN slot_at DATETIME(6) NULL`,
+	`CREATE INDEX idx_orders_slot_at ON orders(slot_at)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating order_slots table and order slot column...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createOrderSlotsMySQL); err != nil {
+				return fmt.Errorf("failed to create order slots: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// order_slots counts the online orders of each time slot, so the count can be
+		// checked against the slot's capacity and incremented in one statement
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS order_slots (
+				slot_at TIMESTAMP WITH TIME ZONE PRIMARY KEY,
+				orders INTEGER NOT NULL DEFAULT 0,
+				CONSTRAINT chk_order_slots_orders CHECK (orders >= 0)
+			);
+
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS slot_at TIMESTAMP WITH TIME ZONE NULL;
+			CREATE INDEX IF NOT EXISTS idx_orders_slot_at ON orders(slot_at);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create order slots: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping order_slots table and order slot column...")
+
+		err := execAll(ctx, db, []string{
+			`ALTER TABLE orders DROP COLUMN slot_at`,
+			`DROP TABLE IF EXISTS order_slots`,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop order slots: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// addOrderNumbersMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var addOrderNumbersMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS order_numbers (
+		business_day DATE PRIMARY KEY,
+		last_number INT NOT NULL DEFAULT 0
+	)`,
+	`ALTER TABLE orders ADD COLUMN business_day DATE NULL, ADD COLUMN number INT NULL`,
+	`CREATE UNIQUE INDEX uq_orders_business_day_number ON orders(business_day, number)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating order_numbers table and order number columns...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, addOrderNumbersMySQL); err != nil {
+				return fmt.Errorf("failed to add order numbers: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// order_numbers holds the last order number given on each business day. Orders
+		// placed before numbering was introduced have none.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS order_numbers (
+				business_day DATE PRIMARY KEY,
+				last_number INTEGER NOT NULL DEFAULT 0
+			);
+
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS business_day DATE NULL;
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS number INTEGER NULL;
+			CREATE UNIQUE INDEX IF NOT EXISTS uq_orders_business_day_number ON orders(business_day, number);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to add order numbers: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping order_numbers table and order number columns...")
+
+		err := execAll(ctx, db, []string{
+			`ALTER TABLE orders DROP COLUMN number`,
+			`ALTER TABLE orders DROP COLUMN business_day`,
+			`DROP TABLE IF EXISTS order_numbers`,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop order numbers: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,109 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createDiningTablesMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createDiningTablesMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS dining_tables (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(50) NOT NULL,
+		token VARCHAR(64) NOT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE INDEX idx_dining_tables_name (name),
+		UNIQUE INDEX idx_dining_tables_token (token)
+	)`, `
+	CREATE TABLE IF NOT EXISTS guest_sessions (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		token VARCHAR(64) NOT NULL,
+		table_id INT NOT NULL,
+		expires_at DATETIME(6) NOT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE INDEX idx_guest_sessions_token (token),
+		CONSTRAINT fk_guest_sessions_table FOREIGN KEY (table_id) REFERENCES dining_tables(id) ON DELETE CASCADE
+	)`,
+	`ALTER TABLE orders
+		ADD COLUMN table_id INT NULL,
+		ADD COLUMN table_name VARCHAR(50) NULL,
+		ADD COLUMN guest_session_id INT NULL`,
+	`ALTER TABLE orders
+		ADD CONSTRAINT fk_orders_table FOREIGN KEY (table_id) REFERENCES dining_tables(id) ON DELETE SET NULL,
+		ADD CONSTRAINT fk_orders_guest_session FOREIGN KEY (guest_session_id) REFERENCES guest_sessions(id) ON DELETE SET NULL`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating dining_tables and guest_sessions tables...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createDiningTablesMySQL); err != nil {
+				return fmt.Errorf("failed to create dining tables: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// A table's token is encoded in the QR code on it; scanning it starts a guest
+		// session whose token orders for the table until it expires. Orders keep the
+		// name of their table when it is renamed or deleted.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS dining_tables (
+				id SERIAL PRIMARY KEY,
+				name VARCHAR(50) NOT NULL UNIQUE,
+				token VARCHAR(64) NOT NULL UNIQUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE IF NOT EXISTS guest_sessions (
+				id SERIAL PRIMARY KEY,
+				token VARCHAR(64) NOT NULL UNIQUE,
+				table_id INTEGER NOT NULL REFERENCES dining_tables(id) ON DELETE CASCADE,
+				expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS table_id INTEGER NULL REFERENCES dining_tables(id) ON DELETE SET NULL;
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS table_name VARCHAR(50) NULL;
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS guest_session_id INTEGER NULL REFERENCES guest_sessions(id) ON DELETE SET NULL;
+			CREATE INDEX IF NOT EXISTS idx_orders_guest_session_id ON orders(guest_session_id);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create dining tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping dining_tables and guest_sessions tables...")
+
+		var err error
+		if database.IsMySQL(db) {
+			err = execAll(ctx, db, []string{
+				`ALTER TABLE orders DROP FOREIGN KEY fk_orders_table, DROP FOREIGN KEY fk_orders_guest_session`,
+				`ALTER TABLE orders DROP COLUMN table_id, DROP COLUMN table_name, DROP COLUMN guest_session_id`,
+				`DROP TABLE IF EXISTS guest_sessions`,
+				`DROP TABLE IF EXISTS dining_tables`,
+			})
+		} else {
+			err = execAll(ctx, db, []string{
+				`ALTER TABLE orders DROP COLUMN IF EXISTS table_id, DROP COLUMN IF EXISTS table_name, DROP COLUMN IF EXISTS guest_session_id`,
+				`DROP TABLE IF EXISTS guest_sessions`,
+				`DROP TABLE IF EXISTS dining_tables`,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to drop dining tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
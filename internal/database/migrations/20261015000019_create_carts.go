@@ -0,0 +1,108 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createCartsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createCartsMySQL = []string{
+	`ALTER TABLE orders ADD COLUMN tip DECIMAL(10,2) NOT NULL DEFAULT 0`, `
+	CREATE TABLE IF NOT EXISTS carts (
+		id CHAR(36) PRIMARY KEY,
+		channel VARCHAR(20) NOT NULL,
+		customer_name VARCHAR(200) NULL,
+		customer_phone VARCHAR(50) NULL,
+		notes TEXT NULL,
+		coupon_code VARCHAR(50) NULL,
+		tip DECIMAL(10,2) NULL,
+		tip_percent DECIMAL(5,2) NULL,
+		order_id CHAR(36) NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		CONSTRAINT fk_carts_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE SET NULL
+	)`, `
+	CREATE TABLE IF NOT EXISTS cart_items (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		cart_id CHAR(36) NOT NULL,
+		menu_item_id INT NOT NULL,
+		name VARCHAR(200) NOT NULL,
+		quantity INT NOT NULL,
+		notes TEXT NULL,
+		unit_price DECIMAL(10,2) NOT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		INDEX idx_cart_items_cart (cart_id),
+		CONSTRAINT fk_cart_items_cart FOREIGN KEY (cart_id) REFERENCES carts(id) ON DELETE CASCADE,
+		CONSTRAINT chk_cart_items_quantity CHECK (quantity > 0)
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating carts tables and order tip column...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createCartsMySQL); err != nil {
+				return fmt.Errorf("failed to create carts: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Tips are paid on top of an order's total and aren't part of its revenue. A
+		// cart line keeps the price the customer was shown, checked again at checkout.
+		_, err := db.ExecContext(ctx, `
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS tip DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+			CREATE TABLE IF NOT EXISTS carts (
+				id UUID PRIMARY KEY,
+				channel VARCHAR(20) NOT NULL,
+				customer_name VARCHAR(200) NULL,
+				customer_phone VARCHAR(50) NULL,
+				notes TEXT NULL,
+				coupon_code VARCHAR(50) NULL,
+				tip DECIMAL(10,2) NULL,
+				tip_percent DECIMAL(5,2) NULL,
+				order_id UUID NULL REFERENCES orders(id) ON DELETE SET NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE IF NOT EXISTS cart_items (
+				id SERIAL PRIMARY KEY,
+				cart_id UUID NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+				menu_item_id INTEGER NOT NULL,
+				name VARCHAR(200) NOT NULL,
+				quantity INTEGER NOT NULL CHECK (quantity > 0),
+				notes TEXT NULL,
+				unit_price DECIMAL(10,2) NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+			CREATE INDEX IF NOT EXISTS idx_cart_items_cart ON cart_items(cart_id);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create carts: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping carts tables and order tip column...")
+
+		err := execAll(ctx, db, []string{
+			`DROP TABLE IF EXISTS cart_items`,
+			`DROP TABLE IF EXISTS carts`,
+			`ALTER TABLE orders DROP COLUMN tip`,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop carts: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
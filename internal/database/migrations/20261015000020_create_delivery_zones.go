@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createDeliveryZonesMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createDeliveryZonesMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS delivery_zones (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		center_lat DOUBLE NULL,
+		center_lng DOUBLE NULL,
+		radius_meters INT NULL,
+		polygon TEXT NULL,
+		fee DECIMAL(10,2) NOT NULL DEFAULT 0,
+		minimum_order DECIMAL(10,2) NOT NULL DEFAULT 0,
+		is_active BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE INDEX idx_delivery_zones_name (name)
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating delivery_zones table...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createDeliveryZonesMySQL); err != nil {
+				return fmt.Errorf("failed to create delivery_zones table: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// A zone is a circle of radius_meters around its center, or a polygon stored as a
+		// JSON array of points. Zones are few, so addresses are matched against them in
+		// the application rather than with a spatial index.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS delivery_zones (
+				id SERIAL PRIMARY KEY,
+				name VARCHAR(100) NOT NULL UNIQUE,
+				center_lat DOUBLE PRECISION NULL,
+				center_lng DOUBLE PRECISION NULL,
+				radius_meters INTEGER NULL,
+				polygon TEXT NULL,
+				fee DECIMAL(10,2) NOT NULL DEFAULT 0,
+				minimum_order DECIMAL(10,2) NOT NULL DEFAULT 0,
+				is_active BOOLEAN NOT NULL DEFAULT TRUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create delivery_zones table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping delivery_zones table...")
+
+		if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS delivery_zones`); err != nil {
+			return fmt.Errorf("failed to drop delivery_zones table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
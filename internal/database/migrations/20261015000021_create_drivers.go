@@ -0,0 +1,93 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createDriversMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createDriversMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS drivers (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		phone VARCHAR(50) NULL,
+		is_active BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+	)`,
+	`ALTER TABLE orders
+		ADD COLUMN driver_id INT NULL,
+		ADD COLUMN delivery_status VARCHAR(20) NULL,
+		ADD COLUMN assigned_at DATETIME(6) NULL,
+		ADD COLUMN picked_up_at DATETIME(6) NULL,
+		ADD COLUMN delivered_at DATETIME(6) NULL`,
+	`ALTER TABLE orders
+		ADD CONSTRAINT fk_orders_driver FOREIGN KEY (driver_id) REFERENCES drivers(id) ON DELETE SET NULL`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating drivers table and order delivery columns...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createDriversMySQL); err != nil {
+				return fmt.Errorf("failed to create drivers table: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Delivery orders move from assigned to picked_up to delivered once a driver is
+		// assigned, recording when each step happened. The foreign key on driver_id
+		// also indexes it in MySQL; PostgreSQL gets an explicit index.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS drivers (
+				id SERIAL PRIMARY KEY,
+				name VARCHAR(100) NOT NULL,
+				phone VARCHAR(50) NULL,
+				is_active BOOLEAN NOT NULL DEFAULT TRUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS driver_id INTEGER NULL REFERENCES drivers(id) ON DELETE SET NULL;
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_status VARCHAR(20) NULL;
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP WITH TIME ZONE NULL;
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS picked_up_at TIMESTAMP WITH TIME ZONE NULL;
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP WITH TIME ZONE NULL;
+			CREATE INDEX IF NOT EXISTS idx_orders_driver_id ON orders(driver_id);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create drivers table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping drivers table and order delivery columns...")
+
+		var err error
+		if database.IsMySQL(db) {
+			err = execAll(ctx, db, []string{
+				`ALTER TABLE orders DROP FOREIGN KEY fk_orders_driver`,
+				`ALTER TABLE orders DROP COLUMN driver_id, DROP COLUMN delivery_status, DROP COLUMN assigned_at, DROP COLUMN picked_up_at, DROP COLUMN delivered_at`,
+				`DROP TABLE IF EXISTS drivers`,
+			})
+		} else {
+			err = execAll(ctx, db, []string{
+				`ALTER TABLE orders DROP COLUMN IF EXISTS driver_id, DROP COLUMN IF EXISTS delivery_status, DROP COLUMN IF EXISTS assigned_at, DROP COLUMN IF EXISTS picked_up_at, DROP COLUMN IF EXISTS delivered_at`,
+				`DROP TABLE IF EXISTS drivers`,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to drop drivers table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// emptyFloorPlan is the layout of the floor plan before it is first saved
+const emptyFloorPlan = `{"width":0,"height":0,"sections":[],"tables":[]}`
+
+// createFloorPlanMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createFloorPlanMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS floor_plans (
+		id INT PRIMARY KEY,
+		layout TEXT NOT NULL,
+		version INT NOT NULL DEFAULT 0,
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+	)`,
+	`INSERT IGNORE INTO floor_plans (id, layout, version) VALUES (1, '` + emptyFloorPlan + `', 0)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating floor_plans table...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createFloorPlanMySQL); err != nil {
+				return fmt.Errorf("failed to create floor_plans table: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// The restaurant has one floor plan, row 1, saved whole as a JSON layout of its
+		// sections and where its tables stand. Each save bumps the version, so two host
+		// stands editing the plan at once don't overwrite each other.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS floor_plans (
+				id INTEGER PRIMARY KEY,
+				layout TEXT NOT NULL,
+				version INTEGER NOT NULL DEFAULT 0,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			INSERT INTO floor_plans (id, layout, version) VALUES (1, '`+emptyFloorPlan+`', 0) ON CONFLICT (id) DO NOTHING;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create floor_plans table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping floor_plans table...")
+
+		if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS floor_plans`); err != nil {
+			return fmt.Errorf("failed to drop floor_plans table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// addTableStatusMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var addTableStatusMySQL = []string{
+	`ALTER TABLE dining_tables
+		ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'free',
+		ADD COLUMN status_changed_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] adding dining table status columns...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, addTableStatusMySQL); err != nil {
+				return fmt.Errorf("failed to add table status: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Existing tables start free, as if their status was set when the column was
+		// added
+		_, err := db.ExecContext(ctx, `
+			ALTER TABLE dining_tables
+				ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'free',
+				ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to add table status: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping dining table status columns...")
+
+		if _, err := db.ExecContext(ctx, `ALTER TABLE dining_tables DROP COLUMN status, DROP COLUMN status_changed_at`); err != nil {
+			return fmt.Errorf("failed to drop table status: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,97 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createStaffShiftsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createStaffShiftsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS staff (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		role VARCHAR(50) NOT NULL,
+		phone VARCHAR(50) NULL,
+		is_active BOOLEAN NOT NULL DEFAULT TRUE,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+	)`, `
+	CREATE TABLE IF NOT EXISTS shifts (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		staff_id INT NOT NULL,
+		role VARCHAR(50) NOT NULL,
+		starts_at DATETIME(6) NOT NULL,
+		ends_at DATETIME(6) NOT NULL,
+		notes TEXT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		INDEX idx_shifts_staff_starts_at (staff_id, starts_at),
+		INDEX idx_shifts_starts_at (starts_at),
+		CONSTRAINT fk_shifts_staff FOREIGN KEY (staff_id) REFERENCES staff(id) ON DELETE CASCADE
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating staff and shifts tables...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createStaffShiftsMySQL); err != nil {
+				return fmt.Errorf("failed to create staff tables: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// A shift is scheduled for one staff member in a role, which defaults to theirs;
+		// a staff member's shifts don't overlap. The schedule is read by start time.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS staff (
+				id SERIAL PRIMARY KEY,
+				name VARCHAR(100) NOT NULL,
+				role VARCHAR(50) NOT NULL,
+				phone VARCHAR(50) NULL,
+				is_active BOOLEAN NOT NULL DEFAULT TRUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE IF NOT EXISTS shifts (
+				id SERIAL PRIMARY KEY,
+				staff_id INTEGER NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+				role VARCHAR(50) NOT NULL,
+				starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				notes TEXT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_shifts_staff_starts_at ON shifts(staff_id, starts_at);
+			CREATE INDEX IF NOT EXISTS idx_shifts_starts_at ON shifts(starts_at);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create staff tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping staff and shifts tables...")
+
+		err := execAll(ctx, db, []string{
+			`DROP TABLE IF EXISTS shifts`,
+			`DROP TABLE IF EXISTS staff`,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop staff tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createTimePunchesMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema
+// below; it has no partial indexes, so only the service keeps a staff member from
+// being clocked in twice
+var createTimePunchesMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS time_punches (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		staff_id INT NOT NULL,
+		shift_id INT NULL,
+		clock_in_at DATETIME(6) NOT NULL,
+		clock_out_at DATETIME(6) NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		INDEX idx_time_punches_staff_clock_in_at (staff_id, clock_in_at),
+		INDEX idx_time_punches_clock_in_at (clock_in_at),
+		CONSTRAINT fk_time_punches_staff FOREIGN KEY (staff_id) REFERENCES staff(id),
+		CONSTRAINT fk_time_punches_shift FOREIGN KEY (shift_id) REFERENCES shifts(id) ON DELETE SET NULL
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating time_punches table...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createTimePunchesMySQL); err != nil {
+				return fmt.Errorf("failed to create time_punches table: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// A punch is a staff member's time on the clock, for the shift they clocked in
+		// for unless they worked unscheduled. Punches are payroll records, so staff
+		// who have them can't be deleted; a staff member has at most one open punch.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS time_punches (
+				id SERIAL PRIMARY KEY,
+				staff_id INTEGER NOT NULL REFERENCES staff(id),
+				shift_id INTEGER NULL REFERENCES shifts(id) ON DELETE SET NULL,
+				clock_in_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				clock_out_at TIMESTAMP WITH TIME ZONE NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_time_punches_staff_clock_in_at ON time_punches(staff_id, clock_in_at);
+			CREATE INDEX IF NOT EXISTS idx_time_punches_clock_in_at ON time_punches(clock_in_at);
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_time_punches_open ON time_punches(staff_id) WHERE clock_out_at IS NULL;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create time_punches table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping time_punches table...")
+
+		if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS time_punches`); err != nil {
+			return fmt.Errorf("failed to drop time_punches table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,88 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createMenuItemReviewsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createMenuItemReviewsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS menu_item_reviews (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		menu_item_id INT NOT NULL,
+		order_id CHAR(36) NULL,
+		rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+		comment TEXT NULL,
+		author_name VARCHAR(100) NULL,
+		status VARCHAR(20) NOT NULL,
+		flags TEXT NULL,
+		client_ip VARCHAR(45) NULL,
+		moderated_at DATETIME(6) NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE KEY uq_menu_item_reviews_order (order_id, menu_item_id),
+		INDEX idx_menu_item_reviews_item_status (menu_item_id, status, created_at),
+		INDEX idx_menu_item_reviews_status (status, created_at),
+		INDEX idx_menu_item_reviews_client_ip (client_ip, created_at),
+		CONSTRAINT fk_menu_item_reviews_item FOREIGN KEY (menu_item_id) REFERENCES menu_items(id) ON DELETE CASCADE,
+		CONSTRAINT fk_menu_item_reviews_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE SET NULL
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating menu_item_reviews table...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createMenuItemReviewsMySQL); err != nil {
+				return fmt.Errorf("failed to create menu_item_reviews table: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// A review rates a menu item from 1 to 5, once per order when it references
+		// one. Flagged reviews wait for a manager as pending; the others are approved
+		// right away. The client IP is kept to spot repeated reviews.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS menu_item_reviews (
+				id SERIAL PRIMARY KEY,
+				menu_item_id INTEGER NOT NULL REFERENCES menu_items(id) ON DELETE CASCADE,
+				order_id UUID NULL REFERENCES orders(id) ON DELETE SET NULL,
+				rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+				comment TEXT NULL,
+				author_name VARCHAR(100) NULL,
+				status VARCHAR(20) NOT NULL,
+				flags TEXT NULL,
+				client_ip VARCHAR(45) NULL,
+				moderated_at TIMESTAMP WITH TIME ZONE NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				UNIQUE (order_id, menu_item_id)
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_menu_item_reviews_item_status ON menu_item_reviews(menu_item_id, status, created_at);
+			CREATE INDEX IF NOT EXISTS idx_menu_item_reviews_status ON menu_item_reviews(status, created_at);
+			CREATE INDEX IF NOT EXISTS idx_menu_item_reviews_client_ip ON menu_item_reviews(client_ip, created_at);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create menu_item_reviews table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping menu_item_reviews table...")
+
+		if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS menu_item_reviews`); err != nil {
+			return fmt.Errorf("failed to drop menu_item_reviews table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
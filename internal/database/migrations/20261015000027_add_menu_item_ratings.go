@@ -0,0 +1,73 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// addMenuItemRatingsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var addMenuItemRatingsMySQL = []string{
+	`ALTER TABLE menu_items
+		ADD COLUMN review_count INT NOT NULL DEFAULT 0,
+		ADD COLUMN rating_total INT NOT NULL DEFAULT 0`,
+	`UPDATE menu_items mi
+		JOIN (
+			SELECT menu_item_id, COUNT(*) AS review_count, SUM(rating) AS rating_total
+			FROM menu_item_reviews
+			WHERE status = 'approved'
+			GROUP BY menu_item_id
+		) r ON r.menu_item_id = mi.id
+		SET mi.review_count = r.review_count, mi.rating_total = r.rating_total`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] adding menu item rating columns...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, addMenuItemRatingsMySQL); err != nil {
+				return fmt.Errorf("failed to add menu item ratings: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// The number of approved reviews of an item and the sum of their ratings, kept
+		// up to date as reviews are approved, rejected and deleted so item reads don't
+		// aggregate the reviews. Existing reviews are counted once here.
+		_, err := db.ExecContext(ctx, `
+			ALTER TABLE menu_items
+				ADD COLUMN IF NOT EXISTS review_count INTEGER NOT NULL DEFAULT 0,
+				ADD COLUMN IF NOT EXISTS rating_total INTEGER NOT NULL DEFAULT 0;
+
+			UPDATE menu_items mi
+			SET review_count = r.review_count, rating_total = r.rating_total
+			FROM (
+				SELECT menu_item_id, COUNT(*) AS review_count, SUM(rating) AS rating_total
+				FROM menu_item_reviews
+				WHERE status = 'approved'
+				GROUP BY menu_item_id
+			) r
+			WHERE r.menu_item_id = mi.id;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to add menu item ratings: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping menu item rating columns...")
+
+		if _, err := db.ExecContext(ctx, `ALTER TABLE menu_items DROP COLUMN review_count, DROP COLUMN rating_total`); err != nil {
+			return fmt.Errorf("failed to drop menu item ratings: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,120 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createPriceExperimentsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createPriceExperimentsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS price_experiments (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		menu_item_id INT NOT NULL,
+		starts_at DATETIME(6) NOT NULL,
+		ends_at DATETIME(6) NOT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		INDEX idx_price_experiments_item (menu_item_id, starts_at),
+		INDEX idx_price_experiments_window (starts_at, ends_at),
+		CONSTRAINT fk_price_experiments_item FOREIGN KEY (menu_item_id) REFERENCES menu_items(id) ON DELETE CASCADE
+	)`, `
+	CREATE TABLE IF NOT EXISTS price_experiment_variants (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		experiment_id INT NOT NULL,
+		name VARCHAR(50) NOT NULL,
+		price DECIMAL(10,2) NOT NULL,
+		weight INT NOT NULL,
+		UNIQUE KEY uq_price_experiment_variants_name (experiment_id, name),
+		CONSTRAINT fk_price_experiment_variants_experiment FOREIGN KEY (experiment_id) REFERENCES price_experiments(id) ON DELETE CASCADE
+	)`, `
+	CREATE TABLE IF NOT EXISTS price_experiment_exposures (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		experiment_id INT NOT NULL,
+		variant_id INT NOT NULL,
+		guest_session_id INT NOT NULL,
+		shown_at DATETIME(6) NOT NULL,
+		UNIQUE KEY uq_price_experiment_exposures_session (experiment_id, guest_session_id),
+		INDEX idx_price_experiment_exposures_variant (variant_id),
+		INDEX idx_price_experiment_exposures_guest_session (guest_session_id),
+		CONSTRAINT fk_price_experiment_exposures_experiment FOREIGN KEY (experiment_id) REFERENCES price_experiments(id) ON DELETE CASCADE,
+		CONSTRAINT fk_price_experiment_exposures_variant FOREIGN KEY (variant_id) REFERENCES price_experiment_variants(id) ON DELETE CASCADE,
+		CONSTRAINT fk_price_experiment_exposures_guest_session FOREIGN KEY (guest_session_id) REFERENCES guest_sessions(id) ON DELETE CASCADE
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating price experiment tables...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createPriceExperimentsMySQL); err != nil {
+				return fmt.Errorf("failed to create price experiment tables: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// An experiment splits the guest sessions viewing the menu between the variant
+		// prices of one menu item while it runs. The variant each session was shown is
+		// recorded once, for joining with the session's orders.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS price_experiments (
+				id SERIAL PRIMARY KEY,
+				name VARCHAR(100) NOT NULL,
+				menu_item_id INTEGER NOT NULL REFERENCES menu_items(id) ON DELETE CASCADE,
+				starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE TABLE IF NOT EXISTS price_experiment_variants (
+				id SERIAL PRIMARY KEY,
+				experiment_id INTEGER NOT NULL REFERENCES price_experiments(id) ON DELETE CASCADE,
+				name VARCHAR(50) NOT NULL,
+				price DECIMAL(10,2) NOT NULL,
+				weight INTEGER NOT NULL,
+				UNIQUE (experiment_id, name)
+			);
+
+			CREATE TABLE IF NOT EXISTS price_experiment_exposures (
+				id SERIAL PRIMARY KEY,
+				experiment_id INTEGER NOT NULL REFERENCES price_experiments(id) ON DELETE CASCADE,
+				variant_id INTEGER NOT NULL REFERENCES price_experiment_variants(id) ON DELETE CASCADE,
+				guest_session_id INTEGER NOT NULL REFERENCES guest_sessions(id) ON DELETE CASCADE,
+				shown_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				UNIQUE (experiment_id, guest_session_id)
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_price_experiments_item ON price_experiments(menu_item_id, starts_at);
+			CREATE INDEX IF NOT EXISTS idx_price_experiments_window ON price_experiments(starts_at, ends_at);
+			CREATE INDEX IF NOT EXISTS idx_price_experiment_exposures_variant ON price_experiment_exposures(variant_id);
+			CREATE INDEX IF NOT EXISTS idx_price_experiment_exposures_guest_session ON price_experiment_exposures(guest_session_id);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create price experiment tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping price experiment tables...")
+
+		err := execAll(ctx, db, []string{
+			`DROP TABLE IF EXISTS price_experiment_exposures`,
+			`DROP TABLE IF EXISTS price_experiment_variants`,
+			`DROP TABLE IF EXISTS price_experiments`,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop price experiment tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createFeatureFlagsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createFeatureFlagsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS feature_flags (
+		name VARCHAR(100) PRIMARY KEY,
+		description VARCHAR(255) NOT NULL DEFAULT '',
+		enabled BOOLEAN NOT NULL DEFAULT FALSE,
+		environments TEXT NOT NULL,
+		tenants TEXT NOT NULL,
+		created_at DATETIME(6) NOT NULL,
+		updated_at DATETIME(6) NOT NULL
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating feature_flags table...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createFeatureFlagsMySQL); err != nil {
+				return fmt.Errorf("failed to create feature_flags table: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Environments and tenants are stored as JSON arrays; an empty array means
+		// the flag applies to all of them
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS feature_flags (
+				name VARCHAR(100) PRIMARY KEY,
+				description VARCHAR(255) NOT NULL DEFAULT '',
+				enabled BOOLEAN NOT NULL DEFAULT FALSE,
+				environments TEXT NOT NULL,
+				tenants TEXT NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+			);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create feature_flags table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping feature_flags table...")
+
+		if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS feature_flags`); err != nil {
+			return fmt.Errorf("failed to drop feature_flags table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createSettingsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createSettingsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS settings (
+		name VARCHAR(100) PRIMARY KEY,
+		value TEXT NOT NULL,
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating settings table...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createSettingsMySQL); err != nil {
+				return fmt.Errorf("failed to create settings table: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Business settings by name, their values encoded as text. Settings that
+		// aren't stored fall back to the server's environment.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS settings (
+				name VARCHAR(100) PRIMARY KEY,
+				value TEXT NOT NULL,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create settings table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping settings table...")
+
+		if _, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS settings`); err != nil {
+			return fmt.Errorf("failed to drop settings table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,280 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// restaurantTables are the tables whose rows belong to a restaurant. The rows of the
+// other tables, such as order items or guest sessions, belong to the restaurant of
+// the row they hang off.
+var restaurantTables = []string{
+	"menu_items", "menu_item_reviews",
+	"orders", "order_numbers", "order_slots", "carts",
+	"tax_rates", "pricing_rules", "promotions", "coupons", "price_experiments",
+	"gift_cards", "loyalty_accounts", "loyalty_transactions", "store_credit_accounts", "store_credit_entries",
+	"dining_tables", "delivery_zones", "drivers",
+	"staff", "shifts", "time_punches",
+	"settings",
+}
+
+// Sales per restaurant and day, and per restaurant, menu item and day, replacing the
+// report views of migrations 005 and 009
+const (
+	dailySalesByRestaurantQuery = `
+		SELECT o.restaurant_id,
+			CAST(o.created_at AS DATE) AS day,
+			COUNT(*) AS orders,
+			SUM(o.total) AS revenue
+		FROM orders AS o
+		WHERE o.status <> 'cancelled'
+		GROUP BY o.restaurant_id, CAST(o.created_at AS DATE)`
+
+	itemSalesByRestaurantQuery = `
+		SELECT o.restaurant_id,
+			CAST(o.created_at AS DATE) AS day,
+			COALESCE(oi.menu_item_id, 0) AS menu_item_id,
+			oi.name,
+			COALESCE(mi.category, 'uncategorized') AS category,
+			COUNT(DISTINCT o.id) AS orders,
+			SUM(oi.quantity) AS quantity,
+			SUM(oi.quantity * oi.unit_price - oi.discount) AS revenue
+		FROM orders AS o
+		JOIN order_items AS oi ON oi.order_id = o.id
+		LEFT JOIN menu_items AS mi ON mi.id = oi.menu_item_id
+		WHERE o.status <> 'cancelled'
+		GROUP BY o.restaurant_id, CAST(o.created_at AS DATE), COALESCE(oi.menu_item_id, 0), oi.name, COALESCE(mi.category, 'uncategorized')`
+)
+
+// createRestaurantsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createRestaurantsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS restaurants (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		slug VARCHAR(50) NOT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE INDEX idx_restaurants_slug (slug)
+	)`,
+	`INSERT IGNORE INTO restaurants (id, name, slug) VALUES (1, 'Default', 'default')`,
+}
+
+// scopeUniqueKeysMySQL makes the keys that were unique across the database unique per
+// restaurant. Store credit entries reference their account, whose key changes.
+var scopeUniqueKeysMySQL = []string{
+	`ALTER TABLE coupons DROP INDEX idx_coupons_code, ADD UNIQUE INDEX idx_coupons_restaurant_code (restaurant_id, code)`,
+	`ALTER TABLE gift_cards DROP INDEX idx_gift_cards_code, ADD UNIQUE INDEX idx_gift_cards_restaurant_code (restaurant_id, code)`,
+	`ALTER TABLE dining_tables DROP INDEX idx_dining_tables_name, ADD UNIQUE INDEX idx_dining_tables_restaurant_name (restaurant_id, name)`,
+	`ALTER TABLE delivery_zones DROP INDEX idx_delivery_zones_name, ADD UNIQUE INDEX idx_delivery_zones_restaurant_name (restaurant_id, name)`,
+	`ALTER TABLE tax_rates DROP INDEX idx_tax_rates_scope, ADD UNIQUE INDEX idx_tax_rates_restaurant_scope (restaurant_id, scope_key)`,
+	`ALTER TABLE orders
+		DROP INDEX idx_orders_source_external_id,
+		DROP INDEX uq_orders_business_day_number,
+		ADD UNIQUE INDEX idx_orders_restaurant_source_external_id (restaurant_id, source, external_id),
+		ADD UNIQUE INDEX uq_orders_restaurant_business_day_number (restaurant_id, business_day, number)`,
+	`ALTER TABLE order_numbers DROP PRIMARY KEY, ADD PRIMARY KEY (restaurant_id, business_day)`,
+	`ALTER TABLE order_slots DROP PRIMARY KEY, ADD PRIMARY KEY (restaurant_id, slot_at)`,
+	`ALTER TABLE loyalty_accounts DROP PRIMARY KEY, ADD PRIMARY KEY (restaurant_id, customer_phone)`,
+	`ALTER TABLE store_credit_entries DROP FOREIGN KEY fk_store_credit_entries_account`,
+	`ALTER TABLE store_credit_accounts DROP PRIMARY KEY, ADD PRIMARY KEY (restaurant_id, customer_phone)`,
+	`ALTER TABLE store_credit_entries ADD CONSTRAINT fk_store_credit_entries_account
+		FOREIGN KEY (restaurant_id, customer_phone) REFERENCES store_credit_accounts(restaurant_id, customer_phone)`,
+	`ALTER TABLE settings DROP PRIMARY KEY, ADD PRIMARY KEY (restaurant_id, name)`,
+	`ALTER TABLE floor_plans RENAME COLUMN id TO restaurant_id`,
+	`ALTER TABLE floor_plans ADD CONSTRAINT fk_floor_plans_restaurant FOREIGN KEY (restaurant_id) REFERENCES restaurants(id)`,
+	`ALTER TABLE report_daily_sales ADD COLUMN restaurant_id INT NOT NULL DEFAULT 1 FIRST,
+		DROP PRIMARY KEY, ADD PRIMARY KEY (restaurant_id, day)`,
+	`ALTER TABLE report_item_sales ADD COLUMN restaurant_id INT NOT NULL DEFAULT 1 FIRST,
+		DROP PRIMARY KEY, ADD PRIMARY KEY (restaurant_id, day, menu_item_id, name, category)`,
+}
+
+// addRestaurantColumnsMySQL gives each restaurant table a restaurant_id, the default
+// restaurant's for existing rows. MySQL ignores REFERENCES in column definitions, so
+// the foreign key is a separate constraint; it also indexes the column.
+func addRestaurantColumnsMySQL() []string {
+	statements := make([]string, len(restaurantTables))
+	for i, table := range restaurantTables {
+		statements[i] = fmt.Sprintf(`ALTER TABLE %[1]s
+			ADD COLUMN restaurant_id INT NOT NULL DEFAULT 1,
+			ADD CONSTRAINT fk_%[1]s_restaurant FOREIGN KEY (restaurant_id) REFERENCES restaurants(id)`, table)
+	}
+	return statements
+}
+
+// addRestaurantColumnsPostgres is the PostgreSQL equivalent of addRestaurantColumnsMySQL
+func addRestaurantColumnsPostgres() string {
+	var b strings.Builder
+	for _, table := range restaurantTables {
+		fmt.Fprintf(&b, `
+			ALTER TABLE %[1]s ADD COLUMN IF NOT EXISTS restaurant_id INTEGER NOT NULL DEFAULT 1 REFERENCES restaurants(id);
+			CREATE INDEX IF NOT EXISTS idx_%[1]s_restaurant_id ON %[1]s(restaurant_id);`, table)
+	}
+	return b.String()
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating restaurants table and restaurant columns...")
+
+		if database.IsMySQL(db) {
+			statements := append(append(createRestaurantsMySQL, addRestaurantColumnsMySQL()...), scopeUniqueKeysMySQL...)
+			if err := execAll(ctx, db, statements); err != nil {
+				return fmt.Errorf("failed to create restaurants: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Every existing row belongs to the default restaurant, row 1. Codes, names and
+		// order numbers become unique per restaurant; the QR tokens of tables and guest
+		// sessions stay unique across restaurants, as guests are found by them before
+		// the restaurant is known. The floor plan, one per restaurant, is keyed by it.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS restaurants (
+				id SERIAL PRIMARY KEY,
+				name VARCHAR(100) NOT NULL,
+				slug VARCHAR(50) NOT NULL UNIQUE,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+			INSERT INTO restaurants (id, name, slug) VALUES (1, 'Default', 'default') ON CONFLICT (id) DO NOTHING;
+			SELECT setval(pg_get_serial_sequence('restaurants', 'id'), (SELECT MAX(id) FROM restaurants));
+		`+addRestaurantColumnsPostgres()+`
+
+			ALTER TABLE coupons DROP CONSTRAINT IF EXISTS coupons_code_key;
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_coupons_restaurant_code ON coupons(restaurant_id, code);
+			ALTER TABLE gift_cards DROP CONSTRAINT IF EXISTS gift_cards_code_key;
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_gift_cards_restaurant_code ON gift_cards(restaurant_id, code);
+			ALTER TABLE dining_tables DROP CONSTRAINT IF EXISTS dining_tables_name_key;
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_dining_tables_restaurant_name ON dining_tables(restaurant_id, name);
+			ALTER TABLE delivery_zones DROP CONSTRAINT IF EXISTS delivery_zones_name_key;
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_delivery_zones_restaurant_name ON delivery_zones(restaurant_id, name);
+
+			DROP INDEX IF EXISTS idx_tax_rates_menu_item_id;
+			DROP INDEX IF EXISTS idx_tax_rates_category;
+			DROP INDEX IF EXISTS idx_tax_rates_default;
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rates_menu_item_id ON tax_rates(restaurant_id, menu_item_id) WHERE menu_item_id IS NOT NULL;
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rates_category ON tax_rates(restaurant_id, category) WHERE category IS NOT NULL AND menu_item_id IS NULL;
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rates_default ON tax_rates(restaurant_id) WHERE category IS NULL AND menu_item_id IS NULL;
+
+			DROP INDEX IF EXISTS idx_orders_source_external_id;
+			DROP INDEX IF EXISTS uq_orders_business_day_number;
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_restaurant_source_external_id ON orders(restaurant_id, source, external_id);
+			CREATE UNIQUE INDEX IF NOT EXISTS uq_orders_restaurant_business_day_number ON orders(restaurant_id, business_day, number);
+
+			ALTER TABLE order_numbers DROP CONSTRAINT order_numbers_pkey, ADD PRIMARY KEY (restaurant_id, business_day);
+			ALTER TABLE order_slots DROP CONSTRAINT order_slots_pkey, ADD PRIMARY KEY (restaurant_id, slot_at);
+			ALTER TABLE loyalty_accounts DROP CONSTRAINT loyalty_accounts_pkey, ADD PRIMARY KEY (restaurant_id, customer_phone);
+			ALTER TABLE store_credit_entries DROP CONSTRAINT IF EXISTS store_credit_entries_customer_phone_fkey;
+			ALTER TABLE store_credit_accounts DROP CONSTRAINT store_credit_accounts_pkey, ADD PRIMARY KEY (restaurant_id, customer_phone);
+			ALTER TABLE store_credit_entries ADD CONSTRAINT store_credit_entries_account_fkey
+				FOREIGN KEY (restaurant_id, customer_phone) REFERENCES store_credit_accounts(restaurant_id, customer_phone);
+			ALTER TABLE settings DROP CONSTRAINT settings_pkey, ADD PRIMARY KEY (restaurant_id, name);
+
+			ALTER TABLE floor_plans RENAME COLUMN id TO restaurant_id;
+			ALTER TABLE floor_plans ADD CONSTRAINT floor_plans_restaurant_id_fkey FOREIGN KEY (restaurant_id) REFERENCES restaurants(id);
+
+			DROP MATERIALIZED VIEW IF EXISTS report_daily_sales;
+			DROP MATERIALIZED VIEW IF EXISTS report_item_sales;
+			CREATE MATERIALIZED VIEW report_daily_sales AS`+dailySalesByRestaurantQuery+`;
+			CREATE MATERIALIZED VIEW report_item_sales AS`+itemSalesByRestaurantQuery+`;
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_report_daily_sales_key ON report_daily_sales(restaurant_id, day);
+			CREATE UNIQUE INDEX IF NOT EXISTS idx_report_item_sales_key ON report_item_sales(restaurant_id, day, menu_item_id, name, category);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create restaurants: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping restaurants table and restaurant columns...")
+
+		// Keys unique per restaurant only become unique again while a single
+		// restaurant has data
+		var statements []string
+		if database.IsMySQL(db) {
+			statements = []string{
+				`ALTER TABLE report_item_sales DROP PRIMARY KEY, DROP COLUMN restaurant_id, ADD PRIMARY KEY (day, menu_item_id, name, category)`,
+				`ALTER TABLE report_daily_sales DROP PRIMARY KEY, DROP COLUMN restaurant_id, ADD PRIMARY KEY (day)`,
+				`ALTER TABLE floor_plans DROP FOREIGN KEY fk_floor_plans_restaurant`,
+				`ALTER TABLE floor_plans RENAME COLUMN restaurant_id TO id`,
+				`ALTER TABLE settings DROP PRIMARY KEY, ADD PRIMARY KEY (name)`,
+				`ALTER TABLE store_credit_entries DROP FOREIGN KEY fk_store_credit_entries_account`,
+				`ALTER TABLE store_credit_accounts DROP PRIMARY KEY, ADD PRIMARY KEY (customer_phone)`,
+				`ALTER TABLE store_credit_entries ADD CONSTRAINT fk_store_credit_entries_account
+					FOREIGN KEY (customer_phone) REFERENCES store_credit_accounts(customer_phone)`,
+				`ALTER TABLE loyalty_accounts DROP PRIMARY KEY, ADD PRIMARY KEY (customer_phone)`,
+				`ALTER TABLE order_slots DROP PRIMARY KEY, ADD PRIMARY KEY (slot_at)`,
+				`ALTER TABLE order_numbers DROP PRIMARY KEY, ADD PRIMARY KEY (business_day)`,
+				`ALTER TABLE orders
+					DROP INDEX idx_orders_restaurant_source_external_id,
+					DROP INDEX uq_orders_restaurant_business_day_number,
+					ADD UNIQUE INDEX idx_orders_source_external_id (source, external_id),
+					ADD UNIQUE INDEX uq_orders_business_day_number (business_day, number)`,
+				`ALTER TABLE tax_rates DROP INDEX idx_tax_rates_restaurant_scope, ADD UNIQUE INDEX idx_tax_rates_scope (scope_key)`,
+				`ALTER TABLE delivery_zones DROP INDEX idx_delivery_zones_restaurant_name, ADD UNIQUE INDEX idx_delivery_zones_name (name)`,
+				`ALTER TABLE dining_tables DROP INDEX idx_dining_tables_restaurant_name, ADD UNIQUE INDEX idx_dining_tables_name (name)`,
+				`ALTER TABLE gift_cards DROP INDEX idx_gift_cards_restaurant_code, ADD UNIQUE INDEX idx_gift_cards_code (code)`,
+				`ALTER TABLE coupons DROP INDEX idx_coupons_restaurant_code, ADD UNIQUE INDEX idx_coupons_code (code)`,
+			}
+			for _, table := range restaurantTables {
+				statements = append(statements,
+					fmt.Sprintf(`ALTER TABLE %[1]s DROP FOREIGN KEY fk_%[1]s_restaurant`, table),
+					fmt.Sprintf(`ALTER TABLE %[1]s DROP COLUMN restaurant_id`, table))
+			}
+		} else {
+			statements = []string{
+				`DROP MATERIALIZED VIEW IF EXISTS report_item_sales`,
+				`DROP MATERIALIZED VIEW IF EXISTS report_daily_sales`,
+				`CREATE MATERIALIZED VIEW report_daily_sales AS` + dailySalesQuery,
+				`CREATE MATERIALIZED VIEW report_item_sales AS` + itemSalesNetQuery,
+				`CREATE UNIQUE INDEX IF NOT EXISTS idx_report_daily_sales_day ON report_daily_sales(day)`,
+				`CREATE UNIQUE INDEX IF NOT EXISTS idx_report_item_sales_key ON report_item_sales(day, menu_item_id, name, category)`,
+				`ALTER TABLE floor_plans DROP CONSTRAINT floor_plans_restaurant_id_fkey`,
+				`ALTER TABLE floor_plans RENAME COLUMN restaurant_id TO id`,
+				`ALTER TABLE settings DROP CONSTRAINT settings_pkey, ADD PRIMARY KEY (name)`,
+				`ALTER TABLE store_credit_entries DROP CONSTRAINT store_credit_entries_account_fkey`,
+				`ALTER TABLE store_credit_accounts DROP CONSTRAINT store_credit_accounts_pkey, ADD PRIMARY KEY (customer_phone)`,
+				`ALTER TABLE store_credit_entries ADD CONSTRAINT store_credit_entries_customer_phone_fkey
+					FOREIGN KEY (customer_phone) REFERENCES store_credit_accounts(customer_phone)`,
+				`ALTER TABLE loyalty_accounts DROP CONSTRAINT loyalty_accounts_pkey, ADD PRIMARY KEY (customer_phone)`,
+				`ALTER TABLE order_slots DROP CONSTRAINT order_slots_pkey, ADD PRIMARY KEY (slot_at)`,
+				`ALTER TABLE order_numbers DROP CONSTRAINT order_numbers_pkey, ADD PRIMARY KEY (business_day)`,
+				`DROP INDEX IF EXISTS uq_orders_restaurant_business_day_number`,
+				`DROP INDEX IF EXISTS idx_orders_restaurant_source_external_id`,
+				`CREATE UNIQUE INDEX IF NOT EXISTS uq_orders_business_day_number ON orders(business_day, number)`,
+				`CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_source_external_id ON orders(source, external_id)`,
+				`DROP INDEX IF EXISTS idx_tax_rates_menu_item_id`,
+				`DROP INDEX IF EXISTS idx_tax_rates_category`,
+				`DROP INDEX IF EXISTS idx_tax_rates_default`,
+				`CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rates_menu_item_id ON tax_rates(menu_item_id) WHERE menu_item_id IS NOT NULL`,
+				`CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rates_category ON tax_rates(category) WHERE category IS NOT NULL AND menu_item_id IS NULL`,
+				`CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rates_default ON tax_rates((true)) WHERE category IS NULL AND menu_item_id IS NULL`,
+				`DROP INDEX IF EXISTS idx_delivery_zones_restaurant_name`,
+				`ALTER TABLE delivery_zones ADD CONSTRAINT delivery_zones_name_key UNIQUE (name)`,
+				`DROP INDEX IF EXISTS idx_dining_tables_restaurant_name`,
+				`ALTER TABLE dining_tables ADD CONSTRAINT dining_tables_name_key UNIQUE (name)`,
+				`DROP INDEX IF EXISTS idx_gift_cards_restaurant_code`,
+				`ALTER TABLE gift_cards ADD CONSTRAINT gift_cards_code_key UNIQUE (code)`,
+				`DROP INDEX IF EXISTS idx_coupons_restaurant_code`,
+				`ALTER TABLE coupons ADD CONSTRAINT coupons_code_key UNIQUE (code)`,
+			}
+			for _, table := range restaurantTables {
+				statements = append(statements, fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS restaurant_id`, table))
+			}
+		}
+		statements = append(statements, `DROP TABLE IF EXISTS restaurants`)
+		if err := execAll(ctx, db, statements); err != nil {
+			return fmt.Errorf("failed to drop restaurants: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
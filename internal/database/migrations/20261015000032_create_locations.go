@@ -0,0 +1,91 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createLocationsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createLocationsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS locations (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		restaurant_id INT NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE KEY idx_locations_restaurant_name (restaurant_id, name),
+		CONSTRAINT fk_locations_restaurant FOREIGN KEY (restaurant_id) REFERENCES restaurants(id)
+	)`, `
+	CREATE TABLE IF NOT EXISTS location_menu_items (
+		location_id INT NOT NULL,
+		menu_item_id INT NOT NULL,
+		is_available BOOLEAN NULL,
+		price DECIMAL(10,2) NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		PRIMARY KEY (location_id, menu_item_id),
+		INDEX idx_location_menu_items_item (menu_item_id),
+		CONSTRAINT fk_location_menu_items_location FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE CASCADE,
+		CONSTRAINT fk_location_menu_items_item FOREIGN KEY (menu_item_id) REFERENCES menu_items(id) ON DELETE CASCADE
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating location tables...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createLocationsMySQL); err != nil {
+				return fmt.Errorf("failed to create location tables: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// A location is a branch of a restaurant sharing its menu. A branch can
+		// override the availability and price of any item; NULL keeps the menu's.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS locations (
+				id SERIAL PRIMARY KEY,
+				restaurant_id INTEGER NOT NULL REFERENCES restaurants(id),
+				name VARCHAR(100) NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				UNIQUE (restaurant_id, name)
+			);
+
+			CREATE TABLE IF NOT EXISTS location_menu_items (
+				location_id INTEGER NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+				menu_item_id INTEGER NOT NULL REFERENCES menu_items(id) ON DELETE CASCADE,
+				is_available BOOLEAN,
+				price DECIMAL(10,2),
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (location_id, menu_item_id)
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_location_menu_items_item ON location_menu_items(menu_item_id);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create location tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping location tables...")
+
+		for _, table := range []string{"location_menu_items", "locations"} {
+			if _, err := db.ExecContext(ctx, "DROP TABLE IF EXISTS "+table); err != nil {
+				return fmt.Errorf("failed to drop %s table: %w", table, err)
+			}
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
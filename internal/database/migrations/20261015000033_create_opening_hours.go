@@ -0,0 +1,122 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createOpeningHoursMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createOpeningHoursMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS location_hours (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		location_id INT NOT NULL,
+		day VARCHAR(3) NOT NULL,
+		opens_at VARCHAR(5) NOT NULL,
+		closes_at VARCHAR(5) NOT NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		CONSTRAINT fk_location_hours_location FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE CASCADE
+	)`, `
+	CREATE TABLE IF NOT EXISTS location_hour_exceptions (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		location_id INT NOT NULL,
+		date DATE NOT NULL,
+		opens_at VARCHAR(5) NULL,
+		closes_at VARCHAR(5) NULL,
+		note VARCHAR(200) NULL,
+		created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE KEY idx_location_hour_exceptions_date (location_id, date),
+		CONSTRAINT fk_location_hour_exceptions_location FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE CASCADE
+	)`,
+	`ALTER TABLE orders ADD COLUMN location_id INT NULL`,
+	`ALTER TABLE orders
+		ADD CONSTRAINT fk_orders_location FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE SET NULL`,
+	`ALTER TABLE carts ADD COLUMN location_id INT NULL`,
+	`ALTER TABLE carts
+		ADD CONSTRAINT fk_carts_location FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE SET NULL`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating opening hours tables and order location columns...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createOpeningHoursMySQL); err != nil {
+				return fmt.Errorf("failed to create opening hours tables: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// A location opens on a weekday (sun, mon, ...) for each of its periods that
+		// day, from opens_at to closes_at (HH:MM, restaurant time), past midnight when
+		// closes_at is earlier. An exception replaces the periods of one date: it is
+		// closed all day when opens_at is NULL. Online orders and the carts they are
+		// checked out from may name the location they are placed at.
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS location_hours (
+				id SERIAL PRIMARY KEY,
+				location_id INTEGER NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+				day VARCHAR(3) NOT NULL,
+				opens_at VARCHAR(5) NOT NULL,
+				closes_at VARCHAR(5) NOT NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_location_hours_location_id ON location_hours(location_id);
+
+			CREATE TABLE IF NOT EXISTS location_hour_exceptions (
+				id SERIAL PRIMARY KEY,
+				location_id INTEGER NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+				date DATE NOT NULL,
+				opens_at VARCHAR(5) NULL,
+				closes_at VARCHAR(5) NULL,
+				note VARCHAR(200) NULL,
+				created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				UNIQUE (location_id, date)
+			);
+
+			ALTER TABLE orders ADD COLUMN IF NOT EXISTS location_id INTEGER NULL REFERENCES locations(id) ON DELETE SET NULL;
+			CREATE INDEX IF NOT EXISTS idx_orders_location_id ON orders(location_id);
+			ALTER TABLE carts ADD COLUMN IF NOT EXISTS location_id INTEGER NULL REFERENCES locations(id) ON DELETE SET NULL;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create opening hours tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping opening hours tables and order location columns...")
+
+		var err error
+		if database.IsMySQL(db) {
+			err = execAll(ctx, db, []string{
+				`ALTER TABLE carts DROP FOREIGN KEY fk_carts_location`,
+				`ALTER TABLE carts DROP COLUMN location_id`,
+				`ALTER TABLE orders DROP FOREIGN KEY fk_orders_location`,
+				`ALTER TABLE orders DROP COLUMN location_id`,
+				`DROP TABLE IF EXISTS location_hour_exceptions`,
+				`DROP TABLE IF EXISTS location_hours`,
+			})
+		} else {
+			err = execAll(ctx, db, []string{
+				`ALTER TABLE carts DROP COLUMN IF EXISTS location_id`,
+				`ALTER TABLE orders DROP COLUMN IF EXISTS location_id`,
+				`DROP TABLE IF EXISTS location_hour_exceptions`,
+				`DROP TABLE IF EXISTS location_hours`,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to drop opening hours tables: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
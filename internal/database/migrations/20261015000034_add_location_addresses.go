@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// addLocationAddressesMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var addLocationAddressesMySQL = []string{
+	`ALTER TABLE locations
+		ADD COLUMN address VARCHAR(300) NULL,
+		ADD COLUMN latitude DOUBLE NULL,
+		ADD COLUMN longitude DOUBLE NULL`,
+	`CREATE INDEX idx_locations_coordinates ON locations (latitude, longitude)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] adding location addresses and coordinates...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, addLocationAddressesMySQL); err != nil {
+				return fmt.Errorf("failed to add location addresses: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Nearby locations are searched with the earthdistance extension (trusted since
+		// PostgreSQL 13, so the database owner can create it), through a GiST index on
+		// the points of the locations that have coordinates. MySQL matches them on a
+		// bounding box of the coordinates index instead.
+		_, err := db.ExecContext(ctx, `
+			CREATE EXTENSION IF NOT EXISTS cube;
+			CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+			ALTER TABLE locations
+				ADD COLUMN IF NOT EXISTS address VARCHAR(300) NULL,
+				ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION NULL,
+				ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION NULL;
+
+			CREATE INDEX IF NOT EXISTS idx_locations_earth ON locations
+				USING gist (ll_to_earth(latitude, longitude))
+				WHERE latitude IS NOT NULL AND longitude IS NOT NULL;
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to add location addresses: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping location addresses and coordinates...")
+
+		var err error
+		if database.IsMySQL(db) {
+			err = execAll(ctx, db, []string{
+				`DROP INDEX idx_locations_coordinates ON locations`,
+				`ALTER TABLE locations DROP COLUMN address, DROP COLUMN latitude, DROP COLUMN longitude`,
+			})
+		} else {
+			// The extensions are left in place, as other objects may depend on them
+			err = execAll(ctx, db, []string{
+				`DROP INDEX IF EXISTS idx_locations_earth`,
+				`ALTER TABLE locations DROP COLUMN IF EXISTS address, DROP COLUMN IF EXISTS latitude, DROP COLUMN IF EXISTS longitude`,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to drop location addresses: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -0,0 +1,76 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// createAnonymizationRunsMySQL is the MySQL/MariaDB equivalent of the PostgreSQL schema below
+var createAnonymizationRunsMySQL = []string{`
+	CREATE TABLE IF NOT EXISTS anonymization_runs (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		retention_days INT NOT NULL,
+		cutoff DATETIME(6) NOT NULL,
+		orders INT NOT NULL DEFAULT 0,
+		coupon_redemptions INT NOT NULL DEFAULT 0,
+		carts INT NOT NULL DEFAULT 0,
+		reviews INT NOT NULL DEFAULT 0,
+		error TEXT NULL,
+		started_at DATETIME(6) NOT NULL,
+		finished_at DATETIME(6) NOT NULL,
+		INDEX idx_anonymization_runs_started_at (started_at)
+	)`,
+}
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [UP] creating anonymization_runs table...")
+
+		if database.IsMySQL(db) {
+			if err := execAll(ctx, db, createAnonymizationRunsMySQL); err != nil {
+				return fmt.Errorf("failed to create anonymization_runs table: %w", err)
+			}
+			fmt.Println(" ✓")
+			return nil
+		}
+
+		// Each run of the anonymize_orders task is audited with the cutoff it anonymized
+		// orders before, the rows it changed and the error it stopped on, if any
+		_, err := db.ExecContext(ctx, `
+			CREATE TABLE IF NOT EXISTS anonymization_runs (
+				id BIGSERIAL PRIMARY KEY,
+				retention_days INTEGER NOT NULL,
+				cutoff TIMESTAMP WITH TIME ZONE NOT NULL,
+				orders INTEGER NOT NULL DEFAULT 0,
+				coupon_redemptions INTEGER NOT NULL DEFAULT 0,
+				carts INTEGER NOT NULL DEFAULT 0,
+				reviews INTEGER NOT NULL DEFAULT 0,
+				error TEXT NULL,
+				started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+				finished_at TIMESTAMP WITH TIME ZONE NOT NULL
+			);
+
+			CREATE INDEX IF NOT EXISTS idx_anonymization_runs_started_at ON anonymization_runs(started_at);
+		`)
+		if err != nil {
+			return fmt.Errorf("failed to create anonymization_runs table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	}, func(ctx context.Context, db *bun.DB) error {
+		fmt.Print(" [DOWN] dropping anonymization_runs table...")
+
+		_, err := db.ExecContext(ctx, `DROP TABLE IF EXISTS anonymization_runs`)
+		if err != nil {
+			return fmt.Errorf("failed to drop anonymization_runs table: %w", err)
+		}
+
+		fmt.Println(" ✓")
+		return nil
+	})
+}
@@ -12,6 +12,17 @@ import (
 // Migrations holds all registered migrations
 var Migrations = migrate.NewMigrations()
 
+// execAll runs statements one at a time; MySQL drivers reject multi-statement
+// Exec calls unless multiStatements is enabled on the connection
+func execAll(ctx context.Context, db *bun.DB, statements []string) error {
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // RunMigrations runs all pending migrations
 func RunMigrations(ctx context.Context, db *bun.DB) error {
 	migrator := migrate.NewMigrator(db, Migrations)
@@ -72,3 +83,15 @@ func GetMigrationStatus(ctx context.Context, db *bun.DB) error {
 	slog.Info("Migration tables initialized successfully")
 	return nil
 }
+
+// ListMigrations returns all registered migrations with their applied status
+func ListMigrations(ctx context.Context, db *bun.DB) (migrate.MigrationSlice, error) {
+	migrator := migrate.NewMigrator(db, Migrations)
+
+	ms, err := migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migration status: %w", err)
+	}
+
+	return ms, nil
+}
@@ -0,0 +1,187 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// AnonymizationRun audits one anonymization of the contact details of old orders:
+// the orders created before Cutoff, RetentionDays before the run, and the rows it
+// anonymized. Error is set when the run stopped early; the rows anonymized until then
+// are still counted.
+type AnonymizationRun struct {
+	bun.BaseModel `bun:"table:anonymization_runs,alias:ar"`
+
+	ID                int64     `bun:"id,pk,autoincrement" json:"id"`
+	RetentionDays     int       `bun:"retention_days,notnull" json:"retention_days"`
+	Cutoff            time.Time `bun:"cutoff,notnull" json:"cutoff"`
+	Orders            int       `bun:"orders,notnull" json:"orders"`
+	CouponRedemptions int       `bun:"coupon_redemptions,notnull" json:"coupon_redemptions"`
+	Carts             int       `bun:"carts,notnull" json:"carts"`
+	Reviews           int       `bun:"reviews,notnull" json:"reviews"`
+	Error             *string   `bun:"error,type:text" json:"error,omitempty"`
+	StartedAt         time.Time `bun:"started_at,notnull" json:"started_at"`
+	FinishedAt        time.Time `bun:"finished_at,notnull" json:"finished_at"`
+}
+
+// AnonymizationQuery anonymizes the contact details of old orders of every restaurant
+// and keeps the audit of its runs
+type AnonymizationQuery struct {
+	db *bun.DB
+}
+
+// NewAnonymizationQuery creates a new query builder for anonymization runs
+func NewAnonymizationQuery(db *bun.DB) *AnonymizationQuery {
+	return &AnonymizationQuery{db: db}
+}
+
+// AnonymizeOrders clears the contact details of the orders created before cutoff, at
+// every restaurant and whatever their status, as CustomerQuery.Erase does: the
+// customer name, phone number and notes of the orders and of the carts checked out
+// into them, the phone number of the coupons redeemed on them and the author and IP
+// address of their reviews. Carts created before cutoff and never checked out are
+// cleared too. Rows are anonymized batchSize at a time, each batch in its own
+// transaction, so a large backlog doesn't hold locks for long; the counts added to
+// run include the batches done before an error.
+func (q *AnonymizationQuery) AnonymizeOrders(ctx context.Context, cutoff time.Time, batchSize int, run *AnonymizationRun) error {
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	for {
+		var orderIDs []string
+		err := q.db.NewSelect().
+			Model((*Order)(nil)).
+			Column("o.id").
+			Where("o.created_at < ?", cutoff).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("o.customer_name IS NOT NULL OR o.customer_phone IS NOT NULL OR o.notes IS NOT NULL").
+					WhereOr("EXISTS (SELECT 1 FROM carts AS c WHERE c.order_id = o.id AND " +
+						"(c.customer_name IS NOT NULL OR c.customer_phone IS NOT NULL OR c.notes IS NOT NULL))").
+					WhereOr("EXISTS (SELECT 1 FROM menu_item_reviews AS mr WHERE mr.order_id = o.id AND " +
+						"(mr.author_name IS NOT NULL OR mr.client_ip IS NOT NULL))").
+					WhereOr("EXISTS (SELECT 1 FROM coupon_redemptions AS cr WHERE cr.order_id = o.id AND " +
+						"cr.customer_phone IS NOT NULL)")
+			}).
+			Order("o.created_at ASC").
+			Limit(batchSize).
+			Scan(ctx, &orderIDs)
+		if err != nil {
+			return err
+		}
+		if len(orderIDs) == 0 {
+			break
+		}
+
+		var batch AnonymizationRun
+		err = q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			now := time.Now()
+			var err error
+			if batch.CouponRedemptions, err = affected(tx.NewUpdate().
+				Model((*CouponRedemption)(nil)).
+				Set("customer_phone = NULL").
+				Where("order_id IN (?) AND customer_phone IS NOT NULL", bun.In(orderIDs)).
+				Exec(ctx)); err != nil {
+				return err
+			}
+			if batch.Reviews, err = affected(tx.NewUpdate().
+				Model((*MenuItemReview)(nil)).
+				Set("author_name = NULL").
+				Set("client_ip = NULL").
+				Set("updated_at = ?", now).
+				Where("order_id IN (?) AND (author_name IS NOT NULL OR client_ip IS NOT NULL)", bun.In(orderIDs)).
+				Exec(ctx)); err != nil {
+				return err
+			}
+			if batch.Carts, err = affected(tx.NewUpdate().
+				Model((*Cart)(nil)).
+				Set("customer_name = NULL").
+				Set("customer_phone = NULL").
+				Set("notes = NULL").
+				Set("updated_at = ?", now).
+				Where("order_id IN (?) AND (customer_name IS NOT NULL OR customer_phone IS NOT NULL OR notes IS NOT NULL)", bun.In(orderIDs)).
+				Exec(ctx)); err != nil {
+				return err
+			}
+			batch.Orders, err = affected(tx.NewUpdate().
+				Model((*Order)(nil)).
+				Set("customer_name = NULL").
+				Set("customer_phone = NULL").
+				Set("notes = NULL").
+				Set("updated_at = ?", now).
+				Where("id IN (?) AND (customer_name IS NOT NULL OR customer_phone IS NOT NULL OR notes IS NOT NULL)", bun.In(orderIDs)).
+				Exec(ctx))
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		run.Orders += batch.Orders
+		run.CouponRedemptions += batch.CouponRedemptions
+		run.Carts += batch.Carts
+		run.Reviews += batch.Reviews
+
+		if len(orderIDs) < batchSize {
+			break
+		}
+	}
+
+	// Carts that were never checked out have no order to age with
+	for {
+		var cartIDs []string
+		err := q.db.NewSelect().
+			Model((*Cart)(nil)).
+			Column("c.id").
+			Where("c.order_id IS NULL AND c.created_at < ?", cutoff).
+			Where("c.customer_name IS NOT NULL OR c.customer_phone IS NOT NULL OR c.notes IS NOT NULL").
+			Order("c.created_at ASC").
+			Limit(batchSize).
+			Scan(ctx, &cartIDs)
+		if err != nil {
+			return err
+		}
+		if len(cartIDs) == 0 {
+			return nil
+		}
+
+		carts, err := affected(q.db.NewUpdate().
+			Model((*Cart)(nil)).
+			Set("customer_name = NULL").
+			Set("customer_phone = NULL").
+			Set("notes = NULL").
+			Set("updated_at = ?", time.Now()).
+			Where("id IN (?)", bun.In(cartIDs)).
+			Exec(ctx))
+		if err != nil {
+			return err
+		}
+		run.Carts += carts
+
+		if len(cartIDs) < batchSize {
+			return nil
+		}
+	}
+}
+
+// RecordRun stores the audit entry of an anonymization run
+func (q *AnonymizationQuery) RecordRun(ctx context.Context, run *AnonymizationRun) error {
+	_, err := q.db.NewInsert().Model(run).Exec(ctx)
+	return err
+}
+
+// ListRuns returns the most recent anonymization runs, newest first
+func (q *AnonymizationQuery) ListRuns(ctx context.Context, limit int) ([]AnonymizationRun, error) {
+	runs := []AnonymizationRun{}
+	err := q.db.NewSelect().
+		Model(&runs).
+		Order("ar.started_at DESC", "ar.id DESC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
@@ -0,0 +1,111 @@
+package models_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/migrations"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// testDB connects to the database of TEST_DATABASE_URL and migrates it, skipping the
+// test when it isn't set
+func testDB(t *testing.T) *bun.DB {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	config := &database.Config{MaxOpenConns: 5, MaxIdleConns: 5}
+	if err := config.ApplyURL(url); err != nil {
+		t.Fatalf("invalid TEST_DATABASE_URL: %v", err)
+	}
+	db, err := database.NewConnection(config)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err := migrations.RunMigrations(context.Background(), db); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+func TestAnonymizeOrdersClearsCartsAndReviews(t *testing.T) {
+	db := testDB(t)
+	ctx := database.WithRestaurant(context.Background(), database.DefaultRestaurantID)
+	name, phone, notes, ip := "Lina", "+962790000000", "No onions", "203.0.113.7"
+
+	item := &models.MenuItem{Name: "Mansaf", Price: decimal.NewFromInt(9), Category: "main"}
+	mustInsert(t, ctx, db, item)
+
+	// An old order that never left pending, with the cart it was checked out from
+	// and a review, and a recent order that must be kept
+	old := &models.Order{Source: "pos", Channel: models.OrderChannelTakeaway, Status: models.OrderStatusPending,
+		CustomerName: &name, CustomerPhone: &phone, Notes: &notes}
+	recent := &models.Order{Source: "pos", Channel: models.OrderChannelTakeaway, Status: models.OrderStatusPending,
+		CustomerName: &name, CustomerPhone: &phone}
+	mustInsert(t, ctx, db, old)
+	mustInsert(t, ctx, db, recent)
+	if _, err := db.NewUpdate().Model((*models.Order)(nil)).
+		Set("created_at = ?", time.Now().AddDate(0, 0, -2)).
+		Where("id = ?", old.ID).
+		Exec(ctx); err != nil {
+		t.Fatalf("failed to backdate order: %v", err)
+	}
+	cart := &models.Cart{Channel: models.OrderChannelTakeaway, CustomerName: &name, CustomerPhone: &phone,
+		Notes: &notes, OrderID: &old.ID}
+	review := &models.MenuItemReview{MenuItemID: item.ID, OrderID: &old.ID, Rating: 5, AuthorName: &name,
+		ClientIP: &ip, Status: models.ReviewStatusApproved}
+	mustInsert(t, ctx, db, cart)
+	mustInsert(t, ctx, db, review)
+
+	run := &models.AnonymizationRun{}
+	err := models.NewAnonymizationQuery(db).AnonymizeOrders(ctx, time.Now().AddDate(0, 0, -1), 10, run)
+	if err != nil {
+		t.Fatalf("AnonymizeOrders(): %v", err)
+	}
+	if run.Orders < 1 || run.Carts < 1 || run.Reviews < 1 {
+		t.Fatalf("anonymized %d orders, %d carts and %d reviews, want at least 1 of each",
+			run.Orders, run.Carts, run.Reviews)
+	}
+
+	mustSelect(t, ctx, db, old)
+	if old.CustomerName != nil || old.CustomerPhone != nil || old.Notes != nil {
+		t.Errorf("old pending order kept its contact details")
+	}
+	mustSelect(t, ctx, db, cart)
+	if cart.CustomerName != nil || cart.CustomerPhone != nil || cart.Notes != nil {
+		t.Errorf("checked-out cart kept its contact details")
+	}
+	mustSelect(t, ctx, db, review)
+	if review.AuthorName != nil || review.ClientIP != nil {
+		t.Errorf("review kept its author and IP address")
+	}
+	mustSelect(t, ctx, db, recent)
+	if recent.CustomerName == nil || recent.CustomerPhone == nil {
+		t.Errorf("recent order was anonymized")
+	}
+}
+
+func mustInsert(t *testing.T, ctx context.Context, db *bun.DB, model any) {
+	t.Helper()
+	if _, err := db.NewInsert().Model(model).Exec(ctx); err != nil {
+		t.Fatalf("failed to insert %T: %v", model, err)
+	}
+}
+
+func mustSelect(t *testing.T, ctx context.Context, db *bun.DB, model any) {
+	t.Helper()
+	if err := db.NewSelect().Model(model).WherePK().Scan(ctx); err != nil {
+		t.Fatalf("failed to reload %T: %v", model, err)
+	}
+}
@@ -0,0 +1,163 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// ErrCartCheckedOut is returned when a cart was already converted into an order
+var ErrCartCheckedOut = newOutcome("cart already checked out")
+
+// Cart is an order being put together by a customer, converted into an order at
+// checkout. OrderID is set once it has been checked out.
+type Cart struct {
+	bun.BaseModel `bun:"table:carts,alias:c"`
+
+	// Primary key - UUID generated on insert
+	ID string `bun:"id,pk" json:"id"`
+	// Restaurant the cart orders from
+	RestaurantID int `bun:"restaurant_id,notnull" json:"-"`
+
+	Channel       string  `bun:"channel,notnull" json:"channel"`
+	CustomerName  *string `bun:"customer_name" json:"customer_name,omitempty"`
+	CustomerPhone *string `bun:"customer_phone" json:"customer_phone,omitempty"`
+	Notes         *string `bun:"notes,type:text" json:"notes,omitempty"`
+	// Location the cart orders from, if it names one
+	LocationID *int `bun:"location_id" json:"location_id,omitempty"`
+
+	// Coupon to redeem at checkout, and the tip as an amount or a percentage
+	CouponCode *string          `bun:"coupon_code" json:"coupon_code,omitempty"`
+	Tip        *decimal.Decimal `bun:"tip,type:decimal(10,2)" json:"tip,omitempty"`
+	TipPercent *decimal.Decimal `bun:"tip_percent,type:decimal(5,2)" json:"tip_percent,omitempty"`
+
+	OrderID *string    `bun:"order_id" json:"order_id,omitempty"`
+	Items   []CartItem `bun:"rel:has-many,join:id=cart_id" json:"items"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (c *Cart) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if c.RestaurantID == 0 {
+			c.RestaurantID = database.RestaurantID(ctx)
+		}
+		if c.ID == "" {
+			c.ID = uuid.NewString()
+		}
+		now := time.Now()
+		c.CreatedAt = now
+		c.UpdatedAt = now
+	case *bun.UpdateQuery:
+		c.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// CartItem is a line of a cart. Name and UnitPrice are what the customer was shown
+// when the line was added or last checked.
+type CartItem struct {
+	bun.BaseModel `bun:"table:cart_items,alias:ci"`
+
+	ID         int             `bun:"id,pk,autoincrement" json:"id"`
+	CartID     string          `bun:"cart_id,notnull" json:"cart_id"`
+	MenuItemID int             `bun:"menu_item_id,notnull" json:"menu_item_id"`
+	Name       string          `bun:"name,notnull" json:"name"`
+	Quantity   int             `bun:"quantity,notnull" json:"quantity"`
+	Notes      *string         `bun:"notes,type:text" json:"notes,omitempty"`
+	UnitPrice  decimal.Decimal `bun:"unit_price,type:decimal(10,2),notnull" json:"unit_price"`
+	CreatedAt  time.Time       `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting
+func (i *CartItem) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	if _, ok := query.(*bun.InsertQuery); ok {
+		i.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+// CartQuery provides query methods for Cart
+type CartQuery struct {
+	db *bun.DB
+}
+
+// NewCartQuery creates a new query builder for Cart
+func NewCartQuery(db *bun.DB) *CartQuery {
+	return &CartQuery{db: db}
+}
+
+// FindByID finds a cart by ID with its lines, in the order they were added. It reads
+// from the primary, as carts are read right after each change.
+func (q *CartQuery) FindByID(ctx context.Context, id string) (*Cart, error) {
+	cart := new(Cart)
+	err := database.Scope(ctx, q.db.NewSelect().Model(cart)).
+		Relation("Items", func(q *bun.SelectQuery) *bun.SelectQuery { return q.Order("ci.id ASC") }).
+		Where("c.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// Create inserts a cart
+func (q *CartQuery) Create(ctx context.Context, cart *Cart) error {
+	_, err := q.db.NewInsert().Model(cart).Exec(ctx)
+	return err
+}
+
+// Update saves the columns of a cart but its restaurant, not its lines
+func (q *CartQuery) Update(ctx context.Context, cart *Cart) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model(cart)).WherePK().ExcludeColumn("restaurant_id", "created_at").Exec(ctx)
+	return err
+}
+
+// AddItem inserts a cart line
+func (q *CartQuery) AddItem(ctx context.Context, item *CartItem) error {
+	_, err := q.db.NewInsert().Model(item).Exec(ctx)
+	return err
+}
+
+// UpdateItem saves the quantity, notes, name and price of a cart line
+func (q *CartQuery) UpdateItem(ctx context.Context, item *CartItem) error {
+	_, err := q.db.NewUpdate().
+		Model(item).
+		Column("quantity", "notes", "name", "unit_price").
+		WherePK().
+		Exec(ctx)
+	return err
+}
+
+// DeleteItem removes a line of a cart
+func (q *CartQuery) DeleteItem(ctx context.Context, cartID string, itemID int) error {
+	_, err := q.db.NewDelete().Model((*CartItem)(nil)).Where("id = ? AND cart_id = ?", itemID, cartID).Exec(ctx)
+	return err
+}
+
+// CheckOut records the order a cart was converted into. It fails with
+// ErrCartCheckedOut when the cart was already checked out.
+func (q *CartQuery) CheckOut(ctx context.Context, cartID, orderID string) error {
+	res, err := database.Scope(ctx, q.db.NewUpdate().Model((*Cart)(nil))).
+		Set("order_id = ?", orderID).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ? AND order_id IS NULL", cartID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrCartCheckedOut
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// ErrCouponUsedUp is returned when creating an order redeeming a coupon that reached
+// its usage limit in the meantime
+var ErrCouponUsedUp = newOutcome("coupon used up")
+
+// ErrCouponCodeTaken is returned when saving a coupon whose code another coupon of
+// the restaurant took in the meantime
+var ErrCouponCodeTaken = newOutcome("coupon code already exists")
+
+// Coupon is a discount code customers redeem on orders. Like a pricing rule, it
+// discounts one menu item when MenuItemID is set, the items of a category when
+// Category is set, and otherwise the whole order.
+type Coupon struct {
+	bun.BaseModel `bun:"table:coupons,alias:c"`
+
+	ID           int     `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int     `bun:"restaurant_id,notnull" json:"-"` // Restaurant the coupon is redeemed at
+	Code         string  `bun:"code,notnull" json:"code"`       // Upper-case, unique per restaurant
+	Description  *string `bun:"description,type:text" json:"description,omitempty"`
+
+	DiscountType string          `bun:"discount_type,notnull" json:"discount_type"` // DiscountPercent or DiscountAmount
+	Discount     decimal.Decimal `bun:"discount,type:decimal(10,2),notnull" json:"discount"`
+	Category     *string         `bun:"category" json:"category,omitempty"`
+	MenuItemID   *int            `bun:"menu_item_id" json:"menu_item_id,omitempty"`
+
+	// Usage limits, unlimited when nil; customers are told apart by phone number
+	MaxUses            *int `bun:"max_uses" json:"max_uses,omitempty"`
+	MaxUsesPerCustomer *int `bun:"max_uses_per_customer" json:"max_uses_per_customer,omitempty"`
+	TimesUsed          int  `bun:"times_used,notnull" json:"times_used"`
+
+	// Validity window, open-ended when nil
+	ValidFrom  *time.Time `bun:"valid_from" json:"valid_from,omitempty"`
+	ValidUntil *time.Time `bun:"valid_until" json:"valid_until,omitempty"`
+	IsActive   bool       `bun:"is_active,notnull,default:true" json:"is_active"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (c *Coupon) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if c.RestaurantID == 0 {
+			c.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		c.CreatedAt = now
+		c.UpdatedAt = now
+	case *bun.UpdateQuery:
+		c.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// CouponRedemption records the use of a coupon on an order
+type CouponRedemption struct {
+	bun.BaseModel `bun:"table:coupon_redemptions,alias:cr"`
+
+	ID            int             `bun:"id,pk,autoincrement" json:"id"`
+	CouponID      int             `bun:"coupon_id,notnull" json:"coupon_id"`
+	OrderID       string          `bun:"order_id,notnull" json:"order_id"`
+	CustomerPhone *string         `bun:"customer_phone" json:"customer_phone,omitempty"`
+	Discount      decimal.Decimal `bun:"discount,type:decimal(10,2),notnull" json:"discount"`
+	CreatedAt     time.Time       `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// CouponQuery provides query methods for Coupon
+type CouponQuery struct {
+	db *bun.DB
+}
+
+// NewCouponQuery creates a new query builder for Coupon
+func NewCouponQuery(db *bun.DB) *CouponQuery {
+	return &CouponQuery{db: db}
+}
+
+// List returns all coupons by ID
+func (q *CouponQuery) List(ctx context.Context) ([]Coupon, error) {
+	var coupons []Coupon
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&coupons)).Order("c.id ASC").Scan(ctx)
+	return coupons, err
+}
+
+// FindByID finds a coupon by ID
+func (q *CouponQuery) FindByID(ctx context.Context, id int) (*Coupon, error) {
+	coupon := new(Coupon)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(coupon)).Where("c.id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return coupon, nil
+}
+
+// FindByCode finds a coupon by its upper-case code
+func (q *CouponQuery) FindByCode(ctx context.Context, code string) (*Coupon, error) {
+	coupon := new(Coupon)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(coupon)).Where("c.code = ?", code).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return coupon, nil
+}
+
+// Create inserts a coupon
+func (q *CouponQuery) Create(ctx context.Context, coupon *Coupon) error {
+	_, err := q.db.NewInsert().Model(coupon).Exec(ctx)
+	if database.IsUniqueViolation(err) {
+		return ErrCouponCodeTaken
+	}
+	return err
+}
+
+// Update saves every column of a coupon but its restaurant and usage count
+func (q *CouponQuery) Update(ctx context.Context, coupon *Coupon) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model(coupon)).WherePK().ExcludeColumn("restaurant_id", "created_at", "times_used").Exec(ctx)
+	if database.IsUniqueViolation(err) {
+		return ErrCouponCodeTaken
+	}
+	return err
+}
+
+// Delete removes a coupon with its redemptions; orders keep their discount
+func (q *CouponQuery) Delete(ctx context.Context, id int) error {
+	_, err := database.Scope(ctx, q.db.NewDelete().Model((*Coupon)(nil))).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+// Redemptions returns the redemptions of a coupon, newest first
+func (q *CouponQuery) Redemptions(ctx context.Context, couponID int) ([]CouponRedemption, error) {
+	var redemptions []CouponRedemption
+	err := database.Reader(ctx, q.db).NewSelect().
+		Model(&redemptions).
+		Where("cr.coupon_id = ?", couponID).
+		Order("cr.created_at DESC", "cr.id DESC").
+		Scan(ctx)
+	return redemptions, err
+}
+
+// CountRedemptions returns how often a customer redeemed a coupon. It reads from the
+// primary, as it is checked right before an order is inserted.
+func (q *CouponQuery) CountRedemptions(ctx context.Context, couponID int, customerPhone string) (int, error) {
+	return q.db.NewSelect().
+		Model((*CouponRedemption)(nil)).
+		Where("coupon_id = ? AND customer_phone = ?", couponID, customerPhone).
+		Count(ctx)
+}
+
+// redeem counts a use of the redemption's coupon, unless it reached its usage limit,
+// and records the redemption
+func redeem(ctx context.Context, tx bun.Tx, redemption *CouponRedemption) error {
+	res, err := database.Scope(ctx, tx.NewUpdate().Model((*Coupon)(nil))).
+		Set("times_used = times_used + 1").
+		Where("id = ?", redemption.CouponID).
+		Where("max_uses IS NULL OR times_used < max_uses").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrCouponUsedUp
+	}
+	_, err = tx.NewInsert().Model(redemption).Exec(ctx)
+	return err
+}
@@ -0,0 +1,269 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// CustomerData is the personal data stored about a customer at a restaurant, told
+// apart by phone number: their orders and carts, their reviews of items of those
+// orders, the coupons redeemed on them, and their loyalty and store credit accounts
+// when they have one
+type CustomerData struct {
+	Orders            []Order
+	Carts             []Cart
+	Reviews           []MenuItemReview
+	CouponRedemptions []CouponRedemption
+	Loyalty           *LoyaltyAccount
+	StoreCredit       *StoreCreditAccount
+}
+
+// Empty reports whether nothing is stored about the customer
+func (d *CustomerData) Empty() bool {
+	return len(d.Orders) == 0 && len(d.Carts) == 0 && len(d.Reviews) == 0 &&
+		len(d.CouponRedemptions) == 0 && d.Loyalty == nil && d.StoreCredit == nil
+}
+
+// CustomerErasure counts the rows an erasure anonymized
+type CustomerErasure struct {
+	Orders             int
+	Carts              int
+	Reviews            int
+	CouponRedemptions  int
+	LoyaltyAccount     bool
+	StoreCreditAccount bool
+}
+
+// Empty reports whether the erasure found nothing to anonymize
+func (e *CustomerErasure) Empty() bool {
+	return *e == CustomerErasure{}
+}
+
+// CustomerQuery provides the queries of data protection requests, across the tables
+// holding a customer's personal data
+type CustomerQuery struct {
+	db *bun.DB
+}
+
+// NewCustomerQuery creates a new query builder for customer data
+func NewCustomerQuery(db *bun.DB) *CustomerQuery {
+	return &CustomerQuery{db: db}
+}
+
+// Export loads the personal data of a customer at the restaurant of ctx, oldest first.
+// It reads from the primary, so the export has all the data written before it.
+func (q *CustomerQuery) Export(ctx context.Context, customerPhone string) (*CustomerData, error) {
+	data := &CustomerData{Orders: []Order{}, Carts: []Cart{}, Reviews: []MenuItemReview{}, CouponRedemptions: []CouponRedemption{}}
+	restaurantID := database.RestaurantID(ctx)
+
+	err := q.db.NewSelect().
+		Model(&data.Orders).
+		Relation("Items", orderItemsInOrder).
+		Relation("Promotions", orderPromotionsInOrder).
+		Relation("Payments", orderPaymentsInOrder).
+		Where("o.restaurant_id = ? AND o.customer_phone = ?", restaurantID, customerPhone).
+		Order("o.created_at ASC", "o.id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.db.NewSelect().
+		Model(&data.Carts).
+		Relation("Items", func(q *bun.SelectQuery) *bun.SelectQuery { return q.Order("ci.id ASC") }).
+		Where("c.restaurant_id = ? AND c.customer_phone = ?", restaurantID, customerPhone).
+		Order("c.created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data.Orders) > 0 {
+		orderIDs := make([]string, len(data.Orders))
+		for i := range data.Orders {
+			orderIDs[i] = data.Orders[i].ID
+		}
+		err = q.db.NewSelect().
+			Model(&data.Reviews).
+			Relation("MenuItem").
+			Where("mr.restaurant_id = ? AND mr.order_id IN (?)", restaurantID, bun.In(orderIDs)).
+			Order("mr.created_at ASC", "mr.id ASC").
+			Scan(ctx)
+		if err != nil {
+			return nil, err
+		}
+		err = q.db.NewSelect().
+			Model(&data.CouponRedemptions).
+			Where("cr.order_id IN (?)", bun.In(orderIDs)).
+			Order("cr.created_at ASC", "cr.id ASC").
+			Scan(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	loyalty := new(LoyaltyAccount)
+	err = q.db.NewSelect().
+		Model(loyalty).
+		Relation("Transactions", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("lt.created_at ASC", "lt.id ASC")
+		}).
+		Where("la.restaurant_id = ? AND la.customer_phone = ?", restaurantID, customerPhone).
+		Scan(ctx)
+	switch {
+	case err == nil:
+		data.Loyalty = loyalty
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+
+	credit := new(StoreCreditAccount)
+	err = q.db.NewSelect().
+		Model(credit).
+		Relation("Entries", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("sce.created_at ASC", "sce.id ASC")
+		}).
+		Where("sca.restaurant_id = ? AND sca.customer_phone = ?", restaurantID, customerPhone).
+		Scan(ctx)
+	switch {
+	case err == nil:
+		data.StoreCredit = credit
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+	return data, nil
+}
+
+// Erase anonymizes the personal data of a customer at the restaurant of ctx in one
+// transaction. Names, phone numbers, notes and the client IPs of reviews are cleared,
+// leaving orders, their lines, payments and totals as they were. The loyalty and store
+// credit accounts, whose balances are still owed, move to pseudonym, which takes the
+// customer's phone number in their ledgers; store credit notes are cleared.
+func (q *CustomerQuery) Erase(ctx context.Context, customerPhone, pseudonym string) (*CustomerErasure, error) {
+	erasure := &CustomerErasure{}
+	err := q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		restaurantID := database.RestaurantID(ctx)
+		now := time.Now()
+
+		var orderIDs []string
+		err := tx.NewSelect().
+			Model((*Order)(nil)).
+			Column("id").
+			Where("restaurant_id = ? AND customer_phone = ?", restaurantID, customerPhone).
+			Scan(ctx, &orderIDs)
+		if err != nil {
+			return err
+		}
+		if len(orderIDs) > 0 {
+			if erasure.Reviews, err = affected(tx.NewUpdate().
+				Model((*MenuItemReview)(nil)).
+				Set("author_name = NULL").
+				Set("client_ip = NULL").
+				Set("updated_at = ?", now).
+				Where("restaurant_id = ? AND order_id IN (?)", restaurantID, bun.In(orderIDs)).
+				Exec(ctx)); err != nil {
+				return err
+			}
+			if erasure.CouponRedemptions, err = affected(tx.NewUpdate().
+				Model((*CouponRedemption)(nil)).
+				Set("customer_phone = NULL").
+				Where("order_id IN (?) AND customer_phone IS NOT NULL", bun.In(orderIDs)).
+				Exec(ctx)); err != nil {
+				return err
+			}
+		}
+		if erasure.Orders, err = affected(tx.NewUpdate().
+			Model((*Order)(nil)).
+			Set("customer_name = NULL").
+			Set("customer_phone = NULL").
+			Set("notes = NULL").
+			Set("updated_at = ?", now).
+			Where("restaurant_id = ? AND customer_phone = ?", restaurantID, customerPhone).
+			Exec(ctx)); err != nil {
+			return err
+		}
+		if erasure.Carts, err = affected(tx.NewUpdate().
+			Model((*Cart)(nil)).
+			Set("customer_name = NULL").
+			Set("customer_phone = NULL").
+			Set("notes = NULL").
+			Set("updated_at = ?", now).
+			Where("restaurant_id = ? AND customer_phone = ?", restaurantID, customerPhone).
+			Exec(ctx)); err != nil {
+			return err
+		}
+
+		// Loyalty transactions have no foreign key to their account
+		accounts, err := affected(tx.NewUpdate().
+			Model((*LoyaltyAccount)(nil)).
+			Set("customer_phone = ?", pseudonym).
+			Set("updated_at = ?", now).
+			Where("restaurant_id = ? AND customer_phone = ?", restaurantID, customerPhone).
+			Exec(ctx))
+		if err != nil {
+			return err
+		}
+		if erasure.LoyaltyAccount = accounts > 0; erasure.LoyaltyAccount {
+			if _, err := tx.NewUpdate().
+				Model((*LoyaltyTransaction)(nil)).
+				Set("customer_phone = ?", pseudonym).
+				Where("restaurant_id = ? AND customer_phone = ?", restaurantID, customerPhone).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// Store credit entries reference their account, so the account is copied to the
+		// pseudonym before they move to it
+		account := new(StoreCreditAccount)
+		err = tx.NewSelect().
+			Model(account).
+			Where("sca.restaurant_id = ? AND sca.customer_phone = ?", restaurantID, customerPhone).
+			For("UPDATE").
+			Scan(ctx)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		erasure.StoreCreditAccount = true
+		account.CustomerPhone = pseudonym
+		account.UpdatedAt = now
+		if _, err := tx.NewInsert().Model(account).Exec(ctx); err != nil {
+			return err
+		}
+		if _, err := tx.NewUpdate().
+			Model((*StoreCreditEntry)(nil)).
+			Set("customer_phone = ?", pseudonym).
+			Set("note = NULL").
+			Where("restaurant_id = ? AND customer_phone = ?", restaurantID, customerPhone).
+			Exec(ctx); err != nil {
+			return err
+		}
+		_, err = tx.NewDelete().
+			Model((*StoreCreditAccount)(nil)).
+			Where("restaurant_id = ? AND customer_phone = ?", restaurantID, customerPhone).
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return erasure, nil
+}
+
+// affected returns the number of rows a statement changed
+func affected(res sql.Result, err error) (int, error) {
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// GeoPoint is a position in degrees of latitude and longitude
+type GeoPoint struct {
+	Lat float64 `json:"lat" example:"31.9539"`
+	Lng float64 `json:"lng" example:"35.9106"`
+}
+
+// ErrDeliveryZoneNameTaken is returned when saving a delivery zone whose name another
+// zone of the restaurant took in the meantime
+var ErrDeliveryZoneNameTaken = newOutcome("delivery zone name already exists")
+
+// DeliveryZone is an area the restaurant delivers to: a circle of RadiusMeters around
+// its center, or a polygon. Orders delivered there pay Fee and must total at least
+// MinimumOrder.
+type DeliveryZone struct {
+	bun.BaseModel `bun:"table:delivery_zones,alias:dz"`
+
+	ID           int    `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int    `bun:"restaurant_id,notnull" json:"-"` // Restaurant delivering to the zone
+	Name         string `bun:"name,notnull" json:"name"`
+
+	// Circle zones set the center and radius, polygon zones their points in order
+	CenterLat    *float64   `bun:"center_lat" json:"center_lat,omitempty"`
+	CenterLng    *float64   `bun:"center_lng" json:"center_lng,omitempty"`
+	RadiusMeters *int       `bun:"radius_meters" json:"radius_meters,omitempty"`
+	Polygon      []GeoPoint `bun:"polygon,type:text,nullzero" json:"polygon,omitempty"`
+
+	Fee          decimal.Decimal `bun:"fee,type:decimal(10,2),notnull" json:"fee"`
+	MinimumOrder decimal.Decimal `bun:"minimum_order,type:decimal(10,2),notnull" json:"minimum_order"`
+	IsActive     bool            `bun:"is_active,notnull" json:"is_active"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (z *DeliveryZone) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if z.RestaurantID == 0 {
+			z.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		z.CreatedAt = now
+		z.UpdatedAt = now
+	case *bun.UpdateQuery:
+		z.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// DeliveryZoneQuery provides query methods for DeliveryZone
+type DeliveryZoneQuery struct {
+	db *bun.DB
+}
+
+// NewDeliveryZoneQuery creates a new query builder for DeliveryZone
+func NewDeliveryZoneQuery(db *bun.DB) *DeliveryZoneQuery {
+	return &DeliveryZoneQuery{db: db}
+}
+
+// List returns all delivery zones by name
+func (q *DeliveryZoneQuery) List(ctx context.Context) ([]DeliveryZone, error) {
+	var zones []DeliveryZone
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&zones)).Order("dz.name ASC").Scan(ctx)
+	return zones, err
+}
+
+// Active returns the zones the restaurant currently delivers to, by ID
+func (q *DeliveryZoneQuery) Active(ctx context.Context) ([]DeliveryZone, error) {
+	var zones []DeliveryZone
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&zones)).
+		Where("dz.is_active = ?", true).
+		Order("dz.id ASC").
+		Scan(ctx)
+	return zones, err
+}
+
+// FindByID finds a delivery zone by ID
+func (q *DeliveryZoneQuery) FindByID(ctx context.Context, id int) (*DeliveryZone, error) {
+	zone := new(DeliveryZone)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(zone)).Where("dz.id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// FindByName finds a delivery zone by name. It reads from the primary, as it guards
+// against two zones having the same name.
+func (q *DeliveryZoneQuery) FindByName(ctx context.Context, name string) (*DeliveryZone, error) {
+	zone := new(DeliveryZone)
+	err := database.Scope(ctx, q.db.NewSelect().Model(zone)).Where("dz.name = ?", name).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// Create inserts a delivery zone
+func (q *DeliveryZoneQuery) Create(ctx context.Context, zone *DeliveryZone) error {
+	_, err := q.db.NewInsert().Model(zone).Exec(ctx)
+	if database.IsUniqueViolation(err) {
+		return ErrDeliveryZoneNameTaken
+	}
+	return err
+}
+
+// Update saves every column of a delivery zone but its restaurant
+func (q *DeliveryZoneQuery) Update(ctx context.Context, zone *DeliveryZone) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model(zone)).WherePK().ExcludeColumn("restaurant_id", "created_at").Exec(ctx)
+	if database.IsUniqueViolation(err) {
+		return ErrDeliveryZoneNameTaken
+	}
+	return err
+}
+
+// Delete removes a delivery zone
+func (q *DeliveryZoneQuery) Delete(ctx context.Context, id int) error {
+	_, err := database.Scope(ctx, q.db.NewDelete().Model((*DeliveryZone)(nil))).Where("id = ?", id).Exec(ctx)
+	return err
+}
@@ -0,0 +1,190 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Statuses of a dining table through its service, as the host stand sets them
+const (
+	TableStatusFree          = "free"
+	TableStatusSeated        = "seated"
+	TableStatusOrdered       = "ordered"
+	TableStatusCheckDropped  = "check_dropped"
+	TableStatusNeedsCleaning = "needs_cleaning"
+)
+
+// ErrTableNameTaken is returned when saving a table whose name another table of the
+// restaurant took in the meantime
+var ErrTableNameTaken = newOutcome("table name already exists")
+
+// DiningTable is a table of the restaurant. Token is encoded in the QR code on the
+// table; scanning it starts a guest session ordering for the table.
+type DiningTable struct {
+	bun.BaseModel `bun:"table:dining_tables,alias:dt"`
+
+	ID           int    `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int    `bun:"restaurant_id,notnull" json:"-"` // Restaurant the table is in
+	Name         string `bun:"name,notnull" json:"name"`
+	Token        string `bun:"token,notnull" json:"token"`
+
+	// Status is only changed by SetStatus, so renaming a table doesn't undo a change
+	// made meanwhile
+	Status          string    `bun:"status,notnull" json:"status"`
+	StatusChangedAt time.Time `bun:"status_changed_at,nullzero,notnull,default:current_timestamp" json:"status_changed_at"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (t *DiningTable) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if t.RestaurantID == 0 {
+			t.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		t.CreatedAt = now
+		t.UpdatedAt = now
+		t.StatusChangedAt = now
+	case *bun.UpdateQuery:
+		t.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// GuestSession lets guests at a table browse the menu and order for the table, with
+// Token, until ExpiresAt
+type GuestSession struct {
+	bun.BaseModel `bun:"table:guest_sessions,alias:gs"`
+
+	ID        int          `bun:"id,pk,autoincrement" json:"id"`
+	Token     string       `bun:"token,notnull" json:"token"`
+	TableID   int          `bun:"table_id,notnull" json:"table_id"`
+	Table     *DiningTable `bun:"rel:belongs-to,join:table_id=id" json:"table,omitempty"`
+	ExpiresAt time.Time    `bun:"expires_at,notnull" json:"expires_at"`
+	CreatedAt time.Time    `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// DiningTableQuery provides query methods for DiningTable and GuestSession
+type DiningTableQuery struct {
+	db *bun.DB
+}
+
+// NewDiningTableQuery creates a new query builder for DiningTable
+func NewDiningTableQuery(db *bun.DB) *DiningTableQuery {
+	return &DiningTableQuery{db: db}
+}
+
+// List returns all tables by name
+func (q *DiningTableQuery) List(ctx context.Context) ([]DiningTable, error) {
+	var tables []DiningTable
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&tables)).Order("dt.name ASC").Scan(ctx)
+	return tables, err
+}
+
+// FindByID finds a table by ID
+func (q *DiningTableQuery) FindByID(ctx context.Context, id int) (*DiningTable, error) {
+	table := new(DiningTable)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(table)).Where("dt.id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// FindByName finds a table by name. It reads from the primary, as it guards against
+// two tables having the same name.
+func (q *DiningTableQuery) FindByName(ctx context.Context, name string) (*DiningTable, error) {
+	table := new(DiningTable)
+	err := database.Scope(ctx, q.db.NewSelect().Model(table)).Where("dt.name = ?", name).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// FindByToken finds the table whose QR code carries token, in any restaurant: table
+// tokens are unique across restaurants. It reads from the primary, as guests scan the
+// code of a table right after it is printed.
+func (q *DiningTableQuery) FindByToken(ctx context.Context, token string) (*DiningTable, error) {
+	table := new(DiningTable)
+	err := q.db.NewSelect().Model(table).Where("dt.token = ?", token).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// Create inserts a table
+func (q *DiningTableQuery) Create(ctx context.Context, table *DiningTable) error {
+	_, err := q.db.NewInsert().Model(table).Exec(ctx)
+	if database.IsUniqueViolation(err) {
+		return ErrTableNameTaken
+	}
+	return err
+}
+
+// Update saves every column of a table but its restaurant and status
+func (q *DiningTableQuery) Update(ctx context.Context, table *DiningTable) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model(table)).
+		WherePK().
+		ExcludeColumn("restaurant_id", "created_at", "status", "status_changed_at").
+		Exec(ctx)
+	if database.IsUniqueViolation(err) {
+		return ErrTableNameTaken
+	}
+	return err
+}
+
+// SetStatus sets the status of a table, changed at at
+func (q *DiningTableQuery) SetStatus(ctx context.Context, id int, status string, at time.Time) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model((*DiningTable)(nil))).
+		Set("status = ?", status).
+		Set("status_changed_at = ?", at).
+		Set("updated_at = ?", at).
+		Where("id = ?", id).
+		Exec(ctx)
+	return err
+}
+
+// Delete removes a table and its guest sessions; orders keep the table's name
+func (q *DiningTableQuery) Delete(ctx context.Context, id int) error {
+	_, err := database.Scope(ctx, q.db.NewDelete().Model((*DiningTable)(nil))).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+// CreateSession inserts a guest session
+func (q *DiningTableQuery) CreateSession(ctx context.Context, session *GuestSession) error {
+	_, err := q.db.NewInsert().Model(session).Exec(ctx)
+	return err
+}
+
+// FindSession finds a guest session by token, with its table, in any restaurant:
+// session tokens are unique across restaurants. It reads from the primary, as guests
+// use a session right after starting it.
+func (q *DiningTableQuery) FindSession(ctx context.Context, token string) (*GuestSession, error) {
+	session := new(GuestSession)
+	err := q.db.NewSelect().Model(session).Relation("Table").Where("gs.token = ?", token).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// EndTableSessions expires the sessions of a table that are still open at now, as
+// when its QR code is replaced
+func (q *DiningTableQuery) EndTableSessions(ctx context.Context, tableID int, now time.Time) error {
+	_, err := q.db.NewUpdate().
+		Model((*GuestSession)(nil)).
+		Set("expires_at = ?", now).
+		Where("table_id = ?", tableID).
+		Where("expires_at > ?", now).
+		Exec(ctx)
+	return err
+}
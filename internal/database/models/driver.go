@@ -0,0 +1,172 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Delivery statuses of a delivery order, in the order they are reached
+const (
+	DeliveryStatusAssigned  = "assigned"
+	DeliveryStatusPickedUp  = "picked_up"
+	DeliveryStatusDelivered = "delivered"
+)
+
+// ErrDeliveryStatusChanged is returned when an order's delivery moved on, or the
+// order was closed, before it could be updated
+var ErrDeliveryStatusChanged = newOutcome("delivery status changed")
+
+// Driver delivers the restaurant's delivery orders. Inactive drivers keep their past
+// deliveries but aren't assigned new ones.
+type Driver struct {
+	bun.BaseModel `bun:"table:drivers,alias:dr"`
+
+	ID           int     `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int     `bun:"restaurant_id,notnull" json:"-"` // Restaurant the driver delivers for
+	Name         string  `bun:"name,notnull" json:"name"`
+	Phone        *string `bun:"phone" json:"phone,omitempty"`
+	IsActive     bool    `bun:"is_active,notnull" json:"is_active"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (d *Driver) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if d.RestaurantID == 0 {
+			d.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		d.CreatedAt = now
+		d.UpdatedAt = now
+	case *bun.UpdateQuery:
+		d.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// DriverQuery provides query methods for Driver
+type DriverQuery struct {
+	db *bun.DB
+}
+
+// NewDriverQuery creates a new query builder for Driver
+func NewDriverQuery(db *bun.DB) *DriverQuery {
+	return &DriverQuery{db: db}
+}
+
+// List returns all drivers by name
+func (q *DriverQuery) List(ctx context.Context) ([]Driver, error) {
+	var drivers []Driver
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&drivers)).Order("dr.name ASC", "dr.id ASC").Scan(ctx)
+	return drivers, err
+}
+
+// FindByID finds a driver by ID
+func (q *DriverQuery) FindByID(ctx context.Context, id int) (*Driver, error) {
+	driver := new(Driver)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(driver)).Where("dr.id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return driver, nil
+}
+
+// Create inserts a driver
+func (q *DriverQuery) Create(ctx context.Context, driver *Driver) error {
+	_, err := q.db.NewInsert().Model(driver).Exec(ctx)
+	return err
+}
+
+// Update saves every column of a driver but its restaurant
+func (q *DriverQuery) Update(ctx context.Context, driver *Driver) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model(driver)).WherePK().ExcludeColumn("restaurant_id", "created_at").Exec(ctx)
+	return err
+}
+
+// Delete removes a driver; their past deliveries keep no driver
+func (q *DriverQuery) Delete(ctx context.Context, id int) error {
+	_, err := database.Scope(ctx, q.db.NewDelete().Model((*Driver)(nil))).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+// AssignDriver gives a delivery order to a driver at at, or to another driver before
+// the order is picked up. It fails with ErrDeliveryStatusChanged once the order was
+// picked up, completed or cancelled.
+func (q *OrderQuery) AssignDriver(ctx context.Context, orderID string, driverID int, at time.Time) error {
+	res, err := database.Scope(ctx, q.db.NewUpdate().Model((*Order)(nil))).
+		Set("driver_id = ?", driverID).
+		Set("delivery_status = ?", DeliveryStatusAssigned).
+		Set("assigned_at = ?", at).
+		Set("updated_at = ?", at).
+		Where("id = ?", orderID).
+		Where("delivery_status IS NULL OR delivery_status = ?", DeliveryStatusAssigned).
+		Where("status NOT IN (?)", bun.In([]string{OrderStatusCompleted, OrderStatusCancelled})).
+		Exec(ctx)
+	return deliveryUpdated(res, err)
+}
+
+// PickUp records that the driver of an order picked it up at at. It fails with
+// ErrDeliveryStatusChanged unless the order is assigned and still open.
+func (q *OrderQuery) PickUp(ctx context.Context, orderID string, at time.Time) error {
+	res, err := database.Scope(ctx, q.db.NewUpdate().Model((*Order)(nil))).
+		Set("delivery_status = ?", DeliveryStatusPickedUp).
+		Set("picked_up_at = ?", at).
+		Set("updated_at = ?", at).
+		Where("id = ?", orderID).
+		Where("delivery_status = ?", DeliveryStatusAssigned).
+		Where("status NOT IN (?)", bun.In([]string{OrderStatusCompleted, OrderStatusCancelled})).
+		Exec(ctx)
+	return deliveryUpdated(res, err)
+}
+
+// Deliver records that an order was delivered at at, which completes it. It fails
+// with ErrDeliveryStatusChanged unless the order was picked up.
+func (q *OrderQuery) Deliver(ctx context.Context, orderID string, at time.Time) error {
+	res, err := database.Scope(ctx, q.db.NewUpdate().Model((*Order)(nil))).
+		Set("delivery_status = ?", DeliveryStatusDelivered).
+		Set("delivered_at = ?", at).
+		Set("status = ?", OrderStatusCompleted).
+		Set("updated_at = ?", at).
+		Where("id = ?", orderID).
+		Where("delivery_status = ?", DeliveryStatusPickedUp).
+		Where("status <> ?", OrderStatusCancelled).
+		Exec(ctx)
+	return deliveryUpdated(res, err)
+}
+
+// DriverOrders returns the orders a driver has yet to deliver, oldest first, with
+// their items
+func (q *OrderQuery) DriverOrders(ctx context.Context, driverID int) ([]Order, error) {
+	var orders []Order
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&orders)).
+		Relation("Items", orderItemsInOrder).
+		Relation("Driver").
+		Where("o.driver_id = ?", driverID).
+		Where("o.delivery_status IN (?)", bun.In([]string{DeliveryStatusAssigned, DeliveryStatusPickedUp})).
+		Where("o.status <> ?", OrderStatusCancelled).
+		Order("o.assigned_at ASC", "o.id ASC").
+		Scan(ctx)
+	return orders, err
+}
+
+// deliveryUpdated turns an update of an order's delivery that matched no row into
+// ErrDeliveryStatusChanged
+func deliveryUpdated(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrDeliveryStatusChanged
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package models
+
+import "errors"
+
+// outcomeError is a business rule a query refused a change by, such as a full order
+// slot, as opposed to a failure of the database
+type outcomeError struct {
+	msg string
+}
+
+func (e *outcomeError) Error() string {
+	return e.msg
+}
+
+// newOutcome creates a sentinel error for a business rule outcome
+func newOutcome(msg string) error {
+	return &outcomeError{msg: msg}
+}
+
+// IsOutcome reports whether err is, or wraps, a business rule outcome of a query
+// rather than a database failure
+func IsOutcome(err error) bool {
+	var outcome *outcomeError
+	return errors.As(err, &outcome)
+}
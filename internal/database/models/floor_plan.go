@@ -0,0 +1,120 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// ErrFloorPlanChanged is returned when the floor plan was saved since the version an
+// update was made from
+var ErrFloorPlanChanged = newOutcome("floor plan changed")
+
+// FloorPlan is a restaurant's seating map: its sections and where each table stands.
+// Each restaurant has one. Version counts the times it was saved.
+type FloorPlan struct {
+	bun.BaseModel `bun:"table:floor_plans,alias:fp"`
+
+	RestaurantID int             `bun:"restaurant_id,pk" json:"-"`
+	Layout       FloorPlanLayout `bun:"layout,type:text,notnull" json:"layout"`
+	Version      int             `bun:"version,notnull" json:"version"`
+	UpdatedAt    time.Time       `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// FloorPlanLayout is the floor plan document, in the units the host-stand UI draws
+// it in, with the origin at the top left
+type FloorPlanLayout struct {
+	Width    int                `json:"width" example:"1200"`
+	Height   int                `json:"height" example:"800"`
+	Sections []FloorPlanSection `json:"sections"`
+	Tables   []FloorPlanTable   `json:"tables"`
+}
+
+// FloorPlanSection is an area of the floor, such as a terrace or a server's station
+type FloorPlanSection struct {
+	Name  string  `json:"name" example:"Terrace"`
+	Color *string `json:"color,omitempty" example:"#4caf50"`
+}
+
+// FloorPlanTable is where a dining table stands on the floor plan: the top left
+// corner of its bounding box, its size and its clockwise rotation in degrees
+type FloorPlanTable struct {
+	TableID  int     `json:"table_id" example:"3"`
+	Section  *string `json:"section,omitempty" example:"Terrace"`
+	Shape    string  `json:"shape" example:"rectangle" enums:"rectangle,circle"`
+	X        int     `json:"x" example:"120"`
+	Y        int     `json:"y" example:"80"`
+	Width    int     `json:"width" example:"90"`
+	Height   int     `json:"height" example:"60"`
+	Rotation int     `json:"rotation" example:"0"`
+	Seats    int     `json:"seats" example:"4"`
+}
+
+// FloorPlanQuery provides query methods for FloorPlan
+type FloorPlanQuery struct {
+	db *bun.DB
+}
+
+// NewFloorPlanQuery creates a new query builder for FloorPlan
+func NewFloorPlanQuery(db *bun.DB) *FloorPlanQuery {
+	return &FloorPlanQuery{db: db}
+}
+
+// Find returns the floor plan of the restaurant of ctx, an empty one at version 0
+// when the restaurant hasn't saved one yet
+func (q *FloorPlanQuery) Find(ctx context.Context) (*FloorPlan, error) {
+	restaurant := database.RestaurantID(ctx)
+	plan := new(FloorPlan)
+	err := database.Reader(ctx, q.db).NewSelect().Model(plan).Where("fp.restaurant_id = ?", restaurant).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return emptyFloorPlan(restaurant), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// Save replaces the layout of the floor plan of the restaurant of ctx at at, if it is
+// still at version, and bumps its version. It fails with ErrFloorPlanChanged once the
+// plan was saved since.
+func (q *FloorPlanQuery) Save(ctx context.Context, layout FloorPlanLayout, version int, at time.Time) error {
+	restaurant := database.RestaurantID(ctx)
+	if version == 0 {
+		// The restaurant's first save: store the empty plan it is made from
+		if _, err := q.db.NewInsert().Model(emptyFloorPlan(restaurant)).Ignore().Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	res, err := q.db.NewUpdate().
+		Model((*FloorPlan)(nil)).
+		Set("layout = ?", layout).
+		Set("version = version + 1").
+		Set("updated_at = ?", at).
+		Where("restaurant_id = ?", restaurant).
+		Where("version = ?", version).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrFloorPlanChanged
+	}
+	return nil
+}
+
+// emptyFloorPlan is the floor plan of a restaurant that hasn't saved one
+func emptyFloorPlan(restaurant int) *FloorPlan {
+	return &FloorPlan{
+		RestaurantID: restaurant,
+		Layout:       FloorPlanLayout{Sections: []FloorPlanSection{}, Tables: []FloorPlanTable{}},
+	}
+}
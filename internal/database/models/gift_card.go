@@ -0,0 +1,166 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Gift card transaction types
+const (
+	GiftCardIssue  = "issue"
+	GiftCardRedeem = "redeem"
+)
+
+// ErrGiftCardBalance is returned when redeeming more than is left on a gift card
+var ErrGiftCardBalance = newOutcome("gift card balance too low")
+
+// ErrGiftCardCodeTaken is returned when issuing a gift card whose code another card
+// of the restaurant took in the meantime
+var ErrGiftCardCodeTaken = newOutcome("gift card code already exists")
+
+// GiftCard is a prepaid card customers pay with until its balance runs out
+type GiftCard struct {
+	bun.BaseModel `bun:"table:gift_cards,alias:gc"`
+
+	ID             int             `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID   int             `bun:"restaurant_id,notnull" json:"-"` // Restaurant the card is redeemed at
+	Code           string          `bun:"code,notnull" json:"code"`       // Upper-case, unique per restaurant
+	InitialBalance decimal.Decimal `bun:"initial_balance,type:decimal(10,2),notnull" json:"initial_balance"`
+	Balance        decimal.Decimal `bun:"balance,type:decimal(10,2),notnull" json:"balance"`
+	RecipientName  *string         `bun:"recipient_name" json:"recipient_name,omitempty"`
+
+	// Expiry, never when nil
+	ExpiresAt *time.Time `bun:"expires_at" json:"expires_at,omitempty"`
+	IsActive  bool       `bun:"is_active,notnull,default:true" json:"is_active"`
+
+	Transactions []GiftCardTransaction `bun:"rel:has-many,join:id=gift_card_id" json:"transactions,omitempty"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (g *GiftCard) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if g.RestaurantID == 0 {
+			g.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		g.CreatedAt = now
+		g.UpdatedAt = now
+	case *bun.UpdateQuery:
+		g.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// GiftCardTransaction records a change to a gift card's balance. Amount is positive
+// when issuing and negative when redeeming; Balance is the balance after it.
+type GiftCardTransaction struct {
+	bun.BaseModel `bun:"table:gift_card_transactions,alias:gct"`
+
+	ID         int             `bun:"id,pk,autoincrement" json:"id"`
+	GiftCardID int             `bun:"gift_card_id,notnull" json:"gift_card_id"`
+	OrderID    *string         `bun:"order_id" json:"order_id,omitempty"` // Order paid by a redemption
+	Type       string          `bun:"type,notnull" json:"type"`           // GiftCardIssue or GiftCardRedeem
+	Amount     decimal.Decimal `bun:"amount,type:decimal(10,2),notnull" json:"amount"`
+	Balance    decimal.Decimal `bun:"balance,type:decimal(10,2),notnull" json:"balance"`
+	CreatedAt  time.Time       `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// GiftCardQuery provides query methods for GiftCard
+type GiftCardQuery struct {
+	db *bun.DB
+}
+
+// NewGiftCardQuery creates a new query builder for GiftCard
+func NewGiftCardQuery(db *bun.DB) *GiftCardQuery {
+	return &GiftCardQuery{db: db}
+}
+
+// FindByCode finds a gift card by its upper-case code with its transactions, newest
+// first
+func (q *GiftCardQuery) FindByCode(ctx context.Context, code string) (*GiftCard, error) {
+	card := new(GiftCard)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(card)).
+		Relation("Transactions", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("gct.created_at DESC", "gct.id DESC")
+		}).
+		Where("gc.code = ?", code).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// Create inserts a gift card with its issue transaction for its initial balance
+func (q *GiftCardQuery) Create(ctx context.Context, card *GiftCard) error {
+	return q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		card.Balance = card.InitialBalance
+		if _, err := tx.NewInsert().Model(card).Exec(ctx); err != nil {
+			if database.IsUniqueViolation(err) {
+				return ErrGiftCardCodeTaken
+			}
+			return err
+		}
+		card.Transactions = []GiftCardTransaction{{
+			GiftCardID: card.ID,
+			Type:       GiftCardIssue,
+			Amount:     card.InitialBalance,
+			Balance:    card.Balance,
+			CreatedAt:  card.CreatedAt,
+		}}
+		_, err := tx.NewInsert().Model(&card.Transactions).Exec(ctx)
+		return err
+	})
+}
+
+// Redeem takes amount off a gift card's balance in one transaction, paying it towards
+// an order when payment is non-nil. It fails with ErrGiftCardBalance when the balance
+// is too low and like OrderQuery.Pay when paying the order fails.
+func (q *GiftCardQuery) Redeem(ctx context.Context, card *GiftCard, amount decimal.Decimal, payment *OrderPayment) (*GiftCardTransaction, error) {
+	transaction := &GiftCardTransaction{GiftCardID: card.ID, Type: GiftCardRedeem, Amount: amount.Neg()}
+	err := q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := database.Scope(ctx, tx.NewUpdate().Model((*GiftCard)(nil))).
+			Set("balance = balance - ?", amount).
+			Set("updated_at = ?", time.Now()).
+			Where("id = ? AND balance >= ?", card.ID, amount).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return ErrGiftCardBalance
+		}
+
+		if payment != nil {
+			payment.Method = PaymentGiftCard
+			payment.Amount = amount
+			if err := pay(ctx, tx, payment); err != nil {
+				return err
+			}
+			transaction.OrderID = &payment.OrderID
+		}
+
+		if err := tx.NewSelect().Model((*GiftCard)(nil)).Column("balance").Where("id = ?", card.ID).
+			Scan(ctx, &transaction.Balance); err != nil {
+			return err
+		}
+		_, err = tx.NewInsert().Model(transaction).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	card.Balance = transaction.Balance
+	return transaction, nil
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// ErrTicketPrepared is returned when every line of a station's ticket was already
+// marked prepared
+var ErrTicketPrepared = newOutcome("ticket already prepared")
+
+// kitchenStatuses are the statuses of orders the kitchen may still be preparing
+var kitchenStatuses = []string{OrderStatusPending, OrderStatusAccepted, OrderStatusPreparing}
+
+// InKitchen reports whether the kitchen is still expected to be preparing the order
+// at now. Orders for a later time slot aren't in the kitchen until their slot starts.
+func (o Order) InKitchen(now time.Time) bool {
+	return o.EstimatedReadyAt != nil && o.EstimatedReadyAt.After(now) && slices.Contains(kitchenStatuses, o.Status) &&
+		(o.SlotAt == nil || !o.SlotAt.After(now))
+}
+
+// inKitchen restricts a query on orders (o) to those in the kitchen at now, see
+// Order.InKitchen
+func inKitchen(now time.Time) func(*bun.SelectQuery) *bun.SelectQuery {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("o.estimated_ready_at > ?", now).Where("o.status IN (?)", bun.In(kitchenStatuses)).
+			Where("o.slot_at IS NULL OR o.slot_at <= ?", now)
+	}
+}
+
+// StationQueues counts, per kitchen station, the orders still in the kitchen with
+// lines the station hasn't prepared, among those placed before placedBefore when it
+// is not zero. It reads from the primary, as the counts feed new estimates.
+func (q *OrderQuery) StationQueues(ctx context.Context, placedBefore time.Time) (map[string]int, error) {
+	var counts []struct {
+		Station string `bun:"station"`
+		Orders  int    `bun:"orders"`
+	}
+	query := database.ScopeAs(ctx, q.db.NewSelect().TableExpr("order_items AS oi"), "o").
+		Join("JOIN orders AS o ON o.id = oi.order_id").
+		ColumnExpr("oi.station").
+		ColumnExpr("COUNT(DISTINCT oi.order_id) AS orders").
+		Where("oi.station IS NOT NULL").
+		Where("oi.prepared_at IS NULL").
+		Apply(inKitchen(time.Now())).
+		GroupExpr("oi.station")
+	if !placedBefore.IsZero() {
+		query = query.Where("o.created_at < ?", placedBefore)
+	}
+	if err := query.Scan(ctx, &counts); err != nil {
+		return nil, err
+	}
+
+	queues := make(map[string]int, len(counts))
+	for _, count := range counts {
+		queues[count.Station] = count.Orders
+	}
+	return queues, nil
+}
+
+// QueuedAt returns the orders still in the kitchen with lines station hasn't
+// prepared that were placed at or after placed, oldest first, with their items
+func (q *OrderQuery) QueuedAt(ctx context.Context, station string, placed time.Time) ([]Order, error) {
+	var orders []Order
+	err := database.Scope(ctx, q.db.NewSelect().Model(&orders)).
+		Relation("Items", orderItemsInOrder).
+		Where("EXISTS (SELECT 1 FROM order_items AS oi WHERE oi.order_id = o.id AND oi.station = ? AND oi.prepared_at IS NULL)", station).
+		Where("o.created_at >= ?", placed).
+		Apply(inKitchen(time.Now())).
+		Order("o.created_at ASC", "o.id ASC").
+		Scan(ctx)
+	return orders, err
+}
+
+// PrepareItems marks the given lines of an order, those of one station's ticket, as
+// prepared at at. It fails with ErrTicketPrepared when none was left to prepare.
+func (q *OrderQuery) PrepareItems(ctx context.Context, orderID string, itemIDs []int, at time.Time) error {
+	res, err := q.db.NewUpdate().
+		Model((*OrderItem)(nil)).
+		Set("prepared_at = ?", at).
+		Where("order_id = ?", orderID).
+		Where("id IN (?)", bun.In(itemIDs)).
+		Where("prepared_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrTicketPrepared
+	}
+	return nil
+}
+
+// SetReadyEstimate records when the kitchen is expected to have prepared an order
+func (q *OrderQuery) SetReadyEstimate(ctx context.Context, orderID string, at time.Time) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model((*Order)(nil))).
+		Set("estimated_ready_at = ?", at).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", orderID).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,327 @@
+package models
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Location is a branch of a restaurant. Branches share the restaurant's menu, and
+// may override the availability and price of its items.
+type Location struct {
+	bun.BaseModel `bun:"table:locations,alias:loc"`
+
+	ID           int    `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int    `bun:"restaurant_id,notnull" json:"-"` // Restaurant the branch belongs to
+	Name         string `bun:"name,notnull" json:"name"`
+
+	Address   *string  `bun:"address" json:"address,omitempty"`
+	Latitude  *float64 `bun:"latitude" json:"latitude,omitempty"` // Set along with Longitude, for nearby searches
+	Longitude *float64 `bun:"longitude" json:"longitude,omitempty"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (l *Location) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if l.RestaurantID == 0 {
+			l.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		l.CreatedAt = now
+		l.UpdatedAt = now
+	case *bun.UpdateQuery:
+		l.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// LocationMenuItem overrides a menu item at a location. A nil IsAvailable or Price
+// keeps the menu item's own.
+type LocationMenuItem struct {
+	bun.BaseModel `bun:"table:location_menu_items,alias:lmi"`
+
+	LocationID  int              `bun:"location_id,pk" json:"location_id"`
+	MenuItemID  int              `bun:"menu_item_id,pk" json:"menu_item_id"`
+	IsAvailable *bool            `bun:"is_available" json:"is_available,omitempty"`
+	Price       *decimal.Decimal `bun:"price,type:decimal(10,2)" json:"price,omitempty"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// LocationQuery provides query methods for Location, its menu overrides and its
+// opening hours
+type LocationQuery struct {
+	db *bun.DB
+}
+
+// NewLocationQuery creates a new query builder for Location
+func NewLocationQuery(db *bun.DB) *LocationQuery {
+	return &LocationQuery{db: db}
+}
+
+// List returns all locations by name
+func (q *LocationQuery) List(ctx context.Context) ([]Location, error) {
+	var locations []Location
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&locations)).Order("loc.name ASC").Scan(ctx)
+	return locations, err
+}
+
+// FindByID finds a location by ID
+func (q *LocationQuery) FindByID(ctx context.Context, id int) (*Location, error) {
+	location := new(Location)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(location)).Where("loc.id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return location, nil
+}
+
+// FindByName finds a location by name. It reads from the primary, as it guards
+// against two locations having the same name.
+func (q *LocationQuery) FindByName(ctx context.Context, name string) (*Location, error) {
+	location := new(Location)
+	err := database.Scope(ctx, q.db.NewSelect().Model(location)).Where("loc.name = ?", name).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return location, nil
+}
+
+// Create inserts a location
+func (q *LocationQuery) Create(ctx context.Context, location *Location) error {
+	_, err := q.db.NewInsert().Model(location).Exec(ctx)
+	return err
+}
+
+// Update saves the name, address and coordinates of a location
+func (q *LocationQuery) Update(ctx context.Context, location *Location) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model(location)).
+		WherePK().
+		Column("name", "address", "latitude", "longitude", "updated_at").
+		Exec(ctx)
+	return err
+}
+
+// NearbyLocation is a location with its distance from the point it was searched from
+type NearbyLocation struct {
+	Location `bun:",extend"`
+
+	DistanceMeters float64 `bun:"distance_meters,scanonly" json:"distance_meters"`
+}
+
+// earthRadiusMeters is the mean radius of the Earth, and metersPerDegree the length of
+// a degree of latitude on it
+const (
+	earthRadiusMeters = 6371008.8
+	metersPerDegree   = earthRadiusMeters * math.Pi / 180
+)
+
+// Nearby returns up to limit locations with coordinates within radius meters of a
+// point, nearest first. PostgreSQL measures with earthdistance; MySQL, which has no
+// equivalent on MariaDB, with the haversine formula over a bounding box of the point.
+func (q *LocationQuery) Nearby(ctx context.Context, lat, lon, radius float64, limit int) ([]NearbyLocation, error) {
+	var locations []NearbyLocation
+	query := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&locations)).
+		ColumnExpr("loc.*").
+		Where("loc.latitude IS NOT NULL AND loc.longitude IS NOT NULL")
+
+	if database.IsMySQL(q.db) {
+		const distance = "2 * ? * ASIN(LEAST(1, SQRT(" +
+			"POWER(SIN(RADIANS(loc.latitude - ?) / 2), 2) + " +
+			"COS(RADIANS(?)) * COS(RADIANS(loc.latitude)) * POWER(SIN(RADIANS(loc.longitude - ?) / 2), 2))))"
+		query = query.ColumnExpr(distance+" AS distance_meters", earthRadiusMeters, lat, lat, lon).
+			Where(distance+" <= ?", earthRadiusMeters, lat, lat, lon, radius)
+
+		// The bounding box narrows the search on the coordinates index. Longitudes
+		// are left out near the poles and across the antimeridian.
+		latDelta := radius / metersPerDegree
+		query = query.Where("loc.latitude BETWEEN ? AND ?", lat-latDelta, lat+latDelta)
+		if cos := math.Cos(lat * math.Pi / 180); cos > 0.01 {
+			lonDelta := latDelta / cos
+			if lon-lonDelta >= -180 && lon+lonDelta <= 180 {
+				query = query.Where("loc.longitude BETWEEN ? AND ?", lon-lonDelta, lon+lonDelta)
+			}
+		}
+	} else {
+		query = query.ColumnExpr("earth_distance(ll_to_earth(?, ?), ll_to_earth(loc.latitude, loc.longitude)) AS distance_meters", lat, lon).
+			Where("earth_box(ll_to_earth(?, ?), ?) @> ll_to_earth(loc.latitude, loc.longitude)", lat, lon, radius).
+			Where("earth_distance(ll_to_earth(?, ?), ll_to_earth(loc.latitude, loc.longitude)) <= ?", lat, lon, radius)
+	}
+
+	err := query.OrderExpr("distance_meters ASC, loc.id ASC").Limit(limit).Scan(ctx)
+	return locations, err
+}
+
+// Delete removes a location along with its menu overrides and opening hours
+func (q *LocationQuery) Delete(ctx context.Context, id int) error {
+	_, err := database.Scope(ctx, q.db.NewDelete().Model((*Location)(nil))).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+// Overrides returns the menu overrides of a location by menu item. The location must
+// have been looked up in the restaurant of ctx.
+func (q *LocationQuery) Overrides(ctx context.Context, locationID int) ([]LocationMenuItem, error) {
+	var overrides []LocationMenuItem
+	err := database.Reader(ctx, q.db).NewSelect().
+		Model(&overrides).
+		Where("lmi.location_id = ?", locationID).
+		Order("lmi.menu_item_id ASC").
+		Scan(ctx)
+	return overrides, err
+}
+
+// SetOverride creates or replaces the override of a menu item at a location
+func (q *LocationQuery) SetOverride(ctx context.Context, override *LocationMenuItem) error {
+	now := time.Now()
+	override.CreatedAt = now
+	override.UpdatedAt = now
+
+	query := q.db.NewInsert().Model(override)
+	if database.IsMySQL(q.db) {
+		query = query.On("DUPLICATE KEY UPDATE").
+			Set("is_available = VALUES(is_available)").
+			Set("price = VALUES(price)").
+			Set("updated_at = VALUES(updated_at)")
+	} else {
+		query = query.On("CONFLICT (location_id, menu_item_id) DO UPDATE").
+			Set("is_available = EXCLUDED.is_available").
+			Set("price = EXCLUDED.price").
+			Set("updated_at = EXCLUDED.updated_at")
+	}
+	_, err := query.Exec(ctx)
+	return err
+}
+
+// DeleteOverride removes the override of a menu item at a location and reports
+// whether it had one
+func (q *LocationQuery) DeleteOverride(ctx context.Context, locationID, menuItemID int) (bool, error) {
+	res, err := q.db.NewDelete().
+		Model((*LocationMenuItem)(nil)).
+		Where("location_id = ? AND menu_item_id = ?", locationID, menuItemID).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// LocationHours is a period a location opens on a weekday (sun, mon, ...), from
+// OpensAt to ClosesAt (HH:MM, restaurant time), running past midnight when ClosesAt is
+// earlier. A location may open several times a day, e.g. for lunch and dinner.
+type LocationHours struct {
+	bun.BaseModel `bun:"table:location_hours,alias:lh"`
+
+	ID         int    `bun:"id,pk,autoincrement" json:"id"`
+	LocationID int    `bun:"location_id,notnull" json:"location_id"`
+	Day        string `bun:"day,notnull" json:"day"`
+	OpensAt    string `bun:"opens_at,notnull" json:"opens_at"`
+	ClosesAt   string `bun:"closes_at,notnull" json:"closes_at"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// LocationHourException replaces the weekly hours of a location on one date, e.g. a
+// holiday. The location is closed all day when OpensAt is nil, and otherwise opens
+// once, from OpensAt to ClosesAt.
+type LocationHourException struct {
+	bun.BaseModel `bun:"table:location_hour_exceptions,alias:lhe"`
+
+	ID         int       `bun:"id,pk,autoincrement" json:"id"`
+	LocationID int       `bun:"location_id,notnull" json:"location_id"`
+	Date       time.Time `bun:"date,type:date,notnull" json:"date"`
+	OpensAt    *string   `bun:"opens_at" json:"opens_at,omitempty"`
+	ClosesAt   *string   `bun:"closes_at" json:"closes_at,omitempty"`
+	Note       *string   `bun:"note" json:"note,omitempty"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// Hours returns the weekly hours of a location by ID. The location must have been
+// looked up in the restaurant of ctx.
+func (q *LocationQuery) Hours(ctx context.Context, locationID int) ([]LocationHours, error) {
+	var hours []LocationHours
+	err := database.Reader(ctx, q.db).NewSelect().
+		Model(&hours).
+		Where("lh.location_id = ?", locationID).
+		Order("lh.id ASC").
+		Scan(ctx)
+	return hours, err
+}
+
+// SetHours replaces the weekly hours of a location in a transaction
+func (q *LocationQuery) SetHours(ctx context.Context, locationID int, hours []LocationHours) error {
+	return q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().Model((*LocationHours)(nil)).Where("location_id = ?", locationID).Exec(ctx)
+		if err != nil || len(hours) == 0 {
+			return err
+		}
+		now := time.Now()
+		for i := range hours {
+			hours[i].LocationID = locationID
+			hours[i].CreatedAt = now
+		}
+		_, err = tx.NewInsert().Model(&hours).Exec(ctx)
+		return err
+	})
+}
+
+// Exceptions returns the hour exceptions of a location dated from on or later, by date
+func (q *LocationQuery) Exceptions(ctx context.Context, locationID int, from time.Time) ([]LocationHourException, error) {
+	var exceptions []LocationHourException
+	err := database.Reader(ctx, q.db).NewSelect().
+		Model(&exceptions).
+		Where("lhe.location_id = ? AND lhe.date >= ?", locationID, from).
+		Order("lhe.date ASC").
+		Scan(ctx)
+	return exceptions, err
+}
+
+// SetException creates or replaces the hour exception of a location on its date
+func (q *LocationQuery) SetException(ctx context.Context, exception *LocationHourException) error {
+	now := time.Now()
+	exception.CreatedAt = now
+	exception.UpdatedAt = now
+
+	query := q.db.NewInsert().Model(exception)
+	if database.IsMySQL(q.db) {
+		query = query.On("DUPLICATE KEY UPDATE").
+			Set("opens_at = VALUES(opens_at)").
+			Set("closes_at = VALUES(closes_at)").
+			Set("note = VALUES(note)").
+			Set("updated_at = VALUES(updated_at)")
+	} else {
+		query = query.On("CONFLICT (location_id, date) DO UPDATE").
+			Set("opens_at = EXCLUDED.opens_at").
+			Set("closes_at = EXCLUDED.closes_at").
+			Set("note = EXCLUDED.note").
+			Set("updated_at = EXCLUDED.updated_at")
+	}
+	_, err := query.Exec(ctx)
+	return err
+}
+
+// DeleteException removes the hour exception of a location on a date and reports
+// whether it had one
+func (q *LocationQuery) DeleteException(ctx context.Context, locationID int, date time.Time) (bool, error) {
+	res, err := q.db.NewDelete().
+		Model((*LocationHourException)(nil)).
+		Where("location_id = ? AND date = ?", locationID, date).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Loyalty transaction types
+const (
+	LoyaltyEarn   = "earn"
+	LoyaltyRedeem = "redeem"
+)
+
+// ErrLoyaltyBalance is returned when creating an order redeeming more points than the
+// customer has left
+var ErrLoyaltyBalance = newOutcome("loyalty points balance too low")
+
+// LoyaltyAccount holds the points balance of a customer at a restaurant, told apart
+// by phone number
+type LoyaltyAccount struct {
+	bun.BaseModel `bun:"table:loyalty_accounts,alias:la"`
+
+	RestaurantID  int    `bun:"restaurant_id,pk" json:"-"`
+	CustomerPhone string `bun:"customer_phone,pk" json:"customer_phone"`
+	Points        int    `bun:"points,notnull" json:"points"`
+
+	Transactions []LoyaltyTransaction `bun:"rel:has-many,join:restaurant_id=restaurant_id,join:customer_phone=customer_phone" json:"transactions,omitempty"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// LoyaltyTransaction records points earned on a paid order or redeemed on a new one.
+// Points is negative for redemptions; Balance is the balance after it.
+type LoyaltyTransaction struct {
+	bun.BaseModel `bun:"table:loyalty_transactions,alias:lt"`
+
+	ID            int       `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID  int       `bun:"restaurant_id,notnull" json:"-"`
+	CustomerPhone string    `bun:"customer_phone,notnull" json:"customer_phone"`
+	OrderID       *string   `bun:"order_id" json:"order_id,omitempty"`
+	Type          string    `bun:"type,notnull" json:"type"` // LoyaltyEarn or LoyaltyRedeem
+	Points        int       `bun:"points,notnull" json:"points"`
+	Balance       int       `bun:"balance,notnull" json:"balance"`
+	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// LoyaltyQuery provides query methods for LoyaltyAccount
+type LoyaltyQuery struct {
+	db *bun.DB
+}
+
+// NewLoyaltyQuery creates a new query builder for LoyaltyAccount
+func NewLoyaltyQuery(db *bun.DB) *LoyaltyQuery {
+	return &LoyaltyQuery{db: db}
+}
+
+// FindAccount finds the account of a customer at the restaurant of ctx with its
+// transactions, newest first
+func (q *LoyaltyQuery) FindAccount(ctx context.Context, customerPhone string) (*LoyaltyAccount, error) {
+	account := new(LoyaltyAccount)
+	err := database.Reader(ctx, q.db).NewSelect().
+		Model(account).
+		Relation("Transactions", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("lt.created_at DESC", "lt.id DESC")
+		}).
+		Where("la.restaurant_id = ? AND la.customer_phone = ?", database.RestaurantID(ctx), customerPhone).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// earnPoints adds the points of an earn transaction to the customer's account at the
+// restaurant of ctx, opening it on their first order there, and records the
+// transaction
+func earnPoints(ctx context.Context, tx bun.Tx, transaction *LoyaltyTransaction) error {
+	now := time.Now()
+	transaction.RestaurantID = database.RestaurantID(ctx)
+	account := &LoyaltyAccount{
+		RestaurantID:  transaction.RestaurantID,
+		CustomerPhone: transaction.CustomerPhone,
+		Points:        transaction.Points,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	insert := tx.NewInsert().Model(account)
+	if database.IsMySQL(tx) {
+		insert = insert.On("DUPLICATE KEY UPDATE").Set("points = points + VALUES(points), updated_at = VALUES(updated_at)")
+	} else {
+		insert = insert.On("CONFLICT (restaurant_id, customer_phone) DO UPDATE").
+			Set("points = la.points + EXCLUDED.points, updated_at = EXCLUDED.updated_at")
+	}
+	if _, err := insert.Exec(ctx); err != nil {
+		return err
+	}
+	return recordPoints(ctx, tx, transaction)
+}
+
+// spendPoints takes the points of a redeem transaction off the customer's account at
+// the restaurant of ctx, unless they have fewer left, and records the transaction
+func spendPoints(ctx context.Context, tx bun.Tx, transaction *LoyaltyTransaction) error {
+	transaction.RestaurantID = database.RestaurantID(ctx)
+	res, err := tx.NewUpdate().
+		Model((*LoyaltyAccount)(nil)).
+		Set("points = points + ?", transaction.Points).
+		Set("updated_at = ?", time.Now()).
+		Where("restaurant_id = ? AND customer_phone = ?", transaction.RestaurantID, transaction.CustomerPhone).
+		Where("points >= ?", -transaction.Points).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrLoyaltyBalance
+	}
+	return recordPoints(ctx, tx, transaction)
+}
+
+// recordPoints inserts a transaction with the customer's balance after it
+func recordPoints(ctx context.Context, tx bun.Tx, transaction *LoyaltyTransaction) error {
+	if err := tx.NewSelect().Model((*LoyaltyAccount)(nil)).Column("points").
+		Where("restaurant_id = ? AND customer_phone = ?", transaction.RestaurantID, transaction.CustomerPhone).
+		Scan(ctx, &transaction.Balance); err != nil {
+		return err
+	}
+	_, err := tx.NewInsert().Model(transaction).Exec(ctx)
+	return err
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
 )
 
 // MenuItem represents a dish/item on the restaurant menu
@@ -15,6 +17,8 @@ type MenuItem struct {
 
 	// Primary key - Auto-increment integer
 	ID int `bun:"id,pk,autoincrement" json:"id"`
+	// Restaurant the item is on the menu of
+	RestaurantID int `bun:"restaurant_id,notnull" json:"-"`
 
 	// Required fields
 	Name     string          `bun:"name,notnull" json:"name" validate:"required,min=1,max=100"`
@@ -24,6 +28,17 @@ type MenuItem struct {
 	// Optional fields
 	Description *string `bun:"description,type:text" json:"description,omitempty"`
 	IsAvailable bool    `bun:"is_available,notnull,default:true" json:"is_available"`
+	// Kitchen station preparing the item, its category's station when nil
+	Station *string `bun:"station" json:"station,omitempty"`
+	// Minutes the item takes to prepare, the kitchen's default when nil
+	PrepMinutes *int `bun:"prep_minutes" json:"prep_minutes,omitempty"`
+	// Number of approved reviews and the sum of their ratings, kept up to date by
+	// ReviewQuery as reviews are approved, rejected and deleted
+	ReviewCount int `bun:"review_count,notnull" json:"review_count"`
+	RatingTotal int `bun:"rating_total,notnull" json:"rating_total"`
+
+	// Prices on specific order channels, loaded with the "Prices" relation
+	Prices []MenuItemPrice `bun:"rel:has-many,join:id=menu_item_id" json:"prices,omitempty"`
 
 	// Timestamps for auditing
 	CreatedAt time.Time  `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
@@ -39,6 +54,9 @@ func (m *MenuItem) BeforeAppendModel(ctx context.Context, query bun.Query) error
 		now := time.Now()
 		m.CreatedAt = now
 		m.UpdatedAt = now
+		if m.RestaurantID == 0 {
+			m.RestaurantID = database.RestaurantID(ctx)
+		}
 	case *bun.UpdateQuery:
 		// Update timestamp on updates (only if not a soft delete)
 		if m.DeletedAt == nil {
@@ -48,51 +66,32 @@ func (m *MenuItem) BeforeAppendModel(ctx context.Context, query bun.Query) error
 	return nil
 }
 
-// SoftDelete marks the record as deleted by setting deleted_at timestamp
-func (m *MenuItem) SoftDelete(ctx context.Context, db *bun.DB) error {
-	now := time.Now()
-	m.DeletedAt = &now
-	m.UpdatedAt = now
-
-	_, err := db.NewUpdate().
-		Model(m).
-		Set("deleted_at = ?", now).
-		Set("updated_at = ?", now).
-		Where("id = ?", m.ID).
-		Exec(ctx)
-
-	return err
+// AverageRating returns the mean rating of the item's approved reviews, 0 when it has
+// none
+func (m MenuItem) AverageRating() float64 {
+	if m.ReviewCount == 0 {
+		return 0
+	}
+	return float64(m.RatingTotal) / float64(m.ReviewCount)
 }
 
-// Restore restores a soft-deleted record
-func (m *MenuItem) Restore(ctx context.Context, db *bun.DB) error {
-	now := time.Now()
-	m.DeletedAt = nil
-	m.UpdatedAt = now
-
-	_, err := db.NewUpdate().
-		Table("menu_items").
-		Set("deleted_at = NULL").
-		Set("updated_at = ?", now).
-		Where("id = ?", m.ID).
-		Exec(ctx)
-
-	return err
+// WithMinRating restricts a menu item query to the items whose approved reviews
+// average at least rating; items without reviews are left out
+func WithMinRating(rating float64) database.QueryOption {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("mi.review_count > 0 AND mi.rating_total >= ? * mi.review_count", rating)
+	}
 }
 
-// ForceDelete permanently deletes the record from database
-func (m *MenuItem) ForceDelete(ctx context.Context, db *bun.DB) error {
-	_, err := db.NewDelete().
-		Model(m).
-		Where("id = ?", m.ID).
-		ForceDelete().
-		Exec(ctx)
-
-	return err
+// WithIDs restricts a menu item query to the items with the given IDs
+func WithIDs(ids ...int) database.QueryOption {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("mi.id IN (?)", bun.In(ids))
+	}
 }
 
 // IsDeleted checks if the record is soft deleted
-func (m *MenuItem) IsDeleted() bool {
+func (m MenuItem) IsDeleted() bool {
 	return m.DeletedAt != nil
 }
 
@@ -111,61 +110,185 @@ func (m *MenuItem) String() string {
 		m.ID, m.Name, m.Price.String(), m.Category, status)
 }
 
+// MenuItemRelations maps the names clients may pass in ?include= to Bun relation names.
+// Add an entry here when a relation field (bun:"rel:...") is added to MenuItem.
+var MenuItemRelations = map[string]string{
+	"prices": "Prices",
+}
+
 // MenuItemQuery provides query methods for MenuItem with soft delete support
 type MenuItemQuery struct {
-	db *bun.DB
+	db   *bun.DB
+	repo *database.Repository[MenuItem]
 }
 
 // NewMenuItemQuery creates a new query builder for MenuItem
 func NewMenuItemQuery(db *bun.DB) *MenuItemQuery {
-	return &MenuItemQuery{db: db}
+	return &MenuItemQuery{
+		db:   db,
+		repo: database.NewRepository[MenuItem](db),
+	}
 }
 
 // All returns all non-deleted menu items
-func (q *MenuItemQuery) All(ctx context.Context) ([]MenuItem, error) {
+func (q *MenuItemQuery) All(ctx context.Context, opts ...database.QueryOption) ([]MenuItem, error) {
+	return q.repo.All(ctx, opts...)
+}
+
+// WithDeleted returns all menu items including soft-deleted ones
+func (q *MenuItemQuery) WithDeleted(ctx context.Context, opts ...database.QueryOption) ([]MenuItem, error) {
+	return q.repo.WithDeleted(ctx, opts...)
+}
+
+// OnlyDeleted returns only soft-deleted menu items
+func (q *MenuItemQuery) OnlyDeleted(ctx context.Context, opts ...database.QueryOption) ([]MenuItem, error) {
+	return q.repo.OnlyDeleted(ctx, opts...)
+}
+
+// FindByID finds a menu item by ID (excludes soft-deleted)
+func (q *MenuItemQuery) FindByID(ctx context.Context, id int, opts ...database.QueryOption) (*MenuItem, error) {
+	return q.repo.FindByID(ctx, id, opts...)
+}
+
+// FindByIDWithDeleted finds a menu item by ID (includes soft-deleted)
+func (q *MenuItemQuery) FindByIDWithDeleted(ctx context.Context, id int, opts ...database.QueryOption) (*MenuItem, error) {
+	return q.repo.FindByIDWithDeleted(ctx, id, opts...)
+}
+
+// ByCategory returns non-deleted menu items in the given category
+func (q *MenuItemQuery) ByCategory(ctx context.Context, category string, opts ...database.QueryOption) ([]MenuItem, error) {
 	var items []MenuItem
-	err := q.db.NewSelect().
-		Model(&items).
-		Where("deleted_at IS NULL").
+	err := database.ApplyOptions(database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&items)), opts...).
+		Where("category = ? AND deleted_at IS NULL", category).
 		Scan(ctx)
 	return items, err
 }
 
-// WithDeleted returns all menu items including soft-deleted ones
-func (q *MenuItemQuery) WithDeleted(ctx context.Context) ([]MenuItem, error) {
+// Available returns non-deleted menu items that are currently available
+func (q *MenuItemQuery) Available(ctx context.Context, opts ...database.QueryOption) ([]MenuItem, error) {
 	var items []MenuItem
-	err := q.db.NewSelect().
-		Table("menu_items").
-		Scan(ctx, &items)
+	err := database.ApplyOptions(database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&items)), opts...).
+		Where("is_available = true AND deleted_at IS NULL").
+		Scan(ctx)
 	return items, err
 }
 
-// OnlyDeleted returns only soft-deleted menu items
-func (q *MenuItemQuery) OnlyDeleted(ctx context.Context) ([]MenuItem, error) {
+// Search returns non-deleted menu items whose name or description matches the term
+func (q *MenuItemQuery) Search(ctx context.Context, term string, opts ...database.QueryOption) ([]MenuItem, error) {
 	var items []MenuItem
-	err := q.db.NewSelect().
-		Table("menu_items").
-		Where("deleted_at IS NOT NULL").
-		Scan(ctx, &items)
+	searchPattern := "%" + term + "%"
+	db := database.Reader(ctx, q.db)
+	err := database.ApplyOptions(database.Scope(ctx, db.NewSelect().Model(&items)), opts...).
+		Where("(name ? ? OR description ? ?) AND deleted_at IS NULL",
+			bun.Safe(database.ILike(db)), searchPattern, bun.Safe(database.ILike(db)), searchPattern).
+		Scan(ctx)
 	return items, err
 }
 
-// FindByID finds a menu item by ID (excludes soft-deleted)
-func (q *MenuItemQuery) FindByID(ctx context.Context, id int) (*MenuItem, error) {
-	var item MenuItem
-	err := q.db.NewSelect().
-		Model(&item).
-		Where("id = ?", id).
-		Scan(ctx)
-	return &item, err
+// CategoryCount is the number of non-deleted menu items of a category, and how many
+// of them are available
+type CategoryCount struct {
+	Category  string `bun:"category"`
+	Total     int    `bun:"total"`
+	Available int    `bun:"available"`
 }
 
-// FindByIDWithDeleted finds a menu item by ID (includes soft-deleted)
-func (q *MenuItemQuery) FindByIDWithDeleted(ctx context.Context, id int) (*MenuItem, error) {
-	var item MenuItem
-	err := q.db.NewSelect().
-		Table("menu_items").
-		Where("id = ?", id).
-		Scan(ctx, &item)
-	return &item, err
+// CountByCategory counts the non-deleted menu items of each category
+func (q *MenuItemQuery) CountByCategory(ctx context.Context) ([]CategoryCount, error) {
+	var counts []CategoryCount
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model((*MenuItem)(nil))).
+		Column("category").
+		ColumnExpr("COUNT(*) AS total").
+		ColumnExpr("SUM(CASE WHEN is_available THEN 1 ELSE 0 END) AS available").
+		Where("deleted_at IS NULL").
+		Group("category").
+		Order("category ASC").
+		Scan(ctx, &counts)
+	return counts, err
+}
+
+// Stream calls fn for every non-deleted menu item in ID order, reading rows from a
+// database cursor one at a time instead of loading the whole table into memory
+func (q *MenuItemQuery) Stream(ctx context.Context, fn func(item *MenuItem) error) error {
+	db := database.Reader(ctx, q.db)
+	rows, err := database.Scope(ctx, db.NewSelect().Model((*MenuItem)(nil))).
+		Where("deleted_at IS NULL").
+		Order("id ASC").
+		Rows(ctx)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item MenuItem
+		if err := db.ScanRow(ctx, rows, &item); err != nil {
+			return err
+		}
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Create inserts a new menu item
+func (q *MenuItemQuery) Create(ctx context.Context, item *MenuItem) error {
+	_, err := q.db.NewInsert().Model(item).Exec(ctx)
+	return err
+}
+
+// Update saves all columns of an existing menu item but its restaurant and its
+// rating, which only ReviewQuery changes
+func (q *MenuItemQuery) Update(ctx context.Context, item *MenuItem) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model(item)).
+		ExcludeColumn("restaurant_id", "review_count", "rating_total").
+		Where("id = ?", item.ID).
+		Exec(ctx)
+	return err
+}
+
+// SoftDelete marks the menu item as deleted
+func (q *MenuItemQuery) SoftDelete(ctx context.Context, item *MenuItem) error {
+	return q.repo.SoftDelete(ctx, item)
+}
+
+// Restore clears the deleted marker of a soft-deleted menu item
+func (q *MenuItemQuery) Restore(ctx context.Context, item *MenuItem) error {
+	return q.repo.Restore(ctx, item)
+}
+
+// ForceDelete permanently removes the menu item
+func (q *MenuItemQuery) ForceDelete(ctx context.Context, item *MenuItem) error {
+	return q.repo.ForceDelete(ctx, item)
+}
+
+// PurgeDeleted permanently removes menu items soft-deleted before cutoff, or only
+// counts them when dryRun is set
+func (q *MenuItemQuery) PurgeDeleted(ctx context.Context, cutoff time.Time, dryRun bool) (int, error) {
+	return q.repo.PurgeDeleted(ctx, cutoff, dryRun)
+}
+
+// BulkCreate inserts items in multi-row batches inside a single transaction.
+// progress, if non-nil, is called after each batch with the number of rows inserted so far.
+func (q *MenuItemQuery) BulkCreate(ctx context.Context, items []MenuItem, batchSize int, progress func(done, total int)) error {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	return q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for start := 0; start < len(items); start += batchSize {
+			end := min(start+batchSize, len(items))
+			batch := items[start:end]
+
+			if _, err := tx.NewInsert().Model(&batch).Exec(ctx); err != nil {
+				return fmt.Errorf("failed to insert rows %d-%d: %w", start+1, end, err)
+			}
+
+			if progress != nil {
+				progress(end, len(items))
+			}
+		}
+		return nil
+	})
 }
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// MenuItemPrice is the price of a menu item on one order channel (dine_in, takeaway or
+// delivery), replacing the item's own price there
+type MenuItemPrice struct {
+	bun.BaseModel `bun:"table:menu_item_prices,alias:mip"`
+
+	ID         int             `bun:"id,pk,autoincrement" json:"id"`
+	MenuItemID int             `bun:"menu_item_id,notnull" json:"menu_item_id"`
+	Channel    string          `bun:"channel,notnull" json:"channel"`
+	Price      decimal.Decimal `bun:"price,type:decimal(10,2),notnull" json:"price"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// PriceFor returns the item's price on an order channel: its price tier for the
+// channel when it has one, its own price otherwise. Prices must be loaded.
+func (m MenuItem) PriceFor(channel string) decimal.Decimal {
+	for _, tier := range m.Prices {
+		if tier.Channel == channel {
+			return tier.Price
+		}
+	}
+	return m.Price
+}
+
+// SetPrice creates or replaces the price of a menu item on a channel
+func (q *MenuItemQuery) SetPrice(ctx context.Context, price *MenuItemPrice) error {
+	now := time.Now()
+	price.CreatedAt = now
+	price.UpdatedAt = now
+
+	query := q.db.NewInsert().Model(price)
+	if database.IsMySQL(q.db) {
+		query = query.On("DUPLICATE KEY UPDATE").Set("price = VALUES(price)").Set("updated_at = VALUES(updated_at)")
+	} else {
+		query = query.On("CONFLICT (menu_item_id, channel) DO UPDATE").Set("price = EXCLUDED.price").Set("updated_at = EXCLUDED.updated_at")
+	}
+	_, err := query.Exec(ctx)
+	return err
+}
+
+// DeletePrice removes the price of a menu item on a channel and reports whether it
+// had one
+func (q *MenuItemQuery) DeletePrice(ctx context.Context, menuItemID int, channel string) (bool, error) {
+	res, err := q.db.NewDelete().
+		Model((*MenuItemPrice)(nil)).
+		Where("menu_item_id = ? AND channel = ?", menuItemID, channel).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,351 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Order statuses
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusAccepted  = "accepted"
+	OrderStatusPreparing = "preparing"
+	OrderStatusReady     = "ready"
+	OrderStatusCompleted = "completed"
+	OrderStatusCancelled = "cancelled"
+)
+
+// Order channels
+const (
+	OrderChannelDineIn   = "dine_in"
+	OrderChannelTakeaway = "takeaway"
+	OrderChannelDelivery = "delivery"
+)
+
+// Order sources: orders received from a delivery platform carry the platform's name
+// as their source
+const (
+	// OrderSourcePOS marks orders placed by staff in the restaurant
+	OrderSourcePOS = "pos"
+	// OrderSourceTable marks orders placed by guests from their table's QR code
+	OrderSourceTable = "table"
+	// OrderSourceWeb marks orders checked out from a cart, whose ID is their external ID
+	OrderSourceWeb = "web"
+)
+
+// Order is a customer order with its line items
+type Order struct {
+	bun.BaseModel `bun:"table:orders,alias:o"`
+
+	// Primary key - UUID generated on insert
+	ID string `bun:"id,pk" json:"id"`
+	// Restaurant the order was placed at
+	RestaurantID int `bun:"restaurant_id,notnull" json:"-"`
+
+	// Where the order came from; ExternalID is the order's ID there, unique per source
+	Source     string  `bun:"source,notnull" json:"source"`
+	ExternalID *string `bun:"external_id" json:"external_id,omitempty"`
+
+	Channel string          `bun:"channel,notnull" json:"channel"`
+	Status  string          `bun:"status,notnull" json:"status"`
+	Total   decimal.Decimal `bun:"total,type:decimal(10,2),notnull" json:"total"`
+
+	// Subtotal is the sum of the line totals, Discount the sum of their discounts and
+	// Tax the sum of their tax. Total is Subtotal less Discount plus Tax, or less
+	// Discount only when prices include tax (TaxIncluded).
+	Subtotal    decimal.Decimal `bun:"subtotal,type:decimal(10,2),notnull" json:"subtotal"`
+	Discount    decimal.Decimal `bun:"discount,type:decimal(10,2),notnull" json:"discount"`
+	Tax         decimal.Decimal `bun:"tax,type:decimal(10,2),notnull" json:"tax"`
+	TaxIncluded bool            `bun:"tax_included,notnull" json:"tax_included"`
+
+	// Tip for the staff, paid on top of Total and not part of the sale
+	Tip decimal.Decimal `bun:"tip,type:decimal(10,2),notnull" json:"tip"`
+
+	// Part of Total and Tip paid by Payments, at most their sum
+	AmountPaid decimal.Decimal `bun:"amount_paid,type:decimal(10,2),notnull" json:"amount_paid"`
+
+	// Coupon redeemed on the order; Redemption is only set when creating it
+	CouponCode *string           `bun:"coupon_code" json:"coupon_code,omitempty"`
+	Redemption *CouponRedemption `bun:"-" json:"-"`
+
+	// Loyalty points redeemed on the order for LoyaltyDiscount, part of Discount;
+	// LoyaltyRedemption is only set when creating it
+	LoyaltyPoints     int                 `bun:"loyalty_points,notnull" json:"loyalty_points"`
+	LoyaltyDiscount   decimal.Decimal     `bun:"loyalty_discount,type:decimal(10,2),notnull" json:"loyalty_discount"`
+	LoyaltyRedemption *LoyaltyTransaction `bun:"-" json:"-"`
+
+	// Optional fields
+	CustomerName  *string `bun:"customer_name" json:"customer_name,omitempty"`
+	CustomerPhone *string `bun:"customer_phone" json:"customer_phone,omitempty"`
+	Notes         *string `bun:"notes,type:text" json:"notes,omitempty"`
+
+	Items []OrderItem `bun:"rel:has-many,join:id=order_id" json:"items"`
+	// Promotions applied to the order, explaining part of Discount
+	Promotions []OrderPromotion `bun:"rel:has-many,join:id=order_id" json:"promotions"`
+	Payments   []OrderPayment   `bun:"rel:has-many,join:id=order_id" json:"payments"`
+
+	// When the kitchen is expected to have prepared the order
+	EstimatedReadyAt *time.Time `bun:"estimated_ready_at" json:"estimated_ready_at,omitempty"`
+
+	// Time slot an online order was placed in; SlotCapacity is the most orders the
+	// slot takes, 0 for no limit, and is only set when creating the order
+	SlotAt       *time.Time `bun:"slot_at" json:"slot_at,omitempty"`
+	SlotCapacity int        `bun:"-" json:"-"`
+
+	// Number of the order among those of its business day (a date), given when it is
+	// created; orders placed before numbering was introduced have none
+	BusinessDay *time.Time `bun:"business_day,type:date" json:"business_day,omitempty"`
+	Number      *int       `bun:"number" json:"number,omitempty"`
+
+	// Table the order is served at, with its name when ordered, and the guest session
+	// that placed it from the table's QR code
+	TableID        *int    `bun:"table_id" json:"table_id,omitempty"`
+	TableName      *string `bun:"table_name" json:"table_name,omitempty"`
+	GuestSessionID *int    `bun:"guest_session_id" json:"guest_session_id,omitempty"`
+
+	// Location of the restaurant the order was placed at, if it named one
+	LocationID *int `bun:"location_id" json:"location_id,omitempty"`
+
+	// Driver delivering a delivery order, the step the delivery reached and when each
+	// step happened; unset until a driver is assigned
+	DriverID       *int       `bun:"driver_id" json:"driver_id,omitempty"`
+	Driver         *Driver    `bun:"rel:belongs-to,join:driver_id=id" json:"driver,omitempty"`
+	DeliveryStatus *string    `bun:"delivery_status" json:"delivery_status,omitempty"`
+	AssignedAt     *time.Time `bun:"assigned_at" json:"assigned_at,omitempty"`
+	PickedUpAt     *time.Time `bun:"picked_up_at" json:"picked_up_at,omitempty"`
+	DeliveredAt    *time.Time `bun:"delivered_at" json:"delivered_at,omitempty"`
+
+	// Timestamps for auditing
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// AmountDue returns what is left to pay of the order's total and tip
+func (o *Order) AmountDue() decimal.Decimal {
+	return o.Total.Add(o.Tip).Sub(o.AmountPaid)
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (o *Order) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if o.ID == "" {
+			o.ID = uuid.NewString()
+		}
+		if o.RestaurantID == 0 {
+			o.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		o.CreatedAt = now
+		o.UpdatedAt = now
+	case *bun.UpdateQuery:
+		o.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// OrderItem is a line of an order. MenuItemID is nil when the item could not be
+// matched to the menu, e.g. for an unknown item in a delivery platform order.
+type OrderItem struct {
+	bun.BaseModel `bun:"table:order_items,alias:oi"`
+
+	ID         int             `bun:"id,pk,autoincrement" json:"id"`
+	OrderID    string          `bun:"order_id,notnull" json:"order_id"`
+	MenuItemID *int            `bun:"menu_item_id" json:"menu_item_id,omitempty"`
+	Name       string          `bun:"name,notnull" json:"name"`
+	Quantity   int             `bun:"quantity,notnull" json:"quantity"`
+	UnitPrice  decimal.Decimal `bun:"unit_price,type:decimal(10,2),notnull" json:"unit_price"`
+	Notes      *string         `bun:"notes,type:text" json:"notes,omitempty"`
+	// Kitchen station the line was routed to when the order was placed
+	Station *string `bun:"station" json:"station,omitempty"`
+	// Minutes the line takes to prepare, and when its station marked it prepared
+	PrepMinutes int        `bun:"prep_minutes,notnull" json:"prep_minutes"`
+	PreparedAt  *time.Time `bun:"prepared_at" json:"prepared_at,omitempty"`
+
+	// Tax charged on the line, at the rate in effect when the order was placed
+	TaxName   *string         `bun:"tax_name" json:"tax_name,omitempty"`
+	TaxRate   decimal.Decimal `bun:"tax_rate,type:decimal(6,3),notnull" json:"tax_rate"`
+	TaxAmount decimal.Decimal `bun:"tax_amount,type:decimal(10,2),notnull" json:"tax_amount"`
+
+	// Pricing rule that discounted the unit price when the order was placed
+	PricingRule *string `bun:"pricing_rule" json:"pricing_rule,omitempty"`
+	// Part of the order's discount taken off the line
+	Discount decimal.Decimal `bun:"discount,type:decimal(10,2),notnull" json:"discount"`
+}
+
+// LineTotal returns the unit price times the quantity
+func (i OrderItem) LineTotal() decimal.Decimal {
+	return i.UnitPrice.Mul(decimal.NewFromInt(int64(i.Quantity)))
+}
+
+// DiscountedTotal returns the line total less the line's discount, which is what the
+// line is taxed on
+func (i OrderItem) DiscountedTotal() decimal.Decimal {
+	return i.LineTotal().Sub(i.Discount)
+}
+
+// OrderFilter narrows OrderQuery.List; empty fields match any order
+type OrderFilter struct {
+	Status        string
+	Source        string
+	CreatedAfter  time.Time // Inclusive
+	CreatedBefore time.Time // Exclusive
+	BusinessDay   time.Time // Orders numbered on this business day; zero matches any
+	Number        int       // Orders with this number; 0 matches any
+	GuestSession  int       // Orders placed in this guest session; 0 matches any
+	Limit         int
+	Offset        int
+}
+
+// OrderQuery provides query methods for Order
+type OrderQuery struct {
+	db *bun.DB
+}
+
+// NewOrderQuery creates a new query builder for Order
+func NewOrderQuery(db *bun.DB) *OrderQuery {
+	return &OrderQuery{db: db}
+}
+
+// Create inserts an order with its items and promotions in one transaction, redeeming
+// the order's coupon and loyalty points if it has them, counting it in its time slot
+// and numbering it after the last order of its business day at the restaurant of ctx.
+// It fails with ErrCouponUsedUp when the coupon reached its usage limit, with
+// ErrLoyaltyBalance when the customer has fewer points left and with ErrSlotFull when
+// the slot has no room left.
+func (q *OrderQuery) Create(ctx context.Context, order *Order) error {
+	return q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if order.SlotAt != nil {
+			if err := claimSlot(ctx, tx, *order.SlotAt, order.SlotCapacity); err != nil {
+				return err
+			}
+		}
+		if order.BusinessDay != nil {
+			number, err := nextNumber(ctx, tx, *order.BusinessDay)
+			if err != nil {
+				return err
+			}
+			order.Number = &number
+		}
+		if _, err := tx.NewInsert().Model(order).Exec(ctx); err != nil {
+			return err
+		}
+		if order.Redemption != nil {
+			order.Redemption.OrderID = order.ID
+			if err := redeem(ctx, tx, order.Redemption); err != nil {
+				return err
+			}
+		}
+		if order.LoyaltyRedemption != nil {
+			order.LoyaltyRedemption.OrderID = &order.ID
+			if err := spendPoints(ctx, tx, order.LoyaltyRedemption); err != nil {
+				return err
+			}
+		}
+		if len(order.Promotions) > 0 {
+			for i := range order.Promotions {
+				order.Promotions[i].OrderID = order.ID
+			}
+			if _, err := tx.NewInsert().Model(&order.Promotions).Exec(ctx); err != nil {
+				return err
+			}
+		}
+		if len(order.Items) == 0 {
+			return nil
+		}
+		for i := range order.Items {
+			order.Items[i].OrderID = order.ID
+		}
+		_, err := tx.NewInsert().Model(&order.Items).Exec(ctx)
+		return err
+	})
+}
+
+// FindByID finds an order with its items and driver
+func (q *OrderQuery) FindByID(ctx context.Context, id string) (*Order, error) {
+	order := new(Order)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(order)).
+		Relation("Items", orderItemsInOrder).
+		Relation("Promotions", orderPromotionsInOrder).
+		Relation("Payments", orderPaymentsInOrder).
+		Relation("Driver").
+		Where("o.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// FindByExternalID finds the order a source knows by externalID. It reads from the
+// primary, as it is used to detect duplicates right before inserting.
+func (q *OrderQuery) FindByExternalID(ctx context.Context, source, externalID string) (*Order, error) {
+	order := new(Order)
+	err := database.Scope(ctx, q.db.NewSelect().Model(order)).
+		Relation("Items", orderItemsInOrder).
+		Relation("Promotions", orderPromotionsInOrder).
+		Relation("Payments", orderPaymentsInOrder).
+		Relation("Driver").
+		Where("o.source = ? AND o.external_id = ?", source, externalID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// List returns orders matching filter with their items, newest first
+func (q *OrderQuery) List(ctx context.Context, filter OrderFilter) ([]Order, error) {
+	var orders []Order
+	query := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&orders)).
+		Relation("Items", orderItemsInOrder).
+		Relation("Promotions", orderPromotionsInOrder).
+		Relation("Payments", orderPaymentsInOrder).
+		Relation("Driver").
+		Order("o.created_at DESC", "o.id DESC")
+	if filter.Status != "" {
+		query = query.Where("o.status = ?", filter.Status)
+	}
+	if filter.Source != "" {
+		query = query.Where("o.source = ?", filter.Source)
+	}
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where("o.created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("o.created_at < ?", filter.CreatedBefore)
+	}
+	if !filter.BusinessDay.IsZero() {
+		query = query.Where("o.business_day = ?", filter.BusinessDay)
+	}
+	if filter.Number > 0 {
+		query = query.Where("o.number = ?", filter.Number)
+	}
+	if filter.GuestSession > 0 {
+		query = query.Where("o.guest_session_id = ?", filter.GuestSession)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	err := query.Scan(ctx)
+	return orders, err
+}
+
+// orderPromotionsInOrder loads an order's promotions in the order they were applied
+func orderPromotionsInOrder(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.Order("op.id ASC")
+}
+
+// orderItemsInOrder loads order items in the order they were added
+func orderItemsInOrder(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.Order("oi.id ASC")
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// OrderNumber is the last order number given on a business day at a restaurant
+type OrderNumber struct {
+	bun.BaseModel `bun:"table:order_numbers,alias:onum"`
+
+	RestaurantID int       `bun:"restaurant_id,pk" json:"-"`
+	BusinessDay  time.Time `bun:"business_day,pk,type:date" json:"business_day"`
+	LastNumber   int       `bun:"last_number,notnull" json:"last_number"`
+}
+
+// nextNumber returns the number of a new order placed on a business day at the
+// restaurant of ctx, the one after the day's last there. The day's counter stays
+// locked until tx ends, so concurrent orders get consecutive numbers, and an order
+// rolled back gives its number back.
+func nextNumber(ctx context.Context, tx bun.Tx, day time.Time) (int, error) {
+	restaurant := database.RestaurantID(ctx)
+	if _, err := tx.NewInsert().Model(&OrderNumber{RestaurantID: restaurant, BusinessDay: day}).Ignore().Exec(ctx); err != nil {
+		return 0, err
+	}
+	_, err := tx.NewUpdate().
+		Model((*OrderNumber)(nil)).
+		Set("last_number = last_number + 1").
+		Where("restaurant_id = ? AND business_day = ?", restaurant, day).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var number int
+	err = tx.NewSelect().
+		Model((*OrderNumber)(nil)).
+		Column("last_number").
+		Where("restaurant_id = ? AND business_day = ?", restaurant, day).
+		Scan(ctx, &number)
+	return number, err
+}
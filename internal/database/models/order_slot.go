@@ -0,0 +1,64 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// ErrSlotFull is returned when an order's time slot has no room left
+var ErrSlotFull = newOutcome("order slot is full")
+
+// OrderSlot counts the online orders placed at a restaurant in a time slot starting
+// at SlotAt
+type OrderSlot struct {
+	bun.BaseModel `bun:"table:order_slots,alias:os"`
+
+	RestaurantID int       `bun:"restaurant_id,pk" json:"-"`
+	SlotAt       time.Time `bun:"slot_at,pk" json:"slot_at"`
+	Orders       int       `bun:"orders,notnull" json:"orders"`
+}
+
+// claimSlot counts an order in the slot of the restaurant of ctx starting at slotAt,
+// failing with ErrSlotFull when it already has capacity orders; a capacity of 0 is
+// unlimited
+func claimSlot(ctx context.Context, tx bun.Tx, slotAt time.Time, capacity int) error {
+	restaurant := database.RestaurantID(ctx)
+	if _, err := tx.NewInsert().Model(&OrderSlot{RestaurantID: restaurant, SlotAt: slotAt}).Ignore().Exec(ctx); err != nil {
+		return err
+	}
+
+	query := tx.NewUpdate().
+		Model((*OrderSlot)(nil)).
+		Set("orders = orders + 1").
+		Where("restaurant_id = ? AND slot_at = ?", restaurant, slotAt)
+	if capacity > 0 {
+		query = query.Where("orders < ?", capacity)
+	}
+	res, err := query.Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrSlotFull
+	}
+	return nil
+}
+
+// SlotCounts returns the slots starting from from and before to that have orders,
+// earliest first. It reads from the primary, as it is used to find room for a new
+// order.
+func (q *OrderQuery) SlotCounts(ctx context.Context, from, to time.Time) ([]OrderSlot, error) {
+	var slots []OrderSlot
+	err := database.Scope(ctx, q.db.NewSelect().Model(&slots)).
+		Where("slot_at >= ? AND slot_at < ?", from, to).
+		Where("orders > 0").
+		Order("slot_at ASC").
+		Scan(ctx)
+	return slots, err
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Payment methods
+const (
+	PaymentCash        = "cash"
+	PaymentCard        = "card"
+	PaymentGiftCard    = "gift_card"
+	PaymentStoreCredit = "store_credit"
+)
+
+// ErrOrderPaid is returned when paying more than an order has left to pay
+var ErrOrderPaid = newOutcome("order already paid")
+
+// OrderPayment records an amount paid towards an order's total
+type OrderPayment struct {
+	bun.BaseModel `bun:"table:order_payments,alias:op"`
+
+	ID        int             `bun:"id,pk,autoincrement" json:"id"`
+	OrderID   string          `bun:"order_id,notnull" json:"order_id"`
+	Method    string          `bun:"method,notnull" json:"method"`
+	Amount    decimal.Decimal `bun:"amount,type:decimal(10,2),notnull" json:"amount"`
+	CreatedAt time.Time       `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+
+	// Points the customer earns if the payment completes the order, or nil
+	Earn *LoyaltyTransaction `bun:"-" json:"-"`
+}
+
+// orderPaymentsInOrder sorts an order's payments by when they were made
+func orderPaymentsInOrder(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.Order("op.id ASC")
+}
+
+// Pay records a payment towards an order in one transaction. It fails with
+// ErrOrderPaid when the amount is more than the order has left to pay, or the order
+// was cancelled.
+func (q *OrderQuery) Pay(ctx context.Context, payment *OrderPayment) error {
+	return q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return pay(ctx, tx, payment)
+	})
+}
+
+// pay adds a payment to its order's amount paid, unless that would exceed the order's
+// total and tip, and records it. When the payment completes the order, the customer
+// earns the payment's points.
+func pay(ctx context.Context, tx bun.Tx, payment *OrderPayment) error {
+	res, err := database.Scope(ctx, tx.NewUpdate().Model((*Order)(nil))).
+		Set("amount_paid = amount_paid + ?", payment.Amount).
+		Set("updated_at = ?", time.Now()).
+		Where("id = ? AND amount_paid + ? <= total + tip AND status <> ?", payment.OrderID, payment.Amount, OrderStatusCancelled).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrOrderPaid
+	}
+	if _, err := tx.NewInsert().Model(payment).Exec(ctx); err != nil {
+		return err
+	}
+
+	if payment.Earn == nil {
+		return nil
+	}
+	paid, err := tx.NewSelect().
+		Model((*Order)(nil)).
+		Where("id = ? AND amount_paid = total + tip", payment.OrderID).
+		Exists(ctx)
+	if err != nil || !paid {
+		return err
+	}
+	payment.Earn.OrderID = &payment.OrderID
+	return earnPoints(ctx, tx, payment.Earn)
+}
@@ -0,0 +1,246 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// ErrExperimentEnded is returned when ending a price experiment that has ended already
+var ErrExperimentEnded = newOutcome("price experiment already ended")
+
+// PriceExperiment tests variant prices of a menu item from StartsAt until EndsAt. Guest
+// sessions viewing the menu while it runs are split between its variants by their
+// weights, and each is shown and charged its variant's price.
+type PriceExperiment struct {
+	bun.BaseModel `bun:"table:price_experiments,alias:pe"`
+
+	ID           int                      `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int                      `bun:"restaurant_id,notnull" json:"-"` // Restaurant whose menu the experiment runs on
+	Name         string                   `bun:"name,notnull" json:"name"`
+	MenuItemID   int                      `bun:"menu_item_id,notnull" json:"menu_item_id"`
+	StartsAt     time.Time                `bun:"starts_at,notnull" json:"starts_at"`
+	EndsAt       time.Time                `bun:"ends_at,notnull" json:"ends_at"`
+	Variants     []PriceExperimentVariant `bun:"rel:has-many,join:id=experiment_id" json:"variants,omitempty"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (e *PriceExperiment) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if e.RestaurantID == 0 {
+			e.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		e.CreatedAt = now
+		e.UpdatedAt = now
+	case *bun.UpdateQuery:
+		e.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// PriceExperimentVariant is a price tested by an experiment. Weight is the percentage of
+// the experiment's sessions shown it.
+type PriceExperimentVariant struct {
+	bun.BaseModel `bun:"table:price_experiment_variants,alias:pv"`
+
+	ID           int             `bun:"id,pk,autoincrement" json:"id"`
+	ExperimentID int             `bun:"experiment_id,notnull" json:"experiment_id"`
+	Name         string          `bun:"name,notnull" json:"name"`
+	Price        decimal.Decimal `bun:"price,type:decimal(10,2),notnull" json:"price"`
+	Weight       int             `bun:"weight,notnull" json:"weight"`
+}
+
+// PriceExperimentExposure records the variant a guest session was shown, the first time
+// it viewed the menu while the experiment ran
+type PriceExperimentExposure struct {
+	bun.BaseModel `bun:"table:price_experiment_exposures,alias:px"`
+
+	ID             int       `bun:"id,pk,autoincrement" json:"id"`
+	ExperimentID   int       `bun:"experiment_id,notnull" json:"experiment_id"`
+	VariantID      int       `bun:"variant_id,notnull" json:"variant_id"`
+	GuestSessionID int       `bun:"guest_session_id,notnull" json:"guest_session_id"`
+	ShownAt        time.Time `bun:"shown_at,notnull" json:"shown_at"`
+}
+
+// ExposedPrice is the price of a menu item a guest session was shown by an experiment
+type ExposedPrice struct {
+	MenuItemID int             `bun:"menu_item_id"`
+	Price      decimal.Decimal `bun:"price"`
+}
+
+// VariantResult is what the guest sessions shown a variant ordered of the experiment's
+// menu item, in non-cancelled orders placed after they were shown it
+type VariantResult struct {
+	VariantID int             `bun:"variant_id"`
+	Sessions  int             `bun:"sessions"`
+	Ordering  int             `bun:"ordering"` // Sessions that ordered the item
+	Quantity  int             `bun:"quantity"`
+	Revenue   decimal.Decimal `bun:"revenue"` // Net of line discounts
+}
+
+// PriceExperimentQuery provides query methods for PriceExperiment
+type PriceExperimentQuery struct {
+	db *bun.DB
+}
+
+// NewPriceExperimentQuery creates a new query builder for PriceExperiment
+func NewPriceExperimentQuery(db *bun.DB) *PriceExperimentQuery {
+	return &PriceExperimentQuery{db: db}
+}
+
+// List returns all price experiments, latest start first, with their variants
+func (q *PriceExperimentQuery) List(ctx context.Context) ([]PriceExperiment, error) {
+	var experiments []PriceExperiment
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&experiments)).
+		Relation("Variants", orderVariants).
+		Order("pe.starts_at DESC", "pe.id DESC").
+		Scan(ctx)
+	return experiments, err
+}
+
+// FindByID finds a price experiment by ID, with its variants
+func (q *PriceExperimentQuery) FindByID(ctx context.Context, id int) (*PriceExperiment, error) {
+	experiment := new(PriceExperiment)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(experiment)).
+		Relation("Variants", orderVariants).
+		Where("pe.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return experiment, nil
+}
+
+// Running returns the price experiments running at at, with their variants
+func (q *PriceExperimentQuery) Running(ctx context.Context, at time.Time) ([]PriceExperiment, error) {
+	var experiments []PriceExperiment
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&experiments)).
+		Relation("Variants", orderVariants).
+		Where("pe.starts_at <= ?", at).
+		Where("pe.ends_at > ?", at).
+		Order("pe.id ASC").
+		Scan(ctx)
+	return experiments, err
+}
+
+// orderVariants loads an experiment's variants in the order they were given
+func orderVariants(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.Order("pv.id ASC")
+}
+
+// Overlapping reports whether a price experiment of a menu item runs at some time from
+// from until to. It reads from the primary, as it guards against running two
+// experiments of an item at once.
+func (q *PriceExperimentQuery) Overlapping(ctx context.Context, menuItemID int, from, to time.Time) (bool, error) {
+	return database.Scope(ctx, q.db.NewSelect().Model((*PriceExperiment)(nil))).
+		Where("menu_item_id = ?", menuItemID).
+		Where("starts_at < ?", to).
+		Where("ends_at > ?", from).
+		Exists(ctx)
+}
+
+// Create inserts a price experiment with its variants
+func (q *PriceExperimentQuery) Create(ctx context.Context, experiment *PriceExperiment) error {
+	return q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(experiment).Exec(ctx); err != nil {
+			return err
+		}
+		for i := range experiment.Variants {
+			experiment.Variants[i].ExperimentID = experiment.ID
+		}
+		_, err := tx.NewInsert().Model(&experiment.Variants).Exec(ctx)
+		return err
+	})
+}
+
+// End ends a running or scheduled price experiment at at. It fails with
+// ErrExperimentEnded once the experiment has ended.
+func (q *PriceExperimentQuery) End(ctx context.Context, id int, at time.Time) error {
+	res, err := database.Scope(ctx, q.db.NewUpdate().Model((*PriceExperiment)(nil))).
+		Set("ends_at = ?", at).
+		Set("starts_at = CASE WHEN starts_at > ? THEN ? ELSE starts_at END", at, at).
+		Set("updated_at = ?", at).
+		Where("id = ?", id).
+		Where("ends_at > ?", at).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrExperimentEnded
+	}
+	return nil
+}
+
+// Delete removes a price experiment with its variants and exposures
+func (q *PriceExperimentQuery) Delete(ctx context.Context, id int) error {
+	_, err := database.Scope(ctx, q.db.NewDelete().Model((*PriceExperiment)(nil))).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+// HasExposures reports whether a price experiment was shown to a guest session. It
+// reads from the primary, as it guards against deleting experiments with results.
+func (q *PriceExperimentQuery) HasExposures(ctx context.Context, id int) (bool, error) {
+	return q.db.NewSelect().
+		Model((*PriceExperimentExposure)(nil)).
+		Where("experiment_id = ?", id).
+		Exists(ctx)
+}
+
+// RecordExposures inserts exposures, keeping the one recorded first for a session and
+// experiment
+func (q *PriceExperimentQuery) RecordExposures(ctx context.Context, exposures []PriceExperimentExposure) error {
+	if len(exposures) == 0 {
+		return nil
+	}
+	_, err := q.db.NewInsert().Model(&exposures).Ignore().Exec(ctx)
+	return err
+}
+
+// ExposedPrices returns the experiment prices a guest session was shown, by menu item.
+// It reads from the primary, as the session's orders are charged them.
+func (q *PriceExperimentQuery) ExposedPrices(ctx context.Context, guestSessionID int) ([]ExposedPrice, error) {
+	var prices []ExposedPrice
+	err := q.db.NewSelect().
+		Model((*PriceExperimentExposure)(nil)).
+		Join("JOIN price_experiments AS pe ON pe.id = px.experiment_id").
+		Join("JOIN price_experiment_variants AS pv ON pv.id = px.variant_id").
+		ColumnExpr("pe.menu_item_id, pv.price").
+		Where("px.guest_session_id = ?", guestSessionID).
+		Order("px.shown_at ASC").
+		Scan(ctx, &prices)
+	return prices, err
+}
+
+// Results sums, for each variant of a price experiment of menuItemID, what the
+// sessions shown it ordered of the item
+func (q *PriceExperimentQuery) Results(ctx context.Context, id, menuItemID int) ([]VariantResult, error) {
+	var results []VariantResult
+	err := database.Reader(ctx, q.db).NewSelect().
+		TableExpr("price_experiment_variants AS pv").
+		Join("LEFT JOIN price_experiment_exposures AS px ON px.variant_id = pv.id").
+		Join("LEFT JOIN (orders AS o JOIN order_items AS oi ON oi.order_id = o.id AND oi.menu_item_id = ?) "+
+			"ON o.guest_session_id = px.guest_session_id AND o.status <> ? AND o.created_at >= px.shown_at",
+			menuItemID, OrderStatusCancelled).
+		ColumnExpr("pv.id AS variant_id").
+		ColumnExpr("COUNT(DISTINCT px.guest_session_id) AS sessions").
+		ColumnExpr("COUNT(DISTINCT o.guest_session_id) AS ordering").
+		ColumnExpr("COALESCE(SUM(oi.quantity), 0) AS quantity").
+		ColumnExpr("COALESCE(SUM(oi.quantity * oi.unit_price - oi.discount), 0) AS revenue").
+		Where("pv.experiment_id = ?", id).
+		GroupExpr("pv.id").
+		OrderExpr("pv.id ASC").
+		Scan(ctx, &results)
+	return results, err
+}
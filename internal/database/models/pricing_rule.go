@@ -0,0 +1,105 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Pricing rule discount types
+const (
+	DiscountPercent = "percent" // Discount is a percentage of the price
+	DiscountAmount  = "amount"  // Discount is taken off the price
+)
+
+// PricingRule discounts menu items during a recurring time window, e.g. a happy hour.
+// It applies to one menu item when MenuItemID is set, to the items of a category when
+// Category is set, and otherwise to every item.
+type PricingRule struct {
+	bun.BaseModel `bun:"table:pricing_rules,alias:pr"`
+
+	ID           int     `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int     `bun:"restaurant_id,notnull" json:"-"` // Restaurant the rule prices orders of
+	Name         string  `bun:"name,notnull" json:"name"`
+	Category     *string `bun:"category" json:"category,omitempty"`
+	MenuItemID   *int    `bun:"menu_item_id" json:"menu_item_id,omitempty"`
+
+	// Days are comma-separated weekdays (mon,tue,...), every day when empty. The
+	// window runs from StartTime to EndTime (HH:MM, restaurant time) and past
+	// midnight when EndTime is earlier.
+	Days      string `bun:"days,notnull" json:"days"`
+	StartTime string `bun:"start_time,notnull" json:"start_time"`
+	EndTime   string `bun:"end_time,notnull" json:"end_time"`
+
+	DiscountType string          `bun:"discount_type,notnull" json:"discount_type"`
+	Discount     decimal.Decimal `bun:"discount,type:decimal(10,2),notnull" json:"discount"`
+	IsActive     bool            `bun:"is_active,notnull,default:true" json:"is_active"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (p *PricingRule) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if p.RestaurantID == 0 {
+			p.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		p.CreatedAt = now
+		p.UpdatedAt = now
+	case *bun.UpdateQuery:
+		p.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// PricingRuleQuery provides query methods for PricingRule
+type PricingRuleQuery struct {
+	db *bun.DB
+}
+
+// NewPricingRuleQuery creates a new query builder for PricingRule
+func NewPricingRuleQuery(db *bun.DB) *PricingRuleQuery {
+	return &PricingRuleQuery{db: db}
+}
+
+// List returns all pricing rules by ID
+func (q *PricingRuleQuery) List(ctx context.Context) ([]PricingRule, error) {
+	var rules []PricingRule
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&rules)).Order("pr.id ASC").Scan(ctx)
+	return rules, err
+}
+
+// FindByID finds a pricing rule by ID
+func (q *PricingRuleQuery) FindByID(ctx context.Context, id int) (*PricingRule, error) {
+	rule := new(PricingRule)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(rule)).Where("pr.id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Create inserts a pricing rule
+func (q *PricingRuleQuery) Create(ctx context.Context, rule *PricingRule) error {
+	_, err := q.db.NewInsert().Model(rule).Exec(ctx)
+	return err
+}
+
+// Update saves every column of a pricing rule but its restaurant
+func (q *PricingRuleQuery) Update(ctx context.Context, rule *PricingRule) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model(rule)).WherePK().ExcludeColumn("restaurant_id", "created_at").Exec(ctx)
+	return err
+}
+
+// Delete removes a pricing rule; orders keep the prices they were charged
+func (q *PricingRuleQuery) Delete(ctx context.Context, id int) error {
+	_, err := database.Scope(ctx, q.db.NewDelete().Model((*PricingRule)(nil))).Where("id = ?", id).Exec(ctx)
+	return err
+}
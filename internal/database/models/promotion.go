@@ -0,0 +1,128 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Promotion types
+const (
+	// PromotionBuyGet discounts GetQuantity of every BuyQuantity + GetQuantity
+	// eligible units ordered, cheapest first, e.g. buy one get one free
+	PromotionBuyGet = "buy_get"
+	// PromotionSpendGet discounts the eligible lines once they total MinSpend, e.g.
+	// spend 50 get 10 off
+	PromotionSpendGet = "spend_get"
+)
+
+// Promotion is a deal evaluated when orders are priced. Like a pricing rule, it
+// covers one menu item when MenuItemID is set, the items of a category when Category
+// is set, and otherwise every item.
+type Promotion struct {
+	bun.BaseModel `bun:"table:promotions,alias:pm"`
+
+	ID           int     `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int     `bun:"restaurant_id,notnull" json:"-"` // Restaurant running the promotion
+	Name         string  `bun:"name,notnull" json:"name"`
+	Type         string  `bun:"type,notnull" json:"type"`
+	Category     *string `bun:"category" json:"category,omitempty"`
+	MenuItemID   *int    `bun:"menu_item_id" json:"menu_item_id,omitempty"`
+
+	// Conditions: BuyQuantity and GetQuantity for PromotionBuyGet, MinSpend for
+	// PromotionSpendGet
+	BuyQuantity int             `bun:"buy_quantity,notnull" json:"buy_quantity"`
+	GetQuantity int             `bun:"get_quantity,notnull" json:"get_quantity"`
+	MinSpend    decimal.Decimal `bun:"min_spend,type:decimal(10,2),notnull" json:"min_spend"`
+
+	// Reward: the discount on the free units of PromotionBuyGet (100 percent makes
+	// them free) or on the eligible lines of PromotionSpendGet
+	DiscountType string          `bun:"discount_type,notnull" json:"discount_type"` // DiscountPercent or DiscountAmount
+	Discount     decimal.Decimal `bun:"discount,type:decimal(10,2),notnull" json:"discount"`
+
+	// Validity window, open-ended when nil
+	ValidFrom  *time.Time `bun:"valid_from" json:"valid_from,omitempty"`
+	ValidUntil *time.Time `bun:"valid_until" json:"valid_until,omitempty"`
+	IsActive   bool       `bun:"is_active,notnull,default:true" json:"is_active"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (p *Promotion) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if p.RestaurantID == 0 {
+			p.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		p.CreatedAt = now
+		p.UpdatedAt = now
+	case *bun.UpdateQuery:
+		p.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// OrderPromotion is a promotion applied to an order, with an explanation of what it
+// discounted
+type OrderPromotion struct {
+	bun.BaseModel `bun:"table:order_promotions,alias:op"`
+
+	ID          int             `bun:"id,pk,autoincrement" json:"id"`
+	OrderID     string          `bun:"order_id,notnull" json:"order_id"`
+	PromotionID *int            `bun:"promotion_id" json:"promotion_id,omitempty"`
+	Name        string          `bun:"name,notnull" json:"name"`
+	Description string          `bun:"description,notnull" json:"description"`
+	Discount    decimal.Decimal `bun:"discount,type:decimal(10,2),notnull" json:"discount"`
+}
+
+// PromotionQuery provides query methods for Promotion
+type PromotionQuery struct {
+	db *bun.DB
+}
+
+// NewPromotionQuery creates a new query builder for Promotion
+func NewPromotionQuery(db *bun.DB) *PromotionQuery {
+	return &PromotionQuery{db: db}
+}
+
+// List returns all promotions by ID
+func (q *PromotionQuery) List(ctx context.Context) ([]Promotion, error) {
+	var promotions []Promotion
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&promotions)).Order("pm.id ASC").Scan(ctx)
+	return promotions, err
+}
+
+// FindByID finds a promotion by ID
+func (q *PromotionQuery) FindByID(ctx context.Context, id int) (*Promotion, error) {
+	promotion := new(Promotion)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(promotion)).Where("pm.id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+// Create inserts a promotion
+func (q *PromotionQuery) Create(ctx context.Context, promotion *Promotion) error {
+	_, err := q.db.NewInsert().Model(promotion).Exec(ctx)
+	return err
+}
+
+// Update saves every column of a promotion but its restaurant
+func (q *PromotionQuery) Update(ctx context.Context, promotion *Promotion) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model(promotion)).WherePK().ExcludeColumn("restaurant_id", "created_at").Exec(ctx)
+	return err
+}
+
+// Delete removes a promotion; orders keep the promotions applied to them
+func (q *PromotionQuery) Delete(ctx context.Context, id int) error {
+	_, err := database.Scope(ctx, q.db.NewDelete().Model((*Promotion)(nil))).Where("id = ?", id).Exec(ctx)
+	return err
+}
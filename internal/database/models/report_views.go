@@ -0,0 +1,68 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// ReportViews are the materialized views of pre-aggregated sales of every restaurant,
+// created by the report views migration, with the query each is computed from on MySQL
+var ReportViews = map[string]string{
+	"report_daily_sales": `
+		INSERT INTO report_daily_sales (restaurant_id, day, orders, revenue)
+		SELECT o.restaurant_id, CAST(o.created_at AS DATE), COUNT(*), SUM(o.total)
+		FROM orders AS o
+		WHERE o.status <> 'cancelled'
+		GROUP BY o.restaurant_id, CAST(o.created_at AS DATE)`,
+	"report_item_sales": `
+		INSERT INTO report_item_sales (restaurant_id, day, menu_item_id, name, category, orders, quantity, revenue)
+		SELECT o.restaurant_id, CAST(o.created_at AS DATE), COALESCE(oi.menu_item_id, 0), oi.name,
+			COALESCE(mi.category, 'uncategorized'), COUNT(DISTINCT o.id), SUM(oi.quantity),
+			SUM(oi.quantity * oi.unit_price - oi.discount)
+		FROM orders AS o
+		JOIN order_items AS oi ON oi.order_id = o.id
+		LEFT JOIN menu_items AS mi ON mi.id = oi.menu_item_id
+		WHERE o.status <> 'cancelled'
+		GROUP BY o.restaurant_id, CAST(o.created_at AS DATE), COALESCE(oi.menu_item_id, 0), oi.name, COALESCE(mi.category, 'uncategorized')`,
+}
+
+// ReportViewQuery refreshes the report views
+type ReportViewQuery struct {
+	db *bun.DB
+}
+
+// NewReportViewQuery creates a report view query builder
+func NewReportViewQuery(db *bun.DB) *ReportViewQuery {
+	return &ReportViewQuery{db: db}
+}
+
+// Refresh recomputes a report view and returns its number of rows. PostgreSQL
+// refreshes the materialized view concurrently, so reads aren't blocked; on MySQL the
+// view is a table whose rows are replaced in a transaction.
+func (q *ReportViewQuery) Refresh(ctx context.Context, view string) (int, error) {
+	fill, ok := ReportViews[view]
+	if !ok {
+		return 0, fmt.Errorf("unknown report view %q", view)
+	}
+
+	if database.IsMySQL(q.db) {
+		err := q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			if _, err := tx.NewDelete().TableExpr("?", bun.Ident(view)).Where("1 = 1").Exec(ctx); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, fill)
+			return err
+		})
+		if err != nil {
+			return 0, err
+		}
+	} else if _, err := q.db.NewRaw("REFRESH MATERIALIZED VIEW CONCURRENTLY ?", bun.Ident(view)).Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return q.db.NewSelect().TableExpr("?", bun.Ident(view)).Count(ctx)
+}
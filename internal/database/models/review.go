@@ -0,0 +1,248 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Moderation statuses of a review
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// MenuItemReview is a customer's rating of a menu item from 1 to 5, with an optional
+// comment. Flags name the spam and abuse checks it tripped; flagged reviews stay
+// pending until a manager approves or rejects them.
+type MenuItemReview struct {
+	bun.BaseModel `bun:"table:menu_item_reviews,alias:mr"`
+
+	ID           int       `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int       `bun:"restaurant_id,notnull" json:"-"` // Restaurant of the menu item
+	MenuItemID   int       `bun:"menu_item_id,notnull" json:"menu_item_id"`
+	MenuItem     *MenuItem `bun:"rel:belongs-to,join:menu_item_id=id" json:"menu_item,omitempty"`
+	// Order the item was eaten in, when the customer gave it
+	OrderID     *string    `bun:"order_id" json:"order_id,omitempty"`
+	Rating      int        `bun:"rating,notnull" json:"rating"`
+	Comment     *string    `bun:"comment,type:text" json:"comment,omitempty"`
+	AuthorName  *string    `bun:"author_name" json:"author_name,omitempty"`
+	Status      string     `bun:"status,notnull" json:"status"`
+	Flags       []string   `bun:"flags,type:text,nullzero" json:"flags,omitempty"`
+	ClientIP    *string    `bun:"client_ip" json:"-"`
+	ModeratedAt *time.Time `bun:"moderated_at" json:"moderated_at,omitempty"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (r *MenuItemReview) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if r.RestaurantID == 0 {
+			r.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		r.CreatedAt = now
+		r.UpdatedAt = now
+	case *bun.UpdateQuery:
+		r.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// ReviewFilter narrows ReviewQuery.List; empty fields match any review
+type ReviewFilter struct {
+	MenuItemID int
+	Status     string
+	Flagged    bool // Only reviews with flags
+	Limit      int
+	Offset     int
+}
+
+// ReviewQuery provides query methods for MenuItemReview
+type ReviewQuery struct {
+	db *bun.DB
+}
+
+// NewReviewQuery creates a new query builder for MenuItemReview
+func NewReviewQuery(db *bun.DB) *ReviewQuery {
+	return &ReviewQuery{db: db}
+}
+
+// List returns the reviews matching filter, newest first, with their menu item
+func (q *ReviewQuery) List(ctx context.Context, filter ReviewFilter) ([]MenuItemReview, error) {
+	var reviews []MenuItemReview
+	query := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&reviews)).
+		Relation("MenuItem", reviewMenuItem)
+	if filter.MenuItemID != 0 {
+		query = query.Where("mr.menu_item_id = ?", filter.MenuItemID)
+	}
+	if filter.Status != "" {
+		query = query.Where("mr.status = ?", filter.Status)
+	}
+	if filter.Flagged {
+		query = query.Where("mr.flags IS NOT NULL")
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	err := query.Order("mr.created_at DESC", "mr.id DESC").Scan(ctx)
+	return reviews, err
+}
+
+// FindByID finds a review by ID, with its menu item
+func (q *ReviewQuery) FindByID(ctx context.Context, id int) (*MenuItemReview, error) {
+	review := new(MenuItemReview)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(review)).
+		Relation("MenuItem", reviewMenuItem).
+		Where("mr.id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// reviewMenuItem loads the ID and name of a review's menu item, also once it was
+// deleted
+func reviewMenuItem(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.Column("id", "name").WhereAllWithDeleted()
+}
+
+// Create inserts a review, counting it in its menu item's rating when it is approved
+func (q *ReviewQuery) Create(ctx context.Context, review *MenuItemReview) error {
+	return q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().Model(review).Exec(ctx); err != nil {
+			return err
+		}
+		if review.Status != ReviewStatusApproved {
+			return nil
+		}
+		return addRating(ctx, tx, review.MenuItemID, 1, review.Rating)
+	})
+}
+
+// Moderate sets the status of a review, moderated at at, and adds it to or removes it
+// from its menu item's rating as it becomes or stops being approved. It returns
+// sql.ErrNoRows when the review doesn't exist.
+func (q *ReviewQuery) Moderate(ctx context.Context, id int, status string, at time.Time) error {
+	return q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		review, err := lockReview(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		_, err = database.Scope(ctx, tx.NewUpdate().Model((*MenuItemReview)(nil))).
+			Set("status = ?", status).
+			Set("moderated_at = ?", at).
+			Set("updated_at = ?", at).
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		switch {
+		case review.Status != ReviewStatusApproved && status == ReviewStatusApproved:
+			return addRating(ctx, tx, review.MenuItemID, 1, review.Rating)
+		case review.Status == ReviewStatusApproved && status != ReviewStatusApproved:
+			return addRating(ctx, tx, review.MenuItemID, -1, -review.Rating)
+		}
+		return nil
+	})
+}
+
+// Delete removes a review, and it from its menu item's rating when it was approved. It
+// returns sql.ErrNoRows when the review doesn't exist.
+func (q *ReviewQuery) Delete(ctx context.Context, id int) error {
+	return q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		review, err := lockReview(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if _, err := database.Scope(ctx, tx.NewDelete().Model((*MenuItemReview)(nil))).Where("id = ?", id).Exec(ctx); err != nil {
+			return err
+		}
+		if review.Status != ReviewStatusApproved {
+			return nil
+		}
+		return addRating(ctx, tx, review.MenuItemID, -1, -review.Rating)
+	})
+}
+
+// lockReview reads the menu item, rating and status of a review, locking its row
+// until tx ends so concurrent moderations count it in its item's rating once
+func lockReview(ctx context.Context, tx bun.Tx, id int) (*MenuItemReview, error) {
+	review := new(MenuItemReview)
+	err := database.Scope(ctx, tx.NewSelect().Model(review)).
+		Column("id", "menu_item_id", "rating", "status").
+		Where("id = ?", id).
+		For("UPDATE").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// addRating adds count reviews rating total to a menu item's rating, also once the
+// item was deleted
+func addRating(ctx context.Context, tx bun.Tx, menuItemID, count, total int) error {
+	_, err := tx.NewUpdate().
+		Model((*MenuItem)(nil)).
+		Set("review_count = review_count + ?", count).
+		Set("rating_total = rating_total + ?", total).
+		Where("id = ?", menuItemID).
+		WhereAllWithDeleted().
+		Exec(ctx)
+	return err
+}
+
+// RatingCounts returns the number of approved reviews of a menu item by rating
+func (q *ReviewQuery) RatingCounts(ctx context.Context, menuItemID int) (map[int]int, error) {
+	var rows []struct {
+		Rating int `bun:"rating"`
+		Count  int `bun:"count"`
+	}
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model((*MenuItemReview)(nil))).
+		Column("mr.rating").
+		ColumnExpr("COUNT(*) AS count").
+		Where("mr.menu_item_id = ?", menuItemID).
+		Where("mr.status = ?", ReviewStatusApproved).
+		Group("mr.rating").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[int]int, len(rows))
+	for _, row := range rows {
+		counts[row.Rating] = row.Count
+	}
+	return counts, nil
+}
+
+// CountFromIP counts the reviews of a menu item posted from a client IP since since. It
+// reads from the primary, as it flags repeated reviews.
+func (q *ReviewQuery) CountFromIP(ctx context.Context, menuItemID int, clientIP string, since time.Time) (int, error) {
+	return database.Scope(ctx, q.db.NewSelect().Model((*MenuItemReview)(nil))).
+		Where("menu_item_id = ?", menuItemID).
+		Where("client_ip = ?", clientIP).
+		Where("created_at >= ?", since).
+		Count(ctx)
+}
+
+// ExistsForOrder reports whether an order's review of a menu item exists. It reads from
+// the primary, as it guards against reviewing an item twice per order.
+func (q *ReviewQuery) ExistsForOrder(ctx context.Context, orderID string, menuItemID int) (bool, error) {
+	return database.Scope(ctx, q.db.NewSelect().Model((*MenuItemReview)(nil))).
+		Where("order_id = ?", orderID).
+		Where("menu_item_id = ?", menuItemID).
+		Exists(ctx)
+}
@@ -0,0 +1,199 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// SalesRow aggregates the sales of one group: a category, a menu item or all orders
+type SalesRow struct {
+	Key        string          `bun:"group_key"`
+	MenuItemID *int            `bun:"menu_item_id"`
+	Revenue    decimal.Decimal `bun:"revenue"`
+	Orders     int             `bun:"orders"`
+	Quantity   int             `bun:"quantity"`
+}
+
+// OrderTotal is the creation time, total and tax of one order
+type OrderTotal struct {
+	CreatedAt time.Time       `bun:"created_at"`
+	Total     decimal.Decimal `bun:"total"`
+	Tax       decimal.Decimal `bun:"tax"`
+}
+
+// ItemSales are the sales of one menu item
+type ItemSales struct {
+	MenuItemID int             `bun:"menu_item_id"`
+	Name       string          `bun:"name"`
+	Category   string          `bun:"category"`
+	Revenue    decimal.Decimal `bun:"revenue"`
+	Orders     int             `bun:"orders"`
+	Quantity   int             `bun:"quantity"`
+}
+
+// ItemPairing is a menu item ordered along with another one, and in how many orders
+type ItemPairing struct {
+	MenuItemID int `bun:"menu_item_id"`
+	Orders     int `bun:"orders"`
+}
+
+// ItemSalesFilter selects and ranks the menu items of an item sales query
+type ItemSalesFilter struct {
+	From     time.Time
+	To       time.Time
+	Category string
+	// SortBy is the ranking column: revenue, quantity or orders
+	SortBy    string
+	Ascending bool
+	Limit     int
+}
+
+// SalesQuery aggregates orders for sales reports. Cancelled orders are not sales and
+// are left out; a period includes from and excludes to.
+type SalesQuery struct {
+	db *bun.DB
+}
+
+// NewSalesQuery creates a sales query builder. Reports read from a replica when one is
+// configured.
+func NewSalesQuery(db *bun.DB) *SalesQuery {
+	return &SalesQuery{db: db}
+}
+
+// Totals returns the revenue and number of orders of the period
+func (q *SalesQuery) Totals(ctx context.Context, from, to time.Time) (SalesRow, error) {
+	var row SalesRow
+	err := q.orders(ctx, from, to).
+		ColumnExpr("COALESCE(SUM(o.total), 0) AS revenue").
+		ColumnExpr("COUNT(*) AS orders").
+		Scan(ctx, &row)
+	return row, err
+}
+
+// OrderTotals returns the creation time, total and tax of each order of the period, for
+// grouping by day in the restaurant's timezone
+func (q *SalesQuery) OrderTotals(ctx context.Context, from, to time.Time) ([]OrderTotal, error) {
+	var totals []OrderTotal
+	err := q.orders(ctx, from, to).
+		Column("o.created_at", "o.total", "o.tax").
+		OrderExpr("o.created_at ASC").
+		Scan(ctx, &totals)
+	return totals, err
+}
+
+// ByCategory returns the sales of each menu category, highest revenue first. Lines
+// not matched to the menu are grouped under "uncategorized".
+func (q *SalesQuery) ByCategory(ctx context.Context, from, to time.Time) ([]SalesRow, error) {
+	var rows []SalesRow
+	err := q.lines(ctx, from, to).
+		ColumnExpr("COALESCE(mi.category, 'uncategorized') AS group_key").
+		GroupExpr("COALESCE(mi.category, 'uncategorized')").
+		OrderExpr("revenue DESC").
+		Scan(ctx, &rows)
+	return rows, err
+}
+
+// ByItem returns the sales of each item as named on the order, highest revenue first
+func (q *SalesQuery) ByItem(ctx context.Context, from, to time.Time) ([]SalesRow, error) {
+	var rows []SalesRow
+	err := q.lines(ctx, from, to).
+		ColumnExpr("oi.name AS group_key").
+		ColumnExpr("oi.menu_item_id").
+		GroupExpr("oi.menu_item_id, oi.name").
+		OrderExpr("revenue DESC").
+		Scan(ctx, &rows)
+	return rows, err
+}
+
+// TopItems ranks the non-deleted menu items by their sales of the period, items
+// without sales included. Ties are broken by name.
+func (q *SalesQuery) TopItems(ctx context.Context, filter ItemSalesFilter) ([]ItemSales, error) {
+	direction := "DESC"
+	if filter.Ascending {
+		direction = "ASC"
+	}
+
+	var rows []ItemSales
+	query := database.ScopeAs(ctx, database.Reader(ctx, q.db).NewSelect(), "mi").
+		TableExpr("menu_items AS mi").
+		Join("LEFT JOIN (order_items AS oi JOIN orders AS o ON o.id = oi.order_id AND o.status <> ? AND o.created_at >= ? AND o.created_at < ?) ON oi.menu_item_id = mi.id",
+			OrderStatusCancelled, filter.From, filter.To).
+		ColumnExpr("mi.id AS menu_item_id").
+		ColumnExpr("mi.name, mi.category").
+		ColumnExpr("COALESCE(SUM(oi.quantity * oi.unit_price - oi.discount), 0) AS revenue").
+		ColumnExpr("COUNT(DISTINCT o.id) AS orders").
+		ColumnExpr("COALESCE(SUM(oi.quantity), 0) AS quantity").
+		Where("mi.deleted_at IS NULL").
+		GroupExpr("mi.id, mi.name, mi.category").
+		OrderExpr("? "+direction, bun.Ident(filter.SortBy)).
+		OrderExpr("mi.name ASC")
+	if filter.Category != "" {
+		query = query.Where("mi.category = ?", filter.Category)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	err := query.Scan(ctx, &rows)
+	return rows, err
+}
+
+// OrdersWith counts the orders of the period that included a menu item
+func (q *SalesQuery) OrdersWith(ctx context.Context, menuItemID int, from, to time.Time) (int, error) {
+	var count int
+	err := q.orders(ctx, from, to).
+		Join("JOIN order_items AS oi ON oi.order_id = o.id").
+		ColumnExpr("COUNT(DISTINCT o.id)").
+		Where("oi.menu_item_id = ?", menuItemID).
+		Scan(ctx, &count)
+	return count, err
+}
+
+// OrderedWith ranks the other menu items by the number of orders of the period they
+// were in along with a menu item, most first and ties by name. Only available,
+// non-deleted items are ranked; limit caps their number when positive.
+func (q *SalesQuery) OrderedWith(ctx context.Context, menuItemID int, from, to time.Time, limit int) ([]ItemPairing, error) {
+	var pairings []ItemPairing
+	query := q.orders(ctx, from, to).
+		Join("JOIN order_items AS base ON base.order_id = o.id AND base.menu_item_id = ?", menuItemID).
+		Join("JOIN order_items AS oi ON oi.order_id = o.id").
+		Join("JOIN menu_items AS mi ON mi.id = oi.menu_item_id").
+		ColumnExpr("mi.id AS menu_item_id").
+		ColumnExpr("COUNT(DISTINCT o.id) AS orders").
+		Where("mi.id <> ?", menuItemID).
+		Where("mi.deleted_at IS NULL").
+		Where("mi.is_available = true").
+		GroupExpr("mi.id, mi.name").
+		OrderExpr("orders DESC").
+		OrderExpr("mi.name ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Scan(ctx, &pairings)
+	return pairings, err
+}
+
+// orders selects the orders of the period that count as sales, at the restaurant of
+// ctx
+func (q *SalesQuery) orders(ctx context.Context, from, to time.Time) *bun.SelectQuery {
+	return database.ScopeAs(ctx, database.Reader(ctx, q.db).NewSelect(), "o").
+		TableExpr("orders AS o").
+		Where("o.status <> ?", OrderStatusCancelled).
+		Where("o.created_at >= ?", from).
+		Where("o.created_at < ?", to)
+}
+
+// lines selects the revenue (net of line discounts), orders and quantity of the order
+// lines of the period, with their menu item (soft-deleted or not) when matched
+func (q *SalesQuery) lines(ctx context.Context, from, to time.Time) *bun.SelectQuery {
+	return q.orders(ctx, from, to).
+		Join("JOIN order_items AS oi ON oi.order_id = o.id").
+		Join("LEFT JOIN menu_items AS mi ON mi.id = oi.menu_item_id").
+		ColumnExpr("SUM(oi.quantity * oi.unit_price - oi.discount) AS revenue").
+		ColumnExpr("COUNT(DISTINCT o.id) AS orders").
+		ColumnExpr("SUM(oi.quantity) AS quantity")
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Setting is a business setting of a restaurant stored by name, with its value
+// encoded as text
+type Setting struct {
+	bun.BaseModel `bun:"table:settings,alias:bs"`
+
+	RestaurantID int       `bun:"restaurant_id,pk" json:"-"`
+	Name         string    `bun:"name,pk" json:"name"`
+	Value        string    `bun:"value,notnull" json:"value"`
+	UpdatedAt    time.Time `bun:"updated_at,notnull" json:"updated_at"`
+}
+
+// SettingQuery provides query methods for Setting
+type SettingQuery struct {
+	db *bun.DB
+}
+
+// NewSettingQuery creates a new query builder for Setting
+func NewSettingQuery(db *bun.DB) *SettingQuery {
+	return &SettingQuery{db: db}
+}
+
+// All returns every stored setting of every restaurant. It reads from the primary, as
+// the settings are applied as soon as they are read.
+func (q *SettingQuery) All(ctx context.Context) ([]Setting, error) {
+	var settings []Setting
+	err := q.db.NewSelect().Model(&settings).Order("bs.restaurant_id ASC", "bs.name ASC").Scan(ctx)
+	return settings, err
+}
+
+// Save creates or replaces settings of the restaurant of ctx
+func (q *SettingQuery) Save(ctx context.Context, settings []Setting) error {
+	if len(settings) == 0 {
+		return nil
+	}
+	now := time.Now()
+	restaurant := database.RestaurantID(ctx)
+	for i := range settings {
+		settings[i].RestaurantID = restaurant
+		settings[i].UpdatedAt = now
+	}
+
+	query := q.db.NewInsert().Model(&settings)
+	if database.IsMySQL(q.db) {
+		query = query.On("DUPLICATE KEY UPDATE").Set("value = VALUES(value)").Set("updated_at = VALUES(updated_at)")
+	} else {
+		query = query.On("CONFLICT (restaurant_id, name) DO UPDATE").Set("value = EXCLUDED.value").Set("updated_at = EXCLUDED.updated_at")
+	}
+	_, err := query.Exec(ctx)
+	return err
+}
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// StaffMember works at the restaurant in Role, e.g. server or cook. Inactive staff
+// keep their past shifts but aren't scheduled.
+type StaffMember struct {
+	bun.BaseModel `bun:"table:staff,alias:st"`
+
+	ID           int     `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int     `bun:"restaurant_id,notnull" json:"-"` // Restaurant the member works at
+	Name         string  `bun:"name,notnull" json:"name"`
+	Role         string  `bun:"role,notnull" json:"role"`
+	Phone        *string `bun:"phone" json:"phone,omitempty"`
+	IsActive     bool    `bun:"is_active,notnull" json:"is_active"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (m *StaffMember) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if m.RestaurantID == 0 {
+			m.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		m.CreatedAt = now
+		m.UpdatedAt = now
+	case *bun.UpdateQuery:
+		m.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// Shift schedules a staff member to work in Role from StartsAt until EndsAt
+type Shift struct {
+	bun.BaseModel `bun:"table:shifts,alias:sh"`
+
+	ID           int          `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int          `bun:"restaurant_id,notnull" json:"-"` // Restaurant the shift is worked at
+	StaffID      int          `bun:"staff_id,notnull" json:"staff_id"`
+	Staff        *StaffMember `bun:"rel:belongs-to,join:staff_id=id" json:"staff,omitempty"`
+	Role         string       `bun:"role,notnull" json:"role"`
+	StartsAt     time.Time    `bun:"starts_at,notnull" json:"starts_at"`
+	EndsAt       time.Time    `bun:"ends_at,notnull" json:"ends_at"`
+	Notes        *string      `bun:"notes,type:text" json:"notes,omitempty"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (s *Shift) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if s.RestaurantID == 0 {
+			s.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		s.CreatedAt = now
+		s.UpdatedAt = now
+	case *bun.UpdateQuery:
+		s.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// StaffQuery provides query methods for StaffMember, Shift and TimePunch
+type StaffQuery struct {
+	db *bun.DB
+}
+
+// NewStaffQuery creates a new query builder for StaffMember
+func NewStaffQuery(db *bun.DB) *StaffQuery {
+	return &StaffQuery{db: db}
+}
+
+// List returns all staff by name
+func (q *StaffQuery) List(ctx context.Context) ([]StaffMember, error) {
+	var staff []StaffMember
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&staff)).Order("st.name ASC", "st.id ASC").Scan(ctx)
+	return staff, err
+}
+
+// FindByID finds a staff member by ID
+func (q *StaffQuery) FindByID(ctx context.Context, id int) (*StaffMember, error) {
+	member := new(StaffMember)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(member)).Where("st.id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// Create inserts a staff member
+func (q *StaffQuery) Create(ctx context.Context, member *StaffMember) error {
+	_, err := q.db.NewInsert().Model(member).Exec(ctx)
+	return err
+}
+
+// Update saves every column of a staff member but their restaurant
+func (q *StaffQuery) Update(ctx context.Context, member *StaffMember) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model(member)).WherePK().ExcludeColumn("restaurant_id", "created_at").Exec(ctx)
+	return err
+}
+
+// Delete removes a staff member and their shifts. Staff with punches can't be deleted.
+func (q *StaffQuery) Delete(ctx context.Context, id int) error {
+	_, err := database.Scope(ctx, q.db.NewDelete().Model((*StaffMember)(nil))).Where("id = ?", id).Exec(ctx)
+	return err
+}
+
+// Shifts returns the shifts starting from from until to, of one staff member when
+// staffID is set, by start time, with their staff member
+func (q *StaffQuery) Shifts(ctx context.Context, from, to time.Time, staffID *int) ([]Shift, error) {
+	var shifts []Shift
+	query := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&shifts)).
+		Relation("Staff").
+		Where("sh.starts_at >= ?", from).
+		Where("sh.starts_at < ?", to)
+	if staffID != nil {
+		query = query.Where("sh.staff_id = ?", *staffID)
+	}
+	err := query.Order("sh.starts_at ASC", "sh.id ASC").Scan(ctx)
+	return shifts, err
+}
+
+// FindShift finds a shift by ID, with its staff member
+func (q *StaffQuery) FindShift(ctx context.Context, id int) (*Shift, error) {
+	shift := new(Shift)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(shift)).Relation("Staff").Where("sh.id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+// OverlappingShifts returns the shifts of a staff member, other than exceptID, that
+// overlap the time from start until end. Back-to-back shifts don't overlap. It reads
+// from the primary, as it guards against scheduling someone twice at once.
+func (q *StaffQuery) OverlappingShifts(ctx context.Context, staffID int, start, end time.Time, exceptID int) ([]Shift, error) {
+	var shifts []Shift
+	err := database.Scope(ctx, q.db.NewSelect().Model(&shifts)).
+		Where("sh.staff_id = ?", staffID).
+		Where("sh.id <> ?", exceptID).
+		Where("sh.starts_at < ?", end).
+		Where("sh.ends_at > ?", start).
+		Order("sh.starts_at ASC").
+		Scan(ctx)
+	return shifts, err
+}
+
+// CreateShift inserts a shift
+func (q *StaffQuery) CreateShift(ctx context.Context, shift *Shift) error {
+	_, err := q.db.NewInsert().Model(shift).Exec(ctx)
+	return err
+}
+
+// UpdateShift saves every column of a shift but its restaurant
+func (q *StaffQuery) UpdateShift(ctx context.Context, shift *Shift) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model(shift)).WherePK().ExcludeColumn("restaurant_id", "created_at").Exec(ctx)
+	return err
+}
+
+// DeleteShift removes a shift
+func (q *StaffQuery) DeleteShift(ctx context.Context, id int) error {
+	_, err := database.Scope(ctx, q.db.NewDelete().Model((*Shift)(nil))).Where("id = ?", id).Exec(ctx)
+	return err
+}
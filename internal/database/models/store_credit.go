@@ -0,0 +1,175 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// Store credit entry types
+const (
+	StoreCreditCredit = "credit"
+	StoreCreditDebit  = "debit"
+)
+
+// Reasons for store credit entries
+const (
+	StoreCreditRefund     = "refund"     // Credit refunding an order
+	StoreCreditGoodwill   = "goodwill"   // Credit given as a gesture
+	StoreCreditPayment    = "payment"    // Debit paying an order
+	StoreCreditAdjustment = "adjustment" // Debit correcting a balance
+)
+
+// ErrStoreCreditBalance is returned when debiting more than a customer's store credit
+var ErrStoreCreditBalance = newOutcome("store credit balance too low")
+
+// StoreCreditAccount holds the store credit of a customer at a restaurant, told apart
+// by phone number
+type StoreCreditAccount struct {
+	bun.BaseModel `bun:"table:store_credit_accounts,alias:sca"`
+
+	RestaurantID  int             `bun:"restaurant_id,pk" json:"-"`
+	CustomerPhone string          `bun:"customer_phone,pk" json:"customer_phone"`
+	Balance       decimal.Decimal `bun:"balance,type:decimal(10,2),notnull" json:"balance"`
+
+	Entries []StoreCreditEntry `bun:"rel:has-many,join:restaurant_id=restaurant_id,join:customer_phone=customer_phone" json:"entries,omitempty"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// StoreCreditEntry records a change to a customer's store credit. Amount is positive
+// for credits and negative for debits; Balance is the balance after it. Entries are
+// never changed once recorded.
+type StoreCreditEntry struct {
+	bun.BaseModel `bun:"table:store_credit_entries,alias:sce"`
+
+	ID            int             `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID  int             `bun:"restaurant_id,notnull" json:"-"`
+	CustomerPhone string          `bun:"customer_phone,notnull" json:"customer_phone"`
+	Type          string          `bun:"type,notnull" json:"type"`     // StoreCreditCredit or StoreCreditDebit
+	Reason        string          `bun:"reason,notnull" json:"reason"` // StoreCreditRefund, StoreCreditGoodwill, ...
+	Amount        decimal.Decimal `bun:"amount,type:decimal(10,2),notnull" json:"amount"`
+	Balance       decimal.Decimal `bun:"balance,type:decimal(10,2),notnull" json:"balance"`
+	OrderID       *string         `bun:"order_id" json:"order_id,omitempty"` // Order refunded or paid
+	Note          *string         `bun:"note,type:text" json:"note,omitempty"`
+	RequestID     *string         `bun:"request_id" json:"request_id,omitempty"` // Request that made the change
+	CreatedAt     time.Time       `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+}
+
+// StoreCreditQuery provides query methods for StoreCreditAccount
+type StoreCreditQuery struct {
+	db *bun.DB
+}
+
+// NewStoreCreditQuery creates a new query builder for StoreCreditAccount
+func NewStoreCreditQuery(db *bun.DB) *StoreCreditQuery {
+	return &StoreCreditQuery{db: db}
+}
+
+// FindAccount finds the account of a customer at the restaurant of ctx with its
+// entries, newest first
+func (q *StoreCreditQuery) FindAccount(ctx context.Context, customerPhone string) (*StoreCreditAccount, error) {
+	account := new(StoreCreditAccount)
+	err := database.Reader(ctx, q.db).NewSelect().
+		Model(account).
+		Relation("Entries", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Order("sce.created_at DESC", "sce.id DESC")
+		}).
+		Where("sca.restaurant_id = ? AND sca.customer_phone = ?", database.RestaurantID(ctx), customerPhone).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// RefundedToCredit returns how much of an order was refunded to store credit. It reads
+// from the primary, as it is checked right before refunding more.
+func (q *StoreCreditQuery) RefundedToCredit(ctx context.Context, orderID string) (decimal.Decimal, error) {
+	var refunded decimal.NullDecimal
+	err := q.db.NewSelect().
+		Model((*StoreCreditEntry)(nil)).
+		ColumnExpr("SUM(amount)").
+		Where("order_id = ? AND reason = ?", orderID, StoreCreditRefund).
+		Scan(ctx, &refunded)
+	return refunded.Decimal, err
+}
+
+// Credit adds a credit entry's amount to the customer's account at the restaurant of
+// ctx, opening it on their first credit there, and records the entry
+func (q *StoreCreditQuery) Credit(ctx context.Context, entry *StoreCreditEntry) error {
+	return q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		now := time.Now()
+		entry.RestaurantID = database.RestaurantID(ctx)
+		account := &StoreCreditAccount{
+			RestaurantID:  entry.RestaurantID,
+			CustomerPhone: entry.CustomerPhone,
+			Balance:       entry.Amount,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		insert := tx.NewInsert().Model(account)
+		if database.IsMySQL(tx) {
+			insert = insert.On("DUPLICATE KEY UPDATE").Set("balance = balance + VALUES(balance), updated_at = VALUES(updated_at)")
+		} else {
+			insert = insert.On("CONFLICT (restaurant_id, customer_phone) DO UPDATE").
+				Set("balance = sca.balance + EXCLUDED.balance, updated_at = EXCLUDED.updated_at")
+		}
+		if _, err := insert.Exec(ctx); err != nil {
+			return err
+		}
+		return recordStoreCredit(ctx, tx, entry)
+	})
+}
+
+// Debit takes a debit entry's amount, negative, off the customer's account at the
+// restaurant of ctx, unless they have less left, and records the entry, in one
+// transaction. When payment is non-nil the amount pays it, failing like
+// OrderQuery.Pay.
+func (q *StoreCreditQuery) Debit(ctx context.Context, entry *StoreCreditEntry, payment *OrderPayment) error {
+	return q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		entry.RestaurantID = database.RestaurantID(ctx)
+		res, err := tx.NewUpdate().
+			Model((*StoreCreditAccount)(nil)).
+			Set("balance = balance + ?", entry.Amount).
+			Set("updated_at = ?", time.Now()).
+			Where("restaurant_id = ? AND customer_phone = ?", entry.RestaurantID, entry.CustomerPhone).
+			Where("balance >= ?", entry.Amount.Neg()).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return ErrStoreCreditBalance
+		}
+
+		if payment != nil {
+			payment.Method = PaymentStoreCredit
+			payment.Amount = entry.Amount.Neg()
+			if err := pay(ctx, tx, payment); err != nil {
+				return err
+			}
+			entry.OrderID = &payment.OrderID
+		}
+		return recordStoreCredit(ctx, tx, entry)
+	})
+}
+
+// recordStoreCredit inserts an entry with the customer's balance after it
+func recordStoreCredit(ctx context.Context, tx bun.Tx, entry *StoreCreditEntry) error {
+	if err := tx.NewSelect().Model((*StoreCreditAccount)(nil)).Column("balance").
+		Where("restaurant_id = ? AND customer_phone = ?", entry.RestaurantID, entry.CustomerPhone).
+		Scan(ctx, &entry.Balance); err != nil {
+		return err
+	}
+	entry.CreatedAt = time.Now()
+	_, err := tx.NewInsert().Model(entry).Exec(ctx)
+	return err
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// ErrTaxRateScopeTaken is returned when saving a tax rate for a menu item, category or
+// default that another rate of the restaurant took in the meantime
+var ErrTaxRateScopeTaken = newOutcome("tax rate already applies there")
+
+// TaxRate is a tax applied to order lines. It applies to one menu item when
+// MenuItemID is set, to the items of a category when Category is set, and otherwise
+// to every item without a more specific rate.
+type TaxRate struct {
+	bun.BaseModel `bun:"table:tax_rates,alias:tr"`
+
+	ID           int             `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int             `bun:"restaurant_id,notnull" json:"-"` // Restaurant charging the tax
+	Name         string          `bun:"name,notnull" json:"name"`
+	Rate         decimal.Decimal `bun:"rate,type:decimal(6,3),notnull" json:"rate"` // Percent
+	Category     *string         `bun:"category" json:"category,omitempty"`
+	MenuItemID   *int            `bun:"menu_item_id" json:"menu_item_id,omitempty"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (t *TaxRate) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if t.RestaurantID == 0 {
+			t.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		t.CreatedAt = now
+		t.UpdatedAt = now
+	case *bun.UpdateQuery:
+		t.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// TaxRateQuery provides query methods for TaxRate
+type TaxRateQuery struct {
+	db *bun.DB
+}
+
+// NewTaxRateQuery creates a new query builder for TaxRate
+func NewTaxRateQuery(db *bun.DB) *TaxRateQuery {
+	return &TaxRateQuery{db: db}
+}
+
+// List returns all tax rates by ID. It reads from the primary, as orders are taxed
+// with the rates right before being inserted.
+func (q *TaxRateQuery) List(ctx context.Context) ([]TaxRate, error) {
+	var rates []TaxRate
+	err := database.Scope(ctx, q.db.NewSelect().Model(&rates)).Order("tr.id ASC").Scan(ctx)
+	return rates, err
+}
+
+// FindByID finds a tax rate by ID
+func (q *TaxRateQuery) FindByID(ctx context.Context, id int) (*TaxRate, error) {
+	rate := new(TaxRate)
+	err := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(rate)).Where("tr.id = ?", id).Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// Create inserts a tax rate
+func (q *TaxRateQuery) Create(ctx context.Context, rate *TaxRate) error {
+	_, err := q.db.NewInsert().Model(rate).Exec(ctx)
+	if database.IsUniqueViolation(err) {
+		return ErrTaxRateScopeTaken
+	}
+	return err
+}
+
+// Update saves every column of a tax rate but its restaurant
+func (q *TaxRateQuery) Update(ctx context.Context, rate *TaxRate) error {
+	_, err := database.Scope(ctx, q.db.NewUpdate().Model(rate)).WherePK().ExcludeColumn("restaurant_id", "created_at").Exec(ctx)
+	if database.IsUniqueViolation(err) {
+		return ErrTaxRateScopeTaken
+	}
+	return err
+}
+
+// Delete removes a tax rate; orders keep the tax they were charged
+func (q *TaxRateQuery) Delete(ctx context.Context, id int) error {
+	_, err := database.Scope(ctx, q.db.NewDelete().Model((*TaxRate)(nil))).Where("id = ?", id).Exec(ctx)
+	return err
+}
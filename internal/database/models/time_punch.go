@@ -0,0 +1,112 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+)
+
+// ErrPunchClosed is returned when clocking out of a punch that was already clocked out
+var ErrPunchClosed = newOutcome("punch already clocked out")
+
+// TimePunch is a staff member's time on the clock, from ClockInAt until ClockOutAt, which
+// is nil while they are clocked in. ShiftID is the shift they clocked in for, nil when
+// they worked unscheduled or the shift was deleted since.
+type TimePunch struct {
+	bun.BaseModel `bun:"table:time_punches,alias:tp"`
+
+	ID           int          `bun:"id,pk,autoincrement" json:"id"`
+	RestaurantID int          `bun:"restaurant_id,notnull" json:"-"` // Restaurant the time was worked at
+	StaffID      int          `bun:"staff_id,notnull" json:"staff_id"`
+	Staff        *StaffMember `bun:"rel:belongs-to,join:staff_id=id" json:"staff,omitempty"`
+	ShiftID      *int         `bun:"shift_id" json:"shift_id,omitempty"`
+	Shift        *Shift       `bun:"rel:belongs-to,join:shift_id=id" json:"shift,omitempty"`
+	ClockInAt    time.Time    `bun:"clock_in_at,notnull" json:"clock_in_at"`
+	ClockOutAt   *time.Time   `bun:"clock_out_at" json:"clock_out_at,omitempty"`
+
+	CreatedAt time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// BeforeAppendModel is a Bun hook called before inserting/updating
+func (p *TimePunch) BeforeAppendModel(ctx context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if p.RestaurantID == 0 {
+			p.RestaurantID = database.RestaurantID(ctx)
+		}
+		now := time.Now()
+		p.CreatedAt = now
+		p.UpdatedAt = now
+	case *bun.UpdateQuery:
+		p.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// OpenPunch returns the punch a staff member is clocked in on, with its shift. It reads
+// from the primary, as it guards against clocking someone in twice.
+func (q *StaffQuery) OpenPunch(ctx context.Context, staffID int) (*TimePunch, error) {
+	punch := new(TimePunch)
+	err := database.Scope(ctx, q.db.NewSelect().Model(punch)).
+		Relation("Shift").
+		Where("tp.staff_id = ?", staffID).
+		Where("tp.clock_out_at IS NULL").
+		Order("tp.clock_in_at DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return punch, nil
+}
+
+// CreatePunch inserts a punch
+func (q *StaffQuery) CreatePunch(ctx context.Context, punch *TimePunch) error {
+	_, err := q.db.NewInsert().Model(punch).Exec(ctx)
+	return err
+}
+
+// ClockOut closes a punch at at. It fails with ErrPunchClosed once the punch was
+// clocked out.
+func (q *StaffQuery) ClockOut(ctx context.Context, id int, at time.Time) error {
+	res, err := database.Scope(ctx, q.db.NewUpdate().Model((*TimePunch)(nil))).
+		Set("clock_out_at = ?", at).
+		Set("updated_at = ?", at).
+		Where("id = ?", id).
+		Where("clock_out_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrPunchClosed
+	}
+	return nil
+}
+
+// Punches returns the punches clocked in from from until to, of one staff member when
+// staffID is set, by clock-in time, with their staff member and shift
+func (q *StaffQuery) Punches(ctx context.Context, from, to time.Time, staffID *int) ([]TimePunch, error) {
+	var punches []TimePunch
+	query := database.Scope(ctx, database.Reader(ctx, q.db).NewSelect().Model(&punches)).
+		Relation("Staff").
+		Relation("Shift").
+		Where("tp.clock_in_at >= ?", from).
+		Where("tp.clock_in_at < ?", to)
+	if staffID != nil {
+		query = query.Where("tp.staff_id = ?", *staffID)
+	}
+	err := query.Order("tp.clock_in_at ASC", "tp.id ASC").Scan(ctx)
+	return punches, err
+}
+
+// HasPunches reports whether a staff member ever clocked in
+func (q *StaffQuery) HasPunches(ctx context.Context, staffID int) (bool, error) {
+	return database.Scope(ctx, q.db.NewSelect().Model((*TimePunch)(nil))).Where("staff_id = ?", staffID).Exists(ctx)
+}
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// replica tracks a read replica and its last known health
+type replica struct {
+	db      *bun.DB
+	healthy atomic.Bool
+}
+
+var (
+	replicasMu sync.RWMutex
+	replicas   = map[*bun.DB]*replica{}
+)
+
+// primaryKey marks contexts whose reads must go to the primary
+type primaryKey struct{}
+
+// NewReplicaConnection opens a pool to the read replica configured in DB_REPLICA_DSN.
+// Unlike NewConnection it does not wait for the replica: an unreachable replica is
+// simply skipped until a health check succeeds.
+func NewReplicaConnection(config *Config) (*bun.DB, error) {
+	connector, err := newConnector(config.ReplicaDSN, config)
+	if err != nil {
+		return nil, err
+	}
+	return newBunDB(openSQLDB(connector, config), config), nil
+}
+
+// RegisterReplica routes reads for primary to replica while it is healthy, checking
+// its health every interval until ctx is cancelled
+func RegisterReplica(ctx context.Context, primary, replicaDB *bun.DB, interval time.Duration) {
+	rep := &replica{db: replicaDB}
+	rep.healthy.Store(checkReplica(ctx, rep))
+
+	replicasMu.Lock()
+	replicas[primary] = rep
+	replicasMu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				healthy := checkReplica(ctx, rep)
+				if previous := rep.healthy.Swap(healthy); previous != healthy {
+					if healthy {
+						slog.Info("Read replica recovered, routing reads to replica")
+					} else {
+						slog.Warn("Read replica unavailable, falling back to primary")
+					}
+				}
+			}
+		}
+	}()
+}
+
+// checkReplica pings the replica with a short timeout
+func checkReplica(ctx context.Context, rep *replica) bool {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	return rep.db.PingContext(ctx) == nil
+}
+
+// UsePrimary returns a context whose reads are served by the primary, for
+// read-modify-write flows that must not observe replication lag
+func UsePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// Reader returns the database that should serve reads for primary: its replica
+// when one is registered and healthy, otherwise the primary itself
+func Reader(ctx context.Context, primary *bun.DB) *bun.DB {
+	if forced, _ := ctx.Value(primaryKey{}).(bool); forced {
+		return primary
+	}
+
+	replicasMu.RLock()
+	rep, ok := replicas[primary]
+	replicasMu.RUnlock()
+
+	if ok && rep.healthy.Load() {
+		return rep.db
+	}
+	return primary
+}
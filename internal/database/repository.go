@@ -0,0 +1,214 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// SoftDeletable is implemented by models that have a bun `soft_delete` column
+type SoftDeletable interface {
+	IsDeleted() bool
+}
+
+// QueryOption customizes a select query, e.g. to eager-load relations
+type QueryOption func(*bun.SelectQuery) *bun.SelectQuery
+
+// WithRelations eager-loads the given Bun relations
+func WithRelations(relations ...string) QueryOption {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		for _, relation := range relations {
+			q = q.Relation(relation)
+		}
+		return q
+	}
+}
+
+// ApplyOptions applies query options to a select query; the model must already be set
+func ApplyOptions(q *bun.SelectQuery, opts ...QueryOption) *bun.SelectQuery {
+	for _, opt := range opts {
+		q = opt(q)
+	}
+	return q
+}
+
+// Repository provides soft-delete aware queries for any model T with a
+// `soft_delete` column. Models with an `updated_at` column have it bumped on
+// soft delete and restore, and models with a `restaurant_id` column are scoped to
+// the restaurant of the context.
+type Repository[T SoftDeletable] struct {
+	db     *bun.DB
+	table  *schema.Table
+	scoped bool
+}
+
+// NewRepository creates a repository for T; it panics if T has no soft_delete column
+func NewRepository[T SoftDeletable](db *bun.DB) *Repository[T] {
+	table := db.Table(reflect.TypeFor[T]())
+	if table.SoftDeleteField == nil {
+		panic(fmt.Sprintf("database: model %s has no soft_delete column", table.TypeName))
+	}
+
+	return &Repository[T]{db: db, table: table, scoped: table.LookupField("restaurant_id") != nil}
+}
+
+// DB returns the underlying database handle for model-specific queries
+func (r *Repository[T]) DB() *bun.DB {
+	return r.db
+}
+
+// All returns all non-deleted records
+func (r *Repository[T]) All(ctx context.Context, opts ...QueryOption) ([]T, error) {
+	var items []T
+	err := ApplyOptions(r.scopeSelect(ctx, Reader(ctx, r.db).NewSelect().Model(&items)), opts...).
+		Scan(ctx)
+	return items, err
+}
+
+// WithDeleted returns all records including soft-deleted ones
+func (r *Repository[T]) WithDeleted(ctx context.Context, opts ...QueryOption) ([]T, error) {
+	var items []T
+	err := ApplyOptions(r.scopeSelect(ctx, Reader(ctx, r.db).NewSelect().Model(&items)), opts...).
+		WhereAllWithDeleted().
+		Scan(ctx)
+	return items, err
+}
+
+// OnlyDeleted returns only soft-deleted records
+func (r *Repository[T]) OnlyDeleted(ctx context.Context, opts ...QueryOption) ([]T, error) {
+	var items []T
+	err := ApplyOptions(r.scopeSelect(ctx, Reader(ctx, r.db).NewSelect().Model(&items)), opts...).
+		WhereDeleted().
+		Scan(ctx)
+	return items, err
+}
+
+// FindByID finds a record by primary key (excludes soft-deleted)
+func (r *Repository[T]) FindByID(ctx context.Context, id any, opts ...QueryOption) (*T, error) {
+	item := new(T)
+	err := ApplyOptions(r.scopeSelect(ctx, Reader(ctx, r.db).NewSelect().Model(item)), opts...).
+		Where("?TablePKs = ?", id).
+		Scan(ctx)
+	return item, err
+}
+
+// FindByIDWithDeleted finds a record by primary key (includes soft-deleted)
+func (r *Repository[T]) FindByIDWithDeleted(ctx context.Context, id any, opts ...QueryOption) (*T, error) {
+	item := new(T)
+	err := ApplyOptions(r.scopeSelect(ctx, Reader(ctx, r.db).NewSelect().Model(item)), opts...).
+		Where("?TablePKs = ?", id).
+		WhereAllWithDeleted().
+		Scan(ctx)
+	return item, err
+}
+
+// SoftDelete marks the record as deleted by setting its soft_delete column
+func (r *Repository[T]) SoftDelete(ctx context.Context, item *T) error {
+	now := time.Now()
+
+	query := r.scopeUpdate(ctx, r.db.NewUpdate().
+		Model(item).
+		Set("? = ?", bun.Ident(r.table.SoftDeleteField.Name), now).
+		WherePK())
+	query = r.touch(query, item, now)
+
+	if _, err := query.Exec(ctx); err != nil {
+		return err
+	}
+
+	field := r.table.SoftDeleteField.Value(reflect.ValueOf(item).Elem())
+	return r.table.UpdateSoftDeleteField(field, now)
+}
+
+// Restore clears the soft_delete column of a deleted record
+func (r *Repository[T]) Restore(ctx context.Context, item *T) error {
+	now := time.Now()
+
+	query := r.scopeUpdate(ctx, r.db.NewUpdate().
+		Model(item).
+		Set("? = NULL", bun.Ident(r.table.SoftDeleteField.Name)).
+		WherePK().
+		WhereAllWithDeleted())
+	query = r.touch(query, item, now)
+
+	if _, err := query.Exec(ctx); err != nil {
+		return err
+	}
+
+	field := r.table.SoftDeleteField.Value(reflect.ValueOf(item).Elem())
+	field.Set(reflect.Zero(field.Type()))
+	return nil
+}
+
+// ForceDelete permanently removes the record
+func (r *Repository[T]) ForceDelete(ctx context.Context, item *T) error {
+	query := r.db.NewDelete().
+		Model(item).
+		WherePK().
+		WhereAllWithDeleted().
+		ForceDelete()
+	if r.scoped {
+		query = Scope(ctx, query)
+	}
+	_, err := query.Exec(ctx)
+	return err
+}
+
+// PurgeDeleted permanently removes records soft-deleted before cutoff and returns
+// how many there were. With dryRun the records are only counted.
+func (r *Repository[T]) PurgeDeleted(ctx context.Context, cutoff time.Time, dryRun bool) (int, error) {
+	column := bun.Ident(r.table.SoftDeleteField.Name)
+
+	if dryRun {
+		return r.db.NewSelect().
+			Model((*T)(nil)).
+			WhereDeleted().
+			Where("? < ?", column, cutoff).
+			Count(ctx)
+	}
+
+	res, err := r.db.NewDelete().
+		Model((*T)(nil)).
+		WhereDeleted().
+		Where("? < ?", column, cutoff).
+		ForceDelete().
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// scopeSelect limits a select to the restaurant of ctx when T belongs to restaurants
+func (r *Repository[T]) scopeSelect(ctx context.Context, query *bun.SelectQuery) *bun.SelectQuery {
+	if r.scoped {
+		return Scope(ctx, query)
+	}
+	return query
+}
+
+// scopeUpdate limits an update to the restaurant of ctx when T belongs to restaurants
+func (r *Repository[T]) scopeUpdate(ctx context.Context, query *bun.UpdateQuery) *bun.UpdateQuery {
+	if r.scoped {
+		return Scope(ctx, query)
+	}
+	return query
+}
+
+// touch bumps updated_at alongside soft-delete changes when the model has one
+func (r *Repository[T]) touch(query *bun.UpdateQuery, item *T, now time.Time) *bun.UpdateQuery {
+	field := r.table.LookupField("updated_at")
+	if field == nil {
+		return query
+	}
+
+	if v := field.Value(reflect.ValueOf(item).Elem()); v.CanSet() && v.Type() == reflect.TypeOf(now) {
+		v.Set(reflect.ValueOf(now))
+	}
+	return query.Set("updated_at = ?", now)
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// DefaultRestaurantID is the restaurant of requests that don't name one, and the one
+// data created before restaurants were introduced belongs to
+const DefaultRestaurantID = 1
+
+// restaurantKey is the context key of the restaurant queries are scoped to
+type restaurantKey struct{}
+
+// WithRestaurant returns a context whose queries are scoped to a restaurant's data
+// and whose inserts belong to it
+func WithRestaurant(ctx context.Context, id int) context.Context {
+	return context.WithValue(ctx, restaurantKey{}, id)
+}
+
+// WithoutRestaurant returns a context whose queries see the data of every restaurant,
+// for lookups by tokens that are unique across restaurants, such as the QR code of a
+// table, before the restaurant is known
+func WithoutRestaurant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, restaurantKey{}, 0)
+}
+
+// RestaurantFrom returns the restaurant queries made with ctx are scoped to. Background
+// work, such as purging deleted rows, has none and sees every restaurant.
+func RestaurantFrom(ctx context.Context) (int, bool) {
+	id, _ := ctx.Value(restaurantKey{}).(int)
+	return id, id != 0
+}
+
+// RestaurantID returns the restaurant rows inserted with ctx belong to, the default
+// restaurant when ctx has none
+func RestaurantID(ctx context.Context) int {
+	if id, ok := RestaurantFrom(ctx); ok {
+		return id
+	}
+	return DefaultRestaurantID
+}
+
+// whereQuery is a select, update or delete query
+type whereQuery[Q any] interface {
+	Where(query string, args ...any) Q
+}
+
+// Scope restricts a query on a model with a restaurant_id column to the rows of the
+// restaurant of ctx. Queries made without a restaurant are left as they are.
+func Scope[Q whereQuery[Q]](ctx context.Context, q Q) Q {
+	id, ok := RestaurantFrom(ctx)
+	if !ok {
+		return q
+	}
+	// Selects may join tables with a restaurant_id column too; MySQL deletes have no
+	// table alias
+	if _, isSelect := any(q).(*bun.SelectQuery); isSelect {
+		return q.Where("?TableAlias.restaurant_id = ?", id)
+	}
+	return q.Where("restaurant_id = ?", id)
+}
+
+// ScopeAs is Scope for queries on a table expression rather than a model, restricting
+// the table aliased alias
+func ScopeAs[Q whereQuery[Q]](ctx context.Context, q Q, alias string) Q {
+	if id, ok := RestaurantFrom(ctx); ok {
+		return q.Where(alias+".restaurant_id = ?", id)
+	}
+	return q
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+var (
+	// stringLiteral matches single-quoted SQL strings, including escaped quotes
+	stringLiteral = regexp.MustCompile(`'(?:[^']|'')*'`)
+	// numericLiteral matches standalone numbers that are not part of identifiers
+	numericLiteral = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+)
+
+// SlowQueryHook logs statements that take longer than a threshold
+type SlowQueryHook struct {
+	threshold time.Duration
+}
+
+var _ bun.QueryHook = (*SlowQueryHook)(nil)
+
+// NewSlowQueryHook creates a hook that warns about queries slower than threshold
+func NewSlowQueryHook(threshold time.Duration) *SlowQueryHook {
+	return &SlowQueryHook{threshold: threshold}
+}
+
+// BeforeQuery implements bun.QueryHook
+func (h *SlowQueryHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+// AfterQuery implements bun.QueryHook
+func (h *SlowQueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	duration := time.Since(event.StartTime)
+	if duration < h.threshold {
+		return
+	}
+
+	logging.FromContext(ctx).Warn("Slow database query",
+		slog.String("operation", event.Operation()),
+		slog.Duration("duration", duration),
+		slog.Duration("threshold", h.threshold),
+		slog.String("query", redactQuery(event)),
+	)
+}
+
+// redactQuery returns the query text with bound argument values removed
+func redactQuery(event *bun.QueryEvent) string {
+	// Raw queries keep their placeholder template
+	if event.QueryTemplate != "" {
+		return event.QueryTemplate
+	}
+
+	// Query builders inline arguments, so strip literal values instead
+	query := stringLiteral.ReplaceAllString(event.Query, "'?'")
+	return numericLiteral.ReplaceAllString(query, "?")
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLSFiles holds the certificate material used for verified TLS connections
+type TLSFiles struct {
+	RootCert   string // CA bundle used to verify the server (DB_SSL_ROOT_CERT)
+	ClientCert string // Client certificate for mutual TLS (DB_SSL_CERT)
+	ClientKey  string // Private key of the client certificate (DB_SSL_KEY)
+}
+
+// IsZero reports whether no certificate files are configured
+func (f TLSFiles) IsZero() bool {
+	return f.RootCert == "" && f.ClientCert == "" && f.ClientKey == ""
+}
+
+// buildTLSConfig creates the TLS configuration for sslMode using the configured
+// certificate files, following libpq semantics:
+//   - require: encrypt only (verify the chain like verify-ca when a root cert is given)
+//   - verify-ca: verify the server certificate chain but not its host name
+//   - verify-full: verify the chain and that the certificate matches serverName
+//
+// It returns nil when TLS is disabled.
+func buildTLSConfig(sslMode string, files TLSFiles, serverName string) (*tls.Config, error) {
+	if sslMode == "" || sslMode == "disable" {
+		if !files.IsZero() {
+			return nil, errors.New("certificate files are configured but sslmode is disable")
+		}
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if files.ClientCert != "" || files.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(files.ClientCert, files.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if files.RootCert != "" {
+		rawCA, err := os.ReadFile(files.RootCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read root certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(rawCA) {
+			return nil, errors.New("no certificates found in root certificate file")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	switch sslMode {
+	case "verify-full":
+		// Standard verification: chain and host name
+	case "verify-ca":
+		verifyChainOnly(tlsConfig)
+	case "require", "prefer", "allow":
+		if files.RootCert == "" {
+			tlsConfig.InsecureSkipVerify = true
+		} else {
+			verifyChainOnly(tlsConfig)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported sslmode %q", sslMode)
+	}
+
+	return tlsConfig, nil
+}
+
+// verifyChainOnly checks the server certificate against RootCAs while skipping
+// the host name check, which crypto/tls has no built-in option for
+func verifyChainOnly(tlsConfig *tls.Config) {
+	tlsConfig.InsecureSkipVerify = true
+	tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("server presented no certificate")
+		}
+
+		certs := make([]*x509.Certificate, 0, len(rawCerts))
+		for _, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return fmt.Errorf("failed to parse server certificate: %w", err)
+			}
+			certs = append(certs, cert)
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+
+		_, err := certs[0].Verify(x509.VerifyOptions{
+			Roots:         tlsConfig.RootCAs,
+			Intermediates: intermediates,
+		})
+		return err
+	}
+}
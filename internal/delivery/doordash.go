@@ -0,0 +1,120 @@
+package delivery
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// DoorDash receives DoorDash style order webhooks. X-DoorDash-Signature is the
+// base64 HMAC-SHA256 of "<X-DoorDash-Timestamp>.<body>" keyed with the signing secret.
+// The order's store.merchant_supplied_id is the restaurant ID.
+type DoorDash struct {
+	secret []byte
+}
+
+// NewDoorDash creates the DoorDash adapter
+func NewDoorDash(secret string) *DoorDash {
+	return &DoorDash{secret: []byte(secret)}
+}
+
+// doorDashEvent is the subset of the webhook payload the adapter reads
+type doorDashEvent struct {
+	EventType string `json:"event_type"`
+	Order     *struct {
+		ID                  string `json:"id"`
+		SpecialInstructions string `json:"special_instructions"`
+		Store               struct {
+			MerchantSuppliedID string `json:"merchant_supplied_id"`
+		} `json:"store"`
+		Customer struct {
+			FirstName   string `json:"first_name"`
+			LastName    string `json:"last_name"`
+			PhoneNumber string `json:"phone_number"`
+		} `json:"customer"`
+		Items []struct {
+			MerchantSuppliedID  string `json:"merchant_supplied_id"`
+			Name                string `json:"name"`
+			Quantity            int    `json:"quantity"`
+			Price               int64  `json:"price"`
+			SpecialInstructions string `json:"special_instructions"`
+		} `json:"items"`
+	} `json:"order"`
+}
+
+func (p *DoorDash) Name() string {
+	return "doordash"
+}
+
+func (p *DoorDash) Verify(header http.Header, body []byte) error {
+	timestamp := header.Get("X-DoorDash-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if err := checkEventTime(time.Unix(seconds, 0)); err != nil {
+		return err
+	}
+
+	received, err := base64.StdEncoding.DecodeString(header.Get("X-DoorDash-Signature"))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	if !equalMAC(received, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (p *DoorDash) ParseOrder(body []byte) (*services.CreateOrderRequest, error) {
+	var event doorDashEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if event.EventType != "order_created" {
+		return nil, ErrIgnored
+	}
+	if event.Order == nil || event.Order.ID == "" {
+		return nil, fmt.Errorf("%w: order.id is required", ErrInvalidPayload)
+	}
+
+	order := event.Order
+	req := &services.CreateOrderRequest{
+		Source:        p.Name(),
+		ExternalID:    &order.ID,
+		Channel:       models.OrderChannelDelivery,
+		CustomerName:  optional(strings.TrimSpace(order.Customer.FirstName + " " + order.Customer.LastName)),
+		CustomerPhone: optional(order.Customer.PhoneNumber),
+		Notes:         optional(order.SpecialInstructions),
+	}
+	for _, item := range order.Items {
+		req.Items = append(req.Items, services.CreateOrderItemRequest{
+			MenuItemID: menuItemID(item.MerchantSuppliedID),
+			Name:       item.Name,
+			Quantity:   item.Quantity,
+			UnitPrice:  fromMinorUnits(item.Price),
+			Notes:      optional(item.SpecialInstructions),
+		})
+	}
+	return req, nil
+}
+
+func (p *DoorDash) Restaurant(body []byte) string {
+	var event doorDashEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.Order == nil {
+		return ""
+	}
+	return event.Order.Store.MerchantSuppliedID
+}
@@ -0,0 +1,80 @@
+// Package delivery adapts order webhooks of delivery platforms (Uber Eats, DoorDash)
+// to internal orders
+package delivery
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// Inbound webhook errors
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+	// ErrIgnored is returned for well-formed events that don't carry a new order
+	ErrIgnored = errors.New("event ignored")
+)
+
+// eventTolerance is how far a webhook's signed timestamp may be from the current time,
+// limiting replays of captured requests
+const eventTolerance = 5 * time.Minute
+
+// Provider adapts one delivery platform's order webhooks
+type Provider interface {
+	// Name is the provider's path segment in /webhooks/{provider} and the source
+	// recorded on its orders
+	Name() string
+	// Verify checks the request signature over the raw body and rejects events
+	// signed outside eventTolerance
+	Verify(header http.Header, body []byte) error
+	// ParseOrder translates the payload into an order request
+	ParseOrder(body []byte) (*services.CreateOrderRequest, error)
+	// Restaurant returns the restaurant ID the order's store is registered with on
+	// the platform, from the signed payload, or "" for the default restaurant
+	Restaurant(body []byte) string
+}
+
+// checkEventTime rejects events signed outside eventTolerance of the current time
+func checkEventTime(signed time.Time) error {
+	if age := time.Since(signed); age > eventTolerance || age < -eventTolerance {
+		return fmt.Errorf("%w: timestamp outside the allowed window", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// equalMAC compares a received signature with the expected one in constant time
+func equalMAC(received, expected []byte) bool {
+	return len(received) > 0 && hmac.Equal(received, expected)
+}
+
+// menuItemID maps a platform's merchant-supplied item ID to a menu item ID when it
+// is one
+func menuItemID(merchantID string) *int {
+	id, err := strconv.Atoi(merchantID)
+	if err != nil || id < 1 {
+		return nil
+	}
+	return &id
+}
+
+// fromMinorUnits converts an amount in cents to a decimal price
+func fromMinorUnits(amount int64) *decimal.Decimal {
+	price := decimal.New(amount, -2)
+	return &price
+}
+
+// optional returns nil for an empty string
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,122 @@
+package delivery
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// UberEats receives Uber Eats style order webhooks. X-Uber-Signature is the
+// lowercase hex HMAC-SHA256 of the body keyed with the client secret, and the body's
+// event_time, in Unix seconds, dates the event. The order's
+// store.external_reference_id is the restaurant ID.
+type UberEats struct {
+	secret []byte
+}
+
+// NewUberEats creates the Uber Eats adapter
+func NewUberEats(secret string) *UberEats {
+	return &UberEats{secret: []byte(secret)}
+}
+
+// uberEatsEvent is the subset of the webhook payload the adapter reads
+type uberEatsEvent struct {
+	EventType string `json:"event_type"`
+	EventTime int64  `json:"event_time"`
+	Order     *struct {
+		ID    string `json:"id"`
+		Store struct {
+			ExternalReferenceID string `json:"external_reference_id"`
+		} `json:"store"`
+		Eater struct {
+			FirstName string `json:"first_name"`
+			LastName  string `json:"last_name"`
+			Phone     string `json:"phone"`
+		} `json:"eater"`
+		Cart struct {
+			SpecialInstructions string `json:"special_instructions"`
+			Items               []struct {
+				Title               string `json:"title"`
+				ExternalData        string `json:"external_data"`
+				Quantity            int    `json:"quantity"`
+				SpecialInstructions string `json:"special_instructions"`
+				Price               struct {
+					UnitPrice struct {
+						Amount int64 `json:"amount"`
+					} `json:"unit_price"`
+				} `json:"price"`
+			} `json:"items"`
+		} `json:"cart"`
+	} `json:"order"`
+}
+
+func (p *UberEats) Name() string {
+	return "ubereats"
+}
+
+func (p *UberEats) Verify(header http.Header, body []byte) error {
+	received, err := hex.DecodeString(strings.ToLower(header.Get("X-Uber-Signature")))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(body)
+	if !equalMAC(received, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	var event uberEatsEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.EventTime == 0 {
+		return fmt.Errorf("%w: event_time is required", ErrInvalidSignature)
+	}
+	return checkEventTime(time.Unix(event.EventTime, 0))
+}
+
+func (p *UberEats) ParseOrder(body []byte) (*services.CreateOrderRequest, error) {
+	var event uberEatsEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if event.EventType != "orders.notification" {
+		return nil, ErrIgnored
+	}
+	if event.Order == nil || event.Order.ID == "" {
+		return nil, fmt.Errorf("%w: order.id is required", ErrInvalidPayload)
+	}
+
+	order := event.Order
+	req := &services.CreateOrderRequest{
+		Source:        p.Name(),
+		ExternalID:    &order.ID,
+		Channel:       models.OrderChannelDelivery,
+		CustomerName:  optional(strings.TrimSpace(order.Eater.FirstName + " " + order.Eater.LastName)),
+		CustomerPhone: optional(order.Eater.Phone),
+		Notes:         optional(order.Cart.SpecialInstructions),
+	}
+	for _, item := range order.Cart.Items {
+		req.Items = append(req.Items, services.CreateOrderItemRequest{
+			MenuItemID: menuItemID(item.ExternalData),
+			Name:       item.Title,
+			Quantity:   item.Quantity,
+			UnitPrice:  fromMinorUnits(item.Price.UnitPrice.Amount),
+			Notes:      optional(item.SpecialInstructions),
+		})
+	}
+	return req, nil
+}
+
+func (p *UberEats) Restaurant(body []byte) string {
+	var event uberEatsEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.Order == nil {
+		return ""
+	}
+	return event.Order.Store.ExternalReferenceID
+}
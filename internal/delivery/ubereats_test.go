@@ -0,0 +1,49 @@
+package delivery
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestUberEatsRejectsStaleEvents(t *testing.T) {
+	p := NewUberEats("secret")
+	sign := func(body string) http.Header {
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(body))
+		header := http.Header{}
+		header.Set("X-Uber-Signature", hex.EncodeToString(mac.Sum(nil)))
+		return header
+	}
+	event := func(sent time.Time) string {
+		return fmt.Sprintf(`{"event_type":"orders.notification","event_time":%d,"order":{"id":"o-1","store":{"external_reference_id":"2"}}}`, sent.Unix())
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"recent", event(time.Now()), false},
+		{"stale", event(time.Now().Add(-time.Hour)), true},
+		{"from the future", event(time.Now().Add(time.Hour)), true},
+		{"undated", `{"event_type":"orders.notification","order":{"id":"o-1"}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Verify(sign(tt.body), []byte(tt.body))
+			if tt.wantErr != (err != nil) || (err != nil && !errors.Is(err, ErrInvalidSignature)) {
+				t.Fatalf("Verify() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if restaurant := p.Restaurant([]byte(event(time.Now()))); restaurant != "2" {
+		t.Fatalf("Restaurant() = %q, want 2", restaurant)
+	}
+}
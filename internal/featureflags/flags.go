@@ -0,0 +1,336 @@
+// Package featureflags decides whether features being rolled out are on. Flags are
+// stored in the database, limited to environments (APP_ENV) and tenants, and can be
+// forced on or off with the FEATURE_FLAGS setting.
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/config"
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+)
+
+// Feature flag errors
+var (
+	ErrNotFound    = errors.New("feature flag not found")
+	ErrInvalidFlag = errors.New("invalid feature flag")
+)
+
+// TenantHeader names the tenant a request is made for
+const TenantHeader = "X-Tenant-ID"
+
+// refreshInterval is how long flags are cached before they are read again, so
+// changes made through another instance apply within it
+const refreshInterval = 30 * time.Second
+
+// namePattern is the format of flag names, e.g. payments or kds.tickets
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,99}$`)
+
+// environments are the APP_ENV values a flag can be limited to
+var environments = []string{config.EnvDevelopment, config.EnvStaging, config.EnvProduction, config.EnvTest}
+
+// Flag is a feature that is on while Enabled, in the listed environments and for
+// the listed tenants. Empty lists mean all environments or all tenants.
+type Flag struct {
+	bun.BaseModel `bun:"table:feature_flags,alias:ff" swaggerignore:"true"`
+
+	Name         string    `bun:"name,pk" json:"name" example:"payments"`
+	Description  string    `bun:"description,notnull" json:"description,omitempty" example:"Card payments of orders"`
+	Enabled      bool      `bun:"enabled,notnull" json:"enabled" example:"true"`
+	Environments []string  `bun:"environments,notnull" json:"environments" example:"staging"`
+	Tenants      []string  `bun:"tenants,notnull" json:"tenants" example:"downtown"`
+	CreatedAt    time.Time `bun:"created_at,notnull" json:"created_at"`
+	UpdatedAt    time.Time `bun:"updated_at,notnull" json:"updated_at"`
+}
+
+// On reports whether the flag is on in env for tenant
+func (f *Flag) On(env, tenant string) bool {
+	return f.Enabled &&
+		(len(f.Environments) == 0 || slices.Contains(f.Environments, env)) &&
+		(len(f.Tenants) == 0 || slices.Contains(f.Tenants, tenant))
+}
+
+// SetRequest describes the new state of a flag
+type SetRequest struct {
+	Description  string   `json:"description,omitempty" example:"Card payments of orders"`
+	Enabled      *bool    `json:"enabled" example:"true"`
+	Environments []string `json:"environments,omitempty" example:"staging"`
+	Tenants      []string `json:"tenants,omitempty" example:"downtown"`
+}
+
+// validate checks the request and trims its lists
+func (r *SetRequest) validate() error {
+	if r.Enabled == nil {
+		return fmt.Errorf("%w: enabled is required", ErrInvalidFlag)
+	}
+	if len(r.Description) > 255 {
+		return fmt.Errorf("%w: description must be at most 255 characters", ErrInvalidFlag)
+	}
+	for i, env := range r.Environments {
+		r.Environments[i] = strings.TrimSpace(env)
+		if !slices.Contains(environments, r.Environments[i]) {
+			return fmt.Errorf("%w: environment %q must be one of %s", ErrInvalidFlag, env, strings.Join(environments, ", "))
+		}
+	}
+	for i, tenant := range r.Tenants {
+		r.Tenants[i] = strings.TrimSpace(tenant)
+		if r.Tenants[i] == "" || len(r.Tenants[i]) > 100 {
+			return fmt.Errorf("%w: tenant %q must be 1 to 100 characters", ErrInvalidFlag, tenant)
+		}
+	}
+	return nil
+}
+
+// Status is a stored flag with the override from FEATURE_FLAGS and whether the flag
+// is on in the running environment, for requests of any tenant it is limited to
+type Status struct {
+	Flag
+	Override *bool `json:"override,omitempty" example:"false"`
+	Active   bool  `json:"active" example:"true"`
+}
+
+// Flags evaluates feature flags in one environment. Stored flags are cached and
+// read again every refreshInterval; overrides take precedence over them.
+type Flags struct {
+	db  bun.IDB
+	env string
+
+	mu        sync.RWMutex
+	flags     map[string]Flag
+	loadedAt  time.Time
+	overrides map[string]bool
+
+	refreshing atomic.Bool
+}
+
+// New creates the flags of environment env with the FEATURE_FLAGS overrides
+func New(db bun.IDB, env string, overrides []string) *Flags {
+	f := &Flags{db: db, env: env}
+	f.SetOverrides(overrides)
+	return f
+}
+
+// SetOverrides replaces the overrides: names listed are forced on, and names
+// prefixed with "-" are forced off, in every environment and for every tenant
+func (f *Flags) SetOverrides(overrides []string) {
+	forced := make(map[string]bool, len(overrides))
+	for _, name := range overrides {
+		if off, ok := strings.CutPrefix(name, "-"); ok {
+			forced[off] = false
+		} else {
+			forced[name] = true
+		}
+	}
+
+	f.mu.Lock()
+	f.overrides = forced
+	f.mu.Unlock()
+}
+
+// Enabled reports whether a flag is on for tenant, which is empty for requests
+// not made for one. Unknown flags are off.
+func (f *Flags) Enabled(ctx context.Context, name, tenant string) bool {
+	f.mu.RLock()
+	on, forced := f.overrides[name]
+	f.mu.RUnlock()
+	if forced {
+		return on
+	}
+
+	flag, ok := f.flag(ctx, name)
+	return ok && flag.On(f.env, tenant)
+}
+
+// EnabledFor reports whether a flag is on for the tenant of a request
+func (f *Flags) EnabledFor(r *http.Request, name string) bool {
+	return f.Enabled(r.Context(), name, Tenant(r))
+}
+
+// Require serves next only while a flag is on for the request's tenant, and answers
+// 404 otherwise as if the route did not exist
+func (f *Flags) Require(name string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !f.EnabledFor(r, name) {
+				middlewares.SendErrorResponse(w, r, http.StatusNotFound, "Not Found", "The requested resource was not found")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireFunc is Require for a handler function, for use with HandleFunc
+func (f *Flags) RequireFunc(name string, next http.HandlerFunc) http.HandlerFunc {
+	return f.Require(name)(next).ServeHTTP
+}
+
+// Tenant returns the tenant a request is made for, from its X-Tenant-ID header
+func Tenant(r *http.Request) string {
+	return strings.TrimSpace(r.Header.Get(TenantHeader))
+}
+
+// flag returns a cached flag, reading the flags again once the cache is stale. While
+// one request reads them, the others use the stale cache.
+func (f *Flags) flag(ctx context.Context, name string) (Flag, bool) {
+	f.mu.RLock()
+	flag, ok := f.flags[name]
+	stale := time.Since(f.loadedAt) > refreshInterval
+	f.mu.RUnlock()
+	if !stale || !f.refreshing.CompareAndSwap(false, true) {
+		return flag, ok
+	}
+	defer f.refreshing.Store(false)
+
+	if _, err := f.Refresh(ctx); err != nil {
+		// Keep the cached flags and retry after the next interval
+		logging.FromContext(ctx).Warn("Failed to refresh feature flags", slog.String("error", err.Error()))
+		f.mu.Lock()
+		f.loadedAt = time.Now()
+		f.mu.Unlock()
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	flag, ok = f.flags[name]
+	return flag, ok
+}
+
+// Refresh reads the stored flags into the cache and returns them by name
+func (f *Flags) Refresh(ctx context.Context) ([]Flag, error) {
+	var flags []Flag
+	if err := f.db.NewSelect().Model(&flags).Order("name ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	cache := make(map[string]Flag, len(flags))
+	for _, flag := range flags {
+		cache[flag.Name] = flag
+	}
+	f.mu.Lock()
+	f.flags = cache
+	f.loadedAt = time.Now()
+	f.mu.Unlock()
+
+	return flags, nil
+}
+
+// List returns the stored flags by name with their status in the running environment.
+// Overridden flags that aren't stored are listed too.
+func (f *Flags) List(ctx context.Context) ([]Status, error) {
+	flags, err := f.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for name := range f.overrides {
+		if _, ok := f.flags[name]; !ok {
+			flags = append(flags, Flag{Name: name, Environments: []string{}, Tenants: []string{}})
+		}
+	}
+	slices.SortFunc(flags, func(a, b Flag) int { return strings.Compare(a.Name, b.Name) })
+
+	statuses := make([]Status, len(flags))
+	for i, flag := range flags {
+		statuses[i] = Status{Flag: flag, Active: flag.Enabled && (len(flag.Environments) == 0 || slices.Contains(flag.Environments, f.env))}
+		if on, forced := f.overrides[flag.Name]; forced {
+			statuses[i].Override = &on
+			statuses[i].Active = on
+		}
+	}
+	return statuses, nil
+}
+
+// Set creates or replaces a flag. The change applies on this instance at once and on
+// the others within refreshInterval.
+func (f *Flags) Set(ctx context.Context, name string, req SetRequest) (*Flag, error) {
+	if !namePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: name must be lowercase letters, digits, '.', '_' or '-', at most 100 characters", ErrInvalidFlag)
+	}
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	flag := &Flag{
+		Name:         name,
+		Description:  req.Description,
+		Enabled:      *req.Enabled,
+		Environments: nonNil(req.Environments),
+		Tenants:      nonNil(req.Tenants),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	query := f.db.NewInsert().Model(flag)
+	if database.IsMySQL(f.db) {
+		query = query.On("DUPLICATE KEY UPDATE").
+			Set("description = VALUES(description), enabled = VALUES(enabled)").
+			Set("environments = VALUES(environments), tenants = VALUES(tenants), updated_at = VALUES(updated_at)")
+	} else {
+		query = query.On("CONFLICT (name) DO UPDATE").
+			Set("description = EXCLUDED.description, enabled = EXCLUDED.enabled").
+			Set("environments = EXCLUDED.environments, tenants = EXCLUDED.tenants, updated_at = EXCLUDED.updated_at")
+	}
+	if _, err := query.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	// Read it back for the creation time of a replaced flag
+	if err := f.db.NewSelect().Model(flag).WherePK().Scan(ctx); err != nil {
+		return nil, err
+	}
+	f.cache(flag.Name, flag)
+	return flag, nil
+}
+
+// Delete removes a flag, which turns it off unless it is overridden
+func (f *Flags) Delete(ctx context.Context, name string) error {
+	res, err := f.db.NewDelete().Model((*Flag)(nil)).Where("name = ?", name).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	f.cache(name, nil)
+	return nil
+}
+
+// cache stores a changed flag, or removes a deleted one, without waiting for the
+// next refresh
+func (f *Flags) cache(name string, flag *Flag) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.flags == nil {
+		return // Not read yet; the first lookup reads it
+	}
+	if flag == nil {
+		delete(f.flags, name)
+	} else {
+		f.flags[name] = *flag
+	}
+}
+
+// nonNil stores empty lists as [] rather than null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,50 @@
+package graph
+
+import (
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// maxPageSize caps the limit argument of paged queries
+const maxPageSize = 100
+
+// Resolver is the root resolver, backed by the same services as the REST API
+type Resolver struct {
+	ItemService  services.MenuItemService
+	OrderService services.OrderService
+}
+
+// pageBounds validates the limit and offset arguments of a paged query
+func pageBounds(limit, offset *int) (int, int, error) {
+	first, skip := 50, 0
+	if limit != nil {
+		first = *limit
+	}
+	if offset != nil {
+		skip = *offset
+	}
+	if first < 1 || first > maxPageSize {
+		return 0, 0, badInput("limit must be between 1 and %d", maxPageSize)
+	}
+	if skip < 0 {
+		return 0, 0, badInput("offset must not be negative")
+	}
+	return first, skip, nil
+}
+
+// pointers returns pointers to the elements of s, as gqlgen expects for lists
+func pointers[T any](s []T) []*T {
+	p := make([]*T, len(s))
+	for i := range s {
+		p[i] = &s[i]
+	}
+	return p
+}
+
+// badInput returns an error about an invalid argument
+func badInput(format string, args ...any) *gqlerror.Error {
+	err := gqlerror.Errorf(format, args...)
+	err.Extensions = map[string]any{"code": "BAD_USER_INPUT"}
+	return err
+}
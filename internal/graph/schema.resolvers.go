@@ -0,0 +1,141 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.70
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// Items is the resolver for the items field.
+func (r *categoryResolver) Items(ctx context.Context, obj *Category) ([]*services.MenuItemResponse, error) {
+	items, err := r.ItemService.GetMenuItemsByCategory(ctx, obj.Name, services.QueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return pointers(items), nil
+}
+
+// MenuItems is the resolver for the menuItems field.
+func (r *queryResolver) MenuItems(ctx context.Context, filter *MenuItemFilter, limit *int, offset *int) (*MenuItemPage, error) {
+	first, skip, err := pageBounds(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		filter = &MenuItemFilter{}
+	}
+
+	// Load with the most selective service call, then apply the remaining filters
+	var items []services.MenuItemResponse
+	switch {
+	case filter.Search != nil && *filter.Search != "":
+		items, err = r.ItemService.SearchMenuItems(ctx, *filter.Search, services.QueryOptions{})
+	case filter.Category != nil:
+		items, err = r.ItemService.GetMenuItemsByCategory(ctx, *filter.Category, services.QueryOptions{})
+	case filter.Available != nil && *filter.Available:
+		items, err = r.ItemService.GetAvailableMenuItems(ctx, services.QueryOptions{})
+	case filter.IncludeDeleted != nil && *filter.IncludeDeleted:
+		items, err = r.ItemService.GetAllMenuItemsWithDeleted(ctx, services.QueryOptions{})
+	default:
+		items, err = r.ItemService.GetAllMenuItems(ctx, services.QueryOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	matching := items[:0]
+	for _, item := range items {
+		if filter.Category != nil && !strings.EqualFold(item.Category, *filter.Category) {
+			continue
+		}
+		if filter.Available != nil && item.IsAvailable != *filter.Available {
+			continue
+		}
+		matching = append(matching, item)
+	}
+
+	page := &MenuItemPage{TotalCount: len(matching), HasNextPage: skip+first < len(matching)}
+	if skip < len(matching) {
+		page.Items = pointers(matching[skip:min(skip+first, len(matching))])
+	} else {
+		page.Items = []*services.MenuItemResponse{}
+	}
+	return page, nil
+}
+
+// MenuItem is the resolver for the menuItem field.
+func (r *queryResolver) MenuItem(ctx context.Context, id int) (*services.MenuItemResponse, error) {
+	item, err := r.ItemService.GetMenuItemByID(ctx, id, services.QueryOptions{})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return item, err
+}
+
+// Categories is the resolver for the categories field.
+func (r *queryResolver) Categories(ctx context.Context) ([]*Category, error) {
+	categories := make([]*Category, 0, len(services.ValidCategories))
+	for name := range services.ValidCategories {
+		categories = append(categories, &Category{Name: name})
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	return categories, nil
+}
+
+// Category is the resolver for the category field.
+func (r *queryResolver) Category(ctx context.Context, name string) (*Category, error) {
+	if !services.ValidCategories[name] {
+		return nil, nil
+	}
+	return &Category{Name: name}, nil
+}
+
+// Orders is the resolver for the orders field.
+func (r *queryResolver) Orders(ctx context.Context, filter *OrderFilter, limit *int, offset *int) (*OrderPage, error) {
+	first, skip, err := pageBounds(limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	opts := services.OrderListOptions{Limit: first + 1, Offset: skip}
+	if filter != nil && filter.Status != nil {
+		opts.Status = *filter.Status
+	}
+	if filter != nil && filter.Source != nil {
+		opts.Source = *filter.Source
+	}
+
+	// One extra order tells whether there is a next page
+	orders, err := r.OrderService.ListOrders(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	page := &OrderPage{HasNextPage: len(orders) > first}
+	page.Items = pointers(orders[:min(first, len(orders))])
+	return page, nil
+}
+
+// Order is the resolver for the order field.
+func (r *queryResolver) Order(ctx context.Context, id string) (*services.OrderResponse, error) {
+	order, err := r.OrderService.GetOrderByID(ctx, id)
+	if errors.Is(err, services.ErrOrderNotFound) {
+		return nil, nil
+	}
+	return order, err
+}
+
+// Category returns CategoryResolver implementation.
+func (r *Resolver) Category() CategoryResolver { return &categoryResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type categoryResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
@@ -0,0 +1,172 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	agorav1 "github.com/Zughayyar/agora-server/internal/grpcapi/agora/v1"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// menuItemServer implements agora.v1.MenuItemService
+type menuItemServer struct {
+	agorav1.UnimplementedMenuItemServiceServer
+	service services.MenuItemService
+}
+
+// ListMenuItems returns the menu items matching the first filter set in the request
+func (s *menuItemServer) ListMenuItems(ctx context.Context, req *agorav1.ListMenuItemsRequest) (*agorav1.ListMenuItemsResponse, error) {
+	var items []services.MenuItemResponse
+	var err error
+	switch {
+	case req.Search != "":
+		items, err = s.service.SearchMenuItems(ctx, req.Search, services.QueryOptions{})
+	case req.Category != "":
+		if !services.ValidCategories[req.Category] {
+			return nil, status.Error(codes.InvalidArgument, "category must be one of: appetizer, main, dessert, drink, side, fast food")
+		}
+		items, err = s.service.GetMenuItemsByCategory(ctx, req.Category, services.QueryOptions{})
+	case req.AvailableOnly:
+		items, err = s.service.GetAvailableMenuItems(ctx, services.QueryOptions{})
+	case req.IncludeDeleted:
+		items, err = s.service.GetAllMenuItemsWithDeleted(ctx, services.QueryOptions{})
+	default:
+		items, err = s.service.GetAllMenuItems(ctx, services.QueryOptions{})
+	}
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	resp := &agorav1.ListMenuItemsResponse{Items: make([]*agorav1.MenuItem, len(items))}
+	for i := range items {
+		resp.Items[i] = menuItemMessage(&items[i])
+	}
+	return resp, nil
+}
+
+// GetMenuItem returns a menu item by ID
+func (s *menuItemServer) GetMenuItem(ctx context.Context, req *agorav1.GetMenuItemRequest) (*agorav1.GetMenuItemResponse, error) {
+	item, err := s.service.GetMenuItemByID(ctx, int(req.Id), services.QueryOptions{})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return &agorav1.GetMenuItemResponse{Item: menuItemMessage(item)}, nil
+}
+
+// CreateMenuItem creates a menu item
+func (s *menuItemServer) CreateMenuItem(ctx context.Context, req *agorav1.CreateMenuItemRequest) (*agorav1.CreateMenuItemResponse, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if !services.ValidCategories[req.Category] {
+		return nil, status.Error(codes.InvalidArgument, "category must be one of: appetizer, main, dessert, drink, side, fast food")
+	}
+	price, err := parsePrice(req.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.service.CreateMenuItem(ctx, services.CreateMenuItemRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Price:       price,
+		Category:    req.Category,
+		IsAvailable: req.IsAvailable,
+	})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return &agorav1.CreateMenuItemResponse{Item: menuItemMessage(item)}, nil
+}
+
+// UpdateMenuItem changes the fields set in the request
+func (s *menuItemServer) UpdateMenuItem(ctx context.Context, req *agorav1.UpdateMenuItemRequest) (*agorav1.UpdateMenuItemResponse, error) {
+	update := services.UpdateMenuItemRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Category:    req.Category,
+		IsAvailable: req.IsAvailable,
+	}
+	if req.Name != nil && *req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+	}
+	if req.Category != nil && !services.ValidCategories[*req.Category] {
+		return nil, status.Error(codes.InvalidArgument, "category must be one of: appetizer, main, dessert, drink, side, fast food")
+	}
+	if req.Price != nil {
+		price, err := parsePrice(*req.Price)
+		if err != nil {
+			return nil, err
+		}
+		update.Price = &price
+	}
+
+	item, err := s.service.UpdateMenuItem(ctx, int(req.Id), update)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return &agorav1.UpdateMenuItemResponse{Item: menuItemMessage(item)}, nil
+}
+
+// DeleteMenuItem soft-deletes a menu item
+func (s *menuItemServer) DeleteMenuItem(ctx context.Context, req *agorav1.DeleteMenuItemRequest) (*agorav1.DeleteMenuItemResponse, error) {
+	if err := s.service.SoftDeleteMenuItem(ctx, int(req.Id)); err != nil {
+		return nil, serviceError(err)
+	}
+	return &agorav1.DeleteMenuItemResponse{}, nil
+}
+
+// RestoreMenuItem restores a soft-deleted menu item
+func (s *menuItemServer) RestoreMenuItem(ctx context.Context, req *agorav1.RestoreMenuItemRequest) (*agorav1.RestoreMenuItemResponse, error) {
+	item, err := s.service.RestoreMenuItem(ctx, int(req.Id))
+	if err != nil {
+		if strings.Contains(err.Error(), "not deleted") {
+			return nil, status.Error(codes.FailedPrecondition, "menu item is not deleted")
+		}
+		return nil, serviceError(err)
+	}
+	return &agorav1.RestoreMenuItemResponse{Item: menuItemMessage(item)}, nil
+}
+
+// parsePrice parses a positive decimal price
+func parsePrice(value string) (decimal.Decimal, error) {
+	price, err := decimal.NewFromString(value)
+	if err != nil || !price.IsPositive() {
+		return decimal.Zero, status.Error(codes.InvalidArgument, "price must be a positive decimal")
+	}
+	return price, nil
+}
+
+// menuItemMessage converts a menu item to its protobuf message
+func menuItemMessage(item *services.MenuItemResponse) *agorav1.MenuItem {
+	return &agorav1.MenuItem{
+		Id:          int64(item.ID),
+		Name:        item.Name,
+		Description: item.Description,
+		Price:       item.Price.String(),
+		Category:    item.Category,
+		IsAvailable: item.IsAvailable,
+		CreatedAt:   formatTime(item.CreatedAt),
+		UpdatedAt:   formatTime(item.UpdatedAt),
+		DeletedAt:   optionalTime(item.DeletedAt),
+	}
+}
+
+// formatTime formats a timestamp in RFC 3339, keeping its UTC offset
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// optionalTime formats an optional timestamp in RFC 3339
+func optionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := formatTime(*t)
+	return &formatted
+}
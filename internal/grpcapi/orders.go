@@ -0,0 +1,167 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	agorav1 "github.com/Zughayyar/agora-server/internal/grpcapi/agora/v1"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// orderServer implements agora.v1.OrderService
+type orderServer struct {
+	agorav1.UnimplementedOrderServiceServer
+	service services.OrderService
+}
+
+// CreateOrder creates a pending order
+func (s *orderServer) CreateOrder(ctx context.Context, req *agorav1.CreateOrderRequest) (*agorav1.CreateOrderResponse, error) {
+	create := services.CreateOrderRequest{
+		Source:        req.Source,
+		ExternalID:    req.ExternalId,
+		Channel:       req.Channel,
+		CustomerName:  req.CustomerName,
+		CustomerPhone: req.CustomerPhone,
+		Notes:         req.Notes,
+		Items:         make([]services.CreateOrderItemRequest, len(req.Items)),
+		CouponCode:    req.CouponCode,
+	}
+	if req.LoyaltyPoints != nil {
+		points := int(*req.LoyaltyPoints)
+		create.LoyaltyPoints = &points
+	}
+	if req.SlotAt != nil {
+		slotAt, err := services.ParseTimestamp(*req.SlotAt)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "slot_at must be an RFC 3339 timestamp")
+		}
+		create.SlotAt = &slotAt
+	}
+	if req.Tip != nil {
+		tip, err := decimal.NewFromString(*req.Tip)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "tip must be a decimal")
+		}
+		create.Tip = &tip
+	}
+	if req.TipPercent != nil {
+		percent, err := decimal.NewFromString(*req.TipPercent)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "tip_percent must be a decimal")
+		}
+		create.TipPercent = &percent
+	}
+	for i, line := range req.Items {
+		item := services.CreateOrderItemRequest{
+			Name:     line.Name,
+			Quantity: int(line.Quantity),
+			Notes:    line.Notes,
+		}
+		if line.MenuItemId != nil {
+			id := int(*line.MenuItemId)
+			item.MenuItemID = &id
+		}
+		if line.UnitPrice != nil {
+			price, err := decimal.NewFromString(*line.UnitPrice)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "item %d: unit_price must be a decimal", i+1)
+			}
+			item.UnitPrice = &price
+		}
+		create.Items[i] = item
+	}
+
+	order, err := s.service.CreateOrder(ctx, create)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return &agorav1.CreateOrderResponse{Order: orderMessage(order)}, nil
+}
+
+// GetOrder returns an order by ID
+func (s *orderServer) GetOrder(ctx context.Context, req *agorav1.GetOrderRequest) (*agorav1.GetOrderResponse, error) {
+	order, err := s.service.GetOrderByID(ctx, req.Id)
+	if err != nil {
+		return nil, serviceError(err)
+	}
+	return &agorav1.GetOrderResponse{Order: orderMessage(order)}, nil
+}
+
+// ListOrders returns orders, newest first
+func (s *orderServer) ListOrders(ctx context.Context, req *agorav1.ListOrdersRequest) (*agorav1.ListOrdersResponse, error) {
+	if req.Limit < 0 || req.Offset < 0 || req.Number < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit, offset and number must not be negative")
+	}
+	orders, err := s.service.ListOrders(ctx, services.OrderListOptions{
+		Status: req.Status,
+		Source: req.Source,
+		Limit:  int(req.Limit),
+		Offset: int(req.Offset),
+		Number: int(req.Number),
+	})
+	if err != nil {
+		return nil, serviceError(err)
+	}
+
+	resp := &agorav1.ListOrdersResponse{Orders: make([]*agorav1.Order, len(orders))}
+	for i := range orders {
+		resp.Orders[i] = orderMessage(&orders[i])
+	}
+	return resp, nil
+}
+
+// orderMessage converts an order to its protobuf message
+func orderMessage(order *services.OrderResponse) *agorav1.Order {
+	msg := &agorav1.Order{
+		Id:               order.ID,
+		Source:           order.Source,
+		ExternalId:       order.ExternalID,
+		Channel:          order.Channel,
+		Status:           order.Status,
+		Total:            order.Total.String(),
+		Discount:         order.Discount.String(),
+		CouponCode:       order.CouponCode,
+		Tip:              order.Tip.String(),
+		AmountPaid:       order.AmountPaid.String(),
+		AmountDue:        order.AmountDue.String(),
+		LoyaltyPoints:    int32(order.LoyaltyPoints),
+		LoyaltyDiscount:  order.LoyaltyDiscount.String(),
+		CustomerName:     order.CustomerName,
+		CustomerPhone:    order.CustomerPhone,
+		Notes:            order.Notes,
+		Items:            make([]*agorav1.OrderItem, len(order.Items)),
+		EstimatedReadyAt: optionalTime(order.EstimatedReadyAt),
+		SlotAt:           optionalTime(order.SlotAt),
+		Table:            order.Table,
+		DeliveryStatus:   order.DeliveryStatus,
+		Driver:           order.Driver,
+		CreatedAt:        formatTime(order.CreatedAt),
+		UpdatedAt:        formatTime(order.UpdatedAt),
+	}
+	if order.TrackingToken != "" {
+		msg.TrackingToken = &order.TrackingToken
+	}
+	if order.Number != nil {
+		number := int32(*order.Number)
+		msg.Number = &number
+	}
+	for i, item := range order.Items {
+		line := &agorav1.OrderItem{
+			Id:        int64(item.ID),
+			Name:      item.Name,
+			Quantity:  int32(item.Quantity),
+			UnitPrice: item.UnitPrice.String(),
+			LineTotal: item.LineTotal.String(),
+			Notes:     item.Notes,
+		}
+		if item.MenuItemID != nil {
+			id := int64(*item.MenuItemID)
+			line.MenuItemId = &id
+		}
+		msg.Items[i] = line
+	}
+	return msg
+}
@@ -0,0 +1,193 @@
+// Package grpcapi serves the menu and order services over gRPC for internal
+// consumers. The protobuf definitions are in proto/agora/v1 and the generated code
+// in agora/v1; regenerate it with `make proto-generate`.
+package grpcapi
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	agorav1 "github.com/Zughayyar/agora-server/internal/grpcapi/agora/v1"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/restaurants"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// requestIDKey is the metadata key used to propagate request IDs
+const requestIDKey = "x-request-id"
+
+// restaurantKey is the metadata key naming the restaurant a call is made for, the
+// X-Restaurant-ID header of the REST API
+const restaurantKey = "x-restaurant-id"
+
+// mutatingMethods are rejected while read-only mode is on
+var mutatingMethods = map[string]bool{
+	agorav1.MenuItemService_CreateMenuItem_FullMethodName:  true,
+	agorav1.MenuItemService_UpdateMenuItem_FullMethodName:  true,
+	agorav1.MenuItemService_DeleteMenuItem_FullMethodName:  true,
+	agorav1.MenuItemService_RestoreMenuItem_FullMethodName: true,
+	agorav1.OrderService_CreateOrder_FullMethodName:        true,
+}
+
+// NewServer creates a gRPC server exposing MenuItemService and OrderService, backed
+// by the same services as the REST API, along with the standard health and
+// reflection services. Calls are limited to timeout, and to the restaurant of their
+// x-restaurant-id metadata in directory.
+func NewServer(items services.MenuItemService, orders services.OrderService, directory *restaurants.Directory, timeout time.Duration) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		requestContextInterceptor,
+		loggingInterceptor,
+		recoveryInterceptor,
+		restaurantInterceptor(directory),
+		timeoutInterceptor(timeout),
+		readOnlyInterceptor,
+	))
+	agorav1.RegisterMenuItemServiceServer(server, &menuItemServer{service: items})
+	agorav1.RegisterOrderServiceServer(server, &orderServer{service: orders})
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	reflection.Register(server)
+	return server
+}
+
+// requestContextInterceptor assigns a request ID, reusing the caller's x-request-id
+// metadata, and stores a request-scoped logger in the context
+func requestContextInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err == nil {
+			requestID = hex.EncodeToString(b)
+		}
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, requestID))
+
+	logger := slog.Default().With(
+		slog.String("request_id", requestID),
+		slog.String("grpc_method", info.FullMethod),
+	)
+	return handler(logging.NewContext(ctx, logger), req)
+}
+
+// loggingInterceptor logs calls with their status code and timing
+func loggingInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	code := status.Code(err)
+	level := slog.LevelInfo
+	switch code {
+	case codes.OK, codes.NotFound, codes.InvalidArgument, codes.AlreadyExists, codes.FailedPrecondition:
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		level = slog.LevelWarn
+	default:
+		level = slog.LevelError
+	}
+	logging.FromContext(ctx).Log(ctx, level, "gRPC call",
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)))
+	return resp, err
+}
+
+// recoveryInterceptor turns a panicking handler into an Internal error
+func recoveryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			logging.FromContext(ctx).Error("gRPC handler panicked", slog.String("panic", fmt.Sprint(p)))
+			err = status.Error(codes.Internal, "internal server error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// restaurantInterceptor limits calls to the restaurant named by their x-restaurant-id
+// metadata, the default restaurant when there is none. Malformed IDs are rejected with
+// InvalidArgument and unknown restaurants with NotFound.
+func restaurantInterceptor(directory *restaurants.Directory) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var raw string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(restaurantKey); len(values) > 0 {
+				raw = values[0]
+			}
+		}
+		id, err := directory.Resolve(ctx, raw)
+		switch {
+		case errors.Is(err, restaurants.ErrInvalidRestaurant):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, restaurants.ErrNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case err != nil:
+			logging.FromContext(ctx).Error("Failed to resolve restaurant", slog.String("error", err.Error()))
+			return nil, status.Error(codes.Internal, "failed to resolve restaurant")
+		}
+
+		ctx = database.WithRestaurant(ctx, id)
+		return handler(logging.With(ctx, slog.Int("restaurant_id", id)), req)
+	}
+}
+
+// timeoutInterceptor bounds calls to d, or to the caller's deadline if sooner
+func timeoutInterceptor(d time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if d <= 0 {
+			return handler(ctx, req)
+		}
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// readOnlyInterceptor rejects mutating calls with Unavailable while read-only mode is on
+func readOnlyInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if middlewares.ReadOnly.Load() && mutatingMethods[info.FullMethod] {
+		return nil, status.Error(codes.Unavailable, "the service is in read-only mode")
+	}
+	return handler(ctx, req)
+}
+
+// serviceError maps a service error to a gRPC status
+func serviceError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, services.ErrOrderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, services.ErrServiceUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, services.ErrInvalidInclude), errors.Is(err, services.ErrInvalidOrder),
+		errors.Is(err, services.ErrCouponNotRedeemable), errors.Is(err, services.ErrLoyaltyNotRedeemable):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, services.ErrOrderExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, services.ErrLocationClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, services.ErrSlotFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
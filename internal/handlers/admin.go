@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/migrations"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+)
+
+// LogLevelRequest represents a request to change the log level
+type LogLevelRequest struct {
+	Level string `json:"level" example:"debug"`
+}
+
+// LogLevelResponse represents the current log level
+type LogLevelResponse struct {
+	Level string `json:"level" example:"info"`
+}
+
+// GetLogLevel handles GET /admin/log-level
+// @Summary Get log level
+// @Description Returns the current minimum log level
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} SuccessResponse{data=LogLevelResponse} "Current log level"
+// @Router /admin/log-level [get]
+func GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, http.StatusOK, SuccessResponse{
+		Data:    LogLevelResponse{Level: strings.ToLower(logging.Level.Level().String())},
+		Message: "Log level retrieved successfully",
+	})
+}
+
+// SetLogLevel handles PUT /admin/log-level
+// @Summary Set log level
+// @Description Changes the minimum log level of the running instance without a restart
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param level body LogLevelRequest true "New log level (debug, info, warn, error)"
+// @Success 200 {object} SuccessResponse{data=LogLevelResponse} "Log level updated"
+// @Failure 400 {object} ErrorResponse "Invalid log level"
+// @Router /admin/log-level [put]
+func SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req LogLevelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(req.Level)); err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid log level. Must be one of: debug, info, warn, error")
+		return
+	}
+
+	previous := logging.Level.Level()
+	logging.Level.Set(level)
+
+	logging.FromContext(r.Context()).Info("Log level changed",
+		slog.String("from", previous.String()),
+		slog.String("to", level.String()))
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{
+		Data:    LogLevelResponse{Level: strings.ToLower(level.String())},
+		Message: "Log level updated successfully",
+	})
+}
+
+// ReadOnlyRequest represents a request to switch read-only mode on or off
+type ReadOnlyRequest struct {
+	Enabled *bool `json:"enabled" example:"true"`
+}
+
+// ReadOnlyResponse represents the current read-only mode
+type ReadOnlyResponse struct {
+	Enabled bool `json:"enabled" example:"false"`
+}
+
+// GetReadOnly handles GET /admin/read-only
+// @Summary Get read-only mode
+// @Description Reports whether mutating API requests are currently rejected
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} SuccessResponse{data=ReadOnlyResponse} "Current read-only mode"
+// @Router /admin/read-only [get]
+func GetReadOnly(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, http.StatusOK, SuccessResponse{
+		Data:    ReadOnlyResponse{Enabled: middlewares.ReadOnly.Load()},
+		Message: "Read-only mode retrieved successfully",
+	})
+}
+
+// SetReadOnly handles PUT /admin/read-only
+// @Summary Set read-only mode
+// @Description Switches read-only mode on or off without a restart. While enabled, mutating API requests return 503 and reads keep working.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param mode body ReadOnlyRequest true "Whether read-only mode is enabled"
+// @Success 200 {object} SuccessResponse{data=ReadOnlyResponse} "Read-only mode updated"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Router /admin/read-only [put]
+func SetReadOnly(w http.ResponseWriter, r *http.Request) {
+	var req ReadOnlyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+	if req.Enabled == nil {
+		writeError(w, r, http.StatusBadRequest, "Field 'enabled' is required")
+		return
+	}
+
+	previous := middlewares.ReadOnly.Swap(*req.Enabled)
+
+	logging.FromContext(r.Context()).Warn("Read-only mode changed",
+		slog.Bool("from", previous),
+		slog.Bool("to", *req.Enabled))
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{
+		Data:    ReadOnlyResponse{Enabled: *req.Enabled},
+		Message: "Read-only mode updated successfully",
+	})
+}
+
+// RouteInfo describes a registered route. Method is empty when the route accepts any method.
+type RouteInfo struct {
+	Method   string `json:"method,omitempty" example:"GET"`
+	Pattern  string `json:"pattern" example:"/api/v1/items/{id}"`
+	Handler  string `json:"handler" example:"handlers.(*MenuItemHandlers).GetMenuItemByID"`
+	Listener string `json:"listener" example:"public"`
+}
+
+// RoutesHandler handles GET /admin/routes
+// @Summary Registered routes
+// @Description Lists every registered route with its method, path pattern, handler and listener
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} SuccessResponse{data=[]RouteInfo} "Registered routes"
+// @Router /admin/routes [get]
+func RoutesHandler(list func() []RouteInfo) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    list(),
+			Message: "Routes retrieved successfully",
+		})
+	}
+}
+
+// DatabaseStatsResponse represents connection pool statistics
+type DatabaseStatsResponse struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`
+}
+
+// DatabaseStatsHandler handles GET /admin/db/stats
+// @Summary Database pool statistics
+// @Description Returns connection pool statistics for tuning DB_MAX_OPEN_CONNS and related settings
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} SuccessResponse{data=DatabaseStatsResponse} "Connection pool statistics"
+// @Router /admin/db/stats [get]
+func DatabaseStatsHandler(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		stats := database.GetStats(db)
+
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data: DatabaseStatsResponse{
+				MaxOpenConnections: stats.MaxOpenConnections,
+				OpenConnections:    stats.OpenConnections,
+				InUse:              stats.InUse,
+				Idle:               stats.Idle,
+				WaitCount:          stats.WaitCount,
+				WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+				MaxIdleClosed:      stats.MaxIdleClosed,
+				MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+				MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+			},
+			Message: "Database statistics retrieved successfully",
+		})
+	}
+}
+
+// MigrationInfo describes a single schema migration
+type MigrationInfo struct {
+	Name       string     `json:"name" example:"20250628_001"`
+	Applied    bool       `json:"applied"`
+	GroupID    int64      `json:"group_id,omitempty"`
+	MigratedAt *time.Time `json:"migrated_at,omitempty"`
+}
+
+// MigrationStatusResponse lists applied and pending migrations
+type MigrationStatusResponse struct {
+	Applied    int             `json:"applied"`
+	Pending    int             `json:"pending"`
+	Migrations []MigrationInfo `json:"migrations"`
+}
+
+// MigrationStatusHandler handles GET /admin/migrations
+// @Summary Migration status
+// @Description Lists all schema migrations and whether they have been applied
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} SuccessResponse{data=MigrationStatusResponse} "Migration status"
+// @Failure 500 {object} ErrorResponse "Migration status unavailable"
+// @Router /admin/migrations [get]
+func MigrationStatusHandler(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ms, err := migrations.ListMigrations(r.Context(), db)
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to list migrations", slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to read migration status")
+			return
+		}
+
+		response := MigrationStatusResponse{Migrations: make([]MigrationInfo, 0, len(ms))}
+		for _, m := range ms {
+			info := MigrationInfo{Name: m.Name, Applied: m.IsApplied(), GroupID: m.GroupID}
+			if info.Applied {
+				migratedAt := m.MigratedAt
+				info.MigratedAt = &migratedAt
+				response.Applied++
+			} else {
+				response.Pending++
+			}
+			response.Migrations = append(response.Migrations, info)
+		}
+
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    response,
+			Message: "Migration status retrieved successfully",
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// AnonymizationRunsHandler handles GET /admin/anonymization-runs
+// @Summary Anonymization runs
+// @Description Returns the audit entries of the anonymize_orders task, newest first: the cutoff before which orders were anonymized, the orders, coupon redemptions, carts and reviews whose contact details were cleared, and the error a run stopped on
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Param limit query int false "Maximum number of runs (default 100, at most 500)"
+// @Success 200 {object} SuccessResponse{data=[]services.AnonymizationRunResponse} "Anonymization runs"
+// @Failure 400 {object} ErrorResponse "Invalid limit"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/anonymization-runs [get]
+func AnonymizationRunsHandler(anonymizer *services.OrderAnonymizer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 0
+		if value := r.URL.Query().Get("limit"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				writeError(w, r, http.StatusBadRequest, "limit must be a positive integer")
+				return
+			}
+			limit = n
+		}
+
+		runs, err := anonymizer.Runs(r.Context(), limit)
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to list anonymization runs", slog.String("error", err.Error()))
+			writeError(w, r, serviceErrorStatus(err), "Failed to list anonymization runs")
+			return
+		}
+
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    runs,
+			Message: "Anonymization runs retrieved successfully",
+		})
+	}
+}
@@ -0,0 +1,357 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// CartHandlers contains HTTP handlers for carts, which customers fill and check out
+// into online orders
+type CartHandlers struct {
+	service services.CartService
+}
+
+// NewCartHandlers creates a new cart handlers instance
+func NewCartHandlers(service services.CartService) *CartHandlers {
+	return &CartHandlers{service: service}
+}
+
+// CreateCart handles POST /api/v1/carts
+// @Summary Create cart
+// @Description Starts an empty cart for an online order. Its ID is the only key to the cart, so clients keep it private.
+// @Tags Carts
+// @Accept json
+// @Produce json
+// @Param cart body services.CartRequest true "Cart"
+// @Success 201 {object} SuccessResponse{data=services.CartResponse} "Cart created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid cart"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/carts [post]
+func (h *CartHandlers) CreateCart(w http.ResponseWriter, r *http.Request) {
+	var req services.CartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	cart, err := h.service.CreateCart(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create cart")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: cart, Message: "Cart created successfully"})
+}
+
+// GetCart handles GET /api/v1/carts/{id}
+// @Summary Get cart
+// @Description Retrieves a cart with its lines at the prices the customer was shown
+// @Tags Carts
+// @Produce json
+// @Param id path string true "Cart ID"
+// @Success 200 {object} SuccessResponse{data=services.CartResponse} "Cart retrieved successfully"
+// @Failure 404 {object} ErrorResponse "Cart not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/carts/{id} [get]
+func (h *CartHandlers) GetCart(w http.ResponseWriter, r *http.Request) {
+	cart, err := h.service.GetCart(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to get cart")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: cart, Message: "Cart retrieved successfully"})
+}
+
+// UpdateCart handles PUT /api/v1/carts/{id}
+// @Summary Update cart
+// @Description Changes the channel and customer details of a cart. Its lines are repriced when the channel changes.
+// @Tags Carts
+// @Accept json
+// @Produce json
+// @Param id path string true "Cart ID"
+// @Param cart body services.CartRequest true "Cart"
+// @Success 200 {object} SuccessResponse{data=services.CartResponse} "Cart updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid cart"
+// @Failure 404 {object} ErrorResponse "Cart not found"
+// @Failure 409 {object} ErrorResponse "Cart already checked out"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/carts/{id} [put]
+func (h *CartHandlers) UpdateCart(w http.ResponseWriter, r *http.Request) {
+	var req services.CartRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	cart, err := h.service.UpdateCart(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update cart")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: cart, Message: "Cart updated successfully"})
+}
+
+// AddCartItem handles POST /api/v1/carts/{id}/items
+// @Summary Add cart item
+// @Description Adds an available menu item to a cart at its current price on the cart's channel
+// @Tags Carts
+// @Accept json
+// @Produce json
+// @Param id path string true "Cart ID"
+// @Param item body services.CartItemRequest true "Cart line"
+// @Success 201 {object} SuccessResponse{data=services.CartResponse} "Item added successfully"
+// @Failure 400 {object} ErrorResponse "Invalid or unavailable item"
+// @Failure 404 {object} ErrorResponse "Cart not found"
+// @Failure 409 {object} ErrorResponse "Cart already checked out"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/carts/{id}/items [post]
+func (h *CartHandlers) AddCartItem(w http.ResponseWriter, r *http.Request) {
+	var req services.CartItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	cart, err := h.service.AddCartItem(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to add item")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: cart, Message: "Item added successfully"})
+}
+
+// UpdateCartItem handles PUT /api/v1/carts/{id}/items/{itemId}
+// @Summary Update cart item
+// @Description Changes the quantity and notes of a cart line
+// @Tags Carts
+// @Accept json
+// @Produce json
+// @Param id path string true "Cart ID"
+// @Param itemId path int true "Cart line ID"
+// @Param item body services.CartItemRequest true "Cart line"
+// @Success 200 {object} SuccessResponse{data=services.CartResponse} "Item updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid item"
+// @Failure 404 {object} ErrorResponse "Cart or line not found"
+// @Failure 409 {object} ErrorResponse "Cart already checked out"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/carts/{id}/items/{itemId} [put]
+func (h *CartHandlers) UpdateCartItem(w http.ResponseWriter, r *http.Request) {
+	itemID, err := strconv.Atoi(r.PathValue("itemId"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid item ID")
+		return
+	}
+	var req services.CartItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	cart, err := h.service.UpdateCartItem(r.Context(), r.PathValue("id"), itemID, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update item")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: cart, Message: "Item updated successfully"})
+}
+
+// RemoveCartItem handles DELETE /api/v1/carts/{id}/items/{itemId}
+// @Summary Remove cart item
+// @Description Removes a line from a cart
+// @Tags Carts
+// @Produce json
+// @Param id path string true "Cart ID"
+// @Param itemId path int true "Cart line ID"
+// @Success 200 {object} SuccessResponse{data=services.CartResponse} "Item removed successfully"
+// @Failure 400 {object} ErrorResponse "Invalid item ID"
+// @Failure 404 {object} ErrorResponse "Cart or line not found"
+// @Failure 409 {object} ErrorResponse "Cart already checked out"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/carts/{id}/items/{itemId} [delete]
+func (h *CartHandlers) RemoveCartItem(w http.ResponseWriter, r *http.Request) {
+	itemID, err := strconv.Atoi(r.PathValue("itemId"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid item ID")
+		return
+	}
+
+	cart, err := h.service.RemoveCartItem(r.Context(), r.PathValue("id"), itemID)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to remove item")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: cart, Message: "Item removed successfully"})
+}
+
+// ApplyCartCoupon handles PUT /api/v1/carts/{id}/coupon
+// @Summary Apply coupon to cart
+// @Description Applies a coupon to a cart after checking it can be redeemed on the cart's items. Fails with 422 and the reason when it can't. The coupon is only redeemed at checkout.
+// @Tags Carts
+// @Accept json
+// @Produce json
+// @Param id path string true "Cart ID"
+// @Param coupon body services.CartCouponRequest true "Coupon"
+// @Success 200 {object} SuccessResponse{data=services.CartResponse} "Coupon applied successfully"
+// @Failure 400 {object} ErrorResponse "Invalid coupon or empty cart"
+// @Failure 404 {object} ErrorResponse "Cart not found"
+// @Failure 409 {object} ErrorResponse "Cart already checked out"
+// @Failure 422 {object} ErrorResponse "Coupon can't be redeemed on the cart"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/carts/{id}/coupon [put]
+func (h *CartHandlers) ApplyCartCoupon(w http.ResponseWriter, r *http.Request) {
+	var req services.CartCouponRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	cart, err := h.service.ApplyCoupon(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to apply coupon")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: cart, Message: "Coupon applied successfully"})
+}
+
+// RemoveCartCoupon handles DELETE /api/v1/carts/{id}/coupon
+// @Summary Remove coupon from cart
+// @Description Removes the coupon of a cart
+// @Tags Carts
+// @Produce json
+// @Param id path string true "Cart ID"
+// @Success 200 {object} SuccessResponse{data=services.CartResponse} "Coupon removed successfully"
+// @Failure 404 {object} ErrorResponse "Cart not found"
+// @Failure 409 {object} ErrorResponse "Cart already checked out"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/carts/{id}/coupon [delete]
+func (h *CartHandlers) RemoveCartCoupon(w http.ResponseWriter, r *http.Request) {
+	cart, err := h.service.RemoveCoupon(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to remove coupon")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: cart, Message: "Coupon removed successfully"})
+}
+
+// SetCartTip handles PUT /api/v1/carts/{id}/tip
+// @Summary Set cart tip
+// @Description Sets the tip of a cart as an amount or a percentage of its total; setting neither removes it. The tip is paid on top of the order's total.
+// @Tags Carts
+// @Accept json
+// @Produce json
+// @Param id path string true "Cart ID"
+// @Param tip body services.CartTipRequest true "Tip"
+// @Success 200 {object} SuccessResponse{data=services.CartResponse} "Tip set successfully"
+// @Failure 400 {object} ErrorResponse "Invalid tip"
+// @Failure 404 {object} ErrorResponse "Cart not found"
+// @Failure 409 {object} ErrorResponse "Cart already checked out"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/carts/{id}/tip [put]
+func (h *CartHandlers) SetCartTip(w http.ResponseWriter, r *http.Request) {
+	var req services.CartTipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	cart, err := h.service.SetTip(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to set tip")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: cart, Message: "Tip set successfully"})
+}
+
+// PreviewCart handles GET /api/v1/carts/{id}/preview
+// @Summary Preview cart
+// @Description Prices a cart as checking it out now would, with its promotions, coupon, tax and tip, without creating an order
+// @Tags Carts
+// @Produce json
+// @Param id path string true "Cart ID"
+// @Success 200 {object} SuccessResponse{data=services.OrderResponse} "Cart priced successfully"
+// @Failure 400 {object} ErrorResponse "Empty cart"
+// @Failure 404 {object} ErrorResponse "Cart not found"
+// @Failure 422 {object} ErrorResponse "Coupon can't be redeemed on the cart"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/carts/{id}/preview [get]
+func (h *CartHandlers) PreviewCart(w http.ResponseWriter, r *http.Request) {
+	quote, err := h.service.PreviewCart(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to price cart")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: quote, Message: "Cart priced successfully"})
+}
+
+// CheckOutCart handles POST /api/v1/carts/{id}/checkout
+// @Summary Check out cart
+// @Description Converts a cart into an online order after checking its items are still available at the prices the customer was shown. When they aren't, the cart's prices are updated and 409 lists what changed, for the customer to review before checking out again. Fails with 422 when the cart's location is closed at the order's time, and with 429 and Retry-After when the order's time slot is full.
+// @Tags Carts
+// @Produce json
+// @Param id path string true "Cart ID"
+// @Success 201 {object} SuccessResponse{data=services.OrderResponse} "Order placed successfully"
+// @Failure 400 {object} ErrorResponse "Empty cart"
+// @Failure 404 {object} ErrorResponse "Cart not found"
+// @Failure 409 {object} ErrorResponse "Cart already checked out, or its items or prices changed"
+// @Failure 422 {object} ErrorResponse "Coupon or loyalty points can't be redeemed on the cart, or the location is closed"
+// @Failure 429 {object} ErrorResponse "Order slot is full"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/carts/{id}/checkout [post]
+func (h *CartHandlers) CheckOutCart(w http.ResponseWriter, r *http.Request) {
+	order, err := h.service.CheckOut(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to check out cart")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: order, Message: "Order placed successfully"})
+}
+
+// writeServiceError maps a cart service error to its status code
+func (h *CartHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrCartNotFound):
+		writeError(w, r, http.StatusNotFound, "Cart not found")
+	case errors.Is(err, services.ErrCartItemNotFound):
+		writeError(w, r, http.StatusNotFound, "Cart item not found")
+	case errors.Is(err, services.ErrInvalidCart), errors.Is(err, services.ErrInvalidOrder):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrCartCheckedOut), errors.Is(err, services.ErrCartChanged):
+		writeError(w, r, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrCouponNotRedeemable), errors.Is(err, services.ErrLoyaltyNotRedeemable),
+		errors.Is(err, services.ErrLocationClosed):
+		writeError(w, r, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, services.ErrSlotFull):
+		var full *services.SlotFullError
+		if errors.As(err, &full) && !full.NextSlot.IsZero() {
+			w.Header().Set("Retry-After", strconv.Itoa(max(1, int(math.Ceil(time.Until(full.NextSlot).Seconds())))))
+		}
+		writeError(w, r, http.StatusTooManyRequests, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
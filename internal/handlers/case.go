@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ResponseCaseHeader lets a client pick the field naming convention of a response
+const ResponseCaseHeader = "X-Response-Case"
+
+// Field naming conventions of JSON responses
+const (
+	CaseSnake = "snake" // is_available, the convention of the API's types
+	CaseCamel = "camel" // isAvailable
+)
+
+// responseCase is the naming convention used when a request doesn't ask for one
+var responseCase = CaseSnake
+
+// SetResponseCase sets the default field naming convention of responses. It must be
+// called before the server starts.
+func SetResponseCase(convention string) {
+	responseCase = convention
+}
+
+// requestedCase returns the naming convention for r: the X-Response-Case header when
+// it names a known convention, the default otherwise
+func requestedCase(r *http.Request) string {
+	switch convention := strings.ToLower(strings.TrimSpace(r.Header.Get(ResponseCaseHeader))); convention {
+	case CaseSnake, CaseCamel:
+		return convention
+	default:
+		return responseCase
+	}
+}
+
+// withResponseCase renames the fields of v to the naming convention requested by r.
+// The result encodes like v, keeping the field order, in every response format.
+func withResponseCase(w http.ResponseWriter, r *http.Request, v interface{}) (interface{}, error) {
+	w.Header().Add("Vary", ResponseCaseHeader)
+	if requestedCase(r) != CaseCamel {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	renamed, err := camelCaseKeys(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(renamed), nil
+}
+
+// camelCaseKeys rewrites the object keys of a JSON document to camelCase
+func camelCaseKeys(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var out bytes.Buffer
+	if err := writeCamelValue(&out, dec); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// writeCamelValue copies the next JSON value from dec to out, renaming object keys
+func writeCamelValue(out *bytes.Buffer, dec *json.Decoder) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		encoded, err := json.Marshal(token)
+		if err != nil {
+			return err
+		}
+		out.Write(encoded)
+		return nil
+	}
+
+	out.WriteRune(rune(delim))
+	for i := 0; dec.More(); i++ {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		if delim == '{' {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			encoded, err := json.Marshal(camelCase(key.(string)))
+			if err != nil {
+				return err
+			}
+			out.Write(encoded)
+			out.WriteByte(':')
+		}
+		if err := writeCamelValue(out, dec); err != nil {
+			return err
+		}
+	}
+	// Copy the closing delimiter
+	end, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	out.WriteRune(rune(end.(json.Delim)))
+	return nil
+}
+
+// camelCase converts a snake_case name to camelCase, e.g. is_available to isAvailable
+func camelCase(name string) string {
+	if !strings.Contains(name, "_") {
+		return name
+	}
+	parts := strings.Split(name, "_")
+	var b strings.Builder
+	b.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// CouponHandlers contains HTTP handlers for coupon operations
+type CouponHandlers struct {
+	service services.CouponService
+	orders  services.OrderService
+}
+
+// NewCouponHandlers creates a new coupon handlers instance. Coupons are validated by
+// quoting orders with orders.
+func NewCouponHandlers(service services.CouponService, orders services.OrderService) *CouponHandlers {
+	return &CouponHandlers{service: service, orders: orders}
+}
+
+// GetCoupons handles GET /api/v1/coupons
+// @Summary List coupons
+// @Description Retrieves the coupons with their usage counts
+// @Tags Coupons
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=[]services.CouponResponse} "Coupons retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/coupons [get]
+func (h *CouponHandlers) GetCoupons(w http.ResponseWriter, r *http.Request) {
+	coupons, err := h.service.ListCoupons(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to list coupons", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to list coupons")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: coupons, Message: "Coupons retrieved successfully"})
+}
+
+// CreateCoupon handles POST /api/v1/coupons
+// @Summary Create coupon
+// @Description Creates a coupon code giving a percentage or fixed discount on one menu item, a category or the whole order, with optional usage limits and validity window
+// @Tags Coupons
+// @Accept json
+// @Produce json
+// @Param coupon body services.CouponRequest true "Coupon"
+// @Success 201 {object} SuccessResponse{data=services.CouponResponse} "Coupon created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid coupon"
+// @Failure 409 {object} ErrorResponse "The code is already taken"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/coupons [post]
+func (h *CouponHandlers) CreateCoupon(w http.ResponseWriter, r *http.Request) {
+	var req services.CouponRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	coupon, err := h.service.CreateCoupon(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create coupon")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: coupon, Message: "Coupon created successfully"})
+}
+
+// UpdateCoupon handles PUT /api/v1/coupons/{id}
+// @Summary Update coupon
+// @Description Replaces a coupon, keeping its usage count. Existing orders keep their discount.
+// @Tags Coupons
+// @Accept json
+// @Produce json
+// @Param id path int true "Coupon ID"
+// @Param coupon body services.CouponRequest true "Coupon"
+// @Success 200 {object} SuccessResponse{data=services.CouponResponse} "Coupon updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid coupon"
+// @Failure 404 {object} ErrorResponse "Coupon not found"
+// @Failure 409 {object} ErrorResponse "The code is already taken"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/coupons/{id} [put]
+func (h *CouponHandlers) UpdateCoupon(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid coupon ID")
+		return
+	}
+	var req services.CouponRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	coupon, err := h.service.UpdateCoupon(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update coupon")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: coupon, Message: "Coupon updated successfully"})
+}
+
+// DeleteCoupon handles DELETE /api/v1/coupons/{id}
+// @Summary Delete coupon
+// @Description Deletes a coupon and its redemption history. Existing orders keep their discount.
+// @Tags Coupons
+// @Produce json
+// @Param id path int true "Coupon ID"
+// @Success 200 {object} SuccessResponse "Coupon deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid coupon ID"
+// @Failure 404 {object} ErrorResponse "Coupon not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/coupons/{id} [delete]
+func (h *CouponHandlers) DeleteCoupon(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid coupon ID")
+		return
+	}
+
+	if err := h.service.DeleteCoupon(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "Failed to delete coupon")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Coupon deleted successfully"})
+}
+
+// GetCouponRedemptions handles GET /api/v1/coupons/{id}/redemptions
+// @Summary List coupon redemptions
+// @Description Lists the orders a coupon was redeemed on, newest first
+// @Tags Coupons
+// @Produce json,xml,application/msgpack
+// @Param id path int true "Coupon ID"
+// @Success 200 {object} SuccessResponse{data=[]services.CouponRedemptionResponse} "Redemptions retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid coupon ID"
+// @Failure 404 {object} ErrorResponse "Coupon not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/coupons/{id}/redemptions [get]
+func (h *CouponHandlers) GetCouponRedemptions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid coupon ID")
+		return
+	}
+
+	redemptions, err := h.service.ListRedemptions(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to list redemptions")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: redemptions, Message: "Redemptions retrieved successfully"})
+}
+
+// ValidateCoupon handles POST /api/v1/coupons/validate
+// @Summary Validate coupon
+// @Description Prices an order with its coupon_code without creating it, returning the quote with the discount applied. Fails with 422 and the reason when the coupon can't be redeemed on the order. The coupon is only redeemed when the order is created.
+// @Tags Coupons
+// @Accept json
+// @Produce json
+// @Param order body services.CreateOrderRequest true "Order to price, with coupon_code"
+// @Success 200 {object} SuccessResponse{data=services.OrderResponse} "Coupon is valid"
+// @Failure 400 {object} ErrorResponse "Invalid order"
+// @Failure 422 {object} ErrorResponse "Coupon can't be redeemed on the order"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/coupons/validate [post]
+func (h *CouponHandlers) ValidateCoupon(w http.ResponseWriter, r *http.Request) {
+	var req services.CreateOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+	if req.CouponCode == nil || strings.TrimSpace(*req.CouponCode) == "" {
+		writeError(w, r, http.StatusBadRequest, "coupon_code is required")
+		return
+	}
+
+	quote, err := h.orders.QuoteOrder(r.Context(), req)
+	switch {
+	case errors.Is(err, services.ErrCouponNotRedeemable), errors.Is(err, services.ErrLoyaltyNotRedeemable):
+		writeError(w, r, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, services.ErrInvalidOrder):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case err != nil:
+		logging.FromContext(r.Context()).Error("Failed to validate coupon", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to validate coupon")
+	default:
+		writeJSON(w, r, http.StatusOK, SuccessResponse{Data: quote, Message: "Coupon is valid"})
+	}
+}
+
+// writeServiceError maps a coupon service error to its status code
+func (h *CouponHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrCouponNotFound):
+		writeError(w, r, http.StatusNotFound, "Coupon not found")
+	case errors.Is(err, services.ErrInvalidCoupon):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrCouponExists):
+		writeError(w, r, http.StatusConflict, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
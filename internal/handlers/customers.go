@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// CustomerHandlers contains HTTP handlers for customers' data protection requests
+type CustomerHandlers struct {
+	service services.CustomerService
+}
+
+// NewCustomerHandlers creates a new customer handlers instance
+func NewCustomerHandlers(service services.CustomerService) *CustomerHandlers {
+	return &CustomerHandlers{service: service}
+}
+
+// ExportCustomer handles GET /api/v1/customers/{id}/export
+// @Summary Export a customer's personal data
+// @Description Retrieves all the personal data stored about a customer, for a data access request: their orders with their lines and payments, their carts, their reviews of items of their orders with the IP address they were written from, the coupons redeemed on their orders, and their loyalty points and store credit with their ledgers, oldest first. Customers are identified by their phone number.
+// @Tags Customers
+// @Produce json
+// @Param id path string true "Customer phone number"
+// @Success 200 {object} SuccessResponse{data=services.CustomerExportResponse} "Customer data exported successfully"
+// @Failure 404 {object} ErrorResponse "No data is stored about the customer"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customers/{id}/export [get]
+func (h *CustomerHandlers) ExportCustomer(w http.ResponseWriter, r *http.Request) {
+	export, err := h.service.ExportCustomer(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to export customer data")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: export, Message: "Customer data exported successfully"})
+}
+
+// EraseCustomer handles DELETE /api/v1/customers/{id}/erase
+// @Summary Erase a customer's personal data
+// @Description Anonymizes the personal data stored about a customer, for a right-to-erasure request. Their name, phone number and notes are cleared from their orders and carts, and their name and IP address from their reviews. Orders keep their lines, payments and totals, so sales reports don't change. Their loyalty points and store credit, still owed, move to a random pseudonym returned as erased_as. It can't be undone.
+// @Tags Customers
+// @Produce json
+// @Param id path string true "Customer phone number"
+// @Success 200 {object} SuccessResponse{data=services.CustomerErasureResponse} "Customer data erased successfully"
+// @Failure 404 {object} ErrorResponse "No data is stored about the customer"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customers/{id}/erase [delete]
+func (h *CustomerHandlers) EraseCustomer(w http.ResponseWriter, r *http.Request) {
+	erasure, err := h.service.EraseCustomer(r.Context(), r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to erase customer data")
+		return
+	}
+
+	logging.FromContext(r.Context()).Info("Customer data erased",
+		slog.Int("orders", erasure.Orders),
+		slog.Int("carts", erasure.Carts),
+		slog.Int("reviews", erasure.Reviews))
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: erasure, Message: "Customer data erased successfully"})
+}
+
+// writeServiceError maps a customer service error to its status code
+func (h *CustomerHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrCustomerNotFound):
+		writeError(w, r, http.StatusNotFound, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
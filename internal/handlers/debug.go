@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// ProfilingHandler wraps a pprof handler so long-running profiles are not cut off
+// by the server's WriteTimeout (CPU profiles and traces default to 30 seconds)
+func ProfilingHandler(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+			logging.FromContext(r.Context()).Warn("Failed to clear write deadline for profiling request")
+		}
+		h(w, r)
+	}
+}
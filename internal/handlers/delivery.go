@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/delivery"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/restaurants"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// DeliveryWebhookHandlers receives order webhooks from delivery platforms
+type DeliveryWebhookHandlers struct {
+	providers map[string]delivery.Provider
+	orders    services.OrderService
+	directory *restaurants.Directory
+}
+
+// NewDeliveryWebhookHandlers creates handlers for the given providers, placing orders
+// at the restaurants of directory
+func NewDeliveryWebhookHandlers(orders services.OrderService, directory *restaurants.Directory, providers ...delivery.Provider) *DeliveryWebhookHandlers {
+	h := &DeliveryWebhookHandlers{providers: make(map[string]delivery.Provider), orders: orders, directory: directory}
+	for _, p := range providers {
+		h.providers[p.Name()] = p
+	}
+	return h
+}
+
+// ReceiveOrder handles POST /webhooks/{provider}
+// @Summary Receive a delivery platform order
+// @Description Verifies the provider's signature and creates an order from the payload at the restaurant its store is registered with on the platform. Events signed more than 5 minutes away from the server's time are rejected. Orders received again (same provider order ID) are acknowledged without being created twice; events other than new orders are acknowledged and ignored.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider (ubereats, doordash)"
+// @Success 200 {object} SuccessResponse{data=services.OrderResponse} "Order already received, or event ignored"
+// @Success 201 {object} SuccessResponse{data=services.OrderResponse} "Order created"
+// @Failure 400 {object} ErrorResponse "Invalid payload, or a store ID that is not a restaurant ID"
+// @Failure 401 {object} ErrorResponse "Invalid signature, or a stale event"
+// @Failure 404 {object} ErrorResponse "Unknown or disabled provider, or unknown restaurant"
+// @Failure 422 {object} ErrorResponse "The order's location is closed"
+// @Failure 429 {object} ErrorResponse "The current order slot is full; Retry-After is the wait for the next available one"
+// @Failure 503 {object} ErrorResponse "Read-only mode is enabled"
+// @Router /webhooks/{provider} [post]
+func (h *DeliveryWebhookHandlers) ReceiveOrder(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("provider")
+	provider, ok := h.providers[name]
+	if !ok {
+		writeError(w, r, http.StatusNotFound, "Unknown webhook provider")
+		return
+	}
+	logger := logging.FromContext(r.Context()).With(slog.String("provider", name))
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		status, message := requestBodyError(err, "Failed to read request body")
+		writeError(w, r, status, message)
+		return
+	}
+	if err := provider.Verify(r.Header, body); err != nil {
+		logger.Warn("Rejected delivery webhook", slog.String("error", err.Error()))
+		writeError(w, r, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	// Let the platform retry later rather than losing the order
+	if middlewares.ReadOnly.Load() {
+		w.Header().Set("Retry-After", "60")
+		writeError(w, r, http.StatusServiceUnavailable, "Read-only mode is enabled")
+		return
+	}
+
+	req, err := provider.ParseOrder(body)
+	if errors.Is(err, delivery.ErrIgnored) {
+		writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Event ignored"})
+		return
+	}
+	if err != nil {
+		logger.Warn("Invalid delivery webhook payload", slog.String("error", err.Error()))
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// The restaurant comes from the signed payload, so a captured request can't be
+	// replayed against another restaurant
+	restaurant, err := h.directory.Resolve(r.Context(), provider.Restaurant(body))
+	switch {
+	case errors.Is(err, restaurants.ErrInvalidRestaurant):
+		writeError(w, r, http.StatusBadRequest, "The order's store ID is not a restaurant ID")
+		return
+	case errors.Is(err, restaurants.ErrNotFound):
+		writeError(w, r, http.StatusNotFound, "Restaurant not found")
+		return
+	case err != nil:
+		logger.Error("Failed to resolve restaurant", slog.String("error", err.Error()))
+		writeError(w, r, http.StatusInternalServerError, "Failed to resolve restaurant")
+		return
+	}
+	ctx := database.WithRestaurant(r.Context(), restaurant)
+	logger = logger.With(slog.Int("restaurant_id", restaurant))
+
+	order, err := h.orders.CreateOrder(ctx, *req)
+	switch {
+	case errors.Is(err, services.ErrOrderExists):
+		writeJSON(w, r, http.StatusOK, SuccessResponse{Data: order, Message: "Order already received"})
+	case errors.Is(err, services.ErrInvalidOrder):
+		logger.Warn("Rejected delivery order", slog.String("error", err.Error()))
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrLocationClosed):
+		logger.Warn("Rejected delivery order", slog.String("error", err.Error()))
+		writeError(w, r, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, services.ErrSlotFull):
+		logger.Warn("Delivery order throttled", slog.String("error", err.Error()))
+		var full *services.SlotFullError
+		if errors.As(err, &full) && !full.NextSlot.IsZero() {
+			w.Header().Set("Retry-After", strconv.Itoa(max(1, int(math.Ceil(time.Until(full.NextSlot).Seconds())))))
+		}
+		writeError(w, r, http.StatusTooManyRequests, err.Error())
+	case err != nil:
+		logger.Error("Failed to create delivery order", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to create order")
+	default:
+		logger.Info("Delivery order received", slog.String("order_id", order.ID))
+		writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: order, Message: "Order created"})
+	}
+}
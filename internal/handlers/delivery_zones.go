@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// DeliveryZoneHandlers contains HTTP handlers for delivery zone operations
+type DeliveryZoneHandlers struct {
+	service services.DeliveryZoneService
+}
+
+// NewDeliveryZoneHandlers creates a new delivery zone handlers instance
+func NewDeliveryZoneHandlers(service services.DeliveryZoneService) *DeliveryZoneHandlers {
+	return &DeliveryZoneHandlers{service: service}
+}
+
+// GetDeliveryZones handles GET /api/v1/delivery-zones
+// @Summary List delivery zones
+// @Description Retrieves the delivery zones by name, with their fees and minimum order amounts
+// @Tags Delivery Zones
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=[]services.DeliveryZoneResponse} "Delivery zones retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/delivery-zones [get]
+func (h *DeliveryZoneHandlers) GetDeliveryZones(w http.ResponseWriter, r *http.Request) {
+	zones, err := h.service.ListDeliveryZones(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to list delivery zones", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to list delivery zones")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: zones, Message: "Delivery zones retrieved successfully"})
+}
+
+// CreateDeliveryZone handles POST /api/v1/delivery-zones
+// @Summary Create delivery zone
+// @Description Creates a delivery zone: a circle of radius_meters around its center, or a polygon of at least three points
+// @Tags Delivery Zones
+// @Accept json
+// @Produce json
+// @Param zone body services.DeliveryZoneRequest true "Delivery zone"
+// @Success 201 {object} SuccessResponse{data=services.DeliveryZoneResponse} "Delivery zone created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid delivery zone"
+// @Failure 409 {object} ErrorResponse "Another zone has the name"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/delivery-zones [post]
+func (h *DeliveryZoneHandlers) CreateDeliveryZone(w http.ResponseWriter, r *http.Request) {
+	var req services.DeliveryZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	zone, err := h.service.CreateDeliveryZone(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create delivery zone")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: zone, Message: "Delivery zone created successfully"})
+}
+
+// UpdateDeliveryZone handles PUT /api/v1/delivery-zones/{id}
+// @Summary Update delivery zone
+// @Description Replaces a delivery zone
+// @Tags Delivery Zones
+// @Accept json
+// @Produce json
+// @Param id path int true "Delivery zone ID"
+// @Param zone body services.DeliveryZoneRequest true "Delivery zone"
+// @Success 200 {object} SuccessResponse{data=services.DeliveryZoneResponse} "Delivery zone updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid delivery zone"
+// @Failure 404 {object} ErrorResponse "Delivery zone not found"
+// @Failure 409 {object} ErrorResponse "Another zone has the name"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/delivery-zones/{id} [put]
+func (h *DeliveryZoneHandlers) UpdateDeliveryZone(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid delivery zone ID")
+		return
+	}
+	var req services.DeliveryZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	zone, err := h.service.UpdateDeliveryZone(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update delivery zone")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: zone, Message: "Delivery zone updated successfully"})
+}
+
+// DeleteDeliveryZone handles DELETE /api/v1/delivery-zones/{id}
+// @Summary Delete delivery zone
+// @Description Deletes a delivery zone
+// @Tags Delivery Zones
+// @Produce json
+// @Param id path int true "Delivery zone ID"
+// @Success 200 {object} SuccessResponse "Delivery zone deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid delivery zone ID"
+// @Failure 404 {object} ErrorResponse "Delivery zone not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/delivery-zones/{id} [delete]
+func (h *DeliveryZoneHandlers) DeleteDeliveryZone(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid delivery zone ID")
+		return
+	}
+
+	if err := h.service.DeleteDeliveryZone(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "Failed to delete delivery zone")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Delivery zone deleted successfully"})
+}
+
+// CheckDelivery handles POST /api/v1/delivery-zones/check
+// @Summary Check delivery address
+// @Description Finds the active delivery zone of an address, given by the coordinates it was geocoded to, and returns its delivery fee and minimum order. With order_total, also reports whether the order reaches the minimum. Where zones overlap, the one with the lowest fee applies. Fails with 422 outside every zone.
+// @Tags Delivery Zones
+// @Accept json
+// @Produce json
+// @Param location body services.DeliveryCheckRequest true "Delivery location"
+// @Success 200 {object} SuccessResponse{data=services.DeliveryCheckResponse} "Address is deliverable"
+// @Failure 400 {object} ErrorResponse "Invalid coordinates or order total"
+// @Failure 422 {object} ErrorResponse "Address is outside the delivery zones"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/delivery-zones/check [post]
+func (h *DeliveryZoneHandlers) CheckDelivery(w http.ResponseWriter, r *http.Request) {
+	var req services.DeliveryCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	check, err := h.service.CheckDelivery(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to check delivery address")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: check, Message: "Address is deliverable"})
+}
+
+// writeServiceError maps a delivery zone service error to its status code
+func (h *DeliveryZoneHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrDeliveryZoneNotFound):
+		writeError(w, r, http.StatusNotFound, "Delivery zone not found")
+	case errors.Is(err, services.ErrInvalidDeliveryZone), errors.Is(err, services.ErrInvalidDeliveryCheck):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrDeliveryZoneExists):
+		writeError(w, r, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrNotDeliverable):
+		writeError(w, r, http.StatusUnprocessableEntity, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// DriverHandlers contains HTTP handlers for drivers and the delivery of orders
+type DriverHandlers struct {
+	service services.DriverService
+}
+
+// NewDriverHandlers creates a new driver handlers instance
+func NewDriverHandlers(service services.DriverService) *DriverHandlers {
+	return &DriverHandlers{service: service}
+}
+
+// GetDrivers handles GET /api/v1/drivers
+// @Summary List drivers
+// @Description Retrieves the delivery drivers by name
+// @Tags Drivers
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=[]services.DriverResponse} "Drivers retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/drivers [get]
+func (h *DriverHandlers) GetDrivers(w http.ResponseWriter, r *http.Request) {
+	drivers, err := h.service.ListDrivers(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to list drivers", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to list drivers")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: drivers, Message: "Drivers retrieved successfully"})
+}
+
+// CreateDriver handles POST /api/v1/drivers
+// @Summary Create driver
+// @Description Creates a delivery driver
+// @Tags Drivers
+// @Accept json
+// @Produce json
+// @Param driver body services.DriverRequest true "Driver"
+// @Success 201 {object} SuccessResponse{data=services.DriverResponse} "Driver created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid driver"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/drivers [post]
+func (h *DriverHandlers) CreateDriver(w http.ResponseWriter, r *http.Request) {
+	var req services.DriverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	driver, err := h.service.CreateDriver(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create driver")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: driver, Message: "Driver created successfully"})
+}
+
+// UpdateDriver handles PUT /api/v1/drivers/{id}
+// @Summary Update driver
+// @Description Replaces a delivery driver. Orders already assigned to a driver made inactive stay with them.
+// @Tags Drivers
+// @Accept json
+// @Produce json
+// @Param id path int true "Driver ID"
+// @Param driver body services.DriverRequest true "Driver"
+// @Success 200 {object} SuccessResponse{data=services.DriverResponse} "Driver updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid driver"
+// @Failure 404 {object} ErrorResponse "Driver not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/drivers/{id} [put]
+func (h *DriverHandlers) UpdateDriver(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid driver ID")
+		return
+	}
+	var req services.DriverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	driver, err := h.service.UpdateDriver(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update driver")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: driver, Message: "Driver updated successfully"})
+}
+
+// DeleteDriver handles DELETE /api/v1/drivers/{id}
+// @Summary Delete driver
+// @Description Deletes a delivery driver with no orders left to deliver. Their past deliveries keep no driver.
+// @Tags Drivers
+// @Produce json
+// @Param id path int true "Driver ID"
+// @Success 200 {object} SuccessResponse "Driver deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid driver ID"
+// @Failure 404 {object} ErrorResponse "Driver not found"
+// @Failure 409 {object} ErrorResponse "Driver has orders to deliver"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/drivers/{id} [delete]
+func (h *DriverHandlers) DeleteDriver(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid driver ID")
+		return
+	}
+
+	if err := h.service.DeleteDriver(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "Failed to delete driver")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Driver deleted successfully"})
+}
+
+// GetDriverOrders handles GET /api/v1/drivers/{id}/orders
+// @Summary List driver orders
+// @Description Retrieves the orders a driver has yet to deliver, in the order they were assigned
+// @Tags Drivers
+// @Produce json,xml,application/msgpack
+// @Param id path int true "Driver ID"
+// @Success 200 {object} SuccessResponse{data=[]services.OrderResponse} "Driver orders retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid driver ID"
+// @Failure 404 {object} ErrorResponse "Driver not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/drivers/{id}/orders [get]
+func (h *DriverHandlers) GetDriverOrders(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid driver ID")
+		return
+	}
+
+	orders, err := h.service.GetDriverOrders(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to list driver orders")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: orders, Message: "Driver orders retrieved successfully"})
+}
+
+// AssignDriver handles PUT /api/v1/orders/{id}/driver
+// @Summary Assign order to driver
+// @Description Gives an open delivery order to an active driver, or to another driver until it is picked up. Publishes order.delivery_updated.
+// @Tags Drivers
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param assignment body services.AssignDriverRequest true "Driver"
+// @Success 200 {object} SuccessResponse{data=services.OrderResponse} "Driver assigned successfully"
+// @Failure 400 {object} ErrorResponse "Not a delivery order, or unknown or inactive driver"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 409 {object} ErrorResponse "Order already picked up or closed"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/orders/{id}/driver [put]
+func (h *DriverHandlers) AssignDriver(w http.ResponseWriter, r *http.Request) {
+	var req services.AssignDriverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	order, err := h.service.AssignDriver(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to assign driver")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: order, Message: "Driver assigned successfully"})
+}
+
+// UpdateDeliveryStatus handles PUT /api/v1/orders/{id}/delivery-status
+// @Summary Update delivery status
+// @Description Moves a delivery order from assigned to picked_up, or from picked_up to delivered, which completes the order. Publishes order.delivery_updated.
+// @Tags Drivers
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param status body services.DeliveryStatusRequest true "Delivery status"
+// @Success 200 {object} SuccessResponse{data=services.OrderResponse} "Delivery status updated successfully"
+// @Failure 400 {object} ErrorResponse "Not a delivery order, or unknown status"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 409 {object} ErrorResponse "Order is not at the previous step or is closed"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/orders/{id}/delivery-status [put]
+func (h *DriverHandlers) UpdateDeliveryStatus(w http.ResponseWriter, r *http.Request) {
+	var req services.DeliveryStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	order, err := h.service.UpdateDeliveryStatus(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update delivery status")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: order, Message: "Delivery status updated successfully"})
+}
+
+// writeServiceError maps a driver service error to its status code
+func (h *DriverHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrDriverNotFound):
+		writeError(w, r, http.StatusNotFound, "Driver not found")
+	case errors.Is(err, services.ErrOrderNotFound):
+		writeError(w, r, http.StatusNotFound, "Order not found")
+	case errors.Is(err, services.ErrInvalidDriver), errors.Is(err, services.ErrInvalidDelivery):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrDriverBusy), errors.Is(err, services.ErrDeliveryStatus):
+		writeError(w, r, http.StatusConflict, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// Encoder writes a response body in one media type
+type Encoder interface {
+	ContentType() string
+	Encode(w io.Writer, v interface{}) error
+}
+
+// encoders maps accepted media types to their encoder. JSON is the default.
+var encoders = map[string]Encoder{
+	"application/json":        jsonEncoder{},
+	"application/xml":         xmlEncoder{},
+	"text/xml":                xmlEncoder{},
+	"application/msgpack":     msgpackEncoder{},
+	"application/x-msgpack":   msgpackEncoder{},
+	"application/vnd.msgpack": msgpackEncoder{},
+}
+
+// RegisterEncoder makes responses of read endpoints available in another media type.
+// It must be called before the server starts.
+func RegisterEncoder(mediaType string, enc Encoder) {
+	encoders[mediaType] = enc
+}
+
+// writeResponse encodes v in the format negotiated from the Accept header, falling
+// back to JSON when the client accepts none of the registered media types, with the
+// field naming convention requested by the client
+func writeResponse(w http.ResponseWriter, r *http.Request, statusCode int, v interface{}) {
+	enc := negotiateEncoder(r.Header.Get("Accept"))
+	v, err := withResponseCase(w, r, v)
+
+	var buf bytes.Buffer
+	if err == nil {
+		err = enc.Encode(&buf, v)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to encode response",
+			slog.String("content_type", enc.ContentType()),
+			slog.String("error", err.Error()))
+		writeError(w, r, http.StatusInternalServerError, "Failed to encode response")
+		return
+	}
+
+	w.Header().Set("Content-Type", enc.ContentType())
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logging.FromContext(r.Context()).Error("Failed to write response body", slog.String("error", err.Error()))
+	}
+}
+
+// negotiateEncoder picks the registered encoder with the highest quality in an Accept
+// header. A wildcard counts as JSON, and browsers (which list text/html and rank XML
+// above */*) always get JSON.
+func negotiateEncoder(accept string) Encoder {
+	type candidate struct {
+		enc Encoder
+		q   float64
+	}
+	var candidates []candidate
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if mediaType == "text/html" {
+			return jsonEncoder{}
+		}
+		enc, ok := encoders[mediaType]
+		if mediaType == "*/*" {
+			enc, ok = jsonEncoder{}, true
+		}
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{enc: enc, q: q})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return jsonEncoder{}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].enc
+}
+
+// genericValue converts v to maps, slices and scalars via its JSON form, so other
+// formats use the same field names and value formats as JSON (e.g. decimal prices as strings)
+func genericValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var generic interface{}
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// jsonEncoder writes JSON, the default format
+type jsonEncoder struct{}
+
+func (jsonEncoder) ContentType() string { return "application/json" }
+
+func (jsonEncoder) Encode(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// xmlEncoder writes the JSON structure as XML: objects become elements named after
+// their keys and array entries become <item> elements, inside a <response> root
+type xmlEncoder struct{}
+
+func (xmlEncoder) ContentType() string { return "application/xml; charset=utf-8" }
+
+func (xmlEncoder) Encode(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	enc := xml.NewEncoder(w)
+	if err := writeXMLValue(enc, dec, "response"); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+// writeXMLValue reads the next JSON value from dec and writes it as an element named
+// name, keeping the key order of JSON objects
+func writeXMLValue(enc *xml.Encoder, dec *json.Decoder, name string) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	switch t := token.(type) {
+	case json.Delim:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for dec.More() {
+			child := "item"
+			if t == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				child = xmlName(key.(string))
+			}
+			if err := writeXMLValue(enc, dec, child); err != nil {
+				return err
+			}
+		}
+		// Consume the closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		return enc.EncodeToken(start.End())
+	case nil:
+		start.Attr = []xml.Attr{{Name: xml.Name{Local: "nil"}, Value: "true"}}
+		return enc.EncodeElement("", start)
+	default:
+		return enc.EncodeElement(fmt.Sprint(t), start)
+	}
+}
+
+// xmlName turns a JSON key into a valid XML element name
+func xmlName(key string) string {
+	var b strings.Builder
+	for i, c := range key {
+		switch {
+		case c == '_' || c == '-' || c == '.' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z'):
+			b.WriteRune(c)
+		case '0' <= c && c <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(c)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "item"
+	}
+	return b.String()
+}
+
+// msgpackEncoder writes MessagePack
+type msgpackEncoder struct{}
+
+func (msgpackEncoder) ContentType() string { return "application/msgpack" }
+
+func (msgpackEncoder) Encode(w io.Writer, v interface{}) error {
+	generic, err := genericValue(v)
+	if err != nil {
+		return err
+	}
+	enc := msgpack.NewEncoder(w)
+	enc.SetSortMapKeys(true)
+	return enc.Encode(msgpackValue(generic))
+}
+
+// msgpackValue replaces JSON numbers with integers or floats so they are encoded as
+// MessagePack numbers rather than strings
+func msgpackValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		if f, err := t.Float64(); err == nil {
+			return f
+		}
+		return t.String()
+	case map[string]interface{}:
+		for key, value := range t {
+			t[key] = msgpackValue(value)
+		}
+		return t
+	case []interface{}:
+		for i, value := range t {
+			t[i] = msgpackValue(value)
+		}
+		return t
+	}
+	return v
+}
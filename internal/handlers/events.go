@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/realtime"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// sseHeartbeatInterval keeps idle streams alive through proxies and load balancers
+const sseHeartbeatInterval = 15 * time.Second
+
+// EventsHandler handles GET /events
+// @Summary Real-time event stream
+// @Description Server-Sent Events stream of menu changes (menu_item.created, menu_item.updated, menu_item.deleted, menu_item.restored, menu_item.purged), new orders (order.created), their kitchen tickets (ticket.created, one per station, and ticket.prepared), ready estimates (order.eta_updated), deliveries (order.delivery_updated) and table statuses (table.status_updated). Streams only receive the events of the restaurant of the request; browsers' EventSource, which can't set the X-Restaurant-ID header, passes ?restaurant_id= instead. Kitchen displays pass ?station= to receive only their station's tickets. A server.shutdown event is sent before the server restarts; clients should reconnect.
+// @Tags Events
+// @Produce text/event-stream
+// @Param station query string false "Kitchen station whose tickets to receive, e.g. grill; every event when omitted"
+// @Param restaurant_id query int false "Restaurant whose events to receive when the X-Restaurant-ID header can't be set"
+// @Success 200 {object} realtime.Event "Event stream"
+// @Failure 400 {object} ErrorResponse "Unknown station"
+// @Failure 503 {object} ErrorResponse "Server is shutting down"
+// @Router /api/v1/events [get]
+func EventsHandler(hub *realtime.Hub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context())
+		restaurant := database.RestaurantID(r.Context())
+
+		station := r.URL.Query().Get("station")
+		if station != "" && !services.ValidStation(station) {
+			writeError(w, r, http.StatusBadRequest, "Unknown station")
+			return
+		}
+
+		sub, err := hub.Subscribe()
+		if err != nil {
+			writeError(w, r, http.StatusServiceUnavailable, "Server is shutting down, retry shortly")
+			return
+		}
+		defer sub.Close()
+
+		// Streams outlive the server's WriteTimeout
+		rc := http.NewResponseController(w)
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+			logger.Debug("Could not clear write deadline for event stream", slog.String("error", err.Error()))
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		// Ask clients to reconnect quickly after the stream ends
+		fmt.Fprint(w, "retry: 3000\n\n")
+		if err := rc.Flush(); err != nil {
+			logger.Error("Event stream does not support flushing", slog.String("error", err.Error()))
+			return
+		}
+
+		heartbeat := time.NewTicker(sseHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+			case event, ok := <-sub.Events():
+				if !ok {
+					return
+				}
+				if event.Restaurant != 0 && event.Restaurant != restaurant {
+					continue
+				}
+				// Station streams only get their own tickets, and the shutdown notice
+				if station != "" && event.Station != station && event.Type != realtime.EventShutdown {
+					continue
+				}
+				data, err := json.Marshal(event.Data)
+				if err != nil {
+					logger.Error("Failed to encode event", slog.String("type", event.Type), slog.String("error", err.Error()))
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			}
+
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// ExportHandlers contains HTTP handlers for bulk data exports
+type ExportHandlers struct {
+	exporter *services.MenuItemExporter
+}
+
+// NewExportHandlers creates a new export handlers instance
+func NewExportHandlers(exporter *services.MenuItemExporter) *ExportHandlers {
+	return &ExportHandlers{exporter: exporter}
+}
+
+// ExportMenuItemsNDJSON handles GET /api/v1/items/export.ndjson
+// @Summary Export menu items as NDJSON
+// @Description Streams all non-deleted menu items as newline-delimited JSON, one item per line, ordered by ID. Rows are read from a database cursor, so large exports are not buffered in memory. An error after streaming has started ends the response early.
+// @Tags Menu Items
+// @Produce application/x-ndjson
+// @Success 200 {object} services.MenuItemResponse "One menu item per line"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/items/export.ndjson [get]
+func (h *ExportHandlers) ExportMenuItemsNDJSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", `attachment; filename="menu_items.ndjson"`)
+
+	rc := http.NewResponseController(w)
+	rows, err := h.exporter.ExportNDJSON(r.Context(), w, func() {
+		if err := rc.Flush(); err != nil {
+			logging.FromContext(r.Context()).Debug("Could not flush export", slog.String("error", err.Error()))
+		}
+	})
+	if err != nil {
+		if rows == 0 {
+			// Nothing has been sent yet, so the client can still get a proper error
+			w.Header().Del("Content-Disposition")
+			logging.FromContext(r.Context()).Error("Failed to export menu items", slog.String("error", err.Error()))
+			writeError(w, r, serviceErrorStatus(err), err.Error())
+			return
+		}
+		// Headers are already sent; the truncated body is all the client will see
+		logging.FromContext(r.Context()).Error("Menu item export aborted",
+			slog.Int("rows", rows),
+			slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// ExportJobHandlers contains HTTP handlers for asynchronous export jobs
+type ExportJobHandlers struct {
+	jobs *services.ExportJobs
+}
+
+// NewExportJobHandlers creates a new export job handlers instance
+func NewExportJobHandlers(jobs *services.ExportJobs) *ExportJobHandlers {
+	return &ExportJobHandlers{jobs: jobs}
+}
+
+// CreateExport handles POST /api/v1/exports
+// @Summary Start an export
+// @Description Starts exporting all menu items in the background and returns the job. Poll GET /exports/{id} until its status is "completed", then download the file from GET /exports/{id}/download. Finished exports are kept for 24 hours.
+// @Tags Exports
+// @Accept json
+// @Produce json
+// @Param export body services.CreateExportRequest false "Export options (format defaults to ndjson)"
+// @Success 202 {object} SuccessResponse{data=services.ExportJob} "Export started"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 429 {object} ErrorResponse "Too many exports in progress"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/exports [post]
+func (h *ExportJobHandlers) CreateExport(w http.ResponseWriter, r *http.Request) {
+	var req services.CreateExportRequest
+
+	// The body is optional
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	job, err := h.jobs.Enqueue(r.Context(), req)
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("Export rejected", slog.String("error", err.Error()))
+		writeError(w, r, exportErrorStatus(err), err.Error())
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("/api/v1/exports/%s", job.ID))
+	writeJSON(w, r, http.StatusAccepted, SuccessResponse{
+		Data:    job,
+		Message: "Export started",
+	})
+}
+
+// GetExport handles GET /api/v1/exports/{id}
+// @Summary Get export status
+// @Description Reports the status of an export: pending (also while waiting for a retry), running, completed or failed
+// @Tags Exports
+// @Produce json
+// @Param id path string true "Export ID"
+// @Success 200 {object} SuccessResponse{data=services.ExportJob} "Export retrieved successfully"
+// @Failure 404 {object} ErrorResponse "Export not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/exports/{id} [get]
+func (h *ExportJobHandlers) GetExport(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobs.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		if status := exportErrorStatus(err); status == http.StatusInternalServerError {
+			logging.FromContext(r.Context()).Error("Failed to load export", slog.String("error", err.Error()))
+			writeError(w, r, status, "Failed to load export")
+		} else {
+			writeError(w, r, status, err.Error())
+		}
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{
+		Data:    job,
+		Message: "Export retrieved successfully",
+	})
+}
+
+// DownloadExport handles GET /api/v1/exports/{id}/download
+// @Summary Download an export
+// @Description Downloads the file of a completed export. Range requests are supported.
+// @Tags Exports
+// @Produce application/x-ndjson
+// @Param id path string true "Export ID"
+// @Success 200 {file} file "Export file"
+// @Failure 404 {object} ErrorResponse "Export not found"
+// @Failure 409 {object} ErrorResponse "Export is not completed"
+// @Router /api/v1/exports/{id}/download [get]
+func (h *ExportJobHandlers) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	file, job, err := h.jobs.Open(r.Context(), r.PathValue("id"))
+	if err != nil {
+		if status := exportErrorStatus(err); status == http.StatusInternalServerError {
+			logging.FromContext(r.Context()).Error("Failed to open export", slog.String("error", err.Error()))
+			writeError(w, r, status, "Failed to open export")
+		} else {
+			writeError(w, r, status, err.Error())
+		}
+		return
+	}
+	defer file.Close()
+
+	name := fmt.Sprintf("menu_items-%s.%s", job.ID, job.Format)
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	http.ServeContent(w, r, name, file.ModTime(), file)
+}
+
+// exportErrorStatus maps export job errors to HTTP status codes
+func exportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, services.ErrInvalidExportFormat):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrExportNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrExportNotReady):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrTooManyExports):
+		return http.StatusTooManyRequests
+	}
+	return serviceErrorStatus(err)
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/featureflags"
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// FeatureFlagsHandler handles GET /admin/feature-flags
+// @Summary Feature flags
+// @Description Lists the feature flags by name, with their FEATURE_FLAGS override and whether they are active in this instance's environment. Flags limited to tenants are active only for requests whose X-Tenant-ID header names one of them.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} SuccessResponse{data=[]featureflags.Status} "Feature flags"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/feature-flags [get]
+func FeatureFlagsHandler(flags *featureflags.Flags) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		statuses, err := flags.List(r.Context())
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to list feature flags", slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to list feature flags")
+			return
+		}
+
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    statuses,
+			Message: "Feature flags retrieved successfully",
+		})
+	}
+}
+
+// SetFeatureFlagHandler handles PUT /admin/feature-flags/{name}
+// @Summary Set a feature flag
+// @Description Creates or replaces a feature flag. It is on while enabled, in the listed environments (development, staging, production, test) and for the listed tenants; empty lists mean all of them. Other instances pick the change up within 30 seconds.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param name path string true "Flag name (lowercase letters, digits, '.', '_' or '-')"
+// @Param flag body featureflags.SetRequest true "Flag"
+// @Success 200 {object} SuccessResponse{data=featureflags.Flag} "Feature flag updated"
+// @Failure 400 {object} ErrorResponse "Invalid feature flag"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/feature-flags/{name} [put]
+func SetFeatureFlagHandler(flags *featureflags.Flags) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req featureflags.SetRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			status, message := requestBodyError(err, "Invalid JSON format")
+			writeError(w, r, status, message)
+			return
+		}
+
+		name := r.PathValue("name")
+		flag, err := flags.Set(r.Context(), name, req)
+		if errors.Is(err, featureflags.ErrInvalidFlag) {
+			writeError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to set feature flag", slog.String("flag", name), slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to set feature flag")
+			return
+		}
+
+		logging.FromContext(r.Context()).Info("Feature flag set",
+			slog.String("flag", flag.Name),
+			slog.Bool("enabled", flag.Enabled),
+			slog.Any("environments", flag.Environments),
+			slog.Any("tenants", flag.Tenants))
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    flag,
+			Message: "Feature flag updated successfully",
+		})
+	}
+}
+
+// DeleteFeatureFlagHandler handles DELETE /admin/feature-flags/{name}
+// @Summary Delete a feature flag
+// @Description Removes a feature flag, which turns it off unless FEATURE_FLAGS forces it on
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Param name path string true "Flag name"
+// @Success 200 {object} SuccessResponse "Feature flag deleted"
+// @Failure 404 {object} ErrorResponse "Feature flag not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/feature-flags/{name} [delete]
+func DeleteFeatureFlagHandler(flags *featureflags.Flags) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		err := flags.Delete(r.Context(), name)
+		if errors.Is(err, featureflags.ErrNotFound) {
+			writeError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to delete feature flag", slog.String("flag", name), slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to delete feature flag")
+			return
+		}
+
+		logging.FromContext(r.Context()).Info("Feature flag deleted", slog.String("flag", name))
+		writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Feature flag deleted successfully"})
+	}
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// menuItemFields are the menu item fields that can be selected with ?fields=
+var menuItemFields = jsonFieldNames(services.MenuItemResponse{})
+
+// jsonFieldNames lists the JSON field names of a struct
+func jsonFieldNames(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseFields reads the sparse fieldset requested with ?fields=id,name,price,
+// rejecting fields that aren't in allowed. No fields means all of them.
+func parseFields(r *http.Request, allowed []string) ([]string, error) {
+	fields := parseListParam(r, "fields")
+	for _, field := range fields {
+		if !slices.Contains(allowed, field) {
+			return nil, fmt.Errorf("unknown field %q, must be one of: %s", field, strings.Join(allowed, ", "))
+		}
+	}
+	return fields, nil
+}
+
+// selectFields trims v, a struct or a slice of structs, to the given JSON fields so
+// clients only receive what they render. With no fields v is returned unchanged.
+func selectFields(v interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return v, nil
+	}
+
+	generic, err := genericValue(v)
+	if err != nil {
+		return nil, err
+	}
+	trim := func(value interface{}) interface{} {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		trimmed := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			if fieldValue, ok := object[field]; ok {
+				trimmed[field] = fieldValue
+			}
+		}
+		return trimmed
+	}
+
+	if list, ok := generic.([]interface{}); ok {
+		for i := range list {
+			list[i] = trim(list[i])
+		}
+		return list, nil
+	}
+	return trim(generic), nil
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// FloorPlanHandlers contains HTTP handlers for the floor plan
+type FloorPlanHandlers struct {
+	service services.FloorPlanService
+}
+
+// NewFloorPlanHandlers creates a new floor plan handlers instance
+func NewFloorPlanHandlers(service services.FloorPlanService) *FloorPlanHandlers {
+	return &FloorPlanHandlers{service: service}
+}
+
+// GetFloorPlan handles GET /api/v1/floor-plan
+// @Summary Get floor plan
+// @Description Retrieves the restaurant's seating map: its sections and where each table stands, with the table's current name. Tables deleted since the plan was saved are left out.
+// @Tags Tables
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=services.FloorPlanResponse} "Floor plan retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/floor-plan [get]
+func (h *FloorPlanHandlers) GetFloorPlan(w http.ResponseWriter, r *http.Request) {
+	plan, err := h.service.GetFloorPlan(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to get floor plan", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to get floor plan")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: plan, Message: "Floor plan retrieved successfully"})
+}
+
+// UpdateFloorPlan handles PUT /api/v1/floor-plan
+// @Summary Update floor plan
+// @Description Replaces the floor plan. Each table is placed at most once, within the plan, and in one of its sections when it has one. Pass the version the changes were made from to fail with 409 if someone else saved the plan since.
+// @Tags Tables
+// @Accept json
+// @Produce json
+// @Param plan body services.FloorPlanRequest true "Floor plan"
+// @Success 200 {object} SuccessResponse{data=services.FloorPlanResponse} "Floor plan updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid floor plan"
+// @Failure 409 {object} ErrorResponse "Floor plan was saved since the version"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/floor-plan [put]
+func (h *FloorPlanHandlers) UpdateFloorPlan(w http.ResponseWriter, r *http.Request) {
+	var req services.FloorPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	plan, err := h.service.UpdateFloorPlan(r.Context(), req)
+	switch {
+	case errors.Is(err, services.ErrInvalidFloorPlan):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrFloorPlanConflict):
+		writeError(w, r, http.StatusConflict, err.Error())
+	case err != nil:
+		logging.FromContext(r.Context()).Error("Failed to update floor plan", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to update floor plan")
+	default:
+		writeJSON(w, r, http.StatusOK, SuccessResponse{Data: plan, Message: "Floor plan updated successfully"})
+	}
+}
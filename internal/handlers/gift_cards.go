@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// GiftCardHandlers contains HTTP handlers for gift card operations
+type GiftCardHandlers struct {
+	service services.GiftCardService
+}
+
+// NewGiftCardHandlers creates a new gift card handlers instance
+func NewGiftCardHandlers(service services.GiftCardService) *GiftCardHandlers {
+	return &GiftCardHandlers{service: service}
+}
+
+// IssueGiftCard handles POST /api/v1/gift-cards
+// @Summary Issue gift card
+// @Description Issues a gift card loaded with an amount. Without a code, a random code such as K7QM-4XPB-9TRC-HW2D is generated.
+// @Tags Gift Cards
+// @Accept json
+// @Produce json
+// @Param giftCard body services.IssueGiftCardRequest true "Gift card"
+// @Success 201 {object} SuccessResponse{data=services.GiftCardResponse} "Gift card issued successfully"
+// @Failure 400 {object} ErrorResponse "Invalid gift card"
+// @Failure 409 {object} ErrorResponse "The code is already taken"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/gift-cards [post]
+func (h *GiftCardHandlers) IssueGiftCard(w http.ResponseWriter, r *http.Request) {
+	var req services.IssueGiftCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	card, err := h.service.IssueGiftCard(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to issue gift card")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: card, Message: "Gift card issued successfully"})
+}
+
+// GetGiftCard handles GET /api/v1/gift-cards/{code}
+// @Summary Check gift card balance
+// @Description Retrieves a gift card by its code, case-insensitively, with its balance and transactions, newest first
+// @Tags Gift Cards
+// @Produce json,xml,application/msgpack
+// @Param code path string true "Gift card code"
+// @Success 200 {object} SuccessResponse{data=services.GiftCardResponse} "Gift card retrieved successfully"
+// @Failure 404 {object} ErrorResponse "Gift card not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/gift-cards/{code} [get]
+func (h *GiftCardHandlers) GetGiftCard(w http.ResponseWriter, r *http.Request) {
+	card, err := h.service.GetGiftCard(r.Context(), r.PathValue("code"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to get gift card")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: card, Message: "Gift card retrieved successfully"})
+}
+
+// RedeemGiftCard handles POST /api/v1/gift-cards/{code}/redeem
+// @Summary Redeem gift card
+// @Description Takes an amount off a gift card's balance. With an order_id, the amount pays part or all of the order, adding to its amount_paid; the amount then defaults to as much of the order's amount_due as the balance covers.
+// @Tags Gift Cards
+// @Accept json
+// @Produce json
+// @Param code path string true "Gift card code"
+// @Param redemption body services.RedeemGiftCardRequest true "Redemption"
+// @Success 200 {object} SuccessResponse{data=services.GiftCardRedemptionResponse} "Gift card redeemed successfully"
+// @Failure 400 {object} ErrorResponse "Invalid amount"
+// @Failure 404 {object} ErrorResponse "Gift card or order not found"
+// @Failure 422 {object} ErrorResponse "The gift card is inactive, expired or its balance too low, or the order is cancelled or has less left to pay"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/gift-cards/{code}/redeem [post]
+func (h *GiftCardHandlers) RedeemGiftCard(w http.ResponseWriter, r *http.Request) {
+	var req services.RedeemGiftCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	redemption, err := h.service.RedeemGiftCard(r.Context(), r.PathValue("code"), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to redeem gift card")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: redemption, Message: "Gift card redeemed successfully"})
+}
+
+// writeServiceError maps a gift card service error to its status code
+func (h *GiftCardHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrGiftCardNotFound):
+		writeError(w, r, http.StatusNotFound, "Gift card not found")
+	case errors.Is(err, services.ErrOrderNotFound):
+		writeError(w, r, http.StatusNotFound, "Order not found")
+	case errors.Is(err, services.ErrInvalidGiftCard):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrGiftCardExists):
+		writeError(w, r, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrGiftCardNotRedeemable):
+		writeError(w, r, http.StatusUnprocessableEntity, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/Zughayyar/agora-server/internal/graph"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// graphQLComplexityLimit bounds the number of fields a single query may resolve
+const graphQLComplexityLimit = 1000
+
+// GraphQLRequest is a GraphQL query with its variables
+type GraphQLRequest struct {
+	Query         string         `json:"query" example:"{ menuItems(limit: 10) { items { id name price } } }"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// GraphQLHandler serves GraphQL queries over GET and POST, resolved by the same
+// services as the REST API. Queries are limited to graphQLComplexityLimit.
+// @Summary GraphQL query
+// @Description Runs a read-only GraphQL query over menu items, categories and orders; the schema is in internal/graph/schema.graphqls and can be explored at /graphql/playground. Errors are returned in the "errors" array with status 200.
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Param query body GraphQLRequest true "GraphQL request"
+// @Success 200 {object} object "GraphQL response with data and errors"
+// @Failure 413 {object} ErrorResponse "Request body too large"
+// @Router /graphql [post]
+func GraphQLHandler(items services.MenuItemService, orders services.OrderService) http.HandlerFunc {
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers: &graph.Resolver{ItemService: items, OrderService: orders},
+	}))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.FixedComplexityLimit(graphQLComplexityLimit))
+	srv.SetErrorPresenter(presentGraphQLError)
+	srv.SetRecoverFunc(func(ctx context.Context, err any) error {
+		logging.FromContext(ctx).Error("GraphQL resolver panicked", slog.String("panic", fmt.Sprint(err)))
+		return errors.New("internal server error")
+	})
+	return srv.ServeHTTP
+}
+
+// GraphQLPlaygroundHandler serves the GraphiQL UI for the /graphql endpoint
+func GraphQLPlaygroundHandler(endpoint string) http.HandlerFunc {
+	return playground.Handler("Agora GraphQL", endpoint)
+}
+
+// presentGraphQLError logs resolver failures and tags errors the client can act on
+// with an extension code
+func presentGraphQLError(ctx context.Context, err error) *gqlerror.Error {
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+
+	var code string
+	switch {
+	case errors.Is(err, services.ErrServiceUnavailable):
+		code = "SERVICE_UNAVAILABLE"
+	case errors.Is(err, services.ErrInvalidInclude), errors.Is(err, services.ErrInvalidOrder):
+		code = "BAD_USER_INPUT"
+	}
+	if code != "" {
+		if presented.Extensions == nil {
+			presented.Extensions = map[string]any{}
+		}
+		presented.Extensions["code"] = code
+	}
+
+	var gqlErr *gqlerror.Error
+	if code == "" && !errors.As(err, &gqlErr) {
+		logging.FromContext(ctx).Error("GraphQL resolver failed",
+			slog.String("path", presented.Path.String()),
+			slog.String("error", err.Error()))
+	}
+	return presented
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// GuestHandlers contains the HTTP handlers guests use from a table's QR code. Apart
+// from starting a session, requests carry the session token as a bearer token.
+type GuestHandlers struct {
+	service services.GuestService
+}
+
+// NewGuestHandlers creates a new guest handlers instance
+func NewGuestHandlers(service services.GuestService) *GuestHandlers {
+	return &GuestHandlers{service: service}
+}
+
+// StartGuestSessionRequest starts a guest session with the token of a table's QR code
+type StartGuestSessionRequest struct {
+	TableToken string `json:"table_token" example:"9f86d081884c7d659a2feaa0c55ad015"`
+}
+
+// StartGuestSession handles POST /api/v1/guest/sessions
+// @Summary Start guest session
+// @Description Starts a guest session at the table whose QR code carries table_token, as when a guest scans it. The session's token, sent as a bearer token, lets the guest browse the menu and order for the table until it expires (GUEST_SESSION_MINUTES).
+// @Tags Guest Ordering
+// @Accept json
+// @Produce json
+// @Param session body StartGuestSessionRequest true "Table token"
+// @Success 201 {object} SuccessResponse{data=services.GuestSessionResponse} "Guest session started successfully"
+// @Failure 400 {object} ErrorResponse "Invalid JSON format"
+// @Failure 404 {object} ErrorResponse "No table has the token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/guest/sessions [post]
+func (h *GuestHandlers) StartGuestSession(w http.ResponseWriter, r *http.Request) {
+	var req StartGuestSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	session, err := h.service.StartSession(r.Context(), req.TableToken)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to start guest session")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: session, Message: "Guest session started successfully"})
+}
+
+// GetGuestSession handles GET /api/v1/guest/session
+// @Summary Get guest session
+// @Description Retrieves the guest session of the bearer token: its table and when it expires
+// @Tags Guest Ordering
+// @Produce json
+// @Param Authorization header string true "Bearer session token"
+// @Success 200 {object} SuccessResponse{data=services.GuestSessionResponse} "Guest session retrieved successfully"
+// @Failure 401 {object} ErrorResponse "Missing, unknown or expired session token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/guest/session [get]
+func (h *GuestHandlers) GetGuestSession(w http.ResponseWriter, r *http.Request) {
+	session, err := h.service.GetSession(r.Context(), guestToken(r))
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to get guest session")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: session, Message: "Guest session retrieved successfully"})
+}
+
+// GetGuestMenu handles GET /api/v1/guest/menu
+// @Summary Guest menu
+// @Description Retrieves the menu items guests may order, at their dine-in prices. Items of a running price experiment show the price of the session's variant instead.
+// @Tags Guest Ordering
+// @Produce json
+// @Param Authorization header string true "Bearer session token"
+// @Success 200 {object} SuccessResponse{data=[]services.MenuItemResponse} "Menu retrieved successfully"
+// @Failure 401 {object} ErrorResponse "Missing, unknown or expired session token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/guest/menu [get]
+func (h *GuestHandlers) GetGuestMenu(w http.ResponseWriter, r *http.Request) {
+	menu, err := h.service.GetMenu(r.Context(), guestToken(r))
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to get menu")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: menu, Message: "Menu retrieved successfully"})
+}
+
+// GetGuestOrders handles GET /api/v1/guest/orders
+// @Summary Guest orders
+// @Description Retrieves the orders placed in the guest session, newest first
+// @Tags Guest Ordering
+// @Produce json
+// @Param Authorization header string true "Bearer session token"
+// @Success 200 {object} SuccessResponse{data=[]services.OrderResponse} "Orders retrieved successfully"
+// @Failure 401 {object} ErrorResponse "Missing, unknown or expired session token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/guest/orders [get]
+func (h *GuestHandlers) GetGuestOrders(w http.ResponseWriter, r *http.Request) {
+	orders, err := h.service.ListOrders(r.Context(), guestToken(r))
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to list orders")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: orders, Message: "Orders retrieved successfully"})
+}
+
+// PlaceGuestOrder handles POST /api/v1/guest/orders
+// @Summary Place guest order
+// @Description Places a dine-in order for the session's table. Guests only order available menu items, at their dine-in prices, or the price experiment prices the session was shown. The order is sent to the kitchen like any other.
+// @Tags Guest Ordering
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer session token"
+// @Param order body services.GuestOrderRequest true "Order"
+// @Success 201 {object} SuccessResponse{data=services.OrderResponse} "Order placed successfully"
+// @Failure 400 {object} ErrorResponse "Invalid order"
+// @Failure 401 {object} ErrorResponse "Missing, unknown or expired session token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/guest/orders [post]
+func (h *GuestHandlers) PlaceGuestOrder(w http.ResponseWriter, r *http.Request) {
+	var req services.GuestOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	order, err := h.service.PlaceOrder(r.Context(), guestToken(r), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to place order")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: order, Message: "Order placed successfully"})
+}
+
+// guestToken returns the session token of a guest request, empty when it has none
+func guestToken(r *http.Request) string {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return strings.TrimSpace(token)
+}
+
+// writeServiceError maps a guest service error to its status code
+func (h *GuestHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrGuestSessionNotFound), errors.Is(err, services.ErrGuestSessionExpired):
+		w.Header().Set("WWW-Authenticate", `Bearer realm="guest"`)
+		writeError(w, r, http.StatusUnauthorized, err.Error())
+	case errors.Is(err, services.ErrTableNotFound):
+		writeError(w, r, http.StatusNotFound, "Table not found")
+	case errors.Is(err, services.ErrInvalidOrder):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
@@ -1,14 +1,12 @@
 package handlers
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/version"
 
 	"github.com/uptrace/bun"
 )
@@ -17,6 +15,7 @@ import (
 type HealthResponse struct {
 	Service   string               `json:"service"`
 	Status    string               `json:"status"`
+	Version   version.Info         `json:"version"`
 	Timestamp time.Time            `json:"timestamp"`
 	Database  DatabaseHealthStatus `json:"database"`
 }
@@ -39,23 +38,14 @@ func HealthHandler(w http.ResponseWriter, r *http.Request) {
 	response := HealthResponse{
 		Service:   "agora-server",
 		Status:    "healthy",
+		Version:   version.Get(),
 		Timestamp: time.Now(),
 		Database: DatabaseHealthStatus{
 			Status: "healthy",
 		},
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(response); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusOK)
-	if _, err := w.Write(buf.Bytes()); err != nil {
-		slog.Error("Failed to write response body", slog.String("error", err.Error()))
-	}
+	writeJSON(w, r, http.StatusOK, response)
 }
 
 // HealthHandlerWithDB handles health check with database connectivity check
@@ -68,48 +58,39 @@ func HealthHandler(w http.ResponseWriter, r *http.Request) {
 // @Router /api/v1/health [get]
 func HealthHandlerWithDB(db *bun.DB) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		response := HealthResponse{
-			Service:   "agora-server",
-			Status:    "healthy",
-			Timestamp: time.Now(),
-		}
-
-		// Check database health
-		start := time.Now()
-		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
-		defer cancel()
-
-		if err := database.HealthCheck(ctx, db); err != nil {
-			response.Database = DatabaseHealthStatus{
-				Status:       "unhealthy",
-				ResponseTime: time.Since(start).Milliseconds(),
-				Error:        err.Error(),
-			}
-			response.Status = "degraded" // Overall service is degraded if DB is down
-		} else {
-			response.Database = DatabaseHealthStatus{
-				Status:       "healthy",
-				ResponseTime: time.Since(start).Milliseconds(),
-			}
-		}
+		response, statusCode := checkHealth(r.Context(), db)
+		writeJSON(w, r, statusCode, response)
+	}
+}
 
-		w.Header().Set("Content-Type", "application/json")
+// checkHealth checks database connectivity and returns the health report with its
+// status code: 200, or 503 when the service is degraded
+func checkHealth(ctx context.Context, db *bun.DB) (HealthResponse, int) {
+	response := HealthResponse{
+		Service:   "agora-server",
+		Status:    "healthy",
+		Version:   version.Get(),
+		Timestamp: time.Now(),
+	}
 
-		// Set appropriate HTTP status code
-		statusCode := http.StatusOK
-		if response.Status == "degraded" {
-			statusCode = http.StatusServiceUnavailable
-		}
+	// Check database health
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
 
-		var buf bytes.Buffer
-		if err := json.NewEncoder(&buf).Encode(response); err != nil {
-			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
-			return
+	if err := database.HealthCheck(ctx, db); err != nil {
+		response.Database = DatabaseHealthStatus{
+			Status:       "unhealthy",
+			ResponseTime: time.Since(start).Milliseconds(),
+			Error:        err.Error(),
 		}
+		response.Status = "degraded" // Overall service is degraded if DB is down
+		return response, http.StatusServiceUnavailable
+	}
 
-		w.WriteHeader(statusCode)
-		if _, err := w.Write(buf.Bytes()); err != nil {
-			slog.Error("Failed to write response body", slog.String("error", err.Error()))
-		}
+	response.Database = DatabaseHealthStatus{
+		Status:       "healthy",
+		ResponseTime: time.Since(start).Milliseconds(),
 	}
+	return response, http.StatusOK
 }
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// ImportHandlers contains HTTP handlers for bulk data imports
+type ImportHandlers struct {
+	importer *services.MenuItemImporter
+}
+
+// NewImportHandlers creates a new import handlers instance
+func NewImportHandlers(importer *services.MenuItemImporter) *ImportHandlers {
+	return &ImportHandlers{importer: importer}
+}
+
+// ImportMenuItems handles POST /api/v1/items/import
+// @Summary Bulk import menu items
+// @Description Imports menu items from a CSV file (columns: name, price, category, and optionally description, is_available) using batched inserts. The import is all-or-nothing.
+// @Tags Menu Items
+// @Accept text/csv
+// @Produce json
+// @Param file body string true "CSV file with a header row"
+// @Success 201 {object} SuccessResponse{data=services.ImportResult} "Menu items imported successfully"
+// @Failure 400 {object} ErrorResponse{details=[]services.ImportRowError} "Invalid CSV file"
+// @Failure 413 {object} ErrorResponse "CSV file too large"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/items/import [post]
+func (h *ImportHandlers) ImportMenuItems(w http.ResponseWriter, r *http.Request) {
+	result, err := h.importer.ImportCSV(r.Context(), r.Body)
+	if err != nil {
+		if status, message := requestBodyError(err, ""); status == http.StatusRequestEntityTooLarge {
+			logging.FromContext(r.Context()).Warn("Rejected oversized menu item import", slog.String("error", err.Error()))
+			writeError(w, r, status, message)
+			return
+		}
+
+		if errors.Is(err, services.ErrInvalidImport) {
+			logging.FromContext(r.Context()).Warn("Rejected invalid menu item import", slog.String("error", err.Error()))
+
+			var details interface{}
+			if result != nil {
+				details = result.Errors
+			}
+			writeJSON(w, r, http.StatusBadRequest, ErrorResponse{
+				Error:   http.StatusText(http.StatusBadRequest),
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+				Details: details,
+			})
+			return
+		}
+
+		logging.FromContext(r.Context()).Error("Failed to import menu items", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), err.Error())
+		return
+	}
+
+	logging.FromContext(r.Context()).Info("Menu items imported",
+		slog.Int("imported", result.Imported),
+		slog.Int("batches", result.Batches),
+		slog.Int64("duration_ms", result.DurationMs))
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{
+		Data:    result,
+		Message: "Menu items imported successfully",
+	})
+}
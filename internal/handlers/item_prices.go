@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// MenuItemPriceHandlers contains HTTP handlers for menu items' channel prices
+type MenuItemPriceHandlers struct {
+	service services.MenuItemPriceService
+}
+
+// NewMenuItemPriceHandlers creates a new channel price handlers instance
+func NewMenuItemPriceHandlers(service services.MenuItemPriceService) *MenuItemPriceHandlers {
+	return &MenuItemPriceHandlers{service: service}
+}
+
+// SetMenuItemPrice handles PUT /api/v1/items/{id}/prices/{channel}
+// @Summary Set channel price
+// @Description Sets the price of a menu item on an order channel (dine_in, takeaway or delivery). Menus read with ?channel= and new orders on the channel use it instead of the item's price.
+// @Tags Menu Items
+// @Accept json
+// @Produce json
+// @Param id path int true "Menu item ID"
+// @Param channel path string true "Order channel" Enums(dine_in, takeaway, delivery)
+// @Param price body services.MenuItemPriceRequest true "Channel price"
+// @Success 200 {object} SuccessResponse{data=services.MenuItemResponse} "Price set successfully"
+// @Failure 400 {object} ErrorResponse "Invalid channel or price"
+// @Failure 404 {object} ErrorResponse "Menu item not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/items/{id}/prices/{channel} [put]
+func (h *MenuItemPriceHandlers) SetMenuItemPrice(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid menu item ID")
+		return
+	}
+	var req services.MenuItemPriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	item, err := h.service.SetPrice(r.Context(), id, r.PathValue("channel"), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to set price")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: item, Message: "Price set successfully"})
+}
+
+// DeleteMenuItemPrice handles DELETE /api/v1/items/{id}/prices/{channel}
+// @Summary Remove channel price
+// @Description Removes the price of a menu item on an order channel, which then uses the item's price again. Existing orders keep their prices.
+// @Tags Menu Items
+// @Produce json
+// @Param id path int true "Menu item ID"
+// @Param channel path string true "Order channel" Enums(dine_in, takeaway, delivery)
+// @Success 200 {object} SuccessResponse "Price removed successfully"
+// @Failure 400 {object} ErrorResponse "Invalid channel"
+// @Failure 404 {object} ErrorResponse "The menu item has no price on the channel"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/items/{id}/prices/{channel} [delete]
+func (h *MenuItemPriceHandlers) DeleteMenuItemPrice(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid menu item ID")
+		return
+	}
+
+	if err := h.service.DeletePrice(r.Context(), id, r.PathValue("channel")); err != nil {
+		h.writeServiceError(w, r, err, "Failed to remove price")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Price removed successfully"})
+}
+
+// writeServiceError maps a channel price service error to its status code
+func (h *MenuItemPriceHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, r, http.StatusNotFound, "Menu item not found")
+	case errors.Is(err, services.ErrPriceNotFound):
+		writeError(w, r, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidChannel), errors.Is(err, services.ErrInvalidPrice):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
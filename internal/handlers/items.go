@@ -4,32 +4,33 @@ import (
 	"encoding/json"
 	"errors"
 	"log/slog"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
 
-	"github.com/uptrace/bun"
-
+	"github.com/Zughayyar/agora-server/internal/logging"
 	"github.com/Zughayyar/agora-server/internal/services"
 )
 
 // MenuItemHandlers contains HTTP handlers for menu item operations
 type MenuItemHandlers struct {
-	service *services.MenuItemService
+	service services.MenuItemService
 }
 
 // NewMenuItemHandlers creates a new menu item handlers instance
-func NewMenuItemHandlers(db *bun.DB) *MenuItemHandlers {
+func NewMenuItemHandlers(service services.MenuItemService) *MenuItemHandlers {
 	return &MenuItemHandlers{
-		service: services.NewMenuItemService(db),
+		service: service,
 	}
 }
 
 // ErrorResponse represents an error response
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-	Code    int    `json:"code"`
+	Error   string      `json:"error"`
+	Message string      `json:"message"`
+	Code    int         `json:"code"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 // SuccessResponse represents a success response
@@ -38,7 +39,7 @@ type SuccessResponse struct {
 	Message string      `json:"message"`
 }
 
-// CreateMenuItem handles POST /api/v1/menu-items
+// CreateMenuItem handles POST /api/v1/items
 // @Summary Create a new menu item
 // @Description Creates a new menu item with the provided details
 // @Tags Menu Items
@@ -47,122 +48,146 @@ type SuccessResponse struct {
 // @Param item body services.CreateMenuItemRequest true "Menu item details"
 // @Success 201 {object} SuccessResponse{data=services.MenuItemResponse} "Menu item created successfully"
 // @Failure 400 {object} ErrorResponse "Invalid request format"
+// @Failure 413 {object} ErrorResponse "Request body too large"
 // @Failure 500 {object} ErrorResponse "Internal server error"
-// @Router /menu-items [post]
+// @Router /api/v1/items [post]
 func (h *MenuItemHandlers) CreateMenuItem(w http.ResponseWriter, r *http.Request) {
 	var req services.CreateMenuItemRequest
 
 	// Parse JSON request body
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+		status, message := requestBodyError(err, "Invalid JSON format")
+		h.writeErrorResponse(w, r, message, status)
 		return
 	}
 
 	// Create menu item using service
 	item, err := h.service.CreateMenuItem(r.Context(), req)
 	if err != nil {
-		slog.Error("Failed to create menu item",
+		logging.FromContext(r.Context()).Error("Failed to create menu item",
 			slog.String("error", err.Error()),
 			slog.String("name", req.Name),
 			slog.String("category", req.Category))
-		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		h.writeErrorResponse(w, r, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
 	// Return created item
-	h.writeSuccessResponse(w, item, "Menu item created successfully", http.StatusCreated)
+	h.writeSuccessResponse(w, r, item, "Menu item created successfully", http.StatusCreated)
 }
 
-// GetAllMenuItems handles GET /api/v1/menu-items
-// @Summary Get all menu items
+// GetAllMenuItems handles GET /api/v1/items
+// @Description Retrieves all menu items with optional filtering by category, availability, or search term. With location, items show the availability and price a location overrides them with, so available=true&location= is the menu of the location.
 // @Description Retrieves all menu items with optional filtering by category, availability, or search term
 // @Tags Menu Items
 // @Accept json
-// @Produce json
+// @Produce json,xml,application/msgpack
 // @Param category query string false "Filter by category (appetizer, main, dessert, drink, side, fast food)"
 // @Param available query boolean false "Filter by availability (true/false)"
 // @Param include_deleted query boolean false "Include soft-deleted items (true/false)"
 // @Param search query string false "Search term to filter menu items"
+// @Param include query string false "Comma-separated related resources to embed (prices)"
+// @Param expand query string false "Comma-separated sub-resources to embed (category)"
+// @Param channel query string false "Price the items for an order channel (dine_in, takeaway, delivery)"
+// @Param location query int false "Apply the availability and price overrides of a location"
+// @Param min_rating query number false "Only items whose approved reviews average at least this rating (1-5)"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,name,price"
 // @Success 200 {object} SuccessResponse{data=[]services.MenuItemResponse} "Menu items retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Unknown field, include or expand, or invalid min_rating or location"
 // @Failure 500 {object} ErrorResponse "Internal server error"
-// @Router /menu-items [get]
+// @Router /api/v1/items [get]
 func (h *MenuItemHandlers) GetAllMenuItems(w http.ResponseWriter, r *http.Request) {
 	// Check query parameters for filtering
 	category := r.URL.Query().Get("category")
 	availableOnly := r.URL.Query().Get("available") == "true"
 	includeDeleted := r.URL.Query().Get("include_deleted") == "true"
 	search := r.URL.Query().Get("search")
+	opts := queryOptions(r)
+	fields, err := parseFields(r, menuItemFields)
+	if err != nil {
+		h.writeErrorResponse(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	var items []services.MenuItemResponse
-	var err error
 
 	// Handle different query scenarios
 	switch {
 	case search != "":
-		items, err = h.service.SearchMenuItems(r.Context(), search)
+		items, err = h.service.SearchMenuItems(r.Context(), search, opts)
 	case category != "":
-		items, err = h.service.GetMenuItemsByCategory(r.Context(), category)
+		items, err = h.service.GetMenuItemsByCategory(r.Context(), category, opts)
 	case availableOnly:
-		items, err = h.service.GetAvailableMenuItems(r.Context())
+		items, err = h.service.GetAvailableMenuItems(r.Context(), opts)
 	case includeDeleted:
-		items, err = h.service.GetAllMenuItemsWithDeleted(r.Context())
+		items, err = h.service.GetAllMenuItemsWithDeleted(r.Context(), opts)
 	default:
-		items, err = h.service.GetAllMenuItems(r.Context())
+		items, err = h.service.GetAllMenuItems(r.Context(), opts)
 	}
 
 	if err != nil {
-		slog.Error("Failed to retrieve menu items",
+		logging.FromContext(r.Context()).Error("Failed to retrieve menu items",
 			slog.String("error", err.Error()),
 			slog.String("category", category),
 			slog.Bool("available_only", availableOnly),
 			slog.Bool("include_deleted", includeDeleted),
 			slog.String("search", search))
-		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		h.writeErrorResponse(w, r, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
-	h.writeSuccessResponse(w, items, "Menu items retrieved successfully", http.StatusOK)
+	h.writeFields(w, r, items, fields, "Menu items retrieved successfully")
 }
 
-// GetMenuItemByID handles GET /api/v1/menu-items/{id}
+// GetMenuItemByID handles GET /api/v1/items/{id}
 // @Summary Get menu item by ID
 // @Description Retrieves a specific menu item by its ID
 // @Tags Menu Items
 // @Accept json
-// @Produce json
+// @Produce json,xml,application/msgpack
 // @Param id path int true "Menu item ID"
+// @Param include query string false "Comma-separated related resources to embed (prices)"
+// @Param expand query string false "Comma-separated sub-resources to embed (category)"
+// @Param channel query string false "Price the items for an order channel (dine_in, takeaway, delivery)"
+// @Param location query int false "Apply the availability and price overrides of a location"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,name,price"
 // @Success 200 {object} SuccessResponse{data=services.MenuItemResponse} "Menu item retrieved successfully"
-// @Failure 400 {object} ErrorResponse "Invalid menu item ID"
+// @Failure 400 {object} ErrorResponse "Invalid menu item ID, field, include, expand or location"
 // @Failure 404 {object} ErrorResponse "Menu item not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
-// @Router /menu-items/{id} [get]
+// @Router /api/v1/items/{id} [get]
 func (h *MenuItemHandlers) GetMenuItemByID(w http.ResponseWriter, r *http.Request) {
 	// Extract ID from URL path
 	id, err := h.extractIDFromPath(r.URL.Path)
 	if err != nil {
-		h.writeErrorResponse(w, "Invalid menu item ID", http.StatusBadRequest)
+		h.writeErrorResponse(w, r, "Invalid menu item ID", http.StatusBadRequest)
+		return
+	}
+	fields, err := parseFields(r, menuItemFields)
+	if err != nil {
+		h.writeErrorResponse(w, r, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	// Get menu item by ID
-	item, err := h.service.GetMenuItemByID(r.Context(), id)
+	item, err := h.service.GetMenuItemByID(r.Context(), id, queryOptions(r))
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows") {
-			slog.Warn("Menu item not found", slog.Int("id", id))
-			h.writeErrorResponse(w, "Menu item not found", http.StatusNotFound)
+			logging.FromContext(r.Context()).Warn("Menu item not found", slog.Int("id", id))
+			h.writeErrorResponse(w, r, "Menu item not found", http.StatusNotFound)
 			return
 		}
-		slog.Error("Failed to get menu item by ID",
+		logging.FromContext(r.Context()).Error("Failed to get menu item by ID",
 			slog.String("error", err.Error()),
 			slog.Int("id", id))
-		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		h.writeErrorResponse(w, r, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
-	h.writeSuccessResponse(w, item, "Menu item retrieved successfully", http.StatusOK)
+	h.writeFields(w, r, item, fields, "Menu item retrieved successfully")
 }
 
-// UpdateMenuItem handles PUT /api/v1/menu-items/{id}
+// UpdateMenuItem handles PUT /api/v1/items/{id}
 // @Summary Update menu item
 // @Description Updates an existing menu item with the provided details
 // @Tags Menu Items
@@ -172,21 +197,23 @@ func (h *MenuItemHandlers) GetMenuItemByID(w http.ResponseWriter, r *http.Reques
 // @Param item body services.UpdateMenuItemRequest true "Updated menu item details"
 // @Success 200 {object} SuccessResponse{data=services.MenuItemResponse} "Menu item updated successfully"
 // @Failure 400 {object} ErrorResponse "Invalid request format or menu item ID"
+// @Failure 413 {object} ErrorResponse "Request body too large"
 // @Failure 404 {object} ErrorResponse "Menu item not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
-// @Router /menu-items/{id} [put]
+// @Router /api/v1/items/{id} [put]
 func (h *MenuItemHandlers) UpdateMenuItem(w http.ResponseWriter, r *http.Request) {
 	// Extract ID from URL path
 	id, err := h.extractIDFromPath(r.URL.Path)
 	if err != nil {
-		h.writeErrorResponse(w, "Invalid menu item ID", http.StatusBadRequest)
+		h.writeErrorResponse(w, r, "Invalid menu item ID", http.StatusBadRequest)
 		return
 	}
 
 	// Parse JSON request body
 	var req services.UpdateMenuItemRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeErrorResponse(w, "Invalid JSON format", http.StatusBadRequest)
+		status, message := requestBodyError(err, "Invalid JSON format")
+		h.writeErrorResponse(w, r, message, status)
 		return
 	}
 
@@ -194,38 +221,38 @@ func (h *MenuItemHandlers) UpdateMenuItem(w http.ResponseWriter, r *http.Request
 	item, err := h.service.UpdateMenuItem(r.Context(), id, req)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows") {
-			slog.Warn("Menu item not found for update", slog.Int("id", id))
-			h.writeErrorResponse(w, "Menu item not found", http.StatusNotFound)
+			logging.FromContext(r.Context()).Warn("Menu item not found for update", slog.Int("id", id))
+			h.writeErrorResponse(w, r, "Menu item not found", http.StatusNotFound)
 			return
 		}
-		slog.Error("Failed to update menu item",
+		logging.FromContext(r.Context()).Error("Failed to update menu item",
 			slog.String("error", err.Error()),
 			slog.Int("id", id))
-		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		h.writeErrorResponse(w, r, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
-	h.writeSuccessResponse(w, item, "Menu item updated successfully", http.StatusOK)
+	h.writeSuccessResponse(w, r, item, "Menu item updated successfully", http.StatusOK)
 }
 
-// DeleteMenuItem handles DELETE /api/v1/menu-items/{id}
+// DeleteMenuItem handles DELETE /api/v1/items/{id}
 // @Summary Delete menu item
 // @Description Soft deletes a menu item (can be restored) or permanently deletes with force=true
 // @Tags Menu Items
 // @Accept json
-// @Produce json
+// @Produce json,xml,application/msgpack
 // @Param id path int true "Menu item ID"
 // @Param force query boolean false "Permanently delete the item (true/false)"
 // @Success 200 {object} SuccessResponse "Menu item deleted successfully"
 // @Failure 400 {object} ErrorResponse "Invalid menu item ID"
 // @Failure 404 {object} ErrorResponse "Menu item not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
-// @Router /menu-items/{id} [delete]
+// @Router /api/v1/items/{id} [delete]
 func (h *MenuItemHandlers) DeleteMenuItem(w http.ResponseWriter, r *http.Request) {
 	// Extract ID from URL path
 	id, err := h.extractIDFromPath(r.URL.Path)
 	if err != nil {
-		h.writeErrorResponse(w, "Invalid menu item ID", http.StatusBadRequest)
+		h.writeErrorResponse(w, r, "Invalid menu item ID", http.StatusBadRequest)
 		return
 	}
 
@@ -242,15 +269,15 @@ func (h *MenuItemHandlers) DeleteMenuItem(w http.ResponseWriter, r *http.Request
 
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows") {
-			slog.Warn("Menu item not found for deletion", slog.Int("id", id))
-			h.writeErrorResponse(w, "Menu item not found", http.StatusNotFound)
+			logging.FromContext(r.Context()).Warn("Menu item not found for deletion", slog.Int("id", id))
+			h.writeErrorResponse(w, r, "Menu item not found", http.StatusNotFound)
 			return
 		}
-		slog.Error("Failed to delete menu item",
+		logging.FromContext(r.Context()).Error("Failed to delete menu item",
 			slog.String("error", err.Error()),
 			slog.Int("id", id),
 			slog.Bool("force_delete", forceDelete))
-		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		h.writeErrorResponse(w, r, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
@@ -259,15 +286,25 @@ func (h *MenuItemHandlers) DeleteMenuItem(w http.ResponseWriter, r *http.Request
 		message = "Menu item permanently deleted"
 	}
 
-	h.writeSuccessResponse(w, nil, message, http.StatusOK)
+	h.writeSuccessResponse(w, r, nil, message, http.StatusOK)
 }
 
-// RestoreMenuItem handles POST /api/v1/menu-items/{id}/restore
+// RestoreMenuItem handles POST /api/v1/items/{id}/restore
+// @Summary Restore menu item
+// @Description Restores a soft-deleted menu item
+// @Tags Menu Items
+// @Produce json
+// @Param id path int true "Menu item ID"
+// @Success 200 {object} SuccessResponse{data=services.MenuItemResponse} "Menu item restored successfully"
+// @Failure 400 {object} ErrorResponse "Invalid menu item ID or menu item is not deleted"
+// @Failure 404 {object} ErrorResponse "Menu item not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/items/{id}/restore [post]
 func (h *MenuItemHandlers) RestoreMenuItem(w http.ResponseWriter, r *http.Request) {
 	// Extract ID from URL path
 	id, err := h.extractIDFromPath(r.URL.Path)
 	if err != nil {
-		h.writeErrorResponse(w, "Invalid menu item ID", http.StatusBadRequest)
+		h.writeErrorResponse(w, r, "Invalid menu item ID", http.StatusBadRequest)
 		return
 	}
 
@@ -275,72 +312,159 @@ func (h *MenuItemHandlers) RestoreMenuItem(w http.ResponseWriter, r *http.Reques
 	item, err := h.service.RestoreMenuItem(r.Context(), id)
 	if err != nil {
 		if strings.Contains(err.Error(), "no rows") {
-			slog.Warn("Menu item not found for restoration", slog.Int("id", id))
-			h.writeErrorResponse(w, "Menu item not found", http.StatusNotFound)
+			logging.FromContext(r.Context()).Warn("Menu item not found for restoration", slog.Int("id", id))
+			h.writeErrorResponse(w, r, "Menu item not found", http.StatusNotFound)
 			return
 		}
 		if strings.Contains(err.Error(), "not deleted") {
-			slog.Warn("Attempted to restore non-deleted menu item", slog.Int("id", id))
-			h.writeErrorResponse(w, "Menu item is not deleted", http.StatusBadRequest)
+			logging.FromContext(r.Context()).Warn("Attempted to restore non-deleted menu item", slog.Int("id", id))
+			h.writeErrorResponse(w, r, "Menu item is not deleted", http.StatusBadRequest)
 			return
 		}
-		slog.Error("Failed to restore menu item",
+		logging.FromContext(r.Context()).Error("Failed to restore menu item",
 			slog.String("error", err.Error()),
 			slog.Int("id", id))
-		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		h.writeErrorResponse(w, r, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
-	h.writeSuccessResponse(w, item, "Menu item restored successfully", http.StatusOK)
+	h.writeSuccessResponse(w, r, item, "Menu item restored successfully", http.StatusOK)
 }
 
-// GetDeletedMenuItems handles GET /api/v1/menu-items/deleted
+// GetDeletedMenuItems handles GET /api/v1/items/deleted
+// @Summary Get deleted menu items
+// @Description Retrieves the soft-deleted menu items, which can be restored
+// @Tags Menu Items
+// @Produce json,xml,application/msgpack
+// @Param include query string false "Comma-separated related resources to embed (prices)"
+// @Param expand query string false "Comma-separated sub-resources to embed (category)"
+// @Param channel query string false "Price the items for an order channel (dine_in, takeaway, delivery)"
+// @Param location query int false "Apply the availability and price overrides of a location"
+// @Param min_rating query number false "Only items whose approved reviews average at least this rating (1-5)"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,name,price"
+// @Success 200 {object} SuccessResponse{data=[]services.MenuItemResponse} "Deleted menu items retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Unknown field, include or expand, or invalid min_rating or location"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/items/deleted [get]
 func (h *MenuItemHandlers) GetDeletedMenuItems(w http.ResponseWriter, r *http.Request) {
-	items, err := h.service.GetDeletedMenuItems(r.Context())
+	fields, err := parseFields(r, menuItemFields)
+	if err != nil {
+		h.writeErrorResponse(w, r, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	items, err := h.service.GetDeletedMenuItems(r.Context(), queryOptions(r))
 	if err != nil {
-		slog.Error("Failed to retrieve deleted menu items", slog.String("error", err.Error()))
-		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		logging.FromContext(r.Context()).Error("Failed to retrieve deleted menu items", slog.String("error", err.Error()))
+		h.writeErrorResponse(w, r, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
-	h.writeSuccessResponse(w, items, "Deleted menu items retrieved successfully", http.StatusOK)
+	h.writeFields(w, r, items, fields, "Deleted menu items retrieved successfully")
 }
 
 // GetMenuItemsByCategory handles GET /api/v1/items/category/{category}
+// @Summary Get menu items by category
+// @Description Retrieves the active menu items of a category
+// @Tags Menu Items
+// @Produce json,xml,application/msgpack
+// @Param category path string true "Category (appetizer, main, dessert, drink, side, fast food)"
+// @Param include query string false "Comma-separated related resources to embed (prices)"
+// @Param expand query string false "Comma-separated sub-resources to embed (category)"
+// @Param channel query string false "Price the items for an order channel (dine_in, takeaway, delivery)"
+// @Param location query int false "Apply the availability and price overrides of a location"
+// @Param min_rating query number false "Only items whose approved reviews average at least this rating (1-5)"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,name,price"
+// @Success 200 {object} SuccessResponse{data=[]services.MenuItemResponse} "Menu items retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid category, field, include, expand, min_rating or location"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/items/category/{category} [get]
 func (h *MenuItemHandlers) GetMenuItemsByCategory(w http.ResponseWriter, r *http.Request) {
 	// Extract category from URL path using Go 1.22+ path value
 	category := r.PathValue("category")
 	if category == "" {
-		h.writeErrorResponse(w, "Category parameter is required", http.StatusBadRequest)
+		h.writeErrorResponse(w, r, "Category parameter is required", http.StatusBadRequest)
 		return
 	}
 
 	// Validate category
-	validCategories := map[string]bool{
-		"appetizer": true,
-		"main":      true,
-		"dessert":   true,
-		"drink":     true,
-		"side":      true,
-		"fast food": true,
+	if !services.ValidCategories[category] {
+		h.writeErrorResponse(w, r, "Invalid category. Must be one of: appetizer, main, dessert, drink, side, fast food", http.StatusBadRequest)
+		return
 	}
-
-	if !validCategories[category] {
-		h.writeErrorResponse(w, "Invalid category. Must be one of: appetizer, main, dessert, drink, side, fast food", http.StatusBadRequest)
+	fields, err := parseFields(r, menuItemFields)
+	if err != nil {
+		h.writeErrorResponse(w, r, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	// Get menu items by category
-	items, err := h.service.GetMenuItemsByCategory(r.Context(), category)
+	items, err := h.service.GetMenuItemsByCategory(r.Context(), category, queryOptions(r))
 	if err != nil {
-		slog.Error("Failed to retrieve menu items by category",
+		logging.FromContext(r.Context()).Error("Failed to retrieve menu items by category",
 			slog.String("error", err.Error()),
 			slog.String("category", category))
-		h.writeErrorResponse(w, err.Error(), http.StatusInternalServerError)
+		h.writeErrorResponse(w, r, err.Error(), serviceErrorStatus(err))
 		return
 	}
 
-	h.writeSuccessResponse(w, items, "Menu items retrieved successfully", http.StatusOK)
+	h.writeFields(w, r, items, fields, "Menu items retrieved successfully")
+}
+
+// Helper function to map service errors to HTTP status codes
+func serviceErrorStatus(err error) int {
+	if errors.Is(err, services.ErrServiceUnavailable) {
+		return http.StatusServiceUnavailable
+	}
+	if errors.Is(err, services.ErrInvalidInclude) || errors.Is(err, services.ErrInvalidExpand) ||
+		errors.Is(err, services.ErrInvalidChannel) || errors.Is(err, services.ErrInvalidStation) ||
+		errors.Is(err, services.ErrInvalidPrepTime) || errors.Is(err, services.ErrInvalidMinRating) ||
+		errors.Is(err, services.ErrInvalidLocation) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// Helper function to read the ?include=, ?expand=, ?channel=, ?min_rating= and ?location=
+// options of menu item reads. A min_rating that isn't a number is left NaN and a
+// location that isn't one -1, which the service rejects.
+func queryOptions(r *http.Request) services.QueryOptions {
+	opts := services.QueryOptions{
+		Include: parseListParam(r, "include"),
+		Expand:  parseListParam(r, "expand"),
+		Channel: r.URL.Query().Get("channel"),
+	}
+	if value := r.URL.Query().Get("min_rating"); value != "" {
+		rating, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			rating = math.NaN()
+		}
+		opts.MinRating = rating
+	}
+	if value := r.URL.Query().Get("location"); value != "" {
+		location, err := strconv.Atoi(value)
+		if err != nil || location == 0 {
+			location = -1
+		}
+		opts.Location = location
+	}
+	return opts
+}
+
+// Helper function to parse a comma-separated query parameter such as ?include=a,b
+func parseListParam(r *http.Request, name string) []string {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil
+	}
+
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
 }
 
 // Helper function to extract ID from URL path
@@ -378,37 +502,26 @@ func (h *MenuItemHandlers) extractIDFromPath(path string) (int, error) {
 	return 0, errors.New("invalid ID format: no ID found in path")
 }
 
-// Helper function to write error responses
-func (h *MenuItemHandlers) writeErrorResponse(w http.ResponseWriter, message string, statusCode int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-
-	errorResp := ErrorResponse{
-		Error:   http.StatusText(statusCode),
-		Message: message,
-		Code:    statusCode,
-	}
-
-	if err := json.NewEncoder(w).Encode(errorResp); err != nil {
-		// If we can't encode the error response, there's not much we can do
-		// The status code has already been set, so the client will get that
+// Helper function to write a read response trimmed to the requested fields
+func (h *MenuItemHandlers) writeFields(w http.ResponseWriter, r *http.Request, data interface{}, fields []string, message string) {
+	data, err := selectFields(data, fields)
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to select fields", slog.String("error", err.Error()))
+		writeError(w, r, http.StatusInternalServerError, "Failed to encode response")
 		return
 	}
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: data, Message: message})
 }
 
-// Helper function to write success responses
-func (h *MenuItemHandlers) writeSuccessResponse(w http.ResponseWriter, data interface{}, message string, statusCode int) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+// Helper function to write error responses
+func (h *MenuItemHandlers) writeErrorResponse(w http.ResponseWriter, r *http.Request, message string, statusCode int) {
+	writeError(w, r, statusCode, message)
+}
 
-	successResp := SuccessResponse{
+// Helper function to write success responses
+func (h *MenuItemHandlers) writeSuccessResponse(w http.ResponseWriter, r *http.Request, data interface{}, message string, statusCode int) {
+	writeJSON(w, r, statusCode, SuccessResponse{
 		Data:    data,
 		Message: message,
-	}
-
-	if err := json.NewEncoder(w).Encode(successResp); err != nil {
-		// If we can't encode the success response, there's not much we can do
-		// The status code has already been set, so the client will get that
-		return
-	}
+	})
 }
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/jobs"
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// JobsHandler handles GET /admin/jobs
+// @Summary Background jobs
+// @Description Lists background jobs, newest first. Filter by status=dead to inspect dead-lettered jobs.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Param status query string false "Job status (pending, running, succeeded, dead)"
+// @Param kind query string false "Job kind, e.g. export"
+// @Param limit query int false "Maximum number of jobs (default 100, at most 500)"
+// @Success 200 {object} SuccessResponse{data=[]jobs.Job} "Jobs retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid filter"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/jobs [get]
+func JobsHandler(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		filter := jobs.Filter{Kind: query.Get("kind"), Status: query.Get("status")}
+		switch filter.Status {
+		case "", jobs.StatusPending, jobs.StatusRunning, jobs.StatusSucceeded, jobs.StatusDead:
+		default:
+			writeError(w, r, http.StatusBadRequest, "status must be one of pending, running, succeeded, dead")
+			return
+		}
+		if limit := query.Get("limit"); limit != "" {
+			n, err := strconv.Atoi(limit)
+			if err != nil || n < 1 {
+				writeError(w, r, http.StatusBadRequest, "limit must be a positive integer")
+				return
+			}
+			filter.Limit = n
+		}
+
+		list, err := jobs.List(r.Context(), db, filter)
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to list jobs", slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to list jobs")
+			return
+		}
+		if list == nil {
+			list = []jobs.Job{}
+		}
+
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    list,
+			Message: "Jobs retrieved successfully",
+		})
+	}
+}
+
+// RetryJobHandler handles POST /admin/jobs/{id}/retry
+// @Summary Retry a dead job
+// @Description Puts a dead-lettered job back in the queue with a fresh set of attempts
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Param id path int true "Job ID"
+// @Success 200 {object} SuccessResponse{data=jobs.Job} "Job requeued"
+// @Failure 404 {object} ErrorResponse "Job not found"
+// @Failure 409 {object} ErrorResponse "Job is not dead"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/jobs/{id}/retry [post]
+func RetryJobHandler(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		if err != nil {
+			writeError(w, r, http.StatusNotFound, jobs.ErrNotFound.Error())
+			return
+		}
+
+		job, err := jobs.Retry(r.Context(), db, id)
+		switch {
+		case errors.Is(err, jobs.ErrNotFound):
+			writeError(w, r, http.StatusNotFound, err.Error())
+			return
+		case errors.Is(err, jobs.ErrNotRetryable):
+			writeError(w, r, http.StatusConflict, err.Error())
+			return
+		case err != nil:
+			logging.FromContext(r.Context()).Error("Failed to retry job", slog.Int64("job_id", id), slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to retry job")
+			return
+		}
+
+		logging.FromContext(r.Context()).Info("Dead job requeued", slog.Int64("job_id", id), slog.String("job_kind", job.Kind))
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    job,
+			Message: "Job requeued",
+		})
+	}
+}
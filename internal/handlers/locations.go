@@ -0,0 +1,424 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// LocationHandlers contains HTTP handlers for locations, their menu overrides and
+// their opening hours
+type LocationHandlers struct {
+	service services.LocationService
+}
+
+// NewLocationHandlers creates a new location handlers instance
+func NewLocationHandlers(service services.LocationService) *LocationHandlers {
+	return &LocationHandlers{service: service}
+}
+
+// GetLocations handles GET /api/v1/locations
+// @Summary List locations
+// @Description Retrieves the restaurant's locations (branches) by name
+// @Tags Locations
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=[]services.LocationResponse} "Locations retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/locations [get]
+func (h *LocationHandlers) GetLocations(w http.ResponseWriter, r *http.Request) {
+	locations, err := h.service.ListLocations(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to list locations", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to list locations")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: locations, Message: "Locations retrieved successfully"})
+}
+
+// CreateLocation handles POST /api/v1/locations
+// @Summary Create location
+// @Description Creates a location of the restaurant. It serves the restaurant's menu until items are overridden there.
+// @Tags Locations
+// @Accept json
+// @Produce json
+// @Param location body services.LocationRequest true "Location"
+// @Success 201 {object} SuccessResponse{data=services.LocationResponse} "Location created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid location"
+// @Failure 409 {object} ErrorResponse "Another location has the name"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/locations [post]
+func (h *LocationHandlers) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	var req services.LocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	location, err := h.service.CreateLocation(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create location")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: location, Message: "Location created successfully"})
+}
+
+// UpdateLocation handles PUT /api/v1/locations/{id}
+// @Summary Update location
+// @Description Replaces the name, address and coordinates of a location. A location without coordinates isn't found by nearby searches.
+// @Tags Locations
+// @Accept json
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param location body services.LocationRequest true "Location"
+// @Success 200 {object} SuccessResponse{data=services.LocationResponse} "Location updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid location"
+// @Failure 404 {object} ErrorResponse "Location not found"
+// @Failure 409 {object} ErrorResponse "Another location has the name"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/locations/{id} [put]
+func (h *LocationHandlers) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid location ID")
+		return
+	}
+	var req services.LocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	location, err := h.service.UpdateLocation(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update location")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: location, Message: "Location updated successfully"})
+}
+
+// DeleteLocation handles DELETE /api/v1/locations/{id}
+// @Summary Delete location
+// @Description Deletes a location along with its menu overrides
+// @Tags Locations
+// @Produce json
+// @Param id path int true "Location ID"
+// @Success 200 {object} SuccessResponse "Location deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid location ID"
+// @Failure 404 {object} ErrorResponse "Location not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/locations/{id} [delete]
+func (h *LocationHandlers) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid location ID")
+		return
+	}
+
+	if err := h.service.DeleteLocation(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "Failed to delete location")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Location deleted successfully"})
+}
+
+// GetLocationOverrides handles GET /api/v1/locations/{id}/items
+// @Summary List menu overrides of a location
+// @Description Retrieves the menu items a location overrides, by menu item ID, with the availability and price it sets for them
+// @Tags Locations
+// @Produce json,xml,application/msgpack
+// @Param id path int true "Location ID"
+// @Success 200 {object} SuccessResponse{data=[]services.LocationOverrideResponse} "Overrides retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid location ID"
+// @Failure 404 {object} ErrorResponse "Location not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/locations/{id}/items [get]
+func (h *LocationHandlers) GetLocationOverrides(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid location ID")
+		return
+	}
+
+	overrides, err := h.service.ListOverrides(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to list overrides")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: overrides, Message: "Overrides retrieved successfully"})
+}
+
+// SetLocationOverride handles PUT /api/v1/locations/{id}/items/{item_id}
+// @Summary Override a menu item at a location
+// @Description Sets the availability, the price or both of a menu item at a location, replacing any override it had. Menus read with ?location= show them instead of the item's; omitted fields follow the item.
+// @Tags Locations
+// @Accept json
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param item_id path int true "Menu item ID"
+// @Param override body services.LocationOverrideRequest true "Override"
+// @Success 200 {object} SuccessResponse{data=services.LocationOverrideResponse} "Override set successfully"
+// @Failure 400 {object} ErrorResponse "Invalid ID or override"
+// @Failure 404 {object} ErrorResponse "Location or menu item not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/locations/{id}/items/{item_id} [put]
+func (h *LocationHandlers) SetLocationOverride(w http.ResponseWriter, r *http.Request) {
+	id, itemID, ok := overridePath(w, r)
+	if !ok {
+		return
+	}
+	var req services.LocationOverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	override, err := h.service.SetOverride(r.Context(), id, itemID, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to set override")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: override, Message: "Override set successfully"})
+}
+
+// DeleteLocationOverride handles DELETE /api/v1/locations/{id}/items/{item_id}
+// @Summary Remove the override of a menu item at a location
+// @Description Removes the override of a menu item at a location, which then follows the menu again
+// @Tags Locations
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param item_id path int true "Menu item ID"
+// @Success 200 {object} SuccessResponse "Override removed successfully"
+// @Failure 400 {object} ErrorResponse "Invalid ID"
+// @Failure 404 {object} ErrorResponse "Location not found, or it doesn't override the item"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/locations/{id}/items/{item_id} [delete]
+func (h *LocationHandlers) DeleteLocationOverride(w http.ResponseWriter, r *http.Request) {
+	id, itemID, ok := overridePath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteOverride(r.Context(), id, itemID); err != nil {
+		h.writeServiceError(w, r, err, "Failed to remove override")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Override removed successfully"})
+}
+
+// GetLocationHours handles GET /api/v1/locations/{id}/hours
+// @Summary Get opening hours of a location
+// @Description Retrieves the weekly opening hours of a location, its hour exceptions from today on, and whether it is open now. A location without weekly hours is always open.
+// @Tags Locations
+// @Produce json,xml,application/msgpack
+// @Param id path int true "Location ID"
+// @Success 200 {object} SuccessResponse{data=services.LocationHoursResponse} "Opening hours retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid location ID"
+// @Failure 404 {object} ErrorResponse "Location not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/locations/{id}/hours [get]
+func (h *LocationHandlers) GetLocationHours(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid location ID")
+		return
+	}
+
+	hours, err := h.service.GetHours(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to get opening hours")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: hours, Message: "Opening hours retrieved successfully"})
+}
+
+// SetLocationHours handles PUT /api/v1/locations/{id}/hours
+// @Summary Set opening hours of a location
+// @Description Replaces the weekly opening hours of a location. Each period opens on a day (sun, mon, ...) from opens_at to closes_at (HH:MM, restaurant time), running past midnight when closes_at is earlier. An empty list leaves the location always open. Online orders are only placed while their location is open.
+// @Tags Locations
+// @Accept json
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param hours body services.LocationHoursRequest true "Weekly opening hours"
+// @Success 200 {object} SuccessResponse{data=services.LocationHoursResponse} "Opening hours set successfully"
+// @Failure 400 {object} ErrorResponse "Invalid location ID or hours"
+// @Failure 404 {object} ErrorResponse "Location not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/locations/{id}/hours [put]
+func (h *LocationHandlers) SetLocationHours(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid location ID")
+		return
+	}
+	var req services.LocationHoursRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	hours, err := h.service.SetHours(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to set opening hours")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: hours, Message: "Opening hours set successfully"})
+}
+
+// SetLocationHoursException handles PUT /api/v1/locations/{id}/hours/exceptions/{date}
+// @Summary Set an hour exception of a location
+// @Description Replaces the weekly hours of a location on a date (YYYY-MM-DD), e.g. a holiday. Without opens_at the location is closed all day; otherwise it opens once, from opens_at to closes_at.
+// @Tags Locations
+// @Accept json
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param date path string true "Date (YYYY-MM-DD)"
+// @Param exception body services.HoursExceptionRequest true "Hours on the date"
+// @Success 200 {object} SuccessResponse{data=services.HoursExceptionResponse} "Hour exception set successfully"
+// @Failure 400 {object} ErrorResponse "Invalid location ID, date or hours"
+// @Failure 404 {object} ErrorResponse "Location not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/locations/{id}/hours/exceptions/{date} [put]
+func (h *LocationHandlers) SetLocationHoursException(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid location ID")
+		return
+	}
+	var req services.HoursExceptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	exception, err := h.service.SetHoursException(r.Context(), id, r.PathValue("date"), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to set hour exception")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: exception, Message: "Hour exception set successfully"})
+}
+
+// DeleteLocationHoursException handles DELETE /api/v1/locations/{id}/hours/exceptions/{date}
+// @Summary Remove an hour exception of a location
+// @Description Removes the hour exception of a location on a date (YYYY-MM-DD), which then follows the weekly hours again
+// @Tags Locations
+// @Produce json
+// @Param id path int true "Location ID"
+// @Param date path string true "Date (YYYY-MM-DD)"
+// @Success 200 {object} SuccessResponse "Hour exception removed successfully"
+// @Failure 400 {object} ErrorResponse "Invalid location ID or date"
+// @Failure 404 {object} ErrorResponse "Location not found, or it has no exception on the date"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/locations/{id}/hours/exceptions/{date} [delete]
+func (h *LocationHandlers) DeleteLocationHoursException(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid location ID")
+		return
+	}
+
+	if err := h.service.DeleteHoursException(r.Context(), id, r.PathValue("date")); err != nil {
+		h.writeServiceError(w, r, err, "Failed to remove hour exception")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Hour exception removed successfully"})
+}
+
+// GetNearbyLocations handles GET /public/locations/nearby
+// @Summary Find nearby locations
+// @Description Lists the restaurant's locations within radius meters of a point, nearest first, for ordering apps to pick the closest branch. Each has its distance in meters and whether it is open now. Locations without coordinates aren't listed. At most 20 are returned.
+// @Tags Locations
+// @Produce json,xml,application/msgpack
+// @Param lat query number true "Latitude of the point"
+// @Param lon query number true "Longitude of the point"
+// @Param radius query number false "Radius in meters (default 10000, at most 100000)"
+// @Success 200 {object} SuccessResponse{data=[]services.NearbyLocationResponse} "Nearby locations retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid lat, lon or radius"
+// @Failure 404 {object} ErrorResponse "Unknown restaurant"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /public/locations/nearby [get]
+func (h *LocationHandlers) GetNearbyLocations(w http.ResponseWriter, r *http.Request) {
+	var lat, lon, radius float64
+	for name, target := range map[string]*float64{"lat": &lat, "lon": &lon, "radius": &radius} {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			if name == "radius" {
+				continue
+			}
+			writeError(w, r, http.StatusBadRequest, name+" is required")
+			return
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, name+" must be a number")
+			return
+		}
+		*target = n
+	}
+
+	locations, err := h.service.NearbyLocations(r.Context(), lat, lon, radius)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to search nearby locations")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: locations, Message: "Nearby locations retrieved successfully"})
+}
+
+// overridePath reads the location and menu item IDs of an override's path, writing
+// a 400 when either isn't a number
+func overridePath(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid location ID")
+		return 0, 0, false
+	}
+	itemID, err := strconv.Atoi(r.PathValue("item_id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid menu item ID")
+		return 0, 0, false
+	}
+	return id, itemID, true
+}
+
+// writeServiceError maps a location service error to its status code
+func (h *LocationHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrLocationNotFound):
+		writeError(w, r, http.StatusNotFound, "Location not found")
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, r, http.StatusNotFound, "Menu item not found")
+	case errors.Is(err, services.ErrOverrideNotFound), errors.Is(err, services.ErrHoursExceptionNotFound):
+		writeError(w, r, http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrInvalidLocation), errors.Is(err, services.ErrInvalidOverride),
+		errors.Is(err, services.ErrInvalidHours):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrLocationExists):
+		writeError(w, r, http.StatusConflict, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
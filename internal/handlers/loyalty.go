@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// LoyaltyHandlers contains HTTP handlers for loyalty operations
+type LoyaltyHandlers struct {
+	service services.LoyaltyService
+}
+
+// NewLoyaltyHandlers creates a new loyalty handlers instance
+func NewLoyaltyHandlers(service services.LoyaltyService) *LoyaltyHandlers {
+	return &LoyaltyHandlers{service: service}
+}
+
+// GetCustomerLoyalty handles GET /api/v1/customers/{id}/loyalty
+// @Summary Get customer loyalty points
+// @Description Retrieves a customer's loyalty points balance, the discount it is worth and the ledger of points earned on paid orders and redeemed on new ones, newest first. Customers are identified by their phone number; customers who never earned points have none.
+// @Tags Loyalty
+// @Produce json,xml,application/msgpack
+// @Param id path string true "Customer phone number"
+// @Success 200 {object} SuccessResponse{data=services.LoyaltyResponse} "Loyalty points retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customers/{id}/loyalty [get]
+func (h *LoyaltyHandlers) GetCustomerLoyalty(w http.ResponseWriter, r *http.Request) {
+	loyalty, err := h.service.GetLoyalty(r.Context(), r.PathValue("id"))
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to get loyalty points", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to get loyalty points")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: loyalty, Message: "Loyalty points retrieved successfully"})
+}
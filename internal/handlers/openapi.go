@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// undocumentedTag groups the routes that have no swag annotations
+const undocumentedTag = "Undocumented"
+
+// wildcardSegment matches a {name...} path wildcard of a ServeMux pattern
+var wildcardSegment = regexp.MustCompile(`\{(\w+)\.\.\.\}`)
+
+// pathParameter matches a {name} path parameter
+var pathParameter = regexp.MustCompile(`\{(\w+)\}`)
+
+// OpenAPIHandler handles GET /openapi.json. It serves the spec generated from the
+// swag annotations, reconciled with the routes actually registered on the public
+// listener: operations whose route isn't registered are dropped, and registered
+// routes without annotations are listed under the "Undocumented" tag. The spec is
+// built on the first request, once all routes are registered.
+func OpenAPIHandler(readDoc func() string, list func() []RouteInfo) http.HandlerFunc {
+	spec := sync.OnceValues(func() ([]byte, error) {
+		return syncOpenAPISpec(readDoc(), list())
+	})
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := spec()
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to build the OpenAPI spec", slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to build the OpenAPI spec")
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}
+}
+
+// syncOpenAPISpec reconciles the swagger document doc with the registered routes
+func syncOpenAPISpec(doc string, routes []RouteInfo) ([]byte, error) {
+	var spec map[string]any
+	if err := json.Unmarshal([]byte(doc), &spec); err != nil {
+		return nil, fmt.Errorf("invalid swagger document: %w", err)
+	}
+	// Let clients use the host and scheme they loaded the spec from
+	delete(spec, "host")
+	delete(spec, "schemes")
+	spec["basePath"] = "/"
+
+	registered := map[string]map[string]RouteInfo{}
+	for _, route := range routes {
+		path, method, ok := openAPIOperation(route)
+		if !ok {
+			continue
+		}
+		if registered[path] == nil {
+			registered[path] = map[string]RouteInfo{}
+		}
+		registered[path][method] = route
+	}
+
+	paths, _ := spec["paths"].(map[string]any)
+	if paths == nil {
+		paths = map[string]any{}
+		spec["paths"] = paths
+	}
+	for path, item := range paths {
+		operations, _ := item.(map[string]any)
+		for method := range operations {
+			if _, ok := registered[path][method]; !ok {
+				delete(operations, method)
+			}
+		}
+		if len(operations) == 0 {
+			delete(paths, path)
+		}
+	}
+
+	for path, methods := range registered {
+		operations, _ := paths[path].(map[string]any)
+		if operations == nil {
+			operations = map[string]any{}
+			paths[path] = operations
+		}
+		for method, route := range methods {
+			if _, ok := operations[method]; !ok {
+				operations[method] = undocumentedOperation(path, route)
+			}
+		}
+	}
+
+	return json.Marshal(spec)
+}
+
+// openAPIOperation returns the spec path and method of a route. Routes of other
+// listeners, mounted subtrees and catch-alls are not operations.
+func openAPIOperation(route RouteInfo) (path, method string, ok bool) {
+	if route.Listener != "public" || strings.HasSuffix(route.Pattern, "/") || strings.HasSuffix(route.Pattern, "...}") {
+		return "", "", false
+	}
+	method = strings.ToLower(route.Method)
+	if method == "" {
+		method = "get"
+	}
+	path = strings.TrimSuffix(route.Pattern, "{$}")
+	path = wildcardSegment.ReplaceAllString(path, "{$1}")
+	return path, method, true
+}
+
+// undocumentedOperation describes a route that has no swag annotations
+func undocumentedOperation(path string, route RouteInfo) map[string]any {
+	var parameters []any
+	for _, match := range pathParameter.FindAllStringSubmatch(path, -1) {
+		parameters = append(parameters, map[string]any{
+			"name":     match[1],
+			"in":       "path",
+			"required": true,
+			"type":     "string",
+		})
+	}
+	operation := map[string]any{
+		"summary":   route.Handler,
+		"tags":      []string{undocumentedTag},
+		"responses": map[string]any{"default": map[string]any{"description": "Not documented"}},
+	}
+	if parameters != nil {
+		operation["parameters"] = parameters
+	}
+	return operation
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// OrderSlotHandlers contains HTTP handlers for order time slot operations
+type OrderSlotHandlers struct {
+	service services.OrderSlotService
+}
+
+// NewOrderSlotHandlers creates a new order slot handlers instance
+func NewOrderSlotHandlers(service services.OrderSlotService) *OrderSlotHandlers {
+	return &OrderSlotHandlers{service: service}
+}
+
+// GetOrderSlots handles GET /api/v1/order-slots
+// @Summary List order slots
+// @Description Lists the time slots online orders are placed in, with how many orders each has and whether it takes more. Slots are ORDER_SLOT_MINUTES long from the start of the business day and take at most ORDER_SLOT_CAPACITY online orders; point of sale orders aren't counted.
+// @Tags Orders
+// @Produce json,xml,application/msgpack
+// @Param from query string false "List from the slot this time falls in (RFC 3339, date, or Unix seconds; default now)"
+// @Param count query int false "Number of slots (default 8, at most 96)"
+// @Success 200 {object} SuccessResponse{data=[]services.OrderSlotResponse} "Order slots retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid count or timestamp"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/order-slots [get]
+func (h *OrderSlotHandlers) GetOrderSlots(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var from time.Time
+	if value := query.Get("from"); value != "" {
+		t, err := services.ParseTimestamp(value)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "from: "+err.Error())
+			return
+		}
+		from = t
+	}
+	var count int
+	if value := query.Get("count"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			writeError(w, r, http.StatusBadRequest, "count must be a positive integer")
+			return
+		}
+		count = n
+	}
+
+	slots, err := h.service.ListOrderSlots(r.Context(), from, count)
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to list order slots", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to list order slots")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: slots, Message: "Order slots retrieved successfully"})
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// OrderHandlers contains HTTP handlers for order operations
+type OrderHandlers struct {
+	service services.OrderService
+}
+
+// NewOrderHandlers creates a new order handlers instance
+func NewOrderHandlers(service services.OrderService) *OrderHandlers {
+	return &OrderHandlers{service: service}
+}
+
+// GetOrders handles GET /api/v1/orders
+// @Summary List orders
+// @Description Retrieves orders with their items, newest first
+// @Tags Orders
+// @Produce json,xml,application/msgpack
+// @Param status query string false "Filter by status (pending, accepted, preparing, ready, completed, cancelled)"
+// @Param source query string false "Filter by source (pos, ubereats, doordash)"
+// @Param since query string false "Only orders created at or after this time (RFC 3339, date, or Unix seconds)"
+// @Param until query string false "Only orders created before this time (RFC 3339, date, or Unix seconds)"
+// @Param limit query int false "Page size (default 50, at most 200)"
+// @Param offset query int false "Number of orders to skip"
+// @Param number query int false "Only the order with this number of the day, today's unless day is given"
+// @Param day query string false "Only orders numbered on this business day (date)"
+// @Success 200 {object} SuccessResponse{data=[]services.OrderResponse} "Orders retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid paging parameters or timestamps"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/orders [get]
+func (h *OrderHandlers) GetOrders(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := services.OrderListOptions{Status: query.Get("status"), Source: query.Get("source")}
+	for name, target := range map[string]*int{"limit": &opts.Limit, "offset": &opts.Offset, "number": &opts.Number} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			writeError(w, r, http.StatusBadRequest, name+" must be a non-negative integer")
+			return
+		}
+		*target = n
+	}
+	for name, target := range map[string]*time.Time{"since": &opts.Since, "until": &opts.Until, "day": &opts.Day} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		t, err := services.ParseTimestamp(value)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, name+": "+err.Error())
+			return
+		}
+		*target = t
+	}
+
+	orders, err := h.service.ListOrders(r.Context(), opts)
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to list orders", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to list orders")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: orders, Message: "Orders retrieved successfully"})
+}
+
+// GetOrderByID handles GET /api/v1/orders/{id}
+// @Summary Get order by ID
+// @Description Retrieves an order with its items
+// @Tags Orders
+// @Produce json,xml,application/msgpack
+// @Param id path string true "Order ID"
+// @Success 200 {object} SuccessResponse{data=services.OrderResponse} "Order retrieved successfully"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/orders/{id} [get]
+func (h *OrderHandlers) GetOrderByID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	order, err := h.service.GetOrderByID(r.Context(), id)
+	if errors.Is(err, services.ErrOrderNotFound) {
+		writeError(w, r, http.StatusNotFound, "Order not found")
+		return
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to get order", slog.String("id", id), slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to get order")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: order, Message: "Order retrieved successfully"})
+}
+
+// TrackOrder handles GET /api/v1/track/{token}
+// @Summary Track order
+// @Description Retrieves the status of an order and when it should be ready, for the customer's status page. The token is the order's tracking_token, signed with ORDER_TRACKING_SECRET, so this needs no other authorization; it shows no prices or customer details.
+// @Tags Orders
+// @Produce json
+// @Param token path string true "Tracking token"
+// @Success 200 {object} SuccessResponse{data=services.OrderTrackingResponse} "Order retrieved successfully"
+// @Failure 404 {object} ErrorResponse "Unknown tracking token"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/track/{token} [get]
+func (h *OrderHandlers) TrackOrder(w http.ResponseWriter, r *http.Request) {
+	order, err := h.service.TrackOrder(r.Context(), r.PathValue("token"))
+	if errors.Is(err, services.ErrOrderNotFound) {
+		writeError(w, r, http.StatusNotFound, "Order not found")
+		return
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to track order", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to track order")
+		return
+	}
+
+	// Status pages poll for changes
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: order, Message: "Order retrieved successfully"})
+}
+
+// PayOrder handles POST /api/v1/orders/{id}/payments
+// @Summary Pay order
+// @Description Records a cash, card or store_credit payment towards an order, by default of its amount_due or, with store_credit, as much of it as the customer's store credit covers. The payment completing the order earns its customer loyalty points. Gift cards pay orders through /gift-cards/{code}/redeem.
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param payment body services.PayOrderRequest true "Payment"
+// @Success 200 {object} SuccessResponse{data=services.OrderResponse} "Order paid successfully"
+// @Failure 400 {object} ErrorResponse "Invalid payment"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 422 {object} ErrorResponse "The order is cancelled or has less left to pay, or the customer's store credit is too low"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/orders/{id}/payments [post]
+func (h *OrderHandlers) PayOrder(w http.ResponseWriter, r *http.Request) {
+	var req services.PayOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+	req.RequestID = middlewares.RequestID(r.Context())
+
+	id := r.PathValue("id")
+	order, err := h.service.PayOrder(r.Context(), id, req)
+	switch {
+	case errors.Is(err, services.ErrOrderNotFound):
+		writeError(w, r, http.StatusNotFound, "Order not found")
+	case errors.Is(err, services.ErrInvalidPayment):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrOrderNotPayable), errors.Is(err, services.ErrStoreCreditTooLow):
+		writeError(w, r, http.StatusUnprocessableEntity, err.Error())
+	case err != nil:
+		logging.FromContext(r.Context()).Error("Failed to pay order", slog.String("id", id), slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to pay order")
+	default:
+		writeJSON(w, r, http.StatusOK, SuccessResponse{Data: order, Message: "Order paid successfully"})
+	}
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// PriceExperimentHandlers contains HTTP handlers for price experiment operations
+type PriceExperimentHandlers struct {
+	service services.PriceExperimentService
+}
+
+// NewPriceExperimentHandlers creates a new price experiment handlers instance
+func NewPriceExperimentHandlers(service services.PriceExperimentService) *PriceExperimentHandlers {
+	return &PriceExperimentHandlers{service: service}
+}
+
+// GetPriceExperiments handles GET /api/v1/price-experiments
+// @Summary List price experiments
+// @Description Retrieves the price experiments, latest start first, with their variants and whether they are scheduled, running or ended
+// @Tags Price Experiments
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=[]services.PriceExperimentResponse} "Price experiments retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/price-experiments [get]
+func (h *PriceExperimentHandlers) GetPriceExperiments(w http.ResponseWriter, r *http.Request) {
+	experiments, err := h.service.ListExperiments(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to list price experiments", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to list price experiments")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: experiments, Message: "Price experiments retrieved successfully"})
+}
+
+// CreatePriceExperiment handles POST /api/v1/price-experiments
+// @Summary Create price experiment
+// @Description Tests variant prices of a menu item from starts_at (default now) until ends_at. While it runs, each guest session viewing the guest menu is assigned a variant by the variants' weights, which add up to 100, and is shown and charged its price. A session keeps its variant on every request. A menu item runs one experiment at a time.
+// @Tags Price Experiments
+// @Accept json
+// @Produce json
+// @Param experiment body services.PriceExperimentRequest true "Price experiment"
+// @Success 201 {object} SuccessResponse{data=services.PriceExperimentResponse} "Price experiment created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid price experiment"
+// @Failure 409 {object} ErrorResponse "The menu item has an experiment in that period"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/price-experiments [post]
+func (h *PriceExperimentHandlers) CreatePriceExperiment(w http.ResponseWriter, r *http.Request) {
+	var req services.PriceExperimentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	experiment, err := h.service.CreateExperiment(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create price experiment")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: experiment, Message: "Price experiment created successfully"})
+}
+
+// GetPriceExperimentResults handles GET /api/v1/price-experiments/{id}/results
+// @Summary Price experiment results
+// @Description Compares the variants of a price experiment: for each, the guest sessions shown it, how many of them ordered the menu item in non-cancelled orders placed after seeing it, the quantity ordered and the revenue of those lines
+// @Tags Price Experiments
+// @Produce json,xml,application/msgpack
+// @Param id path int true "Price experiment ID"
+// @Success 200 {object} SuccessResponse{data=services.PriceExperimentResults} "Price experiment results retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid price experiment ID"
+// @Failure 404 {object} ErrorResponse "Price experiment not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/price-experiments/{id}/results [get]
+func (h *PriceExperimentHandlers) GetPriceExperimentResults(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid price experiment ID")
+		return
+	}
+
+	results, err := h.service.GetExperimentResults(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to retrieve price experiment results")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: results, Message: "Price experiment results retrieved successfully"})
+}
+
+// EndPriceExperiment handles POST /api/v1/price-experiments/{id}/end
+// @Summary End price experiment
+// @Description Ends a running price experiment now, or cancels a scheduled one. Guest sessions already shown a variant keep being charged its price.
+// @Tags Price Experiments
+// @Produce json
+// @Param id path int true "Price experiment ID"
+// @Success 200 {object} SuccessResponse{data=services.PriceExperimentResponse} "Price experiment ended successfully"
+// @Failure 400 {object} ErrorResponse "Invalid price experiment ID"
+// @Failure 404 {object} ErrorResponse "Price experiment not found"
+// @Failure 409 {object} ErrorResponse "Price experiment already ended"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/price-experiments/{id}/end [post]
+func (h *PriceExperimentHandlers) EndPriceExperiment(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid price experiment ID")
+		return
+	}
+
+	experiment, err := h.service.EndExperiment(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to end price experiment")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: experiment, Message: "Price experiment ended successfully"})
+}
+
+// DeletePriceExperiment handles DELETE /api/v1/price-experiments/{id}
+// @Summary Delete price experiment
+// @Description Deletes a price experiment no guest was shown yet. Experiments with results can only be ended.
+// @Tags Price Experiments
+// @Produce json
+// @Param id path int true "Price experiment ID"
+// @Success 200 {object} SuccessResponse "Price experiment deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid price experiment ID"
+// @Failure 404 {object} ErrorResponse "Price experiment not found"
+// @Failure 409 {object} ErrorResponse "Price experiment was shown to guests"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/price-experiments/{id} [delete]
+func (h *PriceExperimentHandlers) DeletePriceExperiment(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid price experiment ID")
+		return
+	}
+
+	if err := h.service.DeleteExperiment(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "Failed to delete price experiment")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Price experiment deleted successfully"})
+}
+
+// writeServiceError maps a price experiment service error to its status code
+func (h *PriceExperimentHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrExperimentNotFound):
+		writeError(w, r, http.StatusNotFound, "Price experiment not found")
+	case errors.Is(err, services.ErrInvalidExperiment):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrExperimentOverlap), errors.Is(err, services.ErrExperimentEnded),
+		errors.Is(err, services.ErrExperimentShown):
+		writeError(w, r, http.StatusConflict, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// PricingRuleHandlers contains HTTP handlers for pricing rule operations
+type PricingRuleHandlers struct {
+	service services.PricingRuleService
+}
+
+// NewPricingRuleHandlers creates a new pricing rule handlers instance
+func NewPricingRuleHandlers(service services.PricingRuleService) *PricingRuleHandlers {
+	return &PricingRuleHandlers{service: service}
+}
+
+// GetPricingRules handles GET /api/v1/pricing-rules
+// @Summary List pricing rules
+// @Description Retrieves the pricing rules, each reporting whether it is in effect now. A rule discounts one menu item, a category or, with neither set, every item during its time window.
+// @Tags Pricing Rules
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=[]services.PricingRuleResponse} "Pricing rules retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/pricing-rules [get]
+func (h *PricingRuleHandlers) GetPricingRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.ListPricingRules(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to list pricing rules", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to list pricing rules")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: rules, Message: "Pricing rules retrieved successfully"})
+}
+
+// CreatePricingRule handles POST /api/v1/pricing-rules
+// @Summary Create pricing rule
+// @Description Creates a pricing rule, e.g. a happy hour. While it is in effect, menus are served and new orders priced at the discounted prices.
+// @Tags Pricing Rules
+// @Accept json
+// @Produce json
+// @Param pricingRule body services.PricingRuleRequest true "Pricing rule"
+// @Success 201 {object} SuccessResponse{data=services.PricingRuleResponse} "Pricing rule created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid pricing rule"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/pricing-rules [post]
+func (h *PricingRuleHandlers) CreatePricingRule(w http.ResponseWriter, r *http.Request) {
+	var req services.PricingRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	rule, err := h.service.CreatePricingRule(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create pricing rule")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: rule, Message: "Pricing rule created successfully"})
+}
+
+// UpdatePricingRule handles PUT /api/v1/pricing-rules/{id}
+// @Summary Update pricing rule
+// @Description Replaces a pricing rule. Existing orders keep their prices.
+// @Tags Pricing Rules
+// @Accept json
+// @Produce json
+// @Param id path int true "Pricing rule ID"
+// @Param pricingRule body services.PricingRuleRequest true "Pricing rule"
+// @Success 200 {object} SuccessResponse{data=services.PricingRuleResponse} "Pricing rule updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid pricing rule"
+// @Failure 404 {object} ErrorResponse "Pricing rule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/pricing-rules/{id} [put]
+func (h *PricingRuleHandlers) UpdatePricingRule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid pricing rule ID")
+		return
+	}
+	var req services.PricingRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	rule, err := h.service.UpdatePricingRule(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update pricing rule")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: rule, Message: "Pricing rule updated successfully"})
+}
+
+// DeletePricingRule handles DELETE /api/v1/pricing-rules/{id}
+// @Summary Delete pricing rule
+// @Description Deletes a pricing rule. Existing orders keep their prices.
+// @Tags Pricing Rules
+// @Produce json
+// @Param id path int true "Pricing rule ID"
+// @Success 200 {object} SuccessResponse "Pricing rule deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid pricing rule ID"
+// @Failure 404 {object} ErrorResponse "Pricing rule not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/pricing-rules/{id} [delete]
+func (h *PricingRuleHandlers) DeletePricingRule(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid pricing rule ID")
+		return
+	}
+
+	if err := h.service.DeletePricingRule(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "Failed to delete pricing rule")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Pricing rule deleted successfully"})
+}
+
+// writeServiceError maps a pricing rule service error to its status code
+func (h *PricingRuleHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrPricingRuleNotFound):
+		writeError(w, r, http.StatusNotFound, "Pricing rule not found")
+	case errors.Is(err, services.ErrInvalidPricingRule):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// PromotionHandlers contains HTTP handlers for promotion operations
+type PromotionHandlers struct {
+	service services.PromotionService
+}
+
+// NewPromotionHandlers creates a new promotion handlers instance
+func NewPromotionHandlers(service services.PromotionService) *PromotionHandlers {
+	return &PromotionHandlers{service: service}
+}
+
+// GetPromotions handles GET /api/v1/promotions
+// @Summary List promotions
+// @Description Retrieves the promotions. A buy_get promotion discounts get_quantity of every buy_quantity + get_quantity eligible units of an order, cheapest first; a spend_get promotion discounts the eligible lines once they total min_spend. A promotion applies to one menu item, a category or, with neither set, every item.
+// @Tags Promotions
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=[]services.PromotionResponse} "Promotions retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/promotions [get]
+func (h *PromotionHandlers) GetPromotions(w http.ResponseWriter, r *http.Request) {
+	promotions, err := h.service.ListPromotions(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to list promotions", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to list promotions")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: promotions, Message: "Promotions retrieved successfully"})
+}
+
+// CreatePromotion handles POST /api/v1/promotions
+// @Summary Create promotion
+// @Description Creates a promotion, e.g. buy one get one free. Active promotions within their validity window are applied to new orders, which explain them in their promotions.
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Param promotion body services.PromotionRequest true "Promotion"
+// @Success 201 {object} SuccessResponse{data=services.PromotionResponse} "Promotion created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid promotion"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/promotions [post]
+func (h *PromotionHandlers) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	var req services.PromotionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	promotion, err := h.service.CreatePromotion(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create promotion")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: promotion, Message: "Promotion created successfully"})
+}
+
+// UpdatePromotion handles PUT /api/v1/promotions/{id}
+// @Summary Update promotion
+// @Description Replaces a promotion. Existing orders keep their discounts.
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Param id path int true "Promotion ID"
+// @Param promotion body services.PromotionRequest true "Promotion"
+// @Success 200 {object} SuccessResponse{data=services.PromotionResponse} "Promotion updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid promotion"
+// @Failure 404 {object} ErrorResponse "Promotion not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/promotions/{id} [put]
+func (h *PromotionHandlers) UpdatePromotion(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid promotion ID")
+		return
+	}
+	var req services.PromotionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	promotion, err := h.service.UpdatePromotion(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update promotion")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: promotion, Message: "Promotion updated successfully"})
+}
+
+// DeletePromotion handles DELETE /api/v1/promotions/{id}
+// @Summary Delete promotion
+// @Description Deletes a promotion. Existing orders keep their discounts and promotions.
+// @Tags Promotions
+// @Produce json
+// @Param id path int true "Promotion ID"
+// @Success 200 {object} SuccessResponse "Promotion deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid promotion ID"
+// @Failure 404 {object} ErrorResponse "Promotion not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/promotions/{id} [delete]
+func (h *PromotionHandlers) DeletePromotion(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid promotion ID")
+		return
+	}
+
+	if err := h.service.DeletePromotion(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "Failed to delete promotion")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Promotion deleted successfully"})
+}
+
+// writeServiceError maps a promotion service error to its status code
+func (h *PromotionHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrPromotionNotFound):
+		writeError(w, r, http.StatusNotFound, "Promotion not found")
+	case errors.Is(err, services.ErrInvalidPromotion):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// PurgeDeletedHandler handles POST /admin/purge-deleted
+// @Summary Purge soft-deleted rows
+// @Description Permanently deletes rows soft-deleted more than PURGE_DELETED_AFTER_DAYS days ago. With dry_run=true only counts them.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Param dry_run query bool false "Only count the rows that would be deleted"
+// @Success 200 {object} SuccessResponse{data=services.PurgeResult} "Purge result"
+// @Failure 400 {object} ErrorResponse "Invalid dry_run"
+// @Failure 503 {object} ErrorResponse "Read-only mode is enabled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/purge-deleted [post]
+func PurgeDeletedHandler(purger *services.SoftDeletePurger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dryRun := false
+		if value := r.URL.Query().Get("dry_run"); value != "" {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				writeError(w, r, http.StatusBadRequest, "dry_run must be a boolean")
+				return
+			}
+			dryRun = b
+		}
+		if !dryRun && middlewares.ReadOnly.Load() {
+			writeError(w, r, http.StatusServiceUnavailable, "Read-only mode is enabled")
+			return
+		}
+
+		result, err := purger.Purge(r.Context(), dryRun)
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to purge deleted rows", slog.String("error", err.Error()))
+			writeError(w, r, serviceErrorStatus(err), "Failed to purge deleted rows")
+			return
+		}
+
+		message := "Deleted rows purged successfully"
+		if dryRun {
+			message = "Dry run: no rows were deleted"
+		}
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    result,
+			Message: message,
+		})
+	}
+}
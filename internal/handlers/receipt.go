@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// receiptWidth is the number of characters per line of a receipt, as on 80 mm
+// thermal paper
+const receiptWidth = 42
+
+// GetOrderReceipt handles GET /api/v1/orders/{id}/receipt
+// @Summary Order receipt
+// @Description Plain-text receipt of an order, sized for 80 mm receipt printers, with its lines, subtotal, tax per rate and total, followed by the receipt_footer setting
+// @Tags Orders
+// @Produce plain
+// @Param id path string true "Order ID"
+// @Success 200 {string} string "Receipt"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/orders/{id}/receipt [get]
+func (h *OrderHandlers) GetOrderReceipt(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	order, err := h.service.GetOrderByID(r.Context(), id)
+	if errors.Is(err, services.ErrOrderNotFound) {
+		writeError(w, r, http.StatusNotFound, "Order not found")
+		return
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to get order", slog.String("id", id), slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to get order")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if _, err := w.Write([]byte(receipt(order, services.CurrentSettings(r.Context())))); err != nil {
+		logging.FromContext(r.Context()).Error("Failed to write response body", slog.String("error", err.Error()))
+	}
+}
+
+// orderLabel names an order on printouts by its number of the day, or the start of its
+// ID for orders placed before numbering was introduced
+func orderLabel(id string, number *int) string {
+	if number != nil {
+		return fmt.Sprintf("#%d", *number)
+	}
+	return id[:min(8, len(id))]
+}
+
+// receipt renders an order as a plain-text receipt, with the footer of its
+// restaurant's settings
+func receipt(order *services.OrderResponse, settings services.Settings) string {
+	var b strings.Builder
+	rule := strings.Repeat("-", receiptWidth) + "\n"
+	line := func(label string, amount decimal.Decimal) {
+		value := amount.StringFixed(2)
+		if width := receiptWidth - len(value) - 1; len(label) > width {
+			label = label[:width]
+		}
+		fmt.Fprintf(&b, "%-*s %s\n", receiptWidth-len(value)-1, label, value)
+	}
+
+	fmt.Fprintf(&b, "Order %s\n", orderLabel(order.ID, order.Number))
+	fmt.Fprintf(&b, "%s  %s\n", order.CreatedAt.Format(time.DateTime), strings.ReplaceAll(order.Channel, "_", " "))
+	if order.Table != nil {
+		fmt.Fprintf(&b, "Table %s\n", *order.Table)
+	}
+	b.WriteString(rule)
+	for _, item := range order.Items {
+		line(fmt.Sprintf("%d x %s", item.Quantity, item.Name), item.LineTotal)
+	}
+	b.WriteString(rule)
+	line("Subtotal", order.Subtotal)
+	// Promotions are listed by name; the rest of the discount is the coupon's
+	discount := order.Discount
+	for _, promotion := range order.Promotions {
+		line(promotion.Name, promotion.Discount.Neg())
+		discount = discount.Sub(promotion.Discount)
+	}
+	if order.LoyaltyPoints > 0 {
+		line(fmt.Sprintf("Loyalty %d pts", order.LoyaltyPoints), order.LoyaltyDiscount.Neg())
+		discount = discount.Sub(order.LoyaltyDiscount)
+	}
+	if discount.IsPositive() {
+		label := "Discount"
+		if order.CouponCode != nil {
+			label += " " + *order.CouponCode
+		}
+		line(label, discount.Neg())
+	}
+	for _, tax := range order.Taxes {
+		label := fmt.Sprintf("%s %s%%", tax.Name, tax.Rate.String())
+		if order.TaxIncluded {
+			label += " (included)"
+		}
+		line(label, tax.Amount)
+	}
+	line("TOTAL", order.Total)
+	if order.Tip.IsPositive() {
+		line("Tip", order.Tip)
+	}
+	for _, payment := range order.Payments {
+		line("Paid "+strings.ReplaceAll(payment.Method, "_", " "), payment.Amount.Neg())
+	}
+	if order.AmountPaid.IsPositive() || order.Tip.IsPositive() {
+		line("AMOUNT DUE", order.AmountDue)
+	}
+	if footer := settings.ReceiptFooter; footer != "" {
+		b.WriteString(rule)
+		b.WriteString(footer + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// RecommendationHandlers handles HTTP requests for menu item recommendations
+type RecommendationHandlers struct {
+	service services.RecommendationService
+}
+
+// NewRecommendationHandlers creates a new recommendation handlers instance
+func NewRecommendationHandlers(service services.RecommendationService) *RecommendationHandlers {
+	return &RecommendationHandlers{service: service}
+}
+
+// GetRecommendations handles GET /api/v1/recommendations/items/{id}
+// @Summary Items ordered together
+// @Description Lists the available menu items most often ordered along with a menu item, for upselling pairings. Items are ranked by the number of non-cancelled orders of the lookback period that included both, with that number's share of the item's orders. Items never ordered with it aren't listed.
+// @Tags Menu Items
+// @Produce json,xml,application/msgpack
+// @Param id path int true "Menu item ID"
+// @Param limit query int false "Number of recommendations (default 5, at most 20)"
+// @Param days query int false "Days of orders to look back over (default 90, at most 365)"
+// @Success 200 {object} SuccessResponse{data=services.RecommendationsResponse} "Recommendations retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid menu item ID, limit or days"
+// @Failure 404 {object} ErrorResponse "Menu item not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/recommendations/items/{id} [get]
+func (h *RecommendationHandlers) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid menu item ID")
+		return
+	}
+	var opts services.RecommendationOptions
+	for name, target := range map[string]*int{"limit": &opts.Limit, "days": &opts.Days} {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			writeError(w, r, http.StatusBadRequest, name+" must be a positive integer")
+			return
+		}
+		*target = n
+	}
+
+	recommendations, err := h.service.Recommendations(r.Context(), id, opts)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, r, http.StatusNotFound, "Menu item not found")
+		return
+	case err != nil:
+		logging.FromContext(r.Context()).Error("Failed to retrieve recommendations",
+			slog.String("error", err.Error()),
+			slog.Int("menu_item_id", id))
+		writeError(w, r, serviceErrorStatus(err), "Failed to retrieve recommendations")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: recommendations, Message: "Recommendations retrieved successfully"})
+}
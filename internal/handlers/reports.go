@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// ReportHandlers contains HTTP handlers for reports
+type ReportHandlers struct {
+	service services.ReportService
+}
+
+// NewReportHandlers creates a new report handlers instance
+func NewReportHandlers(service services.ReportService) *ReportHandlers {
+	return &ReportHandlers{service: service}
+}
+
+// GetSalesReport handles GET /api/v1/reports/sales
+// @Summary Sales summary
+// @Description Revenue, order count and average ticket of a period, grouped by day, category or item. Cancelled orders are not counted. Days are business days in the restaurant's timezone (see BUSINESS_DAY_START).
+// @Tags Reports
+// @Produce json,xml,application/msgpack
+// @Param from query string false "Start of the period, inclusive (RFC 3339, date, or Unix seconds; a bare date starts at the start of that business day; default: the start of the business day six days before to)"
+// @Param to query string false "End of the period, exclusive; a bare date includes that business day (default: now)"
+// @Param group_by query string false "Grouping: day (default), category or item"
+// @Success 200 {object} SuccessResponse{data=services.SalesReport} "Sales report generated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid period or grouping"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/reports/sales [get]
+func (h *ReportHandlers) GetSalesReport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := services.SalesReportOptions{GroupBy: query.Get("group_by")}
+	if opts.GroupBy == "" {
+		opts.GroupBy = services.SalesByDay
+	}
+	var ok bool
+	if opts.From, opts.To, ok = parseReportPeriod(w, r); !ok {
+		return
+	}
+
+	report, err := h.service.SalesReport(r.Context(), opts)
+	if errors.Is(err, services.ErrInvalidReport) {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to generate sales report", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to generate sales report")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: report, Message: "Sales report generated successfully"})
+}
+
+// GetTopItems handles GET /api/v1/reports/top-items
+// @Summary Top menu items
+// @Description Ranks the current menu items by revenue, quantity sold or number of orders over a period. Items without sales are ranked too, so order=asc lists the dishes that sell least. Cancelled orders are not counted.
+// @Tags Reports
+// @Produce json,xml,application/msgpack
+// @Param from query string false "Start of the period, inclusive (RFC 3339, date, or Unix seconds; a bare date starts at the start of that business day; default: the start of the business day six days before to)"
+// @Param to query string false "End of the period, exclusive; a bare date includes that business day (default: now)"
+// @Param category query string false "Only items of this category (appetizer, main, dessert, drink, side, fast food)"
+// @Param rank_by query string false "Ranking: revenue (default), quantity or orders"
+// @Param order query string false "desc (default) for best sellers first, asc for the weakest first"
+// @Param limit query int false "Number of items (default 10, at most 100)"
+// @Success 200 {object} SuccessResponse{data=services.TopItemsReport} "Top items report generated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid period, category, ranking or limit"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/reports/top-items [get]
+func (h *ReportHandlers) GetTopItems(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := services.TopItemsOptions{Category: query.Get("category"), RankBy: query.Get("rank_by")}
+	switch query.Get("order") {
+	case "", "desc":
+	case "asc":
+		opts.Ascending = true
+	default:
+		writeError(w, r, http.StatusBadRequest, "order must be asc or desc")
+		return
+	}
+	if value := query.Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			writeError(w, r, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		opts.Limit = n
+	}
+	var ok bool
+	if opts.From, opts.To, ok = parseReportPeriod(w, r); !ok {
+		return
+	}
+
+	report, err := h.service.TopItems(r.Context(), opts)
+	if errors.Is(err, services.ErrInvalidReport) {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to generate top items report", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to generate top items report")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: report, Message: "Top items report generated successfully"})
+}
+
+// ExportAccounting handles GET /api/v1/reports/accounting-export
+// @Summary Accounting export
+// @Description Journal-style CSV of the sales of a period, one balanced journal entry per business day with sales: the takings are debited to ACCOUNTING_DEPOSIT_ACCOUNT, their tax credited to ACCOUNTING_TAX_ACCOUNT and the rest to ACCOUNTING_SALES_ACCOUNT. The quickbooks format matches the QuickBooks Online journal entry import, the xero format the Xero manual journal import. Cancelled orders are not counted.
+// @Tags Reports
+// @Produce text/csv
+// @Param from query string false "Start of the period, inclusive (RFC 3339, date, or Unix seconds; a bare date starts at the start of that business day; default: the start of the business day six days before to)"
+// @Param to query string false "End of the period, exclusive; a bare date includes that business day (default: now)"
+// @Param format query string false "File format: quickbooks (default) or xero"
+// @Success 200 {string} string "Journal CSV"
+// @Failure 400 {object} ErrorResponse "Invalid period or format"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/reports/accounting-export [get]
+func (h *ReportHandlers) ExportAccounting(w http.ResponseWriter, r *http.Request) {
+	opts := services.AccountingExportOptions{Format: r.URL.Query().Get("format")}
+	if opts.Format == "" {
+		opts.Format = services.AccountingQuickBooks
+	}
+	var ok bool
+	if opts.From, opts.To, ok = parseReportPeriod(w, r); !ok {
+		return
+	}
+
+	entries, err := h.service.AccountingJournal(r.Context(), opts)
+	if errors.Is(err, services.ErrInvalidReport) {
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to generate accounting export", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to generate accounting export")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="journal_`+opts.Format+`.csv"`)
+	if err := services.WriteJournalCSV(w, opts.Format, entries); err != nil {
+		logging.FromContext(r.Context()).Error("Accounting export aborted", slog.String("error", err.Error()))
+	}
+}
+
+// parseReportPeriod parses the from and to parameters of a report, leaving those not
+// given zero. Bare dates stand for business days: from starts at the start of its
+// business day and to includes its whole business day. It writes a 400 response and
+// returns false when a timestamp is invalid.
+func parseReportPeriod(w http.ResponseWriter, r *http.Request) (from, to time.Time, ok bool) {
+	for name, target := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := strings.TrimSpace(r.URL.Query().Get(name))
+		if value == "" {
+			continue
+		}
+		t, err := services.ParseTimestamp(value)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, name+": "+err.Error())
+			return from, to, false
+		}
+		if _, err := time.Parse(time.DateOnly, value); err == nil {
+			if name == "to" {
+				t = t.AddDate(0, 0, 1)
+			}
+			t = services.StartOfBusinessDay(t)
+		}
+		*target = t
+	}
+	return from, to, true
+}
+
+// RefreshReportViewsHandler handles POST /admin/reports/refresh
+// @Summary Refresh report views
+// @Description Recomputes the materialized views of pre-aggregated sales (report_daily_sales, report_item_sales) now rather than at the next refresh_reports run
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} SuccessResponse{data=services.ViewRefreshResult} "Report views refreshed"
+// @Failure 503 {object} ErrorResponse "Read-only mode is enabled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/reports/refresh [post]
+func RefreshReportViewsHandler(refresher *services.ReportViewRefresher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if middlewares.ReadOnly.Load() {
+			writeError(w, r, http.StatusServiceUnavailable, "Read-only mode is enabled")
+			return
+		}
+
+		result, err := refresher.Refresh(r.Context())
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to refresh report views", slog.String("error", err.Error()))
+			writeError(w, r, serviceErrorStatus(err), "Failed to refresh report views")
+			return
+		}
+
+		writeJSON(w, r, http.StatusOK, SuccessResponse{Data: result, Message: "Report views refreshed"})
+	}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// writeJSON encodes v in the requested field naming convention and writes it with
+// the given status code
+func writeJSON(w http.ResponseWriter, r *http.Request, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	v, err := withResponseCase(w, r, v)
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(statusCode)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		logging.FromContext(r.Context()).Error("Failed to write response body", slog.String("error", err.Error()))
+	}
+}
+
+// writeError writes an ErrorResponse with the given status code and message
+func writeError(w http.ResponseWriter, r *http.Request, statusCode int, message string) {
+	writeJSON(w, r, statusCode, ErrorResponse{
+		Error:   http.StatusText(statusCode),
+		Message: message,
+		Code:    statusCode,
+	})
+}
+
+// requestBodyError maps a failure to read or decode the request body to a status code
+// and message: 413 when the body exceeded the size limit, 400 otherwise
+func requestBodyError(err error, invalidMessage string) (int, string) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body must not exceed %d bytes", tooLarge.Limit)
+	}
+	return http.StatusBadRequest, invalidMessage
+}
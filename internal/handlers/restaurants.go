@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/restaurants"
+)
+
+// RestaurantsHandler handles GET /admin/restaurants
+// @Summary Restaurants
+// @Description Lists the restaurants served by the deployment. API requests name theirs with the X-Restaurant-ID header, and default to restaurant 1.
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} SuccessResponse{data=[]restaurants.Restaurant} "Restaurants"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/restaurants [get]
+func RestaurantsHandler(directory *restaurants.Directory) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		list, err := directory.List(r.Context())
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to list restaurants", slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to list restaurants")
+			return
+		}
+
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    list,
+			Message: "Restaurants retrieved successfully",
+		})
+	}
+}
+
+// CreateRestaurantHandler handles POST /admin/restaurants
+// @Summary Create a restaurant
+// @Description Adds a restaurant with its own menu, orders, tables, staff and settings. It starts empty, with the default settings.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param restaurant body restaurants.CreateRequest true "Restaurant"
+// @Success 201 {object} SuccessResponse{data=restaurants.Restaurant} "Restaurant created"
+// @Failure 400 {object} ErrorResponse "Invalid restaurant"
+// @Failure 409 {object} ErrorResponse "Slug already exists"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/restaurants [post]
+func CreateRestaurantHandler(directory *restaurants.Directory) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req restaurants.CreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			status, message := requestBodyError(err, "Invalid JSON format")
+			writeError(w, r, status, message)
+			return
+		}
+
+		restaurant, err := directory.Create(r.Context(), req)
+		if errors.Is(err, restaurants.ErrInvalidRestaurant) {
+			writeError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		if errors.Is(err, restaurants.ErrExists) {
+			writeError(w, r, http.StatusConflict, err.Error())
+			return
+		}
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to create restaurant", slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to create restaurant")
+			return
+		}
+
+		logging.FromContext(r.Context()).Info("Restaurant created",
+			slog.Int("restaurant_id", restaurant.ID),
+			slog.String("slug", restaurant.Slug))
+		writeJSON(w, r, http.StatusCreated, SuccessResponse{
+			Data:    restaurant,
+			Message: "Restaurant created",
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// ReviewHandlers handles HTTP requests for menu item reviews
+type ReviewHandlers struct {
+	service services.ReviewService
+}
+
+// NewReviewHandlers creates a new review handlers instance
+func NewReviewHandlers(service services.ReviewService) *ReviewHandlers {
+	return &ReviewHandlers{service: service}
+}
+
+// CreateReview handles POST /api/v1/items/{id}/reviews
+// @Summary Review menu item
+// @Description Rates a menu item from 1 to 5, with an optional comment. With order_id, the order must include the item, and the review is shown as verified; each item of an order can be reviewed once. Reviews tripping a spam or abuse check (link, blocked_word, shouting, repeated_characters, repeated) are flagged and wait for a manager as pending; the others are approved right away.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Menu item ID"
+// @Param review body services.CreateReviewRequest true "Review"
+// @Success 201 {object} SuccessResponse{data=services.ReviewResponse} "Review created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid review"
+// @Failure 404 {object} ErrorResponse "Menu item not found"
+// @Failure 409 {object} ErrorResponse "Item already reviewed for the order"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/items/{id}/reviews [post]
+func (h *ReviewHandlers) CreateReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid menu item ID")
+		return
+	}
+	var req services.CreateReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	review, err := h.service.CreateReview(r.Context(), id, req, middlewares.ClientIP(r))
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create review")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: review, Message: "Review created successfully"})
+}
+
+// GetItemReviews handles GET /api/v1/reviews/items/{id}
+// @Summary Menu item reviews
+// @Description Retrieves a menu item's approved reviews, newest first, with their average rating and the number of reviews per rating from 1 to 5
+// @Tags Reviews
+// @Produce json,xml,application/msgpack
+// @Param id path int true "Menu item ID"
+// @Param limit query int false "Page size (default 50, at most 200)"
+// @Param offset query int false "Number of reviews to skip"
+// @Success 200 {object} SuccessResponse{data=services.ItemReviewsResponse} "Reviews retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid menu item ID or paging parameters"
+// @Failure 404 {object} ErrorResponse "Menu item not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/reviews/items/{id} [get]
+func (h *ReviewHandlers) GetItemReviews(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid menu item ID")
+		return
+	}
+	var opts services.ReviewListOptions
+	if !parseReviewPaging(w, r, &opts) {
+		return
+	}
+
+	reviews, err := h.service.GetItemReviews(r.Context(), id, opts)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to retrieve reviews")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: reviews, Message: "Reviews retrieved successfully"})
+}
+
+// GetReviews handles GET /api/v1/reviews
+// @Summary List reviews for moderation
+// @Description Retrieves reviews of any status, newest first, with their flags and order
+// @Tags Reviews
+// @Produce json,xml,application/msgpack
+// @Param status query string false "Filter by status (pending, approved, rejected)"
+// @Param flagged query bool false "Only reviews with flags"
+// @Param item_id query int false "Only the reviews of this menu item"
+// @Param limit query int false "Page size (default 50, at most 200)"
+// @Param offset query int false "Number of reviews to skip"
+// @Success 200 {object} SuccessResponse{data=[]services.ReviewResponse} "Reviews retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid filter or paging parameters"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/reviews [get]
+func (h *ReviewHandlers) GetReviews(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := services.ReviewListOptions{Status: query.Get("status")}
+	if !parseReviewPaging(w, r, &opts) {
+		return
+	}
+	if value := query.Get("flagged"); value != "" {
+		flagged, err := strconv.ParseBool(value)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "flagged must be true or false")
+			return
+		}
+		opts.Flagged = flagged
+	}
+	if value := query.Get("item_id"); value != "" {
+		itemID, err := strconv.Atoi(value)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "Invalid menu item ID")
+			return
+		}
+		opts.MenuItemID = itemID
+	}
+
+	reviews, err := h.service.ListReviews(r.Context(), opts)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to list reviews")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: reviews, Message: "Reviews retrieved successfully"})
+}
+
+// ModerateReview handles PATCH /api/v1/reviews/{id}
+// @Summary Moderate review
+// @Description Approves or rejects a review. Approved reviews are shown on their menu item whatever their flags; rejected ones are hidden.
+// @Tags Reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param moderation body services.ModerateReviewRequest true "New status"
+// @Success 200 {object} SuccessResponse{data=services.ReviewResponse} "Review moderated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid review ID or status"
+// @Failure 404 {object} ErrorResponse "Review not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/reviews/{id} [patch]
+func (h *ReviewHandlers) ModerateReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid review ID")
+		return
+	}
+	var req services.ModerateReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	review, err := h.service.ModerateReview(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to moderate review")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: review, Message: "Review moderated successfully"})
+}
+
+// DeleteReview handles DELETE /api/v1/reviews/{id}
+// @Summary Delete review
+// @Description Deletes a review
+// @Tags Reviews
+// @Produce json
+// @Param id path int true "Review ID"
+// @Success 200 {object} SuccessResponse "Review deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid review ID"
+// @Failure 404 {object} ErrorResponse "Review not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/reviews/{id} [delete]
+func (h *ReviewHandlers) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid review ID")
+		return
+	}
+
+	if err := h.service.DeleteReview(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "Failed to delete review")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Review deleted successfully"})
+}
+
+// parseReviewPaging reads the limit and offset parameters into opts. It writes a 400
+// response and returns false when one is invalid.
+func parseReviewPaging(w http.ResponseWriter, r *http.Request, opts *services.ReviewListOptions) bool {
+	for name, target := range map[string]*int{"limit": &opts.Limit, "offset": &opts.Offset} {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			writeError(w, r, http.StatusBadRequest, name+" must be a non-negative integer")
+			return false
+		}
+		*target = n
+	}
+	return true
+}
+
+// writeServiceError maps a review service error to its status code
+func (h *ReviewHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		writeError(w, r, http.StatusNotFound, "Menu item not found")
+	case errors.Is(err, services.ErrReviewNotFound):
+		writeError(w, r, http.StatusNotFound, "Review not found")
+	case errors.Is(err, services.ErrInvalidReview):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrReviewExists):
+		writeError(w, r, http.StatusConflict, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SettingsHandlers contains HTTP handlers for the settings of the request's restaurant
+type SettingsHandlers struct {
+	service services.SettingsService
+}
+
+// NewSettingsHandlers creates a new settings handlers instance
+func NewSettingsHandlers(service services.SettingsService) *SettingsHandlers {
+	return &SettingsHandlers{service: service}
+}
+
+// GetSettings handles GET /api/v1/settings
+// @Summary Get settings
+// @Description Retrieves the business settings of the request's restaurant: timezone, currency, tax mode, service charge and receipt footer. Settings never changed through the API keep their defaults from the server's environment.
+// @Tags Settings
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=services.Settings} "Settings retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/settings [get]
+func (h *SettingsHandlers) GetSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.service.GetSettings(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to retrieve settings", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to retrieve settings")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: settings, Message: "Settings retrieved successfully"})
+}
+
+// UpdateSettings handles PATCH /api/v1/settings
+// @Summary Update settings
+// @Description Changes the settings set in the request and leaves the others. The timezone and tax mode apply to the next requests; orders keep the tax mode they were placed with. Timestamps and business days of every restaurant use the default restaurant's timezone, so only it can change the timezone; business days start at BUSINESS_DAY_START for every restaurant, so business_day_start is rejected. Other instances pick changes up within a minute.
+// @Tags Settings
+// @Accept json
+// @Produce json
+// @Param settings body services.SettingsPatch true "Settings to change"
+// @Success 200 {object} SuccessResponse{data=services.Settings} "Settings updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid settings"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/settings [patch]
+func (h *SettingsHandlers) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	var patch services.SettingsPatch
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(r.Context(), patch)
+	switch {
+	case errors.Is(err, services.ErrInvalidSettings):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		logging.FromContext(r.Context()).Error("Failed to update settings", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to update settings")
+		return
+	}
+
+	logging.FromContext(r.Context()).Info("Settings updated",
+		slog.String("timezone", settings.Timezone),
+		slog.String("currency", settings.Currency),
+		slog.String("tax_mode", settings.TaxMode))
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: settings, Message: "Settings updated successfully"})
+}
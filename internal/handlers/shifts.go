@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// GetShifts handles GET /api/v1/shifts
+// @Summary List shifts
+// @Description Retrieves the shifts starting in a period, by start time, at most 62 days at once
+// @Tags Staff
+// @Produce json,xml,application/msgpack
+// @Param from query string false "Start of the period, inclusive (RFC 3339, date, or Unix seconds; a bare date starts at the start of that business day; default: the start of today's business day)"
+// @Param to query string false "End of the period, exclusive; a bare date includes that business day (default: seven business days after from)"
+// @Param staff_id query int false "Only the shifts of this staff member"
+// @Success 200 {object} SuccessResponse{data=[]services.ShiftResponse} "Shifts retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid period or staff member ID"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/shifts [get]
+func (h *StaffHandlers) GetShifts(w http.ResponseWriter, r *http.Request) {
+	var opts services.ShiftListOptions
+	var ok bool
+	if opts.From, opts.To, ok = parseReportPeriod(w, r); !ok {
+		return
+	}
+	if value := r.URL.Query().Get("staff_id"); value != "" {
+		staffID, err := strconv.Atoi(value)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "Invalid staff member ID")
+			return
+		}
+		opts.StaffID = &staffID
+	}
+
+	shifts, err := h.service.ListShifts(r.Context(), opts)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to list shifts")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: shifts, Message: "Shifts retrieved successfully"})
+}
+
+// CreateShift handles POST /api/v1/shifts
+// @Summary Create shift
+// @Description Schedules an active staff member for a shift of at most 16 hours, in their role unless role is given. Fails with 409 if it overlaps another of their shifts; back-to-back shifts are fine.
+// @Tags Staff
+// @Accept json
+// @Produce json
+// @Param shift body services.ShiftRequest true "Shift"
+// @Success 201 {object} SuccessResponse{data=services.ShiftResponse} "Shift created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid shift"
+// @Failure 409 {object} ErrorResponse "Shift overlaps another shift of the staff member"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/shifts [post]
+func (h *StaffHandlers) CreateShift(w http.ResponseWriter, r *http.Request) {
+	var req services.ShiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	shift, err := h.service.CreateShift(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create shift")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: shift, Message: "Shift created successfully"})
+}
+
+// UpdateShift handles PUT /api/v1/shifts/{id}
+// @Summary Update shift
+// @Description Replaces a shift, which may move it to another staff member
+// @Tags Staff
+// @Accept json
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Param shift body services.ShiftRequest true "Shift"
+// @Success 200 {object} SuccessResponse{data=services.ShiftResponse} "Shift updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid shift"
+// @Failure 404 {object} ErrorResponse "Shift not found"
+// @Failure 409 {object} ErrorResponse "Shift overlaps another shift of the staff member"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/shifts/{id} [put]
+func (h *StaffHandlers) UpdateShift(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid shift ID")
+		return
+	}
+	var req services.ShiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	shift, err := h.service.UpdateShift(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update shift")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: shift, Message: "Shift updated successfully"})
+}
+
+// DeleteShift handles DELETE /api/v1/shifts/{id}
+// @Summary Delete shift
+// @Description Deletes a shift
+// @Tags Staff
+// @Produce json
+// @Param id path int true "Shift ID"
+// @Success 200 {object} SuccessResponse "Shift deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid shift ID"
+// @Failure 404 {object} ErrorResponse "Shift not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/shifts/{id} [delete]
+func (h *StaffHandlers) DeleteShift(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid shift ID")
+		return
+	}
+
+	if err := h.service.DeleteShift(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "Failed to delete shift")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Shift deleted successfully"})
+}
+
+// GetWeeklySchedule handles GET /api/v1/schedule/week
+// @Summary Weekly schedule
+// @Description The staff schedule of a week, Monday to Sunday in business days: the shifts of each day, by the business day they start in, and the scheduled hours of each day, each staff member and the week. Active staff without shifts are listed with none.
+// @Tags Staff
+// @Produce json,xml,application/msgpack
+// @Param date query string false "Any day of the week, as a date or timestamp (default: today)"
+// @Success 200 {object} SuccessResponse{data=services.WeeklyScheduleResponse} "Schedule retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid date"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/schedule/week [get]
+func (h *StaffHandlers) GetWeeklySchedule(w http.ResponseWriter, r *http.Request) {
+	date := time.Now()
+	if value := strings.TrimSpace(r.URL.Query().Get("date")); value != "" {
+		t, err := services.ParseTimestamp(value)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "date: "+err.Error())
+			return
+		}
+		// A bare date stands for its business day
+		if _, err := time.Parse(time.DateOnly, value); err == nil {
+			t = services.StartOfBusinessDay(t)
+		}
+		date = t
+	}
+
+	schedule, err := h.service.WeeklySchedule(r.Context(), date)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to retrieve schedule")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: schedule, Message: "Schedule retrieved successfully"})
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// StaffHandlers contains HTTP handlers for staff and their shifts
+type StaffHandlers struct {
+	service services.StaffService
+}
+
+// NewStaffHandlers creates a new staff handlers instance
+func NewStaffHandlers(service services.StaffService) *StaffHandlers {
+	return &StaffHandlers{service: service}
+}
+
+// GetStaff handles GET /api/v1/staff
+// @Summary List staff
+// @Description Retrieves the restaurant's staff by name
+// @Tags Staff
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=[]services.StaffResponse} "Staff retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/staff [get]
+func (h *StaffHandlers) GetStaff(w http.ResponseWriter, r *http.Request) {
+	staff, err := h.service.ListStaff(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to list staff", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to list staff")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: staff, Message: "Staff retrieved successfully"})
+}
+
+// CreateStaff handles POST /api/v1/staff
+// @Summary Create staff member
+// @Description Creates a staff member with the role they are scheduled in by default, e.g. server or cook
+// @Tags Staff
+// @Accept json
+// @Produce json
+// @Param staff body services.StaffRequest true "Staff member"
+// @Success 201 {object} SuccessResponse{data=services.StaffResponse} "Staff member created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid staff member"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/staff [post]
+func (h *StaffHandlers) CreateStaff(w http.ResponseWriter, r *http.Request) {
+	var req services.StaffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	member, err := h.service.CreateStaff(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create staff member")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: member, Message: "Staff member created successfully"})
+}
+
+// UpdateStaff handles PUT /api/v1/staff/{id}
+// @Summary Update staff member
+// @Description Replaces a staff member. Their shifts keep the role they were scheduled in; inactive staff can't be scheduled.
+// @Tags Staff
+// @Accept json
+// @Produce json
+// @Param id path int true "Staff member ID"
+// @Param staff body services.StaffRequest true "Staff member"
+// @Success 200 {object} SuccessResponse{data=services.StaffResponse} "Staff member updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid staff member"
+// @Failure 404 {object} ErrorResponse "Staff member not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/staff/{id} [put]
+func (h *StaffHandlers) UpdateStaff(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid staff member ID")
+		return
+	}
+	var req services.StaffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	member, err := h.service.UpdateStaff(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update staff member")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: member, Message: "Staff member updated successfully"})
+}
+
+// DeleteStaff handles DELETE /api/v1/staff/{id}
+// @Summary Delete staff member
+// @Description Deletes a staff member and their shifts. Fails with 409 once they clocked in, as their punches are payroll records; make staff who left inactive instead, which also keeps their past shifts.
+// @Tags Staff
+// @Produce json
+// @Param id path int true "Staff member ID"
+// @Success 200 {object} SuccessResponse "Staff member deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid staff member ID"
+// @Failure 404 {object} ErrorResponse "Staff member not found"
+// @Failure 409 {object} ErrorResponse "Staff member has time punches"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/staff/{id} [delete]
+func (h *StaffHandlers) DeleteStaff(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid staff member ID")
+		return
+	}
+
+	if err := h.service.DeleteStaff(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "Failed to delete staff member")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Staff member deleted successfully"})
+}
+
+// writeServiceError maps a staff service error to its status code
+func (h *StaffHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrStaffNotFound):
+		writeError(w, r, http.StatusNotFound, "Staff member not found")
+	case errors.Is(err, services.ErrShiftNotFound):
+		writeError(w, r, http.StatusNotFound, "Shift not found")
+	case errors.Is(err, services.ErrInvalidStaff), errors.Is(err, services.ErrInvalidShift), errors.Is(err, services.ErrInvalidReport):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrShiftOverlap), errors.Is(err, services.ErrStaffHasPunches),
+		errors.Is(err, services.ErrAlreadyClockedIn), errors.Is(err, services.ErrNotClockedIn):
+		writeError(w, r, http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrNoScheduledShift):
+		writeError(w, r, http.StatusUnprocessableEntity, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
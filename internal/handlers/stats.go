@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// StatsHandlers contains HTTP handlers for dashboard statistics
+type StatsHandlers struct {
+	service services.StatsService
+}
+
+// NewStatsHandlers creates a new statistics handlers instance
+func NewStatsHandlers(service services.StatsService) *StatsHandlers {
+	return &StatsHandlers{service: service}
+}
+
+// GetDashboard handles GET /api/v1/stats/dashboard
+// @Summary Dashboard statistics
+// @Description Active menu item counts, overall and per category, and the order count, revenue and average ticket of the current business day (see BUSINESS_DAY_START). Cancelled orders are not counted.
+// @Tags Reports
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=services.DashboardStats} "Dashboard statistics retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/stats/dashboard [get]
+func (h *StatsHandlers) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.Dashboard(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to compute dashboard statistics", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to compute dashboard statistics")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: stats, Message: "Dashboard statistics retrieved successfully"})
+}
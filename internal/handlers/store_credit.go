@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// StoreCreditHandlers contains HTTP handlers for store credit operations
+type StoreCreditHandlers struct {
+	service services.StoreCreditService
+}
+
+// NewStoreCreditHandlers creates a new store credit handlers instance
+func NewStoreCreditHandlers(service services.StoreCreditService) *StoreCreditHandlers {
+	return &StoreCreditHandlers{service: service}
+}
+
+// GetStoreCredit handles GET /api/v1/customers/{id}/store-credit
+// @Summary Get customer store credit
+// @Description Retrieves a customer's store credit balance and the ledger of its credits and debits, newest first, each with the order it concerns and the ID of the request that made it. Customers are identified by their phone number; customers who were never credited have none.
+// @Tags Store Credit
+// @Produce json,xml,application/msgpack
+// @Param id path string true "Customer phone number"
+// @Success 200 {object} SuccessResponse{data=services.StoreCreditResponse} "Store credit retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customers/{id}/store-credit [get]
+func (h *StoreCreditHandlers) GetStoreCredit(w http.ResponseWriter, r *http.Request) {
+	credit, err := h.service.GetStoreCredit(r.Context(), r.PathValue("id"))
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to get store credit", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to get store credit")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: credit, Message: "Store credit retrieved successfully"})
+}
+
+// CreditStoreCredit handles POST /api/v1/customers/{id}/store-credit/credits
+// @Summary Credit store credit
+// @Description Adds to a customer's store credit, refunding one of their orders (reason refund, with its order_id) or as a goodwill gesture. A refund may return at most what was paid for the order, less what was already refunded to credit.
+// @Tags Store Credit
+// @Accept json
+// @Produce json
+// @Param id path string true "Customer phone number"
+// @Param credit body services.StoreCreditRequest true "Credit"
+// @Success 201 {object} SuccessResponse{data=services.StoreCreditEntryResponse} "Store credit credited successfully"
+// @Failure 400 {object} ErrorResponse "Invalid credit"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customers/{id}/store-credit/credits [post]
+func (h *StoreCreditHandlers) CreditStoreCredit(w http.ResponseWriter, r *http.Request) {
+	var req services.StoreCreditRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+	req.RequestID = middlewares.RequestID(r.Context())
+
+	entry, err := h.service.CreditStoreCredit(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to credit store credit")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: entry, Message: "Store credit credited successfully"})
+}
+
+// DebitStoreCredit handles POST /api/v1/customers/{id}/store-credit/debits
+// @Summary Debit store credit
+// @Description Takes an adjustment off a customer's store credit. Orders are paid with store credit through POST /orders/{id}/payments instead.
+// @Tags Store Credit
+// @Accept json
+// @Produce json
+// @Param id path string true "Customer phone number"
+// @Param debit body services.StoreCreditRequest true "Debit"
+// @Success 201 {object} SuccessResponse{data=services.StoreCreditEntryResponse} "Store credit debited successfully"
+// @Failure 400 {object} ErrorResponse "Invalid debit"
+// @Failure 422 {object} ErrorResponse "The customer's store credit is too low"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/customers/{id}/store-credit/debits [post]
+func (h *StoreCreditHandlers) DebitStoreCredit(w http.ResponseWriter, r *http.Request) {
+	var req services.StoreCreditRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+	req.RequestID = middlewares.RequestID(r.Context())
+
+	entry, err := h.service.DebitStoreCredit(r.Context(), r.PathValue("id"), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to debit store credit")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: entry, Message: "Store credit debited successfully"})
+}
+
+// writeServiceError maps a store credit service error to its status code
+func (h *StoreCreditHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrOrderNotFound):
+		writeError(w, r, http.StatusNotFound, "Order not found")
+	case errors.Is(err, services.ErrInvalidStoreCredit):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrStoreCreditTooLow):
+		writeError(w, r, http.StatusUnprocessableEntity, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// TableHandlers contains HTTP handlers for dining table operations
+type TableHandlers struct {
+	service services.TableService
+}
+
+// NewTableHandlers creates a new table handlers instance
+func NewTableHandlers(service services.TableService) *TableHandlers {
+	return &TableHandlers{service: service}
+}
+
+// GetTables handles GET /api/v1/tables
+// @Summary List tables
+// @Description Retrieves the restaurant's tables by name, with the token their QR code carries and, when GUEST_ORDER_URL is set, the link to encode in it
+// @Tags Tables
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=[]services.TableResponse} "Tables retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tables [get]
+func (h *TableHandlers) GetTables(w http.ResponseWriter, r *http.Request) {
+	tables, err := h.service.ListTables(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to list tables", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to list tables")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: tables, Message: "Tables retrieved successfully"})
+}
+
+// CreateTable handles POST /api/v1/tables
+// @Summary Create table
+// @Description Creates a table with a new QR code token. Guests scanning the code order for the table.
+// @Tags Tables
+// @Accept json
+// @Produce json
+// @Param table body services.TableRequest true "Table"
+// @Success 201 {object} SuccessResponse{data=services.TableResponse} "Table created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid table"
+// @Failure 409 {object} ErrorResponse "Another table has the name"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tables [post]
+func (h *TableHandlers) CreateTable(w http.ResponseWriter, r *http.Request) {
+	var req services.TableRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	table, err := h.service.CreateTable(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create table")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: table, Message: "Table created successfully"})
+}
+
+// UpdateTable handles PUT /api/v1/tables/{id}
+// @Summary Rename table
+// @Description Renames a table. Its QR code keeps working; orders keep the name the table had when they were placed.
+// @Tags Tables
+// @Accept json
+// @Produce json
+// @Param id path int true "Table ID"
+// @Param table body services.TableRequest true "Table"
+// @Success 200 {object} SuccessResponse{data=services.TableResponse} "Table updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid table"
+// @Failure 404 {object} ErrorResponse "Table not found"
+// @Failure 409 {object} ErrorResponse "Another table has the name"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tables/{id} [put]
+func (h *TableHandlers) UpdateTable(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid table ID")
+		return
+	}
+	var req services.TableRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	table, err := h.service.UpdateTable(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update table")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: table, Message: "Table updated successfully"})
+}
+
+// DeleteTable handles DELETE /api/v1/tables/{id}
+// @Summary Delete table
+// @Description Deletes a table and ends its guest sessions. Orders keep the table's name.
+// @Tags Tables
+// @Produce json
+// @Param id path int true "Table ID"
+// @Success 200 {object} SuccessResponse "Table deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid table ID"
+// @Failure 404 {object} ErrorResponse "Table not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tables/{id} [delete]
+func (h *TableHandlers) DeleteTable(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid table ID")
+		return
+	}
+
+	if err := h.service.DeleteTable(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "Failed to delete table")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Table deleted successfully"})
+}
+
+// RotateTableToken handles POST /api/v1/tables/{id}/token
+// @Summary Replace table QR code
+// @Description Gives a table a new QR code token, as when its code was copied. The old code stops working and the table's open guest sessions end.
+// @Tags Tables
+// @Produce json
+// @Param id path int true "Table ID"
+// @Success 200 {object} SuccessResponse{data=services.TableResponse} "Table token replaced successfully"
+// @Failure 400 {object} ErrorResponse "Invalid table ID"
+// @Failure 404 {object} ErrorResponse "Table not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tables/{id}/token [post]
+func (h *TableHandlers) RotateTableToken(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid table ID")
+		return
+	}
+
+	table, err := h.service.RotateTableToken(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to replace table token")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: table, Message: "Table token replaced successfully"})
+}
+
+// UpdateTableStatus handles PATCH /api/v1/tables/{id}/status
+// @Summary Update table status
+// @Description Sets where a table is in its service: free, seated, ordered, check_dropped or needs_cleaning. Any status can follow any other, to correct a mistake. A change is published to real-time clients as table.status_updated.
+// @Tags Tables
+// @Accept json
+// @Produce json
+// @Param id path int true "Table ID"
+// @Param status body services.TableStatusRequest true "Table status"
+// @Success 200 {object} SuccessResponse{data=services.TableResponse} "Table status updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid table ID or status"
+// @Failure 404 {object} ErrorResponse "Table not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tables/{id}/status [patch]
+func (h *TableHandlers) UpdateTableStatus(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid table ID")
+		return
+	}
+	var req services.TableStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	table, err := h.service.UpdateTableStatus(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update table status")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: table, Message: "Table status updated successfully"})
+}
+
+// writeServiceError maps a table service error to its status code
+func (h *TableHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrTableNotFound):
+		writeError(w, r, http.StatusNotFound, "Table not found")
+	case errors.Is(err, services.ErrInvalidTable), errors.Is(err, services.ErrInvalidTableStatus):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrTableExists):
+		writeError(w, r, http.StatusConflict, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/scheduler"
+)
+
+// TasksHandler handles GET /admin/tasks
+// @Summary Scheduled tasks
+// @Description Lists the periodic tasks with their schedule, next run and last run
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} SuccessResponse{data=[]scheduler.TaskInfo} "Scheduled tasks"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/tasks [get]
+func TasksHandler(sched *scheduler.Scheduler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tasks, err := sched.Tasks(r.Context())
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to list scheduled tasks", slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to list scheduled tasks")
+			return
+		}
+
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    tasks,
+			Message: "Scheduled tasks retrieved successfully",
+		})
+	}
+}
+
+// TaskRunsHandler handles GET /admin/tasks/runs
+// @Summary Scheduled task runs
+// @Description Returns the run history of the periodic tasks, newest first
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Param task query string false "Only runs of this task"
+// @Param limit query int false "Maximum number of runs (default 100, at most 500)"
+// @Success 200 {object} SuccessResponse{data=[]scheduler.Run} "Task runs"
+// @Failure 400 {object} ErrorResponse "Invalid limit"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/tasks/runs [get]
+func TaskRunsHandler(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := 0
+		if value := r.URL.Query().Get("limit"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				writeError(w, r, http.StatusBadRequest, "limit must be a positive integer")
+				return
+			}
+			limit = n
+		}
+
+		runs, err := scheduler.ListRuns(r.Context(), db, r.URL.Query().Get("task"), limit)
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to list task runs", slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to list task runs")
+			return
+		}
+		if runs == nil {
+			runs = []scheduler.Run{}
+		}
+
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    runs,
+			Message: "Task runs retrieved successfully",
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// TaxRateHandlers contains HTTP handlers for tax rate operations
+type TaxRateHandlers struct {
+	service services.TaxRateService
+}
+
+// NewTaxRateHandlers creates a new tax rate handlers instance
+func NewTaxRateHandlers(service services.TaxRateService) *TaxRateHandlers {
+	return &TaxRateHandlers{service: service}
+}
+
+// GetTaxRates handles GET /api/v1/tax-rates
+// @Summary List tax rates
+// @Description Retrieves the tax rates. A rate applies to one menu item, to a category, or, with neither set, to every other item.
+// @Tags Tax Rates
+// @Produce json,xml,application/msgpack
+// @Success 200 {object} SuccessResponse{data=[]services.TaxRateResponse} "Tax rates retrieved successfully"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tax-rates [get]
+func (h *TaxRateHandlers) GetTaxRates(w http.ResponseWriter, r *http.Request) {
+	rates, err := h.service.ListTaxRates(r.Context())
+	if err != nil {
+		logging.FromContext(r.Context()).Error("Failed to list tax rates", slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to list tax rates")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: rates, Message: "Tax rates retrieved successfully"})
+}
+
+// CreateTaxRate handles POST /api/v1/tax-rates
+// @Summary Create tax rate
+// @Description Creates a tax rate for a menu item, a category, or the default rate. New orders are taxed with it; existing orders keep their tax.
+// @Tags Tax Rates
+// @Accept json
+// @Produce json
+// @Param taxRate body services.TaxRateRequest true "Tax rate"
+// @Success 201 {object} SuccessResponse{data=services.TaxRateResponse} "Tax rate created successfully"
+// @Failure 400 {object} ErrorResponse "Invalid tax rate"
+// @Failure 409 {object} ErrorResponse "The menu item, category or default already has a rate"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tax-rates [post]
+func (h *TaxRateHandlers) CreateTaxRate(w http.ResponseWriter, r *http.Request) {
+	var req services.TaxRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	rate, err := h.service.CreateTaxRate(r.Context(), req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to create tax rate")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: rate, Message: "Tax rate created successfully"})
+}
+
+// UpdateTaxRate handles PUT /api/v1/tax-rates/{id}
+// @Summary Update tax rate
+// @Description Replaces a tax rate. New orders are taxed with it; existing orders keep their tax.
+// @Tags Tax Rates
+// @Accept json
+// @Produce json
+// @Param id path int true "Tax rate ID"
+// @Param taxRate body services.TaxRateRequest true "Tax rate"
+// @Success 200 {object} SuccessResponse{data=services.TaxRateResponse} "Tax rate updated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid tax rate"
+// @Failure 404 {object} ErrorResponse "Tax rate not found"
+// @Failure 409 {object} ErrorResponse "The menu item, category or default already has a rate"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tax-rates/{id} [put]
+func (h *TaxRateHandlers) UpdateTaxRate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid tax rate ID")
+		return
+	}
+	var req services.TaxRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	rate, err := h.service.UpdateTaxRate(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to update tax rate")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: rate, Message: "Tax rate updated successfully"})
+}
+
+// DeleteTaxRate handles DELETE /api/v1/tax-rates/{id}
+// @Summary Delete tax rate
+// @Description Deletes a tax rate. Existing orders keep their tax.
+// @Tags Tax Rates
+// @Produce json
+// @Param id path int true "Tax rate ID"
+// @Success 200 {object} SuccessResponse "Tax rate deleted successfully"
+// @Failure 400 {object} ErrorResponse "Invalid tax rate ID"
+// @Failure 404 {object} ErrorResponse "Tax rate not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/tax-rates/{id} [delete]
+func (h *TaxRateHandlers) DeleteTaxRate(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid tax rate ID")
+		return
+	}
+
+	if err := h.service.DeleteTaxRate(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "Failed to delete tax rate")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Tax rate deleted successfully"})
+}
+
+// writeServiceError maps a tax rate service error to its status code
+func (h *TaxRateHandlers) writeServiceError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrTaxRateNotFound):
+		writeError(w, r, http.StatusNotFound, "Tax rate not found")
+	case errors.Is(err, services.ErrInvalidTaxRate):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrTaxRateExists):
+		writeError(w, r, http.StatusConflict, err.Error())
+	default:
+		logging.FromContext(r.Context()).Error(message, slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), message)
+	}
+}
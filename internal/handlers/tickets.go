@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// GetOrderTickets handles GET /api/v1/orders/{id}/tickets
+// @Summary Order kitchen tickets
+// @Description Splits an order into kitchen tickets, one per station (e.g. grill, fry, bar) preparing some of its items. Menu items are routed to their own station or their category's.
+// @Tags Orders
+// @Produce json,xml,application/msgpack
+// @Param id path string true "Order ID"
+// @Param station query string false "Only the ticket of this station"
+// @Success 200 {object} SuccessResponse{data=[]services.KitchenTicketResponse} "Tickets retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Unknown station"
+// @Failure 404 {object} ErrorResponse "Order not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/orders/{id}/tickets [get]
+func (h *OrderHandlers) GetOrderTickets(w http.ResponseWriter, r *http.Request) {
+	tickets, ok := h.orderTickets(w, r, r.URL.Query().Get("station"))
+	if !ok {
+		return
+	}
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: tickets, Message: "Tickets retrieved successfully"})
+}
+
+// PrintOrderTicket handles GET /api/v1/orders/{id}/tickets/{station}
+// @Summary Print kitchen ticket
+// @Description Plain-text kitchen ticket of the items of an order a station prepares, sized for 80 mm printers. Stations without items on the order get 404.
+// @Tags Orders
+// @Produce plain
+// @Param id path string true "Order ID"
+// @Param station path string true "Kitchen station, e.g. grill"
+// @Success 200 {string} string "Ticket"
+// @Failure 400 {object} ErrorResponse "Unknown station"
+// @Failure 404 {object} ErrorResponse "Order not found or nothing for the station"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/orders/{id}/tickets/{station} [get]
+func (h *OrderHandlers) PrintOrderTicket(w http.ResponseWriter, r *http.Request) {
+	tickets, ok := h.orderTickets(w, r, r.PathValue("station"))
+	if !ok {
+		return
+	}
+	if len(tickets) == 0 {
+		writeError(w, r, http.StatusNotFound, "Nothing on the order for this station")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if _, err := w.Write([]byte(kitchenTicket(tickets[0]))); err != nil {
+		logging.FromContext(r.Context()).Error("Failed to write response body", slog.String("error", err.Error()))
+	}
+}
+
+// PrepareOrderTicket handles POST /api/v1/orders/{id}/tickets/{station}/prepared
+// @Summary Mark kitchen ticket prepared
+// @Description Marks a station's items of an order as prepared, as when a cook bumps the ticket off the kitchen display. The order and the orders queued behind it at the station are estimated again: ticket.prepared is published to the station's stream and order.eta_updated for every estimate that moved earlier.
+// @Tags Orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param station path string true "Kitchen station, e.g. grill"
+// @Success 200 {object} SuccessResponse{data=services.KitchenTicketResponse} "Ticket prepared successfully"
+// @Failure 400 {object} ErrorResponse "Unknown station"
+// @Failure 404 {object} ErrorResponse "Order not found or nothing for the station"
+// @Failure 409 {object} ErrorResponse "Ticket already prepared"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/orders/{id}/tickets/{station}/prepared [post]
+func (h *OrderHandlers) PrepareOrderTicket(w http.ResponseWriter, r *http.Request) {
+	id, station := r.PathValue("id"), r.PathValue("station")
+	ticket, err := h.service.PrepareTicket(r.Context(), id, station)
+	switch {
+	case errors.Is(err, services.ErrOrderNotFound):
+		writeError(w, r, http.StatusNotFound, "Order not found")
+	case errors.Is(err, services.ErrTicketNotFound):
+		writeError(w, r, http.StatusNotFound, "Nothing on the order for this station")
+	case errors.Is(err, services.ErrInvalidStation):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrTicketPrepared):
+		writeError(w, r, http.StatusConflict, err.Error())
+	case err != nil:
+		logging.FromContext(r.Context()).Error("Failed to prepare ticket",
+			slog.String("id", id), slog.String("station", station), slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to prepare ticket")
+	default:
+		writeJSON(w, r, http.StatusOK, SuccessResponse{Data: ticket, Message: "Ticket prepared successfully"})
+	}
+}
+
+// orderTickets loads the tickets of the order in the path, writing the error response
+// and returning false when that fails
+func (h *OrderHandlers) orderTickets(w http.ResponseWriter, r *http.Request, station string) ([]services.KitchenTicketResponse, bool) {
+	id := r.PathValue("id")
+	tickets, err := h.service.GetOrderTickets(r.Context(), id, station)
+	switch {
+	case errors.Is(err, services.ErrOrderNotFound):
+		writeError(w, r, http.StatusNotFound, "Order not found")
+	case errors.Is(err, services.ErrInvalidStation):
+		writeError(w, r, http.StatusBadRequest, err.Error())
+	case err != nil:
+		logging.FromContext(r.Context()).Error("Failed to get order tickets", slog.String("id", id), slog.String("error", err.Error()))
+		writeError(w, r, serviceErrorStatus(err), "Failed to get order tickets")
+	default:
+		return tickets, true
+	}
+	return nil, false
+}
+
+// kitchenTicket renders a kitchen ticket as plain text: no prices, just what to
+// prepare and the notes on it
+func kitchenTicket(ticket services.KitchenTicketResponse) string {
+	var b strings.Builder
+	rule := strings.Repeat("-", receiptWidth) + "\n"
+
+	fmt.Fprintf(&b, "%s\n", strings.ToUpper(ticket.Station))
+	fmt.Fprintf(&b, "Order %s  %s\n", orderLabel(ticket.OrderID, ticket.OrderNumber), strings.ReplaceAll(ticket.Channel, "_", " "))
+	fmt.Fprintf(&b, "%s\n", ticket.CreatedAt.Format(time.DateTime))
+	if ticket.Table != nil {
+		fmt.Fprintf(&b, "Table %s\n", *ticket.Table)
+	}
+	if ticket.CustomerName != nil {
+		fmt.Fprintf(&b, "For %s\n", *ticket.CustomerName)
+	}
+	if ticket.EstimatedReadyAt != nil {
+		fmt.Fprintf(&b, "Ready by %s\n", ticket.EstimatedReadyAt.Format(time.TimeOnly))
+	}
+	b.WriteString(rule)
+	for _, item := range ticket.Items {
+		fmt.Fprintf(&b, "%d x %s\n", item.Quantity, item.Name)
+		if item.Notes != nil {
+			fmt.Fprintf(&b, "    %s\n", *item.Notes)
+		}
+	}
+	if ticket.Notes != nil {
+		b.WriteString(rule)
+		fmt.Fprintf(&b, "%s\n", *ticket.Notes)
+	}
+	return b.String()
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// ClockIn handles POST /api/v1/staff/{id}/clock-in
+// @Summary Clock in
+// @Description Clocks an active staff member in for their shift under way or starting within CLOCK_IN_EARLY_MINUTES (default 15). Without such a shift it fails with 422 unless unscheduled is set. Fails with 409 if they are clocked in already.
+// @Tags Staff
+// @Accept json
+// @Produce json
+// @Param id path int true "Staff member ID"
+// @Param punch body services.ClockInRequest false "Clock-in options"
+// @Success 201 {object} SuccessResponse{data=services.TimePunchResponse} "Clocked in successfully"
+// @Failure 400 {object} ErrorResponse "Invalid staff member ID or inactive staff member"
+// @Failure 404 {object} ErrorResponse "Staff member not found"
+// @Failure 409 {object} ErrorResponse "Staff member is clocked in already"
+// @Failure 422 {object} ErrorResponse "No shift under way or starting soon"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/staff/{id}/clock-in [post]
+func (h *StaffHandlers) ClockIn(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid staff member ID")
+		return
+	}
+	var req services.ClockInRequest
+
+	// The body is optional
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeError(w, r, status, message)
+		return
+	}
+
+	punch, err := h.service.ClockIn(r.Context(), id, req)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to clock in")
+		return
+	}
+
+	writeJSON(w, r, http.StatusCreated, SuccessResponse{Data: punch, Message: "Clocked in successfully"})
+}
+
+// ClockOut handles POST /api/v1/staff/{id}/clock-out
+// @Summary Clock out
+// @Description Clocks a staff member out. Fails with 409 if they aren't clocked in.
+// @Tags Staff
+// @Produce json
+// @Param id path int true "Staff member ID"
+// @Success 200 {object} SuccessResponse{data=services.TimePunchResponse} "Clocked out successfully"
+// @Failure 400 {object} ErrorResponse "Invalid staff member ID"
+// @Failure 404 {object} ErrorResponse "Staff member not found"
+// @Failure 409 {object} ErrorResponse "Staff member is not clocked in"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/staff/{id}/clock-out [post]
+func (h *StaffHandlers) ClockOut(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "Invalid staff member ID")
+		return
+	}
+
+	punch, err := h.service.ClockOut(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to clock out")
+		return
+	}
+
+	writeJSON(w, r, http.StatusOK, SuccessResponse{Data: punch, Message: "Clocked out successfully"})
+}
+
+// GetTimesheet handles GET /api/v1/timesheets
+// @Summary Timesheet
+// @Description The timesheet of a pay period, PAY_PERIOD_DAYS business days long (default 14): the hours each staff member was scheduled for and worked, with their punches by clock-in time. Staff are listed by name when they had a shift or punch in the period. Punches still clocked in count no hours yet.
+// @Tags Staff
+// @Produce json,xml,application/msgpack
+// @Param date query string false "Any day of the pay period, as a date or timestamp (default: today)"
+// @Param staff_id query int false "Only this staff member, listed even without shifts or punches"
+// @Success 200 {object} SuccessResponse{data=services.TimesheetResponse} "Timesheet retrieved successfully"
+// @Failure 400 {object} ErrorResponse "Invalid date or staff member ID"
+// @Failure 404 {object} ErrorResponse "Staff member not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/timesheets [get]
+func (h *StaffHandlers) GetTimesheet(w http.ResponseWriter, r *http.Request) {
+	var opts services.TimesheetOptions
+	if value := strings.TrimSpace(r.URL.Query().Get("date")); value != "" {
+		t, err := services.ParseTimestamp(value)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "date: "+err.Error())
+			return
+		}
+		// A bare date stands for its business day
+		if _, err := time.Parse(time.DateOnly, value); err == nil {
+			t = services.StartOfBusinessDay(t)
+		}
+		opts.Date = t
+	}
+	if value := r.URL.Query().Get("staff_id"); value != "" {
+		staffID, err := strconv.Atoi(value)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "Invalid staff member ID")
+			return
+		}
+		opts.StaffID = &staffID
+	}
+
+	timesheet, err := h.service.Timesheet(r.Context(), opts)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to retrieve timesheet")
+		return
+	}
+
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: timesheet, Message: "Timesheet retrieved successfully"})
+}
+
+// ExportTimesheets handles GET /api/v1/reports/timesheets
+// @Summary Payroll export
+// @Description The hours each staff member worked in a period, from the punches clocked in during it, split into regular hours and overtime, for import into payroll systems. Weeks are counted from from; a week's overtime is the time beyond OVERTIME_WEEKLY_HOURS (default 40) or, when larger, the sum of the time beyond OVERTIME_DAILY_HOURS (default off) in each business day. Punches not clocked out count no hours and are reported as open_punches.
+// @Tags Reports
+// @Produce json,text/csv
+// @Param from query string false "Start of the period, inclusive (RFC 3339, date, or Unix seconds; a bare date starts at the start of that business day; default: the start of the pay period)"
+// @Param to query string false "End of the period, exclusive; a bare date includes that business day (default: the end of the pay period of from)"
+// @Param format query string false "File format: json (default) or csv"
+// @Success 200 {object} SuccessResponse{data=services.PayrollExport} "Payroll export generated successfully"
+// @Failure 400 {object} ErrorResponse "Invalid period or format"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /api/v1/reports/timesheets [get]
+func (h *StaffHandlers) ExportTimesheets(w http.ResponseWriter, r *http.Request) {
+	opts := services.PayrollExportOptions{Format: r.URL.Query().Get("format")}
+	if opts.Format == "" {
+		opts.Format = services.PayrollJSON
+	}
+	var ok bool
+	if opts.From, opts.To, ok = parseReportPeriod(w, r); !ok {
+		return
+	}
+
+	export, err := h.service.PayrollExport(r.Context(), opts)
+	if err != nil {
+		h.writeServiceError(w, r, err, "Failed to generate payroll export")
+		return
+	}
+
+	if opts.Format == services.PayrollCSV {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="timesheets_`+export.From.Format("20060102")+`.csv"`)
+		if err := services.WritePayrollCSV(w, export); err != nil {
+			logging.FromContext(r.Context()).Error("Payroll export aborted", slog.String("error", err.Error()))
+		}
+		return
+	}
+	writeResponse(w, r, http.StatusOK, SuccessResponse{Data: export, Message: "Payroll export generated successfully"})
+}
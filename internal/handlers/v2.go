@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/services"
+	"github.com/Zughayyar/agora-server/internal/version"
+)
+
+// V2Response is the single response envelope of /api/v2. Successful responses carry
+// data, failed ones carry error; meta is always present.
+type V2Response struct {
+	Data  any      `json:"data,omitempty"`
+	Error *V2Error `json:"error,omitempty"`
+	Meta  V2Meta   `json:"meta"`
+}
+
+// V2Meta describes the response
+type V2Meta struct {
+	RequestID  string        `json:"request_id,omitempty" example:"3f9a1c0e5b7d4e2a8c6b0d1f2e3a4b5c"`
+	Pagination *V2Pagination `json:"pagination,omitempty"`
+}
+
+// V2Pagination describes a page of a list. Total is omitted when it isn't known.
+type V2Pagination struct {
+	Limit   int  `json:"limit" example:"50"`
+	Offset  int  `json:"offset" example:"0"`
+	Total   *int `json:"total,omitempty" example:"120"`
+	HasMore bool `json:"has_more" example:"true"`
+}
+
+// V2Error is a typed error: clients branch on Code, Message is for humans
+type V2Error struct {
+	Code    string         `json:"code" example:"invalid_argument"`
+	Message string         `json:"message" example:"price must be greater than 0"`
+	Details []V2FieldError `json:"details,omitempty"`
+}
+
+// V2FieldError points at an invalid request field
+type V2FieldError struct {
+	Field   string `json:"field" example:"price"`
+	Message string `json:"message" example:"must be greater than 0"`
+}
+
+// Error codes of /api/v2
+const (
+	V2CodeInvalidArgument  = "invalid_argument"
+	V2CodeUnauthorized     = "unauthorized"
+	V2CodeForbidden        = "forbidden"
+	V2CodeNotFound         = "not_found"
+	V2CodeMethodNotAllowed = "method_not_allowed"
+	V2CodeConflict         = "conflict"
+	V2CodePayloadTooLarge  = "payload_too_large"
+	V2CodeRateLimited      = "rate_limited"
+	V2CodeInternal         = "internal"
+	V2CodeUnavailable      = "unavailable"
+	V2CodeTimeout          = "timeout"
+)
+
+// v2ErrorCodes maps status codes to error codes
+var v2ErrorCodes = map[int]string{
+	http.StatusBadRequest:            V2CodeInvalidArgument,
+	http.StatusUnauthorized:          V2CodeUnauthorized,
+	http.StatusForbidden:             V2CodeForbidden,
+	http.StatusNotFound:              V2CodeNotFound,
+	http.StatusMethodNotAllowed:      V2CodeMethodNotAllowed,
+	http.StatusConflict:              V2CodeConflict,
+	http.StatusRequestEntityTooLarge: V2CodePayloadTooLarge,
+	http.StatusTooManyRequests:       V2CodeRateLimited,
+	http.StatusInternalServerError:   V2CodeInternal,
+	http.StatusServiceUnavailable:    V2CodeUnavailable,
+	http.StatusGatewayTimeout:        V2CodeTimeout,
+}
+
+// Page size limits of /api/v2 lists
+const (
+	v2DefaultPageSize = 50
+	v2MaxPageSize     = 200
+)
+
+// writeV2 writes a successful v2 response
+func writeV2(w http.ResponseWriter, r *http.Request, statusCode int, data any, pagination *V2Pagination) {
+	writeJSON(w, r, statusCode, V2Response{
+		Data: data,
+		Meta: V2Meta{RequestID: middlewares.RequestID(r.Context()), Pagination: pagination},
+	})
+}
+
+// WriteV2Error writes a failed v2 response, with the error code derived from the
+// status code. It is registered as the error formatter of /api/v2 so errors raised by
+// middlewares use the same envelope.
+func WriteV2Error(w http.ResponseWriter, r *http.Request, statusCode int, message string) {
+	writeV2Error(w, r, statusCode, message, nil)
+}
+
+// writeV2Error writes a failed v2 response with optional field errors
+func writeV2Error(w http.ResponseWriter, r *http.Request, statusCode int, message string, details []V2FieldError) {
+	code, ok := v2ErrorCodes[statusCode]
+	if !ok {
+		code = V2CodeInternal
+	}
+	writeJSON(w, r, statusCode, V2Response{
+		Error: &V2Error{Code: code, Message: message, Details: details},
+		Meta:  V2Meta{RequestID: middlewares.RequestID(r.Context())},
+	})
+}
+
+// writeV2ServiceError maps a service error to a v2 error. Unexpected errors are logged
+// and reported without their internals.
+func writeV2ServiceError(w http.ResponseWriter, r *http.Request, err error, notFound string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, services.ErrOrderNotFound):
+		writeV2Error(w, r, http.StatusNotFound, notFound, nil)
+	case errors.Is(err, services.ErrServiceUnavailable):
+		w.Header().Set("Retry-After", "5")
+		writeV2Error(w, r, http.StatusServiceUnavailable, "The database is temporarily unavailable", nil)
+	case errors.Is(err, services.ErrInvalidInclude), errors.Is(err, services.ErrInvalidExpand), errors.Is(err, services.ErrInvalidOrder),
+		errors.Is(err, services.ErrInvalidMinRating):
+		writeV2Error(w, r, http.StatusBadRequest, err.Error(), nil)
+	default:
+		logging.FromContext(r.Context()).Error("Request failed", slog.String("error", err.Error()))
+		writeV2Error(w, r, http.StatusInternalServerError, "An unexpected error occurred", nil)
+	}
+}
+
+// parseV2Page reads the limit and offset query parameters, writing a 400 and
+// returning false when they are invalid
+func parseV2Page(w http.ResponseWriter, r *http.Request) (limit, offset int, ok bool) {
+	limit, offset = v2DefaultPageSize, 0
+	var details []V2FieldError
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > v2MaxPageSize {
+			details = append(details, V2FieldError{Field: "limit", Message: "must be an integer between 1 and " + strconv.Itoa(v2MaxPageSize)})
+		}
+		limit = n
+	}
+	if value := r.URL.Query().Get("offset"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			details = append(details, V2FieldError{Field: "offset", Message: "must be a non-negative integer"})
+		}
+		offset = n
+	}
+	if details != nil {
+		writeV2Error(w, r, http.StatusBadRequest, "Invalid pagination parameters", details)
+		return 0, 0, false
+	}
+	return limit, offset, true
+}
+
+// pageOf returns the page of items at offset with its pagination metadata
+func pageOf[T any](items []T, limit, offset int) ([]T, *V2Pagination) {
+	total := len(items)
+	page := &V2Pagination{Limit: limit, Offset: offset, Total: &total, HasMore: offset+limit < total}
+	if offset >= total {
+		return []T{}, page
+	}
+	return items[offset:min(offset+limit, total)], page
+}
+
+// V2Handlers contains the HTTP handlers of /api/v2
+type V2Handlers struct {
+	items  services.MenuItemService
+	orders services.OrderService
+	db     *bun.DB
+}
+
+// NewV2Handlers creates the /api/v2 handlers
+func NewV2Handlers(items services.MenuItemService, orders services.OrderService, db *bun.DB) *V2Handlers {
+	return &V2Handlers{items: items, orders: orders, db: db}
+}
+
+// Health handles GET /api/v2/health
+// @Summary Health check (v2)
+// @Description Reports the service and database health. A degraded service answers 503 with the report as data and an unavailable error.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} V2Response{data=HealthResponse} "Service is healthy"
+// @Failure 503 {object} V2Response{data=HealthResponse} "Service is degraded"
+// @Router /api/v2/health [get]
+func (h *V2Handlers) Health(w http.ResponseWriter, r *http.Request) {
+	response, statusCode := checkHealth(r.Context(), h.db)
+	if statusCode != http.StatusOK {
+		writeJSON(w, r, statusCode, V2Response{
+			Data:  response,
+			Error: &V2Error{Code: V2CodeUnavailable, Message: "The database is unavailable"},
+			Meta:  V2Meta{RequestID: middlewares.RequestID(r.Context())},
+		})
+		return
+	}
+	writeV2(w, r, http.StatusOK, response, nil)
+}
+
+// Version handles GET /api/v2/version
+// @Summary Build information (v2)
+// @Description Returns the version, commit and build time of the running binary
+// @Tags Health
+// @Produce json
+// @Success 200 {object} V2Response{data=version.Info} "Build information"
+// @Router /api/v2/version [get]
+func (h *V2Handlers) Version(w http.ResponseWriter, r *http.Request) {
+	writeV2(w, r, http.StatusOK, version.Get(), nil)
+}
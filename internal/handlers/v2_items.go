@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// categoryMessage lists the valid categories for field errors
+const categoryMessage = "must be one of: appetizer, main, dessert, drink, side, fast food"
+
+// ListMenuItems handles GET /api/v2/items
+// @Summary List menu items (v2)
+// @Description Retrieves a page of menu items, optionally filtered by category, availability or search term
+// @Tags Menu Items v2
+// @Produce json
+// @Param category query string false "Filter by category (appetizer, main, dessert, drink, side, fast food)"
+// @Param available query boolean false "Only available items (true/false)"
+// @Param include_deleted query boolean false "Include soft-deleted items (true/false)"
+// @Param search query string false "Search term"
+// @Param include query string false "Comma-separated related resources to embed (prices)"
+// @Param expand query string false "Comma-separated sub-resources to embed (category)"
+// @Param channel query string false "Price the items for an order channel (dine_in, takeaway, delivery)"
+// @Param min_rating query number false "Only items whose approved reviews average at least this rating (1-5)"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,name,price"
+// @Param limit query int false "Page size (default 50, at most 200)"
+// @Param offset query int false "Number of items to skip"
+// @Success 200 {object} V2Response{data=[]services.MenuItemResponse} "A page of menu items"
+// @Failure 400 {object} V2Response "Invalid filter, pagination parameters or fields"
+// @Failure 500 {object} V2Response "Internal server error"
+// @Failure 503 {object} V2Response "Database unavailable"
+// @Router /api/v2/items [get]
+func (h *V2Handlers) ListMenuItems(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parseV2Page(w, r)
+	if !ok {
+		return
+	}
+	fields, ok := parseV2Fields(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	category := query.Get("category")
+	search := query.Get("search")
+	opts := queryOptions(r)
+	if category != "" && !services.ValidCategories[category] {
+		writeV2Error(w, r, http.StatusBadRequest, "Invalid category", []V2FieldError{{Field: "category", Message: categoryMessage}})
+		return
+	}
+
+	var items []services.MenuItemResponse
+	var err error
+	switch {
+	case search != "":
+		items, err = h.items.SearchMenuItems(r.Context(), search, opts)
+	case category != "":
+		items, err = h.items.GetMenuItemsByCategory(r.Context(), category, opts)
+	case query.Get("available") == "true":
+		items, err = h.items.GetAvailableMenuItems(r.Context(), opts)
+	case query.Get("include_deleted") == "true":
+		items, err = h.items.GetAllMenuItemsWithDeleted(r.Context(), opts)
+	default:
+		items, err = h.items.GetAllMenuItems(r.Context(), opts)
+	}
+	if err != nil {
+		writeV2ServiceError(w, r, err, "Menu item not found")
+		return
+	}
+
+	page, pagination := pageOf(items, limit, offset)
+	writeV2Fields(w, r, page, fields, pagination)
+}
+
+// ListDeletedMenuItems handles GET /api/v2/items/deleted
+// @Summary List deleted menu items (v2)
+// @Description Retrieves a page of the soft-deleted menu items, which can be restored
+// @Tags Menu Items v2
+// @Produce json
+// @Param include query string false "Comma-separated related resources to embed (prices)"
+// @Param expand query string false "Comma-separated sub-resources to embed (category)"
+// @Param channel query string false "Price the items for an order channel (dine_in, takeaway, delivery)"
+// @Param min_rating query number false "Only items whose approved reviews average at least this rating (1-5)"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,name,price"
+// @Param limit query int false "Page size (default 50, at most 200)"
+// @Param offset query int false "Number of items to skip"
+// @Success 200 {object} V2Response{data=[]services.MenuItemResponse} "A page of deleted menu items"
+// @Failure 400 {object} V2Response "Invalid pagination parameters or fields"
+// @Failure 500 {object} V2Response "Internal server error"
+// @Router /api/v2/items/deleted [get]
+func (h *V2Handlers) ListDeletedMenuItems(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parseV2Page(w, r)
+	if !ok {
+		return
+	}
+	fields, ok := parseV2Fields(w, r)
+	if !ok {
+		return
+	}
+
+	items, err := h.items.GetDeletedMenuItems(r.Context(), queryOptions(r))
+	if err != nil {
+		writeV2ServiceError(w, r, err, "Menu item not found")
+		return
+	}
+
+	page, pagination := pageOf(items, limit, offset)
+	writeV2Fields(w, r, page, fields, pagination)
+}
+
+// GetMenuItem handles GET /api/v2/items/{id}
+// @Summary Get a menu item (v2)
+// @Description Retrieves a menu item by its ID
+// @Tags Menu Items v2
+// @Produce json
+// @Param id path int true "Menu item ID"
+// @Param include query string false "Comma-separated related resources to embed (prices)"
+// @Param expand query string false "Comma-separated sub-resources to embed (category)"
+// @Param channel query string false "Price the items for an order channel (dine_in, takeaway, delivery)"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,name,price"
+// @Success 200 {object} V2Response{data=services.MenuItemResponse} "The menu item"
+// @Failure 400 {object} V2Response "Invalid menu item ID or fields"
+// @Failure 404 {object} V2Response "Menu item not found"
+// @Failure 500 {object} V2Response "Internal server error"
+// @Router /api/v2/items/{id} [get]
+func (h *V2Handlers) GetMenuItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := v2ItemID(w, r)
+	if !ok {
+		return
+	}
+	fields, ok := parseV2Fields(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := h.items.GetMenuItemByID(r.Context(), id, queryOptions(r))
+	if err != nil {
+		writeV2ServiceError(w, r, err, "Menu item not found")
+		return
+	}
+	writeV2Fields(w, r, item, fields, nil)
+}
+
+// CreateMenuItem handles POST /api/v2/items
+// @Summary Create a menu item (v2)
+// @Description Creates a menu item. Invalid fields are listed in the error details.
+// @Tags Menu Items v2
+// @Accept json
+// @Produce json
+// @Param item body services.CreateMenuItemRequest true "Menu item details"
+// @Success 201 {object} V2Response{data=services.MenuItemResponse} "The created menu item"
+// @Failure 400 {object} V2Response "Invalid request body"
+// @Failure 413 {object} V2Response "Request body too large"
+// @Failure 500 {object} V2Response "Internal server error"
+// @Router /api/v2/items [post]
+func (h *V2Handlers) CreateMenuItem(w http.ResponseWriter, r *http.Request) {
+	var req services.CreateMenuItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeV2Error(w, r, status, message, nil)
+		return
+	}
+	if details := validateMenuItemFields(&req.Name, &req.Price, &req.Category); details != nil {
+		writeV2Error(w, r, http.StatusBadRequest, "Invalid menu item", details)
+		return
+	}
+
+	item, err := h.items.CreateMenuItem(r.Context(), req)
+	if err != nil {
+		writeV2ServiceError(w, r, err, "Menu item not found")
+		return
+	}
+	w.Header().Set("Location", "/api/v2/items/"+strconv.Itoa(item.ID))
+	writeV2(w, r, http.StatusCreated, item, nil)
+}
+
+// UpdateMenuItem handles PUT /api/v2/items/{id}
+// @Summary Update a menu item (v2)
+// @Description Updates the provided fields of a menu item. Invalid fields are listed in the error details.
+// @Tags Menu Items v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Menu item ID"
+// @Param item body services.UpdateMenuItemRequest true "Fields to update"
+// @Success 200 {object} V2Response{data=services.MenuItemResponse} "The updated menu item"
+// @Failure 400 {object} V2Response "Invalid request body or menu item ID"
+// @Failure 404 {object} V2Response "Menu item not found"
+// @Failure 413 {object} V2Response "Request body too large"
+// @Failure 500 {object} V2Response "Internal server error"
+// @Router /api/v2/items/{id} [put]
+func (h *V2Handlers) UpdateMenuItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := v2ItemID(w, r)
+	if !ok {
+		return
+	}
+
+	var req services.UpdateMenuItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		status, message := requestBodyError(err, "Invalid JSON format")
+		writeV2Error(w, r, status, message, nil)
+		return
+	}
+	if details := validateMenuItemFields(req.Name, req.Price, req.Category); details != nil {
+		writeV2Error(w, r, http.StatusBadRequest, "Invalid menu item", details)
+		return
+	}
+
+	item, err := h.items.UpdateMenuItem(r.Context(), id, req)
+	if err != nil {
+		writeV2ServiceError(w, r, err, "Menu item not found")
+		return
+	}
+	writeV2(w, r, http.StatusOK, item, nil)
+}
+
+// DeleteMenuItem handles DELETE /api/v2/items/{id}
+// @Summary Delete a menu item (v2)
+// @Description Soft deletes a menu item, or permanently deletes it with force=true
+// @Tags Menu Items v2
+// @Produce json
+// @Param id path int true "Menu item ID"
+// @Param force query boolean false "Permanently delete the item (true/false)"
+// @Success 204 "Menu item deleted"
+// @Failure 400 {object} V2Response "Invalid menu item ID"
+// @Failure 404 {object} V2Response "Menu item not found"
+// @Failure 500 {object} V2Response "Internal server error"
+// @Router /api/v2/items/{id} [delete]
+func (h *V2Handlers) DeleteMenuItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := v2ItemID(w, r)
+	if !ok {
+		return
+	}
+
+	var err error
+	if r.URL.Query().Get("force") == "true" {
+		err = h.items.ForceDeleteMenuItem(r.Context(), id)
+	} else {
+		err = h.items.SoftDeleteMenuItem(r.Context(), id)
+	}
+	if err != nil {
+		writeV2ServiceError(w, r, err, "Menu item not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreMenuItem handles POST /api/v2/items/{id}/restore
+// @Summary Restore a menu item (v2)
+// @Description Restores a soft-deleted menu item
+// @Tags Menu Items v2
+// @Produce json
+// @Param id path int true "Menu item ID"
+// @Success 200 {object} V2Response{data=services.MenuItemResponse} "The restored menu item"
+// @Failure 400 {object} V2Response "Invalid menu item ID"
+// @Failure 404 {object} V2Response "Menu item not found"
+// @Failure 409 {object} V2Response "Menu item is not deleted"
+// @Failure 500 {object} V2Response "Internal server error"
+// @Router /api/v2/items/{id}/restore [post]
+func (h *V2Handlers) RestoreMenuItem(w http.ResponseWriter, r *http.Request) {
+	id, ok := v2ItemID(w, r)
+	if !ok {
+		return
+	}
+
+	item, err := h.items.RestoreMenuItem(r.Context(), id)
+	if err != nil && strings.Contains(err.Error(), "not deleted") {
+		writeV2Error(w, r, http.StatusConflict, "Menu item is not deleted", nil)
+		return
+	}
+	if err != nil {
+		writeV2ServiceError(w, r, err, "Menu item not found")
+		return
+	}
+	writeV2(w, r, http.StatusOK, item, nil)
+}
+
+// v2ItemID parses the {id} path value, writing a 400 and returning false when it
+// isn't a positive integer
+func v2ItemID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id < 1 {
+		writeV2Error(w, r, http.StatusBadRequest, "Invalid menu item ID", []V2FieldError{{Field: "id", Message: "must be a positive integer"}})
+		return 0, false
+	}
+	return id, true
+}
+
+// parseV2Fields reads the menu item fields requested with ?fields=, writing a 400 and
+// returning false when one is unknown
+func parseV2Fields(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	fields, err := parseFields(r, menuItemFields)
+	if err != nil {
+		writeV2Error(w, r, http.StatusBadRequest, "Invalid fields", []V2FieldError{{Field: "fields", Message: err.Error()}})
+		return nil, false
+	}
+	return fields, true
+}
+
+// writeV2Fields writes a successful v2 response trimmed to the requested fields
+func writeV2Fields(w http.ResponseWriter, r *http.Request, data interface{}, fields []string, pagination *V2Pagination) {
+	data, err := selectFields(data, fields)
+	if err != nil {
+		writeV2ServiceError(w, r, err, "")
+		return
+	}
+	writeV2(w, r, http.StatusOK, data, pagination)
+}
+
+// validateMenuItemFields checks the menu item fields that are set, returning an error
+// for each invalid one
+func validateMenuItemFields(name *string, price *decimal.Decimal, category *string) []V2FieldError {
+	var details []V2FieldError
+	if name != nil && (strings.TrimSpace(*name) == "" || utf8.RuneCountInString(*name) > 100) {
+		details = append(details, V2FieldError{Field: "name", Message: "must be between 1 and 100 characters"})
+	}
+	if price != nil && !price.IsPositive() {
+		details = append(details, V2FieldError{Field: "price", Message: "must be greater than 0"})
+	}
+	if category != nil && !services.ValidCategories[*category] {
+		details = append(details, V2FieldError{Field: "category", Message: categoryMessage})
+	}
+	return details
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// ListOrders handles GET /api/v2/orders
+// @Summary List orders (v2)
+// @Description Retrieves a page of orders with their items, newest first. The total isn't counted; has_more tells whether another page follows.
+// @Tags Orders v2
+// @Produce json
+// @Param status query string false "Filter by status (pending, accepted, preparing, ready, completed, cancelled)"
+// @Param source query string false "Filter by source (pos, ubereats, doordash)"
+// @Param since query string false "Only orders created at or after this time (RFC 3339, date, or Unix seconds)"
+// @Param until query string false "Only orders created before this time (RFC 3339, date, or Unix seconds)"
+// @Param limit query int false "Page size (default 50, at most 200)"
+// @Param offset query int false "Number of orders to skip"
+// @Success 200 {object} V2Response{data=[]services.OrderResponse} "A page of orders"
+// @Failure 400 {object} V2Response "Invalid pagination parameters or timestamps"
+// @Failure 500 {object} V2Response "Internal server error"
+// @Router /api/v2/orders [get]
+func (h *V2Handlers) ListOrders(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parseV2Page(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	opts := services.OrderListOptions{Status: query.Get("status"), Source: query.Get("source"), Limit: limit, Offset: offset}
+	var details []V2FieldError
+	for name, target := range map[string]*time.Time{"since": &opts.Since, "until": &opts.Until} {
+		if value := query.Get(name); value != "" {
+			t, err := services.ParseTimestamp(value)
+			if err != nil {
+				details = append(details, V2FieldError{Field: name, Message: err.Error()})
+			}
+			*target = t
+		}
+	}
+	if details != nil {
+		writeV2Error(w, r, http.StatusBadRequest, "Invalid timestamps", details)
+		return
+	}
+	orders, err := h.orders.ListOrders(r.Context(), opts)
+	if err != nil {
+		writeV2ServiceError(w, r, err, "Order not found")
+		return
+	}
+
+	// A full page may be the last one; probe for the order after it
+	pagination := &V2Pagination{Limit: limit, Offset: offset}
+	if len(orders) == limit {
+		opts.Offset, opts.Limit = offset+limit, 1
+		next, err := h.orders.ListOrders(r.Context(), opts)
+		if err != nil {
+			writeV2ServiceError(w, r, err, "Order not found")
+			return
+		}
+		pagination.HasMore = len(next) > 0
+	}
+	writeV2(w, r, http.StatusOK, orders, pagination)
+}
+
+// GetOrder handles GET /api/v2/orders/{id}
+// @Summary Get an order (v2)
+// @Description Retrieves an order with its items
+// @Tags Orders v2
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} V2Response{data=services.OrderResponse} "The order"
+// @Failure 404 {object} V2Response "Order not found"
+// @Failure 500 {object} V2Response "Internal server error"
+// @Router /api/v2/orders/{id} [get]
+func (h *V2Handlers) GetOrder(w http.ResponseWriter, r *http.Request) {
+	order, err := h.orders.GetOrderByID(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeV2ServiceError(w, r, err, "Order not found")
+		return
+	}
+	writeV2(w, r, http.StatusOK, order, nil)
+}
@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Zughayyar/agora-server/internal/version"
+)
+
+// VersionHandler returns build metadata for the running server
+// @Summary Build information
+// @Description Returns the version, git commit and build time of the running server
+// @Tags Health
+// @Produce json
+// @Success 200 {object} version.Info "Build information"
+// @Router /version [get]
+// @Router /api/v1/version [get]
+func VersionHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, http.StatusOK, version.Get())
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/webhooks"
+)
+
+// CreatedWebhook is a new subscription along with its signing secret, which is only
+// returned once
+type CreatedWebhook struct {
+	webhooks.Subscription
+	Secret string `json:"secret" example:"whsec_3f9a..."`
+}
+
+// WebhooksHandler handles GET /admin/webhooks
+// @Summary Webhook subscriptions
+// @Description Lists the outbound webhook subscriptions
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} SuccessResponse{data=[]webhooks.Subscription} "Webhook subscriptions"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/webhooks [get]
+func WebhooksHandler(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		subs, err := webhooks.List(r.Context(), db)
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to list webhook subscriptions", slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to list webhook subscriptions")
+			return
+		}
+		if subs == nil {
+			subs = []webhooks.Subscription{}
+		}
+
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    subs,
+			Message: "Webhook subscriptions retrieved successfully",
+		})
+	}
+}
+
+// CreateWebhookHandler handles POST /admin/webhooks
+// @Summary Create a webhook subscription
+// @Description Registers a URL receiving the given event types (exact types, prefixes such as menu_item.*, or * for all). Events are POSTed as JSON and signed: X-Agora-Signature is "sha256=" followed by the hex HMAC-SHA256 of "<X-Agora-Timestamp>.<body>" keyed with the secret returned here.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param subscription body webhooks.CreateRequest true "Subscription"
+// @Success 201 {object} SuccessResponse{data=CreatedWebhook} "Webhook subscription created"
+// @Failure 400 {object} ErrorResponse "Invalid subscription"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/webhooks [post]
+func CreateWebhookHandler(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req webhooks.CreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			status, message := requestBodyError(err, "Invalid JSON format")
+			writeError(w, r, status, message)
+			return
+		}
+
+		sub, err := webhooks.Create(r.Context(), db, req)
+		if errors.Is(err, webhooks.ErrInvalidSubscription) {
+			writeError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to create webhook subscription", slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to create webhook subscription")
+			return
+		}
+
+		logging.FromContext(r.Context()).Info("Webhook subscription created",
+			slog.Int64("subscription_id", sub.ID),
+			slog.String("url", sub.URL))
+		writeJSON(w, r, http.StatusCreated, SuccessResponse{
+			Data:    CreatedWebhook{Subscription: *sub, Secret: sub.Secret},
+			Message: "Webhook subscription created",
+		})
+	}
+}
+
+// DeleteWebhookHandler handles DELETE /admin/webhooks/{id}
+// @Summary Delete a webhook subscription
+// @Description Removes a subscription and its delivery history; queued deliveries are dropped
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Param id path int true "Subscription ID"
+// @Success 200 {object} SuccessResponse "Webhook subscription deleted"
+// @Failure 404 {object} ErrorResponse "Webhook subscription not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/webhooks/{id} [delete]
+func DeleteWebhookHandler(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		if err != nil {
+			writeError(w, r, http.StatusNotFound, webhooks.ErrNotFound.Error())
+			return
+		}
+
+		err = webhooks.Delete(r.Context(), db, id)
+		if errors.Is(err, webhooks.ErrNotFound) {
+			writeError(w, r, http.StatusNotFound, err.Error())
+			return
+		}
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to delete webhook subscription", slog.Int64("subscription_id", id), slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to delete webhook subscription")
+			return
+		}
+
+		logging.FromContext(r.Context()).Info("Webhook subscription deleted", slog.Int64("subscription_id", id))
+		writeJSON(w, r, http.StatusOK, SuccessResponse{Message: "Webhook subscription deleted"})
+	}
+}
+
+// WebhookDeliveriesHandler handles GET /admin/webhooks/{id}/deliveries
+// @Summary Webhook delivery attempts
+// @Description Returns the delivery attempts of a subscription, newest first
+// @Tags Admin
+// @Produce json
+// @Security AdminToken
+// @Param id path int true "Subscription ID"
+// @Param limit query int false "Maximum number of attempts (default 100, at most 500)"
+// @Success 200 {object} SuccessResponse{data=[]webhooks.Delivery} "Delivery attempts"
+// @Failure 400 {object} ErrorResponse "Invalid limit"
+// @Failure 404 {object} ErrorResponse "Webhook subscription not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/webhooks/{id}/deliveries [get]
+func WebhookDeliveriesHandler(db *bun.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		if err != nil {
+			writeError(w, r, http.StatusNotFound, webhooks.ErrNotFound.Error())
+			return
+		}
+		limit := 0
+		if value := r.URL.Query().Get("limit"); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				writeError(w, r, http.StatusBadRequest, "limit must be a positive integer")
+				return
+			}
+			limit = n
+		}
+
+		if _, err := webhooks.Get(r.Context(), db, id); err != nil {
+			if errors.Is(err, webhooks.ErrNotFound) {
+				writeError(w, r, http.StatusNotFound, err.Error())
+				return
+			}
+			logging.FromContext(r.Context()).Error("Failed to load webhook subscription", slog.Int64("subscription_id", id), slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to load webhook subscription")
+			return
+		}
+
+		deliveries, err := webhooks.ListDeliveries(r.Context(), db, id, limit)
+		if err != nil {
+			logging.FromContext(r.Context()).Error("Failed to list webhook deliveries", slog.Int64("subscription_id", id), slog.String("error", err.Error()))
+			writeError(w, r, http.StatusInternalServerError, "Failed to list webhook deliveries")
+			return
+		}
+		if deliveries == nil {
+			deliveries = []webhooks.Delivery{}
+		}
+
+		writeJSON(w, r, http.StatusOK, SuccessResponse{
+			Data:    deliveries,
+			Message: "Webhook deliveries retrieved successfully",
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Job states. Failed attempts go back to StatusPending until the job runs out of
+// attempts, after which it is dead-lettered as StatusDead.
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusDead      = "dead"
+)
+
+// Job errors
+var (
+	ErrNotFound     = errors.New("job not found")
+	ErrNotRetryable = errors.New("only dead jobs can be retried")
+)
+
+// Job is a unit of background work stored in the jobs table
+type Job struct {
+	bun.BaseModel `bun:"table:jobs,alias:j" swaggerignore:"true"`
+
+	ID          int64      `bun:"id,pk,autoincrement" json:"id"`
+	Kind        string     `bun:"kind,notnull" json:"kind" example:"export"`
+	Payload     string     `bun:"payload,notnull" json:"payload" swaggertype:"object"`
+	Result      *string    `bun:"result" json:"result,omitempty" swaggertype:"object"`
+	Status      string     `bun:"status,notnull" json:"status" example:"pending"`
+	Attempts    int        `bun:"attempts,notnull" json:"attempts"`
+	MaxAttempts int        `bun:"max_attempts,notnull" json:"max_attempts"`
+	LastError   *string    `bun:"last_error" json:"last_error,omitempty"`
+	RunAt       time.Time  `bun:"run_at,notnull" json:"run_at"`
+	LockedAt    *time.Time `bun:"locked_at" json:"locked_at,omitempty"`
+	CreatedAt   time.Time  `bun:"created_at,notnull" json:"created_at"`
+	UpdatedAt   time.Time  `bun:"updated_at,notnull" json:"updated_at"`
+	FinishedAt  *time.Time `bun:"finished_at" json:"finished_at,omitempty"`
+}
+
+// MarshalJSON embeds the payload and result as JSON rather than strings
+func (j Job) MarshalJSON() ([]byte, error) {
+	type plain Job
+	out := struct {
+		plain
+		Payload json.RawMessage `json:"payload"`
+		Result  json.RawMessage `json:"result,omitempty"`
+	}{plain: plain(j), Payload: json.RawMessage("null")}
+	if j.Payload != "" {
+		out.Payload = json.RawMessage(j.Payload)
+	}
+	if j.Result != nil {
+		out.Result = json.RawMessage(*j.Result)
+	}
+	return json.Marshal(out)
+}
+
+// Decode unmarshals the job's payload into v
+func (j *Job) Decode(v any) error {
+	return json.Unmarshal([]byte(j.Payload), v)
+}
+
+// DecodeResult unmarshals the result of a succeeded job into v
+func (j *Job) DecodeResult(v any) error {
+	if j.Result == nil {
+		return nil
+	}
+	return json.Unmarshal([]byte(*j.Result), v)
+}
+
+// Get returns a job by ID
+func Get(ctx context.Context, db bun.IDB, id int64) (*Job, error) {
+	job := new(Job)
+	err := db.NewSelect().Model(job).Where("id = ?", id).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Filter narrows List; empty fields match any job
+type Filter struct {
+	Kind   string
+	Status string
+	Limit  int
+}
+
+// List returns jobs matching filter, newest first
+func List(ctx context.Context, db bun.IDB, filter Filter) ([]Job, error) {
+	limit := filter.Limit
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	var jobs []Job
+	q := db.NewSelect().Model(&jobs).Order("id DESC").Limit(limit)
+	if filter.Kind != "" {
+		q = q.Where("kind = ?", filter.Kind)
+	}
+	if filter.Status != "" {
+		q = q.Where("status = ?", filter.Status)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Retry puts a dead-lettered job back in the queue with a fresh set of attempts
+func Retry(ctx context.Context, db bun.IDB, id int64) (*Job, error) {
+	job, err := Get(ctx, db, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != StatusDead {
+		return nil, fmt.Errorf("%w: job %d is %s", ErrNotRetryable, id, job.Status)
+	}
+
+	now := time.Now()
+	job.Status = StatusPending
+	job.Attempts = 0
+	job.RunAt = now
+	job.UpdatedAt = now
+	job.FinishedAt = nil
+	_, err = db.NewUpdate().Model(job).
+		Column("status", "attempts", "run_at", "updated_at", "finished_at").
+		Where("id = ? AND status = ?", id, StatusDead).
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// PruneSucceeded deletes succeeded jobs that finished before cutoff and returns how
+// many were removed. Dead jobs are kept until they are retried or deleted by hand.
+func PruneSucceeded(ctx context.Context, db bun.IDB, cutoff time.Time) (int64, error) {
+	res, err := db.NewDelete().Model((*Job)(nil)).
+		Where("status = ? AND finished_at < ?", StatusSucceeded, cutoff).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Delete removes a job, e.g. once its output has expired
+func Delete(ctx context.Context, db bun.IDB, id int64) error {
+	_, err := db.NewDelete().Model((*Job)(nil)).Where("id = ?", id).Exec(ctx)
+	return err
+}
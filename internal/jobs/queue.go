@@ -0,0 +1,389 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/metrics"
+)
+
+// Handler processes a job. The returned result, if non-nil, is stored as JSON on
+// the job. Returning an error schedules a retry with backoff until the job runs out
+// of attempts and is dead-lettered.
+type Handler func(ctx context.Context, job *Job) (result any, err error)
+
+// Options configures a Queue
+type Options struct {
+	Workers      int           // Jobs processed concurrently (default 2)
+	PollInterval time.Duration // How often idle workers look for due jobs (default 1s)
+	MaxAttempts  int           // Default attempts per job before it is dead-lettered (default 5)
+	BaseBackoff  time.Duration // Delay before the first retry, doubled for each further one (default 10s)
+	MaxBackoff   time.Duration // Upper bound for the retry delay (default 1h)
+	JobTimeout   time.Duration // Time a single attempt may take (default 30m)
+}
+
+// withDefaults fills in unset options
+func (o Options) withDefaults() Options {
+	if o.Workers <= 0 {
+		o.Workers = 2
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = time.Second
+	}
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 5
+	}
+	if o.BaseBackoff <= 0 {
+		o.BaseBackoff = 10 * time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = time.Hour
+	}
+	if o.JobTimeout <= 0 {
+		o.JobTimeout = 30 * time.Minute
+	}
+	return o
+}
+
+// EnqueueOption customizes a single job
+type EnqueueOption func(job *Job)
+
+// RunAt delays a job until t
+func RunAt(t time.Time) EnqueueOption {
+	return func(job *Job) { job.RunAt = t }
+}
+
+// MaxAttempts overrides the number of attempts for a job
+func MaxAttempts(n int) EnqueueOption {
+	return func(job *Job) {
+		if n > 0 {
+			job.MaxAttempts = n
+		}
+	}
+}
+
+// Queue is a worker pool processing jobs from the jobs table. Workers claim due jobs
+// with SELECT ... FOR UPDATE SKIP LOCKED, so several server instances can share the
+// table. A job whose worker died is picked up again once JobTimeout has passed.
+type Queue struct {
+	db   *bun.DB
+	opts Options
+
+	mu       sync.Mutex
+	handlers map[string]Handler
+	started  bool
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewQueue creates a queue on db. Handlers must be registered before Start.
+func NewQueue(db *bun.DB, opts Options) *Queue {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Queue{
+		db:       db,
+		opts:     opts.withDefaults(),
+		handlers: make(map[string]Handler),
+		wake:     make(chan struct{}, 1),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Register sets the handler for jobs of the given kind
+func (q *Queue) Register(kind string, handler Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.started {
+		panic("jobs: Register called after Start")
+	}
+	q.handlers[kind] = handler
+}
+
+// Enqueue stores a new job; payload is encoded as JSON
+func (q *Queue) Enqueue(ctx context.Context, kind string, payload any, opts ...EnqueueOption) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s job payload: %w", kind, err)
+	}
+
+	now := time.Now()
+	job := &Job{
+		Kind:        kind,
+		Payload:     string(data),
+		Status:      StatusPending,
+		MaxAttempts: q.opts.MaxAttempts,
+		RunAt:       now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+
+	if _, err := q.db.NewInsert().Model(job).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to enqueue %s job: %w", kind, err)
+	}
+
+	// Let an idle worker pick it up without waiting for the next poll
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// Get returns a job by ID
+func (q *Queue) Get(ctx context.Context, id int64) (*Job, error) {
+	return Get(ctx, q.db, id)
+}
+
+// Delete removes a job
+func (q *Queue) Delete(ctx context.Context, id int64) error {
+	return Delete(ctx, q.db, id)
+}
+
+// Active counts the pending and running jobs of a kind
+func (q *Queue) Active(ctx context.Context, kind string) (int, error) {
+	return q.db.NewSelect().Model((*Job)(nil)).
+		Where("kind = ? AND status IN (?)", kind, bun.In([]string{StatusPending, StatusRunning})).
+		Count(ctx)
+}
+
+// Start launches the workers
+func (q *Queue) Start() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.started {
+		return
+	}
+	q.started = true
+	for range q.opts.Workers {
+		q.wg.Add(1)
+		go q.work()
+	}
+}
+
+// Shutdown stops the workers and waits for running jobs to return or ctx to be
+// done. Interrupted jobs are put back in the queue without using up an attempt.
+func (q *Queue) Shutdown(ctx context.Context) error {
+	q.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work claims and processes due jobs until the queue shuts down
+func (q *Queue) work() {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(q.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Drain due jobs before going back to sleep
+		for q.ctx.Err() == nil {
+			job, err := q.claim(q.ctx)
+			if err != nil {
+				if q.ctx.Err() == nil {
+					logging.FromContext(q.ctx).Error("Failed to claim job", slog.String("error", err.Error()))
+				}
+				break
+			}
+			if job == nil {
+				break
+			}
+			q.process(job)
+		}
+
+		select {
+		case <-q.ctx.Done():
+			return
+		case <-q.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// claim locks the next due job of a registered kind and marks it running, or
+// returns nil if there is none. Running jobs whose lock is older than JobTimeout
+// belong to a worker that died and are claimed again.
+func (q *Queue) claim(ctx context.Context) (*Job, error) {
+	q.mu.Lock()
+	kinds := make([]string, 0, len(q.handlers))
+	for kind := range q.handlers {
+		kinds = append(kinds, kind)
+	}
+	q.mu.Unlock()
+	if len(kinds) == 0 {
+		return nil, nil
+	}
+
+	var job Job
+	err := q.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		now := time.Now()
+		err := tx.NewSelect().Model(&job).
+			Where("kind IN (?)", bun.In(kinds)).
+			WhereGroup(" AND ", func(sq *bun.SelectQuery) *bun.SelectQuery {
+				return sq.
+					Where("status = ? AND run_at <= ?", StatusPending, now).
+					WhereOr("status = ? AND locked_at < ?", StatusRunning, now.Add(-q.opts.JobTimeout))
+			}).
+			Order("run_at ASC").
+			Limit(1).
+			For("UPDATE SKIP LOCKED").
+			Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		job.Status = StatusRunning
+		job.Attempts++
+		job.LockedAt = &now
+		job.UpdatedAt = now
+		_, err = tx.NewUpdate().Model(&job).
+			Column("status", "attempts", "locked_at", "updated_at").
+			WherePK().
+			Exec(ctx)
+		return err
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// process runs a claimed job and records the outcome
+func (q *Queue) process(job *Job) {
+	q.mu.Lock()
+	handler := q.handlers[job.Kind]
+	q.mu.Unlock()
+
+	logger := logging.FromContext(q.ctx).With(
+		slog.String("job_kind", job.Kind),
+		slog.Int64("job_id", job.ID),
+		slog.Int("attempt", job.Attempts))
+	ctx, cancel := context.WithTimeout(logging.NewContext(q.ctx, logger), q.opts.JobTimeout)
+	defer cancel()
+
+	start := time.Now()
+	result, err := runHandler(ctx, handler, job)
+	duration := time.Since(start)
+
+	// Record the outcome even while shutting down
+	saveCtx, saveCancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer saveCancel()
+
+	now := time.Now()
+	job.LockedAt = nil
+	job.UpdatedAt = now
+	outcome := "succeeded"
+
+	switch {
+	case err == nil:
+		job.Status = StatusSucceeded
+		job.FinishedAt = &now
+		job.LastError = nil
+		if result != nil {
+			data, encodeErr := json.Marshal(result)
+			if encodeErr != nil {
+				logger.Error("Failed to encode job result", slog.String("error", encodeErr.Error()))
+			} else {
+				encoded := string(data)
+				job.Result = &encoded
+			}
+		}
+		logger.Info("Job succeeded", slog.Duration("duration", duration))
+
+	case q.ctx.Err() != nil:
+		// Interrupted by shutdown: run it again after the restart without counting this attempt
+		job.Status = StatusPending
+		job.Attempts--
+		job.RunAt = now
+		outcome = "interrupted"
+		logger.Warn("Job interrupted by shutdown", slog.Duration("duration", duration))
+
+	default:
+		message := err.Error()
+		job.LastError = &message
+		if job.Attempts >= job.MaxAttempts {
+			job.Status = StatusDead
+			job.FinishedAt = &now
+			outcome = "dead"
+			logger.Error("Job failed permanently",
+				slog.Duration("duration", duration),
+				slog.String("error", message))
+		} else {
+			delay := q.backoff(job.Attempts)
+			job.Status = StatusPending
+			job.RunAt = now.Add(delay)
+			outcome = "retried"
+			logger.Warn("Job failed, retrying",
+				slog.Duration("duration", duration),
+				slog.Duration("retry_in", delay),
+				slog.String("error", message))
+		}
+	}
+	metrics.JobsProcessed.WithLabelValues(job.Kind, outcome).Inc()
+
+	_, err = q.db.NewUpdate().Model(job).
+		Column("status", "attempts", "result", "last_error", "run_at", "locked_at", "updated_at", "finished_at").
+		WherePK().
+		Exec(saveCtx)
+	if err != nil {
+		logger.Error("Failed to save job state", slog.String("error", err.Error()))
+	}
+}
+
+// backoff returns the delay before retrying after the given attempt: BaseBackoff
+// doubled per attempt, capped at MaxBackoff, with up to 20% jitter so failed jobs
+// don't retry in lockstep
+func (q *Queue) backoff(attempt int) time.Duration {
+	delay := q.opts.BaseBackoff
+	for i := 1; i < attempt && delay < q.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, q.opts.MaxBackoff)
+	return delay + rand.N(delay/5+1)
+}
+
+// runHandler calls handler, turning a panic into an error
+func runHandler(ctx context.Context, handler Handler, job *Job) (result any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			logging.FromContext(ctx).Error("Job panicked",
+				slog.Any("panic", p),
+				slog.String("stack", string(debug.Stack())))
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return handler(ctx, job)
+}
@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Level is the process-wide minimum log level, adjustable at runtime
+var Level = new(slog.LevelVar)
+
+// contextKey is an unexported type to avoid collisions with other packages
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the given logger
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the request-scoped logger, falling back to the default logger
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With returns a copy of ctx whose logger includes the given attributes
+func With(ctx context.Context, args ...any) context.Context {
+	return NewContext(ctx, FromContext(ctx).With(args...))
+}
+
+// WithUser returns a copy of ctx whose logger is tagged with the authenticated user
+func WithUser(ctx context.Context, user string) context.Context {
+	return With(ctx, slog.String("user", user))
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/uptrace/bun"
+)
+
+const namespace = "agora"
+
+// Registry holds all application metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestsTotal counts handled HTTP requests by method, route and status
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Total number of HTTP requests processed.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPRequestDuration observes HTTP request latencies by method and route
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	// HTTPRequestsInFlight tracks the number of requests currently being served
+	HTTPRequestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_in_flight",
+		Help:      "Number of HTTP requests currently being served.",
+	})
+
+	// MenuItemsCreated counts successfully created menu items
+	MenuItemsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "menu",
+		Name:      "items_created_total",
+		Help:      "Total number of menu items created.",
+	})
+
+	// JobsProcessed counts background job attempts by kind and outcome
+	// (succeeded, retried, dead, interrupted)
+	JobsProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "jobs",
+		Name:      "processed_total",
+		Help:      "Total number of background job attempts.",
+	}, []string{"kind", "outcome"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		HTTPRequestsInFlight,
+		MenuItemsCreated,
+		JobsProcessed,
+	)
+}
+
+// RegisterDBStats exposes connection pool statistics for the given database
+func RegisterDBStats(db *bun.DB) {
+	Registry.MustRegister(newDBStatsCollector(db))
+}
+
+// Handler returns the HTTP handler serving metrics in Prometheus format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/uptrace/bun"
+)
+
+var (
+	// DBQueryDuration observes query latencies by operation type
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Database query latency in seconds.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation"})
+
+	// DBQueryErrors counts failed queries by operation type
+	DBQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_errors_total",
+		Help:      "Total number of failed database queries.",
+	}, []string{"operation"})
+
+	// DBRowsAffected counts rows affected by write queries by operation type
+	DBRowsAffected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "rows_affected_total",
+		Help:      "Total number of rows affected by database queries.",
+	}, []string{"operation"})
+)
+
+func init() {
+	Registry.MustRegister(DBQueryDuration, DBQueryErrors, DBRowsAffected)
+}
+
+// QueryHook is a Bun query hook recording query metrics
+type QueryHook struct{}
+
+var _ bun.QueryHook = (*QueryHook)(nil)
+
+// NewQueryHook creates a query hook that records duration, errors and rows affected
+func NewQueryHook() *QueryHook {
+	return &QueryHook{}
+}
+
+// BeforeQuery implements bun.QueryHook
+func (h *QueryHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+// AfterQuery implements bun.QueryHook
+func (h *QueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	operation := event.Operation()
+
+	DBQueryDuration.WithLabelValues(operation).Observe(time.Since(event.StartTime).Seconds())
+
+	// A missing row is a normal outcome, not a query failure
+	if event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows) {
+		DBQueryErrors.WithLabelValues(operation).Inc()
+		return
+	}
+
+	if event.Result != nil {
+		if rows, err := event.Result.RowsAffected(); err == nil && rows > 0 {
+			DBRowsAffected.WithLabelValues(operation).Add(float64(rows))
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package middlewares
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// clientIPKey is the context key for the resolved client IP
+type clientIPKey struct{}
+
+// ClientIPMiddleware resolves the real client IP and stores it in the request context
+// and logger. X-Forwarded-For and X-Real-IP are only honoured when the connection comes
+// from one of the trusted proxies; X-Forwarded-For is read right to left, skipping
+// trusted hops, so clients cannot spoof their address by prepending entries. Requests
+// arriving on a unix socket always come from a local proxy and are trusted as well.
+func ClientIPMiddleware(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := resolveClientIP(r, trustedProxies)
+
+			ctx := context.WithValue(r.Context(), clientIPKey{}, ip)
+			ctx = logging.With(ctx, slog.String("client_ip", ip))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ClientIP returns the client IP resolved by ClientIPMiddleware, falling back to the
+// address of the connection's peer
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return remoteHost(r)
+}
+
+// resolveClientIP applies the trusted proxy rules to a request
+func resolveClientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	remote := remoteHost(r)
+	if !containsIP(trustedProxies, remote) && !viaUnixSocket(r) {
+		return remote
+	}
+
+	// Walk the chain from the nearest hop; the first untrusted address is the client
+	var hops []string
+	for _, header := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if _, err := netip.ParseAddr(hop); err != nil {
+			// A malformed entry ends the trustworthy part of the chain
+			break
+		}
+		if !containsIP(trustedProxies, hop) {
+			return hop
+		}
+		remote = hop
+	}
+	if len(hops) > 0 {
+		return remote
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+		if _, err := netip.ParseAddr(realIP); err == nil {
+			return realIP
+		}
+	}
+	return remote
+}
+
+// containsIP reports whether ip belongs to one of the ranges
+func containsIP(prefixes []netip.Prefix, ip string) bool {
+	if len(prefixes) == 0 {
+		return false
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// viaUnixSocket reports whether the request arrived on a unix socket, which only a
+// local reverse proxy can connect to
+func viaUnixSocket(r *http.Request) bool {
+	addr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	return ok && addr.Network() == "unix"
+}
+
+// remoteHost returns the host part of the connection's remote address
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,257 @@
+package middlewares
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Content codings supported by CompressionMiddleware, in order of preference
+const (
+	encodingZstd = "zstd"
+	encodingGzip = "gzip"
+)
+
+var (
+	gzipWriters = sync.Pool{New: func() any {
+		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return w
+	}}
+	zstdWriters = sync.Pool{New: func() any {
+		w, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return w
+	}}
+)
+
+// CompressionMiddleware compresses responses of at least minSize bytes with zstd or gzip,
+// as negotiated via Accept-Encoding. Only textual content types (JSON, NDJSON, CSV, text)
+// are compressed. A handler that flushes before minSize is reached is treated as
+// streaming and compressed from the first flush on, so long list and export responses
+// are not buffered in memory.
+func CompressionMiddleware(minSize int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressResponseWriter{ResponseWriter: w, encoding: encoding, minSize: minSize}
+			defer cw.close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateEncoding picks the preferred supported coding from an Accept-Encoding header
+func negotiateEncoding(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != encodingZstd && coding != encodingGzip {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		// Prefer zstd over gzip when the client weighs them equally
+		if q > bestQ || (q == bestQ && coding == encodingZstd) {
+			best, bestQ = coding, q
+		}
+	}
+	return best
+}
+
+// compressibleType reports whether responses of the given Content-Type benefit from compression
+func compressibleType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mediaType == "text/event-stream":
+		// Events are tiny and must reach clients immediately
+		return false
+	case strings.HasPrefix(mediaType, "text/"),
+		mediaType == "application/json",
+		mediaType == "application/x-ndjson",
+		mediaType == "application/problem+json",
+		strings.HasSuffix(mediaType, "+json"):
+		return true
+	}
+	return false
+}
+
+// compressResponseWriter buffers the start of a response until it knows whether to
+// compress it, then either passes it through or streams it through an encoder
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+
+	status  int
+	buf     []byte
+	decided bool
+	encoder io.WriteCloser
+}
+
+func (cw *compressResponseWriter) WriteHeader(code int) {
+	if cw.status != 0 {
+		return
+	}
+	cw.status = code
+
+	// Informational and bodyless responses are never compressed
+	if code < http.StatusOK || code == http.StatusNoContent || code == http.StatusNotModified {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressResponseWriter) Write(b []byte) (int, error) {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+
+	if !cw.decided {
+		cw.buf = append(cw.buf, b...)
+		if len(cw.buf) < cw.minSize {
+			return len(b), nil
+		}
+		if err := cw.start(true); err != nil {
+			return 0, err
+		}
+		return len(b), nil
+	}
+
+	if cw.encoder != nil {
+		return cw.encoder.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// Flush commits to a decision early so streamed responses are sent as they are produced
+func (cw *compressResponseWriter) Flush() {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+	if !cw.decided {
+		if err := cw.start(true); err != nil {
+			return
+		}
+	}
+	if flusher, ok := cw.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (cw *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// Hijack lets protocol upgrades bypass compression
+func (cw *compressResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	cw.decided = true
+	return http.NewResponseController(cw.ResponseWriter).Hijack()
+}
+
+// start decides whether to compress, writes the header and any buffered body
+func (cw *compressResponseWriter) start(allowed bool) error {
+	cw.decide(allowed)
+
+	buffered := cw.buf
+	cw.buf = nil
+	if len(buffered) == 0 {
+		return nil
+	}
+	if cw.encoder != nil {
+		_, err := cw.encoder.Write(buffered)
+		return err
+	}
+	_, err := cw.ResponseWriter.Write(buffered)
+	return err
+}
+
+// decide fixes the response encoding and sends the status line and headers
+func (cw *compressResponseWriter) decide(allowed bool) {
+	if cw.decided {
+		return
+	}
+	cw.decided = true
+
+	header := cw.ResponseWriter.Header()
+	if header.Get("Content-Type") == "" && len(cw.buf) > 0 {
+		header.Set("Content-Type", http.DetectContentType(cw.buf))
+	}
+
+	if allowed && header.Get("Content-Encoding") == "" && compressibleType(header.Get("Content-Type")) {
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+		cw.encoder = cw.newEncoder()
+	}
+
+	if cw.status != 0 {
+		cw.ResponseWriter.WriteHeader(cw.status)
+	}
+}
+
+// newEncoder takes a pooled encoder writing to the underlying response
+func (cw *compressResponseWriter) newEncoder() io.WriteCloser {
+	if cw.encoding == encodingZstd {
+		enc := zstdWriters.Get().(*zstd.Encoder)
+		enc.Reset(cw.ResponseWriter)
+		return enc
+	}
+	gz := gzipWriters.Get().(*gzip.Writer)
+	gz.Reset(cw.ResponseWriter)
+	return gz
+}
+
+// close flushes a response that stayed below minSize uncompressed, or finishes the encoder
+func (cw *compressResponseWriter) close() {
+	if !cw.decided {
+		if cw.status == 0 && len(cw.buf) == 0 {
+			// Nothing was written; let net/http send its implicit 200
+			return
+		}
+		_ = cw.start(false)
+		return
+	}
+	if cw.encoder == nil {
+		return
+	}
+
+	_ = cw.encoder.Close()
+	switch enc := cw.encoder.(type) {
+	case *zstd.Encoder:
+		enc.Reset(nil)
+		zstdWriters.Put(enc)
+	case *gzip.Writer:
+		enc.Reset(io.Discard)
+		gzipWriters.Put(enc)
+	}
+	cw.encoder = nil
+}
@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/netip"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// IPFilterMiddleware rejects clients outside allow (when it is non-empty) or inside
+// deny with 403 Forbidden. The client IP is the one resolved by ClientIPMiddleware.
+func IPFilterMiddleware(allow, deny []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(allow) == 0 && len(deny) == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := ClientIP(r)
+			if containsIP(deny, ip) || (len(allow) > 0 && !containsIP(allow, ip)) {
+				logging.FromContext(r.Context()).Warn("Blocked request from disallowed IP")
+				SendErrorResponse(w, r, http.StatusForbidden, "Forbidden", "Access from your IP address is not allowed")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -2,12 +2,164 @@ package middlewares
 
 import (
 	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/metrics"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// RequestIDHeader is the header used to propagate request IDs
+const RequestIDHeader = "X-Request-ID"
+
+// RequestContextMiddleware assigns a request ID and stores a request-scoped logger in the context
+func RequestContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Reuse the caller's request ID when it is safe to log and echo, otherwise
+		// generate one
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		logger := slog.Default().With(
+			slog.String("request_id", requestID),
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+		)
+
+		// Correlate log lines with the active trace when tracing is enabled
+		if spanCtx := trace.SpanContextFromContext(r.Context()); spanCtx.IsValid() {
+			logger = logger.With(slog.String("trace_id", spanCtx.TraceID().String()))
+		}
+
+		ctx := logging.NewContext(r.Context(), logger)
+		ctx = context.WithValue(ctx, requestInfoKey{}, &requestInfo{id: requestID})
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RouteLogger tags the request-scoped logger with the matched route pattern
+func RouteLogger(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Record the route so outer middlewares can see it after the mux has matched
+		if info, ok := r.Context().Value(requestInfoKey{}).(*requestInfo); ok {
+			info.route = r.Pattern
+		}
+
+		// Name the server span after the route rather than the raw path
+		span := trace.SpanFromContext(r.Context())
+		span.SetName(r.Pattern)
+		span.SetAttributes(semconv.HTTPRoute(r.Pattern))
+
+		ctx := logging.With(r.Context(), slog.String("route", r.Pattern))
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// TracingMiddleware starts a server span for every request and extracts incoming trace context
+func TracingMiddleware(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "http.server",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method
+		}),
+	)
+}
+
+// MetricsMiddleware records request counts, latencies and in-flight requests
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		metrics.HTTPRequestsInFlight.Inc()
+		defer metrics.HTTPRequestsInFlight.Dec()
+
+		start := time.Now()
+		lrw := &loggingResponseWriter{
+			ResponseWriter: w,
+			statusCode:     0,
+		}
+
+		next.ServeHTTP(lrw, r)
+
+		route := requestRoute(r.Context())
+		status := lrw.statusCode
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		metrics.HTTPRequestsTotal.WithLabelValues(r.Method, route, strconv.Itoa(status)).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
+
+// requestInfoKey is the context key for per-request routing information
+type requestInfoKey struct{}
+
+// requestInfo carries data discovered while routing back to outer middlewares
+type requestInfo struct {
+	id    string
+	route string
+}
+
+// RequestID returns the ID assigned to the request by RequestContextMiddleware, or ""
+func RequestID(ctx context.Context) string {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		return info.id
+	}
+	return ""
+}
+
+// requestRoute returns the route pattern RouteLogger matched the request to. Unmatched
+// paths share a single value to keep metric label cardinality bounded.
+func requestRoute(ctx context.Context) string {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok && info.route != "" {
+		return info.route
+	}
+	return "unmatched"
+}
+
+// maxRequestIDLength is the longest request ID accepted from callers
+const maxRequestIDLength = 128
+
+// validRequestID reports whether a caller's request ID is 1 to maxRequestIDLength
+// letters, digits, dots, underscores or hyphens
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range []byte(id) {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '.', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID generates a random 16-byte hex request ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
 // LoggingMiddleware logs HTTP requests with response status and timing
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -31,9 +183,9 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 			level = slog.LevelWarn
 		}
 
-		slog.Log(r.Context(), level, "HTTP Request",
-			slog.String("method", r.Method),
-			slog.String("path", r.URL.Path),
+		// The route is only known once the mux has matched it, after the logger was set up
+		logging.FromContext(r.Context()).Log(r.Context(), level, "HTTP Request",
+			slog.String("route", requestRoute(r.Context())),
 			slog.Int("status", lrw.statusCode),
 			slog.String("remote_addr", r.RemoteAddr),
 			slog.String("user_agent", r.UserAgent()),
@@ -41,20 +193,57 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 	})
 }
 
-// CORSMiddleware handles Cross-Origin Resource Sharing
-func CORSMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+// OriginList is a list of allowed CORS origins that can be replaced at runtime
+type OriginList struct {
+	origins atomic.Pointer[[]string]
+}
 
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+// NewOriginList creates an OriginList holding origins
+func NewOriginList(origins []string) *OriginList {
+	l := &OriginList{}
+	l.Set(origins)
+	return l
+}
 
-		next.ServeHTTP(w, r)
-	})
+// Set replaces the allowed origins
+func (l *OriginList) Set(origins []string) {
+	l.origins.Store(&origins)
+}
+
+// Get returns the allowed origins
+func (l *OriginList) Get() []string {
+	return *l.origins.Load()
+}
+
+// CORSMiddleware handles Cross-Origin Resource Sharing for allowedOrigins ("*" allows any origin)
+func CORSMiddleware(allowedOrigins *OriginList) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			setCORSHeaders(w, r, allowedOrigins.Get())
+
+			if r.Method == "OPTIONS" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setCORSHeaders writes the CORS response headers, echoing the request origin when it is allowed
+func setCORSHeaders(w http.ResponseWriter, r *http.Request, allowedOrigins []string) {
+	if slices.Contains(allowedOrigins, "*") {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	} else {
+		w.Header().Add("Vary", "Origin")
+		if origin := r.Header.Get("Origin"); origin != "" && slices.Contains(allowedOrigins, origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+	}
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-Request-ID, X-Response-Case")
+	w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 }
 
 // NotFoundHandler returns a professional 404 JSON response
@@ -71,8 +260,35 @@ func MethodNotAllowedHandler() http.HandlerFunc {
 	}
 }
 
-// SendErrorResponse sends a standardized JSON error response
+// ErrorFormatter writes an error response in the envelope of an API version
+type ErrorFormatter func(w http.ResponseWriter, r *http.Request, statusCode int, message string)
+
+// errorFormatters are the envelopes of API versions by path prefix
+var errorFormatters []struct {
+	prefix string
+	format ErrorFormatter
+}
+
+// RegisterErrorFormatter makes SendErrorResponse answer requests under prefix with
+// format, so errors raised by middlewares use that API version's envelope. It must
+// be called before the server starts.
+func RegisterErrorFormatter(prefix string, format ErrorFormatter) {
+	errorFormatters = append(errorFormatters, struct {
+		prefix string
+		format ErrorFormatter
+	}{prefix, format})
+}
+
+// SendErrorResponse sends a standardized JSON error response, or the envelope
+// registered for the request's path
 func SendErrorResponse(w http.ResponseWriter, r *http.Request, statusCode int, errorType, message string) {
+	for _, f := range errorFormatters {
+		if strings.HasPrefix(r.URL.Path, f.prefix) {
+			f.format(w, r, statusCode, message)
+			return
+		}
+	}
+
 	response := ErrorResponse{
 		Message:    message,
 		Error:      errorType,
@@ -88,12 +304,12 @@ func SendErrorResponse(w http.ResponseWriter, r *http.Request, statusCode int, e
 	if err := json.NewEncoder(&buf).Encode(response); err != nil {
 		// Fallback to simple text response if JSON encoding fails
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		slog.Error("Failed to encode error response", slog.String("error", err.Error()))
+		logging.FromContext(r.Context()).Error("Failed to encode error response", slog.String("error", err.Error()))
 		return
 	}
 
 	if _, err := w.Write(buf.Bytes()); err != nil {
-		slog.Error("Failed to write error response", slog.String("error", err.Error()))
+		logging.FromContext(r.Context()).Error("Failed to write error response", slog.String("error", err.Error()))
 	}
 }
 
@@ -102,10 +318,8 @@ func RecoveryMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				slog.Error("Panic recovered",
+				logging.FromContext(r.Context()).Error("Panic recovered",
 					slog.Any("error", err),
-					slog.String("path", r.URL.Path),
-					slog.String("method", r.Method),
 				)
 				SendErrorResponse(w, r, http.StatusInternalServerError, "Internal Server Error", "An unexpected error occurred")
 			}
@@ -114,6 +328,29 @@ func RecoveryMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// AdminAuthMiddleware restricts operational endpoints to callers presenting the admin bearer token.
+// When no token is configured the endpoints are disabled entirely.
+func AdminAuthMiddleware(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				NotFoundHandler()(w, r)
+				return
+			}
+
+			provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				logging.FromContext(r.Context()).Warn("Rejected unauthenticated admin request")
+				w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+				SendErrorResponse(w, r, http.StatusUnauthorized, "Unauthorized", "A valid admin token is required")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(logging.WithUser(r.Context(), "admin")))
+		})
+	}
+}
+
 // ResponseWriter wrapper to capture status code and response size
 type loggingResponseWriter struct {
 	http.ResponseWriter
@@ -126,6 +363,21 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap exposes the underlying writer to http.ResponseController
+func (lrw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return lrw.ResponseWriter
+}
+
+// Flush forwards flushes so streaming responses are not buffered
+func (lrw *loggingResponseWriter) Flush() {
+	if lrw.statusCode == 0 {
+		lrw.statusCode = http.StatusOK
+	}
+	if flusher, ok := lrw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 func (lrw *loggingResponseWriter) Write(b []byte) (int, error) {
 	if lrw.statusCode == 0 {
 		lrw.statusCode = http.StatusOK
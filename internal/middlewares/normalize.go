@@ -0,0 +1,36 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+)
+
+// NormalizePathMiddleware collapses repeated slashes in the request path ("/api//v1/items"
+// becomes "/api/v1/items") and serves the request under the cleaned path. ServeMux would
+// otherwise answer with a redirect, which clients follow as GET even for POST or PUT.
+// Trailing slashes are handled by the route fallback, which knows the registered routes.
+func NormalizePathMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "//") {
+			u := *r.URL
+			u.Path = collapseSlashes(u.Path)
+			u.RawPath = ""
+			r = r.Clone(r.Context())
+			r.URL = &u
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// collapseSlashes replaces every run of slashes in path with a single slash
+func collapseSlashes(path string) string {
+	var b strings.Builder
+	b.Grow(len(path))
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' && i > 0 && path[i-1] == '/' {
+			continue
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter limits requests per client IP (as resolved by ClientIPMiddleware) with
+// a token bucket. The limit can be changed at runtime; a rate of 0 disables limiting.
+type RateLimiter struct {
+	mu      sync.Mutex
+	limit   rate.Limit
+	burst   int
+	clients map[string]*clientLimiter
+	evicted time.Time // when idle buckets were last dropped
+}
+
+// clientLimiter is the bucket of a single client
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// clientIdleTimeout is how long an unused client bucket is kept
+const clientIdleTimeout = 10 * time.Minute
+
+// clientEvictInterval is how often idle buckets are looked for, so new clients
+// don't each scan every bucket
+const clientEvictInterval = time.Minute
+
+// NewRateLimiter creates a limiter allowing rps requests per second with the given burst per client
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	l := &RateLimiter{clients: make(map[string]*clientLimiter)}
+	l.SetLimit(rps, burst)
+	return l
+}
+
+// SetLimit changes the limit for all current and future clients
+func (l *RateLimiter) SetLimit(rps float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limit = rate.Limit(rps)
+	l.burst = max(burst, 1)
+	for _, c := range l.clients {
+		c.limiter.SetLimit(l.limit)
+		c.limiter.SetBurst(l.burst)
+	}
+}
+
+// allow reports whether the client may make a request now, and if not how long to wait
+func (l *RateLimiter) allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit <= 0 {
+		return true, 0
+	}
+
+	now := time.Now()
+	c, ok := l.clients[client]
+	if !ok {
+		l.evictIdle(now)
+		c = &clientLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.clients[client] = c
+	}
+	c.lastSeen = now
+
+	reservation := c.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// evictIdle drops buckets of clients not seen recently, at most once per
+// clientEvictInterval; callers hold l.mu
+func (l *RateLimiter) evictIdle(now time.Time) {
+	if now.Sub(l.evicted) < clientEvictInterval {
+		return
+	}
+	l.evicted = now
+	for key, c := range l.clients {
+		if now.Sub(c.lastSeen) > clientIdleTimeout {
+			delete(l.clients, key)
+		}
+	}
+}
+
+// Middleware rejects requests over the limit with 429 Too Many Requests
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ok, retryAfter := l.allow(ClientIP(r)); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			SendErrorResponse(w, r, http.StatusTooManyRequests, "Too Many Requests", "Rate limit exceeded, retry later")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
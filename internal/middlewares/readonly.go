@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// ReadOnly puts the API into read-only mode, e.g. while failing over to a read
+// replica or during a data audit. It is set from READ_ONLY at startup and can be
+// toggled at runtime through a config reload or PUT /admin/read-only.
+var ReadOnly atomic.Bool
+
+// ReadOnlyMiddleware rejects mutating requests with 503 while read-only mode is on.
+// Safe methods (GET, HEAD, OPTIONS) are always served.
+func ReadOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ReadOnly.Load() && !isSafeMethod(r.Method) {
+			w.Header().Set("Retry-After", "60")
+			SendErrorResponse(w, r, http.StatusServiceUnavailable, "Service Unavailable", "The API is in read-only mode; "+r.Method+" requests are temporarily disabled")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isSafeMethod reports whether method does not modify server state
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// TimeoutMiddleware bounds each request to timeout. The request context is cancelled
+// when the time is up, so database queries stop, and the client gets a structured 504
+// even if the handler is still running. Individual routes can change the limit with
+// WithTimeout. A timeout of 0 or less disables the middleware.
+//
+// Responses are buffered until the handler returns; a handler that flushes commits
+// its response and streams from then on, after which a timeout only cancels the context.
+func TimeoutMiddleware(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			base := r.Context()
+			ctx, cancel := context.WithTimeout(base, timeout)
+			defer cancel()
+
+			tw := &timeoutWriter{
+				w:      w,
+				header: make(http.Header),
+				base:   base,
+				start:  time.Now(),
+				timer:  time.NewTimer(timeout),
+				cancel: cancel,
+			}
+			defer tw.timer.Stop()
+
+			done := make(chan struct{})
+			panicked := make(chan any, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+					}
+				}()
+				next.ServeHTTP(tw, r.WithContext(context.WithValue(ctx, timeoutKey{}, tw)))
+				close(done)
+			}()
+
+			select {
+			case p := <-panicked:
+				// Re-panic on the serving goroutine so RecoveryMiddleware can handle it
+				panic(p)
+			case <-done:
+				tw.finish()
+			case <-tw.timer.C:
+				if streaming := tw.expire(r); !streaming {
+					return
+				}
+				// A streaming handler still owns the connection; wait for it to notice the cancellation
+				select {
+				case p := <-panicked:
+					panic(p)
+				case <-done:
+				}
+			}
+		})
+	}
+}
+
+// WithTimeout overrides the limit set by TimeoutMiddleware for a single route, e.g.
+// to give imports and exports longer than ordinary requests. The new limit counts
+// from the start of the request, and the server read/write deadlines are extended to match.
+func WithTimeout(timeout time.Duration) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			tw, ok := r.Context().Value(timeoutKey{}).(*timeoutWriter)
+			if !ok {
+				next(w, r)
+				return
+			}
+
+			ctx, cancel, ok := tw.extend(r.Context(), timeout)
+			if !ok {
+				return
+			}
+			defer cancel()
+
+			// Keep the connection open long enough for the upload to be read and the response written
+			rc := http.NewResponseController(tw.w)
+			if err := rc.SetReadDeadline(tw.start.Add(timeout)); err != nil {
+				logging.FromContext(r.Context()).Debug("Could not extend read deadline", slog.String("error", err.Error()))
+			}
+			if err := rc.SetWriteDeadline(tw.start.Add(timeout + time.Second)); err != nil {
+				logging.FromContext(r.Context()).Debug("Could not extend write deadline", slog.String("error", err.Error()))
+			}
+
+			next(w, r.WithContext(context.WithValue(ctx, timeoutKey{}, tw)))
+		}
+	}
+}
+
+// timeoutKey is the context key for the active timeoutWriter
+type timeoutKey struct{}
+
+// timeoutWriter buffers the handler's response so a 504 can be sent instead if the
+// handler runs out of time
+type timeoutWriter struct {
+	w     http.ResponseWriter
+	base  context.Context
+	start time.Time
+	timer *time.Timer
+
+	mu        sync.Mutex
+	header    http.Header
+	buf       bytes.Buffer
+	status    int
+	cancel    context.CancelFunc
+	committed bool // the response has been handed to w and is streaming
+	timedOut  bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	if tw.committed {
+		return tw.w.Header()
+	}
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.status != 0 {
+		return
+	}
+	tw.status = code
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	if tw.committed {
+		return tw.w.Write(b)
+	}
+	return tw.buf.Write(b)
+}
+
+// Flush commits the response so far and streams the rest directly to the client
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return
+	}
+	if !tw.committed {
+		tw.commit()
+	}
+	if flusher, ok := tw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.w
+}
+
+// commit writes the buffered header and body to the client; callers hold tw.mu
+func (tw *timeoutWriter) commit() {
+	tw.committed = true
+	dst := tw.w.Header()
+	for key, values := range tw.header {
+		dst[key] = values
+	}
+	if tw.status == 0 {
+		tw.status = http.StatusOK
+	}
+	tw.w.WriteHeader(tw.status)
+	if tw.buf.Len() > 0 {
+		_, _ = tw.w.Write(tw.buf.Bytes())
+		tw.buf.Reset()
+	}
+}
+
+// finish sends the buffered response once the handler has returned in time
+func (tw *timeoutWriter) finish() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.committed {
+		return
+	}
+	if tw.status == 0 && tw.buf.Len() == 0 {
+		// Nothing was written; let net/http send its implicit 200
+		tw.committed = true
+		return
+	}
+	tw.commit()
+}
+
+// expire cancels the handler's context and replies 504 unless streaming has begun,
+// reporting whether it had
+func (tw *timeoutWriter) expire(r *http.Request) bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	tw.cancel()
+	logging.FromContext(r.Context()).Warn("Request timed out",
+		slog.Duration("elapsed", time.Since(tw.start)),
+		slog.Bool("streaming", tw.committed))
+
+	if tw.committed {
+		// Headers are already sent; the cancelled context ends the stream
+		return true
+	}
+	tw.timedOut = true
+	SendErrorResponse(tw.w, r, http.StatusGatewayTimeout, "Gateway Timeout", "The request took too long to process")
+	return false
+}
+
+// extend replaces the limit with timeout counted from the start of the request and
+// returns a copy of parent carrying the new deadline instead of the old one. It
+// reports false if the request has already timed out.
+func (tw *timeoutWriter) extend(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc, bool) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return nil, nil, false
+	}
+
+	deadline := tw.start.Add(timeout)
+	tw.timer.Reset(time.Until(deadline))
+
+	// Keep parent's values but not its deadline; still stop when the client goes away
+	ctx, cancel := context.WithDeadline(context.WithoutCancel(parent), deadline)
+	stop := context.AfterFunc(tw.base, cancel)
+	previous := tw.cancel
+	tw.cancel = func() {
+		cancel()
+		previous()
+	}
+	return ctx, func() {
+		stop()
+		cancel()
+	}, true
+}
@@ -0,0 +1,142 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// EventShutdown is sent to every client right before the server stops, telling it to reconnect
+const EventShutdown = "server.shutdown"
+
+// ErrShuttingDown is returned by Subscribe once Shutdown has started
+var ErrShuttingDown = errors.New("real-time hub is shutting down")
+
+// subscriptionBuffer is how many events a client may lag behind before it is dropped
+const subscriptionBuffer = 64
+
+// Event is a message broadcast to real-time clients
+type Event struct {
+	Type string      `json:"type" example:"menu_item.updated"`
+	Data interface{} `json:"data,omitempty"`
+	// Kitchen station the event is for; streams following a station only receive
+	// that station's events
+	Station string `json:"station,omitempty" example:"grill"`
+	// Restaurant the event happened at; streams only receive their restaurant's
+	// events, and events without one, such as the shutdown notice
+	Restaurant int `json:"restaurant_id,omitempty" example:"1"`
+}
+
+// Subscription is a single connected client (e.g. an SSE stream)
+type Subscription struct {
+	hub    *Hub
+	events chan Event
+	once   sync.Once
+}
+
+// Events delivers the broadcast events; it is closed when the subscription ends,
+// either because the client was too slow or because the hub is shutting down
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close unregisters the subscription; it must be called when the client disconnects
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.hub.remove(s)
+		s.hub.wg.Done()
+	})
+}
+
+// Hub is the registry of real-time connections and fans events out to them
+type Hub struct {
+	mu      sync.Mutex
+	subs    map[*Subscription]struct{}
+	closing bool
+	wg      sync.WaitGroup
+}
+
+// NewHub creates an empty hub
+func NewHub() *Hub {
+	return &Hub{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscribe registers a new client
+func (h *Hub) Subscribe() (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closing {
+		return nil, ErrShuttingDown
+	}
+
+	sub := &Subscription{hub: h, events: make(chan Event, subscriptionBuffer)}
+	h.subs[sub] = struct{}{}
+	h.wg.Add(1)
+	return sub, nil
+}
+
+// Publish broadcasts an event to all clients without blocking. Clients whose buffer
+// is full are disconnected so one slow reader cannot hold up the others.
+func (h *Hub) Publish(event Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs {
+		select {
+		case sub.events <- event:
+		default:
+			delete(h.subs, sub)
+			close(sub.events)
+		}
+	}
+}
+
+// Connections returns the number of connected clients
+func (h *Hub) Connections() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
+
+// Shutdown sends EventShutdown to every client, ends their streams and waits until
+// all handlers have released their subscriptions or ctx is done. New subscriptions
+// are refused from then on. It must be called before http.Server.Shutdown, which
+// does not wait for (or interrupt) long-lived streaming responses.
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	h.closing = true
+	for sub := range h.subs {
+		select {
+		case sub.events <- Event{Type: EventShutdown, Data: map[string]string{"message": "server restarting"}}:
+		default:
+		}
+		delete(h.subs, sub)
+		close(sub.events)
+	}
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// remove drops sub from the registry if it is still registered
+func (h *Hub) remove(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subs[sub]; ok {
+		delete(h.subs, sub)
+		close(sub.events)
+	}
+}
@@ -0,0 +1,99 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// maxNotifyPayload is the largest payload Postgres accepts in a NOTIFY
+const maxNotifyPayload = 8000
+
+// notifyTimeout bounds sending a single NOTIFY
+const notifyTimeout = 5 * time.Second
+
+// feedEvent is an Event as sent through NOTIFY; the data is passed on undecoded
+type feedEvent struct {
+	Type       string          `json:"type"`
+	Data       json.RawMessage `json:"data,omitempty"`
+	Station    string          `json:"station,omitempty"`
+	Restaurant int             `json:"restaurant_id,omitempty"`
+}
+
+// PGFeed relays events between server replicas through Postgres LISTEN/NOTIFY.
+// Publish sends the event as a NOTIFY on the channel and every replica, including
+// this one, listens on it and broadcasts what it receives to its own hub, so all
+// clients see the same events whichever replica they are connected to.
+type PGFeed struct {
+	db      *bun.DB
+	hub     *Hub
+	channel string
+	ln      *pgdriver.Listener
+}
+
+// NewPGFeed creates a feed on the given NOTIFY channel delivering to hub. db must use
+// the pgdriver (Postgres) driver.
+func NewPGFeed(db *bun.DB, hub *Hub, channel string) *PGFeed {
+	return &PGFeed{db: db, hub: hub, channel: channel, ln: pgdriver.NewListener(db)}
+}
+
+// Publish sends event to every replica. Events that don't fit in a NOTIFY, or that
+// can't be sent, are broadcast to this replica's clients only.
+func (f *PGFeed) Publish(event Event) {
+	logger := logging.FromContext(context.Background()).With(slog.String("event", event.Type))
+
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		logger.Error("Failed to encode event", slog.String("error", err.Error()))
+		return
+	}
+	payload, err := json.Marshal(feedEvent{Type: event.Type, Data: data, Station: event.Station, Restaurant: event.Restaurant})
+	if err != nil {
+		logger.Error("Failed to encode event", slog.String("error", err.Error()))
+		return
+	}
+	if len(payload) >= maxNotifyPayload {
+		logger.Warn("Event too large for NOTIFY, broadcasting to local clients only", slog.Int("bytes", len(payload)))
+		f.hub.Publish(event)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	if err := pgdriver.Notify(ctx, f.db, f.channel, string(payload)); err != nil {
+		logger.Error("Failed to send event notification, broadcasting to local clients only", slog.String("error", err.Error()))
+		f.hub.Publish(event)
+	}
+}
+
+// Start listens on the channel and broadcasts the received events until Close. The
+// listener reconnects by itself when its connection is lost; events sent meanwhile
+// are missed.
+func (f *PGFeed) Start(ctx context.Context) {
+	logger := logging.FromContext(ctx).With(slog.String("channel", f.channel))
+	if err := f.ln.Listen(ctx, f.channel); err != nil {
+		logger.Warn("Failed to listen for event notifications, retrying in the background", slog.String("error", err.Error()))
+	}
+
+	go func() {
+		for n := range f.ln.Channel() {
+			var event feedEvent
+			if err := json.Unmarshal([]byte(n.Payload), &event); err != nil {
+				logger.Warn("Ignoring malformed event notification", slog.String("error", err.Error()))
+				continue
+			}
+			f.hub.Publish(Event{Type: event.Type, Data: event.Data, Station: event.Station, Restaurant: event.Restaurant})
+		}
+	}()
+}
+
+// Close stops listening
+func (f *PGFeed) Close() error {
+	return f.ln.Close()
+}
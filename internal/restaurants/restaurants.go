@@ -0,0 +1,209 @@
+// Package restaurants knows the restaurants served by the deployment and which one a
+// request is made for. Requests name it with the X-Restaurant-ID header; the data they
+// read and write is limited to it through database.WithRestaurant.
+package restaurants
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+)
+
+// Restaurant errors
+var (
+	ErrNotFound          = errors.New("restaurant not found")
+	ErrInvalidRestaurant = errors.New("invalid restaurant")
+	ErrExists            = errors.New("restaurant slug already exists")
+)
+
+// Header names the restaurant a request is made for
+const Header = "X-Restaurant-ID"
+
+// QueryParam names the restaurant of requests that can't set headers, such as an
+// EventSource or a delivery platform's webhook
+const QueryParam = "restaurant_id"
+
+// slugPattern is the format of restaurant slugs, e.g. downtown
+var slugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// Restaurant is a location whose menu, orders, staff and settings are kept apart
+// from the other restaurants'
+type Restaurant struct {
+	bun.BaseModel `bun:"table:restaurants,alias:rs" swaggerignore:"true"`
+
+	ID        int       `bun:"id,pk,autoincrement" json:"id" example:"1"`
+	Name      string    `bun:"name,notnull" json:"name" example:"Downtown"`
+	Slug      string    `bun:"slug,notnull,unique" json:"slug" example:"downtown"`
+	CreatedAt time.Time `bun:"created_at,notnull" json:"created_at"`
+	UpdatedAt time.Time `bun:"updated_at,notnull" json:"updated_at"`
+}
+
+// CreateRequest describes a new restaurant
+type CreateRequest struct {
+	Name string `json:"name" example:"Downtown"`
+	Slug string `json:"slug" example:"downtown"`
+}
+
+// validate checks the request and trims its fields
+func (r *CreateRequest) validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Slug = strings.TrimSpace(r.Slug)
+	if r.Name == "" || len(r.Name) > 100 {
+		return fmt.Errorf("%w: name must be 1 to 100 characters", ErrInvalidRestaurant)
+	}
+	if !slugPattern.MatchString(r.Slug) {
+		return fmt.Errorf("%w: slug must be lowercase letters, digits or '-', at most 50 characters", ErrInvalidRestaurant)
+	}
+	return nil
+}
+
+// Directory looks up restaurants. Restaurants aren't deleted, so the ones found are
+// cached for the life of the process; unknown IDs are read again on every lookup.
+type Directory struct {
+	db bun.IDB
+
+	mu    sync.RWMutex
+	known map[int]bool
+}
+
+// New creates a directory of the restaurants stored in db
+func New(db bun.IDB) *Directory {
+	return &Directory{db: db, known: map[int]bool{database.DefaultRestaurantID: true}}
+}
+
+// Exists reports whether a restaurant exists
+func (d *Directory) Exists(ctx context.Context, id int) (bool, error) {
+	d.mu.RLock()
+	known := d.known[id]
+	d.mu.RUnlock()
+	if known {
+		return true, nil
+	}
+
+	exists, err := d.db.NewSelect().Model((*Restaurant)(nil)).Where("id = ?", id).Exists(ctx)
+	if err != nil || !exists {
+		return false, err
+	}
+	d.remember(id)
+	return true, nil
+}
+
+// Resolve returns the restaurant named by raw, an ID from a header or query
+// parameter, or the default restaurant when raw is empty
+func (d *Directory) Resolve(ctx context.Context, raw string) (int, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return database.DefaultRestaurantID, nil
+	}
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: %s must be a positive integer", ErrInvalidRestaurant, Header)
+	}
+
+	exists, err := d.Exists(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up restaurant %d: %w", id, err)
+	}
+	if !exists {
+		return 0, fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	return id, nil
+}
+
+// Middleware scopes a request to the restaurant named by its X-Restaurant-ID header,
+// or its restaurant_id query parameter, and to the default restaurant when it names
+// none. It answers 400 for a malformed ID and 404 for an unknown restaurant.
+func (d *Directory) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := d.Resolve(r.Context(), FromRequest(r))
+		switch {
+		case errors.Is(err, ErrInvalidRestaurant):
+			middlewares.SendErrorResponse(w, r, http.StatusBadRequest, "Bad Request", err.Error())
+			return
+		case errors.Is(err, ErrNotFound):
+			middlewares.SendErrorResponse(w, r, http.StatusNotFound, "Not Found", "Restaurant not found")
+			return
+		case err != nil:
+			logging.FromContext(r.Context()).Error("Failed to resolve restaurant", slog.String("error", err.Error()))
+			middlewares.SendErrorResponse(w, r, http.StatusInternalServerError, "Internal Server Error", "Failed to resolve restaurant")
+			return
+		}
+
+		ctx := database.WithRestaurant(r.Context(), id)
+		ctx = logging.With(ctx, slog.Int("restaurant_id", id))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// MiddlewareFunc is Middleware for a handler function, for use with HandleFunc
+func (d *Directory) MiddlewareFunc(next http.HandlerFunc) http.HandlerFunc {
+	return d.Middleware(next).ServeHTTP
+}
+
+// FromRequest returns the restaurant ID a request names, from its X-Restaurant-ID
+// header or else its restaurant_id query parameter
+func FromRequest(r *http.Request) string {
+	if id := r.Header.Get(Header); id != "" {
+		return id
+	}
+	return r.URL.Query().Get(QueryParam)
+}
+
+// List returns the restaurants by ID
+func (d *Directory) List(ctx context.Context) ([]Restaurant, error) {
+	restaurants := []Restaurant{}
+	if err := d.db.NewSelect().Model(&restaurants).Order("id ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+	return restaurants, nil
+}
+
+// Create adds a restaurant. It starts without menu items, tables or staff, and with
+// the default settings.
+func (d *Directory) Create(ctx context.Context, req CreateRequest) (*Restaurant, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+
+	err := d.db.NewSelect().Model((*Restaurant)(nil)).Column("id").Where("slug = ?", req.Slug).Scan(ctx, new(int))
+	if err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrExists, req.Slug)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to look up restaurant %s: %w", req.Slug, err)
+	}
+
+	now := time.Now()
+	restaurant := &Restaurant{Name: req.Name, Slug: req.Slug, CreatedAt: now, UpdatedAt: now}
+	_, err = d.db.NewInsert().Model(restaurant).Exec(ctx)
+	if database.IsUniqueViolation(err) {
+		// Lost a race with a concurrent create of the same slug
+		return nil, fmt.Errorf("%w: %s", ErrExists, req.Slug)
+	}
+	if err != nil {
+		return nil, err
+	}
+	d.remember(restaurant.ID)
+	return restaurant, nil
+}
+
+// remember caches a restaurant that exists
+func (d *Directory) remember(id int) {
+	d.mu.Lock()
+	d.known[id] = true
+	d.mu.Unlock()
+}
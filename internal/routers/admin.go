@@ -0,0 +1,116 @@
+package router
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"net/netip"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/featureflags"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/metrics"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/restaurants"
+	"github.com/Zughayyar/agora-server/internal/scheduler"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupAdminRoutes configures operational endpoints guarded by the admin token and,
+// when allowedIPs is non-empty, restricted to those ranges. They respond 404 when
+// adminToken is empty.
+func SetupAdminRoutes(routes *Routes, db *bun.DB, sched *scheduler.Scheduler, purger *services.SoftDeletePurger, flags *featureflags.Flags, adminToken string, allowedIPs []netip.Prefix) {
+	protected := middlewares.AdminAuthMiddleware(adminToken)(adminMux(routes, db, sched, purger, flags))
+	protected = middlewares.IPFilterMiddleware(allowedIPs, nil)(protected)
+	routes.Handle("/debug/pprof/", protected)
+	routes.Handle("/admin/", protected)
+}
+
+// SetupAdminServerRoutes configures the dedicated admin listener (ADMIN_ADDR) on a
+// table from Routes.Listener, so its routes are listed with the public ones: health,
+// version and metrics are open, while profiling and /admin endpoints require the admin
+// token when one is configured and are limited to allowedIPs when it is non-empty.
+// The listener is meant to be reachable only from localhost or a private network.
+func SetupAdminServerRoutes(routes *Routes, db *bun.DB, sched *scheduler.Scheduler, purger *services.SoftDeletePurger, flags *featureflags.Flags, adminToken string, allowedIPs []netip.Prefix) {
+	operational := http.Handler(adminMux(routes, db, sched, purger, flags))
+	if adminToken != "" {
+		operational = middlewares.AdminAuthMiddleware(adminToken)(operational)
+	}
+	operational = middlewares.IPFilterMiddleware(allowedIPs, nil)(operational)
+	routes.Handle("/debug/pprof/", operational)
+	routes.Handle("/admin/", operational)
+
+	routes.HandleFunc("GET /metrics", metrics.Handler().ServeHTTP)
+	routes.HandleFunc("/health", handlers.HealthHandlerWithDB(db))
+	routes.HandleFunc("GET /version", handlers.VersionHandler)
+	routes.SetupFallback()
+}
+
+// adminMux builds the operational endpoints (profiling, log level, read-only mode, feature flags, restaurants, DB stats, routes, migrations, jobs, tasks, webhooks, purging, anonymization runs, report views)
+func adminMux(routes *Routes, db *bun.DB, sched *scheduler.Scheduler, purger *services.SoftDeletePurger, flags *featureflags.Flags) *http.ServeMux {
+	mux := http.NewServeMux()
+	admin := routes.Group(mux, "")
+
+	// Profiling endpoints (net/http/pprof)
+	admin.HandleFunc("GET /debug/pprof/", handlers.ProfilingHandler(pprof.Index))
+	admin.HandleFunc("GET /debug/pprof/cmdline", handlers.ProfilingHandler(pprof.Cmdline))
+	admin.HandleFunc("GET /debug/pprof/profile", handlers.ProfilingHandler(pprof.Profile))
+	admin.HandleFunc("GET /debug/pprof/symbol", handlers.ProfilingHandler(pprof.Symbol))
+	admin.HandleFunc("POST /debug/pprof/symbol", handlers.ProfilingHandler(pprof.Symbol))
+	admin.HandleFunc("GET /debug/pprof/trace", handlers.ProfilingHandler(pprof.Trace))
+
+	// Runtime log level control
+	admin.HandleFunc("GET /admin/log-level", handlers.GetLogLevel)
+	admin.HandleFunc("PUT /admin/log-level", handlers.SetLogLevel)
+
+	// Runtime read-only mode control
+	admin.HandleFunc("GET /admin/read-only", handlers.GetReadOnly)
+	admin.HandleFunc("PUT /admin/read-only", handlers.SetReadOnly)
+
+	// Feature flags rolling out new endpoints per environment and tenant
+	admin.HandleFunc("GET /admin/feature-flags", handlers.FeatureFlagsHandler(flags))
+	admin.HandleFunc("PUT /admin/feature-flags/{name}", handlers.SetFeatureFlagHandler(flags))
+	admin.HandleFunc("DELETE /admin/feature-flags/{name}", handlers.DeleteFeatureFlagHandler(flags))
+
+	// Restaurants whose data API requests are limited to
+	directory := restaurants.New(db)
+	admin.HandleFunc("GET /admin/restaurants", handlers.RestaurantsHandler(directory))
+	admin.HandleFunc("POST /admin/restaurants", handlers.CreateRestaurantHandler(directory))
+
+	// Connection pool statistics
+	admin.HandleFunc("GET /admin/db/stats", handlers.DatabaseStatsHandler(db))
+
+	// Central route table
+	admin.HandleFunc("GET /admin/routes", handlers.RoutesHandler(routes.List))
+
+	// Schema migration status
+	admin.HandleFunc("GET /admin/migrations", handlers.MigrationStatusHandler(db))
+
+	// Background jobs and dead-letter retries
+	admin.HandleFunc("GET /admin/jobs", handlers.JobsHandler(db))
+	admin.HandleFunc("POST /admin/jobs/{id}/retry", handlers.RetryJobHandler(db))
+
+	// Scheduled tasks and their run history
+	admin.HandleFunc("GET /admin/tasks", handlers.TasksHandler(sched))
+	admin.HandleFunc("GET /admin/tasks/runs", handlers.TaskRunsHandler(db))
+
+	// Outbound webhook subscriptions and their delivery attempts
+	admin.HandleFunc("GET /admin/webhooks", handlers.WebhooksHandler(db))
+	admin.HandleFunc("POST /admin/webhooks", handlers.CreateWebhookHandler(db))
+	admin.HandleFunc("DELETE /admin/webhooks/{id}", handlers.DeleteWebhookHandler(db))
+	admin.HandleFunc("GET /admin/webhooks/{id}/deliveries", handlers.WebhookDeliveriesHandler(db))
+
+	// Manual purge of old soft-deleted rows
+	admin.HandleFunc("POST /admin/purge-deleted", handlers.PurgeDeletedHandler(purger))
+
+	// Audit of the anonymization of old orders' contact details
+	admin.HandleFunc("GET /admin/anonymization-runs", handlers.AnonymizationRunsHandler(services.NewOrderAnonymizer(models.NewAnonymizationQuery(db))))
+
+	// On-demand refresh of the materialized report views
+	admin.HandleFunc("POST /admin/reports/refresh", handlers.RefreshReportViewsHandler(services.NewReportViewRefresher(models.NewReportViewQuery(db))))
+
+	admin.SetupFallback()
+
+	return mux
+}
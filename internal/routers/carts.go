@@ -0,0 +1,27 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupCartRoutes configures the cart routes
+func SetupCartRoutes(routes *Routes, db *bun.DB, events services.EventPublisher) {
+	cartHandlers := handlers.NewCartHandlers(services.NewCartService(models.NewCartQuery(db),
+		models.NewMenuItemQuery(db), models.NewLocationQuery(db), newOrderService(db, events)))
+
+	routes.HandleFunc("POST /carts", cartHandlers.CreateCart)
+	routes.HandleFunc("GET /carts/{id}", cartHandlers.GetCart)
+	routes.HandleFunc("PUT /carts/{id}", cartHandlers.UpdateCart)
+	routes.HandleFunc("POST /carts/{id}/items", cartHandlers.AddCartItem)
+	routes.HandleFunc("PUT /carts/{id}/items/{itemId}", cartHandlers.UpdateCartItem)
+	routes.HandleFunc("DELETE /carts/{id}/items/{itemId}", cartHandlers.RemoveCartItem)
+	routes.HandleFunc("PUT /carts/{id}/coupon", cartHandlers.ApplyCartCoupon)
+	routes.HandleFunc("DELETE /carts/{id}/coupon", cartHandlers.RemoveCartCoupon)
+	routes.HandleFunc("PUT /carts/{id}/tip", cartHandlers.SetCartTip)
+	routes.HandleFunc("GET /carts/{id}/preview", cartHandlers.PreviewCart)
+	routes.HandleFunc("POST /carts/{id}/checkout", cartHandlers.CheckOutCart)
+}
@@ -0,0 +1,21 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupCouponRoutes configures the coupon routes
+func SetupCouponRoutes(routes *Routes, db *bun.DB, events services.EventPublisher) {
+	couponHandlers := handlers.NewCouponHandlers(services.NewCouponService(models.NewCouponQuery(db)), newOrderService(db, events))
+
+	routes.HandleFunc("GET /coupons", couponHandlers.GetCoupons)
+	routes.HandleFunc("POST /coupons", couponHandlers.CreateCoupon)
+	routes.HandleFunc("POST /coupons/validate", couponHandlers.ValidateCoupon)
+	routes.HandleFunc("PUT /coupons/{id}", couponHandlers.UpdateCoupon)
+	routes.HandleFunc("DELETE /coupons/{id}", couponHandlers.DeleteCoupon)
+	routes.HandleFunc("GET /coupons/{id}/redemptions", couponHandlers.GetCouponRedemptions)
+}
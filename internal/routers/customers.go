@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupCustomerRoutes configures the routes of customers' data protection requests
+func SetupCustomerRoutes(routes *Routes, db *bun.DB) {
+	customerHandlers := handlers.NewCustomerHandlers(services.NewCustomerService(models.NewCustomerQuery(db)))
+
+	routes.HandleFunc("GET /customers/{id}/export", customerHandlers.ExportCustomer)
+	routes.HandleFunc("DELETE /customers/{id}/erase", customerHandlers.EraseCustomer)
+}
@@ -0,0 +1,20 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupDeliveryZoneRoutes configures the delivery zone routes
+func SetupDeliveryZoneRoutes(routes *Routes, db *bun.DB) {
+	zoneHandlers := handlers.NewDeliveryZoneHandlers(services.NewDeliveryZoneService(models.NewDeliveryZoneQuery(db)))
+
+	routes.HandleFunc("GET /delivery-zones", zoneHandlers.GetDeliveryZones)
+	routes.HandleFunc("POST /delivery-zones", zoneHandlers.CreateDeliveryZone)
+	routes.HandleFunc("PUT /delivery-zones/{id}", zoneHandlers.UpdateDeliveryZone)
+	routes.HandleFunc("DELETE /delivery-zones/{id}", zoneHandlers.DeleteDeliveryZone)
+	routes.HandleFunc("POST /delivery-zones/check", zoneHandlers.CheckDelivery)
+}
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupDriverRoutes configures the driver and order delivery routes
+func SetupDriverRoutes(routes *Routes, db *bun.DB, events services.EventPublisher) {
+	driverHandlers := handlers.NewDriverHandlers(services.NewDriverService(models.NewDriverQuery(db), models.NewOrderQuery(db), events))
+
+	routes.HandleFunc("GET /drivers", driverHandlers.GetDrivers)
+	routes.HandleFunc("POST /drivers", driverHandlers.CreateDriver)
+	routes.HandleFunc("PUT /drivers/{id}", driverHandlers.UpdateDriver)
+	routes.HandleFunc("DELETE /drivers/{id}", driverHandlers.DeleteDriver)
+	routes.HandleFunc("GET /drivers/{id}/orders", driverHandlers.GetDriverOrders)
+	routes.HandleFunc("PUT /orders/{id}/driver", driverHandlers.AssignDriver)
+	routes.HandleFunc("PUT /orders/{id}/delivery-status", driverHandlers.UpdateDeliveryStatus)
+}
@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupFloorPlanRoutes configures the floor plan routes
+func SetupFloorPlanRoutes(routes *Routes, db *bun.DB) {
+	planHandlers := handlers.NewFloorPlanHandlers(services.NewFloorPlanService(models.NewFloorPlanQuery(db), models.NewDiningTableQuery(db)))
+
+	routes.HandleFunc("GET /floor-plan", planHandlers.GetFloorPlan)
+	routes.HandleFunc("PUT /floor-plan", planHandlers.UpdateFloorPlan)
+}
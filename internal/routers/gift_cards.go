@@ -0,0 +1,18 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupGiftCardRoutes configures the gift card routes
+func SetupGiftCardRoutes(routes *Routes, db *bun.DB) {
+	giftCardHandlers := handlers.NewGiftCardHandlers(services.NewGiftCardService(models.NewGiftCardQuery(db), models.NewOrderQuery(db)))
+
+	routes.HandleFunc("POST /gift-cards", giftCardHandlers.IssueGiftCard)
+	routes.HandleFunc("GET /gift-cards/{code}", giftCardHandlers.GetGiftCard)
+	routes.HandleFunc("POST /gift-cards/{code}/redeem", giftCardHandlers.RedeemGiftCard)
+}
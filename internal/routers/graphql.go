@@ -0,0 +1,30 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/config"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/restaurants"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupGraphQLRoutes configures the GraphQL endpoint and its GraphiQL UI. The
+// endpoint shares the service layer with the REST API, gets its request timeout and
+// body size limit from cfg and is limited to the restaurant of the request.
+func SetupGraphQLRoutes(routes *Routes, db *bun.DB, events services.EventPublisher, cfg *config.Config, directory *restaurants.Directory) {
+	graphQLHandler := handlers.GraphQLHandler(
+		services.NewMenuItemService(models.NewMenuItemQuery(db), models.NewPricingRuleQuery(db), models.NewLocationQuery(db), events),
+		newOrderService(db, events))
+
+	routes.HandleFunc("GET /graphql", graphQLHandler,
+		directory.MiddlewareFunc,
+		routeMiddleware(middlewares.TimeoutMiddleware(cfg.RequestTimeout)))
+	routes.HandleFunc("POST /graphql", graphQLHandler,
+		directory.MiddlewareFunc,
+		routeMiddleware(middlewares.TimeoutMiddleware(cfg.RequestTimeout)),
+		routeMiddleware(middlewares.BodyLimitMiddleware(cfg.MaxBodyBytes)))
+	routes.HandleFunc("GET /graphql/playground", handlers.GraphQLPlaygroundHandler("/graphql"))
+}
@@ -0,0 +1,41 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/config"
+	"github.com/Zughayyar/agora-server/internal/restaurants"
+)
+
+func TestGraphQLBodyLimit(t *testing.T) {
+	db := testDB(t)
+	cfg := &config.Config{RequestTimeout: time.Minute, MaxBodyBytes: 128}
+	mux := http.NewServeMux()
+	SetupGraphQLRoutes(NewRoutes(mux), db, nil, cfg, restaurants.New(db))
+
+	tests := []struct {
+		name    string
+		padding int
+		want    string
+	}{
+		{"within limit", 0, `"__typename":"Query"`},
+		{"over limit", 256, "request body too large"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"query":"{ __typename }","variables":{"padding":"` + strings.Repeat("x", tt.padding) + `"}}`
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if !strings.Contains(rec.Body.String(), tt.want) {
+				t.Fatalf("response %d %s, want it to contain %s", rec.Code, rec.Body, tt.want)
+			}
+		})
+	}
+}
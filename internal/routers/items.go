@@ -1,25 +1,61 @@
 package router
 
 import (
-	"net/http"
-
 	"github.com/uptrace/bun"
 
+	"github.com/Zughayyar/agora-server/internal/config"
+	"github.com/Zughayyar/agora-server/internal/database/models"
 	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/services"
 )
 
-// SetupItemRoutes configures all item-related routes
-func SetupItemRoutes(mux *http.ServeMux, db *bun.DB) {
-	// Initialize handlers
-	menuItemHandlers := handlers.NewMenuItemHandlers(db)
+// SetupItemRoutes configures all item-related routes. Bulk imports and exports get
+// the larger request timeout from cfg, and imports the larger body size limit.
+func SetupItemRoutes(routes *Routes, db *bun.DB, events services.EventPublisher, cfg *config.Config) {
+	// Wire repository -> service -> handlers
+	menuItemQuery := models.NewMenuItemQuery(db)
+	menuItemService := services.NewMenuItemService(menuItemQuery, models.NewPricingRuleQuery(db), models.NewLocationQuery(db), events)
+	menuItemHandlers := handlers.NewMenuItemHandlers(menuItemService)
+	importHandlers := handlers.NewImportHandlers(services.NewMenuItemImporter(menuItemQuery))
+	exportHandlers := handlers.NewExportHandlers(services.NewMenuItemExporter(menuItemQuery))
 
 	// Menu Items CRUD routes
-	mux.HandleFunc("GET /items", menuItemHandlers.GetAllMenuItems)
-	mux.HandleFunc("POST /items", menuItemHandlers.CreateMenuItem)
-	mux.HandleFunc("GET /items/deleted", menuItemHandlers.GetDeletedMenuItems)
-	mux.HandleFunc("GET /items/category/{category}", menuItemHandlers.GetMenuItemsByCategory)
-	mux.HandleFunc("GET /items/{id}", menuItemHandlers.GetMenuItemByID)
-	mux.HandleFunc("PUT /items/{id}", menuItemHandlers.UpdateMenuItem)
-	mux.HandleFunc("DELETE /items/{id}", menuItemHandlers.DeleteMenuItem)
-	mux.HandleFunc("POST /items/{id}/restore", menuItemHandlers.RestoreMenuItem)
+	routes.HandleFunc("GET /items", menuItemHandlers.GetAllMenuItems)
+	routes.HandleFunc("POST /items", menuItemHandlers.CreateMenuItem)
+	routes.HandleFunc("GET /items/export.ndjson", exportHandlers.ExportMenuItemsNDJSON,
+		middlewares.WithTimeout(cfg.BulkRequestTimeout))
+	routes.HandleFunc("GET /items/deleted", menuItemHandlers.GetDeletedMenuItems)
+	routes.HandleFunc("GET /items/category/{category}", menuItemHandlers.GetMenuItemsByCategory)
+	routes.HandleFunc("GET /items/{id}", menuItemHandlers.GetMenuItemByID)
+	routes.HandleFunc("PUT /items/{id}", menuItemHandlers.UpdateMenuItem)
+	routes.HandleFunc("DELETE /items/{id}", menuItemHandlers.DeleteMenuItem)
+	routes.HandleFunc("POST /items/{id}/restore", menuItemHandlers.RestoreMenuItem)
+
+	// Channel price tiers
+	priceHandlers := handlers.NewMenuItemPriceHandlers(services.NewMenuItemPriceService(menuItemQuery, menuItemQuery, events))
+	routes.HandleFunc("PUT /items/{id}/prices/{channel}", priceHandlers.SetMenuItemPrice)
+	routes.HandleFunc("DELETE /items/{id}/prices/{channel}", priceHandlers.DeleteMenuItemPrice)
+
+	// Items frequently ordered together. They're read under /recommendations, as
+	// GET /items/{id}/recommendations would clash with GET /items/category/{category}.
+	recommendationHandlers := handlers.NewRecommendationHandlers(services.NewRecommendationService(
+		models.NewSalesQuery(db), menuItemQuery, models.NewPricingRuleQuery(db)))
+	routes.HandleFunc("GET /recommendations/items/{id}", recommendationHandlers.GetRecommendations)
+
+	// Bulk import
+	routes.HandleFunc("POST /items/import", importHandlers.ImportMenuItems,
+		middlewares.WithTimeout(cfg.BulkRequestTimeout),
+		middlewares.WithBodyLimit(cfg.MaxImportBodyBytes))
+}
+
+// SetupExportRoutes configures the asynchronous export job routes. Downloads get the
+// longer bulk request timeout from cfg.
+func SetupExportRoutes(routes *Routes, exports *services.ExportJobs, cfg *config.Config) {
+	exportJobHandlers := handlers.NewExportJobHandlers(exports)
+
+	routes.HandleFunc("POST /exports", exportJobHandlers.CreateExport)
+	routes.HandleFunc("GET /exports/{id}", exportJobHandlers.GetExport)
+	routes.HandleFunc("GET /exports/{id}/download", exportJobHandlers.DownloadExport,
+		middlewares.WithTimeout(cfg.BulkRequestTimeout))
 }
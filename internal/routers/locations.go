@@ -0,0 +1,45 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/config"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/restaurants"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupLocationRoutes configures the location routes. Menu item reads apply a
+// location's overrides with ?location=, see SetupItemRoutes, and online orders
+// placed at a location are checked against its opening hours.
+func SetupLocationRoutes(routes *Routes, db *bun.DB) {
+	locationHandlers := newLocationHandlers(db)
+
+	routes.HandleFunc("GET /locations", locationHandlers.GetLocations)
+	routes.HandleFunc("POST /locations", locationHandlers.CreateLocation)
+	routes.HandleFunc("PUT /locations/{id}", locationHandlers.UpdateLocation)
+	routes.HandleFunc("DELETE /locations/{id}", locationHandlers.DeleteLocation)
+	routes.HandleFunc("GET /locations/{id}/items", locationHandlers.GetLocationOverrides)
+	routes.HandleFunc("PUT /locations/{id}/items/{item_id}", locationHandlers.SetLocationOverride)
+	routes.HandleFunc("DELETE /locations/{id}/items/{item_id}", locationHandlers.DeleteLocationOverride)
+	routes.HandleFunc("GET /locations/{id}/hours", locationHandlers.GetLocationHours)
+	routes.HandleFunc("PUT /locations/{id}/hours", locationHandlers.SetLocationHours)
+	routes.HandleFunc("PUT /locations/{id}/hours/exceptions/{date}", locationHandlers.SetLocationHoursException)
+	routes.HandleFunc("DELETE /locations/{id}/hours/exceptions/{date}", locationHandlers.DeleteLocationHoursException)
+}
+
+// SetupPublicLocationRoutes configures the public location routes, for ordering apps
+// finding the closest branch of the restaurant of their X-Restaurant-ID header
+func SetupPublicLocationRoutes(routes *Routes, db *bun.DB, cfg *config.Config, directory *restaurants.Directory) {
+	routes.HandleFunc("GET /public/locations/nearby", newLocationHandlers(db).GetNearbyLocations,
+		directory.MiddlewareFunc,
+		routeMiddleware(middlewares.TimeoutMiddleware(cfg.RequestTimeout)))
+}
+
+// newLocationHandlers wires the location handlers to the database
+func newLocationHandlers(db *bun.DB) *handlers.LocationHandlers {
+	return handlers.NewLocationHandlers(services.NewLocationService(
+		models.NewLocationQuery(db), models.NewMenuItemQuery(db)))
+}
@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupLoyaltyRoutes configures the customer loyalty routes
+func SetupLoyaltyRoutes(routes *Routes, db *bun.DB) {
+	loyaltyHandlers := handlers.NewLoyaltyHandlers(services.NewLoyaltyService(models.NewLoyaltyQuery(db)))
+
+	routes.HandleFunc("GET /customers/{id}/loyalty", loyaltyHandlers.GetCustomerLoyalty)
+}
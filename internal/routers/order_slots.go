@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupOrderSlotRoutes configures the online order time slot routes
+func SetupOrderSlotRoutes(routes *Routes, db *bun.DB) {
+	orderSlotHandlers := handlers.NewOrderSlotHandlers(services.NewOrderSlotService(models.NewOrderQuery(db)))
+
+	routes.HandleFunc("GET /order-slots", orderSlotHandlers.GetOrderSlots)
+}
@@ -0,0 +1,55 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/config"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/delivery"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/restaurants"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// newOrderService wires the order repository and service
+func newOrderService(db *bun.DB, events services.EventPublisher) services.OrderService {
+	return services.NewOrderService(models.NewOrderQuery(db), models.NewMenuItemQuery(db), models.NewTaxRateQuery(db),
+		models.NewPricingRuleQuery(db), models.NewCouponQuery(db), models.NewPromotionQuery(db),
+		models.NewLoyaltyQuery(db), models.NewStoreCreditQuery(db), models.NewLocationQuery(db), events)
+}
+
+// SetupOrderRoutes configures the order routes
+func SetupOrderRoutes(routes *Routes, db *bun.DB, events services.EventPublisher) {
+	orderHandlers := handlers.NewOrderHandlers(newOrderService(db, events))
+
+	routes.HandleFunc("GET /orders", orderHandlers.GetOrders)
+	routes.HandleFunc("GET /orders/{id}", orderHandlers.GetOrderByID)
+	routes.HandleFunc("GET /orders/{id}/receipt", orderHandlers.GetOrderReceipt)
+	routes.HandleFunc("GET /orders/{id}/tickets", orderHandlers.GetOrderTickets)
+	routes.HandleFunc("GET /orders/{id}/tickets/{station}", orderHandlers.PrintOrderTicket)
+	routes.HandleFunc("POST /orders/{id}/tickets/{station}/prepared", orderHandlers.PrepareOrderTicket)
+	routes.HandleFunc("POST /orders/{id}/payments", orderHandlers.PayOrder)
+
+	// Public status pages of orders, authorized by the order's signed tracking token
+	routes.HandleFunc("GET /track/{token}", orderHandlers.TrackOrder)
+}
+
+// SetupDeliveryWebhookRoutes configures the order webhooks of the delivery platforms
+// whose signing secret is set in cfg. They are served outside /api/v1, with the API's
+// body size limit and request timeout. Orders are placed at the restaurant named in
+// the signed payload, not by X-Restaurant-ID.
+func SetupDeliveryWebhookRoutes(routes *Routes, db *bun.DB, events services.EventPublisher, cfg *config.Config, directory *restaurants.Directory) {
+	var providers []delivery.Provider
+	if cfg.UberEatsWebhookSecret != "" {
+		providers = append(providers, delivery.NewUberEats(cfg.UberEatsWebhookSecret))
+	}
+	if cfg.DoorDashWebhookSecret != "" {
+		providers = append(providers, delivery.NewDoorDash(cfg.DoorDashWebhookSecret))
+	}
+	deliveryHandlers := handlers.NewDeliveryWebhookHandlers(newOrderService(db, events), directory, providers...)
+
+	routes.HandleFunc("POST /webhooks/{provider}", deliveryHandlers.ReceiveOrder,
+		routeMiddleware(middlewares.TimeoutMiddleware(cfg.RequestTimeout)),
+		routeMiddleware(middlewares.BodyLimitMiddleware(cfg.MaxBodyBytes)))
+}
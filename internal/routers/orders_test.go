@@ -0,0 +1,45 @@
+package router
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+
+	"github.com/Zughayyar/agora-server/internal/config"
+	"github.com/Zughayyar/agora-server/internal/restaurants"
+)
+
+// testDB returns a database handle that never connects, for routes whose requests
+// are rejected before reaching the database
+func testDB(t *testing.T) *bun.DB {
+	t.Helper()
+	db := bun.NewDB(sql.OpenDB(pgdriver.NewConnector(pgdriver.WithDSN("postgres://localhost:1/test"))), pgdialect.New())
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestDeliveryWebhookBodyLimit(t *testing.T) {
+	db := testDB(t)
+	cfg := &config.Config{
+		RequestTimeout:        time.Minute,
+		MaxBodyBytes:          64,
+		UberEatsWebhookSecret: "secret",
+	}
+	mux := http.NewServeMux()
+	SetupDeliveryWebhookRoutes(NewRoutes(mux), db, nil, cfg, restaurants.New(db))
+
+	body := `{"event_type":"orders.notification","padding":"` + strings.Repeat("x", 128) + `"}`
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks/ubereats", strings.NewReader(body)))
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusRequestEntityTooLarge, rec.Body)
+	}
+}
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupPriceExperimentRoutes configures the price experiment routes. The guest menu
+// applies the running experiments, see SetupTableRoutes.
+func SetupPriceExperimentRoutes(routes *Routes, db *bun.DB) {
+	experimentHandlers := handlers.NewPriceExperimentHandlers(services.NewPriceExperimentService(
+		models.NewPriceExperimentQuery(db), models.NewMenuItemQuery(db)))
+
+	routes.HandleFunc("GET /price-experiments", experimentHandlers.GetPriceExperiments)
+	routes.HandleFunc("POST /price-experiments", experimentHandlers.CreatePriceExperiment)
+	routes.HandleFunc("GET /price-experiments/{id}/results", experimentHandlers.GetPriceExperimentResults)
+	routes.HandleFunc("POST /price-experiments/{id}/end", experimentHandlers.EndPriceExperiment)
+	routes.HandleFunc("DELETE /price-experiments/{id}", experimentHandlers.DeletePriceExperiment)
+}
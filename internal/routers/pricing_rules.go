@@ -0,0 +1,19 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupPricingRuleRoutes configures the pricing rule routes
+func SetupPricingRuleRoutes(routes *Routes, db *bun.DB) {
+	pricingRuleHandlers := handlers.NewPricingRuleHandlers(services.NewPricingRuleService(models.NewPricingRuleQuery(db)))
+
+	routes.HandleFunc("GET /pricing-rules", pricingRuleHandlers.GetPricingRules)
+	routes.HandleFunc("POST /pricing-rules", pricingRuleHandlers.CreatePricingRule)
+	routes.HandleFunc("PUT /pricing-rules/{id}", pricingRuleHandlers.UpdatePricingRule)
+	routes.HandleFunc("DELETE /pricing-rules/{id}", pricingRuleHandlers.DeletePricingRule)
+}
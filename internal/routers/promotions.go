@@ -0,0 +1,19 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupPromotionRoutes configures the promotion routes
+func SetupPromotionRoutes(routes *Routes, db *bun.DB) {
+	promotionHandlers := handlers.NewPromotionHandlers(services.NewPromotionService(models.NewPromotionQuery(db)))
+
+	routes.HandleFunc("GET /promotions", promotionHandlers.GetPromotions)
+	routes.HandleFunc("POST /promotions", promotionHandlers.CreatePromotion)
+	routes.HandleFunc("PUT /promotions/{id}", promotionHandlers.UpdatePromotion)
+	routes.HandleFunc("DELETE /promotions/{id}", promotionHandlers.DeletePromotion)
+}
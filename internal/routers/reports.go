@@ -0,0 +1,26 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/config"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupReportRoutes configures the reporting and dashboard statistics routes
+func SetupReportRoutes(routes *Routes, db *bun.DB, cfg *config.Config) {
+	sales := models.NewSalesQuery(db)
+	reportHandlers := handlers.NewReportHandlers(services.NewReportService(sales, services.AccountingAccounts{
+		Sales:   cfg.AccountingSalesAccount,
+		Deposit: cfg.AccountingDepositAccount,
+		Tax:     cfg.AccountingTaxAccount,
+	}))
+	statsHandlers := handlers.NewStatsHandlers(services.NewStatsService(models.NewMenuItemQuery(db), sales))
+
+	routes.HandleFunc("GET /reports/sales", reportHandlers.GetSalesReport)
+	routes.HandleFunc("GET /reports/top-items", reportHandlers.GetTopItems)
+	routes.HandleFunc("GET /reports/accounting-export", reportHandlers.ExportAccounting)
+	routes.HandleFunc("GET /stats/dashboard", statsHandlers.GetDashboard)
+}
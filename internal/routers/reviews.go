@@ -0,0 +1,24 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupReviewRoutes configures the menu item review and moderation routes
+func SetupReviewRoutes(routes *Routes, db *bun.DB) {
+	reviewHandlers := handlers.NewReviewHandlers(services.NewReviewService(
+		models.NewReviewQuery(db), models.NewMenuItemQuery(db), models.NewOrderQuery(db)))
+
+	routes.HandleFunc("POST /items/{id}/reviews", reviewHandlers.CreateReview)
+	// An item's reviews are read under /reviews, as GET /items/{id}/reviews would
+	// clash with GET /items/category/{category}
+	routes.HandleFunc("GET /reviews/items/{id}", reviewHandlers.GetItemReviews)
+
+	routes.HandleFunc("GET /reviews", reviewHandlers.GetReviews)
+	routes.HandleFunc("PATCH /reviews/{id}", reviewHandlers.ModerateReview)
+	routes.HandleFunc("DELETE /reviews/{id}", reviewHandlers.DeleteReview)
+}
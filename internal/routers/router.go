@@ -6,25 +6,133 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 	"github.com/uptrace/bun"
 
+	"github.com/Zughayyar/agora-server/docs"
+	"github.com/Zughayyar/agora-server/internal/config"
+	"github.com/Zughayyar/agora-server/internal/featureflags"
 	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/metrics"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/realtime"
+	"github.com/Zughayyar/agora-server/internal/restaurants"
+	"github.com/Zughayyar/agora-server/internal/scheduler"
+	"github.com/Zughayyar/agora-server/internal/services"
 )
 
-func SetupRoutes(mux *http.ServeMux, db *bun.DB) {
+// SetupRoutes configures all application routes. API requests are limited to
+// cfg.RequestTimeout and answered with 504 when they run over, request bodies are
+// capped at cfg.MaxBodyBytes, and mutating API requests are rejected while
+// read-only mode is on. Unmatched requests get a JSON 404, or 405 with an Allow
+// header when the path exists for other methods. Endpoints being rolled out are
+// wrapped in flags.Require. API, GraphQL, event stream and nearby location requests
+// are limited to the restaurant of their X-Restaurant-ID header, and delivery webhooks
+// to the one of their signed payload. It returns the route table.
+func SetupRoutes(mux *http.ServeMux, db *bun.DB, cfg *config.Config, hub *realtime.Hub, events services.EventPublisher, exports *services.ExportJobs, sched *scheduler.Scheduler, purger *services.SoftDeletePurger, flags *featureflags.Flags) *Routes {
+	routes := NewRoutes(mux)
+	handlers.SetResponseCase(cfg.ResponseCase)
+	directory := restaurants.New(db)
+
 	// API v1 routes
 	apiV1 := http.NewServeMux()
+	v1 := routes.Group(apiV1, "/api/v1")
 
 	// Health check routes
-	apiV1.HandleFunc("/health", handlers.HealthHandlerWithDB(db))
+	v1.HandleFunc("/health", handlers.HealthHandlerWithDB(db))
+
+	// Build information
+	v1.HandleFunc("GET /version", handlers.VersionHandler)
+
+	// Business settings of each restaurant
+	SetupSettingsRoutes(v1, db)
 
 	// Setup item routes
-	SetupItemRoutes(apiV1, db)
+	SetupItemRoutes(v1, db, events, cfg)
+
+	// Locations of the restaurant and their overrides of the menu
+	SetupLocationRoutes(v1, db)
+
+	// Customer reviews of menu items and their moderation
+	SetupReviewRoutes(v1, db)
+
+	// Orders
+	SetupOrderRoutes(v1, db, events)
+	SetupOrderSlotRoutes(v1, db)
+	SetupCartRoutes(v1, db, events)
+	SetupDeliveryZoneRoutes(v1, db)
+	SetupDriverRoutes(v1, db, events)
+
+	// Dining tables, their floor plan and ordering from their QR codes
+	SetupTableRoutes(v1, db, events)
+	SetupFloorPlanRoutes(v1, db)
+
+	// Tax rates, pricing rules, promotions and coupons applied to new orders
+	SetupTaxRateRoutes(v1, db)
+	SetupPricingRuleRoutes(v1, db)
+	SetupPromotionRoutes(v1, db)
+	SetupCouponRoutes(v1, db, events)
+
+	// A/B tests of menu item prices on the guest menu
+	SetupPriceExperimentRoutes(v1, db)
+
+	// Gift cards and store credit paying orders, and loyalty points earned on them
+	SetupGiftCardRoutes(v1, db)
+	SetupStoreCreditRoutes(v1, db)
+	SetupLoyaltyRoutes(v1, db)
+
+	// Export and erasure of customers' personal data
+	SetupCustomerRoutes(v1, db)
+
+	// Staff and their shifts
+	SetupStaffRoutes(v1, db)
+
+	// Reports and dashboard statistics
+	SetupReportRoutes(v1, db, cfg)
+
+	// Asynchronous export jobs
+	SetupExportRoutes(v1, exports, cfg)
+	v1.SetupFallback()
 
 	// Mount API v1 routes
-	mux.Handle("/api/v1/", http.StripPrefix("/api/v1", apiV1))
+	var api http.Handler = http.StripPrefix("/api/v1", apiV1)
+	api = middlewares.BodyLimitMiddleware(cfg.MaxBodyBytes)(api)
+	api = middlewares.TimeoutMiddleware(cfg.RequestTimeout)(api)
+	api = middlewares.ReadOnlyMiddleware(api)
+	api = directory.Middleware(api)
+	routes.Handle("/api/v1/", api)
+
+	// API v2, with one response envelope for data, pagination and errors
+	SetupV2Routes(routes, db, events, cfg, directory)
+
+	// Real-time event stream, registered outside the request timeout
+	routes.HandleFunc("GET /api/v1/events", handlers.EventsHandler(hub), directory.MiddlewareFunc)
+
+	// Nearby locations for ordering apps
+	SetupPublicLocationRoutes(routes, db, cfg, directory)
+
+	// GraphQL API over menu items, categories and orders
+	SetupGraphQLRoutes(routes, db, events, cfg, directory)
 
-	// Swagger UI - serves at /swagger/
-	mux.Handle("/swagger/", httpSwagger.WrapHandler)
+	// Order webhooks of delivery platforms
+	SetupDeliveryWebhookRoutes(routes, db, events, cfg, directory)
+
+	// OpenAPI spec, kept in sync with the registered routes, and the Swagger UI at /swagger/
+	routes.HandleFunc("GET /openapi.json", handlers.OpenAPIHandler(docs.SwaggerInfo.ReadDoc, routes.List))
+	routes.Handle("/swagger/", httpSwagger.Handler(httpSwagger.URL("/openapi.json")))
+
+	// Operational endpoints move to the admin listener when ADMIN_ADDR is set
+	if cfg.AdminAddr == "" {
+		// Admin-only operational endpoints (profiling, log level, feature flags, restaurants, DB stats, migrations)
+		SetupAdminRoutes(routes, db, sched, purger, flags, cfg.AdminToken, cfg.AdminAllowedIPs)
+
+		// Prometheus metrics
+		routes.HandleFunc("GET /metrics", metrics.Handler().ServeHTTP)
+	}
 
 	// Root level health check (simple, no database dependency)
-	mux.HandleFunc("/health", handlers.HealthHandler)
+	routes.HandleFunc("/health", handlers.HealthHandler)
+	routes.HandleFunc("GET /version", handlers.VersionHandler)
+
+	// Catch-all for unmatched routes (JSON 404/405)
+	routes.SetupFallback()
+
+	return routes
 }
@@ -0,0 +1,191 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+)
+
+// fallbackPattern is the catch-all pattern that answers unmatched requests
+const fallbackPattern = "/{path...}"
+
+// Listeners a route can be served on
+const (
+	ListenerPublic = "public"
+	ListenerAdmin  = "admin"
+)
+
+// Routes registers handlers on a ServeMux and records each registration in a central
+// route table, so unmatched methods can be answered with 405 and the registered
+// routes can be listed at GET /admin/routes
+type Routes struct {
+	mux      *http.ServeMux
+	prefix   string
+	listener string
+	table    *[]handlers.RouteInfo
+}
+
+// NewRoutes creates a route table registering on mux, the public listener's mux
+func NewRoutes(mux *http.ServeMux) *Routes {
+	return &Routes{mux: mux, listener: ListenerPublic, table: new([]handlers.RouteInfo)}
+}
+
+// Group returns a table registering on mux, mounted under prefix, that records into
+// the same route list
+func (rt *Routes) Group(mux *http.ServeMux, prefix string) *Routes {
+	return &Routes{mux: mux, prefix: rt.prefix + prefix, listener: rt.listener, table: rt.table}
+}
+
+// Listener returns a table registering on the mux of another listener that records
+// into the same route list
+func (rt *Routes) Listener(mux *http.ServeMux, listener string) *Routes {
+	return &Routes{mux: mux, listener: listener, table: rt.table}
+}
+
+// HandleFunc registers a route handler, tagging its logs and metrics with the route
+// pattern. Route-specific middlewares in wrap are applied in order, outermost first.
+func (rt *Routes) HandleFunc(pattern string, handler http.HandlerFunc, wrap ...func(http.HandlerFunc) http.HandlerFunc) {
+	rt.record(pattern, funcName(handler))
+
+	wrapped := handler
+	for i := len(wrap) - 1; i >= 0; i-- {
+		wrapped = wrap[i](wrapped)
+	}
+	rt.mux.HandleFunc(pattern, middlewares.RouteLogger(wrapped))
+}
+
+// routeMiddleware adapts a handler middleware, such as TimeoutMiddleware, to wrap a
+// single route registered outside the /api/v1 chain
+func routeMiddleware(mw func(http.Handler) http.Handler) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return mw(next).ServeHTTP
+	}
+}
+
+// Handle registers a handler as is, e.g. a mounted subtree or third-party handler
+func (rt *Routes) Handle(pattern string, handler http.Handler) {
+	name := fmt.Sprintf("%T", handler)
+	if fn, ok := handler.(http.HandlerFunc); ok {
+		name = funcName(fn)
+	}
+	rt.record(pattern, name)
+	rt.mux.Handle(pattern, handler)
+}
+
+// List returns all recorded routes
+func (rt *Routes) List() []handlers.RouteInfo {
+	return slices.Clone(*rt.table)
+}
+
+// record adds pattern to the route list
+func (rt *Routes) record(pattern, handler string) {
+	method, path, found := strings.Cut(pattern, " ")
+	if !found {
+		method, path = "", pattern
+	}
+	*rt.table = append(*rt.table, handlers.RouteInfo{
+		Method:   method,
+		Pattern:  rt.prefix + path,
+		Handler:  handler,
+		Listener: rt.listener,
+	})
+}
+
+// funcName returns the short name of a handler function, e.g.
+// "handlers.(*MenuItemHandlers).GetAllMenuItems" or "handlers.HealthHandlerWithDB"
+func funcName(fn http.HandlerFunc) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+
+	// Drop the import path and the suffixes of method values and closures
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.TrimSuffix(name, "-fm")
+	for {
+		base, suffix, found := strings.Cut(name[strings.LastIndex(name, ".")+1:], "func")
+		if !found || base != "" || strings.Trim(suffix, "0123456789") != "" {
+			break
+		}
+		name = name[:strings.LastIndex(name, ".")]
+	}
+	return name
+}
+
+// SetupFallback registers the catch-all handler. A path with a trailing slash that
+// exists without it is served as if requested without it; otherwise the response is
+// 405 with an Allow header when the path exists for other methods, and 404 if not.
+func (rt *Routes) SetupFallback() {
+	rt.mux.HandleFunc(fallbackPattern, func(w http.ResponseWriter, r *http.Request) {
+		trimmed := rt.withoutTrailingSlash(r)
+		if trimmed != nil && rt.matches(trimmed) {
+			rt.mux.ServeHTTP(w, trimmed)
+			return
+		}
+
+		allowed := rt.allowedMethods(r)
+		if len(allowed) == 0 && trimmed != nil {
+			allowed = rt.allowedMethods(trimmed)
+		}
+
+		// Report the path the client requested, not the one stripped of the mount prefix
+		if rt.prefix != "" {
+			u := *r.URL
+			u.Path = rt.prefix + u.Path
+			unstripped := *r
+			unstripped.URL = &u
+			r = &unstripped
+		}
+
+		if len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			middlewares.MethodNotAllowedHandler()(w, r)
+			return
+		}
+		middlewares.NotFoundHandler()(w, r)
+	})
+}
+
+// withoutTrailingSlash returns a copy of r without the trailing slash of its path,
+// or nil if the path has none
+func (rt *Routes) withoutTrailingSlash(r *http.Request) *http.Request {
+	path := r.URL.Path
+	if len(path) <= 1 || !strings.HasSuffix(path, "/") {
+		return nil
+	}
+
+	u := *r.URL
+	u.Path = strings.TrimRight(path, "/")
+	u.RawPath = ""
+	trimmed := r.Clone(r.Context())
+	trimmed.URL = &u
+	return trimmed
+}
+
+// matches reports whether a route other than the fallback matches r
+func (rt *Routes) matches(r *http.Request) bool {
+	_, pattern := rt.mux.Handler(r)
+	return pattern != "" && pattern != fallbackPattern
+}
+
+// allowedMethods lists the methods registered on this mux for the request's path
+func (rt *Routes) allowedMethods(r *http.Request) []string {
+	var allowed []string
+	for _, method := range []string{
+		http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+		http.MethodPatch, http.MethodDelete, http.MethodOptions,
+	} {
+		if method == r.Method {
+			continue
+		}
+		probe := *r
+		probe.Method = method
+		if rt.matches(&probe) {
+			allowed = append(allowed, method)
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupSettingsRoutes configures the restaurant settings routes
+func SetupSettingsRoutes(routes *Routes, db *bun.DB) {
+	settingsHandlers := handlers.NewSettingsHandlers(services.NewSettingsService(models.NewSettingQuery(db)))
+
+	routes.HandleFunc("GET /settings", settingsHandlers.GetSettings)
+	routes.HandleFunc("PATCH /settings", settingsHandlers.UpdateSettings)
+}
@@ -0,0 +1,30 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupStaffRoutes configures the staff, shift scheduling and time clock routes
+func SetupStaffRoutes(routes *Routes, db *bun.DB) {
+	staffHandlers := handlers.NewStaffHandlers(services.NewStaffService(models.NewStaffQuery(db)))
+
+	routes.HandleFunc("GET /staff", staffHandlers.GetStaff)
+	routes.HandleFunc("POST /staff", staffHandlers.CreateStaff)
+	routes.HandleFunc("PUT /staff/{id}", staffHandlers.UpdateStaff)
+	routes.HandleFunc("DELETE /staff/{id}", staffHandlers.DeleteStaff)
+
+	routes.HandleFunc("GET /shifts", staffHandlers.GetShifts)
+	routes.HandleFunc("POST /shifts", staffHandlers.CreateShift)
+	routes.HandleFunc("PUT /shifts/{id}", staffHandlers.UpdateShift)
+	routes.HandleFunc("DELETE /shifts/{id}", staffHandlers.DeleteShift)
+	routes.HandleFunc("GET /schedule/week", staffHandlers.GetWeeklySchedule)
+
+	routes.HandleFunc("POST /staff/{id}/clock-in", staffHandlers.ClockIn)
+	routes.HandleFunc("POST /staff/{id}/clock-out", staffHandlers.ClockOut)
+	routes.HandleFunc("GET /timesheets", staffHandlers.GetTimesheet)
+	routes.HandleFunc("GET /reports/timesheets", staffHandlers.ExportTimesheets)
+}
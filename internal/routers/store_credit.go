@@ -0,0 +1,19 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupStoreCreditRoutes configures the customer store credit routes
+func SetupStoreCreditRoutes(routes *Routes, db *bun.DB) {
+	storeCreditHandlers := handlers.NewStoreCreditHandlers(
+		services.NewStoreCreditService(models.NewStoreCreditQuery(db), models.NewOrderQuery(db)))
+
+	routes.HandleFunc("GET /customers/{id}/store-credit", storeCreditHandlers.GetStoreCredit)
+	routes.HandleFunc("POST /customers/{id}/store-credit/credits", storeCreditHandlers.CreditStoreCredit)
+	routes.HandleFunc("POST /customers/{id}/store-credit/debits", storeCreditHandlers.DebitStoreCredit)
+}
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupTableRoutes configures the dining table routes and the routes guests use from
+// a table's QR code
+func SetupTableRoutes(routes *Routes, db *bun.DB, events services.EventPublisher) {
+	tableQuery := models.NewDiningTableQuery(db)
+	tableHandlers := handlers.NewTableHandlers(services.NewTableService(tableQuery, events))
+
+	routes.HandleFunc("GET /tables", tableHandlers.GetTables)
+	routes.HandleFunc("POST /tables", tableHandlers.CreateTable)
+	routes.HandleFunc("PUT /tables/{id}", tableHandlers.UpdateTable)
+	routes.HandleFunc("DELETE /tables/{id}", tableHandlers.DeleteTable)
+	routes.HandleFunc("POST /tables/{id}/token", tableHandlers.RotateTableToken)
+	routes.HandleFunc("PATCH /tables/{id}/status", tableHandlers.UpdateTableStatus)
+
+	// Guest ordering, authorized by the guest session token instead of staff access
+	menu := services.NewMenuItemService(models.NewMenuItemQuery(db), models.NewPricingRuleQuery(db), models.NewLocationQuery(db), events)
+	guestHandlers := handlers.NewGuestHandlers(services.NewGuestService(tableQuery, menu, newOrderService(db, events),
+		models.NewPriceExperimentQuery(db)))
+
+	routes.HandleFunc("POST /guest/sessions", guestHandlers.StartGuestSession)
+	routes.HandleFunc("GET /guest/session", guestHandlers.GetGuestSession)
+	routes.HandleFunc("GET /guest/menu", guestHandlers.GetGuestMenu)
+	routes.HandleFunc("GET /guest/orders", guestHandlers.GetGuestOrders)
+	routes.HandleFunc("POST /guest/orders", guestHandlers.PlaceGuestOrder)
+}
@@ -0,0 +1,19 @@
+package router
+
+import (
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupTaxRateRoutes configures the tax rate routes
+func SetupTaxRateRoutes(routes *Routes, db *bun.DB) {
+	taxRateHandlers := handlers.NewTaxRateHandlers(services.NewTaxRateService(models.NewTaxRateQuery(db)))
+
+	routes.HandleFunc("GET /tax-rates", taxRateHandlers.GetTaxRates)
+	routes.HandleFunc("POST /tax-rates", taxRateHandlers.CreateTaxRate)
+	routes.HandleFunc("PUT /tax-rates/{id}", taxRateHandlers.UpdateTaxRate)
+	routes.HandleFunc("DELETE /tax-rates/{id}", taxRateHandlers.DeleteTaxRate)
+}
@@ -0,0 +1,52 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/config"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/handlers"
+	"github.com/Zughayyar/agora-server/internal/middlewares"
+	"github.com/Zughayyar/agora-server/internal/restaurants"
+	"github.com/Zughayyar/agora-server/internal/services"
+)
+
+// SetupV2Routes mounts API v2 at /api/v2, with the same body size limit, request
+// timeout, read-only handling and restaurant scoping as v1. Every v2 response,
+// including errors raised by middlewares and the 404/405 fallback, uses the v2
+// envelope.
+func SetupV2Routes(routes *Routes, db *bun.DB, events services.EventPublisher, cfg *config.Config, directory *restaurants.Directory) {
+	middlewares.RegisterErrorFormatter("/api/v2/", handlers.WriteV2Error)
+
+	apiV2 := http.NewServeMux()
+	v2 := routes.Group(apiV2, "/api/v2")
+	v2Handlers := handlers.NewV2Handlers(
+		services.NewMenuItemService(models.NewMenuItemQuery(db), models.NewPricingRuleQuery(db), models.NewLocationQuery(db), events),
+		newOrderService(db, events),
+		db,
+	)
+
+	v2.HandleFunc("GET /health", v2Handlers.Health)
+	v2.HandleFunc("GET /version", v2Handlers.Version)
+
+	v2.HandleFunc("GET /items", v2Handlers.ListMenuItems)
+	v2.HandleFunc("POST /items", v2Handlers.CreateMenuItem)
+	v2.HandleFunc("GET /items/deleted", v2Handlers.ListDeletedMenuItems)
+	v2.HandleFunc("GET /items/{id}", v2Handlers.GetMenuItem)
+	v2.HandleFunc("PUT /items/{id}", v2Handlers.UpdateMenuItem)
+	v2.HandleFunc("DELETE /items/{id}", v2Handlers.DeleteMenuItem)
+	v2.HandleFunc("POST /items/{id}/restore", v2Handlers.RestoreMenuItem)
+
+	v2.HandleFunc("GET /orders", v2Handlers.ListOrders)
+	v2.HandleFunc("GET /orders/{id}", v2Handlers.GetOrder)
+	v2.SetupFallback()
+
+	var api http.Handler = http.StripPrefix("/api/v2", apiV2)
+	api = middlewares.BodyLimitMiddleware(cfg.MaxBodyBytes)(api)
+	api = middlewares.TimeoutMiddleware(cfg.RequestTimeout)(api)
+	api = middlewares.ReadOnlyMiddleware(api)
+	api = directory.Middleware(api)
+	routes.Handle("/api/v2/", api)
+}
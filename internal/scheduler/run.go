@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Task run states
+const (
+	RunRunning   = "running"
+	RunSucceeded = "succeeded"
+	RunFailed    = "failed"
+)
+
+// Run is one activation of a scheduled task, stored in the task_runs table
+type Run struct {
+	bun.BaseModel `bun:"table:task_runs,alias:tr" swaggerignore:"true"`
+
+	ID          int64      `bun:"id,pk,autoincrement" json:"id"`
+	Task        string     `bun:"task,notnull" json:"task" example:"purge_deleted"`
+	ScheduledAt time.Time  `bun:"scheduled_at,notnull" json:"scheduled_at"`
+	StartedAt   time.Time  `bun:"started_at,notnull" json:"started_at"`
+	FinishedAt  *time.Time `bun:"finished_at" json:"finished_at,omitempty"`
+	DurationMs  int64      `bun:"duration_ms,notnull" json:"duration_ms"`
+	Status      string     `bun:"status,notnull" json:"status" example:"succeeded"`
+	Result      *string    `bun:"result" json:"result,omitempty" swaggertype:"object"`
+	Error       *string    `bun:"error" json:"error,omitempty"`
+}
+
+// MarshalJSON embeds the result as JSON rather than a string
+func (r Run) MarshalJSON() ([]byte, error) {
+	type plain Run
+	out := struct {
+		plain
+		Result json.RawMessage `json:"result,omitempty"`
+	}{plain: plain(r)}
+	if r.Result != nil {
+		out.Result = json.RawMessage(*r.Result)
+	}
+	return json.Marshal(out)
+}
+
+// ListRuns returns the most recent runs of a task, newest first; an empty task lists
+// the runs of all tasks
+func ListRuns(ctx context.Context, db bun.IDB, task string, limit int) ([]Run, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	var runs []Run
+	q := db.NewSelect().Model(&runs).Order("scheduled_at DESC", "id DESC").Limit(limit)
+	if task != "" {
+		q = q.Where("task = ?", task)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// PruneRuns deletes the history of runs scheduled before cutoff and returns how many
+// were removed
+func PruneRuns(ctx context.Context, db bun.IDB, cutoff time.Time) (int64, error) {
+	res, err := db.NewDelete().Model((*Run)(nil)).Where("scheduled_at < ?", cutoff.UTC()).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
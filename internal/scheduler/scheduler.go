@@ -0,0 +1,309 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// Task is a periodic job. The returned result, if non-nil, is stored as JSON in
+// the task's run history.
+type Task func(ctx context.Context) (result any, err error)
+
+// taskTimeout bounds a single run of a task
+const taskTimeout = time.Hour
+
+// entry is a registered task
+type entry struct {
+	name     string
+	spec     string
+	schedule Schedule
+	task     Task
+	running  bool
+}
+
+// TaskInfo describes a registered task
+type TaskInfo struct {
+	Name     string     `json:"name" example:"purge_deleted"`
+	Schedule string     `json:"schedule" example:"0 3 * * *"`
+	NextRun  *time.Time `json:"next_run,omitempty"`
+	LastRun  *Run       `json:"last_run,omitempty"`
+}
+
+// Scheduler runs registered tasks on their schedules. Each activation is claimed by
+// inserting its run into the task_runs table, so when several instances share the
+// database only one of them runs it; the same rows are the run history.
+type Scheduler struct {
+	db *bun.DB
+
+	mu      sync.Mutex
+	entries []*entry
+	started bool
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a scheduler recording runs in db
+func New(db *bun.DB) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		db:     db,
+		wake:   make(chan struct{}, 1),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Register adds a task running on spec (see Parse). An empty spec or "off"
+// disables the task.
+func (s *Scheduler) Register(name, spec string, task Task) error {
+	if spec == "" || spec == "off" {
+		logging.FromContext(s.ctx).Info("Scheduled task disabled", slog.String("task", name))
+		return nil
+	}
+	schedule, err := Parse(spec)
+	if err != nil {
+		return fmt.Errorf("task %s: %w", name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("task %s: registered after Start", name)
+	}
+	s.entries = append(s.entries, &entry{name: name, spec: spec, schedule: schedule, task: task})
+	return nil
+}
+
+// Tasks describes the registered tasks with their next and last runs
+func (s *Scheduler) Tasks(ctx context.Context) ([]TaskInfo, error) {
+	s.mu.Lock()
+	now := time.Now()
+	tasks := make([]TaskInfo, 0, len(s.entries))
+	for _, e := range s.entries {
+		info := TaskInfo{Name: e.name, Schedule: e.spec}
+		if next := e.schedule.Next(now); !next.IsZero() {
+			info.NextRun = &next
+		}
+		tasks = append(tasks, info)
+	}
+	s.mu.Unlock()
+
+	for i := range tasks {
+		runs, err := ListRuns(ctx, s.db, tasks[i].Name, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(runs) > 0 {
+			tasks[i].LastRun = &runs[0]
+		}
+	}
+	return tasks, nil
+}
+
+// Start launches the scheduling loop
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Shutdown stops scheduling and waits for running tasks to return or ctx to be done.
+// Running tasks see their context cancelled.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// loop sleeps until the next activation of any task and starts the tasks that are due
+func (s *Scheduler) loop() {
+	defer s.wg.Done()
+
+	next := make(map[*entry]time.Time)
+	now := time.Now()
+	s.mu.Lock()
+	entries := slices.Clone(s.entries)
+	s.mu.Unlock()
+	for _, e := range entries {
+		next[e] = e.schedule.Next(now)
+	}
+
+	for {
+		var earliest time.Time
+		for _, at := range next {
+			if !at.IsZero() && (earliest.IsZero() || at.Before(earliest)) {
+				earliest = at
+			}
+		}
+		if earliest.IsZero() {
+			<-s.ctx.Done()
+			return
+		}
+
+		timer := time.NewTimer(time.Until(earliest))
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		for _, e := range entries {
+			if at := next[e]; !at.IsZero() && !at.After(now) {
+				s.start(e, at)
+				next[e] = e.schedule.Next(now)
+			}
+		}
+	}
+}
+
+// start runs one activation of a task in the background unless the previous run is
+// still going or another instance has already claimed it
+func (s *Scheduler) start(e *entry, scheduledAt time.Time) {
+	logger := logging.FromContext(s.ctx).With(slog.String("task", e.name))
+
+	s.mu.Lock()
+	if e.running {
+		s.mu.Unlock()
+		logger.Warn("Skipping scheduled task, the previous run is still going")
+		return
+	}
+	e.running = true
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			e.running = false
+			s.mu.Unlock()
+		}()
+
+		run, claimed, err := claimRun(s.ctx, s.db, e.name, scheduledAt)
+		if err != nil {
+			logger.Error("Failed to record task run", slog.String("error", err.Error()))
+			return
+		}
+		if !claimed {
+			logger.Debug("Scheduled task already claimed by another instance")
+			return
+		}
+		s.execute(logging.NewContext(s.ctx, logger), e, run)
+	}()
+}
+
+// execute runs the task and records the outcome in run
+func (s *Scheduler) execute(ctx context.Context, e *entry, run *Run) {
+	logger := logging.FromContext(ctx)
+	ctx, cancel := context.WithTimeout(ctx, taskTimeout)
+	defer cancel()
+
+	logger.Info("Scheduled task started")
+	result, err := runTask(ctx, e.task)
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.DurationMs = finished.Sub(run.StartedAt).Milliseconds()
+	if err != nil {
+		message := err.Error()
+		run.Status = RunFailed
+		run.Error = &message
+		logger.Error("Scheduled task failed",
+			slog.Int64("duration_ms", run.DurationMs),
+			slog.String("error", message))
+	} else {
+		run.Status = RunSucceeded
+		if result != nil {
+			if data, encodeErr := json.Marshal(result); encodeErr == nil {
+				encoded := string(data)
+				run.Result = &encoded
+			}
+		}
+		logger.Info("Scheduled task finished", slog.Int64("duration_ms", run.DurationMs))
+	}
+
+	// Record the outcome even while shutting down
+	saveCtx, saveCancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer saveCancel()
+	_, err = s.db.NewUpdate().Model(run).
+		Column("status", "result", "error", "finished_at", "duration_ms").
+		WherePK().
+		Exec(saveCtx)
+	if err != nil {
+		logger.Error("Failed to save task run", slog.String("error", err.Error()))
+	}
+}
+
+// runTask calls task, turning a panic into an error
+func runTask(ctx context.Context, task Task) (result any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			logging.FromContext(ctx).Error("Scheduled task panicked",
+				slog.Any("panic", p),
+				slog.String("stack", string(debug.Stack())))
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return task(ctx)
+}
+
+// claimRun inserts the run of a task's activation, reporting false if another
+// instance inserted it first
+func claimRun(ctx context.Context, db *bun.DB, task string, scheduledAt time.Time) (*Run, bool, error) {
+	run := &Run{
+		Task:        task,
+		ScheduledAt: scheduledAt.UTC(),
+		StartedAt:   time.Now(),
+		Status:      RunRunning,
+	}
+
+	q := db.NewInsert().Model(run)
+	if database.IsMySQL(db) {
+		q = q.Ignore()
+	} else {
+		q = q.On("CONFLICT (task, scheduled_at) DO NOTHING")
+	}
+	res, err := q.Exec(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, false, err
+	}
+	return run, n == 1, nil
+}
@@ -0,0 +1,62 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// AWSProvider reads credentials from an AWS Secrets Manager secret
+type AWSProvider struct {
+	secretID string
+	client   *secretsmanager.Client
+}
+
+// NewAWSProvider creates a provider for secretID using the default AWS credential chain
+func NewAWSProvider(ctx context.Context, secretID string) (*AWSProvider, error) {
+	if secretID == "" {
+		return nil, errors.New("aws provider requires DB_SECRET_ID")
+	}
+
+	awsConfig, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	return &AWSProvider{
+		secretID: secretID,
+		client:   secretsmanager.NewFromConfig(awsConfig),
+	}, nil
+}
+
+// Name identifies the provider in logs
+func (p *AWSProvider) Name() string {
+	return "aws"
+}
+
+// Credentials reads the current version of the secret
+func (p *AWSProvider) Credentials(ctx context.Context) (Credentials, error) {
+	out, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(p.secretID),
+	})
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to read secret %s: %w", p.secretID, err)
+	}
+	if out.SecretString == nil {
+		return Credentials{}, fmt.Errorf("secret %s has no string value", p.secretID)
+	}
+
+	var values map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(*out.SecretString))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return Credentials{}, fmt.Errorf("secret %s is not valid JSON: %w", p.secretID, err)
+	}
+	return parseCredentials(values)
+}
@@ -0,0 +1,91 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// Credentials are the database connection values read from a secret.
+// Empty fields mean the secret does not override the corresponding setting.
+type Credentials struct {
+	Username string
+	Password string
+	Host     string
+	Port     int
+	Database string
+}
+
+// Provider fetches database credentials from an external secrets manager
+type Provider interface {
+	// Name identifies the provider in logs
+	Name() string
+	// Credentials returns the current value of the database secret
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// Settings selects and configures a secrets provider
+type Settings struct {
+	Provider string // "", "vault" or "aws"
+
+	VaultAddr  string
+	VaultToken string
+	VaultPath  string // Path of the database secret in Vault
+
+	AWSSecretID string // ID or ARN of the database secret in AWS Secrets Manager
+}
+
+// New returns the provider selected by settings, or nil when none is configured
+func New(ctx context.Context, settings Settings) (Provider, error) {
+	switch settings.Provider {
+	case "":
+		return nil, nil
+	case "vault":
+		return NewVaultProvider(settings.VaultAddr, settings.VaultToken, settings.VaultPath)
+	case "aws":
+		return NewAWSProvider(ctx, settings.AWSSecretID)
+	default:
+		return nil, fmt.Errorf("unsupported secrets provider %q", settings.Provider)
+	}
+}
+
+// parseCredentials maps the conventional secret keys (as used by AWS RDS secrets
+// and most Vault setups) onto Credentials
+func parseCredentials(values map[string]interface{}) (Credentials, error) {
+	var creds Credentials
+	creds.Username = stringValue(values, "username", "user")
+	creds.Password = stringValue(values, "password")
+	creds.Host = stringValue(values, "host")
+	creds.Database = stringValue(values, "dbname", "database")
+
+	if port := stringValue(values, "port"); port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("invalid port %q in secret", port)
+		}
+		creds.Port = p
+	}
+
+	if creds.Username == "" || creds.Password == "" {
+		return Credentials{}, fmt.Errorf("secret must contain username and password")
+	}
+	return creds, nil
+}
+
+// stringValue returns the first non-empty value among keys, accepting JSON strings and numbers
+func stringValue(values map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		switch v := values[key].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case json.Number:
+			return v.String()
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return ""
+}
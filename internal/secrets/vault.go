@@ -0,0 +1,73 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultProvider reads credentials from a HashiCorp Vault KV secret
+type VaultProvider struct {
+	addr   string
+	token  string
+	path   string
+	client *http.Client
+}
+
+// NewVaultProvider creates a provider for the secret at path (e.g. "secret/data/agora/db"
+// for KV v2 or "secret/agora/db" for KV v1)
+func NewVaultProvider(addr, token, path string) (*VaultProvider, error) {
+	if addr == "" || token == "" || path == "" {
+		return nil, errors.New("vault provider requires VAULT_ADDR, VAULT_TOKEN and DB_SECRET_PATH")
+	}
+	return &VaultProvider{
+		addr:   strings.TrimRight(addr, "/"),
+		token:  token,
+		path:   strings.Trim(path, "/"),
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Name identifies the provider in logs
+func (p *VaultProvider) Name() string {
+	return "vault"
+}
+
+// Credentials reads the secret through Vault's HTTP API
+func (p *VaultProvider) Credentials(ctx context.Context) (Credentials, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.addr+"/v1/"+p.path, nil)
+	if err != nil {
+		return Credentials{}, err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Credentials{}, fmt.Errorf("vault returned status %d for %s", resp.StatusCode, p.path)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return Credentials{}, fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	// KV v2 nests the secret under data.data
+	values := body.Data
+	if nested, ok := values["data"].(map[string]interface{}); ok {
+		values = nested
+	}
+	return parseCredentials(values)
+}
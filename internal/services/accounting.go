@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// Accounting export formats
+const (
+	AccountingQuickBooks = "quickbooks"
+	AccountingXero       = "xero"
+)
+
+// AccountingAccounts names the ledger accounts of the accounting export. With Xero,
+// they are account codes.
+type AccountingAccounts struct {
+	// Sales is credited with the revenue of the day
+	Sales string
+	// Deposit is debited with the takings of the day, e.g. Undeposited Funds
+	Deposit string
+	// Tax is credited with the tax collected
+	Tax string
+}
+
+// AccountingExportOptions selects the period and file format of an accounting export.
+// The period defaults as for SalesReportOptions.
+type AccountingExportOptions struct {
+	From   time.Time
+	To     time.Time
+	Format string
+}
+
+// JournalEntry is the balanced journal of one business day's sales
+type JournalEntry struct {
+	Date  time.Time
+	Lines []JournalLine
+}
+
+// JournalLine debits or credits one account
+type JournalLine struct {
+	Account     string
+	Description string
+	Debit       decimal.Decimal
+	Credit      decimal.Decimal
+}
+
+// AccountingJournal builds one journal entry per business day with sales in the
+// period: the takings are debited to the deposit account, their tax credited to the
+// tax account and the rest to sales. Cancelled orders are not counted.
+func (s *reportService) AccountingJournal(ctx context.Context, opts AccountingExportOptions) ([]JournalEntry, error) {
+	ctx, span := tracer.Start(ctx, "ReportService.AccountingJournal")
+	defer span.End()
+
+	if opts.Format != AccountingQuickBooks && opts.Format != AccountingXero {
+		return nil, fmt.Errorf("%w: format must be one of quickbooks, xero", ErrInvalidReport)
+	}
+	var err error
+	if opts.From, opts.To, err = reportPeriod(opts.From, opts.To); err != nil {
+		return nil, err
+	}
+
+	orders, err := guard(func() ([]models.OrderTotal, error) { return s.repo.OrderTotals(ctx, opts.From, opts.To) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve orders: %w", err)
+	}
+
+	tax := make(map[string]decimal.Decimal)
+	for _, order := range orders {
+		key := businessDay(order.CreatedAt).Format(time.DateOnly)
+		tax[key] = tax[key].Add(order.Tax)
+	}
+
+	var entries []JournalEntry
+	for _, day := range salesByDay(orders, opts.From, opts.To) {
+		if day.OrderCount == 0 {
+			continue
+		}
+		date, _ := time.ParseInLocation(time.DateOnly, day.Key, timezone())
+		description := "Daily sales " + day.Key
+		entry := JournalEntry{
+			Date: date,
+			Lines: []JournalLine{
+				{Account: s.accounts.Deposit, Description: description, Debit: day.Revenue, Credit: decimal.Zero},
+				{Account: s.accounts.Sales, Description: description, Debit: decimal.Zero, Credit: day.Revenue.Sub(tax[day.Key])},
+			},
+		}
+		if !tax[day.Key].IsZero() {
+			entry.Lines = append(entry.Lines, JournalLine{Account: s.accounts.Tax, Description: "Tax on " + strings.ToLower(description), Debit: decimal.Zero, Credit: tax[day.Key]})
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// WriteJournalCSV writes journal entries as a CSV file importable by QuickBooks Online
+// (journal entry import; dates as MM/DD/YYYY) or Xero (manual journal import; dates as
+// DD/MM/YYYY, debits positive and credits negative)
+func WriteJournalCSV(w io.Writer, format string, entries []JournalEntry) error {
+	out := csv.NewWriter(w)
+	switch format {
+	case AccountingXero:
+		out.Write([]string{"Narration", "Date", "Description", "AccountCode", "TaxRate", "Amount"})
+		for _, entry := range entries {
+			narration := "Agora sales " + entry.Date.Format(time.DateOnly)
+			for _, line := range entry.Lines {
+				out.Write([]string{narration, entry.Date.Format("02/01/2006"), line.Description, line.Account, "Tax Exempt", line.Debit.Sub(line.Credit).StringFixed(2)})
+			}
+		}
+	default:
+		out.Write([]string{"JournalNo", "JournalDate", "AccountName", "Debits", "Credits", "Description"})
+		for _, entry := range entries {
+			number := "AG-" + entry.Date.Format("20060102")
+			for _, line := range entry.Lines {
+				out.Write([]string{number, entry.Date.Format("01/02/2006"), line.Account, amount(line.Debit), amount(line.Credit), line.Description})
+			}
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// amount formats a journal amount, leaving zero blank
+func amount(d decimal.Decimal) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.StringFixed(2)
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// AnonymizationRepository anonymizes old orders and keeps the audit of the runs
+type AnonymizationRepository interface {
+	AnonymizeOrders(ctx context.Context, cutoff time.Time, batchSize int, run *models.AnonymizationRun) error
+	RecordRun(ctx context.Context, run *models.AnonymizationRun) error
+	ListRuns(ctx context.Context, limit int) ([]models.AnonymizationRun, error)
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ AnonymizationRepository = (*models.AnonymizationQuery)(nil)
+
+// anonymizationBatchSize is the number of orders anonymized per transaction
+const anonymizationBatchSize = 1000
+
+// AnonymizationRunResponse is the audit entry of an anonymization run
+type AnonymizationRunResponse struct {
+	ID                int64     `json:"id" example:"42"`
+	RetentionDays     int       `json:"retention_days" example:"365"`
+	Cutoff            time.Time `json:"cutoff"`
+	Orders            int       `json:"orders" example:"120"`
+	CouponRedemptions int       `json:"coupon_redemptions" example:"8"`
+	Carts             int       `json:"carts" example:"30"`
+	Reviews           int       `json:"reviews" example:"5"`
+	Error             *string   `json:"error,omitempty"`
+	StartedAt         time.Time `json:"started_at"`
+	FinishedAt        time.Time `json:"finished_at"`
+	DurationMs        int64     `json:"duration_ms" example:"350"`
+}
+
+// OrderAnonymizer clears the contact details of customers from orders older than a
+// retention period, auditing every run
+type OrderAnonymizer struct {
+	repo AnonymizationRepository
+}
+
+// NewOrderAnonymizer creates an anonymizer of old orders
+func NewOrderAnonymizer(repo AnonymizationRepository) *OrderAnonymizer {
+	return &OrderAnonymizer{repo: repo}
+}
+
+// Anonymize clears the customer names, phone numbers and notes of the orders created
+// more than retentionDays ago, at every restaurant and whatever their status, with
+// those of their carts and reviews. The run is audited even when it fails, with the
+// rows anonymized before the error.
+func (a *OrderAnonymizer) Anonymize(ctx context.Context, retentionDays int) (*AnonymizationRunResponse, error) {
+	ctx, span := tracer.Start(ctx, "OrderAnonymizer.Anonymize")
+	defer span.End()
+
+	if retentionDays < 1 {
+		return nil, errors.New("retention period must be at least 1 day")
+	}
+	run := &models.AnonymizationRun{
+		RetentionDays: retentionDays,
+		StartedAt:     time.Now().UTC(),
+	}
+	run.Cutoff = run.StartedAt.AddDate(0, 0, -retentionDays)
+
+	anonymizeErr := guardExec(func() error {
+		return a.repo.AnonymizeOrders(ctx, run.Cutoff, anonymizationBatchSize, run)
+	})
+	if anonymizeErr != nil {
+		message := anonymizeErr.Error()
+		run.Error = &message
+	}
+	run.FinishedAt = time.Now().UTC()
+
+	// Recorded even when the run was cancelled, as the orders it anonymized stay so
+	recordCtx := context.WithoutCancel(ctx)
+	if err := guardExec(func() error { return a.repo.RecordRun(recordCtx, run) }); err != nil {
+		return nil, errors.Join(anonymizeErr, fmt.Errorf("failed to record anonymization run: %w", err))
+	}
+	if anonymizeErr != nil {
+		return nil, fmt.Errorf("failed to anonymize orders: %w", anonymizeErr)
+	}
+
+	logging.FromContext(ctx).Info("Anonymized old orders",
+		slog.Time("cutoff", run.Cutoff),
+		slog.Int("orders", run.Orders),
+		slog.Int("coupon_redemptions", run.CouponRedemptions),
+		slog.Int("carts", run.Carts),
+		slog.Int("reviews", run.Reviews))
+	return newAnonymizationRunResponse(run), nil
+}
+
+// Runs returns the audit entries of the most recent anonymization runs, newest first
+func (a *OrderAnonymizer) Runs(ctx context.Context, limit int) ([]AnonymizationRunResponse, error) {
+	ctx, span := tracer.Start(ctx, "OrderAnonymizer.Runs")
+	defer span.End()
+
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	runs, err := guard(func() ([]models.AnonymizationRun, error) { return a.repo.ListRuns(ctx, limit) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to list anonymization runs: %w", err)
+	}
+
+	responses := make([]AnonymizationRunResponse, len(runs))
+	for i := range runs {
+		responses[i] = *newAnonymizationRunResponse(&runs[i])
+	}
+	return responses, nil
+}
+
+// newAnonymizationRunResponse converts an anonymization run to its response
+func newAnonymizationRunResponse(run *models.AnonymizationRun) *AnonymizationRunResponse {
+	return &AnonymizationRunResponse{
+		ID:                run.ID,
+		RetentionDays:     run.RetentionDays,
+		Cutoff:            localTime(run.Cutoff),
+		Orders:            run.Orders,
+		CouponRedemptions: run.CouponRedemptions,
+		Carts:             run.Carts,
+		Reviews:           run.Reviews,
+		Error:             run.Error,
+		StartedAt:         localTime(run.StartedAt),
+		FinishedAt:        localTime(run.FinishedAt),
+		DurationMs:        run.FinishedAt.Sub(run.StartedAt).Milliseconds(),
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/circuitbreaker"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// ErrServiceUnavailable is returned while the database circuit breaker is open
+var ErrServiceUnavailable = circuitbreaker.ErrOpen
+
+// dbBreaker protects the connection pool during database outages. It is shared by
+// all services so that failures observed anywhere fail fast everywhere.
+var dbBreaker = newDBBreaker(5, 30*time.Second)
+
+// ConfigureCircuitBreaker replaces the database breaker settings; call before serving requests
+func ConfigureCircuitBreaker(failureThreshold int, openTimeout time.Duration) {
+	dbBreaker = newDBBreaker(failureThreshold, openTimeout)
+}
+
+func newDBBreaker(failureThreshold int, openTimeout time.Duration) *circuitbreaker.Breaker {
+	return circuitbreaker.New(circuitbreaker.Settings{
+		Name:             "database",
+		FailureThreshold: failureThreshold,
+		OpenTimeout:      openTimeout,
+		IsFailure:        isDBFailure,
+	})
+}
+
+// isDBFailure reports whether err indicates an unhealthy database rather than a normal
+// outcome, such as a missing row or a change refused by a business rule
+func isDBFailure(err error) bool {
+	return err != nil &&
+		!errors.Is(err, sql.ErrNoRows) &&
+		!errors.Is(err, context.Canceled) &&
+		!models.IsOutcome(err)
+}
+
+// guard runs a database call returning a value through the circuit breaker
+func guard[T any](fn func() (T, error)) (T, error) {
+	var result T
+	err := dbBreaker.Execute(func() error {
+		var err error
+		result, err = fn()
+		return err
+	})
+	return result, err
+}
+
+// guardExec runs a database call through the circuit breaker
+func guardExec(fn func() error) error {
+	return dbBreaker.Execute(fn)
+}
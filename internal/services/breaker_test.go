@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/circuitbreaker"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+func TestBusinessRuleOutcomesDoNotTripBreaker(t *testing.T) {
+	saved := dbBreaker
+	t.Cleanup(func() { dbBreaker = saved })
+	ConfigureCircuitBreaker(2, time.Minute)
+
+	outcomes := []error{
+		models.ErrSlotFull,
+		models.ErrCouponUsedUp,
+		models.ErrCouponCodeTaken,
+		models.ErrGiftCardBalance,
+		models.ErrGiftCardCodeTaken,
+		models.ErrOrderPaid,
+		models.ErrLoyaltyBalance,
+		models.ErrStoreCreditBalance,
+		models.ErrCartCheckedOut,
+		models.ErrTicketPrepared,
+		models.ErrTableNameTaken,
+		models.ErrTaxRateScopeTaken,
+		models.ErrDeliveryStatusChanged,
+		models.ErrDeliveryZoneNameTaken,
+		models.ErrFloorPlanChanged,
+		models.ErrPunchClosed,
+		models.ErrExperimentEnded,
+	}
+	for _, outcome := range outcomes {
+		wrapped := fmt.Errorf("failed to save: %w", outcome)
+		for range 3 {
+			if err := guardExec(func() error { return wrapped }); !errors.Is(err, outcome) {
+				t.Fatalf("guardExec() = %v, want %v", err, outcome)
+			}
+		}
+		if state := dbBreaker.State(); state != circuitbreaker.StateClosed {
+			t.Fatalf("breaker %s after %v, want closed", state, outcome)
+		}
+	}
+
+	for range 2 {
+		_ = guardExec(func() error { return errors.New("connection refused") })
+	}
+	if state := dbBreaker.State(); state != circuitbreaker.StateOpen {
+		t.Fatalf("breaker %s after database failures, want open", state)
+	}
+}
@@ -0,0 +1,614 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// CartRepository abstracts cart storage
+type CartRepository interface {
+	FindByID(ctx context.Context, id string) (*models.Cart, error)
+	Create(ctx context.Context, cart *models.Cart) error
+	Update(ctx context.Context, cart *models.Cart) error
+	AddItem(ctx context.Context, item *models.CartItem) error
+	UpdateItem(ctx context.Context, item *models.CartItem) error
+	DeleteItem(ctx context.Context, cartID string, itemID int) error
+	CheckOut(ctx context.Context, cartID, orderID string) error
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ CartRepository = (*models.CartQuery)(nil)
+
+// CartService defines the operations customers perform on a cart while putting an
+// online order together, up to checking it out into an order
+type CartService interface {
+	CreateCart(ctx context.Context, req CartRequest) (*CartResponse, error)
+	GetCart(ctx context.Context, id string) (*CartResponse, error)
+	UpdateCart(ctx context.Context, id string, req CartRequest) (*CartResponse, error)
+	AddCartItem(ctx context.Context, id string, req CartItemRequest) (*CartResponse, error)
+	UpdateCartItem(ctx context.Context, id string, itemID int, req CartItemRequest) (*CartResponse, error)
+	RemoveCartItem(ctx context.Context, id string, itemID int) (*CartResponse, error)
+	ApplyCoupon(ctx context.Context, id string, req CartCouponRequest) (*CartResponse, error)
+	RemoveCoupon(ctx context.Context, id string) (*CartResponse, error)
+	SetTip(ctx context.Context, id string, req CartTipRequest) (*CartResponse, error)
+	PreviewCart(ctx context.Context, id string) (*OrderResponse, error)
+	CheckOut(ctx context.Context, id string) (*OrderResponse, error)
+}
+
+// Cart errors
+var (
+	ErrCartNotFound     = errors.New("cart not found")
+	ErrInvalidCart      = errors.New("invalid cart")
+	ErrCartItemNotFound = errors.New("cart item not found")
+	// ErrCartCheckedOut is returned when changing or checking out a cart that was
+	// already converted into an order
+	ErrCartCheckedOut = errors.New("cart already checked out")
+	// ErrCartChanged is returned, wrapped in a CartChangedError, when the menu changed
+	// since the customer last saw the cart
+	ErrCartChanged = errors.New("cart changed")
+)
+
+// CartChangedError lists how the menu changed since the customer last saw a cart:
+// items no longer available and lines whose price changed. The cart's lines have
+// been updated to the new prices.
+type CartChangedError struct {
+	Problems []string
+}
+
+// Error implements error
+func (e *CartChangedError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrCartChanged, strings.Join(e.Problems, "; "))
+}
+
+// Is makes CartChangedError match ErrCartChanged
+func (e *CartChangedError) Is(target error) bool {
+	return target == ErrCartChanged
+}
+
+// maxCartItems is the most lines a cart holds
+const maxCartItems = 100
+
+// CartRequest creates a cart or changes its details. Channel defaults to takeaway.
+type CartRequest struct {
+	Channel       string  `json:"channel,omitempty" example:"takeaway"`
+	CustomerName  *string `json:"customer_name,omitempty" example:"Lina"`
+	CustomerPhone *string `json:"customer_phone,omitempty" example:"+962790000000"`
+	Notes         *string `json:"notes,omitempty"`
+	// Location of the restaurant to order from; it must be open at checkout
+	LocationID *int `json:"location_id,omitempty" example:"2"`
+}
+
+// CartItemRequest adds a menu item to a cart or changes a line. MenuItemID is ignored
+// when changing a line.
+type CartItemRequest struct {
+	MenuItemID int     `json:"menu_item_id,omitempty" example:"1"`
+	Quantity   int     `json:"quantity" example:"2"`
+	Notes      *string `json:"notes,omitempty" example:"No onions"`
+}
+
+// CartCouponRequest applies a coupon to a cart
+type CartCouponRequest struct {
+	Code string `json:"code" example:"SUMMER10"`
+}
+
+// CartTipRequest sets the tip of a cart as an amount or a percentage of its total;
+// setting neither removes it
+type CartTipRequest struct {
+	Tip        *decimal.Decimal `json:"tip,omitempty" swaggertype:"string" example:"3.00"`
+	TipPercent *decimal.Decimal `json:"tip_percent,omitempty" swaggertype:"string" example:"10"`
+}
+
+// CartResponse represents the cart data returned to clients. Subtotal adds up the
+// prices the customer was shown; the preview prices the cart with its discounts, tax
+// and tip.
+type CartResponse struct {
+	ID            string             `json:"id" example:"4c1f1d8e-3a4b-4b7f-9a56-2f7c1e0d9b21"`
+	Channel       string             `json:"channel" example:"takeaway"`
+	CustomerName  *string            `json:"customer_name,omitempty"`
+	CustomerPhone *string            `json:"customer_phone,omitempty"`
+	Notes         *string            `json:"notes,omitempty"`
+	LocationID    *int               `json:"location_id,omitempty" example:"2"`
+	CouponCode    *string            `json:"coupon_code,omitempty" example:"SUMMER10"`
+	Tip           *decimal.Decimal   `json:"tip,omitempty" swaggertype:"string" example:"3.00"`
+	TipPercent    *decimal.Decimal   `json:"tip_percent,omitempty" swaggertype:"string" example:"10"`
+	Items         []CartItemResponse `json:"items"`
+	Subtotal      decimal.Decimal    `json:"subtotal" swaggertype:"string" example:"25.00"`
+	// Order the cart was checked out into
+	OrderID   *string   `json:"order_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CartItemResponse is a line of a cart
+type CartItemResponse struct {
+	ID         int             `json:"id" example:"7"`
+	MenuItemID int             `json:"menu_item_id" example:"1"`
+	Name       string          `json:"name" example:"Mansaf"`
+	Quantity   int             `json:"quantity" example:"2"`
+	UnitPrice  decimal.Decimal `json:"unit_price" swaggertype:"string" example:"12.50"`
+	LineTotal  decimal.Decimal `json:"line_total" swaggertype:"string" example:"25.00"`
+	Notes      *string         `json:"notes,omitempty"`
+}
+
+// cartService handles business logic for carts
+type cartService struct {
+	repo      CartRepository
+	menu      MenuItemRepository
+	locations LocationRepository
+	orders    OrderService
+}
+
+// NewCartService creates a new cart service pricing and placing orders through orders
+func NewCartService(repo CartRepository, menu MenuItemRepository, locations LocationRepository, orders OrderService) CartService {
+	return &cartService{repo: repo, menu: menu, locations: locations, orders: orders}
+}
+
+// CreateCart starts an empty cart
+func (s *cartService) CreateCart(ctx context.Context, req CartRequest) (*CartResponse, error) {
+	ctx, span := tracer.Start(ctx, "CartService.CreateCart")
+	defer span.End()
+
+	cart := &models.Cart{Items: []models.CartItem{}}
+	if err := applyCartRequest(cart, req); err != nil {
+		return nil, err
+	}
+	if err := s.checkLocation(ctx, cart); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Create(ctx, cart) }); err != nil {
+		return nil, fmt.Errorf("failed to create cart: %w", err)
+	}
+	return newCartResponse(cart), nil
+}
+
+// GetCart returns a cart with its lines
+func (s *cartService) GetCart(ctx context.Context, id string) (*CartResponse, error) {
+	ctx, span := tracer.Start(ctx, "CartService.GetCart")
+	defer span.End()
+
+	cart, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return newCartResponse(cart), nil
+}
+
+// UpdateCart changes the channel and customer details of a cart. Lines are repriced
+// when the channel changes.
+func (s *cartService) UpdateCart(ctx context.Context, id string, req CartRequest) (*CartResponse, error) {
+	ctx, span := tracer.Start(ctx, "CartService.UpdateCart")
+	defer span.End()
+
+	cart, err := s.open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	channel := cart.Channel
+	if err := applyCartRequest(cart, req); err != nil {
+		return nil, err
+	}
+	if err := s.checkLocation(ctx, cart); err != nil {
+		return nil, err
+	}
+	if cart.Channel != channel {
+		if _, err := s.reprice(ctx, cart); err != nil {
+			return nil, err
+		}
+	}
+	if err := guardExec(func() error { return s.repo.Update(ctx, cart) }); err != nil {
+		return nil, fmt.Errorf("failed to update cart %s: %w", id, err)
+	}
+	return newCartResponse(cart), nil
+}
+
+// AddCartItem adds an available menu item to a cart at its current price
+func (s *cartService) AddCartItem(ctx context.Context, id string, req CartItemRequest) (*CartResponse, error) {
+	ctx, span := tracer.Start(ctx, "CartService.AddCartItem")
+	defer span.End()
+
+	cart, err := s.open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) >= maxCartItems {
+		return nil, fmt.Errorf("%w: a cart holds at most %d items", ErrInvalidCart, maxCartItems)
+	}
+	if req.Quantity < 1 {
+		return nil, fmt.Errorf("%w: quantity must be at least 1", ErrInvalidCart)
+	}
+	if problem, err := s.unavailable(ctx, req.MenuItemID); err != nil {
+		return nil, err
+	} else if problem != "" {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCart, problem)
+	}
+
+	item := models.CartItem{CartID: cart.ID, MenuItemID: req.MenuItemID, Quantity: req.Quantity, Notes: req.Notes}
+	line, err := s.quoteLine(ctx, cart.Channel, item)
+	if err != nil {
+		return nil, err
+	}
+	item.Name, item.UnitPrice = line.Name, line.UnitPrice
+	if err := guardExec(func() error { return s.repo.AddItem(ctx, &item) }); err != nil {
+		return nil, fmt.Errorf("failed to add item to cart %s: %w", id, err)
+	}
+	cart.Items = append(cart.Items, item)
+	return newCartResponse(cart), nil
+}
+
+// UpdateCartItem changes the quantity and notes of a cart line
+func (s *cartService) UpdateCartItem(ctx context.Context, id string, itemID int, req CartItemRequest) (*CartResponse, error) {
+	ctx, span := tracer.Start(ctx, "CartService.UpdateCartItem")
+	defer span.End()
+
+	cart, err := s.open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	item := cartItem(cart, itemID)
+	if item == nil {
+		return nil, ErrCartItemNotFound
+	}
+	if req.Quantity < 1 {
+		return nil, fmt.Errorf("%w: quantity must be at least 1", ErrInvalidCart)
+	}
+	item.Quantity = req.Quantity
+	item.Notes = req.Notes
+	if err := guardExec(func() error { return s.repo.UpdateItem(ctx, item) }); err != nil {
+		return nil, fmt.Errorf("failed to update item %d of cart %s: %w", itemID, id, err)
+	}
+	return newCartResponse(cart), nil
+}
+
+// RemoveCartItem removes a line from a cart
+func (s *cartService) RemoveCartItem(ctx context.Context, id string, itemID int) (*CartResponse, error) {
+	ctx, span := tracer.Start(ctx, "CartService.RemoveCartItem")
+	defer span.End()
+
+	cart, err := s.open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cartItem(cart, itemID) == nil {
+		return nil, ErrCartItemNotFound
+	}
+	if err := guardExec(func() error { return s.repo.DeleteItem(ctx, id, itemID) }); err != nil {
+		return nil, fmt.Errorf("failed to remove item %d from cart %s: %w", itemID, id, err)
+	}
+	items := cart.Items[:0]
+	for _, item := range cart.Items {
+		if item.ID != itemID {
+			items = append(items, item)
+		}
+	}
+	cart.Items = items
+	return newCartResponse(cart), nil
+}
+
+// ApplyCoupon applies a coupon to a cart after checking it can be redeemed on the
+// cart's items. It is only redeemed at checkout.
+func (s *cartService) ApplyCoupon(ctx context.Context, id string, req CartCouponRequest) (*CartResponse, error) {
+	ctx, span := tracer.Start(ctx, "CartService.ApplyCoupon")
+	defer span.End()
+
+	cart, err := s.open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	code := strings.TrimSpace(req.Code)
+	switch {
+	case code == "":
+		return nil, fmt.Errorf("%w: code is required", ErrInvalidCart)
+	case len(cart.Items) == 0:
+		return nil, fmt.Errorf("%w: add items before applying a coupon", ErrInvalidCart)
+	}
+	cart.CouponCode = &code
+	if _, err := s.orders.QuoteOrder(ctx, cartOrder(cart)); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Update(ctx, cart) }); err != nil {
+		return nil, fmt.Errorf("failed to update cart %s: %w", id, err)
+	}
+	return newCartResponse(cart), nil
+}
+
+// RemoveCoupon removes the coupon of a cart
+func (s *cartService) RemoveCoupon(ctx context.Context, id string) (*CartResponse, error) {
+	ctx, span := tracer.Start(ctx, "CartService.RemoveCoupon")
+	defer span.End()
+
+	cart, err := s.open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	cart.CouponCode = nil
+	if err := guardExec(func() error { return s.repo.Update(ctx, cart) }); err != nil {
+		return nil, fmt.Errorf("failed to update cart %s: %w", id, err)
+	}
+	return newCartResponse(cart), nil
+}
+
+// SetTip sets or removes the tip of a cart
+func (s *cartService) SetTip(ctx context.Context, id string, req CartTipRequest) (*CartResponse, error) {
+	ctx, span := tracer.Start(ctx, "CartService.SetTip")
+	defer span.End()
+
+	cart, err := s.open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case req.Tip != nil && req.TipPercent != nil:
+		return nil, fmt.Errorf("%w: set at most one of tip and tip_percent", ErrInvalidCart)
+	case req.Tip != nil && req.Tip.IsNegative():
+		return nil, fmt.Errorf("%w: tip must not be negative", ErrInvalidCart)
+	case req.TipPercent != nil && (req.TipPercent.IsNegative() || req.TipPercent.GreaterThan(decimal.NewFromInt(100))):
+		return nil, fmt.Errorf("%w: tip_percent must be a percentage between 0 and 100", ErrInvalidCart)
+	}
+	cart.Tip, cart.TipPercent = req.Tip, req.TipPercent
+	if cart.Tip != nil {
+		tip := cart.Tip.Round(2)
+		cart.Tip = &tip
+	}
+	if err := guardExec(func() error { return s.repo.Update(ctx, cart) }); err != nil {
+		return nil, fmt.Errorf("failed to update cart %s: %w", id, err)
+	}
+	return newCartResponse(cart), nil
+}
+
+// PreviewCart prices a cart as checking it out now would, with its discounts, tax and
+// tip, without creating an order
+func (s *cartService) PreviewCart(ctx context.Context, id string) (*OrderResponse, error) {
+	ctx, span := tracer.Start(ctx, "CartService.PreviewCart")
+	defer span.End()
+
+	cart, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, fmt.Errorf("%w: the cart is empty", ErrInvalidCart)
+	}
+	return s.orders.QuoteOrder(ctx, cartOrder(cart))
+}
+
+// CheckOut converts a cart into an order after checking its items are still
+// available at the prices the customer was shown. When they aren't, the cart's prices
+// are updated and a CartChangedError lists what changed, for the customer to review
+// before checking out again. Checking out is idempotent: a cart whose order was
+// created but not recorded returns that order.
+func (s *cartService) CheckOut(ctx context.Context, id string) (*OrderResponse, error) {
+	ctx, span := tracer.Start(ctx, "CartService.CheckOut")
+	defer span.End()
+
+	cart, err := s.open(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, fmt.Errorf("%w: the cart is empty", ErrInvalidCart)
+	}
+
+	var problems []string
+	for _, item := range cart.Items {
+		problem, err := s.unavailable(ctx, item.MenuItemID)
+		if err != nil {
+			return nil, err
+		}
+		if problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) == 0 {
+		if problems, err = s.reprice(ctx, cart); err != nil {
+			return nil, err
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &CartChangedError{Problems: problems}
+	}
+
+	order, err := s.orders.CreateOrder(ctx, cartOrder(cart))
+	if err != nil && !errors.Is(err, ErrOrderExists) {
+		return nil, err
+	}
+	err = guardExec(func() error { return s.repo.CheckOut(ctx, cart.ID, order.ID) })
+	if err != nil && !errors.Is(err, models.ErrCartCheckedOut) {
+		return nil, fmt.Errorf("failed to check out cart %s: %w", id, err)
+	}
+	return order, nil
+}
+
+// find loads a cart by ID
+func (s *cartService) find(ctx context.Context, id string) (*models.Cart, error) {
+	cart, err := guard(func() (*models.Cart, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCartNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cart %s: %w", id, err)
+	}
+	return cart, nil
+}
+
+// open loads a cart that hasn't been checked out
+func (s *cartService) open(ctx context.Context, id string) (*models.Cart, error) {
+	cart, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cart.OrderID != nil {
+		return nil, fmt.Errorf("%w: into order %s", ErrCartCheckedOut, *cart.OrderID)
+	}
+	return cart, nil
+}
+
+// unavailable describes why a menu item can't be ordered, empty when it can
+func (s *cartService) unavailable(ctx context.Context, menuItemID int) (string, error) {
+	item, err := guard(func() (*models.MenuItem, error) {
+		return s.menu.FindByID(database.UsePrimary(ctx), menuItemID)
+	})
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return fmt.Sprintf("menu item %d is not on the menu", menuItemID), nil
+	case err != nil:
+		return "", fmt.Errorf("failed to look up menu item %d: %w", menuItemID, err)
+	case !item.IsAvailable:
+		return fmt.Sprintf("%s is not available", item.Name), nil
+	}
+	return "", nil
+}
+
+// quoteLine prices a single cart line as an order on channel would
+func (s *cartService) quoteLine(ctx context.Context, channel string, item models.CartItem) (*OrderItemResponse, error) {
+	quote, err := s.orders.QuoteOrder(ctx, CreateOrderRequest{
+		Source:  models.OrderSourceWeb,
+		Channel: channel,
+		Items:   []CreateOrderItemRequest{cartOrderItem(item)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &quote.Items[0], nil
+}
+
+// reprice updates the names and prices of a cart's lines to the menu's current ones,
+// saving the lines that changed, and describes the changes
+func (s *cartService) reprice(ctx context.Context, cart *models.Cart) ([]string, error) {
+	if len(cart.Items) == 0 {
+		return nil, nil
+	}
+	order := cartOrder(cart)
+	order.CouponCode, order.Tip, order.TipPercent = nil, nil, nil
+	quote, err := s.orders.QuoteOrder(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []string
+	for i := range cart.Items {
+		item, line := &cart.Items[i], quote.Items[i]
+		if item.Name == line.Name && item.UnitPrice.Equal(line.UnitPrice) {
+			continue
+		}
+		if !item.UnitPrice.Equal(line.UnitPrice) {
+			changes = append(changes, fmt.Sprintf("%s now costs %s instead of %s",
+				line.Name, line.UnitPrice.StringFixed(2), item.UnitPrice.StringFixed(2)))
+		}
+		item.Name, item.UnitPrice = line.Name, line.UnitPrice
+		if err := guardExec(func() error { return s.repo.UpdateItem(ctx, item) }); err != nil {
+			return nil, fmt.Errorf("failed to update item %d of cart %s: %w", item.ID, cart.ID, err)
+		}
+	}
+	return changes, nil
+}
+
+// applyCartRequest validates req and copies it onto cart
+func applyCartRequest(cart *models.Cart, req CartRequest) error {
+	if req.Channel == "" {
+		req.Channel = models.OrderChannelTakeaway
+	}
+	if !validChannel(req.Channel) {
+		return fmt.Errorf("%w: channel must be one of dine_in, takeaway, delivery", ErrInvalidCart)
+	}
+	cart.Channel = req.Channel
+	cart.CustomerName = req.CustomerName
+	cart.CustomerPhone = req.CustomerPhone
+	cart.Notes = req.Notes
+	cart.LocationID = req.LocationID
+	return nil
+}
+
+// checkLocation checks the location a cart names is one of the restaurant of ctx
+func (s *cartService) checkLocation(ctx context.Context, cart *models.Cart) error {
+	if cart.LocationID == nil {
+		return nil
+	}
+	_, err := guard(func() (*models.Location, error) { return s.locations.FindByID(ctx, *cart.LocationID) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: location %d not found", ErrInvalidCart, *cart.LocationID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find location %d: %w", *cart.LocationID, err)
+	}
+	return nil
+}
+
+// cartItem returns the line of a cart with the given ID, or nil
+func cartItem(cart *models.Cart, itemID int) *models.CartItem {
+	for i := range cart.Items {
+		if cart.Items[i].ID == itemID {
+			return &cart.Items[i]
+		}
+	}
+	return nil
+}
+
+// cartOrder converts a cart to the order checking it out creates. The cart's ID is
+// the order's external ID, so a cart is never checked out twice.
+func cartOrder(cart *models.Cart) CreateOrderRequest {
+	order := CreateOrderRequest{
+		Source:        models.OrderSourceWeb,
+		ExternalID:    &cart.ID,
+		Channel:       cart.Channel,
+		CustomerName:  cart.CustomerName,
+		CustomerPhone: cart.CustomerPhone,
+		Notes:         cart.Notes,
+		LocationID:    cart.LocationID,
+		CouponCode:    cart.CouponCode,
+		Tip:           cart.Tip,
+		TipPercent:    cart.TipPercent,
+		Items:         make([]CreateOrderItemRequest, len(cart.Items)),
+	}
+	for i, item := range cart.Items {
+		order.Items[i] = cartOrderItem(item)
+	}
+	return order
+}
+
+// cartOrderItem converts a cart line to an order line priced from the menu
+func cartOrderItem(item models.CartItem) CreateOrderItemRequest {
+	return CreateOrderItemRequest{MenuItemID: &item.MenuItemID, Quantity: item.Quantity, Notes: item.Notes}
+}
+
+// newCartResponse converts a cart to its response
+func newCartResponse(cart *models.Cart) *CartResponse {
+	response := &CartResponse{
+		ID:            cart.ID,
+		Channel:       cart.Channel,
+		CustomerName:  cart.CustomerName,
+		CustomerPhone: cart.CustomerPhone,
+		Notes:         cart.Notes,
+		LocationID:    cart.LocationID,
+		CouponCode:    cart.CouponCode,
+		Tip:           cart.Tip,
+		TipPercent:    cart.TipPercent,
+		Items:         make([]CartItemResponse, len(cart.Items)),
+		Subtotal:      decimal.Zero,
+		OrderID:       cart.OrderID,
+		CreatedAt:     localTime(cart.CreatedAt),
+		UpdatedAt:     localTime(cart.UpdatedAt),
+	}
+	for i, item := range cart.Items {
+		lineTotal := item.UnitPrice.Mul(decimal.NewFromInt(int64(item.Quantity)))
+		response.Items[i] = CartItemResponse{
+			ID:         item.ID,
+			MenuItemID: item.MenuItemID,
+			Name:       item.Name,
+			Quantity:   item.Quantity,
+			UnitPrice:  item.UnitPrice,
+			LineTotal:  lineTotal,
+			Notes:      item.Notes,
+		}
+		response.Subtotal = response.Subtotal.Add(lineTotal)
+	}
+	return response
+}
@@ -0,0 +1,401 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// CouponRepository abstracts coupon storage
+type CouponRepository interface {
+	List(ctx context.Context) ([]models.Coupon, error)
+	FindByID(ctx context.Context, id int) (*models.Coupon, error)
+	FindByCode(ctx context.Context, code string) (*models.Coupon, error)
+	Create(ctx context.Context, coupon *models.Coupon) error
+	Update(ctx context.Context, coupon *models.Coupon) error
+	Delete(ctx context.Context, id int) error
+	Redemptions(ctx context.Context, couponID int) ([]models.CouponRedemption, error)
+	CountRedemptions(ctx context.Context, couponID int, customerPhone string) (int, error)
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ CouponRepository = (*models.CouponQuery)(nil)
+
+// CouponService defines business operations on coupons
+type CouponService interface {
+	ListCoupons(ctx context.Context) ([]CouponResponse, error)
+	CreateCoupon(ctx context.Context, req CouponRequest) (*CouponResponse, error)
+	UpdateCoupon(ctx context.Context, id int, req CouponRequest) (*CouponResponse, error)
+	DeleteCoupon(ctx context.Context, id int) error
+	ListRedemptions(ctx context.Context, id int) ([]CouponRedemptionResponse, error)
+}
+
+// Coupon errors
+var (
+	ErrCouponNotFound = errors.New("coupon not found")
+	ErrInvalidCoupon  = errors.New("invalid coupon")
+	ErrCouponExists   = errors.New("coupon code already exists")
+	// ErrCouponNotRedeemable is returned when an order's coupon can't be redeemed on it
+	ErrCouponNotRedeemable = errors.New("coupon not redeemable")
+)
+
+// CouponRequest creates or replaces a coupon. Discount is a percentage for the
+// "percent" type and an amount taken off the eligible lines for "amount". At most one
+// of Category and MenuItemID may be set; with neither, the coupon applies to the
+// whole order. Limits and validity bounds are optional.
+type CouponRequest struct {
+	Code               string          `json:"code" example:"SUMMER10"`
+	Description        *string         `json:"description,omitempty"`
+	DiscountType       string          `json:"discount_type" enums:"percent,amount" example:"percent"`
+	Discount           decimal.Decimal `json:"discount" swaggertype:"string" example:"10"`
+	Category           *string         `json:"category,omitempty" example:"main"`
+	MenuItemID         *int            `json:"menu_item_id,omitempty"`
+	MaxUses            *int            `json:"max_uses,omitempty" example:"100"`
+	MaxUsesPerCustomer *int            `json:"max_uses_per_customer,omitempty" example:"1"`
+	ValidFrom          *time.Time      `json:"valid_from,omitempty"`
+	ValidUntil         *time.Time      `json:"valid_until,omitempty"`
+	IsActive           *bool           `json:"is_active,omitempty"`
+}
+
+// CouponResponse represents the coupon data returned to clients
+type CouponResponse struct {
+	ID                 int             `json:"id" example:"1"`
+	Code               string          `json:"code" example:"SUMMER10"`
+	Description        *string         `json:"description,omitempty"`
+	DiscountType       string          `json:"discount_type" example:"percent"`
+	Discount           decimal.Decimal `json:"discount" swaggertype:"string" example:"10"`
+	Category           *string         `json:"category,omitempty" example:"main"`
+	MenuItemID         *int            `json:"menu_item_id,omitempty"`
+	MaxUses            *int            `json:"max_uses,omitempty" example:"100"`
+	MaxUsesPerCustomer *int            `json:"max_uses_per_customer,omitempty" example:"1"`
+	TimesUsed          int             `json:"times_used" example:"12"`
+	ValidFrom          *time.Time      `json:"valid_from,omitempty"`
+	ValidUntil         *time.Time      `json:"valid_until,omitempty"`
+	IsActive           bool            `json:"is_active"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+}
+
+// CouponRedemptionResponse is one use of a coupon
+type CouponRedemptionResponse struct {
+	OrderID       string          `json:"order_id" example:"0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"`
+	CustomerPhone *string         `json:"customer_phone,omitempty"`
+	Discount      decimal.Decimal `json:"discount" swaggertype:"string" example:"2.50"`
+	RedeemedAt    time.Time       `json:"redeemed_at"`
+}
+
+// couponService handles business logic for coupons
+type couponService struct {
+	repo CouponRepository
+}
+
+// NewCouponService creates a new coupon service
+func NewCouponService(repo CouponRepository) CouponService {
+	return &couponService{repo: repo}
+}
+
+// ListCoupons returns all coupons
+func (s *couponService) ListCoupons(ctx context.Context) ([]CouponResponse, error) {
+	ctx, span := tracer.Start(ctx, "CouponService.ListCoupons")
+	defer span.End()
+
+	coupons, err := guard(func() ([]models.Coupon, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve coupons: %w", err)
+	}
+	responses := make([]CouponResponse, len(coupons))
+	for i := range coupons {
+		responses[i] = *newCouponResponse(&coupons[i])
+	}
+	return responses, nil
+}
+
+// CreateCoupon validates and stores a new coupon
+func (s *couponService) CreateCoupon(ctx context.Context, req CouponRequest) (*CouponResponse, error) {
+	ctx, span := tracer.Start(ctx, "CouponService.CreateCoupon")
+	defer span.End()
+
+	coupon := &models.Coupon{IsActive: true}
+	if err := s.apply(ctx, coupon, req); err != nil {
+		return nil, err
+	}
+	err := guardExec(func() error { return s.repo.Create(ctx, coupon) })
+	if errors.Is(err, models.ErrCouponCodeTaken) {
+		return nil, fmt.Errorf("%w: %s", ErrCouponExists, coupon.Code)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create coupon: %w", err)
+	}
+	return newCouponResponse(coupon), nil
+}
+
+// UpdateCoupon replaces a coupon, keeping its usage count. Orders keep their discount.
+func (s *couponService) UpdateCoupon(ctx context.Context, id int, req CouponRequest) (*CouponResponse, error) {
+	ctx, span := tracer.Start(ctx, "CouponService.UpdateCoupon")
+	defer span.End()
+
+	ctx = database.UsePrimary(ctx)
+	coupon, err := guard(func() (*models.Coupon, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCouponNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find coupon %d: %w", id, err)
+	}
+	if err := s.apply(ctx, coupon, req); err != nil {
+		return nil, err
+	}
+	err = guardExec(func() error { return s.repo.Update(ctx, coupon) })
+	if errors.Is(err, models.ErrCouponCodeTaken) {
+		return nil, fmt.Errorf("%w: %s", ErrCouponExists, coupon.Code)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update coupon %d: %w", id, err)
+	}
+	return newCouponResponse(coupon), nil
+}
+
+// DeleteCoupon removes a coupon and its redemptions. Orders keep their discount and
+// coupon code.
+func (s *couponService) DeleteCoupon(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "CouponService.DeleteCoupon")
+	defer span.End()
+
+	_, err := guard(func() (*models.Coupon, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCouponNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find coupon %d: %w", id, err)
+	}
+	if err := guardExec(func() error { return s.repo.Delete(ctx, id) }); err != nil {
+		return fmt.Errorf("failed to delete coupon %d: %w", id, err)
+	}
+	return nil
+}
+
+// ListRedemptions returns the orders a coupon was redeemed on, newest first
+func (s *couponService) ListRedemptions(ctx context.Context, id int) ([]CouponRedemptionResponse, error) {
+	ctx, span := tracer.Start(ctx, "CouponService.ListRedemptions")
+	defer span.End()
+
+	_, err := guard(func() (*models.Coupon, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCouponNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find coupon %d: %w", id, err)
+	}
+	redemptions, err := guard(func() ([]models.CouponRedemption, error) { return s.repo.Redemptions(ctx, id) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve redemptions of coupon %d: %w", id, err)
+	}
+
+	responses := make([]CouponRedemptionResponse, len(redemptions))
+	for i, redemption := range redemptions {
+		responses[i] = CouponRedemptionResponse{
+			OrderID:       redemption.OrderID,
+			CustomerPhone: redemption.CustomerPhone,
+			Discount:      redemption.Discount,
+			RedeemedAt:    localTime(redemption.CreatedAt),
+		}
+	}
+	return responses, nil
+}
+
+// apply validates req and copies it onto coupon. Codes must be unique within the
+// restaurant regardless of case.
+func (s *couponService) apply(ctx context.Context, coupon *models.Coupon, req CouponRequest) error {
+	code := normalizeCouponCode(req.Code)
+	switch {
+	case code == "":
+		return fmt.Errorf("%w: code is required", ErrInvalidCoupon)
+	case len(code) > 50 || strings.ContainsAny(code, " \t\n"):
+		return fmt.Errorf("%w: code must be at most 50 characters without spaces", ErrInvalidCoupon)
+	case req.Category != nil && req.MenuItemID != nil:
+		return fmt.Errorf("%w: set at most one of category and menu_item_id", ErrInvalidCoupon)
+	case req.Category != nil && !ValidCategories[*req.Category]:
+		return fmt.Errorf("%w: category %s", ErrInvalidCoupon, categoryList())
+	case req.MaxUses != nil && *req.MaxUses < 1, req.MaxUsesPerCustomer != nil && *req.MaxUsesPerCustomer < 1:
+		return fmt.Errorf("%w: usage limits must be at least 1", ErrInvalidCoupon)
+	case req.ValidFrom != nil && req.ValidUntil != nil && !req.ValidUntil.After(*req.ValidFrom):
+		return fmt.Errorf("%w: valid_until must be after valid_from", ErrInvalidCoupon)
+	}
+	if problem := discountProblem(req.DiscountType, req.Discount); problem != "" {
+		return fmt.Errorf("%w: %s", ErrInvalidCoupon, problem)
+	}
+
+	if code != coupon.Code {
+		_, err := guard(func() (*models.Coupon, error) { return s.repo.FindByCode(database.UsePrimary(ctx), code) })
+		if err == nil {
+			return fmt.Errorf("%w: %s", ErrCouponExists, code)
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to look up coupon %s: %w", code, err)
+		}
+	}
+
+	coupon.Code = code
+	coupon.Description = req.Description
+	coupon.DiscountType = req.DiscountType
+	coupon.Discount = req.Discount
+	coupon.Category = req.Category
+	coupon.MenuItemID = req.MenuItemID
+	coupon.MaxUses = req.MaxUses
+	coupon.MaxUsesPerCustomer = req.MaxUsesPerCustomer
+	coupon.ValidFrom = req.ValidFrom
+	coupon.ValidUntil = req.ValidUntil
+	if req.IsActive != nil {
+		coupon.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// normalizeCouponCode returns the stored form of a coupon code
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// newCouponResponse converts a coupon model to its response DTO
+func newCouponResponse(coupon *models.Coupon) *CouponResponse {
+	response := &CouponResponse{
+		ID:                 coupon.ID,
+		Code:               coupon.Code,
+		Description:        coupon.Description,
+		DiscountType:       coupon.DiscountType,
+		Discount:           coupon.Discount,
+		Category:           coupon.Category,
+		MenuItemID:         coupon.MenuItemID,
+		MaxUses:            coupon.MaxUses,
+		MaxUsesPerCustomer: coupon.MaxUsesPerCustomer,
+		TimesUsed:          coupon.TimesUsed,
+		IsActive:           coupon.IsActive,
+		CreatedAt:          localTime(coupon.CreatedAt),
+		UpdatedAt:          localTime(coupon.UpdatedAt),
+	}
+	if coupon.ValidFrom != nil {
+		validFrom := localTime(*coupon.ValidFrom)
+		response.ValidFrom = &validFrom
+	}
+	if coupon.ValidUntil != nil {
+		validUntil := localTime(*coupon.ValidUntil)
+		response.ValidUntil = &validUntil
+	}
+	return response
+}
+
+// applyCoupon checks that the coupon with code can be redeemed on a new order at t
+// and spreads its discount over the order's eligible lines, whose menu categories are
+// given in categories. The redemption is recorded when the order is created.
+func (s *orderService) applyCoupon(ctx context.Context, order *models.Order, code string, categories []string, t time.Time) error {
+	ctx = database.UsePrimary(ctx)
+	code = normalizeCouponCode(code)
+	coupon, err := guard(func() (*models.Coupon, error) { return s.coupons.FindByCode(ctx, code) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: unknown code %s", ErrCouponNotRedeemable, code)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up coupon %s: %w", code, err)
+	}
+
+	switch {
+	case !coupon.IsActive:
+		return fmt.Errorf("%w: %s is not active", ErrCouponNotRedeemable, code)
+	case coupon.ValidFrom != nil && t.Before(*coupon.ValidFrom):
+		return fmt.Errorf("%w: %s is not valid yet", ErrCouponNotRedeemable, code)
+	case coupon.ValidUntil != nil && !t.Before(*coupon.ValidUntil):
+		return fmt.Errorf("%w: %s has expired", ErrCouponNotRedeemable, code)
+	case coupon.MaxUses != nil && coupon.TimesUsed >= *coupon.MaxUses:
+		return fmt.Errorf("%w: %s has been used up", ErrCouponNotRedeemable, code)
+	}
+	if coupon.MaxUsesPerCustomer != nil {
+		if order.CustomerPhone == nil {
+			return fmt.Errorf("%w: customer_phone is required to redeem %s", ErrCouponNotRedeemable, code)
+		}
+		used, err := guard(func() (int, error) { return s.coupons.CountRedemptions(ctx, coupon.ID, *order.CustomerPhone) })
+		if err != nil {
+			return fmt.Errorf("failed to count redemptions of coupon %s: %w", code, err)
+		}
+		if used >= *coupon.MaxUsesPerCustomer {
+			return fmt.Errorf("%w: the customer already redeemed %s", ErrCouponNotRedeemable, code)
+		}
+	}
+
+	discount := discountLines(coupon, order.Items, categories)
+	if !discount.IsPositive() {
+		return fmt.Errorf("%w: %s applies to none of the order's items", ErrCouponNotRedeemable, code)
+	}
+	order.CouponCode = &coupon.Code
+	order.Redemption = &models.CouponRedemption{
+		CouponID:      coupon.ID,
+		CustomerPhone: order.CustomerPhone,
+		Discount:      discount,
+	}
+	return nil
+}
+
+// discountLines adds a coupon's discount to the lines in its scope and returns the
+// total discount
+func discountLines(coupon *models.Coupon, items []models.OrderItem, categories []string) decimal.Decimal {
+	var lines []int
+	for i, item := range items {
+		if inScope(coupon.MenuItemID, coupon.Category, item, categories[i]) {
+			lines = append(lines, i)
+		}
+	}
+	return spreadDiscount(items, lines, coupon.DiscountType, coupon.Discount)
+}
+
+// inScope reports whether an order line of the given menu category is covered by a
+// coupon or promotion scoped to menuItemID or category; with neither, every line is
+func inScope(menuItemID *int, category *string, item models.OrderItem, itemCategory string) bool {
+	switch {
+	case menuItemID != nil:
+		return item.MenuItemID != nil && *item.MenuItemID == *menuItemID
+	case category != nil:
+		return itemCategory == *category
+	}
+	return true
+}
+
+// spreadDiscount adds a discount to the given lines and returns the total added. A
+// percentage applies to each line's discounted total. An amount, capped at the lines'
+// discounted total, is split between them in proportion to it.
+func spreadDiscount(items []models.OrderItem, lines []int, discountType string, discount decimal.Decimal) decimal.Decimal {
+	linesTotal := decimal.Zero
+	for _, i := range lines {
+		linesTotal = linesTotal.Add(items[i].DiscountedTotal())
+	}
+	if !linesTotal.IsPositive() {
+		return decimal.Zero
+	}
+
+	total := decimal.Zero
+	if discountType == models.DiscountPercent {
+		for _, i := range lines {
+			share := items[i].DiscountedTotal().Mul(discount).Div(decimal.NewFromInt(100)).Round(2)
+			items[i].Discount = items[i].Discount.Add(share)
+			total = total.Add(share)
+		}
+		return total
+	}
+
+	amount := decimal.Min(discount, linesTotal)
+	for n, i := range lines {
+		share := amount.Sub(total) // The last line gets the rounding remainder
+		if n < len(lines)-1 {
+			share = amount.Mul(items[i].DiscountedTotal()).Div(linesTotal).Round(2)
+		}
+		items[i].Discount = items[i].Discount.Add(share)
+		total = total.Add(share)
+	}
+	return total
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/circuitbreaker"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// racingCoupons is a coupon repository where another request takes every code
+// between the lookup and the insert
+type racingCoupons struct {
+	CouponRepository
+}
+
+func (racingCoupons) FindByCode(context.Context, string) (*models.Coupon, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (racingCoupons) Create(context.Context, *models.Coupon) error {
+	return models.ErrCouponCodeTaken
+}
+
+func TestCreateCouponRaceReportsExistingCode(t *testing.T) {
+	saved := dbBreaker
+	t.Cleanup(func() { dbBreaker = saved })
+	ConfigureCircuitBreaker(1, time.Minute)
+
+	service := NewCouponService(racingCoupons{})
+	req := CouponRequest{Code: "summer10", DiscountType: models.DiscountPercent, Discount: decimal.NewFromInt(10)}
+	if _, err := service.CreateCoupon(context.Background(), req); !errors.Is(err, ErrCouponExists) {
+		t.Fatalf("CreateCoupon() = %v, want %v", err, ErrCouponExists)
+	}
+	if state := dbBreaker.State(); state != circuitbreaker.StateClosed {
+		t.Fatalf("breaker %s after a taken code, want closed", state)
+	}
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// CustomerRepository abstracts the storage of customers' personal data
+type CustomerRepository interface {
+	Export(ctx context.Context, customerPhone string) (*models.CustomerData, error)
+	Erase(ctx context.Context, customerPhone, pseudonym string) (*models.CustomerErasure, error)
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ CustomerRepository = (*models.CustomerQuery)(nil)
+
+// CustomerService defines the data protection requests of customers: exporting the
+// personal data stored about them and erasing it
+type CustomerService interface {
+	ExportCustomer(ctx context.Context, customerPhone string) (*CustomerExportResponse, error)
+	EraseCustomer(ctx context.Context, customerPhone string) (*CustomerErasureResponse, error)
+}
+
+// ErrCustomerNotFound is returned when no personal data is stored about a customer
+var ErrCustomerNotFound = errors.New("customer not found")
+
+// CustomerExportResponse is all the personal data stored about a customer at the
+// restaurant, oldest first, with their loyalty points and store credit
+type CustomerExportResponse struct {
+	CustomerID        string                     `json:"customer_id" example:"+962791234567"`
+	ExportedAt        time.Time                  `json:"exported_at"`
+	Orders            []OrderResponse            `json:"orders"`
+	Carts             []CartResponse             `json:"carts"`
+	Reviews           []CustomerReviewResponse   `json:"reviews"`
+	CouponRedemptions []CouponRedemptionResponse `json:"coupon_redemptions"`
+	Loyalty           *LoyaltyResponse           `json:"loyalty"`
+	StoreCredit       *StoreCreditResponse       `json:"store_credit"`
+}
+
+// CustomerReviewResponse is a review a customer wrote, with the IP address it was
+// written from
+type CustomerReviewResponse struct {
+	ReviewResponse
+	ClientIP *string `json:"client_ip,omitempty" example:"203.0.113.7"`
+}
+
+// CustomerErasureResponse counts what erasing a customer's personal data anonymized.
+// Their loyalty and store credit accounts, when they had one, now belong to ErasedAs.
+type CustomerErasureResponse struct {
+	CustomerID        string  `json:"customer_id" example:"+962791234567"`
+	ErasedAs          *string `json:"erased_as,omitempty" example:"erased-5f0c2a9e7d1b"`
+	Orders            int     `json:"orders" example:"12"`
+	Carts             int     `json:"carts" example:"3"`
+	Reviews           int     `json:"reviews" example:"2"`
+	CouponRedemptions int     `json:"coupon_redemptions" example:"1"`
+}
+
+// customerService handles data protection requests
+type customerService struct {
+	repo CustomerRepository
+}
+
+// NewCustomerService creates a new customer service
+func NewCustomerService(repo CustomerRepository) CustomerService {
+	return &customerService{repo: repo}
+}
+
+// ExportCustomer returns the personal data stored about a customer, told apart by
+// phone number
+func (s *customerService) ExportCustomer(ctx context.Context, customerPhone string) (*CustomerExportResponse, error) {
+	ctx, span := tracer.Start(ctx, "CustomerService.ExportCustomer")
+	defer span.End()
+
+	customerPhone = strings.TrimSpace(customerPhone)
+	if customerPhone == "" {
+		return nil, fmt.Errorf("%w: no data is stored about a blank phone number", ErrCustomerNotFound)
+	}
+	data, err := guard(func() (*models.CustomerData, error) { return s.repo.Export(ctx, customerPhone) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to export data of %s: %w", customerPhone, err)
+	}
+	if data.Empty() {
+		return nil, fmt.Errorf("%w: no data is stored about %s", ErrCustomerNotFound, customerPhone)
+	}
+
+	response := &CustomerExportResponse{
+		CustomerID:        customerPhone,
+		ExportedAt:        localTime(time.Now()),
+		Orders:            make([]OrderResponse, len(data.Orders)),
+		Carts:             make([]CartResponse, len(data.Carts)),
+		Reviews:           make([]CustomerReviewResponse, len(data.Reviews)),
+		CouponRedemptions: make([]CouponRedemptionResponse, len(data.CouponRedemptions)),
+		Loyalty:           newLoyaltyResponse(customerPhone, data.Loyalty),
+		StoreCredit:       newStoreCreditResponse(customerPhone, data.StoreCredit),
+	}
+	for i := range data.Orders {
+		response.Orders[i] = *newOrderResponse(&data.Orders[i])
+	}
+	for i := range data.Carts {
+		response.Carts[i] = *newCartResponse(&data.Carts[i])
+	}
+	for i := range data.Reviews {
+		response.Reviews[i] = CustomerReviewResponse{
+			ReviewResponse: *newReviewResponse(&data.Reviews[i]),
+			ClientIP:       data.Reviews[i].ClientIP,
+		}
+	}
+	for i, redemption := range data.CouponRedemptions {
+		response.CouponRedemptions[i] = CouponRedemptionResponse{
+			OrderID:       redemption.OrderID,
+			CustomerPhone: redemption.CustomerPhone,
+			Discount:      redemption.Discount,
+			RedeemedAt:    localTime(redemption.CreatedAt),
+		}
+	}
+	return response, nil
+}
+
+// EraseCustomer anonymizes the personal data stored about a customer, keeping their
+// orders and their totals for reports. Loyalty points and store credit, which are
+// still owed, move to a random pseudonym rather than being lost.
+func (s *customerService) EraseCustomer(ctx context.Context, customerPhone string) (*CustomerErasureResponse, error) {
+	ctx, span := tracer.Start(ctx, "CustomerService.EraseCustomer")
+	defer span.End()
+
+	customerPhone = strings.TrimSpace(customerPhone)
+	if customerPhone == "" {
+		return nil, fmt.Errorf("%w: no data is stored about a blank phone number", ErrCustomerNotFound)
+	}
+	// Random, so the accounts can't be tied back to the phone number
+	pseudonym := "erased-" + strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	ctx = database.UsePrimary(ctx)
+	erasure, err := guard(func() (*models.CustomerErasure, error) { return s.repo.Erase(ctx, customerPhone, pseudonym) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase data of %s: %w", customerPhone, err)
+	}
+	if erasure.Empty() {
+		return nil, fmt.Errorf("%w: no data is stored about %s", ErrCustomerNotFound, customerPhone)
+	}
+
+	response := &CustomerErasureResponse{
+		CustomerID:        customerPhone,
+		Orders:            erasure.Orders,
+		Carts:             erasure.Carts,
+		Reviews:           erasure.Reviews,
+		CouponRedemptions: erasure.CouponRedemptions,
+	}
+	if erasure.LoyaltyAccount || erasure.StoreCreditAccount {
+		response.ErasedAs = &pseudonym
+	}
+	return response, nil
+}
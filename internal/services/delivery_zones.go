@@ -0,0 +1,364 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// DeliveryZoneRepository abstracts delivery zone storage
+type DeliveryZoneRepository interface {
+	List(ctx context.Context) ([]models.DeliveryZone, error)
+	Active(ctx context.Context) ([]models.DeliveryZone, error)
+	FindByID(ctx context.Context, id int) (*models.DeliveryZone, error)
+	FindByName(ctx context.Context, name string) (*models.DeliveryZone, error)
+	Create(ctx context.Context, zone *models.DeliveryZone) error
+	Update(ctx context.Context, zone *models.DeliveryZone) error
+	Delete(ctx context.Context, id int) error
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ DeliveryZoneRepository = (*models.DeliveryZoneQuery)(nil)
+
+// DeliveryZoneService defines business operations on delivery zones
+type DeliveryZoneService interface {
+	ListDeliveryZones(ctx context.Context) ([]DeliveryZoneResponse, error)
+	CreateDeliveryZone(ctx context.Context, req DeliveryZoneRequest) (*DeliveryZoneResponse, error)
+	UpdateDeliveryZone(ctx context.Context, id int, req DeliveryZoneRequest) (*DeliveryZoneResponse, error)
+	DeleteDeliveryZone(ctx context.Context, id int) error
+	CheckDelivery(ctx context.Context, req DeliveryCheckRequest) (*DeliveryCheckResponse, error)
+}
+
+// Delivery zone errors
+var (
+	ErrDeliveryZoneNotFound = errors.New("delivery zone not found")
+	ErrInvalidDeliveryZone  = errors.New("invalid delivery zone")
+	// ErrDeliveryZoneExists is returned when another zone has the same name
+	ErrDeliveryZoneExists = errors.New("delivery zone already exists")
+	// ErrInvalidDeliveryCheck is returned for coordinates that aren't on the globe or a
+	// negative order total
+	ErrInvalidDeliveryCheck = errors.New("invalid delivery check")
+	// ErrNotDeliverable is returned for a location outside every active delivery zone
+	ErrNotDeliverable = errors.New("location is outside the delivery zones")
+)
+
+// Delivery zone limits
+const (
+	maxDeliveryZoneNameLength = 100
+	maxDeliveryZonePoints     = 500
+)
+
+// earthRadiusMeters is the mean radius of the Earth
+const earthRadiusMeters = 6371008.8
+
+// DeliveryZoneRequest creates or replaces a delivery zone. Set Center and
+// RadiusMeters for a circle, or Polygon, with at least three points in order, for any
+// other shape.
+type DeliveryZoneRequest struct {
+	Name         string            `json:"name" example:"Downtown"`
+	Center       *models.GeoPoint  `json:"center,omitempty"`
+	RadiusMeters *int              `json:"radius_meters,omitempty" example:"3000"`
+	Polygon      []models.GeoPoint `json:"polygon,omitempty"`
+	Fee          decimal.Decimal   `json:"fee" swaggertype:"string" example:"2.50"`
+	MinimumOrder decimal.Decimal   `json:"minimum_order" swaggertype:"string" example:"10.00"`
+	// Defaults to true
+	IsActive *bool `json:"is_active,omitempty"`
+}
+
+// DeliveryZoneResponse represents the delivery zone data returned to clients
+type DeliveryZoneResponse struct {
+	ID           int               `json:"id" example:"1"`
+	Name         string            `json:"name" example:"Downtown"`
+	Center       *models.GeoPoint  `json:"center,omitempty"`
+	RadiusMeters *int              `json:"radius_meters,omitempty" example:"3000"`
+	Polygon      []models.GeoPoint `json:"polygon,omitempty"`
+	Fee          decimal.Decimal   `json:"fee" swaggertype:"string" example:"2.50"`
+	MinimumOrder decimal.Decimal   `json:"minimum_order" swaggertype:"string" example:"10.00"`
+	IsActive     bool              `json:"is_active"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+// DeliveryCheckRequest asks whether the restaurant delivers to a location, geocoded
+// from the customer's address, and for an order of OrderTotal when given
+type DeliveryCheckRequest struct {
+	Latitude   float64          `json:"latitude" example:"31.9539"`
+	Longitude  float64          `json:"longitude" example:"35.9106"`
+	OrderTotal *decimal.Decimal `json:"order_total,omitempty" swaggertype:"string" example:"18.00"`
+}
+
+// DeliveryCheckResponse is the delivery zone of a location and what delivering there
+// costs. MeetsMinimum and Shortfall are only set when the order total was given.
+type DeliveryCheckResponse struct {
+	ZoneID       int             `json:"zone_id" example:"1"`
+	Zone         string          `json:"zone" example:"Downtown"`
+	Fee          decimal.Decimal `json:"fee" swaggertype:"string" example:"2.50"`
+	MinimumOrder decimal.Decimal `json:"minimum_order" swaggertype:"string" example:"10.00"`
+	// Distance from the center of a circle zone
+	DistanceMeters *int `json:"distance_meters,omitempty" example:"1840"`
+	// Whether the order reaches the zone's minimum, and how much it is short
+	MeetsMinimum *bool           `json:"meets_minimum,omitempty"`
+	Shortfall    decimal.Decimal `json:"shortfall" swaggertype:"string" example:"0.00"`
+}
+
+// deliveryZoneService handles business logic for delivery zones
+type deliveryZoneService struct {
+	repo DeliveryZoneRepository
+}
+
+// NewDeliveryZoneService creates a new delivery zone service
+func NewDeliveryZoneService(repo DeliveryZoneRepository) DeliveryZoneService {
+	return &deliveryZoneService{repo: repo}
+}
+
+// ListDeliveryZones returns all delivery zones by name
+func (s *deliveryZoneService) ListDeliveryZones(ctx context.Context) ([]DeliveryZoneResponse, error) {
+	ctx, span := tracer.Start(ctx, "DeliveryZoneService.ListDeliveryZones")
+	defer span.End()
+
+	zones, err := guard(func() ([]models.DeliveryZone, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve delivery zones: %w", err)
+	}
+	responses := make([]DeliveryZoneResponse, len(zones))
+	for i := range zones {
+		responses[i] = *newDeliveryZoneResponse(&zones[i])
+	}
+	return responses, nil
+}
+
+// CreateDeliveryZone validates and stores a new delivery zone
+func (s *deliveryZoneService) CreateDeliveryZone(ctx context.Context, req DeliveryZoneRequest) (*DeliveryZoneResponse, error) {
+	ctx, span := tracer.Start(ctx, "DeliveryZoneService.CreateDeliveryZone")
+	defer span.End()
+
+	zone := &models.DeliveryZone{}
+	if err := s.apply(ctx, zone, req); err != nil {
+		return nil, err
+	}
+	err := guardExec(func() error { return s.repo.Create(ctx, zone) })
+	if errors.Is(err, models.ErrDeliveryZoneNameTaken) {
+		return nil, fmt.Errorf("%w: %q", ErrDeliveryZoneExists, zone.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create delivery zone: %w", err)
+	}
+	return newDeliveryZoneResponse(zone), nil
+}
+
+// UpdateDeliveryZone replaces a delivery zone
+func (s *deliveryZoneService) UpdateDeliveryZone(ctx context.Context, id int, req DeliveryZoneRequest) (*DeliveryZoneResponse, error) {
+	ctx, span := tracer.Start(ctx, "DeliveryZoneService.UpdateDeliveryZone")
+	defer span.End()
+
+	zone, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, zone, req); err != nil {
+		return nil, err
+	}
+	err = guardExec(func() error { return s.repo.Update(ctx, zone) })
+	if errors.Is(err, models.ErrDeliveryZoneNameTaken) {
+		return nil, fmt.Errorf("%w: %q", ErrDeliveryZoneExists, zone.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update delivery zone %d: %w", id, err)
+	}
+	return newDeliveryZoneResponse(zone), nil
+}
+
+// DeleteDeliveryZone removes a delivery zone
+func (s *deliveryZoneService) DeleteDeliveryZone(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "DeliveryZoneService.DeleteDeliveryZone")
+	defer span.End()
+
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	if err := guardExec(func() error { return s.repo.Delete(ctx, id) }); err != nil {
+		return fmt.Errorf("failed to delete delivery zone %d: %w", id, err)
+	}
+	return nil
+}
+
+// CheckDelivery finds the active delivery zone of a location and its fee. Where zones
+// overlap, the one with the lowest fee applies, e.g. an inner circle delivered to for
+// less than the ring around it.
+func (s *deliveryZoneService) CheckDelivery(ctx context.Context, req DeliveryCheckRequest) (*DeliveryCheckResponse, error) {
+	ctx, span := tracer.Start(ctx, "DeliveryZoneService.CheckDelivery")
+	defer span.End()
+
+	point := models.GeoPoint{Lat: req.Latitude, Lng: req.Longitude}
+	if !validGeoPoint(point) {
+		return nil, fmt.Errorf("%w: latitude must be between -90 and 90 and longitude between -180 and 180", ErrInvalidDeliveryCheck)
+	}
+	if req.OrderTotal != nil && req.OrderTotal.IsNegative() {
+		return nil, fmt.Errorf("%w: order_total must not be negative", ErrInvalidDeliveryCheck)
+	}
+
+	zones, err := guard(func() ([]models.DeliveryZone, error) { return s.repo.Active(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve delivery zones: %w", err)
+	}
+	var match *models.DeliveryZone
+	var distance *int
+	for i := range zones {
+		zone := &zones[i]
+		inside, meters := zoneContains(zone, point)
+		if inside && (match == nil || zone.Fee.LessThan(match.Fee)) {
+			match, distance = zone, meters
+		}
+	}
+	if match == nil {
+		return nil, ErrNotDeliverable
+	}
+
+	response := &DeliveryCheckResponse{
+		ZoneID:         match.ID,
+		Zone:           match.Name,
+		Fee:            match.Fee,
+		MinimumOrder:   match.MinimumOrder,
+		DistanceMeters: distance,
+		Shortfall:      decimal.Zero,
+	}
+	if req.OrderTotal != nil {
+		meets := !req.OrderTotal.LessThan(match.MinimumOrder)
+		response.MeetsMinimum = &meets
+		if !meets {
+			response.Shortfall = match.MinimumOrder.Sub(*req.OrderTotal)
+		}
+	}
+	return response, nil
+}
+
+// find loads a delivery zone by ID
+func (s *deliveryZoneService) find(ctx context.Context, id int) (*models.DeliveryZone, error) {
+	zone, err := guard(func() (*models.DeliveryZone, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeliveryZoneNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find delivery zone %d: %w", id, err)
+	}
+	return zone, nil
+}
+
+// apply validates req and copies it onto zone. No other zone may have the name.
+func (s *deliveryZoneService) apply(ctx context.Context, zone *models.DeliveryZone, req DeliveryZoneRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	circle := req.Center != nil || req.RadiusMeters != nil
+	switch {
+	case req.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidDeliveryZone)
+	case len(req.Name) > maxDeliveryZoneNameLength:
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidDeliveryZone, maxDeliveryZoneNameLength)
+	case req.Fee.IsNegative():
+		return fmt.Errorf("%w: fee must not be negative", ErrInvalidDeliveryZone)
+	case req.MinimumOrder.IsNegative():
+		return fmt.Errorf("%w: minimum_order must not be negative", ErrInvalidDeliveryZone)
+	case circle == (len(req.Polygon) > 0):
+		return fmt.Errorf("%w: set either center and radius_meters or polygon", ErrInvalidDeliveryZone)
+	case circle && (req.Center == nil || req.RadiusMeters == nil):
+		return fmt.Errorf("%w: a circle zone needs both center and radius_meters", ErrInvalidDeliveryZone)
+	case circle && !validGeoPoint(*req.Center):
+		return fmt.Errorf("%w: center must have a latitude between -90 and 90 and a longitude between -180 and 180", ErrInvalidDeliveryZone)
+	case circle && *req.RadiusMeters < 1:
+		return fmt.Errorf("%w: radius_meters must be at least 1", ErrInvalidDeliveryZone)
+	case !circle && (len(req.Polygon) < 3 || len(req.Polygon) > maxDeliveryZonePoints):
+		return fmt.Errorf("%w: polygon must have between 3 and %d points", ErrInvalidDeliveryZone, maxDeliveryZonePoints)
+	}
+	for i, point := range req.Polygon {
+		if !validGeoPoint(point) {
+			return fmt.Errorf("%w: polygon point %d must have a latitude between -90 and 90 and a longitude between -180 and 180", ErrInvalidDeliveryZone, i+1)
+		}
+	}
+
+	other, err := guard(func() (*models.DeliveryZone, error) { return s.repo.FindByName(ctx, req.Name) })
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return fmt.Errorf("failed to look up delivery zone %q: %w", req.Name, err)
+	case other.ID != zone.ID:
+		return fmt.Errorf("%w: %q", ErrDeliveryZoneExists, req.Name)
+	}
+
+	zone.Name = req.Name
+	zone.CenterLat, zone.CenterLng, zone.RadiusMeters, zone.Polygon = nil, nil, nil, nil
+	if circle {
+		zone.CenterLat, zone.CenterLng = &req.Center.Lat, &req.Center.Lng
+		zone.RadiusMeters = req.RadiusMeters
+	} else {
+		zone.Polygon = req.Polygon
+	}
+	zone.Fee = req.Fee.Round(2)
+	zone.MinimumOrder = req.MinimumOrder.Round(2)
+	zone.IsActive = req.IsActive == nil || *req.IsActive
+	return nil
+}
+
+// validGeoPoint reports whether a point's coordinates are on the globe
+func validGeoPoint(p models.GeoPoint) bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lng >= -180 && p.Lng <= 180
+}
+
+// zoneContains reports whether a zone contains a point and, for a circle zone, how
+// far the point is from its center
+func zoneContains(zone *models.DeliveryZone, p models.GeoPoint) (bool, *int) {
+	if zone.RadiusMeters != nil && zone.CenterLat != nil && zone.CenterLng != nil {
+		meters := distanceMeters(models.GeoPoint{Lat: *zone.CenterLat, Lng: *zone.CenterLng}, p)
+		rounded := int(math.Round(meters))
+		return meters <= float64(*zone.RadiusMeters), &rounded
+	}
+	return polygonContains(zone.Polygon, p), nil
+}
+
+// distanceMeters returns the great-circle distance between two points
+func distanceMeters(a, b models.GeoPoint) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat, dLng := lat2-lat1, (b.Lng-a.Lng)*math.Pi/180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// polygonContains reports whether a point is inside a polygon, by casting a ray from
+// it and counting the edges crossed. Treating degrees as plane coordinates is exact
+// enough over the few kilometers a delivery zone spans.
+func polygonContains(polygon []models.GeoPoint, p models.GeoPoint) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) &&
+			p.Lng < (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// newDeliveryZoneResponse converts a delivery zone to its response
+func newDeliveryZoneResponse(zone *models.DeliveryZone) *DeliveryZoneResponse {
+	response := &DeliveryZoneResponse{
+		ID:           zone.ID,
+		Name:         zone.Name,
+		RadiusMeters: zone.RadiusMeters,
+		Polygon:      zone.Polygon,
+		Fee:          zone.Fee,
+		MinimumOrder: zone.MinimumOrder,
+		IsActive:     zone.IsActive,
+		CreatedAt:    localTime(zone.CreatedAt),
+		UpdatedAt:    localTime(zone.UpdatedAt),
+	}
+	if zone.CenterLat != nil && zone.CenterLng != nil {
+		response.Center = &models.GeoPoint{Lat: *zone.CenterLat, Lng: *zone.CenterLng}
+	}
+	return response
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/circuitbreaker"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// racingDeliveryZones is a delivery zone repository where another request takes
+// every name between the lookup and the insert
+type racingDeliveryZones struct {
+	DeliveryZoneRepository
+}
+
+func (racingDeliveryZones) FindByName(context.Context, string) (*models.DeliveryZone, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (racingDeliveryZones) Create(context.Context, *models.DeliveryZone) error {
+	return models.ErrDeliveryZoneNameTaken
+}
+
+func TestCreateDeliveryZoneRaceReportsExistingName(t *testing.T) {
+	saved := dbBreaker
+	t.Cleanup(func() { dbBreaker = saved })
+	ConfigureCircuitBreaker(1, time.Minute)
+
+	service := NewDeliveryZoneService(racingDeliveryZones{})
+	radius := 3000
+	req := DeliveryZoneRequest{
+		Name:         "Downtown",
+		Center:       &models.GeoPoint{Lat: 31.9539, Lng: 35.9106},
+		RadiusMeters: &radius,
+		Fee:          decimal.NewFromInt(2),
+		MinimumOrder: decimal.NewFromInt(10),
+	}
+	if _, err := service.CreateDeliveryZone(context.Background(), req); !errors.Is(err, ErrDeliveryZoneExists) {
+		t.Fatalf("CreateDeliveryZone() = %v, want %v", err, ErrDeliveryZoneExists)
+	}
+	if state := dbBreaker.State(); state != circuitbreaker.StateClosed {
+		t.Fatalf("breaker %s after a taken name, want closed", state)
+	}
+}
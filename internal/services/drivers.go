@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/realtime"
+)
+
+// DriverRepository abstracts driver storage
+type DriverRepository interface {
+	List(ctx context.Context) ([]models.Driver, error)
+	FindByID(ctx context.Context, id int) (*models.Driver, error)
+	Create(ctx context.Context, driver *models.Driver) error
+	Update(ctx context.Context, driver *models.Driver) error
+	Delete(ctx context.Context, id int) error
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ DriverRepository = (*models.DriverQuery)(nil)
+
+// DriverService defines business operations on drivers and the delivery of orders
+type DriverService interface {
+	ListDrivers(ctx context.Context) ([]DriverResponse, error)
+	CreateDriver(ctx context.Context, req DriverRequest) (*DriverResponse, error)
+	UpdateDriver(ctx context.Context, id int, req DriverRequest) (*DriverResponse, error)
+	DeleteDriver(ctx context.Context, id int) error
+	GetDriverOrders(ctx context.Context, id int) ([]OrderResponse, error)
+	AssignDriver(ctx context.Context, orderID string, req AssignDriverRequest) (*OrderResponse, error)
+	UpdateDeliveryStatus(ctx context.Context, orderID string, req DeliveryStatusRequest) (*OrderResponse, error)
+}
+
+// EventOrderDeliveryUpdated is published when a delivery order is assigned to a
+// driver, picked up or delivered
+const EventOrderDeliveryUpdated = "order.delivery_updated"
+
+// Driver and delivery errors
+var (
+	ErrDriverNotFound = errors.New("driver not found")
+	ErrInvalidDriver  = errors.New("invalid driver")
+	// ErrDriverBusy is returned when deleting a driver with orders still to deliver
+	ErrDriverBusy = errors.New("driver has orders to deliver")
+	// ErrInvalidDelivery is returned for an order that isn't delivered, an inactive
+	// driver or an unknown delivery status
+	ErrInvalidDelivery = errors.New("invalid delivery")
+	// ErrDeliveryStatus is returned when an order's delivery can't move to the
+	// requested step from the one it is at, or the order was closed
+	ErrDeliveryStatus = errors.New("delivery status conflict")
+)
+
+// maxDriverNameLength and maxDriverPhoneLength match the drivers table's columns
+const (
+	maxDriverNameLength  = 100
+	maxDriverPhoneLength = 50
+)
+
+// DriverRequest creates or replaces a driver
+type DriverRequest struct {
+	Name  string  `json:"name" example:"Omar"`
+	Phone *string `json:"phone,omitempty" example:"+962791234567"`
+	// Defaults to true; inactive drivers aren't assigned orders
+	IsActive *bool `json:"is_active,omitempty"`
+}
+
+// DriverResponse represents the driver data returned to clients
+type DriverResponse struct {
+	ID        int       `json:"id" example:"4"`
+	Name      string    `json:"name" example:"Omar"`
+	Phone     *string   `json:"phone,omitempty" example:"+962791234567"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AssignDriverRequest gives a delivery order to a driver
+type AssignDriverRequest struct {
+	DriverID int `json:"driver_id" example:"4"`
+}
+
+// DeliveryStatusRequest moves a delivery order to its next step: picked_up once its
+// driver has it, then delivered
+type DeliveryStatusRequest struct {
+	Status string `json:"status" example:"picked_up" enums:"picked_up,delivered"`
+}
+
+// OrderDeliveryResponse is the delivery of an order, sent to real-time clients when it
+// moves to another driver or step
+type OrderDeliveryResponse struct {
+	OrderID        string    `json:"order_id" example:"0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"`
+	Number         *int      `json:"number,omitempty" example:"42"`
+	Status         string    `json:"status" example:"preparing"`
+	DeliveryStatus string    `json:"delivery_status" example:"picked_up"`
+	DriverID       *int      `json:"driver_id,omitempty" example:"4"`
+	Driver         *string   `json:"driver,omitempty" example:"Omar"`
+	At             time.Time `json:"at"`
+}
+
+// driverService handles business logic for drivers and deliveries
+type driverService struct {
+	repo   DriverRepository
+	orders OrderRepository
+	events EventPublisher
+}
+
+// NewDriverService creates a new driver service. Orders are assigned and delivered in
+// orders, and their deliveries published to events.
+func NewDriverService(repo DriverRepository, orders OrderRepository, events EventPublisher) DriverService {
+	return &driverService{repo: repo, orders: orders, events: events}
+}
+
+// ListDrivers returns all drivers by name
+func (s *driverService) ListDrivers(ctx context.Context) ([]DriverResponse, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.ListDrivers")
+	defer span.End()
+
+	drivers, err := guard(func() ([]models.Driver, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve drivers: %w", err)
+	}
+	responses := make([]DriverResponse, len(drivers))
+	for i := range drivers {
+		responses[i] = *newDriverResponse(&drivers[i])
+	}
+	return responses, nil
+}
+
+// CreateDriver validates and stores a new driver
+func (s *driverService) CreateDriver(ctx context.Context, req DriverRequest) (*DriverResponse, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.CreateDriver")
+	defer span.End()
+
+	driver := &models.Driver{}
+	if err := applyDriver(driver, req); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Create(ctx, driver) }); err != nil {
+		return nil, fmt.Errorf("failed to create driver: %w", err)
+	}
+	return newDriverResponse(driver), nil
+}
+
+// UpdateDriver replaces a driver. Orders already assigned to a driver made inactive
+// stay with them.
+func (s *driverService) UpdateDriver(ctx context.Context, id int, req DriverRequest) (*DriverResponse, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.UpdateDriver")
+	defer span.End()
+
+	driver, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyDriver(driver, req); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Update(ctx, driver) }); err != nil {
+		return nil, fmt.Errorf("failed to update driver %d: %w", id, err)
+	}
+	return newDriverResponse(driver), nil
+}
+
+// DeleteDriver removes a driver with no orders left to deliver. Their past deliveries
+// keep no driver.
+func (s *driverService) DeleteDriver(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "DriverService.DeleteDriver")
+	defer span.End()
+
+	ctx = database.UsePrimary(ctx)
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	orders, err := guard(func() ([]models.Order, error) { return s.orders.DriverOrders(ctx, id) })
+	if err != nil {
+		return fmt.Errorf("failed to retrieve orders of driver %d: %w", id, err)
+	}
+	if len(orders) > 0 {
+		return fmt.Errorf("%w: reassign or deliver their %d orders first", ErrDriverBusy, len(orders))
+	}
+	if err := guardExec(func() error { return s.repo.Delete(ctx, id) }); err != nil {
+		return fmt.Errorf("failed to delete driver %d: %w", id, err)
+	}
+	return nil
+}
+
+// GetDriverOrders returns the orders a driver has yet to deliver, in the order they
+// were assigned
+func (s *driverService) GetDriverOrders(ctx context.Context, id int) ([]OrderResponse, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.GetDriverOrders")
+	defer span.End()
+
+	if _, err := s.find(ctx, id); err != nil {
+		return nil, err
+	}
+	orders, err := guard(func() ([]models.Order, error) { return s.orders.DriverOrders(ctx, id) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve orders of driver %d: %w", id, err)
+	}
+	responses := make([]OrderResponse, len(orders))
+	for i := range orders {
+		responses[i] = *newOrderResponse(&orders[i])
+	}
+	return responses, nil
+}
+
+// AssignDriver gives an open delivery order to an active driver, or to another driver
+// until it is picked up
+func (s *driverService) AssignDriver(ctx context.Context, orderID string, req AssignDriverRequest) (*OrderResponse, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.AssignDriver")
+	defer span.End()
+
+	ctx = database.UsePrimary(ctx)
+	if _, err := s.deliveryOrder(ctx, orderID); err != nil {
+		return nil, err
+	}
+	driver, err := s.find(ctx, req.DriverID)
+	if errors.Is(err, ErrDriverNotFound) {
+		return nil, fmt.Errorf("%w: driver %d does not exist", ErrInvalidDelivery, req.DriverID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !driver.IsActive {
+		return nil, fmt.Errorf("%w: driver %s is inactive", ErrInvalidDelivery, driver.Name)
+	}
+
+	err = guardExec(func() error { return s.orders.AssignDriver(ctx, orderID, driver.ID, time.Now()) })
+	if errors.Is(err, models.ErrDeliveryStatusChanged) {
+		return nil, fmt.Errorf("%w: the order was already picked up or is closed", ErrDeliveryStatus)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign order %s: %w", orderID, err)
+	}
+	return s.published(ctx, orderID)
+}
+
+// UpdateDeliveryStatus moves a delivery order from assigned to picked_up, or from
+// picked_up to delivered, which completes the order
+func (s *driverService) UpdateDeliveryStatus(ctx context.Context, orderID string, req DeliveryStatusRequest) (*OrderResponse, error) {
+	ctx, span := tracer.Start(ctx, "DriverService.UpdateDeliveryStatus")
+	defer span.End()
+
+	var update func(ctx context.Context, orderID string, at time.Time) error
+	var from string
+	switch req.Status {
+	case models.DeliveryStatusPickedUp:
+		update, from = s.orders.PickUp, models.DeliveryStatusAssigned
+	case models.DeliveryStatusDelivered:
+		update, from = s.orders.Deliver, models.DeliveryStatusPickedUp
+	default:
+		return nil, fmt.Errorf("%w: status must be %s or %s", ErrInvalidDelivery, models.DeliveryStatusPickedUp, models.DeliveryStatusDelivered)
+	}
+
+	ctx = database.UsePrimary(ctx)
+	if _, err := s.deliveryOrder(ctx, orderID); err != nil {
+		return nil, err
+	}
+	err := guardExec(func() error { return update(ctx, orderID, time.Now()) })
+	if errors.Is(err, models.ErrDeliveryStatusChanged) {
+		return nil, fmt.Errorf("%w: only a %s order that is still open can be %s", ErrDeliveryStatus, strings.ReplaceAll(from, "_", " "),
+			strings.ReplaceAll(req.Status, "_", " "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update delivery of order %s: %w", orderID, err)
+	}
+	return s.published(ctx, orderID)
+}
+
+// deliveryOrder loads an order and checks that it is delivered
+func (s *driverService) deliveryOrder(ctx context.Context, orderID string) (*models.Order, error) {
+	order, err := guard(func() (*models.Order, error) { return s.orders.FindByID(ctx, orderID) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order %s: %w", orderID, err)
+	}
+	if order.Channel != models.OrderChannelDelivery {
+		return nil, fmt.Errorf("%w: order %s is a %s order", ErrInvalidDelivery, orderID, order.Channel)
+	}
+	return order, nil
+}
+
+// published loads an order whose delivery changed and publishes its delivery
+func (s *driverService) published(ctx context.Context, orderID string) (*OrderResponse, error) {
+	order, err := guard(func() (*models.Order, error) { return s.orders.FindByID(ctx, orderID) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order %s: %w", orderID, err)
+	}
+	response := newOrderResponse(order)
+	if s.events != nil && response.DeliveryStatus != nil {
+		delivery := OrderDeliveryResponse{
+			OrderID:        response.ID,
+			Number:         response.Number,
+			Status:         response.Status,
+			DeliveryStatus: *response.DeliveryStatus,
+			DriverID:       response.DriverID,
+			Driver:         response.Driver,
+			At:             response.UpdatedAt,
+		}
+		s.events.Publish(realtime.Event{Type: EventOrderDeliveryUpdated, Data: delivery, Restaurant: database.RestaurantID(ctx)})
+	}
+	return response, nil
+}
+
+// find loads a driver by ID
+func (s *driverService) find(ctx context.Context, id int) (*models.Driver, error) {
+	driver, err := guard(func() (*models.Driver, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDriverNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find driver %d: %w", id, err)
+	}
+	return driver, nil
+}
+
+// applyDriver validates req and copies it onto driver
+func applyDriver(driver *models.Driver, req DriverRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Phone != nil {
+		phone := strings.TrimSpace(*req.Phone)
+		req.Phone = &phone
+		if phone == "" {
+			req.Phone = nil
+		}
+	}
+	switch {
+	case req.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidDriver)
+	case len(req.Name) > maxDriverNameLength:
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidDriver, maxDriverNameLength)
+	case req.Phone != nil && len(*req.Phone) > maxDriverPhoneLength:
+		return fmt.Errorf("%w: phone must be at most %d characters", ErrInvalidDriver, maxDriverPhoneLength)
+	}
+	driver.Name = req.Name
+	driver.Phone = req.Phone
+	driver.IsActive = req.IsActive == nil || *req.IsActive
+	return nil
+}
+
+// newDriverResponse converts a driver model to its response
+func newDriverResponse(driver *models.Driver) *DriverResponse {
+	return &DriverResponse{
+		ID:        driver.ID,
+		Name:      driver.Name,
+		Phone:     driver.Phone,
+		IsActive:  driver.IsActive,
+		CreatedAt: localTime(driver.CreatedAt),
+		UpdatedAt: localTime(driver.UpdatedAt),
+	}
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/realtime"
+)
+
+// Kitchen progress events
+const (
+	EventTicketPrepared  = "ticket.prepared"
+	EventOrderETAUpdated = "order.eta_updated"
+)
+
+// Kitchen ticket errors
+var (
+	ErrTicketNotFound = errors.New("ticket not found")
+	ErrTicketPrepared = errors.New("ticket already prepared")
+)
+
+// OrderETAResponse is the estimated ready time of an order, sent to real-time clients
+// when it changes
+type OrderETAResponse struct {
+	OrderID          string    `json:"order_id" example:"0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"`
+	EstimatedReadyAt time.Time `json:"estimated_ready_at"`
+}
+
+// readyEstimate returns when the lines of an order still to prepare should be ready,
+// given the number of orders queued ahead of it at each station. A station works
+// through the orders ahead of it, taking the kitchen's queue delay for each, and then
+// prepares the order's lines side by side, as long as its slowest line takes; the
+// order is ready when its last station is done.
+func readyEstimate(items []models.OrderItem, queues map[string]int, now time.Time) time.Time {
+	var longest time.Duration
+	for _, item := range items {
+		if item.PreparedAt != nil {
+			continue
+		}
+		station := kitchen.Default
+		if item.Station != nil {
+			station = *item.Station
+		}
+		wait := time.Duration(queues[station])*kitchen.QueueDelay + time.Duration(item.PrepMinutes)*time.Minute
+		longest = max(longest, wait)
+	}
+	return now.Add(longest)
+}
+
+// estimateReady sets when a new order should be ready, queued behind every order the
+// kitchen is still preparing. Orders for a later time slot are prepared when their
+// slot starts, as the queue then is unknown.
+func (s *orderService) estimateReady(ctx context.Context, order *models.Order) error {
+	if now := time.Now(); order.SlotAt != nil && order.SlotAt.After(now) {
+		readyAt := readyEstimate(order.Items, nil, *order.SlotAt)
+		order.EstimatedReadyAt = &readyAt
+		return nil
+	}
+
+	queues, err := guard(func() (map[string]int, error) { return s.repo.StationQueues(ctx, time.Time{}) })
+	if err != nil {
+		return fmt.Errorf("failed to retrieve kitchen queues: %w", err)
+	}
+	readyAt := readyEstimate(order.Items, queues, time.Now())
+	order.EstimatedReadyAt = &readyAt
+	return nil
+}
+
+// PrepareTicket marks the ticket of an order at a station as prepared. The station's
+// queue moves up, so the order and those queued behind it at the station are
+// estimated again; estimates only ever move earlier. The ticket and every estimate
+// that changed are published to real-time clients.
+func (s *orderService) PrepareTicket(ctx context.Context, id, station string) (*KitchenTicketResponse, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.PrepareTicket")
+	defer span.End()
+
+	if !kitchen.valid(station) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStation, stationProblem(station))
+	}
+	ctx = database.UsePrimary(ctx)
+	order, err := s.GetOrderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var itemIDs []int
+	for _, item := range order.Items {
+		if ticketStation(item) == station {
+			itemIDs = append(itemIDs, item.ID)
+		}
+	}
+	if len(itemIDs) == 0 {
+		return nil, fmt.Errorf("%w: the order has no items for %s", ErrTicketNotFound, station)
+	}
+
+	err = guardExec(func() error { return s.repo.PrepareItems(ctx, id, itemIDs, time.Now()) })
+	if errors.Is(err, models.ErrTicketPrepared) {
+		return nil, fmt.Errorf("%w: %s already prepared its items of the order", ErrTicketPrepared, station)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare ticket: %w", err)
+	}
+
+	prepared, err := guard(func() (*models.Order, error) { return s.repo.FindByID(ctx, id) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order %s: %w", id, err)
+	}
+	tickets := kitchenTickets(newOrderResponse(prepared))
+	ticket := tickets[slices.IndexFunc(tickets, func(t KitchenTicketResponse) bool { return t.Station == station })]
+	if s.events != nil {
+		s.events.Publish(realtime.Event{Type: EventTicketPrepared, Station: station, Data: ticket, Restaurant: database.RestaurantID(ctx)})
+	}
+
+	queued, err := guard(func() ([]models.Order, error) { return s.repo.QueuedAt(ctx, station, prepared.CreatedAt) })
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to load the orders queued at the station",
+			slog.String("station", station), slog.String("error", err.Error()))
+	}
+	if prepared.InKitchen(time.Now()) {
+		queued = append([]models.Order{*prepared}, queued...)
+	}
+	s.reestimate(ctx, queued)
+	return &ticket, nil
+}
+
+// reestimate estimates again when orders should be ready, queued behind the orders
+// placed before them, and records and publishes the estimates that moved earlier.
+// Failures are logged: the ticket has already been prepared.
+func (s *orderService) reestimate(ctx context.Context, orders []models.Order) {
+	logger := logging.FromContext(ctx)
+	now := time.Now()
+	for _, order := range orders {
+		queues, err := guard(func() (map[string]int, error) { return s.repo.StationQueues(ctx, order.CreatedAt) })
+		if err != nil {
+			logger.Error("Failed to retrieve kitchen queues", slog.String("error", err.Error()))
+			return
+		}
+		readyAt := readyEstimate(order.Items, queues, now)
+		if order.EstimatedReadyAt != nil && !readyAt.Before(*order.EstimatedReadyAt) {
+			continue
+		}
+		if err := guardExec(func() error { return s.repo.SetReadyEstimate(ctx, order.ID, readyAt) }); err != nil {
+			logger.Error("Failed to update order estimate", slog.String("order_id", order.ID), slog.String("error", err.Error()))
+			continue
+		}
+		s.publish(ctx, EventOrderETAUpdated, OrderETAResponse{OrderID: order.ID, EstimatedReadyAt: localTime(readyAt)})
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"strings"
+)
+
+// MenuItemExpanded holds the sub-resources embedded in a menu item with ?expand=
+type MenuItemExpanded struct {
+	Category *CategoryResponse `json:"category,omitempty"`
+}
+
+// CategoryResponse represents a menu category embedded in a menu item
+type CategoryResponse struct {
+	Name  string `json:"name" example:"fast food"`
+	Label string `json:"label" example:"Fast Food"`
+}
+
+// MenuItemExpansions maps the names clients may pass in ?expand= to the function that
+// embeds the sub-resource in a batch of responses. Add an entry here when a menu item
+// sub-resource (e.g. modifiers or translations) gets its own storage; expansions load
+// it for the whole batch so lists don't issue a query per item.
+var MenuItemExpansions = map[string]func(ctx context.Context, responses []MenuItemResponse) error{
+	"category": expandCategory,
+}
+
+// expandCategory embeds each item's category with its display label
+func expandCategory(_ context.Context, responses []MenuItemResponse) error {
+	for i := range responses {
+		expanded(&responses[i]).Category = &CategoryResponse{
+			Name:  responses[i].Category,
+			Label: categoryLabel(responses[i].Category),
+		}
+	}
+	return nil
+}
+
+// expanded returns the expanded sub-resources of a response, creating them if needed
+func expanded(response *MenuItemResponse) *MenuItemExpanded {
+	if response.Expanded == nil {
+		response.Expanded = &MenuItemExpanded{}
+	}
+	return response.Expanded
+}
+
+// categoryLabel returns the display label of a category, e.g. "Fast Food"
+func categoryLabel(category string) string {
+	words := strings.Fields(category)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// exportFlushRows is the number of rows written between flushes to the client
+const exportFlushRows = 500
+
+// MenuItemStreamer is implemented by repositories that can read rows from a cursor
+type MenuItemStreamer interface {
+	Stream(ctx context.Context, fn func(item *models.MenuItem) error) error
+}
+
+// MenuItemExporter writes menu items out in bulk
+type MenuItemExporter struct {
+	repo MenuItemStreamer
+}
+
+// NewMenuItemExporter creates a new exporter backed by the given repository
+func NewMenuItemExporter(repo MenuItemStreamer) *MenuItemExporter {
+	return &MenuItemExporter{repo: repo}
+}
+
+// ExportNDJSON writes every non-deleted menu item to w as newline-delimited JSON, one
+// object per line in the same shape as the read endpoints. Rows are streamed from the
+// database, so memory use doesn't grow with the table. flush, if non-nil, is called
+// every few hundred rows to push buffered output to the client. It returns the number
+// of rows written.
+func (exp *MenuItemExporter) ExportNDJSON(ctx context.Context, w io.Writer, flush func()) (int, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemExporter.ExportNDJSON")
+	defer span.End()
+
+	start := time.Now()
+	buf := bufio.NewWriter(w)
+	enc := json.NewEncoder(buf)
+
+	rows := 0
+	err := guardExec(func() error {
+		return exp.repo.Stream(ctx, func(item *models.MenuItem) error {
+			if err := enc.Encode(newMenuItemResponse(item)); err != nil {
+				return err
+			}
+			rows++
+			if rows%exportFlushRows == 0 {
+				if err := buf.Flush(); err != nil {
+					return err
+				}
+				if flush != nil {
+					flush()
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return rows, fmt.Errorf("failed to export menu items: %w", err)
+	}
+	if err := buf.Flush(); err != nil {
+		return rows, fmt.Errorf("failed to export menu items: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("Menu items exported",
+		slog.Int("rows", rows),
+		slog.Int64("duration_ms", time.Since(start).Milliseconds()))
+	return rows, nil
+}
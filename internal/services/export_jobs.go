@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/jobs"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/storage"
+)
+
+// Export job states
+const (
+	ExportPending   = "pending"
+	ExportRunning   = "running"
+	ExportCompleted = "completed"
+	ExportFailed    = "failed"
+)
+
+// ExportFormatNDJSON is the newline-delimited JSON export format
+const ExportFormatNDJSON = "ndjson"
+
+// Job kinds handled by ExportJobs
+const (
+	JobExport       = "export"
+	JobExportExpire = "export.expire"
+)
+
+// maxActiveExports caps the exports that may be pending or running at once
+const maxActiveExports = 16
+
+// exportRetention is how long finished exports are kept
+const exportRetention = 24 * time.Hour
+
+// Export job errors
+var (
+	ErrExportNotFound      = errors.New("export not found")
+	ErrExportNotReady      = errors.New("export is not completed")
+	ErrInvalidExportFormat = errors.New("invalid export format")
+	ErrTooManyExports      = errors.New("too many exports in progress")
+)
+
+// CreateExportRequest represents the request to start an export
+type CreateExportRequest struct {
+	Format string `json:"format,omitempty" example:"ndjson"`
+}
+
+// ExportJob describes an asynchronous export and its progress
+type ExportJob struct {
+	ID          string     `json:"id" example:"42"`
+	Format      string     `json:"format" example:"ndjson"`
+	Status      string     `json:"status" example:"completed"`
+	Attempts    int        `json:"attempts"`
+	Rows        int        `json:"rows"`
+	SizeBytes   int64      `json:"size_bytes"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// exportPayload is the job payload of an export
+type exportPayload struct {
+	Format string `json:"format"`
+	// Restaurant whose menu is exported, the default one for exports started before
+	// there were several
+	RestaurantID int `json:"restaurant_id,omitempty"`
+}
+
+// restaurant returns the restaurant whose menu is exported
+func (p exportPayload) restaurant() int {
+	if p.RestaurantID == 0 {
+		return database.DefaultRestaurantID
+	}
+	return p.RestaurantID
+}
+
+// exportResult is the job result of a finished export
+type exportResult struct {
+	Rows      int    `json:"rows"`
+	SizeBytes int64  `json:"size_bytes"`
+	Key       string `json:"key"`
+}
+
+// expirePayload is the job payload that removes a finished export
+type expirePayload struct {
+	ExportID int64  `json:"export_id"`
+	Key      string `json:"key"`
+}
+
+// ExportJobs runs exports on the background job queue and stores the finished files,
+// so heavy exports don't have to fit in a single request. Finished exports and their
+// files are removed exportRetention later by a delayed job.
+type ExportJobs struct {
+	queue    *jobs.Queue
+	exporter *MenuItemExporter
+	storage  storage.Storage
+}
+
+// NewExportJobs registers the export job handlers on queue
+func NewExportJobs(queue *jobs.Queue, exporter *MenuItemExporter, store storage.Storage) *ExportJobs {
+	e := &ExportJobs{queue: queue, exporter: exporter, storage: store}
+	queue.Register(JobExport, e.run)
+	queue.Register(JobExportExpire, e.expire)
+	return e
+}
+
+// Enqueue starts a new export of the menu of the restaurant of ctx and returns it in
+// the pending state
+func (e *ExportJobs) Enqueue(ctx context.Context, req CreateExportRequest) (*ExportJob, error) {
+	format := req.Format
+	if format == "" {
+		format = ExportFormatNDJSON
+	}
+	if format != ExportFormatNDJSON {
+		return nil, fmt.Errorf("%w %q (supported: %s)", ErrInvalidExportFormat, format, ExportFormatNDJSON)
+	}
+
+	active, err := e.queue.Active(ctx, JobExport)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count exports: %w", err)
+	}
+	if active >= maxActiveExports {
+		return nil, ErrTooManyExports
+	}
+
+	job, err := e.queue.Enqueue(ctx, JobExport, exportPayload{Format: format, RestaurantID: database.RestaurantID(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	return toExportJob(job)
+}
+
+// Get returns the current state of an export
+func (e *ExportJobs) Get(ctx context.Context, id string) (*ExportJob, error) {
+	job, err := e.job(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toExportJob(job)
+}
+
+// Open returns the file of a completed export along with the job
+func (e *ExportJobs) Open(ctx context.Context, id string) (storage.Object, *ExportJob, error) {
+	job, err := e.job(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	export, err := toExportJob(job)
+	if err != nil {
+		return nil, nil, err
+	}
+	if export.Status != ExportCompleted {
+		return nil, export, ErrExportNotReady
+	}
+
+	var result exportResult
+	if err := job.DecodeResult(&result); err != nil {
+		return nil, export, fmt.Errorf("invalid export result: %w", err)
+	}
+	obj, err := e.storage.Open(ctx, result.Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, export, ErrExportNotFound
+	}
+	if err != nil {
+		return nil, export, fmt.Errorf("failed to open export: %w", err)
+	}
+	return obj, export, nil
+}
+
+// job loads the queue job behind an export ID of the restaurant of ctx
+func (e *ExportJobs) job(ctx context.Context, id string) (*jobs.Job, error) {
+	jobID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, ErrExportNotFound
+	}
+	job, err := e.queue.Get(ctx, jobID)
+	if errors.Is(err, jobs.ErrNotFound) || (err == nil && job.Kind != JobExport) {
+		return nil, ErrExportNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load export: %w", err)
+	}
+	var payload exportPayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("invalid export payload: %w", err)
+	}
+	if payload.restaurant() != database.RestaurantID(ctx) {
+		return nil, ErrExportNotFound
+	}
+	return job, nil
+}
+
+// run is the job handler writing an export to storage
+func (e *ExportJobs) run(ctx context.Context, job *jobs.Job) (any, error) {
+	var payload exportPayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("invalid export payload: %w", err)
+	}
+	ctx = database.WithRestaurant(ctx, payload.restaurant())
+	key := fmt.Sprintf("menu_items-%d.%s", job.ID, payload.Format)
+
+	pr, pw := io.Pipe()
+	var rows int
+	exported := make(chan struct{})
+	go func() {
+		defer close(exported)
+		var err error
+		rows, err = e.exporter.ExportNDJSON(ctx, pw, nil)
+		pw.CloseWithError(err)
+	}()
+	size, err := e.storage.Put(ctx, key, pr)
+	// Unblock the exporter if storage gave up early
+	pr.CloseWithError(err)
+	<-exported
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove the file and the job once the export has expired
+	_, err = e.queue.Enqueue(ctx, JobExportExpire, expirePayload{ExportID: job.ID, Key: key},
+		jobs.RunAt(time.Now().Add(exportRetention)))
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to schedule export expiry", slog.String("error", err.Error()))
+	}
+
+	return exportResult{Rows: rows, SizeBytes: size, Key: key}, nil
+}
+
+// expire is the job handler removing an expired export
+func (e *ExportJobs) expire(ctx context.Context, job *jobs.Job) (any, error) {
+	var payload expirePayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("invalid export expiry payload: %w", err)
+	}
+	if err := e.storage.Delete(ctx, payload.Key); err != nil {
+		return nil, err
+	}
+	return nil, e.queue.Delete(ctx, payload.ExportID)
+}
+
+// toExportJob converts a queue job to its export view
+func toExportJob(job *jobs.Job) (*ExportJob, error) {
+	var payload exportPayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("invalid export payload: %w", err)
+	}
+
+	export := &ExportJob{
+		ID:        strconv.FormatInt(job.ID, 10),
+		Format:    payload.Format,
+		Attempts:  job.Attempts,
+		CreatedAt: job.CreatedAt,
+	}
+	switch job.Status {
+	case jobs.StatusRunning:
+		export.Status = ExportRunning
+	case jobs.StatusSucceeded:
+		export.Status = ExportCompleted
+		export.CompletedAt = job.FinishedAt
+		var result exportResult
+		if err := job.DecodeResult(&result); err != nil {
+			return nil, fmt.Errorf("invalid export result: %w", err)
+		}
+		export.Rows = result.Rows
+		export.SizeBytes = result.SizeBytes
+	case jobs.StatusDead:
+		export.Status = ExportFailed
+		export.CompletedAt = job.FinishedAt
+	default:
+		export.Status = ExportPending
+	}
+	if job.LastError != nil {
+		export.Error = *job.LastError
+	}
+	return export, nil
+}
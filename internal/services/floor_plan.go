@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// FloorPlanRepository abstracts floor plan storage
+type FloorPlanRepository interface {
+	Find(ctx context.Context) (*models.FloorPlan, error)
+	Save(ctx context.Context, layout models.FloorPlanLayout, version int, at time.Time) error
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ FloorPlanRepository = (*models.FloorPlanQuery)(nil)
+
+// FloorPlanService defines business operations on the floor plan
+type FloorPlanService interface {
+	GetFloorPlan(ctx context.Context) (*FloorPlanResponse, error)
+	UpdateFloorPlan(ctx context.Context, req FloorPlanRequest) (*FloorPlanResponse, error)
+}
+
+// Floor plan errors
+var (
+	ErrInvalidFloorPlan = errors.New("invalid floor plan")
+	// ErrFloorPlanConflict is returned when the floor plan was saved since the version
+	// an update was made from
+	ErrFloorPlanConflict = errors.New("floor plan was changed")
+)
+
+// Floor plan limits
+const (
+	maxFloorPlanSize         = 10000
+	maxFloorPlanSections     = 50
+	maxFloorPlanSectionName  = 50
+	maxFloorPlanSectionColor = 20
+	maxFloorPlanTables       = 500
+	maxFloorPlanSeats        = 100
+)
+
+// Shapes of a table on the floor plan
+const (
+	FloorPlanShapeRectangle = "rectangle"
+	FloorPlanShapeCircle    = "circle"
+)
+
+// FloorPlanRequest replaces the floor plan. With Version, the plan is only saved if
+// nobody saved it since that version.
+type FloorPlanRequest struct {
+	Version  *int                      `json:"version,omitempty" example:"7"`
+	Width    int                       `json:"width" example:"1200"`
+	Height   int                       `json:"height" example:"800"`
+	Sections []models.FloorPlanSection `json:"sections"`
+	Tables   []models.FloorPlanTable   `json:"tables"`
+}
+
+// FloorPlanResponse represents the floor plan returned to clients
+type FloorPlanResponse struct {
+	Version   int                       `json:"version" example:"7"`
+	Width     int                       `json:"width" example:"1200"`
+	Height    int                       `json:"height" example:"800"`
+	Sections  []models.FloorPlanSection `json:"sections"`
+	Tables    []FloorPlanTableResponse  `json:"tables"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+}
+
+// FloorPlanTableResponse is a table on the floor plan, with its current name and
+// status
+type FloorPlanTableResponse struct {
+	models.FloorPlanTable
+	Name   string `json:"name" example:"12"`
+	Status string `json:"status" example:"seated"`
+}
+
+// floorPlanService handles business logic for the floor plan
+type floorPlanService struct {
+	repo   FloorPlanRepository
+	tables TableRepository
+}
+
+// NewFloorPlanService creates a new floor plan service. The tables placed on the plan
+// are those in tables.
+func NewFloorPlanService(repo FloorPlanRepository, tables TableRepository) FloorPlanService {
+	return &floorPlanService{repo: repo, tables: tables}
+}
+
+// GetFloorPlan returns the floor plan. Tables deleted since it was saved are left out.
+func (s *floorPlanService) GetFloorPlan(ctx context.Context) (*FloorPlanResponse, error) {
+	ctx, span := tracer.Start(ctx, "FloorPlanService.GetFloorPlan")
+	defer span.End()
+
+	plan, err := guard(func() (*models.FloorPlan, error) { return s.repo.Find(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve floor plan: %w", err)
+	}
+	tables, err := s.diningTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newFloorPlanResponse(plan.Layout, plan.Version, plan.UpdatedAt, tables), nil
+}
+
+// UpdateFloorPlan validates and saves the floor plan
+func (s *floorPlanService) UpdateFloorPlan(ctx context.Context, req FloorPlanRequest) (*FloorPlanResponse, error) {
+	ctx, span := tracer.Start(ctx, "FloorPlanService.UpdateFloorPlan")
+	defer span.End()
+
+	ctx = database.UsePrimary(ctx)
+	tables, err := s.diningTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := floorPlanLayout(req, tables)
+	if err != nil {
+		return nil, err
+	}
+
+	version := 0
+	if req.Version != nil {
+		version = *req.Version
+	} else {
+		plan, err := guard(func() (*models.FloorPlan, error) { return s.repo.Find(ctx) })
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve floor plan: %w", err)
+		}
+		version = plan.Version
+	}
+
+	now := time.Now()
+	err = guardExec(func() error { return s.repo.Save(ctx, layout, version, now) })
+	if errors.Is(err, models.ErrFloorPlanChanged) {
+		return nil, fmt.Errorf("%w: it is no longer at version %d; reload it and apply the changes again", ErrFloorPlanConflict, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save floor plan: %w", err)
+	}
+	return newFloorPlanResponse(layout, version+1, now, tables), nil
+}
+
+// diningTables returns the restaurant's tables by ID
+func (s *floorPlanService) diningTables(ctx context.Context) (map[int]*models.DiningTable, error) {
+	tables, err := guard(func() ([]models.DiningTable, error) { return s.tables.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tables: %w", err)
+	}
+	byID := make(map[int]*models.DiningTable, len(tables))
+	for i := range tables {
+		byID[tables[i].ID] = &tables[i]
+	}
+	return byID, nil
+}
+
+// floorPlanLayout validates req, placing the restaurant's tables, and returns the
+// layout to save
+func floorPlanLayout(req FloorPlanRequest, tables map[int]*models.DiningTable) (models.FloorPlanLayout, error) {
+	layout := models.FloorPlanLayout{
+		Width:    req.Width,
+		Height:   req.Height,
+		Sections: make([]models.FloorPlanSection, len(req.Sections)),
+		Tables:   make([]models.FloorPlanTable, len(req.Tables)),
+	}
+	switch {
+	case req.Width < 1 || req.Width > maxFloorPlanSize || req.Height < 1 || req.Height > maxFloorPlanSize:
+		return layout, fmt.Errorf("%w: width and height must be between 1 and %d", ErrInvalidFloorPlan, maxFloorPlanSize)
+	case len(req.Sections) > maxFloorPlanSections:
+		return layout, fmt.Errorf("%w: at most %d sections", ErrInvalidFloorPlan, maxFloorPlanSections)
+	case len(req.Tables) > maxFloorPlanTables:
+		return layout, fmt.Errorf("%w: at most %d tables", ErrInvalidFloorPlan, maxFloorPlanTables)
+	}
+
+	sections := make(map[string]bool, len(req.Sections))
+	for i, section := range req.Sections {
+		section.Name = strings.TrimSpace(section.Name)
+		switch {
+		case section.Name == "":
+			return layout, fmt.Errorf("%w: section %d needs a name", ErrInvalidFloorPlan, i+1)
+		case len(section.Name) > maxFloorPlanSectionName:
+			return layout, fmt.Errorf("%w: section names must be at most %d characters", ErrInvalidFloorPlan, maxFloorPlanSectionName)
+		case sections[section.Name]:
+			return layout, fmt.Errorf("%w: section %q appears twice", ErrInvalidFloorPlan, section.Name)
+		case section.Color != nil && len(*section.Color) > maxFloorPlanSectionColor:
+			return layout, fmt.Errorf("%w: section colors must be at most %d characters", ErrInvalidFloorPlan, maxFloorPlanSectionColor)
+		}
+		sections[section.Name] = true
+		layout.Sections[i] = section
+	}
+
+	placed := make(map[int]bool, len(req.Tables))
+	for i, table := range req.Tables {
+		diningTable, ok := tables[table.TableID]
+		if !ok {
+			return layout, fmt.Errorf("%w: table %d does not exist", ErrInvalidFloorPlan, table.TableID)
+		}
+		name := diningTable.Name
+		if table.Section != nil {
+			section := strings.TrimSpace(*table.Section)
+			table.Section = &section
+		}
+		if table.Shape == "" {
+			table.Shape = FloorPlanShapeRectangle
+		}
+		switch {
+		case placed[table.TableID]:
+			return layout, fmt.Errorf("%w: table %s is placed twice", ErrInvalidFloorPlan, name)
+		case table.Section != nil && !sections[*table.Section]:
+			return layout, fmt.Errorf("%w: table %s is in section %q, which isn't on the plan", ErrInvalidFloorPlan, name, *table.Section)
+		case table.Shape != FloorPlanShapeRectangle && table.Shape != FloorPlanShapeCircle:
+			return layout, fmt.Errorf("%w: table %s must be a %s or a %s", ErrInvalidFloorPlan, name, FloorPlanShapeRectangle, FloorPlanShapeCircle)
+		case table.Width < 1 || table.Height < 1:
+			return layout, fmt.Errorf("%w: table %s must have a width and height of at least 1", ErrInvalidFloorPlan, name)
+		case table.X < 0 || table.Y < 0 || table.X+table.Width > req.Width || table.Y+table.Height > req.Height:
+			return layout, fmt.Errorf("%w: table %s must be within the plan", ErrInvalidFloorPlan, name)
+		case table.Rotation < 0 || table.Rotation > 359:
+			return layout, fmt.Errorf("%w: table %s must have a rotation between 0 and 359", ErrInvalidFloorPlan, name)
+		case table.Seats < 0 || table.Seats > maxFloorPlanSeats:
+			return layout, fmt.Errorf("%w: table %s must have between 0 and %d seats", ErrInvalidFloorPlan, name, maxFloorPlanSeats)
+		}
+		placed[table.TableID] = true
+		layout.Tables[i] = table
+	}
+	return layout, nil
+}
+
+// newFloorPlanResponse converts a floor plan layout to its response, with the name and
+// status of its tables and leaving out those no longer among tables
+func newFloorPlanResponse(layout models.FloorPlanLayout, version int, updatedAt time.Time, tables map[int]*models.DiningTable) *FloorPlanResponse {
+	response := &FloorPlanResponse{
+		Version:   version,
+		Width:     layout.Width,
+		Height:    layout.Height,
+		Sections:  layout.Sections,
+		Tables:    make([]FloorPlanTableResponse, 0, len(layout.Tables)),
+		UpdatedAt: localTime(updatedAt),
+	}
+	if response.Sections == nil {
+		response.Sections = []models.FloorPlanSection{}
+	}
+	for _, table := range layout.Tables {
+		if diningTable, ok := tables[table.TableID]; ok {
+			response.Tables = append(response.Tables, FloorPlanTableResponse{FloorPlanTable: table, Name: diningTable.Name, Status: diningTable.Status})
+		}
+	}
+	return response
+}
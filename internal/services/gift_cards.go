@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// GiftCardRepository abstracts gift card storage
+type GiftCardRepository interface {
+	FindByCode(ctx context.Context, code string) (*models.GiftCard, error)
+	Create(ctx context.Context, card *models.GiftCard) error
+	Redeem(ctx context.Context, card *models.GiftCard, amount decimal.Decimal, payment *models.OrderPayment) (*models.GiftCardTransaction, error)
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ GiftCardRepository = (*models.GiftCardQuery)(nil)
+
+// GiftCardService defines business operations on gift cards
+type GiftCardService interface {
+	IssueGiftCard(ctx context.Context, req IssueGiftCardRequest) (*GiftCardResponse, error)
+	GetGiftCard(ctx context.Context, code string) (*GiftCardResponse, error)
+	RedeemGiftCard(ctx context.Context, code string, req RedeemGiftCardRequest) (*GiftCardRedemptionResponse, error)
+}
+
+// Gift card errors
+var (
+	ErrGiftCardNotFound = errors.New("gift card not found")
+	ErrInvalidGiftCard  = errors.New("invalid gift card")
+	ErrGiftCardExists   = errors.New("gift card code already exists")
+	// ErrGiftCardNotRedeemable is returned when a gift card can't pay the amount asked
+	ErrGiftCardNotRedeemable = errors.New("gift card not redeemable")
+)
+
+// giftCardAlphabet leaves out characters easily mistaken for one another
+const giftCardAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// IssueGiftCardRequest issues a gift card worth Amount. Without a Code, a random code of four
+// groups of four characters is generated.
+type IssueGiftCardRequest struct {
+	Code          *string         `json:"code,omitempty" example:"GIFT-2026-ANNA"`
+	Amount        decimal.Decimal `json:"amount" swaggertype:"string" example:"50.00"`
+	RecipientName *string         `json:"recipient_name,omitempty" example:"Anna"`
+	ExpiresAt     *time.Time      `json:"expires_at,omitempty"`
+}
+
+// RedeemGiftCardRequest takes Amount off a gift card's balance. With an OrderID, the
+// amount pays part or all of that order and defaults to as much of the order's
+// amount due as the balance covers.
+type RedeemGiftCardRequest struct {
+	Amount  *decimal.Decimal `json:"amount,omitempty" swaggertype:"string" example:"20.00"`
+	OrderID *string          `json:"order_id,omitempty" example:"0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"`
+}
+
+// GiftCardResponse represents the gift card data returned to clients
+type GiftCardResponse struct {
+	Code           string                        `json:"code" example:"K7QM-4XPB-9TRC-HW2D"`
+	InitialBalance decimal.Decimal               `json:"initial_balance" swaggertype:"string" example:"50.00"`
+	Balance        decimal.Decimal               `json:"balance" swaggertype:"string" example:"30.00"`
+	RecipientName  *string                       `json:"recipient_name,omitempty" example:"Anna"`
+	ExpiresAt      *time.Time                    `json:"expires_at,omitempty"`
+	IsActive       bool                          `json:"is_active"`
+	Transactions   []GiftCardTransactionResponse `json:"transactions"`
+	CreatedAt      time.Time                     `json:"created_at"`
+}
+
+// GiftCardTransactionResponse is a change to a gift card's balance, newest first.
+// Amount is negative for redemptions; Balance is the balance after the change.
+type GiftCardTransactionResponse struct {
+	Type      string          `json:"type" enums:"issue,redeem" example:"redeem"`
+	Amount    decimal.Decimal `json:"amount" swaggertype:"string" example:"-20.00"`
+	Balance   decimal.Decimal `json:"balance" swaggertype:"string" example:"30.00"`
+	OrderID   *string         `json:"order_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// GiftCardRedemptionResponse is the outcome of a redemption: the amount taken off the
+// gift card, its remaining balance and, when it paid an order, the order
+type GiftCardRedemptionResponse struct {
+	Code    string          `json:"code" example:"K7QM-4XPB-9TRC-HW2D"`
+	Amount  decimal.Decimal `json:"amount" swaggertype:"string" example:"20.00"`
+	Balance decimal.Decimal `json:"balance" swaggertype:"string" example:"30.00"`
+	Order   *OrderResponse  `json:"order,omitempty"`
+}
+
+// giftCardService handles business logic for gift cards
+type giftCardService struct {
+	repo   GiftCardRepository
+	orders OrderRepository
+}
+
+// NewGiftCardService creates a new gift card service. Redemptions pay orders from
+// orders.
+func NewGiftCardService(repo GiftCardRepository, orders OrderRepository) GiftCardService {
+	return &giftCardService{repo: repo, orders: orders}
+}
+
+// IssueGiftCard validates and stores a new gift card
+func (s *giftCardService) IssueGiftCard(ctx context.Context, req IssueGiftCardRequest) (*GiftCardResponse, error) {
+	ctx, span := tracer.Start(ctx, "GiftCardService.IssueGiftCard")
+	defer span.End()
+
+	if problem := amountProblem(req.Amount); problem != "" {
+		return nil, fmt.Errorf("%w: amount %s", ErrInvalidGiftCard, problem)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidGiftCard)
+	}
+
+	card := &models.GiftCard{
+		InitialBalance: req.Amount,
+		RecipientName:  req.RecipientName,
+		ExpiresAt:      req.ExpiresAt,
+		IsActive:       true,
+	}
+	if req.Code != nil {
+		card.Code = normalizeCouponCode(*req.Code)
+		if card.Code == "" || len(card.Code) > 50 || strings.ContainsAny(card.Code, " \t\n") {
+			return nil, fmt.Errorf("%w: code must be 1 to 50 characters without spaces", ErrInvalidGiftCard)
+		}
+		_, err := guard(func() (*models.GiftCard, error) { return s.repo.FindByCode(database.UsePrimary(ctx), card.Code) })
+		if err == nil {
+			return nil, fmt.Errorf("%w: %s", ErrGiftCardExists, card.Code)
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to look up gift card %s: %w", card.Code, err)
+		}
+	} else {
+		code, err := newGiftCardCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate gift card code: %w", err)
+		}
+		card.Code = code
+	}
+
+	err := guardExec(func() error { return s.repo.Create(ctx, card) })
+	if errors.Is(err, models.ErrGiftCardCodeTaken) {
+		return nil, fmt.Errorf("%w: %s", ErrGiftCardExists, card.Code)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue gift card: %w", err)
+	}
+	return newGiftCardResponse(card), nil
+}
+
+// GetGiftCard returns a gift card with its balance and transactions
+func (s *giftCardService) GetGiftCard(ctx context.Context, code string) (*GiftCardResponse, error) {
+	ctx, span := tracer.Start(ctx, "GiftCardService.GetGiftCard")
+	defer span.End()
+
+	code = normalizeCouponCode(code)
+	card, err := guard(func() (*models.GiftCard, error) { return s.repo.FindByCode(ctx, code) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrGiftCardNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find gift card %s: %w", code, err)
+	}
+	return newGiftCardResponse(card), nil
+}
+
+// RedeemGiftCard takes an amount off a gift card, paying an order with it when asked
+func (s *giftCardService) RedeemGiftCard(ctx context.Context, code string, req RedeemGiftCardRequest) (*GiftCardRedemptionResponse, error) {
+	ctx, span := tracer.Start(ctx, "GiftCardService.RedeemGiftCard")
+	defer span.End()
+
+	// Balances and amounts due are checked right before they change
+	ctx = database.UsePrimary(ctx)
+	code = normalizeCouponCode(code)
+	card, err := guard(func() (*models.GiftCard, error) { return s.repo.FindByCode(ctx, code) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrGiftCardNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find gift card %s: %w", code, err)
+	}
+	switch {
+	case !card.IsActive:
+		return nil, fmt.Errorf("%w: %s is not active", ErrGiftCardNotRedeemable, code)
+	case card.ExpiresAt != nil && !time.Now().Before(*card.ExpiresAt):
+		return nil, fmt.Errorf("%w: %s has expired", ErrGiftCardNotRedeemable, code)
+	}
+
+	var due *decimal.Decimal
+	var payment *models.OrderPayment
+	if req.OrderID != nil {
+		order, err := guard(func() (*models.Order, error) { return s.orders.FindByID(ctx, *req.OrderID) })
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find order %s: %w", *req.OrderID, err)
+		}
+		left := order.AmountDue()
+		switch {
+		case order.Status == models.OrderStatusCancelled:
+			return nil, fmt.Errorf("%w: order %s was cancelled", ErrGiftCardNotRedeemable, order.ID)
+		case !left.IsPositive():
+			return nil, fmt.Errorf("%w: order %s is already paid", ErrGiftCardNotRedeemable, order.ID)
+		}
+		due = &left
+		payment = &models.OrderPayment{OrderID: order.ID, Earn: loyaltyProgram.earning(order)}
+	}
+
+	var amount decimal.Decimal
+	switch {
+	case req.Amount != nil:
+		amount = *req.Amount
+		if problem := amountProblem(amount); problem != "" {
+			return nil, fmt.Errorf("%w: amount %s", ErrInvalidGiftCard, problem)
+		}
+	case due != nil:
+		amount = decimal.Min(card.Balance, *due)
+	default:
+		return nil, fmt.Errorf("%w: amount is required without order_id", ErrInvalidGiftCard)
+	}
+	switch {
+	case !card.Balance.IsPositive():
+		return nil, fmt.Errorf("%w: %s has no balance left", ErrGiftCardNotRedeemable, code)
+	case amount.GreaterThan(card.Balance):
+		return nil, fmt.Errorf("%w: %s has a balance of %s", ErrGiftCardNotRedeemable, code, card.Balance.StringFixed(2))
+	case due != nil && amount.GreaterThan(*due):
+		return nil, fmt.Errorf("%w: the order has %s left to pay", ErrGiftCardNotRedeemable, due.StringFixed(2))
+	}
+
+	transaction, err := guard(func() (*models.GiftCardTransaction, error) {
+		return s.repo.Redeem(ctx, card, amount, payment)
+	})
+	switch {
+	case errors.Is(err, models.ErrGiftCardBalance):
+		return nil, fmt.Errorf("%w: %s has too low a balance", ErrGiftCardNotRedeemable, code)
+	case errors.Is(err, models.ErrOrderPaid):
+		return nil, fmt.Errorf("%w: the order has less than %s left to pay", ErrGiftCardNotRedeemable, amount.StringFixed(2))
+	case err != nil:
+		return nil, fmt.Errorf("failed to redeem gift card %s: %w", code, err)
+	}
+
+	response := &GiftCardRedemptionResponse{Code: card.Code, Amount: amount, Balance: transaction.Balance}
+	if req.OrderID != nil {
+		order, err := guard(func() (*models.Order, error) { return s.orders.FindByID(ctx, *req.OrderID) })
+		if err != nil {
+			return nil, fmt.Errorf("failed to find order %s: %w", *req.OrderID, err)
+		}
+		response.Order = newOrderResponse(order)
+	}
+	return response, nil
+}
+
+// newGiftCardCode returns a random code such as K7QM-4XPB-9TRC-HW2D
+func newGiftCardCode() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	var code strings.Builder
+	for i, c := range b {
+		if i > 0 && i%4 == 0 {
+			code.WriteByte('-')
+		}
+		code.WriteByte(giftCardAlphabet[int(c)%len(giftCardAlphabet)])
+	}
+	return code.String(), nil
+}
+
+// newGiftCardResponse converts a gift card model to its response DTO
+func newGiftCardResponse(card *models.GiftCard) *GiftCardResponse {
+	response := &GiftCardResponse{
+		Code:           card.Code,
+		InitialBalance: card.InitialBalance,
+		Balance:        card.Balance,
+		RecipientName:  card.RecipientName,
+		IsActive:       card.IsActive,
+		Transactions:   make([]GiftCardTransactionResponse, len(card.Transactions)),
+		CreatedAt:      localTime(card.CreatedAt),
+	}
+	if card.ExpiresAt != nil {
+		expiresAt := localTime(*card.ExpiresAt)
+		response.ExpiresAt = &expiresAt
+	}
+	for i, transaction := range card.Transactions {
+		response.Transactions[i] = GiftCardTransactionResponse{
+			Type:      transaction.Type,
+			Amount:    transaction.Amount,
+			Balance:   transaction.Balance,
+			OrderID:   transaction.OrderID,
+			CreatedAt: localTime(transaction.CreatedAt),
+		}
+	}
+	return response
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/circuitbreaker"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// racingGiftCards is a gift card repository where another request takes every code
+// between the lookup and the insert
+type racingGiftCards struct {
+	GiftCardRepository
+}
+
+func (racingGiftCards) FindByCode(context.Context, string) (*models.GiftCard, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (racingGiftCards) Create(context.Context, *models.GiftCard) error {
+	return models.ErrGiftCardCodeTaken
+}
+
+func TestIssueGiftCardRaceReportsExistingCode(t *testing.T) {
+	saved := dbBreaker
+	t.Cleanup(func() { dbBreaker = saved })
+	ConfigureCircuitBreaker(1, time.Minute)
+
+	service := NewGiftCardService(racingGiftCards{}, nil)
+	code := "gift-anna"
+	req := IssueGiftCardRequest{Code: &code, Amount: decimal.NewFromInt(50)}
+	if _, err := service.IssueGiftCard(context.Background(), req); !errors.Is(err, ErrGiftCardExists) {
+		t.Fatalf("IssueGiftCard() = %v, want %v", err, ErrGiftCardExists)
+	}
+	if state := dbBreaker.State(); state != circuitbreaker.StateClosed {
+		t.Fatalf("breaker %s after a taken code, want closed", state)
+	}
+}
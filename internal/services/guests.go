@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// GuestService defines the operations guests at a table perform from its QR code,
+// without an account: they start a session with the table's token, then browse the
+// menu and order for the table with the session's token until it expires
+type GuestService interface {
+	StartSession(ctx context.Context, tableToken string) (*GuestSessionResponse, error)
+	GetSession(ctx context.Context, token string) (*GuestSessionResponse, error)
+	GetMenu(ctx context.Context, token string) ([]MenuItemResponse, error)
+	PlaceOrder(ctx context.Context, token string, req GuestOrderRequest) (*OrderResponse, error)
+	ListOrders(ctx context.Context, token string) ([]OrderResponse, error)
+}
+
+// Guest session errors
+var (
+	// ErrGuestSessionNotFound is returned for a session token that was never given out
+	ErrGuestSessionNotFound = errors.New("guest session not found")
+	// ErrGuestSessionExpired is returned for a session that has expired or whose table's
+	// QR code was replaced
+	ErrGuestSessionExpired = errors.New("guest session expired")
+)
+
+// maxGuestSessionOrders is the most orders ListOrders returns for a session
+const maxGuestSessionOrders = 50
+
+// GuestSessionResponse is a guest session; Token authorizes the guest's requests
+type GuestSessionResponse struct {
+	Token     string    `json:"token" example:"3c59dc048e8850243be8079a5c74d079"`
+	Table     string    `json:"table" example:"12"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GuestOrderRequest is an order guests place for their table. Guests only order items
+// on the menu, at the menu's dine-in prices.
+type GuestOrderRequest struct {
+	CustomerName *string                 `json:"customer_name,omitempty" example:"Lina"`
+	Notes        *string                 `json:"notes,omitempty" example:"Birthday at this table"`
+	Items        []GuestOrderItemRequest `json:"items"`
+}
+
+// GuestOrderItemRequest is a line of a guest order
+type GuestOrderItemRequest struct {
+	MenuItemID int     `json:"menu_item_id" example:"1"`
+	Quantity   int     `json:"quantity" example:"2"`
+	Notes      *string `json:"notes,omitempty" example:"No onions"`
+}
+
+// guestService handles business logic for guest sessions
+type guestService struct {
+	tables      TableRepository
+	menu        MenuItemService
+	orders      OrderService
+	experiments PriceExperimentRepository
+}
+
+// NewGuestService creates a new guest service placing orders through orders. Guests are
+// shown and charged the variant prices of the running experiments of experiments when
+// it is non-nil.
+func NewGuestService(tables TableRepository, menu MenuItemService, orders OrderService, experiments PriceExperimentRepository) GuestService {
+	return &guestService{tables: tables, menu: menu, orders: orders, experiments: experiments}
+}
+
+// StartSession starts a guest session at the table whose QR code carries tableToken
+func (s *guestService) StartSession(ctx context.Context, tableToken string) (*GuestSessionResponse, error) {
+	ctx, span := tracer.Start(ctx, "GuestService.StartSession")
+	defer span.End()
+
+	table, err := guard(func() (*models.DiningTable, error) { return s.tables.FindByToken(ctx, tableToken) })
+	if errors.Is(err, sql.ErrNoRows) || tableToken == "" {
+		return nil, ErrTableNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find table: %w", err)
+	}
+	ctx = database.WithRestaurant(ctx, table.RestaurantID)
+
+	token, err := newTableToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session token: %w", err)
+	}
+	session := &models.GuestSession{
+		Token:     token,
+		TableID:   table.ID,
+		Table:     table,
+		ExpiresAt: time.Now().Add(guestOrdering.SessionTTL),
+	}
+	if err := guardExec(func() error { return s.tables.CreateSession(ctx, session) }); err != nil {
+		return nil, fmt.Errorf("failed to create guest session: %w", err)
+	}
+	return newGuestSessionResponse(session), nil
+}
+
+// GetSession returns an open guest session
+func (s *guestService) GetSession(ctx context.Context, token string) (*GuestSessionResponse, error) {
+	ctx, span := tracer.Start(ctx, "GuestService.GetSession")
+	defer span.End()
+
+	ctx, session, err := s.session(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return newGuestSessionResponse(session), nil
+}
+
+// GetMenu returns the menu items guests may order, priced for dining in. Items of a
+// running price experiment show the price of the session's variant instead, and the
+// variant shown is recorded.
+func (s *guestService) GetMenu(ctx context.Context, token string) ([]MenuItemResponse, error) {
+	ctx, span := tracer.Start(ctx, "GuestService.GetMenu")
+	defer span.End()
+
+	ctx, session, err := s.session(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	menu, err := s.menu.GetAvailableMenuItems(ctx, QueryOptions{Channel: models.OrderChannelDineIn})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyExperiments(ctx, session, menu); err != nil {
+		return nil, err
+	}
+	return menu, nil
+}
+
+// PlaceOrder places a dine-in order for the session's table
+func (s *guestService) PlaceOrder(ctx context.Context, token string, req GuestOrderRequest) (*OrderResponse, error) {
+	ctx, span := tracer.Start(ctx, "GuestService.PlaceOrder")
+	defer span.End()
+
+	ctx, session, err := s.session(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	menu, err := s.menu.GetAvailableMenuItems(ctx, QueryOptions{})
+	if err != nil {
+		return nil, err
+	}
+	available := make(map[int]bool, len(menu))
+	for _, item := range menu {
+		available[item.ID] = true
+	}
+
+	order := CreateOrderRequest{
+		Source:         models.OrderSourceTable,
+		Channel:        models.OrderChannelDineIn,
+		CustomerName:   req.CustomerName,
+		Notes:          req.Notes,
+		Items:          make([]CreateOrderItemRequest, len(req.Items)),
+		Table:          session.Table,
+		GuestSessionID: &session.ID,
+	}
+	prices, err := s.exposedPrices(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	for i, line := range req.Items {
+		if !available[line.MenuItemID] {
+			return nil, fmt.Errorf("%w: item %d is not on the menu", ErrInvalidOrder, i+1)
+		}
+		order.Items[i] = CreateOrderItemRequest{MenuItemID: &line.MenuItemID, Quantity: line.Quantity, Notes: line.Notes}
+		if price, ok := prices[line.MenuItemID]; ok {
+			order.Items[i].UnitPrice = &price
+		}
+	}
+	return s.orders.CreateOrder(ctx, order)
+}
+
+// ListOrders returns the orders placed in a guest session, newest first
+func (s *guestService) ListOrders(ctx context.Context, token string) ([]OrderResponse, error) {
+	ctx, span := tracer.Start(ctx, "GuestService.ListOrders")
+	defer span.End()
+
+	ctx, session, err := s.session(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.orders.ListOrders(database.UsePrimary(ctx), OrderListOptions{GuestSession: session.ID, Limit: maxGuestSessionOrders})
+}
+
+// applyExperiments prices the menu items of the running price experiments at the
+// variant of session, and records the variants shown
+func (s *guestService) applyExperiments(ctx context.Context, session *models.GuestSession, menu []MenuItemResponse) error {
+	if s.experiments == nil {
+		return nil
+	}
+	now := time.Now()
+	experiments, err := guard(func() ([]models.PriceExperiment, error) { return s.experiments.Running(ctx, now) })
+	if err != nil {
+		return fmt.Errorf("failed to retrieve price experiments: %w", err)
+	}
+	byItem := make(map[int]*models.PriceExperiment, len(experiments))
+	for i := range experiments {
+		byItem[experiments[i].MenuItemID] = &experiments[i]
+	}
+
+	var exposures []models.PriceExperimentExposure
+	for i := range menu {
+		experiment, ok := byItem[menu[i].ID]
+		if !ok {
+			continue
+		}
+		variant := experimentVariant(experiment, session.ID)
+		if variant == nil {
+			continue
+		}
+		menu[i].Price = variant.Price
+		menu[i].RegularPrice = nil
+		menu[i].PricingRule = nil
+		exposures = append(exposures, models.PriceExperimentExposure{
+			ExperimentID:   experiment.ID,
+			VariantID:      variant.ID,
+			GuestSessionID: session.ID,
+			ShownAt:        now,
+		})
+	}
+	if err := guardExec(func() error { return s.experiments.RecordExposures(ctx, exposures) }); err != nil {
+		return fmt.Errorf("failed to record price experiment exposures: %w", err)
+	}
+	return nil
+}
+
+// exposedPrices returns the experiment prices session was shown, by menu item. Its
+// orders are charged them, also once the experiment ended.
+func (s *guestService) exposedPrices(ctx context.Context, session *models.GuestSession) (map[int]decimal.Decimal, error) {
+	if s.experiments == nil {
+		return nil, nil
+	}
+	exposed, err := guard(func() ([]models.ExposedPrice, error) { return s.experiments.ExposedPrices(ctx, session.ID) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve price experiment exposures: %w", err)
+	}
+	prices := make(map[int]decimal.Decimal, len(exposed))
+	for _, price := range exposed {
+		prices[price.MenuItemID] = price.Price
+	}
+	return prices, nil
+}
+
+// session loads the open guest session token authorizes, and returns ctx limited to
+// the restaurant of its table
+func (s *guestService) session(ctx context.Context, token string) (context.Context, *models.GuestSession, error) {
+	if token == "" {
+		return ctx, nil, ErrGuestSessionNotFound
+	}
+	session, err := guard(func() (*models.GuestSession, error) { return s.tables.FindSession(ctx, token) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return ctx, nil, ErrGuestSessionNotFound
+	}
+	if err != nil {
+		return ctx, nil, fmt.Errorf("failed to find guest session: %w", err)
+	}
+	if !session.ExpiresAt.After(time.Now()) {
+		return ctx, nil, ErrGuestSessionExpired
+	}
+	return database.WithRestaurant(ctx, session.Table.RestaurantID), session, nil
+}
+
+// newGuestSessionResponse converts a guest session to its response
+func newGuestSessionResponse(session *models.GuestSession) *GuestSessionResponse {
+	return &GuestSessionResponse{
+		Token:     session.Token,
+		Table:     session.Table.Name,
+		ExpiresAt: localTime(session.ExpiresAt),
+	}
+}
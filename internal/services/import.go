@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/metrics"
+)
+
+// importBatchSize is the number of rows written per multi-row INSERT
+const importBatchSize = 500
+
+// maxReportedImportErrors caps the validation errors returned to the client
+const maxReportedImportErrors = 50
+
+// ValidCategories lists the allowed menu item categories
+var ValidCategories = map[string]bool{
+	"appetizer": true,
+	"main":      true,
+	"dessert":   true,
+	"drink":     true,
+	"side":      true,
+	"fast food": true,
+}
+
+// ErrInvalidImport is returned when an import file fails validation
+var ErrInvalidImport = errors.New("invalid import file")
+
+// MenuItemBulkCreator is implemented by repositories that support batched inserts
+type MenuItemBulkCreator interface {
+	BulkCreate(ctx context.Context, items []models.MenuItem, batchSize int, progress func(done, total int)) error
+}
+
+// ImportRowError describes a validation failure on a CSV line
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ImportResult summarizes a completed import
+type ImportResult struct {
+	Imported   int              `json:"imported"`
+	Batches    int              `json:"batches"`
+	DurationMs int64            `json:"duration_ms"`
+	Errors     []ImportRowError `json:"errors,omitempty"`
+}
+
+// MenuItemImporter loads menu items from CSV in bulk
+type MenuItemImporter struct {
+	repo MenuItemBulkCreator
+}
+
+// NewMenuItemImporter creates a new importer backed by the given repository
+func NewMenuItemImporter(repo MenuItemBulkCreator) *MenuItemImporter {
+	return &MenuItemImporter{repo: repo}
+}
+
+// ImportCSV validates every row of a CSV file and inserts all of them in batches.
+// The file must have a header row with name, price and category columns; description
+// and is_available are optional. Nothing is inserted if any row is invalid.
+func (imp *MenuItemImporter) ImportCSV(ctx context.Context, r io.Reader) (*ImportResult, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemImporter.ImportCSV")
+	defer span.End()
+
+	start := time.Now()
+	logger := logging.FromContext(ctx)
+
+	items, rowErrors, err := parseMenuItemCSV(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImport, err)
+	}
+	if len(rowErrors) > 0 {
+		return &ImportResult{Errors: rowErrors}, fmt.Errorf("%w: %d invalid rows", ErrInvalidImport, len(rowErrors))
+	}
+
+	batches := 0
+	err = guardExec(func() error {
+		return imp.repo.BulkCreate(ctx, items, importBatchSize, func(done, total int) {
+			batches++
+			logger.Info("Menu item import progress",
+				slog.Int("done", done),
+				slog.Int("total", total))
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import menu items: %w", err)
+	}
+	metrics.MenuItemsCreated.Add(float64(len(items)))
+
+	return &ImportResult{
+		Imported:   len(items),
+		Batches:    batches,
+		DurationMs: time.Since(start).Milliseconds(),
+	}, nil
+}
+
+// parseMenuItemCSV converts CSV rows to menu items, collecting per-row validation errors
+func parseMenuItemCSV(r io.Reader) ([]models.MenuItem, []ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"name", "price", "category"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("missing required column %q", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var items []models.MenuItem
+	var rowErrors []ImportRowError
+	addError := func(line int, format string, args ...interface{}) {
+		if len(rowErrors) < maxReportedImportErrors {
+			rowErrors = append(rowErrors, ImportRowError{Line: line, Message: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		// Errors from the underlying reader (e.g. a body size limit) are not recoverable
+		var parseErr *csv.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			return nil, nil, fmt.Errorf("failed to read row: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			addError(line, "malformed row: %v", err)
+			continue
+		}
+
+		item := models.MenuItem{
+			Name:        field(record, "name"),
+			Category:    field(record, "category"),
+			IsAvailable: true,
+		}
+
+		if item.Name == "" || len(item.Name) > 100 {
+			addError(line, "name must be between 1 and 100 characters")
+			continue
+		}
+		if !ValidCategories[item.Category] {
+			addError(line, "invalid category %q", item.Category)
+			continue
+		}
+
+		price, err := decimal.NewFromString(field(record, "price"))
+		if err != nil || !price.IsPositive() {
+			addError(line, "price must be a positive decimal")
+			continue
+		}
+		item.Price = price
+
+		if description := field(record, "description"); description != "" {
+			item.Description = &description
+		}
+
+		if available := field(record, "is_available"); available != "" {
+			value, err := strconv.ParseBool(available)
+			if err != nil {
+				addError(line, "is_available must be true or false")
+				continue
+			}
+			item.IsAvailable = value
+		}
+
+		items = append(items, item)
+	}
+
+	if len(items) == 0 && len(rowErrors) == 0 {
+		return nil, nil, errors.New("file contains no rows")
+	}
+
+	return items, rowErrors, nil
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/realtime"
+)
+
+// ErrInvalidPrice is returned when a channel price is not positive
+var ErrInvalidPrice = errors.New("invalid price")
+
+// ErrPriceNotFound is returned when removing a channel price a menu item does not have
+var ErrPriceNotFound = errors.New("price not found")
+
+// MenuItemPriceRepository abstracts storage of menu items' channel prices
+type MenuItemPriceRepository interface {
+	SetPrice(ctx context.Context, price *models.MenuItemPrice) error
+	DeletePrice(ctx context.Context, menuItemID int, channel string) (bool, error)
+}
+
+var _ MenuItemPriceRepository = (*models.MenuItemQuery)(nil)
+
+// MenuItemPriceRequest represents the price of a menu item on a channel
+type MenuItemPriceRequest struct {
+	Price decimal.Decimal `json:"price" validate:"required" swaggertype:"string" example:"14.00"`
+}
+
+// MenuItemPriceResponse represents the price of a menu item on a channel
+type MenuItemPriceResponse struct {
+	Channel string          `json:"channel" example:"delivery"`
+	Price   decimal.Decimal `json:"price" swaggertype:"string" example:"14.00"`
+}
+
+// priceFor switches the response to the price of its channel tier, if it has one
+func (r *MenuItemResponse) priceFor(channel string) {
+	r.Channel = channel
+	for _, tier := range r.Prices {
+		if tier.Channel == channel {
+			r.Price = tier.Price
+			return
+		}
+	}
+}
+
+// MenuItemPriceService manages menu items' channel prices
+type MenuItemPriceService interface {
+	SetPrice(ctx context.Context, id int, channel string, req MenuItemPriceRequest) (*MenuItemResponse, error)
+	DeletePrice(ctx context.Context, id int, channel string) error
+}
+
+// menuItemPriceService handles business logic for channel prices
+type menuItemPriceService struct {
+	items  MenuItemRepository
+	prices MenuItemPriceRepository
+	events EventPublisher
+}
+
+// NewMenuItemPriceService creates a channel price service. Items whose prices
+// change are published to events as updated when it is non-nil.
+func NewMenuItemPriceService(items MenuItemRepository, prices MenuItemPriceRepository, events EventPublisher) MenuItemPriceService {
+	return &menuItemPriceService{items: items, prices: prices, events: events}
+}
+
+// SetPrice sets the price of a menu item on a channel and returns the item with
+// its prices
+func (s *menuItemPriceService) SetPrice(ctx context.Context, id int, channel string, req MenuItemPriceRequest) (*MenuItemResponse, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemPriceService.SetPrice")
+	defer span.End()
+
+	if !validChannel(channel) {
+		return nil, fmt.Errorf("%w: %q is not one of dine_in, takeaway, delivery", ErrInvalidChannel, channel)
+	}
+	if !req.Price.IsPositive() {
+		return nil, fmt.Errorf("%w: price must be greater than 0", ErrInvalidPrice)
+	}
+
+	ctx = database.UsePrimary(ctx)
+	if _, err := guard(func() (*models.MenuItem, error) { return s.items.FindByID(ctx, id) }); err != nil {
+		return nil, fmt.Errorf("failed to find menu item with ID %d: %w", id, err)
+	}
+
+	price := &models.MenuItemPrice{MenuItemID: id, Channel: channel, Price: req.Price}
+	if err := guardExec(func() error { return s.prices.SetPrice(ctx, price) }); err != nil {
+		return nil, fmt.Errorf("failed to set %s price of menu item %d: %w", channel, id, err)
+	}
+
+	return s.updated(ctx, id)
+}
+
+// DeletePrice removes the price of a menu item on a channel, which then falls back
+// to the item's own price
+func (s *menuItemPriceService) DeletePrice(ctx context.Context, id int, channel string) error {
+	ctx, span := tracer.Start(ctx, "MenuItemPriceService.DeletePrice")
+	defer span.End()
+
+	if !validChannel(channel) {
+		return fmt.Errorf("%w: %q is not one of dine_in, takeaway, delivery", ErrInvalidChannel, channel)
+	}
+
+	ctx = database.UsePrimary(ctx)
+	if _, err := guard(func() (*models.MenuItem, error) { return s.items.FindByID(ctx, id) }); err != nil {
+		return fmt.Errorf("failed to find menu item with ID %d: %w", id, err)
+	}
+	deleted, err := guard(func() (bool, error) { return s.prices.DeletePrice(ctx, id, channel) })
+	if err != nil {
+		return fmt.Errorf("failed to remove %s price of menu item %d: %w", channel, id, err)
+	}
+	if !deleted {
+		return fmt.Errorf("%w: menu item %d has no %s price", ErrPriceNotFound, id, channel)
+	}
+
+	_, err = s.updated(ctx, id)
+	return err
+}
+
+// updated reloads a menu item with its prices and publishes it as updated
+func (s *menuItemPriceService) updated(ctx context.Context, id int) (*MenuItemResponse, error) {
+	item, err := guard(func() (*models.MenuItem, error) {
+		return s.items.FindByID(ctx, id, database.WithRelations("Prices"))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload menu item with ID %d: %w", id, err)
+	}
+
+	response := newMenuItemResponse(item)
+	if s.events != nil {
+		s.events.Publish(realtime.Event{Type: EventMenuItemUpdated, Data: response, Restaurant: database.RestaurantID(ctx)})
+	}
+	return response, nil
+}
@@ -2,25 +2,95 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"time"
 
 	"github.com/shopspring/decimal"
-	"github.com/uptrace/bun"
 
+	"github.com/Zughayyar/agora-server/internal/database"
 	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/metrics"
+	"github.com/Zughayyar/agora-server/internal/realtime"
+	"github.com/Zughayyar/agora-server/internal/tracing"
 )
 
-// MenuItemService handles business logic for menu items
-type MenuItemService struct {
-	db    *bun.DB
-	query *models.MenuItemQuery
+// tracer creates spans for service-layer operations
+var tracer = tracing.Tracer("github.com/Zughayyar/agora-server/internal/services")
+
+// MenuItemRepository abstracts menu item storage
+type MenuItemRepository interface {
+	All(ctx context.Context, opts ...database.QueryOption) ([]models.MenuItem, error)
+	WithDeleted(ctx context.Context, opts ...database.QueryOption) ([]models.MenuItem, error)
+	OnlyDeleted(ctx context.Context, opts ...database.QueryOption) ([]models.MenuItem, error)
+	FindByID(ctx context.Context, id int, opts ...database.QueryOption) (*models.MenuItem, error)
+	FindByIDWithDeleted(ctx context.Context, id int, opts ...database.QueryOption) (*models.MenuItem, error)
+	ByCategory(ctx context.Context, category string, opts ...database.QueryOption) ([]models.MenuItem, error)
+	Available(ctx context.Context, opts ...database.QueryOption) ([]models.MenuItem, error)
+	Search(ctx context.Context, term string, opts ...database.QueryOption) ([]models.MenuItem, error)
+	Create(ctx context.Context, item *models.MenuItem) error
+	Update(ctx context.Context, item *models.MenuItem) error
+	SoftDelete(ctx context.Context, item *models.MenuItem) error
+	Restore(ctx context.Context, item *models.MenuItem) error
+	ForceDelete(ctx context.Context, item *models.MenuItem) error
 }
 
-// NewMenuItemService creates a new menu item service
-func NewMenuItemService(db *bun.DB) *MenuItemService {
-	return &MenuItemService{
-		db:    db,
-		query: models.NewMenuItemQuery(db),
+// The Bun-backed query builder is the default repository implementation
+var _ MenuItemRepository = (*models.MenuItemQuery)(nil)
+
+// MenuItemService defines business operations on menu items
+type MenuItemService interface {
+	CreateMenuItem(ctx context.Context, req CreateMenuItemRequest) (*MenuItemResponse, error)
+	GetAllMenuItems(ctx context.Context, opts QueryOptions) ([]MenuItemResponse, error)
+	GetMenuItemByID(ctx context.Context, id int, opts QueryOptions) (*MenuItemResponse, error)
+	GetMenuItemsByCategory(ctx context.Context, category string, opts QueryOptions) ([]MenuItemResponse, error)
+	GetAvailableMenuItems(ctx context.Context, opts QueryOptions) ([]MenuItemResponse, error)
+	UpdateMenuItem(ctx context.Context, id int, req UpdateMenuItemRequest) (*MenuItemResponse, error)
+	SoftDeleteMenuItem(ctx context.Context, id int) error
+	RestoreMenuItem(ctx context.Context, id int) (*MenuItemResponse, error)
+	ForceDeleteMenuItem(ctx context.Context, id int) error
+	GetDeletedMenuItems(ctx context.Context, opts QueryOptions) ([]MenuItemResponse, error)
+	GetAllMenuItemsWithDeleted(ctx context.Context, opts QueryOptions) ([]MenuItemResponse, error)
+	SearchMenuItems(ctx context.Context, query string, opts QueryOptions) ([]MenuItemResponse, error)
+}
+
+// EventPublisher broadcasts change events to real-time clients
+type EventPublisher interface {
+	Publish(event realtime.Event)
+}
+
+// Menu item change events
+const (
+	EventMenuItemCreated  = "menu_item.created"
+	EventMenuItemUpdated  = "menu_item.updated"
+	EventMenuItemDeleted  = "menu_item.deleted"
+	EventMenuItemRestored = "menu_item.restored"
+	EventMenuItemPurged   = "menu_item.purged"
+)
+
+// menuItemService handles business logic for menu items
+type menuItemService struct {
+	repo      MenuItemRepository
+	rules     PricingRuleRepository
+	locations LocationRepository
+	events    EventPublisher
+}
+
+// NewMenuItemService creates a new menu item service backed by the given repository.
+// Items are served at the prices of the pricing rules in effect when rules is
+// non-nil, and can be read for a location when locations is non-nil. Changes are
+// published to events when it is non-nil.
+func NewMenuItemService(repo MenuItemRepository, rules PricingRuleRepository, locations LocationRepository, events EventPublisher) MenuItemService {
+	return &menuItemService{repo: repo, rules: rules, locations: locations, events: events}
+}
+
+// publish broadcasts a change event at the restaurant of ctx if a publisher is
+// configured
+func (s *menuItemService) publish(ctx context.Context, eventType string, data interface{}) {
+	if s.events != nil {
+		s.events.Publish(realtime.Event{Type: eventType, Data: data, Restaurant: database.RestaurantID(ctx)})
 	}
 }
 
@@ -28,18 +98,121 @@ func NewMenuItemService(db *bun.DB) *MenuItemService {
 type CreateMenuItemRequest struct {
 	Name        string          `json:"name" validate:"required,min=1,max=100"`
 	Description *string         `json:"description,omitempty"`
-	Price       decimal.Decimal `json:"price" validate:"required,gt=0"`
+	Price       decimal.Decimal `json:"price" validate:"required,gt=0" swaggertype:"string" example:"12.50"`
 	Category    string          `json:"category" validate:"required,oneof=appetizer main dessert drink side 'fast food'"`
 	IsAvailable *bool           `json:"is_available,omitempty"`
+	// Kitchen station preparing the item; its category's station when omitted
+	Station *string `json:"station,omitempty" example:"grill"`
+	// Minutes the item takes to prepare; the kitchen's default when omitted
+	PrepMinutes *int `json:"prep_minutes,omitempty" example:"12"`
+}
+
+// QueryOptions controls how menu items are loaded
+type QueryOptions struct {
+	Include []string // Related resources to eager-load, see models.MenuItemRelations
+	Expand  []string // Sub-resources to embed in the responses, see MenuItemExpansions
+	Channel string   // Order channel to price the items for, the items' own price if empty
+	// Location whose availability and prices override the menu's, none if 0
+	Location int
+	// Lists only items whose approved reviews average at least this rating, from 1 to
+	// 5; any item if 0. Reads of a single item ignore it.
+	MinRating float64
+}
+
+// ErrInvalidInclude is returned when an unknown relation is requested via ?include=
+var ErrInvalidInclude = errors.New("invalid include")
+
+// ErrInvalidExpand is returned when an unknown sub-resource is requested via ?expand=
+var ErrInvalidExpand = errors.New("invalid expand")
+
+// ErrInvalidChannel is returned when menu items are priced for an unknown order channel
+var ErrInvalidChannel = errors.New("invalid channel")
+
+// ErrInvalidMinRating is returned when menu items are filtered by a rating outside 1 to 5
+var ErrInvalidMinRating = errors.New("invalid min_rating")
+
+// validChannel reports whether channel is an order channel items can be priced for
+func validChannel(channel string) bool {
+	switch channel {
+	case models.OrderChannelDineIn, models.OrderChannelTakeaway, models.OrderChannelDelivery:
+		return true
+	}
+	return false
+}
+
+// queryOptions validates the requested expansions, channel and rating and resolves the
+// requested includes and rating to repository query options. Pricing for a channel
+// loads the items' price tiers.
+func (o QueryOptions) queryOptions() ([]database.QueryOption, error) {
+	for _, name := range o.Expand {
+		if _, ok := MenuItemExpansions[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown sub-resource %q", ErrInvalidExpand, name)
+		}
+	}
+	if o.Channel != "" && !validChannel(o.Channel) {
+		return nil, fmt.Errorf("%w: %q is not one of dine_in, takeaway, delivery", ErrInvalidChannel, o.Channel)
+	}
+	if o.MinRating != 0 && !(o.MinRating >= 1 && o.MinRating <= 5) {
+		return nil, fmt.Errorf("%w: must be between 1 and 5", ErrInvalidMinRating)
+	}
+	if o.Location < 0 {
+		return nil, fmt.Errorf("%w: location must be a location ID", ErrInvalidLocation)
+	}
+
+	relations := make([]string, 0, len(o.Include)+1)
+	for _, name := range o.Include {
+		relation, ok := models.MenuItemRelations[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown relation %q", ErrInvalidInclude, name)
+		}
+		relations = append(relations, relation)
+	}
+	if o.Channel != "" && !slices.Contains(o.Include, "prices") {
+		relations = append(relations, "Prices")
+	}
+
+	var opts []database.QueryOption
+	if len(relations) > 0 {
+		opts = append(opts, database.WithRelations(relations...))
+	}
+	if o.MinRating != 0 {
+		opts = append(opts, models.WithMinRating(o.MinRating))
+	}
+	return opts, nil
+}
+
+// expand prices responses for the requested channel and embeds the requested
+// sub-resources in them
+func (o QueryOptions) expand(ctx context.Context, responses []MenuItemResponse) error {
+	if o.Channel != "" {
+		showPrices := slices.Contains(o.Include, "prices")
+		for i := range responses {
+			responses[i].priceFor(o.Channel)
+			if !showPrices {
+				responses[i].Prices = nil
+			}
+		}
+	}
+	for _, name := range o.Expand {
+		if err := MenuItemExpansions[name](ctx, responses); err != nil {
+			return fmt.Errorf("failed to expand %s: %w", name, err)
+		}
+	}
+	return nil
 }
 
 // UpdateMenuItemRequest represents the data needed to update a menu item
 type UpdateMenuItemRequest struct {
 	Name        *string          `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
 	Description *string          `json:"description,omitempty"`
-	Price       *decimal.Decimal `json:"price,omitempty" validate:"omitempty,gt=0"`
+	Price       *decimal.Decimal `json:"price,omitempty" validate:"omitempty,gt=0" swaggertype:"string" example:"12.50"`
 	Category    *string          `json:"category,omitempty" validate:"omitempty,oneof=appetizer main dessert drink side 'fast food'"`
 	IsAvailable *bool            `json:"is_available,omitempty"`
+	// Kitchen station preparing the item; an empty string routes it to its
+	// category's station again
+	Station *string `json:"station,omitempty" example:"grill"`
+	// Minutes the item takes to prepare; 0 uses the kitchen's default again
+	PrepMinutes *int `json:"prep_minutes,omitempty" example:"12"`
 }
 
 // MenuItemResponse represents the response structure for menu items
@@ -47,16 +220,53 @@ type MenuItemResponse struct {
 	ID          int             `json:"id"`
 	Name        string          `json:"name"`
 	Description *string         `json:"description,omitempty"`
-	Price       decimal.Decimal `json:"price"`
-	Category    string          `json:"category"`
-	IsAvailable bool            `json:"is_available"`
-	CreatedAt   string          `json:"created_at"`
-	UpdatedAt   string          `json:"updated_at"`
-	DeletedAt   *string         `json:"deleted_at,omitempty"`
+	Price       decimal.Decimal `json:"price" swaggertype:"string" example:"12.50"`
+	// Whether Price includes tax, per the restaurant's pricing mode
+	PriceIncludesTax bool   `json:"price_includes_tax"`
+	Category         string `json:"category"`
+	IsAvailable      bool   `json:"is_available"`
+	// Kitchen station preparing the item, its own or its category's, and the
+	// minutes it takes to prepare
+	Station     string `json:"station" example:"grill"`
+	PrepMinutes int    `json:"prep_minutes" example:"12"`
+	// Mean rating of the item's approved reviews, to one decimal and 0 without
+	// reviews, and their number
+	AverageRating float64    `json:"average_rating" example:"4.6"`
+	ReviewCount   int        `json:"review_count" example:"12"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+
+	// Price before the pricing rule in effect, set along with the rule's name when
+	// one discounts the item
+	RegularPrice *decimal.Decimal `json:"regular_price,omitempty" swaggertype:"string" example:"15.00"`
+	PricingRule  *string          `json:"pricing_rule,omitempty" example:"Happy hour"`
+	// Channel Price applies to when the items were requested for one with ?channel=
+	Channel string `json:"channel,omitempty" example:"delivery"`
+	// Location whose overrides Price and IsAvailable reflect, when the items were
+	// requested for one with ?location=
+	Location int `json:"location,omitempty" example:"2"`
+	// Prices on specific order channels, loaded with ?include=prices
+	Prices []MenuItemPriceResponse `json:"prices,omitempty"`
+
+	// Sub-resources requested with ?expand=, omitted otherwise
+	Expanded *MenuItemExpanded `json:"expanded,omitempty"`
 }
 
 // CreateMenuItem creates a new menu item
-func (s *MenuItemService) CreateMenuItem(ctx context.Context, req CreateMenuItemRequest) (*MenuItemResponse, error) {
+func (s *menuItemService) CreateMenuItem(ctx context.Context, req CreateMenuItemRequest) (*MenuItemResponse, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemService.CreateMenuItem")
+	defer span.End()
+
+	if req.Station != nil {
+		if problem := stationProblem(*req.Station); problem != "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidStation, problem)
+		}
+	}
+	if req.PrepMinutes != nil && (*req.PrepMinutes < 0 || *req.PrepMinutes > maxPrepMinutes) {
+		return nil, fmt.Errorf("%w: prep_minutes must be between 0 and %d", ErrInvalidPrepTime, maxPrepMinutes)
+	}
+
 	// Create new menu item
 	item := &models.MenuItem{
 		Name:        req.Name,
@@ -70,19 +280,38 @@ func (s *MenuItemService) CreateMenuItem(ctx context.Context, req CreateMenuItem
 	if req.IsAvailable != nil {
 		item.IsAvailable = *req.IsAvailable
 	}
+	if req.Station != nil && *req.Station != "" {
+		item.Station = req.Station
+	}
+	if req.PrepMinutes != nil && *req.PrepMinutes > 0 {
+		item.PrepMinutes = req.PrepMinutes
+	}
 
 	// Insert into database
-	_, err := s.db.NewInsert().Model(item).Exec(ctx)
+	err := guardExec(func() error {
+		return s.repo.Create(ctx, item)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create menu item: %w", err)
 	}
+	metrics.MenuItemsCreated.Inc()
 
-	return s.toResponse(item), nil
+	response := s.toResponse(item)
+	s.publish(ctx, EventMenuItemCreated, response)
+	return response, nil
 }
 
 // GetAllMenuItems retrieves all active (non-deleted) menu items
-func (s *MenuItemService) GetAllMenuItems(ctx context.Context) ([]MenuItemResponse, error) {
-	items, err := s.query.All(ctx)
+func (s *menuItemService) GetAllMenuItems(ctx context.Context, opts QueryOptions) ([]MenuItemResponse, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemService.GetAllMenuItems")
+	defer span.End()
+
+	queryOpts, err := opts.queryOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := guard(func() ([]models.MenuItem, error) { return s.repo.All(ctx, queryOpts...) })
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve menu items: %w", err)
 	}
@@ -92,26 +321,46 @@ func (s *MenuItemService) GetAllMenuItems(ctx context.Context) ([]MenuItemRespon
 		responses[i] = *s.toResponse(&item)
 	}
 
+	if err := s.expand(ctx, opts, responses); err != nil {
+		return nil, err
+	}
 	return responses, nil
 }
 
 // GetMenuItemByID retrieves a specific menu item by ID
-func (s *MenuItemService) GetMenuItemByID(ctx context.Context, id int) (*MenuItemResponse, error) {
-	item, err := s.query.FindByID(ctx, id)
+func (s *menuItemService) GetMenuItemByID(ctx context.Context, id int, opts QueryOptions) (*MenuItemResponse, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemService.GetMenuItemByID")
+	defer span.End()
+
+	opts.MinRating = 0
+	queryOpts, err := opts.queryOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := guard(func() (*models.MenuItem, error) { return s.repo.FindByID(ctx, id, queryOpts...) })
 	if err != nil {
 		return nil, fmt.Errorf("failed to find menu item with ID %d: %w", id, err)
 	}
 
-	return s.toResponse(item), nil
+	responses := []MenuItemResponse{*s.toResponse(item)}
+	if err := s.expand(ctx, opts, responses); err != nil {
+		return nil, err
+	}
+	return &responses[0], nil
 }
 
 // GetMenuItemsByCategory retrieves menu items by category
-func (s *MenuItemService) GetMenuItemsByCategory(ctx context.Context, category string) ([]MenuItemResponse, error) {
-	var items []models.MenuItem
-	err := s.db.NewSelect().
-		Model(&items).
-		Where("category = ? AND deleted_at IS NULL", category).
-		Scan(ctx)
+func (s *menuItemService) GetMenuItemsByCategory(ctx context.Context, category string, opts QueryOptions) ([]MenuItemResponse, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemService.GetMenuItemsByCategory")
+	defer span.End()
+
+	queryOpts, err := opts.queryOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := guard(func() ([]models.MenuItem, error) { return s.repo.ByCategory(ctx, category, queryOpts...) })
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve menu items by category %s: %w", category, err)
@@ -122,16 +371,30 @@ func (s *MenuItemService) GetMenuItemsByCategory(ctx context.Context, category s
 		responses[i] = *s.toResponse(&item)
 	}
 
+	if err := s.expand(ctx, opts, responses); err != nil {
+		return nil, err
+	}
 	return responses, nil
 }
 
 // GetAvailableMenuItems retrieves only available menu items
-func (s *MenuItemService) GetAvailableMenuItems(ctx context.Context) ([]MenuItemResponse, error) {
-	var items []models.MenuItem
-	err := s.db.NewSelect().
-		Model(&items).
-		Where("is_available = true AND deleted_at IS NULL").
-		Scan(ctx)
+func (s *menuItemService) GetAvailableMenuItems(ctx context.Context, opts QueryOptions) ([]MenuItemResponse, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemService.GetAvailableMenuItems")
+	defer span.End()
+
+	queryOpts, err := opts.queryOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	// A location may offer items the menu has marked unavailable, so its menu is
+	// filtered once its overrides apply
+	items, err := guard(func() ([]models.MenuItem, error) {
+		if opts.Location != 0 {
+			return s.repo.All(ctx, queryOpts...)
+		}
+		return s.repo.Available(ctx, queryOpts...)
+	})
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve available menu items: %w", err)
@@ -142,13 +405,22 @@ func (s *MenuItemService) GetAvailableMenuItems(ctx context.Context) ([]MenuItem
 		responses[i] = *s.toResponse(&item)
 	}
 
-	return responses, nil
+	if err := s.expand(ctx, opts, responses); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(responses, func(r MenuItemResponse) bool { return !r.IsAvailable }), nil
 }
 
 // UpdateMenuItem updates an existing menu item
-func (s *MenuItemService) UpdateMenuItem(ctx context.Context, id int, req UpdateMenuItemRequest) (*MenuItemResponse, error) {
+func (s *menuItemService) UpdateMenuItem(ctx context.Context, id int, req UpdateMenuItemRequest) (*MenuItemResponse, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemService.UpdateMenuItem")
+	defer span.End()
+
+	// Read-modify-write: read from the primary to avoid replication lag
+	ctx = database.UsePrimary(ctx)
+
 	// First, get the existing item
-	item, err := s.query.FindByID(ctx, id)
+	item, err := guard(func() (*models.MenuItem, error) { return s.repo.FindByID(ctx, id) })
 	if err != nil {
 		return nil, fmt.Errorf("failed to find menu item with ID %d: %w", id, err)
 	}
@@ -169,40 +441,70 @@ func (s *MenuItemService) UpdateMenuItem(ctx context.Context, id int, req Update
 	if req.IsAvailable != nil {
 		item.IsAvailable = *req.IsAvailable
 	}
+	if req.Station != nil {
+		if problem := stationProblem(*req.Station); problem != "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidStation, problem)
+		}
+		item.Station = req.Station
+		if *req.Station == "" {
+			item.Station = nil
+		}
+	}
+	if req.PrepMinutes != nil {
+		if *req.PrepMinutes < 0 || *req.PrepMinutes > maxPrepMinutes {
+			return nil, fmt.Errorf("%w: prep_minutes must be between 0 and %d", ErrInvalidPrepTime, maxPrepMinutes)
+		}
+		item.PrepMinutes = req.PrepMinutes
+		if *req.PrepMinutes == 0 {
+			item.PrepMinutes = nil
+		}
+	}
 
 	// Update in database
-	_, err = s.db.NewUpdate().
-		Model(item).
-		Where("id = ?", id).
-		Exec(ctx)
+	err = guardExec(func() error { return s.repo.Update(ctx, item) })
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to update menu item: %w", err)
 	}
 
-	return s.toResponse(item), nil
+	response := s.toResponse(item)
+	s.publish(ctx, EventMenuItemUpdated, response)
+	return response, nil
 }
 
 // SoftDeleteMenuItem marks a menu item as deleted (soft delete)
-func (s *MenuItemService) SoftDeleteMenuItem(ctx context.Context, id int) error {
+func (s *menuItemService) SoftDeleteMenuItem(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "MenuItemService.SoftDeleteMenuItem")
+	defer span.End()
+
+	// Read-modify-write: read from the primary to avoid replication lag
+	ctx = database.UsePrimary(ctx)
+
 	// Get the item first
-	item, err := s.query.FindByID(ctx, id)
+	item, err := guard(func() (*models.MenuItem, error) { return s.repo.FindByID(ctx, id) })
 	if err != nil {
 		return fmt.Errorf("failed to find menu item with ID %d: %w", id, err)
 	}
 
 	// Perform soft delete
-	if err := item.SoftDelete(ctx, s.db); err != nil {
+	if err := guardExec(func() error { return s.repo.SoftDelete(ctx, item) }); err != nil {
 		return fmt.Errorf("failed to soft delete menu item: %w", err)
 	}
 
+	s.publish(ctx, EventMenuItemDeleted, map[string]int{"id": id})
 	return nil
 }
 
 // RestoreMenuItem restores a soft-deleted menu item
-func (s *MenuItemService) RestoreMenuItem(ctx context.Context, id int) (*MenuItemResponse, error) {
+func (s *menuItemService) RestoreMenuItem(ctx context.Context, id int) (*MenuItemResponse, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemService.RestoreMenuItem")
+	defer span.End()
+
+	// Read-modify-write: read from the primary to avoid replication lag
+	ctx = database.UsePrimary(ctx)
+
 	// Get the item including deleted ones
-	item, err := s.query.FindByIDWithDeleted(ctx, id)
+	item, err := guard(func() (*models.MenuItem, error) { return s.repo.FindByIDWithDeleted(ctx, id) })
 	if err != nil {
 		return nil, fmt.Errorf("failed to find menu item with ID %d: %w", id, err)
 	}
@@ -213,32 +515,49 @@ func (s *MenuItemService) RestoreMenuItem(ctx context.Context, id int) (*MenuIte
 	}
 
 	// Restore the item
-	if err := item.Restore(ctx, s.db); err != nil {
+	if err := guardExec(func() error { return s.repo.Restore(ctx, item) }); err != nil {
 		return nil, fmt.Errorf("failed to restore menu item: %w", err)
 	}
 
-	return s.toResponse(item), nil
+	response := s.toResponse(item)
+	s.publish(ctx, EventMenuItemRestored, response)
+	return response, nil
 }
 
 // ForceDeleteMenuItem permanently deletes a menu item from database
-func (s *MenuItemService) ForceDeleteMenuItem(ctx context.Context, id int) error {
+func (s *menuItemService) ForceDeleteMenuItem(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "MenuItemService.ForceDeleteMenuItem")
+	defer span.End()
+
+	// Read-modify-write: read from the primary to avoid replication lag
+	ctx = database.UsePrimary(ctx)
+
 	// Get the item including deleted ones
-	item, err := s.query.FindByIDWithDeleted(ctx, id)
+	item, err := guard(func() (*models.MenuItem, error) { return s.repo.FindByIDWithDeleted(ctx, id) })
 	if err != nil {
 		return fmt.Errorf("failed to find menu item with ID %d: %w", id, err)
 	}
 
 	// Permanently delete
-	if err := item.ForceDelete(ctx, s.db); err != nil {
+	if err := guardExec(func() error { return s.repo.ForceDelete(ctx, item) }); err != nil {
 		return fmt.Errorf("failed to permanently delete menu item: %w", err)
 	}
 
+	s.publish(ctx, EventMenuItemPurged, map[string]int{"id": id})
 	return nil
 }
 
 // GetDeletedMenuItems retrieves all soft-deleted menu items
-func (s *MenuItemService) GetDeletedMenuItems(ctx context.Context) ([]MenuItemResponse, error) {
-	items, err := s.query.OnlyDeleted(ctx)
+func (s *menuItemService) GetDeletedMenuItems(ctx context.Context, opts QueryOptions) ([]MenuItemResponse, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemService.GetDeletedMenuItems")
+	defer span.End()
+
+	queryOpts, err := opts.queryOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := guard(func() ([]models.MenuItem, error) { return s.repo.OnlyDeleted(ctx, queryOpts...) })
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve deleted menu items: %w", err)
 	}
@@ -248,12 +567,23 @@ func (s *MenuItemService) GetDeletedMenuItems(ctx context.Context) ([]MenuItemRe
 		responses[i] = *s.toResponse(&item)
 	}
 
+	if err := s.expand(ctx, opts, responses); err != nil {
+		return nil, err
+	}
 	return responses, nil
 }
 
 // GetAllMenuItemsWithDeleted retrieves all menu items including soft-deleted ones
-func (s *MenuItemService) GetAllMenuItemsWithDeleted(ctx context.Context) ([]MenuItemResponse, error) {
-	items, err := s.query.WithDeleted(ctx)
+func (s *menuItemService) GetAllMenuItemsWithDeleted(ctx context.Context, opts QueryOptions) ([]MenuItemResponse, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemService.GetAllMenuItemsWithDeleted")
+	defer span.End()
+
+	queryOpts, err := opts.queryOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := guard(func() ([]models.MenuItem, error) { return s.repo.WithDeleted(ctx, queryOpts...) })
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve all menu items: %w", err)
 	}
@@ -263,18 +593,23 @@ func (s *MenuItemService) GetAllMenuItemsWithDeleted(ctx context.Context) ([]Men
 		responses[i] = *s.toResponse(&item)
 	}
 
+	if err := s.expand(ctx, opts, responses); err != nil {
+		return nil, err
+	}
 	return responses, nil
 }
 
 // SearchMenuItems searches menu items by name or description
-func (s *MenuItemService) SearchMenuItems(ctx context.Context, query string) ([]MenuItemResponse, error) {
-	var items []models.MenuItem
-	searchPattern := "%" + query + "%"
+func (s *menuItemService) SearchMenuItems(ctx context.Context, query string, opts QueryOptions) ([]MenuItemResponse, error) {
+	ctx, span := tracer.Start(ctx, "MenuItemService.SearchMenuItems")
+	defer span.End()
 
-	err := s.db.NewSelect().
-		Model(&items).
-		Where("(name ILIKE ? OR description ILIKE ?) AND deleted_at IS NULL", searchPattern, searchPattern).
-		Scan(ctx)
+	queryOpts, err := opts.queryOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := guard(func() ([]models.MenuItem, error) { return s.repo.Search(ctx, query, queryOpts...) })
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to search menu items: %w", err)
@@ -285,26 +620,83 @@ func (s *MenuItemService) SearchMenuItems(ctx context.Context, query string) ([]
 		responses[i] = *s.toResponse(&item)
 	}
 
+	if err := s.expand(ctx, opts, responses); err != nil {
+		return nil, err
+	}
 	return responses, nil
 }
 
+// expand completes read responses: it applies opts, then the overrides of the
+// requested location, and then discounts the prices by the pricing rules in effect. A
+// location's price replaces the item's on every channel.
+func (s *menuItemService) expand(ctx context.Context, opts QueryOptions, responses []MenuItemResponse) error {
+	if err := opts.expand(ctx, responses); err != nil {
+		return err
+	}
+	if opts.Location != 0 {
+		if err := applyLocation(ctx, s.locations, opts.Location, responses); err != nil {
+			return err
+		}
+	}
+	return applyPricingRules(ctx, s.rules, responses)
+}
+
+// applyPricingRules discounts responses by the pricing rules of repo in effect now, if
+// repo is non-nil
+func applyPricingRules(ctx context.Context, repo PricingRuleRepository, responses []MenuItemResponse) error {
+	if repo == nil || len(responses) == 0 {
+		return nil
+	}
+
+	rules, err := guard(func() ([]models.PricingRule, error) { return repo.List(ctx) })
+	if err != nil {
+		return fmt.Errorf("failed to retrieve pricing rules: %w", err)
+	}
+	now := time.Now()
+	for i := range responses {
+		response := &responses[i]
+		price, rule := discountedPrice(response.Price, rules, response.ID, response.Category, now)
+		if rule != nil {
+			regular := response.Price
+			response.RegularPrice = &regular
+			response.Price = price
+			response.PricingRule = &rule.Name
+		}
+	}
+	return nil
+}
+
 // toResponse converts a MenuItem model to MenuItemResponse
-func (s *MenuItemService) toResponse(item *models.MenuItem) *MenuItemResponse {
+func (s *menuItemService) toResponse(item *models.MenuItem) *MenuItemResponse {
+	return newMenuItemResponse(item)
+}
+
+// newMenuItemResponse converts a MenuItem model to MenuItemResponse
+func newMenuItemResponse(item *models.MenuItem) *MenuItemResponse {
 	response := &MenuItemResponse{
-		ID:          item.ID,
-		Name:        item.Name,
-		Description: item.Description,
-		Price:       item.Price,
-		Category:    item.Category,
-		IsAvailable: item.IsAvailable,
-		CreatedAt:   item.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:   item.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:               item.ID,
+		Name:             item.Name,
+		Description:      item.Description,
+		Price:            item.Price,
+		PriceIncludesTax: RestaurantSettings(item.RestaurantID).TaxIncluded(),
+		Category:         item.Category,
+		IsAvailable:      item.IsAvailable,
+		Station:          kitchen.stationFor(item),
+		PrepMinutes:      kitchen.prepMinutesFor(item),
+		AverageRating:    math.Round(item.AverageRating()*10) / 10,
+		ReviewCount:      item.ReviewCount,
+		CreatedAt:        localTime(item.CreatedAt),
+		UpdatedAt:        localTime(item.UpdatedAt),
 	}
 
 	if item.DeletedAt != nil {
-		deletedAt := item.DeletedAt.Format("2006-01-02T15:04:05Z07:00")
+		deletedAt := localTime(*item.DeletedAt)
 		response.DeletedAt = &deletedAt
 	}
 
+	for _, tier := range item.Prices {
+		response.Prices = append(response.Prices, MenuItemPriceResponse{Channel: tier.Channel, Price: tier.Price})
+	}
+
 	return response
 }
@@ -0,0 +1,433 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// LocationRepository abstracts storage of locations, their menu overrides and their
+// opening hours
+type LocationRepository interface {
+	List(ctx context.Context) ([]models.Location, error)
+	FindByID(ctx context.Context, id int) (*models.Location, error)
+	FindByName(ctx context.Context, name string) (*models.Location, error)
+	Create(ctx context.Context, location *models.Location) error
+	Update(ctx context.Context, location *models.Location) error
+	Delete(ctx context.Context, id int) error
+	Overrides(ctx context.Context, locationID int) ([]models.LocationMenuItem, error)
+	SetOverride(ctx context.Context, override *models.LocationMenuItem) error
+	DeleteOverride(ctx context.Context, locationID, menuItemID int) (bool, error)
+	Hours(ctx context.Context, locationID int) ([]models.LocationHours, error)
+	SetHours(ctx context.Context, locationID int, hours []models.LocationHours) error
+	Exceptions(ctx context.Context, locationID int, from time.Time) ([]models.LocationHourException, error)
+	SetException(ctx context.Context, exception *models.LocationHourException) error
+	DeleteException(ctx context.Context, locationID int, date time.Time) (bool, error)
+	Nearby(ctx context.Context, lat, lon, radius float64, limit int) ([]models.NearbyLocation, error)
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ LocationRepository = (*models.LocationQuery)(nil)
+
+// LocationService defines business operations on locations, their menus and their
+// opening hours
+type LocationService interface {
+	ListLocations(ctx context.Context) ([]LocationResponse, error)
+	CreateLocation(ctx context.Context, req LocationRequest) (*LocationResponse, error)
+	UpdateLocation(ctx context.Context, id int, req LocationRequest) (*LocationResponse, error)
+	DeleteLocation(ctx context.Context, id int) error
+	ListOverrides(ctx context.Context, id int) ([]LocationOverrideResponse, error)
+	SetOverride(ctx context.Context, id, itemID int, req LocationOverrideRequest) (*LocationOverrideResponse, error)
+	DeleteOverride(ctx context.Context, id, itemID int) error
+	GetHours(ctx context.Context, id int) (*LocationHoursResponse, error)
+	SetHours(ctx context.Context, id int, req LocationHoursRequest) (*LocationHoursResponse, error)
+	SetHoursException(ctx context.Context, id int, date string, req HoursExceptionRequest) (*HoursExceptionResponse, error)
+	DeleteHoursException(ctx context.Context, id int, date string) error
+	NearbyLocations(ctx context.Context, lat, lon, radius float64) ([]NearbyLocationResponse, error)
+}
+
+// Location errors
+var (
+	ErrLocationNotFound = errors.New("location not found")
+	ErrInvalidLocation  = errors.New("invalid location")
+	// ErrLocationExists is returned when another location has the same name
+	ErrLocationExists = errors.New("location already exists")
+	// ErrInvalidOverride is returned for an override that changes nothing or has a
+	// price that isn't positive
+	ErrInvalidOverride = errors.New("invalid override")
+	// ErrOverrideNotFound is returned when removing an override a location does not have
+	ErrOverrideNotFound = errors.New("override not found")
+)
+
+// Location limits
+const (
+	maxLocationNameLength    = 100
+	maxLocationAddressLength = 300
+	// Nearby searches default to a radius of defaultNearbyRadius meters, and list up
+	// to maxNearbyLocations locations within at most maxNearbyRadius
+	defaultNearbyRadius = 10000
+	maxNearbyRadius     = 100000
+	maxNearbyLocations  = 20
+)
+
+// LocationRequest creates or replaces a location. Latitude and longitude are set
+// together; a location without them isn't found by nearby searches.
+type LocationRequest struct {
+	Name      string   `json:"name" example:"Downtown"`
+	Address   *string  `json:"address,omitempty" example:"12 Rainbow St, Amman"`
+	Latitude  *float64 `json:"latitude,omitempty" example:"31.9539"`
+	Longitude *float64 `json:"longitude,omitempty" example:"35.9106"`
+}
+
+// LocationResponse represents the location data returned to clients
+type LocationResponse struct {
+	ID        int       `json:"id" example:"1"`
+	Name      string    `json:"name" example:"Downtown"`
+	Address   *string   `json:"address,omitempty" example:"12 Rainbow St, Amman"`
+	Latitude  *float64  `json:"latitude,omitempty" example:"31.9539"`
+	Longitude *float64  `json:"longitude,omitempty" example:"35.9106"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NearbyLocationResponse is a location found by a nearby search, with its distance in
+// meters from the point searched and whether it is open now
+type NearbyLocationResponse struct {
+	ID             int     `json:"id" example:"1"`
+	Name           string  `json:"name" example:"Downtown"`
+	Address        *string `json:"address,omitempty" example:"12 Rainbow St, Amman"`
+	Latitude       float64 `json:"latitude" example:"31.9539"`
+	Longitude      float64 `json:"longitude" example:"35.9106"`
+	DistanceMeters int     `json:"distance_meters" example:"850"`
+	IsOpenNow      bool    `json:"is_open_now"`
+}
+
+// LocationOverrideRequest sets what a location changes about a menu item. Omitted
+// fields keep the menu item's own; at least one must be set.
+type LocationOverrideRequest struct {
+	IsAvailable *bool            `json:"is_available,omitempty" example:"false"`
+	Price       *decimal.Decimal `json:"price,omitempty" swaggertype:"string" example:"13.50"`
+}
+
+// LocationOverrideResponse represents the override of a menu item at a location
+type LocationOverrideResponse struct {
+	MenuItemID  int              `json:"menu_item_id" example:"7"`
+	IsAvailable *bool            `json:"is_available,omitempty" example:"false"`
+	Price       *decimal.Decimal `json:"price,omitempty" swaggertype:"string" example:"13.50"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// locationService handles business logic for locations
+type locationService struct {
+	repo  LocationRepository
+	items MenuItemRepository
+}
+
+// NewLocationService creates a new location service. Overridden menu items are
+// looked up in items.
+func NewLocationService(repo LocationRepository, items MenuItemRepository) LocationService {
+	return &locationService{repo: repo, items: items}
+}
+
+// ListLocations returns all locations by name
+func (s *locationService) ListLocations(ctx context.Context) ([]LocationResponse, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.ListLocations")
+	defer span.End()
+
+	locations, err := guard(func() ([]models.Location, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve locations: %w", err)
+	}
+	responses := make([]LocationResponse, len(locations))
+	for i := range locations {
+		responses[i] = *newLocationResponse(&locations[i])
+	}
+	return responses, nil
+}
+
+// CreateLocation validates and stores a new location
+func (s *locationService) CreateLocation(ctx context.Context, req LocationRequest) (*LocationResponse, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.CreateLocation")
+	defer span.End()
+
+	location := &models.Location{}
+	if err := s.apply(ctx, location, req); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Create(ctx, location) }); err != nil {
+		return nil, fmt.Errorf("failed to create location: %w", err)
+	}
+	return newLocationResponse(location), nil
+}
+
+// UpdateLocation replaces the name, address and coordinates of a location
+func (s *locationService) UpdateLocation(ctx context.Context, id int, req LocationRequest) (*LocationResponse, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.UpdateLocation")
+	defer span.End()
+
+	ctx = database.UsePrimary(ctx)
+	location, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, location, req); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Update(ctx, location) }); err != nil {
+		return nil, fmt.Errorf("failed to update location %d: %w", id, err)
+	}
+	return newLocationResponse(location), nil
+}
+
+// DeleteLocation removes a location with its menu overrides and opening hours
+func (s *locationService) DeleteLocation(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "LocationService.DeleteLocation")
+	defer span.End()
+
+	ctx = database.UsePrimary(ctx)
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	if err := guardExec(func() error { return s.repo.Delete(ctx, id) }); err != nil {
+		return fmt.Errorf("failed to delete location %d: %w", id, err)
+	}
+	return nil
+}
+
+// ListOverrides returns the menu overrides of a location by menu item
+func (s *locationService) ListOverrides(ctx context.Context, id int) ([]LocationOverrideResponse, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.ListOverrides")
+	defer span.End()
+
+	if _, err := s.find(ctx, id); err != nil {
+		return nil, err
+	}
+	overrides, err := guard(func() ([]models.LocationMenuItem, error) { return s.repo.Overrides(ctx, id) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve overrides of location %d: %w", id, err)
+	}
+	responses := make([]LocationOverrideResponse, len(overrides))
+	for i := range overrides {
+		responses[i] = *newLocationOverrideResponse(&overrides[i])
+	}
+	return responses, nil
+}
+
+// SetOverride creates or replaces the override of a menu item at a location
+func (s *locationService) SetOverride(ctx context.Context, id, itemID int, req LocationOverrideRequest) (*LocationOverrideResponse, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.SetOverride")
+	defer span.End()
+
+	if req.IsAvailable == nil && req.Price == nil {
+		return nil, fmt.Errorf("%w: set is_available, price or both", ErrInvalidOverride)
+	}
+	if req.Price != nil && !req.Price.IsPositive() {
+		return nil, fmt.Errorf("%w: price must be greater than 0", ErrInvalidOverride)
+	}
+
+	ctx = database.UsePrimary(ctx)
+	if _, err := s.find(ctx, id); err != nil {
+		return nil, err
+	}
+	if _, err := guard(func() (*models.MenuItem, error) { return s.items.FindByID(ctx, itemID) }); err != nil {
+		return nil, fmt.Errorf("failed to find menu item with ID %d: %w", itemID, err)
+	}
+
+	override := &models.LocationMenuItem{LocationID: id, MenuItemID: itemID, IsAvailable: req.IsAvailable}
+	if req.Price != nil {
+		price := req.Price.Round(2)
+		override.Price = &price
+	}
+	if err := guardExec(func() error { return s.repo.SetOverride(ctx, override) }); err != nil {
+		return nil, fmt.Errorf("failed to override menu item %d at location %d: %w", itemID, id, err)
+	}
+	return newLocationOverrideResponse(override), nil
+}
+
+// DeleteOverride removes the override of a menu item at a location, which then
+// follows the menu again
+func (s *locationService) DeleteOverride(ctx context.Context, id, itemID int) error {
+	ctx, span := tracer.Start(ctx, "LocationService.DeleteOverride")
+	defer span.End()
+
+	ctx = database.UsePrimary(ctx)
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	deleted, err := guard(func() (bool, error) { return s.repo.DeleteOverride(ctx, id, itemID) })
+	if err != nil {
+		return fmt.Errorf("failed to remove override of menu item %d at location %d: %w", itemID, id, err)
+	}
+	if !deleted {
+		return fmt.Errorf("%w: location %d does not override menu item %d", ErrOverrideNotFound, id, itemID)
+	}
+	return nil
+}
+
+// NearbyLocations returns the locations within radius meters of a point, nearest
+// first. A radius of 0 is the default one.
+func (s *locationService) NearbyLocations(ctx context.Context, lat, lon, radius float64) ([]NearbyLocationResponse, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.NearbyLocations")
+	defer span.End()
+
+	if !validGeoPoint(models.GeoPoint{Lat: lat, Lng: lon}) {
+		return nil, fmt.Errorf("%w: lat must be between -90 and 90 and lon between -180 and 180", ErrInvalidLocation)
+	}
+	if radius == 0 {
+		radius = defaultNearbyRadius
+	}
+	if !(radius > 0 && radius <= maxNearbyRadius) {
+		return nil, fmt.Errorf("%w: radius must be a positive number of meters, at most %d", ErrInvalidLocation, maxNearbyRadius)
+	}
+
+	locations, err := guard(func() ([]models.NearbyLocation, error) {
+		return s.repo.Nearby(ctx, lat, lon, radius, maxNearbyLocations)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search nearby locations: %w", err)
+	}
+	now := time.Now()
+	responses := make([]NearbyLocationResponse, len(locations))
+	for i := range locations {
+		location := &locations[i]
+		open, err := locationOpen(ctx, s.repo, location.ID, now)
+		if err != nil {
+			return nil, err
+		}
+		responses[i] = NearbyLocationResponse{
+			ID:             location.ID,
+			Name:           location.Name,
+			Address:        location.Address,
+			Latitude:       *location.Latitude,
+			Longitude:      *location.Longitude,
+			DistanceMeters: int(math.Round(location.DistanceMeters)),
+			IsOpenNow:      open,
+		}
+	}
+	return responses, nil
+}
+
+// find loads a location by ID
+func (s *locationService) find(ctx context.Context, id int) (*models.Location, error) {
+	location, err := guard(func() (*models.Location, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLocationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find location %d: %w", id, err)
+	}
+	return location, nil
+}
+
+// apply validates req and copies it onto location. No other location may have the
+// name.
+func (s *locationService) apply(ctx context.Context, location *models.Location, req LocationRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	switch {
+	case req.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidLocation)
+	case len(req.Name) > maxLocationNameLength:
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidLocation, maxLocationNameLength)
+	case (req.Latitude == nil) != (req.Longitude == nil):
+		return fmt.Errorf("%w: latitude and longitude must be set together", ErrInvalidLocation)
+	case req.Latitude != nil && !validGeoPoint(models.GeoPoint{Lat: *req.Latitude, Lng: *req.Longitude}):
+		return fmt.Errorf("%w: latitude must be between -90 and 90 and longitude between -180 and 180", ErrInvalidLocation)
+	}
+	if req.Address != nil {
+		address := strings.TrimSpace(*req.Address)
+		switch {
+		case address == "":
+			req.Address = nil
+		case len(address) > maxLocationAddressLength:
+			return fmt.Errorf("%w: address must be at most %d characters", ErrInvalidLocation, maxLocationAddressLength)
+		default:
+			req.Address = &address
+		}
+	}
+
+	other, err := guard(func() (*models.Location, error) { return s.repo.FindByName(ctx, req.Name) })
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return fmt.Errorf("failed to look up location %q: %w", req.Name, err)
+	case other.ID != location.ID:
+		return fmt.Errorf("%w: %q", ErrLocationExists, req.Name)
+	}
+
+	location.Name = req.Name
+	location.Address = req.Address
+	location.Latitude, location.Longitude = req.Latitude, req.Longitude
+	return nil
+}
+
+// applyLocation replaces the prices and availability of responses with the overrides
+// of a location, if repo is non-nil. The location must be of the restaurant of ctx.
+func applyLocation(ctx context.Context, repo LocationRepository, id int, responses []MenuItemResponse) error {
+	if repo == nil {
+		return nil
+	}
+
+	if _, err := guard(func() (*models.Location, error) { return repo.FindByID(ctx, id) }); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: location %d does not exist", ErrInvalidLocation, id)
+		}
+		return fmt.Errorf("failed to find location %d: %w", id, err)
+	}
+	overrides, err := guard(func() ([]models.LocationMenuItem, error) { return repo.Overrides(ctx, id) })
+	if err != nil {
+		return fmt.Errorf("failed to retrieve overrides of location %d: %w", id, err)
+	}
+
+	byItem := make(map[int]*models.LocationMenuItem, len(overrides))
+	for i := range overrides {
+		byItem[overrides[i].MenuItemID] = &overrides[i]
+	}
+	for i := range responses {
+		response := &responses[i]
+		response.Location = id
+		override, ok := byItem[response.ID]
+		if !ok {
+			continue
+		}
+		if override.IsAvailable != nil {
+			response.IsAvailable = *override.IsAvailable
+		}
+		if override.Price != nil {
+			response.Price = *override.Price
+		}
+	}
+	return nil
+}
+
+// newLocationResponse converts a Location model to LocationResponse
+func newLocationResponse(location *models.Location) *LocationResponse {
+	return &LocationResponse{
+		ID:        location.ID,
+		Name:      location.Name,
+		Address:   location.Address,
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+		CreatedAt: localTime(location.CreatedAt),
+		UpdatedAt: localTime(location.UpdatedAt),
+	}
+}
+
+// newLocationOverrideResponse converts a LocationMenuItem model to
+// LocationOverrideResponse
+func newLocationOverrideResponse(override *models.LocationMenuItem) *LocationOverrideResponse {
+	return &LocationOverrideResponse{
+		MenuItemID:  override.MenuItemID,
+		IsAvailable: override.IsAvailable,
+		Price:       override.Price,
+		UpdatedAt:   localTime(override.UpdatedAt),
+	}
+}
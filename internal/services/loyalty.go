@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// LoyaltyProgram sets how customers earn and redeem loyalty points
+type LoyaltyProgram struct {
+	// Points earned per currency unit of a paid order's total, rounded down; 0
+	// disables earning
+	PointsPerUnit decimal.Decimal
+	// Orders below this total earn no points
+	MinOrder decimal.Decimal
+	// Discount a point is worth when redeemed; 0 disables redeeming
+	PointValue decimal.Decimal
+}
+
+// loyaltyProgram is the restaurant's loyalty program
+var loyaltyProgram = LoyaltyProgram{PointsPerUnit: decimal.NewFromInt(1), PointValue: decimal.RequireFromString("0.01")}
+
+// SetLoyaltyProgram sets how customers earn and redeem loyalty points. It must be
+// called before the server starts.
+func SetLoyaltyProgram(program LoyaltyProgram) {
+	loyaltyProgram = program
+}
+
+// earning returns the points the customer of an order earns when it is paid, or nil
+// when they earn none
+func (p LoyaltyProgram) earning(order *models.Order) *models.LoyaltyTransaction {
+	if order.CustomerPhone == nil || order.Total.LessThan(p.MinOrder) {
+		return nil
+	}
+	points := order.Total.Mul(p.PointsPerUnit).Floor().IntPart()
+	if points < 1 {
+		return nil
+	}
+	return &models.LoyaltyTransaction{CustomerPhone: *order.CustomerPhone, Type: models.LoyaltyEarn, Points: int(points)}
+}
+
+// LoyaltyRepository abstracts loyalty account storage
+type LoyaltyRepository interface {
+	FindAccount(ctx context.Context, customerPhone string) (*models.LoyaltyAccount, error)
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ LoyaltyRepository = (*models.LoyaltyQuery)(nil)
+
+// LoyaltyService defines business operations on loyalty accounts
+type LoyaltyService interface {
+	GetLoyalty(ctx context.Context, customerPhone string) (*LoyaltyResponse, error)
+}
+
+// ErrLoyaltyNotRedeemable is returned when an order's loyalty points can't be redeemed
+// on it
+var ErrLoyaltyNotRedeemable = errors.New("loyalty points not redeemable")
+
+// LoyaltyResponse is a customer's points balance, the discount it is worth, and the
+// ledger of points earned and redeemed, newest first
+type LoyaltyResponse struct {
+	CustomerID   string                       `json:"customer_id" example:"+962791234567"`
+	Points       int                          `json:"points" example:"240"`
+	Value        decimal.Decimal              `json:"value" swaggertype:"string" example:"2.40"`
+	Transactions []LoyaltyTransactionResponse `json:"transactions"`
+}
+
+// LoyaltyTransactionResponse is a change to a customer's points. Points is negative
+// for redemptions; Balance is the balance after the change.
+type LoyaltyTransactionResponse struct {
+	Type      string    `json:"type" enums:"earn,redeem" example:"earn"`
+	Points    int       `json:"points" example:"25"`
+	Balance   int       `json:"balance" example:"240"`
+	OrderID   *string   `json:"order_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// loyaltyService handles business logic for loyalty accounts
+type loyaltyService struct {
+	repo LoyaltyRepository
+}
+
+// NewLoyaltyService creates a new loyalty service
+func NewLoyaltyService(repo LoyaltyRepository) LoyaltyService {
+	return &loyaltyService{repo: repo}
+}
+
+// GetLoyalty returns the points of a customer, told apart by phone number. Customers
+// who never earned points have none.
+func (s *loyaltyService) GetLoyalty(ctx context.Context, customerPhone string) (*LoyaltyResponse, error) {
+	ctx, span := tracer.Start(ctx, "LoyaltyService.GetLoyalty")
+	defer span.End()
+
+	customerPhone = strings.TrimSpace(customerPhone)
+	account, err := guard(func() (*models.LoyaltyAccount, error) { return s.repo.FindAccount(ctx, customerPhone) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return newLoyaltyResponse(customerPhone, nil), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find loyalty account of %s: %w", customerPhone, err)
+	}
+	return newLoyaltyResponse(customerPhone, account), nil
+}
+
+// newLoyaltyResponse converts the LoyaltyAccount model of a customer, nil when they
+// have none, to LoyaltyResponse
+func newLoyaltyResponse(customerPhone string, account *models.LoyaltyAccount) *LoyaltyResponse {
+	response := &LoyaltyResponse{CustomerID: customerPhone, Value: decimal.Zero, Transactions: []LoyaltyTransactionResponse{}}
+	if account == nil {
+		return response
+	}
+
+	response.Points = account.Points
+	response.Value = loyaltyProgram.PointValue.Mul(decimal.NewFromInt(int64(account.Points))).Round(2)
+	for _, transaction := range account.Transactions {
+		response.Transactions = append(response.Transactions, LoyaltyTransactionResponse{
+			Type:      transaction.Type,
+			Points:    transaction.Points,
+			Balance:   transaction.Balance,
+			OrderID:   transaction.OrderID,
+			CreatedAt: localTime(transaction.CreatedAt),
+		})
+	}
+	return response
+}
+
+// redeemPoints discounts a new order by up to points of its customer's loyalty points,
+// spread over its lines like an amount coupon. It redeems only as many points as the
+// order's remaining total needs.
+func (s *orderService) redeemPoints(ctx context.Context, order *models.Order, points int) error {
+	switch {
+	case points < 1:
+		return fmt.Errorf("%w: loyalty_points must be at least 1", ErrInvalidOrder)
+	case !loyaltyProgram.PointValue.IsPositive():
+		return fmt.Errorf("%w: loyalty points can't be redeemed", ErrLoyaltyNotRedeemable)
+	case order.CustomerPhone == nil:
+		return fmt.Errorf("%w: customer_phone is required to redeem loyalty points", ErrLoyaltyNotRedeemable)
+	}
+
+	balance := 0
+	account, err := guard(func() (*models.LoyaltyAccount, error) {
+		return s.loyalty.FindAccount(database.UsePrimary(ctx), *order.CustomerPhone)
+	})
+	switch {
+	case err == nil:
+		balance = account.Points
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("failed to find loyalty account of %s: %w", *order.CustomerPhone, err)
+	}
+	if points > balance {
+		return fmt.Errorf("%w: the customer has %d points", ErrLoyaltyNotRedeemable, balance)
+	}
+
+	lines := make([]int, len(order.Items))
+	remaining := decimal.Zero
+	for i := range order.Items {
+		lines[i] = i
+		remaining = remaining.Add(order.Items[i].DiscountedTotal())
+	}
+	points = min(points, int(remaining.Div(loyaltyProgram.PointValue).Floor().IntPart()))
+	if points < 1 {
+		return nil
+	}
+
+	discount := loyaltyProgram.PointValue.Mul(decimal.NewFromInt(int64(points))).Round(2)
+	order.LoyaltyPoints = points
+	order.LoyaltyDiscount = spreadDiscount(order.Items, lines, models.DiscountAmount, discount)
+	order.LoyaltyRedemption = &models.LoyaltyTransaction{
+		CustomerPhone: *order.CustomerPhone,
+		Type:          models.LoyaltyRedeem,
+		Points:        -points,
+	}
+	return nil
+}
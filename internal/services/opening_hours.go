@@ -0,0 +1,335 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// Opening hours errors
+var (
+	ErrInvalidHours = errors.New("invalid opening hours")
+	// ErrHoursExceptionNotFound is returned when removing an exception a location does
+	// not have on the date
+	ErrHoursExceptionNotFound = errors.New("opening hours exception not found")
+	// ErrLocationClosed is returned for an online order placed at a location that is
+	// closed at the time of the order's slot
+	ErrLocationClosed = errors.New("location is closed")
+)
+
+// Opening hours limits
+const (
+	maxOpeningPeriods  = 50
+	maxHoursNoteLength = 200
+)
+
+// exceptionDateLayout is the format of the dates of hours exceptions
+const exceptionDateLayout = "2006-01-02"
+
+// OpeningPeriod is a period a location opens on a weekday (sun, mon, ...), from
+// OpensAt to ClosesAt (HH:MM, restaurant time). A period closing earlier than it opens
+// runs past midnight, and one closing when it opens lasts 24 hours.
+type OpeningPeriod struct {
+	Day      string `json:"day" example:"mon"`
+	OpensAt  string `json:"opens_at" example:"11:00"`
+	ClosesAt string `json:"closes_at" example:"23:00"`
+}
+
+// LocationHoursRequest replaces the weekly hours of a location. A location without
+// weekly hours is open around the clock, except as its exceptions say.
+type LocationHoursRequest struct {
+	Hours []OpeningPeriod `json:"hours"`
+}
+
+// HoursExceptionRequest sets the hours of a location on one date, replacing its
+// weekly hours that day. Omitting both times closes the location all day.
+type HoursExceptionRequest struct {
+	OpensAt  *string `json:"opens_at,omitempty" example:"12:00"`
+	ClosesAt *string `json:"closes_at,omitempty" example:"18:00"`
+	Note     *string `json:"note,omitempty" example:"New Year's Day"`
+}
+
+// HoursExceptionResponse is the hours of a location on one date
+type HoursExceptionResponse struct {
+	Date     string  `json:"date" example:"2026-12-25"`
+	Closed   bool    `json:"closed"`
+	OpensAt  *string `json:"opens_at,omitempty" example:"12:00"`
+	ClosesAt *string `json:"closes_at,omitempty" example:"18:00"`
+	Note     *string `json:"note,omitempty" example:"Christmas"`
+}
+
+// LocationHoursResponse is the weekly hours of a location in week order, Sunday first,
+// its exceptions from today on, and whether it is open now
+type LocationHoursResponse struct {
+	LocationID int                      `json:"location_id" example:"2"`
+	Timezone   string                   `json:"timezone" example:"Asia/Amman"`
+	Hours      []OpeningPeriod          `json:"hours"`
+	Exceptions []HoursExceptionResponse `json:"exceptions"`
+	IsOpenNow  bool                     `json:"is_open_now"`
+}
+
+// GetHours returns the opening hours of a location and whether it is open now
+func (s *locationService) GetHours(ctx context.Context, id int) (*LocationHoursResponse, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.GetHours")
+	defer span.End()
+
+	if _, err := s.find(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.hours(ctx, id)
+}
+
+// SetHours replaces the weekly hours of a location
+func (s *locationService) SetHours(ctx context.Context, id int, req LocationHoursRequest) (*LocationHoursResponse, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.SetHours")
+	defer span.End()
+
+	if len(req.Hours) > maxOpeningPeriods {
+		return nil, fmt.Errorf("%w: a location has at most %d periods a week", ErrInvalidHours, maxOpeningPeriods)
+	}
+	hours := make([]models.LocationHours, len(req.Hours))
+	for i, period := range req.Hours {
+		if !slices.Contains(weekdays, period.Day) {
+			return nil, fmt.Errorf("%w: period %d: day must be among %s", ErrInvalidHours, i+1, strings.Join(weekdays, ", "))
+		}
+		if _, err := parseClock(period.OpensAt); err != nil {
+			return nil, fmt.Errorf("%w: period %d: opens_at %v", ErrInvalidHours, i+1, err)
+		}
+		if _, err := parseClock(period.ClosesAt); err != nil {
+			return nil, fmt.Errorf("%w: period %d: closes_at %v", ErrInvalidHours, i+1, err)
+		}
+		hours[i] = models.LocationHours{Day: period.Day, OpensAt: period.OpensAt, ClosesAt: period.ClosesAt}
+	}
+	// Stored in week order, so they are listed that way
+	slices.SortStableFunc(hours, func(a, b models.LocationHours) int {
+		if day := slices.Index(weekdays, a.Day) - slices.Index(weekdays, b.Day); day != 0 {
+			return day
+		}
+		return strings.Compare(a.OpensAt, b.OpensAt)
+	})
+
+	ctx = database.UsePrimary(ctx)
+	if _, err := s.find(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.SetHours(ctx, id, hours) }); err != nil {
+		return nil, fmt.Errorf("failed to set hours of location %d: %w", id, err)
+	}
+	return s.hours(ctx, id)
+}
+
+// SetHoursException sets the hours of a location on a date (YYYY-MM-DD), replacing
+// any exception it had then
+func (s *locationService) SetHoursException(ctx context.Context, id int, date string, req HoursExceptionRequest) (*HoursExceptionResponse, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.SetHoursException")
+	defer span.End()
+
+	day, err := parseExceptionDate(date)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case (req.OpensAt == nil) != (req.ClosesAt == nil):
+		return nil, fmt.Errorf("%w: set both opens_at and closes_at, or neither to close all day", ErrInvalidHours)
+	case req.Note != nil && len(*req.Note) > maxHoursNoteLength:
+		return nil, fmt.Errorf("%w: note must be at most %d characters", ErrInvalidHours, maxHoursNoteLength)
+	}
+	if req.OpensAt != nil {
+		if _, err := parseClock(*req.OpensAt); err != nil {
+			return nil, fmt.Errorf("%w: opens_at %v", ErrInvalidHours, err)
+		}
+		if _, err := parseClock(*req.ClosesAt); err != nil {
+			return nil, fmt.Errorf("%w: closes_at %v", ErrInvalidHours, err)
+		}
+	}
+
+	ctx = database.UsePrimary(ctx)
+	if _, err := s.find(ctx, id); err != nil {
+		return nil, err
+	}
+	exception := &models.LocationHourException{
+		LocationID: id,
+		Date:       day,
+		OpensAt:    req.OpensAt,
+		ClosesAt:   req.ClosesAt,
+		Note:       req.Note,
+	}
+	if err := guardExec(func() error { return s.repo.SetException(ctx, exception) }); err != nil {
+		return nil, fmt.Errorf("failed to set hours of location %d on %s: %w", id, date, err)
+	}
+	return newHoursExceptionResponse(exception), nil
+}
+
+// DeleteHoursException removes the exception of a location on a date (YYYY-MM-DD),
+// which then follows its weekly hours again
+func (s *locationService) DeleteHoursException(ctx context.Context, id int, date string) error {
+	ctx, span := tracer.Start(ctx, "LocationService.DeleteHoursException")
+	defer span.End()
+
+	day, err := parseExceptionDate(date)
+	if err != nil {
+		return err
+	}
+
+	ctx = database.UsePrimary(ctx)
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	deleted, err := guard(func() (bool, error) { return s.repo.DeleteException(ctx, id, day) })
+	if err != nil {
+		return fmt.Errorf("failed to remove hours of location %d on %s: %w", id, date, err)
+	}
+	if !deleted {
+		return fmt.Errorf("%w: location %d has no exception on %s", ErrHoursExceptionNotFound, id, date)
+	}
+	return nil
+}
+
+// hours loads the opening hours of a location found in the restaurant of ctx
+func (s *locationService) hours(ctx context.Context, id int) (*LocationHoursResponse, error) {
+	now := time.Now()
+	hours, exceptions, err := loadHours(ctx, s.repo, id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LocationHoursResponse{
+		LocationID: id,
+		Timezone:   timezone().String(),
+		Hours:      make([]OpeningPeriod, len(hours)),
+		Exceptions: []HoursExceptionResponse{},
+		IsOpenNow:  openAt(hours, exceptions, now),
+	}
+	for i, h := range hours {
+		response.Hours[i] = OpeningPeriod{Day: h.Day, OpensAt: h.OpensAt, ClosesAt: h.ClosesAt}
+	}
+	today := calendarDate(now).Format(exceptionDateLayout)
+	for i := range exceptions {
+		if exceptions[i].Date.Format(exceptionDateLayout) >= today {
+			response.Exceptions = append(response.Exceptions, *newHoursExceptionResponse(&exceptions[i]))
+		}
+	}
+	return response, nil
+}
+
+// loadHours loads the weekly hours of a location and its exceptions from the day
+// before t on, which covers a period past midnight running into t
+func loadHours(ctx context.Context, repo LocationRepository, id int, t time.Time) ([]models.LocationHours, []models.LocationHourException, error) {
+	hours, err := guard(func() ([]models.LocationHours, error) { return repo.Hours(ctx, id) })
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve hours of location %d: %w", id, err)
+	}
+	from := calendarDate(t).AddDate(0, 0, -1)
+	exceptions, err := guard(func() ([]models.LocationHourException, error) { return repo.Exceptions(ctx, id, from) })
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to retrieve hour exceptions of location %d: %w", id, err)
+	}
+	return hours, exceptions, nil
+}
+
+// openPeriod is a period a location opens for, in time since the midnight of the day
+// it starts on; End is past 24 hours for a period running past midnight
+type openPeriod struct {
+	Start, End time.Duration
+}
+
+// newOpenPeriod converts the HH:MM times of a period, which have been validated
+func newOpenPeriod(opensAt, closesAt string) openPeriod {
+	start, _ := parseClock(opensAt)
+	end, _ := parseClock(closesAt)
+	if end <= start {
+		end += 24 * time.Hour
+	}
+	return openPeriod{Start: start, End: end}
+}
+
+// openAt reports whether a location with the given weekly hours and exceptions is
+// open at t in the restaurant's timezone. A period past midnight belongs to the day it
+// starts on, so it follows that day's exception.
+func openAt(hours []models.LocationHours, exceptions []models.LocationHourException, t time.Time) bool {
+	t = localTime(t)
+	today := calendarDate(t)
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+
+	for _, period := range periodsOn(hours, exceptions, today) {
+		if clock >= period.Start && clock < period.End {
+			return true
+		}
+	}
+	for _, period := range periodsOn(hours, exceptions, today.AddDate(0, 0, -1)) {
+		if clock+24*time.Hour >= period.Start && clock+24*time.Hour < period.End {
+			return true
+		}
+	}
+	return false
+}
+
+// periodsOn returns the periods a location opens for on a date: those of its
+// exception that day, or else of its weekly hours, around the clock without any
+func periodsOn(hours []models.LocationHours, exceptions []models.LocationHourException, date time.Time) []openPeriod {
+	// Compared by their dates, as drivers scan DATE columns in different locations
+	for _, exception := range exceptions {
+		if exception.Date.Format(exceptionDateLayout) != date.Format(exceptionDateLayout) {
+			continue
+		}
+		if exception.OpensAt == nil || exception.ClosesAt == nil {
+			return nil
+		}
+		return []openPeriod{newOpenPeriod(*exception.OpensAt, *exception.ClosesAt)}
+	}
+
+	if len(hours) == 0 {
+		return []openPeriod{{Start: 0, End: 24 * time.Hour}}
+	}
+	var periods []openPeriod
+	day := weekdays[date.Weekday()]
+	for _, h := range hours {
+		if h.Day == day {
+			periods = append(periods, newOpenPeriod(h.OpensAt, h.ClosesAt))
+		}
+	}
+	return periods
+}
+
+// locationOpen reports whether a location of the restaurant of ctx is open at t
+func locationOpen(ctx context.Context, repo LocationRepository, id int, t time.Time) (bool, error) {
+	hours, exceptions, err := loadHours(ctx, repo, id, t)
+	if err != nil {
+		return false, err
+	}
+	return openAt(hours, exceptions, t), nil
+}
+
+// calendarDate returns the date t falls on in the restaurant's timezone, at midnight
+// UTC as DATE columns hold it
+func calendarDate(t time.Time) time.Time {
+	t = localTime(t)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// parseExceptionDate reads the YYYY-MM-DD date of an hours exception
+func parseExceptionDate(value string) (time.Time, error) {
+	date, err := time.Parse(exceptionDateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: date must be YYYY-MM-DD, e.g. 2026-12-25", ErrInvalidHours)
+	}
+	return date, nil
+}
+
+// newHoursExceptionResponse converts a LocationHourException model to
+// HoursExceptionResponse
+func newHoursExceptionResponse(exception *models.LocationHourException) *HoursExceptionResponse {
+	return &HoursExceptionResponse{
+		Date:     exception.Date.Format(exceptionDateLayout),
+		Closed:   exception.OpensAt == nil,
+		OpensAt:  exception.OpensAt,
+		ClosesAt: exception.ClosesAt,
+		Note:     exception.Note,
+	}
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// OrderSlots sets how many online orders the kitchen takes per time slot
+type OrderSlots struct {
+	// Length of a slot; slots start at the start of the business day
+	Length time.Duration
+	// Most online orders a slot takes; 0 takes any number
+	Capacity int
+}
+
+// orderSlots is the restaurant's online order throttling
+var orderSlots = OrderSlots{Length: 15 * time.Minute}
+
+// SetOrderSlots sets how many online orders the kitchen takes per time slot. It must
+// be called before the server starts.
+func SetOrderSlots(slots OrderSlots) {
+	orderSlots = slots
+}
+
+// slotHorizon is how far ahead online orders may be placed for, and next available
+// slots are looked for
+const slotHorizon = 7 * 24 * time.Hour
+
+// start returns the start of the slot t falls in
+func (s OrderSlots) start(t time.Time) time.Time {
+	day := businessDay(t)
+	return day.Add(t.Sub(day) / s.Length * s.Length)
+}
+
+// next returns the start of the slot after the one starting at slotAt. The last slot
+// of a business day is shorter when the slot length doesn't divide the day.
+func (s OrderSlots) next(slotAt time.Time) time.Time {
+	return s.start(slotAt.Add(s.Length))
+}
+
+// ErrSlotFull is returned, wrapped in a SlotFullError, when an online order's time
+// slot has no room left
+var ErrSlotFull = errors.New("order slot is full")
+
+// SlotFullError is returned when an online order's time slot has no room left.
+// NextSlot is the start of the first later slot with room, zero when none has room
+// within the next seven days.
+type SlotFullError struct {
+	Slot     time.Time
+	NextSlot time.Time
+}
+
+// Error names the full slot and the next available one
+func (e *SlotFullError) Error() string {
+	if e.NextSlot.IsZero() {
+		return fmt.Sprintf("%v: the %s slot is full and no later slot has room", ErrSlotFull, e.Slot.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%v: the %s slot is full, the next available slot is %s",
+		ErrSlotFull, e.Slot.Format(time.RFC3339), e.NextSlot.Format(time.RFC3339))
+}
+
+// Is makes SlotFullError match ErrSlotFull
+func (e *SlotFullError) Is(target error) bool {
+	return target == ErrSlotFull
+}
+
+// OrderSlotResponse is a time slot of online orders
+type OrderSlotResponse struct {
+	SlotAt time.Time `json:"slot_at"`
+	Orders int       `json:"orders" example:"4"`
+	// Most orders the slot takes, omitted when unlimited
+	Capacity  *int `json:"capacity,omitempty" example:"10"`
+	Available bool `json:"available"`
+}
+
+// OrderSlotService defines business operations on time slots of online orders
+type OrderSlotService interface {
+	ListOrderSlots(ctx context.Context, from time.Time, count int) ([]OrderSlotResponse, error)
+}
+
+// orderSlotService handles business logic for order time slots
+type orderSlotService struct {
+	orders OrderRepository
+}
+
+// NewOrderSlotService creates a new order slot service over the slot counts of orders
+func NewOrderSlotService(orders OrderRepository) OrderSlotService {
+	return &orderSlotService{orders: orders}
+}
+
+// Page size limits for ListOrderSlots
+const (
+	defaultOrderSlotCount = 8
+	maxOrderSlotCount     = 96
+)
+
+// ListOrderSlots lists count slots from the one from falls in, the current one when
+// from is zero
+func (s *orderSlotService) ListOrderSlots(ctx context.Context, from time.Time, count int) ([]OrderSlotResponse, error) {
+	ctx, span := tracer.Start(ctx, "OrderSlotService.ListOrderSlots")
+	defer span.End()
+
+	if count <= 0 {
+		count = defaultOrderSlotCount
+	}
+	count = min(count, maxOrderSlotCount)
+	if from.IsZero() {
+		from = time.Now()
+	}
+
+	starts := []time.Time{orderSlots.start(from)}
+	for len(starts) <= count {
+		starts = append(starts, orderSlots.next(starts[len(starts)-1]))
+	}
+	counts, err := slotCounts(ctx, s.orders, starts[0], starts[count])
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]OrderSlotResponse, count)
+	for i, slotAt := range starts[:count] {
+		responses[i] = OrderSlotResponse{
+			SlotAt:    localTime(slotAt),
+			Orders:    counts[slotAt.Unix()],
+			Available: orderSlots.Capacity == 0 || counts[slotAt.Unix()] < orderSlots.Capacity,
+		}
+		if orderSlots.Capacity > 0 {
+			responses[i].Capacity = &orderSlots.Capacity
+		}
+	}
+	return responses, nil
+}
+
+// slotCounts returns the orders of the slots from from and before to, by the Unix
+// time of their start
+func slotCounts(ctx context.Context, orders OrderRepository, from, to time.Time) (map[int64]int, error) {
+	slots, err := guard(func() ([]models.OrderSlot, error) { return orders.SlotCounts(ctx, from, to) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve order slots: %w", err)
+	}
+	counts := make(map[int64]int, len(slots))
+	for _, slot := range slots {
+		counts[slot.SlotAt.Unix()] = slot.Orders
+	}
+	return counts, nil
+}
+
+// assignSlot places an online order in the slot requested, or the current one. Orders
+// placed in the restaurant, at its point of sale or from a table, aren't throttled.
+func assignSlot(order *models.Order, requested *time.Time) error {
+	if order.Source == models.OrderSourcePOS || order.Source == models.OrderSourceTable {
+		if requested != nil {
+			return fmt.Errorf("%w: slot_at is only for online orders", ErrInvalidOrder)
+		}
+		return nil
+	}
+
+	now := time.Now()
+	slotAt := orderSlots.start(now)
+	if requested != nil {
+		if requested.Before(slotAt) || requested.After(now.Add(slotHorizon)) {
+			return fmt.Errorf("%w: slot_at must be within the current slot or the next seven days", ErrInvalidOrder)
+		}
+		slotAt = orderSlots.start(*requested)
+	}
+	order.SlotAt = &slotAt
+	order.SlotCapacity = orderSlots.Capacity
+	return nil
+}
+
+// slotFull returns the SlotFullError of a full slot, with the next slot that has room
+func (s *orderService) slotFull(ctx context.Context, slotAt time.Time) error {
+	from := orderSlots.next(slotAt)
+	to := time.Now().Add(slotHorizon)
+	counts, err := slotCounts(ctx, s.repo, from, to)
+	if err != nil {
+		return err
+	}
+
+	full := &SlotFullError{Slot: localTime(slotAt)}
+	for next := from; next.Before(to); next = orderSlots.next(next) {
+		if counts[next.Unix()] < orderSlots.Capacity {
+			full.NextSlot = localTime(next)
+			break
+		}
+	}
+	return full
+}
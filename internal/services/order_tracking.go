@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// orderTrackingSecret signs the tracking tokens of orders; none are issued while it
+// is empty
+var orderTrackingSecret []byte
+
+// SetOrderTrackingSecret sets the secret signing the tracking tokens of orders. Changing
+// it invalidates the tokens already given out. It must be called before the server
+// starts.
+func SetOrderTrackingSecret(secret string) {
+	orderTrackingSecret = []byte(secret)
+}
+
+// trackingMACSize is how many bytes of the HMAC-SHA256 a tracking token carries
+const trackingMACSize = 16
+
+// OrderTrackingResponse is what customers see of an order on a status page: where it
+// is and when it should be ready, without prices or customer details
+type OrderTrackingResponse struct {
+	Number  *int    `json:"number,omitempty" example:"42"`
+	Channel string  `json:"channel" example:"takeaway"`
+	Status  string  `json:"status" example:"pending"`
+	Table   *string `json:"table,omitempty" example:"12"`
+	// Lines of the order and whether the kitchen has prepared them
+	Items []OrderTrackingItemResponse `json:"items"`
+	// Orders placed before kitchen estimates were introduced have none
+	EstimatedReadyAt *time.Time `json:"estimated_ready_at,omitempty"`
+	// Start of the time slot of an online order
+	SlotAt *time.Time `json:"slot_at,omitempty"`
+	// Where a delivery order's delivery is, by the driver's first name
+	DeliveryStatus *string    `json:"delivery_status,omitempty" example:"picked_up"`
+	Driver         *string    `json:"driver,omitempty" example:"Omar"`
+	PickedUpAt     *time.Time `json:"picked_up_at,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// OrderTrackingItemResponse is a line of a tracked order
+type OrderTrackingItemResponse struct {
+	Name     string `json:"name" example:"Mansaf"`
+	Quantity int    `json:"quantity" example:"2"`
+	Prepared bool   `json:"prepared"`
+}
+
+// TrackOrder returns the status of the order a tracking token was issued for, at any
+// restaurant. Tokens that aren't valid, or were signed with another secret, find no
+// order.
+func (s *orderService) TrackOrder(ctx context.Context, token string) (*OrderTrackingResponse, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.TrackOrder")
+	defer span.End()
+
+	id, ok := parseTrackingToken(token)
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	order, err := guard(func() (*models.Order, error) { return s.repo.FindByID(database.WithoutRestaurant(ctx), id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order %s: %w", id, err)
+	}
+	return newOrderTrackingResponse(order), nil
+}
+
+// trackingToken returns the tracking token of an order: its ID signed with the
+// tracking secret, URL-safe. It is empty when no secret is set.
+func trackingToken(orderID string) string {
+	id, err := uuid.Parse(orderID)
+	if err != nil || len(orderTrackingSecret) == 0 {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(append(id[:], trackingMAC(id)...))
+}
+
+// parseTrackingToken returns the ID of the order a tracking token was issued for
+func parseTrackingToken(token string) (string, bool) {
+	if len(orderTrackingSecret) == 0 {
+		return "", false
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(b) != len(uuid.UUID{})+trackingMACSize {
+		return "", false
+	}
+	id := uuid.UUID(b[:len(uuid.UUID{})])
+	if !hmac.Equal(b[len(id):], trackingMAC(id)) {
+		return "", false
+	}
+	return id.String(), true
+}
+
+// trackingMAC signs an order ID with the tracking secret
+func trackingMAC(id uuid.UUID) []byte {
+	mac := hmac.New(sha256.New, orderTrackingSecret)
+	mac.Write(id[:])
+	return mac.Sum(nil)[:trackingMACSize]
+}
+
+// newOrderTrackingResponse converts an order to what its status page shows
+func newOrderTrackingResponse(order *models.Order) *OrderTrackingResponse {
+	response := &OrderTrackingResponse{
+		Number:    order.Number,
+		Channel:   order.Channel,
+		Status:    order.Status,
+		Table:     order.TableName,
+		Items:     make([]OrderTrackingItemResponse, len(order.Items)),
+		CreatedAt: localTime(order.CreatedAt),
+		UpdatedAt: localTime(order.UpdatedAt),
+	}
+	if order.EstimatedReadyAt != nil {
+		readyAt := localTime(*order.EstimatedReadyAt)
+		response.EstimatedReadyAt = &readyAt
+	}
+	if order.SlotAt != nil {
+		slotAt := localTime(*order.SlotAt)
+		response.SlotAt = &slotAt
+	}
+	if order.Driver != nil {
+		if first, _, _ := strings.Cut(order.Driver.Name, " "); first != "" {
+			response.Driver = &first
+		}
+	}
+	response.DeliveryStatus = order.DeliveryStatus
+	response.PickedUpAt = optionalLocalTime(order.PickedUpAt)
+	response.DeliveredAt = optionalLocalTime(order.DeliveredAt)
+	for i, item := range order.Items {
+		response.Items[i] = OrderTrackingItemResponse{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			Prepared: item.PreparedAt != nil,
+		}
+	}
+	return response
+}
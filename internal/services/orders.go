@@ -0,0 +1,731 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/realtime"
+)
+
+// OrderRepository abstracts order storage
+type OrderRepository interface {
+	Create(ctx context.Context, order *models.Order) error
+	FindByID(ctx context.Context, id string) (*models.Order, error)
+	FindByExternalID(ctx context.Context, source, externalID string) (*models.Order, error)
+	List(ctx context.Context, filter models.OrderFilter) ([]models.Order, error)
+	Pay(ctx context.Context, payment *models.OrderPayment) error
+	StationQueues(ctx context.Context, placedBefore time.Time) (map[string]int, error)
+	QueuedAt(ctx context.Context, station string, placed time.Time) ([]models.Order, error)
+	PrepareItems(ctx context.Context, orderID string, itemIDs []int, at time.Time) error
+	SetReadyEstimate(ctx context.Context, orderID string, at time.Time) error
+	SlotCounts(ctx context.Context, from, to time.Time) ([]models.OrderSlot, error)
+	AssignDriver(ctx context.Context, orderID string, driverID int, at time.Time) error
+	PickUp(ctx context.Context, orderID string, at time.Time) error
+	Deliver(ctx context.Context, orderID string, at time.Time) error
+	DriverOrders(ctx context.Context, driverID int) ([]models.Order, error)
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ OrderRepository = (*models.OrderQuery)(nil)
+
+// OrderService defines business operations on orders
+type OrderService interface {
+	CreateOrder(ctx context.Context, req CreateOrderRequest) (*OrderResponse, error)
+	QuoteOrder(ctx context.Context, req CreateOrderRequest) (*OrderResponse, error)
+	GetOrderByID(ctx context.Context, id string) (*OrderResponse, error)
+	ListOrders(ctx context.Context, opts OrderListOptions) ([]OrderResponse, error)
+	PayOrder(ctx context.Context, id string, req PayOrderRequest) (*OrderResponse, error)
+	GetOrderTickets(ctx context.Context, id, station string) ([]KitchenTicketResponse, error)
+	PrepareTicket(ctx context.Context, id, station string) (*KitchenTicketResponse, error)
+	TrackOrder(ctx context.Context, token string) (*OrderTrackingResponse, error)
+}
+
+// Order change events
+const (
+	EventOrderCreated = "order.created"
+)
+
+// Order errors
+var (
+	ErrOrderNotFound = errors.New("order not found")
+	ErrInvalidOrder  = errors.New("invalid order")
+	// ErrOrderExists is returned with the existing order when an order with the same
+	// source and external ID was already received
+	ErrOrderExists = errors.New("order already exists")
+)
+
+// Page size limits for ListOrders
+const (
+	defaultOrderPageSize = 50
+	maxOrderPageSize     = 200
+)
+
+// CreateOrderRequest represents the data needed to create an order. Items may
+// reference a menu item, whose name and price on the order's channel are used unless
+// given explicitly.
+type CreateOrderRequest struct {
+	Source        string                   `json:"source,omitempty"`
+	ExternalID    *string                  `json:"external_id,omitempty"`
+	Channel       string                   `json:"channel,omitempty"`
+	CustomerName  *string                  `json:"customer_name,omitempty"`
+	CustomerPhone *string                  `json:"customer_phone,omitempty"`
+	Notes         *string                  `json:"notes,omitempty"`
+	Items         []CreateOrderItemRequest `json:"items"`
+	// Coupon to redeem, matched case-insensitively
+	CouponCode *string `json:"coupon_code,omitempty" example:"SUMMER10"`
+	// Loyalty points of the customer to redeem as a discount, at most as many as the
+	// order needs
+	LoyaltyPoints *int `json:"loyalty_points,omitempty" example:"200"`
+	// Tip paid on top of the total, as an amount or a percentage of the total; set at
+	// most one
+	Tip        *decimal.Decimal `json:"tip,omitempty" swaggertype:"string" example:"3.00"`
+	TipPercent *decimal.Decimal `json:"tip_percent,omitempty" swaggertype:"string" example:"10"`
+	// Time slot to place an online order in, the current one when omitted
+	SlotAt *time.Time `json:"slot_at,omitempty"`
+	// Location of the restaurant to place the order at. Online orders are only taken
+	// while it is open.
+	LocationID *int `json:"location_id,omitempty" example:"2"`
+	// Table the order is served at and the guest session ordering from it, set for
+	// orders placed from a table's QR code
+	Table          *models.DiningTable `json:"-"`
+	GuestSessionID *int                `json:"-"`
+}
+
+// CreateOrderItemRequest is a line of a new order
+type CreateOrderItemRequest struct {
+	MenuItemID *int             `json:"menu_item_id,omitempty"`
+	Name       string           `json:"name,omitempty"`
+	Quantity   int              `json:"quantity"`
+	UnitPrice  *decimal.Decimal `json:"unit_price,omitempty" swaggertype:"string" example:"12.50"`
+	Notes      *string          `json:"notes,omitempty"`
+}
+
+// OrderListOptions filters and pages ListOrders
+type OrderListOptions struct {
+	Status string
+	Source string
+	Since  time.Time // Orders created at or after Since; zero matches any
+	Until  time.Time // Orders created before Until; zero matches any
+	// Orders numbered Number, 0 for any, on the business day dated as Day is; a number
+	// without a day is looked up among today's orders
+	Number int
+	Day    time.Time
+	// Orders placed in this guest session, 0 for any
+	GuestSession int
+	Limit        int
+	Offset       int
+}
+
+// OrderResponse represents the order data returned to clients. Subtotal is the sum of
+// the line totals as priced, so it includes tax when TaxIncluded, and Discount is
+// taken off it; Net + Tax = Total in both pricing modes. Promotions explains the part
+// of Discount given by promotions and LoyaltyDiscount the part given for LoyaltyPoints;
+// the rest is the coupon's. Tip is paid on top of Total. AmountPaid is the part of
+// Total and Tip paid by Payments and AmountDue what is left to pay. EstimatedReadyAt is when the kitchen should have
+// prepared the order.
+type OrderResponse struct {
+	ID string `json:"id" example:"0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"`
+	// Token of the order's public status page, /track/{token}, when tracking is set up
+	TrackingToken string `json:"tracking_token,omitempty" example:"C34vPG96SkOfDl0bLDpOX3Xq0n2mQb8e4iJ1cYqg9zY"`
+	// Number of the order among those of its business day, called out to customers
+	Number *int `json:"number,omitempty" example:"42"`
+	// Table the order is served at, by its name when ordered
+	TableID         *int                     `json:"table_id,omitempty" example:"3"`
+	Table           *string                  `json:"table,omitempty" example:"12"`
+	Source          string                   `json:"source" example:"pos"`
+	ExternalID      *string                  `json:"external_id,omitempty"`
+	Channel         string                   `json:"channel" example:"dine_in"`
+	Status          string                   `json:"status" example:"pending"`
+	Subtotal        decimal.Decimal          `json:"subtotal" swaggertype:"string" example:"25.00"`
+	Discount        decimal.Decimal          `json:"discount" swaggertype:"string" example:"0.00"`
+	CouponCode      *string                  `json:"coupon_code,omitempty" example:"SUMMER10"`
+	Promotions      []OrderPromotionResponse `json:"promotions"`
+	LoyaltyPoints   int                      `json:"loyalty_points" example:"0"`
+	LoyaltyDiscount decimal.Decimal          `json:"loyalty_discount" swaggertype:"string" example:"0.00"`
+	TaxIncluded     bool                     `json:"tax_included"`
+	Net             decimal.Decimal          `json:"net" swaggertype:"string" example:"25.00"`
+	Tax             decimal.Decimal          `json:"tax" swaggertype:"string" example:"4.00"`
+	Taxes           []OrderTaxResponse       `json:"taxes"`
+	Total           decimal.Decimal          `json:"total" swaggertype:"string" example:"29.00"`
+	Tip             decimal.Decimal          `json:"tip" swaggertype:"string" example:"0.00"`
+	AmountPaid      decimal.Decimal          `json:"amount_paid" swaggertype:"string" example:"0.00"`
+	AmountDue       decimal.Decimal          `json:"amount_due" swaggertype:"string" example:"29.00"`
+	Payments        []OrderPaymentResponse   `json:"payments"`
+	CustomerName    *string                  `json:"customer_name,omitempty"`
+	CustomerPhone   *string                  `json:"customer_phone,omitempty"`
+	Notes           *string                  `json:"notes,omitempty"`
+	Items           []OrderItemResponse      `json:"items"`
+	// Orders placed before kitchen estimates were introduced have none
+	EstimatedReadyAt *time.Time `json:"estimated_ready_at,omitempty"`
+	// Start of the time slot of an online order
+	SlotAt *time.Time `json:"slot_at,omitempty"`
+	// Location of the restaurant the order was placed at
+	LocationID *int `json:"location_id,omitempty" example:"2"`
+	// Driver of a delivery order, by name, the step its delivery reached and when
+	// each step happened
+	DriverID       *int       `json:"driver_id,omitempty" example:"4"`
+	Driver         *string    `json:"driver,omitempty" example:"Omar"`
+	DeliveryStatus *string    `json:"delivery_status,omitempty" example:"picked_up"`
+	AssignedAt     *time.Time `json:"assigned_at,omitempty"`
+	PickedUpAt     *time.Time `json:"picked_up_at,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// OrderItemResponse represents an order line returned to clients
+type OrderItemResponse struct {
+	ID         int             `json:"id"`
+	MenuItemID *int            `json:"menu_item_id,omitempty"`
+	Name       string          `json:"name"`
+	Quantity   int             `json:"quantity"`
+	UnitPrice  decimal.Decimal `json:"unit_price" swaggertype:"string" example:"12.50"`
+	LineTotal  decimal.Decimal `json:"line_total" swaggertype:"string" example:"25.00"`
+	TaxName    *string         `json:"tax_name,omitempty" example:"VAT"`
+	TaxRate    decimal.Decimal `json:"tax_rate" swaggertype:"string" example:"16"`
+	TaxAmount  decimal.Decimal `json:"tax_amount" swaggertype:"string" example:"4.00"`
+	// Pricing rule that discounted UnitPrice, e.g. a happy hour
+	PricingRule *string `json:"pricing_rule,omitempty" example:"Happy hour"`
+	// Part of the order's discount taken off LineTotal
+	Discount decimal.Decimal `json:"discount" swaggertype:"string" example:"0.00"`
+	Notes    *string         `json:"notes,omitempty"`
+	// Kitchen station preparing the line, the minutes it takes and when the station
+	// marked it prepared
+	Station     *string    `json:"station,omitempty" example:"grill"`
+	PrepMinutes int        `json:"prep_minutes" example:"12"`
+	PreparedAt  *time.Time `json:"prepared_at,omitempty"`
+}
+
+// OrderTaxResponse is the tax of an order at one rate: Amount is charged on the net
+// amount Taxable of the lines taxed at that rate
+type OrderTaxResponse struct {
+	Name    string          `json:"name" example:"VAT"`
+	Rate    decimal.Decimal `json:"rate" swaggertype:"string" example:"16"`
+	Taxable decimal.Decimal `json:"taxable" swaggertype:"string" example:"25.00"`
+	Amount  decimal.Decimal `json:"amount" swaggertype:"string" example:"4.00"`
+}
+
+// orderService handles business logic for orders
+type orderService struct {
+	repo       OrderRepository
+	menu       MenuItemRepository
+	taxes      TaxRateRepository
+	rules      PricingRuleRepository
+	coupons    CouponRepository
+	promotions PromotionRepository
+	loyalty    LoyaltyRepository
+	credits    StoreCreditRepository
+	locations  LocationRepository
+	events     EventPublisher
+}
+
+// NewOrderService creates a new order service. Menu items referenced by new orders are
+// looked up in menu, discounted by the pricing rules in effect in rules, by the
+// promotions in promotions, by the order's coupon from coupons and by the loyalty points
+// its customer redeems from loyalty, and their lines taxed at the rates in taxes.
+// Orders may be paid with their customer's store credit from credits, and are placed
+// at the locations in locations. Changes are published to events when it is non-nil.
+func NewOrderService(repo OrderRepository, menu MenuItemRepository, taxes TaxRateRepository, rules PricingRuleRepository,
+	coupons CouponRepository, promotions PromotionRepository, loyalty LoyaltyRepository, credits StoreCreditRepository,
+	locations LocationRepository, events EventPublisher) OrderService {
+	return &orderService{repo: repo, menu: menu, taxes: taxes, rules: rules, coupons: coupons, promotions: promotions,
+		loyalty: loyalty, credits: credits, locations: locations, events: events}
+}
+
+// linePricing is what the lines of a new order are priced and taxed with
+type linePricing struct {
+	channel string               // The order's channel, selecting menu items' channel prices
+	rates   []models.TaxRate     // Tax rates, applied after discounts
+	rules   []models.PricingRule // Pricing rules, applied as in effect at placed
+	placed  time.Time
+}
+
+// publish broadcasts a change event at the restaurant of ctx if a publisher is
+// configured
+func (s *orderService) publish(ctx context.Context, eventType string, data interface{}) {
+	if s.events != nil {
+		s.events.Publish(realtime.Event{Type: eventType, Data: data, Restaurant: database.RestaurantID(ctx)})
+	}
+}
+
+// CreateOrder creates a pending order, discounting menu-priced lines by the pricing
+// rules in effect and the order's coupon, and taxing each line at the rate of its
+// menu item or category, or the default rate. An order whose source and external ID
+// were already received is not created again; the existing one is returned with
+// ErrOrderExists. Online orders at a location that is closed at their slot fail with
+// ErrLocationClosed.
+func (s *orderService) CreateOrder(ctx context.Context, req CreateOrderRequest) (*OrderResponse, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.CreateOrder")
+	defer span.End()
+
+	order, err := newOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.ExternalID != nil {
+		if existing, err := s.findExisting(ctx, order.Source, *order.ExternalID); existing != nil || err != nil {
+			return existing, err
+		}
+	}
+	// Only orders placed from a table's QR code are taken in the restaurant; the
+	// source a client sends isn't trusted for this
+	if err := s.checkLocation(ctx, order, req.SlotAt, req.Table == nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.price(ctx, order, req); err != nil {
+		return nil, err
+	}
+	if err := s.estimateReady(ctx, order); err != nil {
+		return nil, err
+	}
+	day := businessDate(businessDay(time.Now()))
+	order.BusinessDay = &day
+
+	err = guardExec(func() error {
+		return s.repo.Create(ctx, order)
+	})
+	if errors.Is(err, models.ErrCouponUsedUp) {
+		return nil, fmt.Errorf("%w: %s has been used up", ErrCouponNotRedeemable, *order.CouponCode)
+	}
+	if errors.Is(err, models.ErrLoyaltyBalance) {
+		return nil, fmt.Errorf("%w: the customer has fewer than %d points left", ErrLoyaltyNotRedeemable, order.LoyaltyPoints)
+	}
+	if errors.Is(err, models.ErrSlotFull) {
+		return nil, s.slotFull(ctx, *order.SlotAt)
+	}
+	if err != nil {
+		// Lost a race with a concurrent delivery of the same order
+		if order.ExternalID != nil {
+			if existing, findErr := s.findExisting(ctx, order.Source, *order.ExternalID); existing != nil {
+				return existing, findErr
+			}
+		}
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	response := newOrderResponse(order)
+	s.publish(ctx, EventOrderCreated, response)
+	s.publishTickets(ctx, response)
+	return response, nil
+}
+
+// QuoteOrder prices an order as CreateOrder would without creating it, e.g. to check
+// a coupon before checkout. The quote has no ID and its coupon and loyalty points
+// aren't redeemed; it is estimated to be ready as the order would be if placed now.
+func (s *orderService) QuoteOrder(ctx context.Context, req CreateOrderRequest) (*OrderResponse, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.QuoteOrder")
+	defer span.End()
+
+	order, err := newOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLocation(ctx, order, req.SlotAt, false); err != nil {
+		return nil, err
+	}
+	if err := s.price(ctx, order, req); err != nil {
+		return nil, err
+	}
+	if err := s.estimateReady(ctx, order); err != nil {
+		return nil, err
+	}
+	order.CreatedAt = time.Now()
+	order.UpdatedAt = order.CreatedAt
+	return newOrderResponse(order), nil
+}
+
+// newOrder validates req and converts it to a pending order without lines, at the
+// restaurant of ctx and with its tax mode
+func newOrder(ctx context.Context, req CreateOrderRequest) (*models.Order, error) {
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("%w: an order needs at least one item", ErrInvalidOrder)
+	}
+
+	order := &models.Order{
+		Source:        req.Source,
+		ExternalID:    req.ExternalID,
+		Channel:       req.Channel,
+		RestaurantID:  database.RestaurantID(ctx),
+		Status:        models.OrderStatusPending,
+		TaxIncluded:   CurrentSettings(ctx).TaxIncluded(),
+		CustomerName:  req.CustomerName,
+		CustomerPhone: req.CustomerPhone,
+		Notes:         req.Notes,
+
+		GuestSessionID: req.GuestSessionID,
+		LocationID:     req.LocationID,
+	}
+	if req.Table != nil {
+		order.TableID = &req.Table.ID
+		order.TableName = &req.Table.Name
+	}
+	if order.Source == "" {
+		order.Source = models.OrderSourcePOS
+	}
+	switch order.Channel {
+	case "":
+		order.Channel = models.OrderChannelDineIn
+	case models.OrderChannelDineIn, models.OrderChannelTakeaway, models.OrderChannelDelivery:
+	default:
+		return nil, fmt.Errorf("%w: channel must be one of dine_in, takeaway, delivery", ErrInvalidOrder)
+	}
+	if err := assignSlot(order, req.SlotAt); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// checkLocation checks that the location a new order names is one of the restaurant
+// of ctx. When placing an online order, the location must also be open at the
+// requested slot, or now.
+func (s *orderService) checkLocation(ctx context.Context, order *models.Order, slotAt *time.Time, online bool) error {
+	if order.LocationID == nil || s.locations == nil {
+		return nil
+	}
+	id := *order.LocationID
+	_, err := guard(func() (*models.Location, error) { return s.locations.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: location %d does not exist", ErrInvalidOrder, id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find location %d: %w", id, err)
+	}
+	if !online {
+		return nil
+	}
+
+	at := time.Now()
+	if slotAt != nil {
+		at = *slotAt
+	}
+	open, err := locationOpen(ctx, s.locations, id, at)
+	if err != nil {
+		return err
+	}
+	if !open {
+		return fmt.Errorf("%w: location %d is not open at %s", ErrLocationClosed, id, localTime(at).Format(time.RFC3339))
+	}
+	return nil
+}
+
+// price adds the requested lines to a new order, applies its coupon and computes its
+// tax and totals
+func (s *orderService) price(ctx context.Context, order *models.Order, req CreateOrderRequest) error {
+	var err error
+	pricing := linePricing{channel: order.Channel, placed: time.Now()}
+	pricing.rates, err = guard(func() ([]models.TaxRate, error) { return s.taxes.List(ctx) })
+	if err != nil {
+		return fmt.Errorf("failed to retrieve tax rates: %w", err)
+	}
+	pricing.rules, err = guard(func() ([]models.PricingRule, error) { return s.rules.List(database.UsePrimary(ctx)) })
+	if err != nil {
+		return fmt.Errorf("failed to retrieve pricing rules: %w", err)
+	}
+
+	categories := make([]string, len(req.Items))
+	for i, line := range req.Items {
+		item, category, err := s.orderItem(ctx, line, pricing)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", i+1, err)
+		}
+		order.Items = append(order.Items, *item)
+		categories[i] = category
+	}
+
+	promotions, err := guard(func() ([]models.Promotion, error) { return s.promotions.List(database.UsePrimary(ctx)) })
+	if err != nil {
+		return fmt.Errorf("failed to retrieve promotions: %w", err)
+	}
+	order.Promotions = applyPromotions(promotions, order.Items, categories, pricing.placed)
+
+	if req.CouponCode != nil && strings.TrimSpace(*req.CouponCode) != "" {
+		if err := s.applyCoupon(ctx, order, *req.CouponCode, categories, pricing.placed); err != nil {
+			return err
+		}
+	}
+	if req.LoyaltyPoints != nil {
+		if err := s.redeemPoints(ctx, order, *req.LoyaltyPoints); err != nil {
+			return err
+		}
+	}
+
+	// Lines are taxed on their price after discounts
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.TaxName != nil {
+			item.TaxAmount = lineTax(item.DiscountedTotal(), item.TaxRate, order.TaxIncluded)
+		}
+		order.Subtotal = order.Subtotal.Add(item.LineTotal())
+		order.Discount = order.Discount.Add(item.Discount)
+		order.Tax = order.Tax.Add(item.TaxAmount)
+	}
+	order.Total = order.Subtotal.Sub(order.Discount)
+	if !order.TaxIncluded {
+		order.Total = order.Total.Add(order.Tax)
+	}
+
+	switch {
+	case req.Tip != nil && req.TipPercent != nil:
+		return fmt.Errorf("%w: set at most one of tip and tip_percent", ErrInvalidOrder)
+	case req.Tip != nil:
+		if req.Tip.IsNegative() {
+			return fmt.Errorf("%w: tip must not be negative", ErrInvalidOrder)
+		}
+		order.Tip = req.Tip.Round(2)
+	case req.TipPercent != nil:
+		if req.TipPercent.IsNegative() || req.TipPercent.GreaterThan(decimal.NewFromInt(100)) {
+			return fmt.Errorf("%w: tip_percent must be a percentage between 0 and 100", ErrInvalidOrder)
+		}
+		order.Tip = order.Total.Mul(*req.TipPercent).Div(decimal.NewFromInt(100)).Round(2)
+	}
+	return nil
+}
+
+// findExisting returns the order a source already sent with ErrOrderExists, or nil
+func (s *orderService) findExisting(ctx context.Context, source, externalID string) (*OrderResponse, error) {
+	existing, err := guard(func() (*models.Order, error) { return s.repo.FindByExternalID(ctx, source, externalID) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up order %s from %s: %w", externalID, source, err)
+	}
+	return newOrderResponse(existing), ErrOrderExists
+}
+
+// orderItem resolves a requested line against the menu, priced for the order's
+// channel and discounted by the pricing rules in effect, and picks its tax rate,
+// kitchen station and prep time. It also returns the category of the line's menu item, empty when not on the menu.
+func (s *orderService) orderItem(ctx context.Context, line CreateOrderItemRequest, pricing linePricing) (*models.OrderItem, string, error) {
+	if line.Quantity < 1 {
+		return nil, "", fmt.Errorf("%w: quantity must be at least 1", ErrInvalidOrder)
+	}
+	item := &models.OrderItem{
+		MenuItemID: line.MenuItemID,
+		Name:       line.Name,
+		Quantity:   line.Quantity,
+		Notes:      line.Notes,
+	}
+	if line.UnitPrice != nil {
+		item.UnitPrice = *line.UnitPrice
+	}
+	category := ""
+	station := kitchen.Default
+	item.PrepMinutes = int(kitchen.PrepTime / time.Minute)
+
+	if line.MenuItemID != nil {
+		menuItem, err := guard(func() (*models.MenuItem, error) {
+			return s.menu.FindByID(ctx, *line.MenuItemID, database.WithRelations("Prices"))
+		})
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			// Unknown to the menu: keep the line if it carries its own name and price
+			item.MenuItemID = nil
+		case err != nil:
+			return nil, "", fmt.Errorf("failed to look up menu item %d: %w", *line.MenuItemID, err)
+		default:
+			if item.Name == "" {
+				item.Name = menuItem.Name
+			}
+			if line.UnitPrice == nil {
+				price, rule := discountedPrice(menuItem.PriceFor(pricing.channel), pricing.rules,
+					menuItem.ID, menuItem.Category, pricing.placed)
+				item.UnitPrice = price
+				if rule != nil {
+					item.PricingRule = &rule.Name
+				}
+			}
+			category = menuItem.Category
+			station = kitchen.stationFor(menuItem)
+			item.PrepMinutes = kitchen.prepMinutesFor(menuItem)
+		}
+	}
+
+	if item.Name == "" {
+		return nil, "", fmt.Errorf("%w: name is required for items that are not on the menu", ErrInvalidOrder)
+	}
+	if item.MenuItemID == nil && line.UnitPrice == nil {
+		return nil, "", fmt.Errorf("%w: unit_price is required for items that are not on the menu", ErrInvalidOrder)
+	}
+	if item.UnitPrice.IsNegative() {
+		return nil, "", fmt.Errorf("%w: unit_price must not be negative", ErrInvalidOrder)
+	}
+
+	if rate := taxRateFor(pricing.rates, item.MenuItemID, category); rate != nil {
+		item.TaxName = &rate.Name
+		item.TaxRate = rate.Rate
+	}
+	item.Station = &station
+	return item, category, nil
+}
+
+// GetOrderByID retrieves an order with its items
+func (s *orderService) GetOrderByID(ctx context.Context, id string) (*OrderResponse, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.GetOrderByID")
+	defer span.End()
+
+	order, err := guard(func() (*models.Order, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order %s: %w", id, err)
+	}
+	return newOrderResponse(order), nil
+}
+
+// ListOrders retrieves orders, newest first
+func (s *orderService) ListOrders(ctx context.Context, opts OrderListOptions) ([]OrderResponse, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.ListOrders")
+	defer span.End()
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultOrderPageSize
+	}
+	limit = min(limit, maxOrderPageSize)
+
+	filter := models.OrderFilter{
+		Status:        opts.Status,
+		Source:        opts.Source,
+		CreatedAfter:  opts.Since,
+		CreatedBefore: opts.Until,
+		Number:        max(opts.Number, 0),
+		GuestSession:  opts.GuestSession,
+		Limit:         limit,
+		Offset:        max(opts.Offset, 0),
+	}
+	switch {
+	case !opts.Day.IsZero():
+		filter.BusinessDay = businessDate(StartOfBusinessDay(opts.Day))
+	case filter.Number > 0:
+		filter.BusinessDay = businessDate(businessDay(time.Now()))
+	}
+	orders, err := guard(func() ([]models.Order, error) { return s.repo.List(ctx, filter) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve orders: %w", err)
+	}
+
+	responses := make([]OrderResponse, len(orders))
+	for i := range orders {
+		responses[i] = *newOrderResponse(&orders[i])
+	}
+	return responses, nil
+}
+
+// newOrderResponse converts an order model to its response DTO
+func newOrderResponse(order *models.Order) *OrderResponse {
+	response := &OrderResponse{
+		ID:              order.ID,
+		TrackingToken:   trackingToken(order.ID),
+		Number:          order.Number,
+		TableID:         order.TableID,
+		Table:           order.TableName,
+		Source:          order.Source,
+		ExternalID:      order.ExternalID,
+		Channel:         order.Channel,
+		Status:          order.Status,
+		Subtotal:        order.Subtotal,
+		Discount:        order.Discount,
+		CouponCode:      order.CouponCode,
+		Promotions:      make([]OrderPromotionResponse, len(order.Promotions)),
+		LoyaltyPoints:   order.LoyaltyPoints,
+		LoyaltyDiscount: order.LoyaltyDiscount,
+		TaxIncluded:     order.TaxIncluded,
+		Net:             order.Total.Sub(order.Tax),
+		Tax:             order.Tax,
+		Taxes:           []OrderTaxResponse{},
+		Total:           order.Total,
+		AmountPaid:      order.AmountPaid,
+		Tip:             order.Tip,
+		AmountDue:       order.AmountDue(),
+		Payments:        make([]OrderPaymentResponse, len(order.Payments)),
+		CustomerName:    order.CustomerName,
+		CustomerPhone:   order.CustomerPhone,
+		Notes:           order.Notes,
+		Items:           make([]OrderItemResponse, len(order.Items)),
+		CreatedAt:       localTime(order.CreatedAt),
+		UpdatedAt:       localTime(order.UpdatedAt),
+	}
+	if order.EstimatedReadyAt != nil {
+		readyAt := localTime(*order.EstimatedReadyAt)
+		response.EstimatedReadyAt = &readyAt
+	}
+	if order.SlotAt != nil {
+		slotAt := localTime(*order.SlotAt)
+		response.SlotAt = &slotAt
+	}
+	response.LocationID = order.LocationID
+	if order.Driver != nil {
+		response.Driver = &order.Driver.Name
+	}
+	response.DriverID = order.DriverID
+	response.DeliveryStatus = order.DeliveryStatus
+	response.AssignedAt = optionalLocalTime(order.AssignedAt)
+	response.PickedUpAt = optionalLocalTime(order.PickedUpAt)
+	response.DeliveredAt = optionalLocalTime(order.DeliveredAt)
+	for i, promotion := range order.Promotions {
+		response.Promotions[i] = OrderPromotionResponse{
+			Name:        promotion.Name,
+			Description: promotion.Description,
+			Discount:    promotion.Discount,
+		}
+	}
+	for i, payment := range order.Payments {
+		response.Payments[i] = OrderPaymentResponse{
+			Method: payment.Method,
+			Amount: payment.Amount,
+			PaidAt: localTime(payment.CreatedAt),
+		}
+	}
+	for i, item := range order.Items {
+		response.Items[i] = OrderItemResponse{
+			ID:          item.ID,
+			MenuItemID:  item.MenuItemID,
+			Name:        item.Name,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			LineTotal:   item.LineTotal(),
+			TaxName:     item.TaxName,
+			TaxRate:     item.TaxRate,
+			TaxAmount:   item.TaxAmount,
+			PricingRule: item.PricingRule,
+			Discount:    item.Discount,
+			Notes:       item.Notes,
+			Station:     item.Station,
+			PrepMinutes: item.PrepMinutes,
+		}
+		if item.PreparedAt != nil {
+			preparedAt := localTime(*item.PreparedAt)
+			response.Items[i].PreparedAt = &preparedAt
+		}
+		if item.TaxName == nil {
+			continue
+		}
+		i := slices.IndexFunc(response.Taxes, func(t OrderTaxResponse) bool {
+			return t.Name == *item.TaxName && t.Rate.Equal(item.TaxRate)
+		})
+		if i < 0 {
+			response.Taxes = append(response.Taxes, OrderTaxResponse{Name: *item.TaxName, Rate: item.TaxRate})
+			i = len(response.Taxes) - 1
+		}
+		net := item.DiscountedTotal()
+		if order.TaxIncluded {
+			net = net.Sub(item.TaxAmount)
+		}
+		response.Taxes[i].Taxable = response.Taxes[i].Taxable.Add(net)
+		response.Taxes[i].Amount = response.Taxes[i].Amount.Add(item.TaxAmount)
+	}
+	return response
+}
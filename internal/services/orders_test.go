@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// closedLocation is a location repository whose locations are closed all day today
+// and yesterday
+type closedLocation struct {
+	LocationRepository
+}
+
+func (closedLocation) FindByID(_ context.Context, id int) (*models.Location, error) {
+	return &models.Location{ID: id}, nil
+}
+
+func (closedLocation) Hours(context.Context, int) ([]models.LocationHours, error) {
+	return nil, nil
+}
+
+func (closedLocation) Exceptions(_ context.Context, id int, _ time.Time) ([]models.LocationHourException, error) {
+	today := calendarDate(time.Now())
+	return []models.LocationHourException{
+		{LocationID: id, Date: today},
+		{LocationID: id, Date: today.AddDate(0, 0, -1)},
+	}, nil
+}
+
+func TestCreateOrderIgnoresClientSourceForHours(t *testing.T) {
+	savedDefaults, savedSettings, savedZone := defaultSettings, settings.Load(), zone.Load()
+	t.Cleanup(func() {
+		defaultSettings = savedDefaults
+		settings.Store(savedSettings)
+		zone.Store(savedZone)
+	})
+	SetDefaultSettings(Settings{Timezone: "UTC", Currency: "JOD", TaxMode: TaxModeExclusive})
+
+	service := NewOrderService(nil, nil, nil, nil, nil, nil, nil, nil, closedLocation{}, nil)
+	location := 2
+	for _, source := range []string{"", models.OrderSourcePOS, models.OrderSourceTable} {
+		req := CreateOrderRequest{
+			Source:     source,
+			LocationID: &location,
+			Items:      []CreateOrderItemRequest{{Name: "Mansaf", Quantity: 1}},
+		}
+		if _, err := service.CreateOrder(context.Background(), req); !errors.Is(err, ErrLocationClosed) {
+			t.Errorf("CreateOrder() with source %q = %v, want %v", source, err, ErrLocationClosed)
+		}
+	}
+}
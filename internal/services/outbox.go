@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/broker"
+	"github.com/Zughayyar/agora-server/internal/jobs"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/realtime"
+)
+
+// JobPublishEvent is the job kind that publishes a change event to the message broker
+const JobPublishEvent = "event.publish"
+
+// outboxEnqueueTimeout bounds storing an event in the outbox
+const outboxEnqueueTimeout = 5 * time.Second
+
+// EventPublishers fans events out to several publishers
+type EventPublishers []EventPublisher
+
+// Publish sends event to every publisher
+func (p EventPublishers) Publish(event realtime.Event) {
+	for _, publisher := range p {
+		publisher.Publish(event)
+	}
+}
+
+// BrokerEvent is the message published to the broker for a change event
+type BrokerEvent struct {
+	ID           string          `json:"id" example:"1234"`
+	Type         string          `json:"type" example:"menu_item.updated"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+	RestaurantID int             `json:"restaurant_id,omitempty" example:"1"`
+	Data         json.RawMessage `json:"data,omitempty"`
+}
+
+// outboxPayload is the job payload of an event waiting to be published
+type outboxPayload struct {
+	Type         string          `json:"type"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+	RestaurantID int             `json:"restaurant_id,omitempty"`
+	Data         json.RawMessage `json:"data,omitempty"`
+}
+
+// EventOutbox stores change events in the job queue and publishes them to the message
+// broker from there, so events survive broker outages and restarts and are retried
+// with backoff. Events go to the topic <prefix><entity>, e.g. agora.menu_item, keyed
+// by the entity ID so each entity's events stay in order.
+type EventOutbox struct {
+	queue       *jobs.Queue
+	producer    broker.Producer
+	topicPrefix string
+}
+
+// NewEventOutbox registers the publishing job handler on queue
+func NewEventOutbox(queue *jobs.Queue, producer broker.Producer, topicPrefix string) *EventOutbox {
+	o := &EventOutbox{queue: queue, producer: producer, topicPrefix: topicPrefix}
+	queue.Register(JobPublishEvent, o.publish)
+	return o
+}
+
+// Publish stores event in the outbox. Failures are logged: the change itself has
+// already been committed.
+func (o *EventOutbox) Publish(event realtime.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), outboxEnqueueTimeout)
+	defer cancel()
+
+	logger := logging.FromContext(ctx).With(slog.String("event", event.Type))
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		logger.Error("Failed to encode event for the outbox", slog.String("error", err.Error()))
+		return
+	}
+	payload := outboxPayload{Type: event.Type, OccurredAt: time.Now().UTC(), RestaurantID: event.Restaurant, Data: data}
+	if _, err := o.queue.Enqueue(ctx, JobPublishEvent, payload); err != nil {
+		logger.Error("Failed to store event in the outbox", slog.String("error", err.Error()))
+	}
+}
+
+// publish is the job handler sending an outbox event to the broker
+func (o *EventOutbox) publish(ctx context.Context, job *jobs.Job) (any, error) {
+	var payload outboxPayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("invalid event payload: %w", err)
+	}
+
+	// The job ID is stable across retries, so consumers can drop duplicates
+	id := strconv.FormatInt(job.ID, 10)
+	value, err := json.Marshal(BrokerEvent{
+		ID:           id,
+		Type:         payload.Type,
+		OccurredAt:   payload.OccurredAt,
+		RestaurantID: payload.RestaurantID,
+		Data:         payload.Data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	entity, _, _ := strings.Cut(payload.Type, ".")
+	msg := broker.Message{
+		Topic: o.topicPrefix + entity,
+		ID:    id,
+		Key:   eventKey(payload.Data),
+		Value: value,
+	}
+	if err := o.producer.Publish(ctx, msg); err != nil {
+		return nil, fmt.Errorf("failed to publish %s to %s: %w", payload.Type, msg.Topic, err)
+	}
+	return nil, nil
+}
+
+// eventKey returns the ID of the entity an event is about, or nil if it has none
+func eventKey(data json.RawMessage) []byte {
+	var entity struct {
+		ID json.Number `json:"id"`
+	}
+	if err := json.Unmarshal(data, &entity); err != nil || entity.ID == "" {
+		return nil
+	}
+	return []byte(entity.ID)
+}
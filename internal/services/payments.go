@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// Payment errors
+var (
+	ErrInvalidPayment = errors.New("invalid payment")
+	// ErrOrderNotPayable is returned when an order can't take a payment
+	ErrOrderNotPayable = errors.New("order not payable")
+)
+
+// PayOrderRequest records a payment towards an order, by default of its amount due or,
+// with store_credit, as much of it as the customer's store credit covers. Gift cards
+// pay orders by being redeemed instead.
+type PayOrderRequest struct {
+	Method string           `json:"method" enums:"cash,card,store_credit" example:"cash"`
+	Amount *decimal.Decimal `json:"amount,omitempty" swaggertype:"string" example:"29.00"`
+	// ID of the request making the payment, recorded with store credit debits
+	RequestID string `json:"-"`
+}
+
+// OrderPaymentResponse is an amount paid towards an order
+type OrderPaymentResponse struct {
+	Method string          `json:"method" example:"card"`
+	Amount decimal.Decimal `json:"amount" swaggertype:"string" example:"29.00"`
+	PaidAt time.Time       `json:"paid_at"`
+}
+
+// PayOrder records a payment towards an order. The payment completing the order earns
+// its customer loyalty points.
+func (s *orderService) PayOrder(ctx context.Context, id string, req PayOrderRequest) (*OrderResponse, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.PayOrder")
+	defer span.End()
+
+	switch req.Method {
+	case models.PaymentCash, models.PaymentCard, models.PaymentStoreCredit:
+	default:
+		return nil, fmt.Errorf("%w: method must be cash, card or store_credit", ErrInvalidPayment)
+	}
+
+	// Amounts due are checked right before they change
+	ctx = database.UsePrimary(ctx)
+	order, err := guard(func() (*models.Order, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order %s: %w", id, err)
+	}
+	due := order.AmountDue()
+	switch {
+	case order.Status == models.OrderStatusCancelled:
+		return nil, fmt.Errorf("%w: order %s was cancelled", ErrOrderNotPayable, id)
+	case !due.IsPositive():
+		return nil, fmt.Errorf("%w: order %s is already paid", ErrOrderNotPayable, id)
+	}
+
+	amount := due
+	if req.Amount != nil {
+		amount = *req.Amount
+		if problem := amountProblem(amount); problem != "" {
+			return nil, fmt.Errorf("%w: amount %s", ErrInvalidPayment, problem)
+		}
+		if amount.GreaterThan(due) {
+			return nil, fmt.Errorf("%w: order %s has %s left to pay", ErrOrderNotPayable, id, due.StringFixed(2))
+		}
+	}
+
+	payment := &models.OrderPayment{OrderID: id, Method: req.Method, Amount: amount, Earn: loyaltyProgram.earning(order)}
+	if req.Method == models.PaymentStoreCredit {
+		err = s.payWithStoreCredit(ctx, order, payment, req.Amount == nil, req.RequestID)
+	} else {
+		err = guardExec(func() error { return s.repo.Pay(ctx, payment) })
+	}
+	if errors.Is(err, models.ErrOrderPaid) {
+		return nil, fmt.Errorf("%w: order %s has less than %s left to pay", ErrOrderNotPayable, id, amount.StringFixed(2))
+	}
+	if errors.Is(err, ErrOrderNotPayable) || errors.Is(err, ErrStoreCreditTooLow) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to pay order %s: %w", id, err)
+	}
+
+	order, err = guard(func() (*models.Order, error) { return s.repo.FindByID(ctx, id) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to find order %s: %w", id, err)
+	}
+	return newOrderResponse(order), nil
+}
+
+// payWithStoreCredit pays an order from its customer's store credit, debiting it in
+// the same transaction. When partial, the payment is reduced to the customer's balance
+// if that is lower.
+func (s *orderService) payWithStoreCredit(ctx context.Context, order *models.Order, payment *models.OrderPayment,
+	partial bool, requestID string) error {
+	if order.CustomerPhone == nil {
+		return fmt.Errorf("%w: order %s has no customer_phone to pay with store credit", ErrOrderNotPayable, order.ID)
+	}
+	balance := decimal.Zero
+	account, err := guard(func() (*models.StoreCreditAccount, error) { return s.credits.FindAccount(ctx, *order.CustomerPhone) })
+	switch {
+	case err == nil:
+		balance = account.Balance
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("failed to find store credit of %s: %w", *order.CustomerPhone, err)
+	}
+	if partial {
+		payment.Amount = decimal.Min(payment.Amount, balance)
+	}
+	if !payment.Amount.IsPositive() || payment.Amount.GreaterThan(balance) {
+		return fmt.Errorf("%w: the customer has %s of store credit", ErrStoreCreditTooLow, balance.StringFixed(2))
+	}
+
+	entry := &models.StoreCreditEntry{
+		CustomerPhone: *order.CustomerPhone,
+		Type:          models.StoreCreditDebit,
+		Reason:        models.StoreCreditPayment,
+		Amount:        payment.Amount.Neg(),
+	}
+	if requestID != "" {
+		entry.RequestID = &requestID
+	}
+	err = guardExec(func() error { return s.credits.Debit(ctx, entry, payment) })
+	if errors.Is(err, models.ErrStoreCreditBalance) {
+		return fmt.Errorf("%w: the customer has less than %s of store credit", ErrStoreCreditTooLow, payment.Amount.StringFixed(2))
+	}
+	return err
+}
+
+// amountProblem describes what is wrong with an amount of money paid or loaded on a
+// gift card, or returns "" when it is valid
+func amountProblem(amount decimal.Decimal) string {
+	switch {
+	case !amount.IsPositive():
+		return "must be above 0"
+	case !amount.Equal(amount.Round(2)):
+		return "must have at most 2 decimal places"
+	}
+	return ""
+}
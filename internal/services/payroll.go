@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// Payroll export formats
+const (
+	PayrollJSON = "json"
+	PayrollCSV  = "csv"
+)
+
+// maxPayrollDays is the longest period a payroll export covers
+const maxPayrollDays = maxShiftListDays
+
+// PayrollExportOptions selects the period of a payroll export, by default the current
+// pay period, and its file format
+type PayrollExportOptions struct {
+	From   time.Time
+	To     time.Time
+	Format string
+}
+
+// PayrollExport is the time each staff member worked in a period, split into regular
+// hours and overtime
+type PayrollExport struct {
+	From  time.Time             `json:"from"`
+	To    time.Time             `json:"to"`
+	Staff []PayrollStaffSummary `json:"staff"`
+	// Hours of all staff in the period
+	RegularHours  float64 `json:"regular_hours" example:"380"`
+	OvertimeHours float64 `json:"overtime_hours" example:"25.25"`
+}
+
+// PayrollStaffSummary is the time a staff member worked in a payroll period, from the
+// punches they clocked in on in the period and clocked out of
+type PayrollStaffSummary struct {
+	StaffID       int     `json:"staff_id" example:"2"`
+	Name          string  `json:"name" example:"Lina"`
+	Role          string  `json:"role" example:"server"`
+	RegularHours  float64 `json:"regular_hours" example:"76"`
+	OvertimeHours float64 `json:"overtime_hours" example:"2.5"`
+	TotalHours    float64 `json:"total_hours" example:"78.5"`
+	Punches       int     `json:"punches" example:"10"`
+	// Punches not clocked out yet, which count no hours; a staff member who forgot to
+	// clock out needs their punch fixed before payroll is run
+	OpenPunches int `json:"open_punches" example:"0"`
+	// Whether any time counts as overtime
+	Overtime bool `json:"overtime"`
+}
+
+// PayrollExport sums the punches clocked in during a period into the regular and
+// overtime hours of each staff member who worked in it. Weeks are counted from the
+// start of the period. The overtime of a week is the time worked beyond the weekly
+// threshold or, when larger, the sum of the time beyond the daily threshold in each
+// of its business days.
+func (s *staffService) PayrollExport(ctx context.Context, opts PayrollExportOptions) (*PayrollExport, error) {
+	ctx, span := tracer.Start(ctx, "StaffService.PayrollExport")
+	defer span.End()
+
+	if opts.Format != PayrollJSON && opts.Format != PayrollCSV {
+		return nil, fmt.Errorf("%w: format must be one of json, csv", ErrInvalidReport)
+	}
+	switch {
+	case opts.From.IsZero() && opts.To.IsZero():
+		opts.From, opts.To = timeClock.period(time.Now())
+	case opts.From.IsZero():
+		opts.From, _ = timeClock.period(opts.To.Add(-time.Nanosecond))
+	case opts.To.IsZero():
+		_, opts.To = timeClock.period(opts.From)
+	}
+	switch {
+	case !opts.To.After(opts.From):
+		return nil, fmt.Errorf("%w: to must be after from", ErrInvalidReport)
+	case opts.To.Sub(opts.From) > maxPayrollDays*24*time.Hour:
+		return nil, fmt.Errorf("%w: the period must not exceed %d days", ErrInvalidReport, maxPayrollDays)
+	}
+
+	punches, err := guard(func() ([]models.TimePunch, error) { return s.repo.Punches(ctx, opts.From, opts.To, nil) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve punches: %w", err)
+	}
+
+	type staffHours struct {
+		summary *PayrollStaffSummary
+		days    map[time.Time]time.Duration
+		weeks   map[int]time.Duration
+	}
+	firstDay := businessDate(businessDay(opts.From))
+	hours := make(map[int]*staffHours)
+	for i := range punches {
+		punch := &punches[i]
+		member, ok := hours[punch.StaffID]
+		if !ok {
+			member = &staffHours{
+				summary: &PayrollStaffSummary{StaffID: punch.StaffID},
+				days:    make(map[time.Time]time.Duration),
+				weeks:   make(map[int]time.Duration),
+			}
+			hours[punch.StaffID] = member
+		}
+		member.summary.Punches++
+		if punch.ClockOutAt == nil {
+			member.summary.OpenPunches++
+			continue
+		}
+		worked := punch.ClockOutAt.Sub(punch.ClockInAt)
+		day := businessDate(businessDay(punch.ClockInAt))
+		member.days[day] += worked
+		member.weeks[int(day.Sub(firstDay).Hours()/24)/7] += worked
+	}
+
+	export := &PayrollExport{From: localTime(opts.From), To: localTime(opts.To), Staff: []PayrollStaffSummary{}}
+	staff, err := guard(func() ([]models.StaffMember, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve staff: %w", err)
+	}
+	// Staff are listed by name, as the repository returns them
+	for _, member := range staff {
+		worked, ok := hours[member.ID]
+		if !ok {
+			continue
+		}
+		dailyOvertime := make(map[int]time.Duration)
+		if timeClock.OvertimeDaily > 0 {
+			for day, total := range worked.days {
+				week := int(day.Sub(firstDay).Hours()/24) / 7
+				dailyOvertime[week] += max(0, total-timeClock.OvertimeDaily)
+			}
+		}
+		var total, overtime time.Duration
+		for week, weekTotal := range worked.weeks {
+			weeklyOvertime := time.Duration(0)
+			if timeClock.OvertimeWeekly > 0 {
+				weeklyOvertime = max(0, weekTotal-timeClock.OvertimeWeekly)
+			}
+			total += weekTotal
+			overtime += max(weeklyOvertime, dailyOvertime[week])
+		}
+
+		summary := worked.summary
+		summary.Name, summary.Role = member.Name, member.Role
+		summary.TotalHours = roundHours(total.Hours())
+		summary.OvertimeHours = roundHours(overtime.Hours())
+		summary.RegularHours = roundHours((total - overtime).Hours())
+		summary.Overtime = overtime > 0
+		export.RegularHours += summary.RegularHours
+		export.OvertimeHours += summary.OvertimeHours
+		export.Staff = append(export.Staff, *summary)
+	}
+	export.RegularHours = roundHours(export.RegularHours)
+	export.OvertimeHours = roundHours(export.OvertimeHours)
+	return export, nil
+}
+
+// WritePayrollCSV writes a payroll export as a CSV file with a row per staff member,
+// for import into payroll systems
+func WritePayrollCSV(w io.Writer, export *PayrollExport) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"EmployeeID", "EmployeeName", "Role", "PeriodStart", "PeriodEnd", "RegularHours", "OvertimeHours", "TotalHours", "Punches", "OpenPunches", "Overtime"})
+	periodStart := businessDay(export.From).Format(time.DateOnly)
+	periodEnd := businessDay(export.To.Add(-time.Nanosecond)).Format(time.DateOnly)
+	for _, member := range export.Staff {
+		out.Write([]string{
+			strconv.Itoa(member.StaffID),
+			member.Name,
+			member.Role,
+			periodStart,
+			periodEnd,
+			strconv.FormatFloat(member.RegularHours, 'f', 2, 64),
+			strconv.FormatFloat(member.OvertimeHours, 'f', 2, 64),
+			strconv.FormatFloat(member.TotalHours, 'f', 2, 64),
+			strconv.Itoa(member.Punches),
+			strconv.Itoa(member.OpenPunches),
+			strconv.FormatBool(member.Overtime),
+		})
+	}
+	out.Flush()
+	return out.Error()
+}
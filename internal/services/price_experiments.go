@@ -0,0 +1,402 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// PriceExperimentRepository abstracts price experiment storage
+type PriceExperimentRepository interface {
+	List(ctx context.Context) ([]models.PriceExperiment, error)
+	FindByID(ctx context.Context, id int) (*models.PriceExperiment, error)
+	Running(ctx context.Context, at time.Time) ([]models.PriceExperiment, error)
+	Overlapping(ctx context.Context, menuItemID int, from, to time.Time) (bool, error)
+	Create(ctx context.Context, experiment *models.PriceExperiment) error
+	End(ctx context.Context, id int, at time.Time) error
+	Delete(ctx context.Context, id int) error
+	HasExposures(ctx context.Context, id int) (bool, error)
+	RecordExposures(ctx context.Context, exposures []models.PriceExperimentExposure) error
+	ExposedPrices(ctx context.Context, guestSessionID int) ([]models.ExposedPrice, error)
+	Results(ctx context.Context, id, menuItemID int) ([]models.VariantResult, error)
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ PriceExperimentRepository = (*models.PriceExperimentQuery)(nil)
+
+// PriceExperimentService defines business operations on price experiments
+type PriceExperimentService interface {
+	ListExperiments(ctx context.Context) ([]PriceExperimentResponse, error)
+	CreateExperiment(ctx context.Context, req PriceExperimentRequest) (*PriceExperimentResponse, error)
+	GetExperimentResults(ctx context.Context, id int) (*PriceExperimentResults, error)
+	EndExperiment(ctx context.Context, id int) (*PriceExperimentResponse, error)
+	DeleteExperiment(ctx context.Context, id int) error
+}
+
+// Price experiment errors
+var (
+	ErrExperimentNotFound = errors.New("price experiment not found")
+	ErrInvalidExperiment  = errors.New("invalid price experiment")
+	// ErrExperimentOverlap is returned when an experiment would run at the same time as
+	// another one of its menu item
+	ErrExperimentOverlap = errors.New("price experiment overlaps another")
+	// ErrExperimentEnded is returned when ending an experiment that has ended already
+	ErrExperimentEnded = errors.New("price experiment already ended")
+	// ErrExperimentShown is returned when deleting an experiment already shown to
+	// guests; end it instead to keep its results
+	ErrExperimentShown = errors.New("price experiment was shown to guests")
+)
+
+// Price experiment statuses, from the time
+const (
+	ExperimentScheduled = "scheduled"
+	ExperimentRunning   = "running"
+	ExperimentEnded     = "ended"
+)
+
+// Limits of a price experiment's variants
+const (
+	minExperimentVariants = 2
+	maxExperimentVariants = 5
+	maxVariantNameLength  = 50
+)
+
+// PriceExperimentRequest defines a price experiment of a menu item. It starts at
+// starts_at, now when omitted, and ends at ends_at. The variants' weights are the
+// percentages of guest sessions shown each price, and add up to 100; give the item's
+// current price to one of them to keep a control group.
+type PriceExperimentRequest struct {
+	Name       string                `json:"name" example:"Burger price test"`
+	MenuItemID int                   `json:"menu_item_id" example:"7"`
+	StartsAt   *time.Time            `json:"starts_at,omitempty"`
+	EndsAt     time.Time             `json:"ends_at"`
+	Variants   []PriceVariantRequest `json:"variants"`
+}
+
+// PriceVariantRequest is a price tested by an experiment
+type PriceVariantRequest struct {
+	Name   string          `json:"name" example:"control"`
+	Price  decimal.Decimal `json:"price" swaggertype:"string" example:"12.50"`
+	Weight int             `json:"weight" example:"50"`
+}
+
+// PriceExperimentResponse represents the price experiment data returned to clients
+type PriceExperimentResponse struct {
+	ID         int                    `json:"id" example:"1"`
+	Name       string                 `json:"name" example:"Burger price test"`
+	MenuItemID int                    `json:"menu_item_id" example:"7"`
+	Status     string                 `json:"status" enums:"scheduled,running,ended" example:"running"`
+	StartsAt   time.Time              `json:"starts_at"`
+	EndsAt     time.Time              `json:"ends_at"`
+	Variants   []PriceVariantResponse `json:"variants"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+}
+
+// PriceVariantResponse is a price tested by an experiment
+type PriceVariantResponse struct {
+	ID     int             `json:"id" example:"3"`
+	Name   string          `json:"name" example:"control"`
+	Price  decimal.Decimal `json:"price" swaggertype:"string" example:"12.50"`
+	Weight int             `json:"weight" example:"50"`
+}
+
+// PriceExperimentResults compares what the guest sessions shown each variant of an
+// experiment ordered of its menu item
+type PriceExperimentResults struct {
+	Experiment PriceExperimentResponse `json:"experiment"`
+	Variants   []VariantResultResponse `json:"variants"`
+}
+
+// VariantResultResponse sums the non-cancelled orders of the menu item the guest
+// sessions shown a variant placed after seeing it
+type VariantResultResponse struct {
+	VariantID int             `json:"variant_id" example:"3"`
+	Name      string          `json:"name" example:"control"`
+	Price     decimal.Decimal `json:"price" swaggertype:"string" example:"12.50"`
+	// Sessions shown the variant, and those of them that ordered the item
+	Sessions         int `json:"sessions" example:"240"`
+	OrderingSessions int `json:"ordering_sessions" example:"66"`
+	// Share of the sessions that ordered the item
+	Conversion float64 `json:"conversion" example:"0.275"`
+	Quantity   int     `json:"quantity" example:"81"`
+	// Revenue of the item's lines, net of line discounts, overall and per session shown
+	// the variant
+	Revenue           decimal.Decimal `json:"revenue" swaggertype:"string" example:"1012.50"`
+	RevenuePerSession decimal.Decimal `json:"revenue_per_session" swaggertype:"string" example:"4.22"`
+}
+
+// priceExperimentService handles business logic for price experiments
+type priceExperimentService struct {
+	repo  PriceExperimentRepository
+	items MenuItemRepository
+}
+
+// NewPriceExperimentService creates a new price experiment service
+func NewPriceExperimentService(repo PriceExperimentRepository, items MenuItemRepository) PriceExperimentService {
+	return &priceExperimentService{repo: repo, items: items}
+}
+
+// ListExperiments returns all price experiments, latest start first
+func (s *priceExperimentService) ListExperiments(ctx context.Context) ([]PriceExperimentResponse, error) {
+	ctx, span := tracer.Start(ctx, "PriceExperimentService.ListExperiments")
+	defer span.End()
+
+	experiments, err := guard(func() ([]models.PriceExperiment, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve price experiments: %w", err)
+	}
+	now := time.Now()
+	responses := make([]PriceExperimentResponse, len(experiments))
+	for i := range experiments {
+		responses[i] = *newPriceExperimentResponse(&experiments[i], now)
+	}
+	return responses, nil
+}
+
+// CreateExperiment validates and stores a new price experiment. A menu item runs one
+// experiment at a time.
+func (s *priceExperimentService) CreateExperiment(ctx context.Context, req PriceExperimentRequest) (*PriceExperimentResponse, error) {
+	ctx, span := tracer.Start(ctx, "PriceExperimentService.CreateExperiment")
+	defer span.End()
+
+	now := time.Now()
+	experiment, err := newPriceExperiment(req, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = guard(func() (*models.MenuItem, error) { return s.items.FindByID(ctx, req.MenuItemID) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: menu item %d does not exist", ErrInvalidExperiment, req.MenuItemID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find menu item with ID %d: %w", req.MenuItemID, err)
+	}
+	overlaps, err := guard(func() (bool, error) {
+		return s.repo.Overlapping(ctx, experiment.MenuItemID, experiment.StartsAt, experiment.EndsAt)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check price experiments of menu item %d: %w", experiment.MenuItemID, err)
+	}
+	if overlaps {
+		return nil, fmt.Errorf("%w: menu item %d has an experiment in that period", ErrExperimentOverlap, experiment.MenuItemID)
+	}
+
+	if err := guardExec(func() error { return s.repo.Create(ctx, experiment) }); err != nil {
+		return nil, fmt.Errorf("failed to create price experiment: %w", err)
+	}
+	return newPriceExperimentResponse(experiment, now), nil
+}
+
+// GetExperimentResults compares the variants of a price experiment
+func (s *priceExperimentService) GetExperimentResults(ctx context.Context, id int) (*PriceExperimentResults, error) {
+	ctx, span := tracer.Start(ctx, "PriceExperimentService.GetExperimentResults")
+	defer span.End()
+
+	experiment, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := guard(func() ([]models.VariantResult, error) { return s.repo.Results(ctx, id, experiment.MenuItemID) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute results of price experiment %d: %w", id, err)
+	}
+	byVariant := make(map[int]models.VariantResult, len(rows))
+	for _, row := range rows {
+		byVariant[row.VariantID] = row
+	}
+
+	results := &PriceExperimentResults{
+		Experiment: *newPriceExperimentResponse(experiment, time.Now()),
+		Variants:   make([]VariantResultResponse, len(experiment.Variants)),
+	}
+	for i, variant := range experiment.Variants {
+		row := byVariant[variant.ID]
+		result := VariantResultResponse{
+			VariantID:         variant.ID,
+			Name:              variant.Name,
+			Price:             variant.Price,
+			Sessions:          row.Sessions,
+			OrderingSessions:  row.Ordering,
+			Quantity:          row.Quantity,
+			Revenue:           row.Revenue,
+			RevenuePerSession: decimal.Zero,
+		}
+		if row.Sessions > 0 {
+			result.Conversion = math.Round(float64(row.Ordering)/float64(row.Sessions)*1000) / 1000
+			result.RevenuePerSession = row.Revenue.Div(decimal.NewFromInt(int64(row.Sessions))).Round(2)
+		}
+		results.Variants[i] = result
+	}
+	return results, nil
+}
+
+// EndExperiment ends a running price experiment now, or cancels a scheduled one. Guest
+// sessions already shown a variant price keep being charged it.
+func (s *priceExperimentService) EndExperiment(ctx context.Context, id int) (*PriceExperimentResponse, error) {
+	ctx, span := tracer.Start(ctx, "PriceExperimentService.EndExperiment")
+	defer span.End()
+
+	ctx = database.UsePrimary(ctx)
+	if _, err := s.find(ctx, id); err != nil {
+		return nil, err
+	}
+	err := guardExec(func() error { return s.repo.End(ctx, id, time.Now()) })
+	if errors.Is(err, models.ErrExperimentEnded) {
+		return nil, ErrExperimentEnded
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to end price experiment %d: %w", id, err)
+	}
+	experiment, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return newPriceExperimentResponse(experiment, time.Now()), nil
+}
+
+// DeleteExperiment removes a price experiment that no guest was shown yet
+func (s *priceExperimentService) DeleteExperiment(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "PriceExperimentService.DeleteExperiment")
+	defer span.End()
+
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	shown, err := guard(func() (bool, error) { return s.repo.HasExposures(ctx, id) })
+	if err != nil {
+		return fmt.Errorf("failed to look up exposures of price experiment %d: %w", id, err)
+	}
+	if shown {
+		return fmt.Errorf("%w: end it instead to keep its results", ErrExperimentShown)
+	}
+	if err := guardExec(func() error { return s.repo.Delete(ctx, id) }); err != nil {
+		return fmt.Errorf("failed to delete price experiment %d: %w", id, err)
+	}
+	return nil
+}
+
+// find loads a price experiment by ID
+func (s *priceExperimentService) find(ctx context.Context, id int) (*models.PriceExperiment, error) {
+	experiment, err := guard(func() (*models.PriceExperiment, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrExperimentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find price experiment %d: %w", id, err)
+	}
+	return experiment, nil
+}
+
+// newPriceExperiment validates req and builds the experiment it defines, starting now
+// unless it gives a start
+func newPriceExperiment(req PriceExperimentRequest, now time.Time) (*models.PriceExperiment, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	startsAt := now
+	if req.StartsAt != nil {
+		startsAt = *req.StartsAt
+	}
+	switch {
+	case req.Name == "":
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidExperiment)
+	case len(req.Name) > 100:
+		return nil, fmt.Errorf("%w: name must be at most 100 characters", ErrInvalidExperiment)
+	case req.EndsAt.IsZero():
+		return nil, fmt.Errorf("%w: ends_at is required", ErrInvalidExperiment)
+	case !req.EndsAt.After(startsAt):
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidExperiment)
+	case !req.EndsAt.After(now):
+		return nil, fmt.Errorf("%w: ends_at must be in the future", ErrInvalidExperiment)
+	case len(req.Variants) < minExperimentVariants || len(req.Variants) > maxExperimentVariants:
+		return nil, fmt.Errorf("%w: give between %d and %d variants", ErrInvalidExperiment, minExperimentVariants, maxExperimentVariants)
+	}
+
+	experiment := &models.PriceExperiment{
+		Name:       req.Name,
+		MenuItemID: req.MenuItemID,
+		StartsAt:   startsAt,
+		EndsAt:     req.EndsAt,
+		Variants:   make([]models.PriceExperimentVariant, len(req.Variants)),
+	}
+	names := make(map[string]bool, len(req.Variants))
+	total := 0
+	for i, variant := range req.Variants {
+		name := strings.TrimSpace(variant.Name)
+		switch {
+		case name == "":
+			return nil, fmt.Errorf("%w: variant %d needs a name", ErrInvalidExperiment, i+1)
+		case len(name) > maxVariantNameLength:
+			return nil, fmt.Errorf("%w: variant names must be at most %d characters", ErrInvalidExperiment, maxVariantNameLength)
+		case names[name]:
+			return nil, fmt.Errorf("%w: variant %q is given twice", ErrInvalidExperiment, name)
+		case !variant.Price.IsPositive():
+			return nil, fmt.Errorf("%w: variant %q needs a price above 0", ErrInvalidExperiment, name)
+		case variant.Weight < 1:
+			return nil, fmt.Errorf("%w: variant %q needs a weight of at least 1", ErrInvalidExperiment, name)
+		}
+		names[name] = true
+		total += variant.Weight
+		experiment.Variants[i] = models.PriceExperimentVariant{Name: name, Price: variant.Price.Round(2), Weight: variant.Weight}
+	}
+	if total != 100 {
+		return nil, fmt.Errorf("%w: variant weights must add up to 100, not %d", ErrInvalidExperiment, total)
+	}
+	return experiment, nil
+}
+
+// experimentVariant picks the variant of experiment a guest session sees. Sessions are
+// bucketed by a hash of the experiment and session, so a session keeps its variant on
+// every request while sessions spread over the variants by their weights.
+func experimentVariant(experiment *models.PriceExperiment, guestSessionID int) *models.PriceExperimentVariant {
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%d:%d", experiment.ID, guestSessionID)
+	bucket := int(h.Sum32() % 100)
+	for i := range experiment.Variants {
+		bucket -= experiment.Variants[i].Weight
+		if bucket < 0 {
+			return &experiment.Variants[i]
+		}
+	}
+	return nil
+}
+
+// experimentStatus tells whether experiment is scheduled, running or ended at now
+func experimentStatus(experiment *models.PriceExperiment, now time.Time) string {
+	switch {
+	case now.Before(experiment.StartsAt):
+		return ExperimentScheduled
+	case now.Before(experiment.EndsAt):
+		return ExperimentRunning
+	}
+	return ExperimentEnded
+}
+
+// newPriceExperimentResponse converts a price experiment to its response at now
+func newPriceExperimentResponse(experiment *models.PriceExperiment, now time.Time) *PriceExperimentResponse {
+	response := &PriceExperimentResponse{
+		ID:         experiment.ID,
+		Name:       experiment.Name,
+		MenuItemID: experiment.MenuItemID,
+		Status:     experimentStatus(experiment, now),
+		StartsAt:   localTime(experiment.StartsAt),
+		EndsAt:     localTime(experiment.EndsAt),
+		Variants:   make([]PriceVariantResponse, len(experiment.Variants)),
+		CreatedAt:  localTime(experiment.CreatedAt),
+		UpdatedAt:  localTime(experiment.UpdatedAt),
+	}
+	for i, variant := range experiment.Variants {
+		response.Variants[i] = PriceVariantResponse{ID: variant.ID, Name: variant.Name, Price: variant.Price, Weight: variant.Weight}
+	}
+	return response
+}
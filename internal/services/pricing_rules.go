@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// PricingRuleRepository abstracts pricing rule storage
+type PricingRuleRepository interface {
+	List(ctx context.Context) ([]models.PricingRule, error)
+	FindByID(ctx context.Context, id int) (*models.PricingRule, error)
+	Create(ctx context.Context, rule *models.PricingRule) error
+	Update(ctx context.Context, rule *models.PricingRule) error
+	Delete(ctx context.Context, id int) error
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ PricingRuleRepository = (*models.PricingRuleQuery)(nil)
+
+// PricingRuleService defines business operations on pricing rules
+type PricingRuleService interface {
+	ListPricingRules(ctx context.Context) ([]PricingRuleResponse, error)
+	CreatePricingRule(ctx context.Context, req PricingRuleRequest) (*PricingRuleResponse, error)
+	UpdatePricingRule(ctx context.Context, id int, req PricingRuleRequest) (*PricingRuleResponse, error)
+	DeletePricingRule(ctx context.Context, id int) error
+}
+
+// Pricing rule errors
+var (
+	ErrPricingRuleNotFound = errors.New("pricing rule not found")
+	ErrInvalidPricingRule  = errors.New("invalid pricing rule")
+)
+
+// weekdays are the day names of pricing rules, indexed by time.Weekday
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// PricingRuleRequest creates or replaces a pricing rule. At most one of Category and
+// MenuItemID may be set; with neither, the rule applies to every item. The rule is
+// in effect from StartTime to EndTime (HH:MM, restaurant time) on Days, or every day
+// when Days is empty; a window ending earlier than it starts runs past midnight.
+// Discount is a percentage for the "percent" type and a price reduction for "amount".
+type PricingRuleRequest struct {
+	Name         string          `json:"name" example:"Happy hour"`
+	Category     *string         `json:"category,omitempty" example:"drink"`
+	MenuItemID   *int            `json:"menu_item_id,omitempty"`
+	Days         []string        `json:"days,omitempty" example:"mon,tue,wed,thu,fri"`
+	StartTime    string          `json:"start_time" example:"17:00"`
+	EndTime      string          `json:"end_time" example:"19:00"`
+	DiscountType string          `json:"discount_type" enums:"percent,amount" example:"percent"`
+	Discount     decimal.Decimal `json:"discount" swaggertype:"string" example:"25"`
+	IsActive     *bool           `json:"is_active,omitempty"`
+}
+
+// PricingRuleResponse represents the pricing rule data returned to clients
+type PricingRuleResponse struct {
+	ID           int             `json:"id" example:"1"`
+	Name         string          `json:"name" example:"Happy hour"`
+	Category     *string         `json:"category,omitempty" example:"drink"`
+	MenuItemID   *int            `json:"menu_item_id,omitempty"`
+	Days         []string        `json:"days" example:"mon,tue,wed,thu,fri"`
+	StartTime    string          `json:"start_time" example:"17:00"`
+	EndTime      string          `json:"end_time" example:"19:00"`
+	DiscountType string          `json:"discount_type" example:"percent"`
+	Discount     decimal.Decimal `json:"discount" swaggertype:"string" example:"25"`
+	IsActive     bool            `json:"is_active"`
+	// Whether the rule currently discounts prices
+	InEffect  bool      `json:"in_effect"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// pricingRuleService handles business logic for pricing rules
+type pricingRuleService struct {
+	repo PricingRuleRepository
+}
+
+// NewPricingRuleService creates a new pricing rule service
+func NewPricingRuleService(repo PricingRuleRepository) PricingRuleService {
+	return &pricingRuleService{repo: repo}
+}
+
+// ListPricingRules returns all pricing rules
+func (s *pricingRuleService) ListPricingRules(ctx context.Context) ([]PricingRuleResponse, error) {
+	ctx, span := tracer.Start(ctx, "PricingRuleService.ListPricingRules")
+	defer span.End()
+
+	rules, err := guard(func() ([]models.PricingRule, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve pricing rules: %w", err)
+	}
+	now := time.Now()
+	responses := make([]PricingRuleResponse, len(rules))
+	for i := range rules {
+		responses[i] = *newPricingRuleResponse(&rules[i], now)
+	}
+	return responses, nil
+}
+
+// CreatePricingRule validates and stores a new pricing rule
+func (s *pricingRuleService) CreatePricingRule(ctx context.Context, req PricingRuleRequest) (*PricingRuleResponse, error) {
+	ctx, span := tracer.Start(ctx, "PricingRuleService.CreatePricingRule")
+	defer span.End()
+
+	rule := &models.PricingRule{IsActive: true}
+	if err := applyPricingRule(rule, req); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Create(ctx, rule) }); err != nil {
+		return nil, fmt.Errorf("failed to create pricing rule: %w", err)
+	}
+	return newPricingRuleResponse(rule, time.Now()), nil
+}
+
+// UpdatePricingRule replaces a pricing rule. Orders keep the prices they were charged.
+func (s *pricingRuleService) UpdatePricingRule(ctx context.Context, id int, req PricingRuleRequest) (*PricingRuleResponse, error) {
+	ctx, span := tracer.Start(ctx, "PricingRuleService.UpdatePricingRule")
+	defer span.End()
+
+	rule, err := guard(func() (*models.PricingRule, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPricingRuleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pricing rule %d: %w", id, err)
+	}
+	if err := applyPricingRule(rule, req); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Update(ctx, rule) }); err != nil {
+		return nil, fmt.Errorf("failed to update pricing rule %d: %w", id, err)
+	}
+	return newPricingRuleResponse(rule, time.Now()), nil
+}
+
+// DeletePricingRule removes a pricing rule. Orders keep the prices they were charged.
+func (s *pricingRuleService) DeletePricingRule(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "PricingRuleService.DeletePricingRule")
+	defer span.End()
+
+	_, err := guard(func() (*models.PricingRule, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrPricingRuleNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find pricing rule %d: %w", id, err)
+	}
+	if err := guardExec(func() error { return s.repo.Delete(ctx, id) }); err != nil {
+		return fmt.Errorf("failed to delete pricing rule %d: %w", id, err)
+	}
+	return nil
+}
+
+// applyPricingRule validates req and copies it onto rule. Days are stored in week
+// order, Sunday first.
+func applyPricingRule(rule *models.PricingRule, req PricingRuleRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	switch {
+	case req.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidPricingRule)
+	case req.Category != nil && req.MenuItemID != nil:
+		return fmt.Errorf("%w: set at most one of category and menu_item_id", ErrInvalidPricingRule)
+	case req.Category != nil && !ValidCategories[*req.Category]:
+		return fmt.Errorf("%w: category %s", ErrInvalidPricingRule, categoryList())
+	}
+
+	start, err := parseClock(req.StartTime)
+	if err != nil {
+		return fmt.Errorf("%w: start_time %v", ErrInvalidPricingRule, err)
+	}
+	end, err := parseClock(req.EndTime)
+	if err != nil {
+		return fmt.Errorf("%w: end_time %v", ErrInvalidPricingRule, err)
+	}
+	if start == end {
+		return fmt.Errorf("%w: start_time and end_time must differ", ErrInvalidPricingRule)
+	}
+
+	for _, day := range req.Days {
+		if !slices.Contains(weekdays, day) {
+			return fmt.Errorf("%w: days must be among %s", ErrInvalidPricingRule, strings.Join(weekdays, ", "))
+		}
+	}
+	var days []string
+	for _, day := range weekdays {
+		if slices.Contains(req.Days, day) {
+			days = append(days, day)
+		}
+	}
+
+	if problem := discountProblem(req.DiscountType, req.Discount); problem != "" {
+		return fmt.Errorf("%w: %s", ErrInvalidPricingRule, problem)
+	}
+
+	rule.Name = req.Name
+	rule.Category = req.Category
+	rule.MenuItemID = req.MenuItemID
+	rule.Days = strings.Join(days, ",")
+	rule.StartTime = req.StartTime
+	rule.EndTime = req.EndTime
+	rule.DiscountType = req.DiscountType
+	rule.Discount = req.Discount
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// discountProblem describes what is wrong with a discount of a pricing rule, coupon
+// or promotion, or returns "" if nothing is
+func discountProblem(discountType string, discount decimal.Decimal) string {
+	switch discountType {
+	case models.DiscountPercent:
+		if !discount.IsPositive() || discount.GreaterThan(decimal.NewFromInt(100)) {
+			return "a percent discount must be above 0 and at most 100"
+		}
+	case models.DiscountAmount:
+		if !discount.IsPositive() {
+			return "an amount discount must be above 0"
+		}
+	default:
+		return "discount_type must be percent or amount"
+	}
+	return ""
+}
+
+// parseClock reads an HH:MM time of day as the duration since midnight
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("must be a time of day as HH:MM, e.g. 17:30")
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// newPricingRuleResponse converts a pricing rule model to its response DTO, reporting
+// whether it is in effect at now
+func newPricingRuleResponse(rule *models.PricingRule, now time.Time) *PricingRuleResponse {
+	days := []string{}
+	if rule.Days != "" {
+		days = strings.Split(rule.Days, ",")
+	}
+	return &PricingRuleResponse{
+		ID:           rule.ID,
+		Name:         rule.Name,
+		Category:     rule.Category,
+		MenuItemID:   rule.MenuItemID,
+		Days:         days,
+		StartTime:    rule.StartTime,
+		EndTime:      rule.EndTime,
+		DiscountType: rule.DiscountType,
+		Discount:     rule.Discount,
+		IsActive:     rule.IsActive,
+		InEffect:     ruleInEffect(rule, now),
+		CreatedAt:    localTime(rule.CreatedAt),
+		UpdatedAt:    localTime(rule.UpdatedAt),
+	}
+}
+
+// ruleInEffect reports whether an active rule's time window covers t in the
+// restaurant's timezone. A window past midnight belongs to the day it starts on.
+func ruleInEffect(rule *models.PricingRule, t time.Time) bool {
+	if !rule.IsActive {
+		return false
+	}
+	start, err := parseClock(rule.StartTime)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(rule.EndTime)
+	if err != nil {
+		return false
+	}
+
+	t = localTime(t)
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	day := t.Weekday()
+	switch {
+	case start < end && (clock < start || clock >= end):
+		return false
+	case start > end && clock < start && clock >= end:
+		return false
+	case start > end && clock < end:
+		day = (day + 6) % 7 // Started the day before
+	}
+	return rule.Days == "" || slices.Contains(strings.Split(rule.Days, ","), weekdays[day])
+}
+
+// discountedPrice applies the pricing rules in effect at t to the price of a menu
+// item. When several rules apply, the one giving the lowest price wins. It returns
+// nil when no rule applies.
+func discountedPrice(price decimal.Decimal, rules []models.PricingRule, menuItemID int, category string, t time.Time) (decimal.Decimal, *models.PricingRule) {
+	best, bestRule := price, (*models.PricingRule)(nil)
+	for i := range rules {
+		rule := &rules[i]
+		switch {
+		case rule.MenuItemID != nil && *rule.MenuItemID != menuItemID:
+			continue
+		case rule.Category != nil && *rule.Category != category:
+			continue
+		case !ruleInEffect(rule, t):
+			continue
+		}
+
+		var discounted decimal.Decimal
+		if rule.DiscountType == models.DiscountPercent {
+			discounted = price.Sub(price.Mul(rule.Discount).Div(decimal.NewFromInt(100))).Round(2)
+		} else {
+			discounted = decimal.Max(price.Sub(rule.Discount), decimal.Zero)
+		}
+		if bestRule == nil || discounted.LessThan(best) {
+			best, bestRule = discounted, rule
+		}
+	}
+	return best, bestRule
+}
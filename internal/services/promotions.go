@@ -0,0 +1,354 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// PromotionRepository abstracts promotion storage
+type PromotionRepository interface {
+	List(ctx context.Context) ([]models.Promotion, error)
+	FindByID(ctx context.Context, id int) (*models.Promotion, error)
+	Create(ctx context.Context, promotion *models.Promotion) error
+	Update(ctx context.Context, promotion *models.Promotion) error
+	Delete(ctx context.Context, id int) error
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ PromotionRepository = (*models.PromotionQuery)(nil)
+
+// PromotionService defines business operations on promotions
+type PromotionService interface {
+	ListPromotions(ctx context.Context) ([]PromotionResponse, error)
+	CreatePromotion(ctx context.Context, req PromotionRequest) (*PromotionResponse, error)
+	UpdatePromotion(ctx context.Context, id int, req PromotionRequest) (*PromotionResponse, error)
+	DeletePromotion(ctx context.Context, id int) error
+}
+
+// Promotion errors
+var (
+	ErrPromotionNotFound = errors.New("promotion not found")
+	ErrInvalidPromotion  = errors.New("invalid promotion")
+)
+
+// PromotionRequest creates or replaces a promotion. A buy_get promotion discounts
+// get_quantity of every buy_quantity + get_quantity eligible units ordered, cheapest
+// first: buy 1 get 1 with a 100 percent discount is buy-one-get-one-free. A spend_get
+// promotion discounts the eligible lines once they total min_spend. At most one of
+// Category and MenuItemID may be set; with neither, every item is eligible.
+type PromotionRequest struct {
+	Name         string          `json:"name" example:"Pizza BOGO"`
+	Type         string          `json:"type" enums:"buy_get,spend_get" example:"buy_get"`
+	Category     *string         `json:"category,omitempty" example:"main"`
+	MenuItemID   *int            `json:"menu_item_id,omitempty"`
+	BuyQuantity  int             `json:"buy_quantity,omitempty" example:"1"`
+	GetQuantity  int             `json:"get_quantity,omitempty" example:"1"`
+	MinSpend     decimal.Decimal `json:"min_spend,omitempty" swaggertype:"string" example:"0"`
+	DiscountType string          `json:"discount_type" enums:"percent,amount" example:"percent"`
+	Discount     decimal.Decimal `json:"discount" swaggertype:"string" example:"100"`
+	ValidFrom    *time.Time      `json:"valid_from,omitempty"`
+	ValidUntil   *time.Time      `json:"valid_until,omitempty"`
+	IsActive     *bool           `json:"is_active,omitempty"`
+}
+
+// PromotionResponse represents the promotion data returned to clients
+type PromotionResponse struct {
+	ID           int             `json:"id" example:"1"`
+	Name         string          `json:"name" example:"Pizza BOGO"`
+	Type         string          `json:"type" example:"buy_get"`
+	Category     *string         `json:"category,omitempty" example:"main"`
+	MenuItemID   *int            `json:"menu_item_id,omitempty"`
+	BuyQuantity  int             `json:"buy_quantity,omitempty" example:"1"`
+	GetQuantity  int             `json:"get_quantity,omitempty" example:"1"`
+	MinSpend     decimal.Decimal `json:"min_spend" swaggertype:"string" example:"0"`
+	DiscountType string          `json:"discount_type" example:"percent"`
+	Discount     decimal.Decimal `json:"discount" swaggertype:"string" example:"100"`
+	ValidFrom    *time.Time      `json:"valid_from,omitempty"`
+	ValidUntil   *time.Time      `json:"valid_until,omitempty"`
+	IsActive     bool            `json:"is_active"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// OrderPromotionResponse explains a promotion applied to an order
+type OrderPromotionResponse struct {
+	Name        string          `json:"name" example:"Pizza BOGO"`
+	Description string          `json:"description" example:"Buy 1 get 1 free: 1 of 2 eligible items free"`
+	Discount    decimal.Decimal `json:"discount" swaggertype:"string" example:"12.50"`
+}
+
+// promotionService handles business logic for promotions
+type promotionService struct {
+	repo PromotionRepository
+}
+
+// NewPromotionService creates a new promotion service
+func NewPromotionService(repo PromotionRepository) PromotionService {
+	return &promotionService{repo: repo}
+}
+
+// ListPromotions returns all promotions
+func (s *promotionService) ListPromotions(ctx context.Context) ([]PromotionResponse, error) {
+	ctx, span := tracer.Start(ctx, "PromotionService.ListPromotions")
+	defer span.End()
+
+	promotions, err := guard(func() ([]models.Promotion, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve promotions: %w", err)
+	}
+	responses := make([]PromotionResponse, len(promotions))
+	for i := range promotions {
+		responses[i] = *newPromotionResponse(&promotions[i])
+	}
+	return responses, nil
+}
+
+// CreatePromotion validates and stores a new promotion
+func (s *promotionService) CreatePromotion(ctx context.Context, req PromotionRequest) (*PromotionResponse, error) {
+	ctx, span := tracer.Start(ctx, "PromotionService.CreatePromotion")
+	defer span.End()
+
+	promotion := &models.Promotion{IsActive: true}
+	if err := applyPromotion(promotion, req); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Create(ctx, promotion) }); err != nil {
+		return nil, fmt.Errorf("failed to create promotion: %w", err)
+	}
+	return newPromotionResponse(promotion), nil
+}
+
+// UpdatePromotion replaces a promotion. Orders keep the promotions applied to them.
+func (s *promotionService) UpdatePromotion(ctx context.Context, id int, req PromotionRequest) (*PromotionResponse, error) {
+	ctx, span := tracer.Start(ctx, "PromotionService.UpdatePromotion")
+	defer span.End()
+
+	promotion, err := guard(func() (*models.Promotion, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPromotionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find promotion %d: %w", id, err)
+	}
+	if err := applyPromotion(promotion, req); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Update(ctx, promotion) }); err != nil {
+		return nil, fmt.Errorf("failed to update promotion %d: %w", id, err)
+	}
+	return newPromotionResponse(promotion), nil
+}
+
+// DeletePromotion removes a promotion. Orders keep the promotions applied to them.
+func (s *promotionService) DeletePromotion(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "PromotionService.DeletePromotion")
+	defer span.End()
+
+	_, err := guard(func() (*models.Promotion, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrPromotionNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find promotion %d: %w", id, err)
+	}
+	if err := guardExec(func() error { return s.repo.Delete(ctx, id) }); err != nil {
+		return fmt.Errorf("failed to delete promotion %d: %w", id, err)
+	}
+	return nil
+}
+
+// applyPromotion validates req and copies it onto promotion
+func applyPromotion(promotion *models.Promotion, req PromotionRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	switch {
+	case req.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidPromotion)
+	case req.Category != nil && req.MenuItemID != nil:
+		return fmt.Errorf("%w: set at most one of category and menu_item_id", ErrInvalidPromotion)
+	case req.Category != nil && !ValidCategories[*req.Category]:
+		return fmt.Errorf("%w: category %s", ErrInvalidPromotion, categoryList())
+	case req.ValidFrom != nil && req.ValidUntil != nil && !req.ValidUntil.After(*req.ValidFrom):
+		return fmt.Errorf("%w: valid_until must be after valid_from", ErrInvalidPromotion)
+	}
+	switch req.Type {
+	case models.PromotionBuyGet:
+		if req.BuyQuantity < 1 || req.GetQuantity < 1 {
+			return fmt.Errorf("%w: buy_quantity and get_quantity must be at least 1", ErrInvalidPromotion)
+		}
+		req.MinSpend = decimal.Zero
+	case models.PromotionSpendGet:
+		if !req.MinSpend.IsPositive() {
+			return fmt.Errorf("%w: min_spend must be above 0", ErrInvalidPromotion)
+		}
+		req.BuyQuantity, req.GetQuantity = 0, 0
+	default:
+		return fmt.Errorf("%w: type must be buy_get or spend_get", ErrInvalidPromotion)
+	}
+	if problem := discountProblem(req.DiscountType, req.Discount); problem != "" {
+		return fmt.Errorf("%w: %s", ErrInvalidPromotion, problem)
+	}
+
+	promotion.Name = req.Name
+	promotion.Type = req.Type
+	promotion.Category = req.Category
+	promotion.MenuItemID = req.MenuItemID
+	promotion.BuyQuantity = req.BuyQuantity
+	promotion.GetQuantity = req.GetQuantity
+	promotion.MinSpend = req.MinSpend
+	promotion.DiscountType = req.DiscountType
+	promotion.Discount = req.Discount
+	promotion.ValidFrom = req.ValidFrom
+	promotion.ValidUntil = req.ValidUntil
+	if req.IsActive != nil {
+		promotion.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// newPromotionResponse converts a promotion model to its response DTO
+func newPromotionResponse(promotion *models.Promotion) *PromotionResponse {
+	response := &PromotionResponse{
+		ID:           promotion.ID,
+		Name:         promotion.Name,
+		Type:         promotion.Type,
+		Category:     promotion.Category,
+		MenuItemID:   promotion.MenuItemID,
+		BuyQuantity:  promotion.BuyQuantity,
+		GetQuantity:  promotion.GetQuantity,
+		MinSpend:     promotion.MinSpend,
+		DiscountType: promotion.DiscountType,
+		Discount:     promotion.Discount,
+		IsActive:     promotion.IsActive,
+		CreatedAt:    localTime(promotion.CreatedAt),
+		UpdatedAt:    localTime(promotion.UpdatedAt),
+	}
+	if promotion.ValidFrom != nil {
+		validFrom := localTime(*promotion.ValidFrom)
+		response.ValidFrom = &validFrom
+	}
+	if promotion.ValidUntil != nil {
+		validUntil := localTime(*promotion.ValidUntil)
+		response.ValidUntil = &validUntil
+	}
+	return response
+}
+
+// applyPromotions evaluates the promotions in effect at t against the lines of a new
+// order, whose menu categories are given in categories, in ID order. Each promotion
+// sees the lines as discounted by the previous ones. It adds the discounts to the
+// lines and returns an explanation of each promotion that applied.
+func applyPromotions(promotions []models.Promotion, items []models.OrderItem, categories []string, t time.Time) []models.OrderPromotion {
+	var applied []models.OrderPromotion
+	for i := range promotions {
+		promotion := &promotions[i]
+		switch {
+		case !promotion.IsActive:
+			continue
+		case promotion.ValidFrom != nil && t.Before(*promotion.ValidFrom):
+			continue
+		case promotion.ValidUntil != nil && !t.Before(*promotion.ValidUntil):
+			continue
+		}
+
+		var lines []int
+		for j, item := range items {
+			if inScope(promotion.MenuItemID, promotion.Category, item, categories[j]) {
+				lines = append(lines, j)
+			}
+		}
+
+		var discount decimal.Decimal
+		var description string
+		if promotion.Type == models.PromotionBuyGet {
+			discount, description = buyGet(promotion, items, lines)
+		} else {
+			discount, description = spendGet(promotion, items, lines)
+		}
+		if discount.IsPositive() {
+			applied = append(applied, models.OrderPromotion{
+				PromotionID: &promotion.ID,
+				Name:        promotion.Name,
+				Description: description,
+				Discount:    discount,
+			})
+		}
+	}
+	return applied
+}
+
+// buyGet discounts get_quantity of every buy_quantity + get_quantity units of the
+// given lines, cheapest units first
+func buyGet(promotion *models.Promotion, items []models.OrderItem, lines []int) (decimal.Decimal, string) {
+	units := 0
+	for _, i := range lines {
+		units += items[i].Quantity
+	}
+	discounted := units / (promotion.BuyQuantity + promotion.GetQuantity) * promotion.GetQuantity
+	if discounted == 0 {
+		return decimal.Zero, ""
+	}
+
+	// Lines by the price of their units as discounted so far, cheapest first
+	cheapest := slices.Clone(lines)
+	unitPrice := func(i int) decimal.Decimal {
+		return items[i].DiscountedTotal().Div(decimal.NewFromInt(int64(items[i].Quantity)))
+	}
+	slices.SortStableFunc(cheapest, func(a, b int) int { return unitPrice(a).Cmp(unitPrice(b)) })
+
+	total := decimal.Zero
+	remaining := discounted
+	for _, i := range cheapest {
+		if remaining == 0 {
+			break
+		}
+		count := min(remaining, items[i].Quantity)
+		remaining -= count
+
+		price := unitPrice(i)
+		perUnit := decimal.Min(promotion.Discount, price)
+		if promotion.DiscountType == models.DiscountPercent {
+			perUnit = price.Mul(promotion.Discount).Div(decimal.NewFromInt(100))
+		}
+		share := decimal.Min(perUnit.Mul(decimal.NewFromInt(int64(count))).Round(2), items[i].DiscountedTotal())
+		items[i].Discount = items[i].Discount.Add(share)
+		total = total.Add(share)
+	}
+
+	reward := promotion.Discount.String() + "% off"
+	switch {
+	case promotion.DiscountType == models.DiscountAmount:
+		reward = promotion.Discount.StringFixed(2) + " off"
+	case promotion.Discount.Equal(decimal.NewFromInt(100)):
+		reward = "free"
+	}
+	return total, fmt.Sprintf("Buy %d get %d %s: %d of %d eligible items %s",
+		promotion.BuyQuantity, promotion.GetQuantity, reward, discounted, units, reward)
+}
+
+// spendGet discounts the given lines when they total at least min_spend
+func spendGet(promotion *models.Promotion, items []models.OrderItem, lines []int) (decimal.Decimal, string) {
+	spent := decimal.Zero
+	for _, i := range lines {
+		spent = spent.Add(items[i].DiscountedTotal())
+	}
+	if spent.LessThan(promotion.MinSpend) {
+		return decimal.Zero, ""
+	}
+
+	discount := spreadDiscount(items, lines, promotion.DiscountType, promotion.Discount)
+	reward := promotion.Discount.StringFixed(2) + " off"
+	if promotion.DiscountType == models.DiscountPercent {
+		reward = promotion.Discount.String() + "% off"
+	}
+	return discount, fmt.Sprintf("Spend %s get %s: spent %s on eligible items",
+		promotion.MinSpend.StringFixed(2), reward, spent.StringFixed(2))
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// DeletedPurger is implemented by repositories of soft-deletable models
+type DeletedPurger interface {
+	PurgeDeleted(ctx context.Context, cutoff time.Time, dryRun bool) (int, error)
+}
+
+// PurgeResult reports the rows removed (or, in a dry run, that would be removed) per table
+type PurgeResult struct {
+	DryRun        bool           `json:"dry_run"`
+	RetentionDays int            `json:"retention_days" example:"30"`
+	Cutoff        time.Time      `json:"cutoff"`
+	Tables        map[string]int `json:"tables"`
+	Total         int            `json:"total"`
+}
+
+// SoftDeletePurger permanently deletes rows that were soft-deleted longer ago than the
+// retention period
+type SoftDeletePurger struct {
+	retentionDays int
+	tables        map[string]DeletedPurger
+}
+
+// NewSoftDeletePurger creates a purger for the given tables, keyed by table name
+func NewSoftDeletePurger(retentionDays int, tables map[string]DeletedPurger) *SoftDeletePurger {
+	return &SoftDeletePurger{retentionDays: retentionDays, tables: tables}
+}
+
+// Purge removes rows soft-deleted more than the retention period ago from every
+// table, or only counts them when dryRun is set
+func (p *SoftDeletePurger) Purge(ctx context.Context, dryRun bool) (*PurgeResult, error) {
+	ctx, span := tracer.Start(ctx, "SoftDeletePurger.Purge")
+	defer span.End()
+
+	result := &PurgeResult{
+		DryRun:        dryRun,
+		RetentionDays: p.retentionDays,
+		Cutoff:        time.Now().AddDate(0, 0, -p.retentionDays).UTC(),
+		Tables:        make(map[string]int, len(p.tables)),
+	}
+
+	for _, table := range slices.Sorted(maps.Keys(p.tables)) {
+		var n int
+		err := guardExec(func() error {
+			var err error
+			n, err = p.tables[table].PurgeDeleted(ctx, result.Cutoff, dryRun)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to purge deleted rows from %s: %w", table, err)
+		}
+		result.Tables[table] = n
+		result.Total += n
+	}
+
+	logging.FromContext(ctx).Info("Purged soft-deleted rows",
+		slog.Bool("dry_run", dryRun),
+		slog.Time("cutoff", result.Cutoff),
+		slog.Int("total", result.Total))
+	return result, nil
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// PairingRepository finds the menu items ordered together
+type PairingRepository interface {
+	OrdersWith(ctx context.Context, menuItemID int, from, to time.Time) (int, error)
+	OrderedWith(ctx context.Context, menuItemID int, from, to time.Time, limit int) ([]models.ItemPairing, error)
+}
+
+// The sales query builder is the default pairing repository implementation
+var _ PairingRepository = (*models.SalesQuery)(nil)
+
+// RecommendationService defines the interface for menu item recommendations
+type RecommendationService interface {
+	Recommendations(ctx context.Context, menuItemID int, opts RecommendationOptions) (*RecommendationsResponse, error)
+}
+
+// Paging and lookback of recommendations
+const (
+	defaultRecommendationLimit = 5
+	maxRecommendationLimit     = 20
+	defaultRecommendationDays  = 90
+	maxRecommendationDays      = 365
+)
+
+// RecommendationOptions selects the recommendations of a menu item. Zero values use the
+// defaults, and larger values are capped.
+type RecommendationOptions struct {
+	Limit int // Number of recommendations, default 5, at most 20
+	Days  int // Days of orders to look back over, default 90, at most 365
+}
+
+// RecommendationsResponse lists the menu items most often ordered along with a menu
+// item, for upselling
+type RecommendationsResponse struct {
+	MenuItemID int    `json:"menu_item_id" example:"7"`
+	MenuItem   string `json:"menu_item" example:"Burger"`
+	// Orders of the period that included the item
+	Orders          int                      `json:"orders" example:"120"`
+	From            time.Time                `json:"from"`
+	To              time.Time                `json:"to"`
+	Recommendations []RecommendationResponse `json:"recommendations"`
+}
+
+// RecommendationResponse is a menu item ordered along with another one
+type RecommendationResponse struct {
+	Item MenuItemResponse `json:"item"`
+	// Orders of the other item that included this one too, and their share of all the
+	// other item's orders
+	OrdersTogether int     `json:"orders_together" example:"54"`
+	Share          float64 `json:"share" example:"0.45"`
+}
+
+// recommendationService handles business logic for menu item recommendations
+type recommendationService struct {
+	pairings PairingRepository
+	items    MenuItemRepository
+	rules    PricingRuleRepository
+}
+
+// NewRecommendationService creates a new recommendation service. Recommended items are
+// priced by the pricing rules of rules in effect when it is non-nil.
+func NewRecommendationService(pairings PairingRepository, items MenuItemRepository, rules PricingRuleRepository) RecommendationService {
+	return &recommendationService{pairings: pairings, items: items, rules: rules}
+}
+
+// Recommendations ranks the available menu items by the number of non-cancelled orders
+// they were in along with a menu item over the last opts.Days days. Items never ordered
+// with it aren't recommended, so new items get none until they sell.
+func (s *recommendationService) Recommendations(ctx context.Context, menuItemID int, opts RecommendationOptions) (*RecommendationsResponse, error) {
+	ctx, span := tracer.Start(ctx, "RecommendationService.Recommendations")
+	defer span.End()
+
+	item, err := guard(func() (*models.MenuItem, error) { return s.items.FindByID(ctx, menuItemID) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to find menu item with ID %d: %w", menuItemID, err)
+	}
+
+	limit, days := opts.Limit, opts.Days
+	if limit <= 0 {
+		limit = defaultRecommendationLimit
+	}
+	if days <= 0 {
+		days = defaultRecommendationDays
+	}
+	to := localTime(time.Now())
+	from := to.AddDate(0, 0, -min(days, maxRecommendationDays))
+
+	orders, err := guard(func() (int, error) { return s.pairings.OrdersWith(ctx, item.ID, from, to) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to count orders of menu item %d: %w", item.ID, err)
+	}
+	response := &RecommendationsResponse{
+		MenuItemID:      item.ID,
+		MenuItem:        item.Name,
+		Orders:          orders,
+		From:            from,
+		To:              to,
+		Recommendations: []RecommendationResponse{},
+	}
+	if orders == 0 {
+		return response, nil
+	}
+
+	pairings, err := guard(func() ([]models.ItemPairing, error) {
+		return s.pairings.OrderedWith(ctx, item.ID, from, to, min(limit, maxRecommendationLimit))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find items ordered with menu item %d: %w", item.ID, err)
+	}
+	if len(pairings) == 0 {
+		return response, nil
+	}
+
+	ids := make([]int, len(pairings))
+	for i, pairing := range pairings {
+		ids[i] = pairing.MenuItemID
+	}
+	paired, err := guard(func() ([]models.MenuItem, error) { return s.items.All(ctx, models.WithIDs(ids...)) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve recommended menu items: %w", err)
+	}
+	byID := make(map[int]*models.MenuItem, len(paired))
+	for i := range paired {
+		byID[paired[i].ID] = &paired[i]
+	}
+
+	// Items deleted since the pairings were counted are left out
+	items := make([]MenuItemResponse, 0, len(pairings))
+	together := make([]int, 0, len(pairings))
+	for _, pairing := range pairings {
+		if recommended, ok := byID[pairing.MenuItemID]; ok {
+			items = append(items, *newMenuItemResponse(recommended))
+			together = append(together, pairing.Orders)
+		}
+	}
+	if err := applyPricingRules(ctx, s.rules, items); err != nil {
+		return nil, err
+	}
+	for i := range items {
+		response.Recommendations = append(response.Recommendations, RecommendationResponse{
+			Item:           items[i],
+			OrdersTogether: together[i],
+			Share:          math.Round(float64(together[i])/float64(orders)*100) / 100,
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/logging"
+)
+
+// ReportViewRepository refreshes materialized report views
+type ReportViewRepository interface {
+	Refresh(ctx context.Context, view string) (int, error)
+}
+
+// ViewRefresh reports the refresh of one report view
+type ViewRefresh struct {
+	View       string `json:"view" example:"report_daily_sales"`
+	Rows       int    `json:"rows" example:"365"`
+	DurationMs int64  `json:"duration_ms" example:"120"`
+}
+
+// ViewRefreshResult reports the refresh of all report views
+type ViewRefreshResult struct {
+	RefreshedAt time.Time     `json:"refreshed_at"`
+	Views       []ViewRefresh `json:"views"`
+}
+
+// ReportViewRefresher recomputes the materialized views of pre-aggregated sales
+type ReportViewRefresher struct {
+	repo ReportViewRepository
+}
+
+// NewReportViewRefresher creates a refresher of the report views
+func NewReportViewRefresher(repo ReportViewRepository) *ReportViewRefresher {
+	return &ReportViewRefresher{repo: repo}
+}
+
+// Refresh recomputes every report view, in name order
+func (r *ReportViewRefresher) Refresh(ctx context.Context) (*ViewRefreshResult, error) {
+	ctx, span := tracer.Start(ctx, "ReportViewRefresher.Refresh")
+	defer span.End()
+
+	result := &ViewRefreshResult{RefreshedAt: time.Now().UTC()}
+	for _, view := range slices.Sorted(maps.Keys(models.ReportViews)) {
+		start := time.Now()
+		rows, err := guard(func() (int, error) { return r.repo.Refresh(ctx, view) })
+		if err != nil {
+			return nil, fmt.Errorf("failed to refresh %s: %w", view, err)
+		}
+		result.Views = append(result.Views, ViewRefresh{View: view, Rows: rows, DurationMs: time.Since(start).Milliseconds()})
+	}
+
+	logging.FromContext(ctx).Info("Refreshed report views", slog.Int("views", len(result.Views)))
+	return result, nil
+}
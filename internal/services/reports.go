@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// SalesRepository aggregates orders for sales reports
+type SalesRepository interface {
+	Totals(ctx context.Context, from, to time.Time) (models.SalesRow, error)
+	OrderTotals(ctx context.Context, from, to time.Time) ([]models.OrderTotal, error)
+	ByCategory(ctx context.Context, from, to time.Time) ([]models.SalesRow, error)
+	ByItem(ctx context.Context, from, to time.Time) ([]models.SalesRow, error)
+	TopItems(ctx context.Context, filter models.ItemSalesFilter) ([]models.ItemSales, error)
+}
+
+// ReportService defines the interface for reports
+type ReportService interface {
+	SalesReport(ctx context.Context, opts SalesReportOptions) (*SalesReport, error)
+	TopItems(ctx context.Context, opts TopItemsOptions) (*TopItemsReport, error)
+	AccountingJournal(ctx context.Context, opts AccountingExportOptions) ([]JournalEntry, error)
+}
+
+// Sales report groupings
+const (
+	SalesByDay      = "day"
+	SalesByCategory = "category"
+	SalesByItem     = "item"
+)
+
+// Top items rankings
+const (
+	RankByRevenue  = "revenue"
+	RankByQuantity = "quantity"
+	RankByOrders   = "orders"
+)
+
+// maxReportDays bounds the period of a report
+const maxReportDays = 366
+
+// Number of items of a top items report by default and at most
+const (
+	defaultTopItems = 10
+	maxTopItems     = 100
+)
+
+// ErrInvalidReport is returned when report options are invalid
+var ErrInvalidReport = errors.New("invalid report")
+
+// SalesReportOptions selects the period and grouping of a sales report. The period
+// includes From and excludes To; To defaults to now and From to the start of the
+// business day six days before, so the default report covers the last seven business
+// days.
+type SalesReportOptions struct {
+	From    time.Time
+	To      time.Time
+	GroupBy string
+}
+
+// SalesReport summarizes the sales of a period
+type SalesReport struct {
+	From    time.Time    `json:"from"`
+	To      time.Time    `json:"to"`
+	GroupBy string       `json:"group_by" example:"day"`
+	Totals  SalesFigures `json:"totals"`
+	Groups  []SalesGroup `json:"groups"`
+}
+
+// SalesFigures are the revenue, number of orders and average order value of a group
+type SalesFigures struct {
+	Revenue       decimal.Decimal `json:"revenue" swaggertype:"string" example:"1250.00"`
+	OrderCount    int             `json:"order_count" example:"48"`
+	AverageTicket decimal.Decimal `json:"average_ticket" swaggertype:"string" example:"26.04"`
+}
+
+// SalesGroup is one row of a sales report. Key is the business day (YYYY-MM-DD), the
+// category or the item name.
+type SalesGroup struct {
+	Key        string `json:"key" example:"2024-05-01"`
+	MenuItemID *int   `json:"menu_item_id,omitempty"`
+	ItemsSold  *int   `json:"items_sold,omitempty"`
+	SalesFigures
+}
+
+// TopItemsOptions selects the period, category and ranking of a top items report.
+// The period defaults as for SalesReportOptions. Ascending ranks the weakest items
+// first.
+type TopItemsOptions struct {
+	From      time.Time
+	To        time.Time
+	Category  string
+	RankBy    string
+	Ascending bool
+	Limit     int
+}
+
+// TopItemsReport ranks the menu items by their sales of a period
+type TopItemsReport struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Category string    `json:"category,omitempty" example:"main"`
+	RankBy   string    `json:"rank_by" example:"revenue"`
+	Order    string    `json:"order" example:"desc"`
+	Items    []TopItem `json:"items"`
+}
+
+// TopItem is the sales of one menu item in a top items report
+type TopItem struct {
+	Rank       int    `json:"rank" example:"1"`
+	MenuItemID int    `json:"menu_item_id" example:"1"`
+	Name       string `json:"name" example:"Falafel Wrap"`
+	Category   string `json:"category" example:"main"`
+	ItemsSold  int    `json:"items_sold" example:"120"`
+	SalesFigures
+}
+
+// reportService handles business logic for reports
+type reportService struct {
+	repo     SalesRepository
+	accounts AccountingAccounts
+}
+
+// NewReportService creates a new report service; accounts are the ledger accounts of
+// accounting exports
+func NewReportService(repo SalesRepository, accounts AccountingAccounts) ReportService {
+	return &reportService{repo: repo, accounts: accounts}
+}
+
+// SalesReport computes the revenue, order count and average ticket of a period,
+// grouped by day, category or item. Cancelled orders are not counted.
+func (s *reportService) SalesReport(ctx context.Context, opts SalesReportOptions) (*SalesReport, error) {
+	ctx, span := tracer.Start(ctx, "ReportService.SalesReport")
+	defer span.End()
+
+	if opts.GroupBy != SalesByDay && opts.GroupBy != SalesByCategory && opts.GroupBy != SalesByItem {
+		return nil, fmt.Errorf("%w: group_by must be one of day, category, item", ErrInvalidReport)
+	}
+	var err error
+	if opts.From, opts.To, err = reportPeriod(opts.From, opts.To); err != nil {
+		return nil, err
+	}
+
+	totals, err := guard(func() (models.SalesRow, error) { return s.repo.Totals(ctx, opts.From, opts.To) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute sales totals: %w", err)
+	}
+	report := &SalesReport{
+		From:    localTime(opts.From),
+		To:      localTime(opts.To),
+		GroupBy: opts.GroupBy,
+		Totals:  salesFigures(totals.Revenue, totals.Orders),
+	}
+
+	switch opts.GroupBy {
+	case SalesByDay:
+		orders, err := guard(func() ([]models.OrderTotal, error) { return s.repo.OrderTotals(ctx, opts.From, opts.To) })
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve orders: %w", err)
+		}
+		report.Groups = salesByDay(orders, opts.From, opts.To)
+	default:
+		rows, err := guard(func() ([]models.SalesRow, error) {
+			if opts.GroupBy == SalesByCategory {
+				return s.repo.ByCategory(ctx, opts.From, opts.To)
+			}
+			return s.repo.ByItem(ctx, opts.From, opts.To)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute sales by %s: %w", opts.GroupBy, err)
+		}
+		report.Groups = make([]SalesGroup, len(rows))
+		for i, row := range rows {
+			quantity := row.Quantity
+			report.Groups[i] = SalesGroup{
+				Key:          row.Key,
+				MenuItemID:   row.MenuItemID,
+				ItemsSold:    &quantity,
+				SalesFigures: salesFigures(row.Revenue, row.Orders),
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// TopItems ranks the menu items, optionally of one category, by revenue, quantity
+// sold or number of orders over a period. Items without sales are ranked too, so an
+// ascending report lists the dishes that sell least. Cancelled orders are not counted.
+func (s *reportService) TopItems(ctx context.Context, opts TopItemsOptions) (*TopItemsReport, error) {
+	ctx, span := tracer.Start(ctx, "ReportService.TopItems")
+	defer span.End()
+
+	if opts.RankBy == "" {
+		opts.RankBy = RankByRevenue
+	}
+	if opts.RankBy != RankByRevenue && opts.RankBy != RankByQuantity && opts.RankBy != RankByOrders {
+		return nil, fmt.Errorf("%w: rank_by must be one of revenue, quantity, orders", ErrInvalidReport)
+	}
+	if opts.Category != "" && !ValidCategories[opts.Category] {
+		return nil, fmt.Errorf("%w: unknown category %q", ErrInvalidReport, opts.Category)
+	}
+	if opts.Limit == 0 {
+		opts.Limit = defaultTopItems
+	}
+	if opts.Limit < 0 || opts.Limit > maxTopItems {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidReport, maxTopItems)
+	}
+	var err error
+	if opts.From, opts.To, err = reportPeriod(opts.From, opts.To); err != nil {
+		return nil, err
+	}
+
+	rows, err := guard(func() ([]models.ItemSales, error) {
+		return s.repo.TopItems(ctx, models.ItemSalesFilter{
+			From:      opts.From,
+			To:        opts.To,
+			Category:  opts.Category,
+			SortBy:    opts.RankBy,
+			Ascending: opts.Ascending,
+			Limit:     opts.Limit,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rank menu items: %w", err)
+	}
+
+	report := &TopItemsReport{
+		From:     localTime(opts.From),
+		To:       localTime(opts.To),
+		Category: opts.Category,
+		RankBy:   opts.RankBy,
+		Order:    "desc",
+		Items:    make([]TopItem, len(rows)),
+	}
+	if opts.Ascending {
+		report.Order = "asc"
+	}
+	for i, row := range rows {
+		report.Items[i] = TopItem{
+			Rank:         i + 1,
+			MenuItemID:   row.MenuItemID,
+			Name:         row.Name,
+			Category:     row.Category,
+			ItemsSold:    row.Quantity,
+			SalesFigures: salesFigures(row.Revenue, row.Orders),
+		}
+	}
+
+	return report, nil
+}
+
+// reportPeriod applies the default period of a report and validates it. To defaults
+// to now and from to the start of the business day six days before.
+func reportPeriod(from, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if from.IsZero() {
+		from = StartOfBusinessDay(businessDay(to).AddDate(0, 0, -6))
+	}
+	if !to.After(from) {
+		return from, to, fmt.Errorf("%w: to must be after from", ErrInvalidReport)
+	}
+	if to.Sub(from) > maxReportDays*24*time.Hour {
+		return from, to, fmt.Errorf("%w: the period must not exceed %d days", ErrInvalidReport, maxReportDays)
+	}
+	return from, to, nil
+}
+
+// salesByDay sums orders per business day. Every business day of the period
+// is listed, including those without sales.
+func salesByDay(orders []models.OrderTotal, from, to time.Time) []SalesGroup {
+	type day struct {
+		revenue decimal.Decimal
+		orders  int
+	}
+	days := make(map[string]*day)
+	for _, order := range orders {
+		key := businessDay(order.CreatedAt).Format(time.DateOnly)
+		if days[key] == nil {
+			days[key] = &day{}
+		}
+		days[key].revenue = days[key].revenue.Add(order.Total)
+		days[key].orders++
+	}
+
+	var groups []SalesGroup
+	for d := businessDay(from); d.Before(to); d = StartOfBusinessDay(d.AddDate(0, 0, 1)) {
+		key := d.Format(time.DateOnly)
+		group := SalesGroup{Key: key, SalesFigures: salesFigures(decimal.Zero, 0)}
+		if sales := days[key]; sales != nil {
+			group.SalesFigures = salesFigures(sales.revenue, sales.orders)
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// salesFigures derives the average ticket from revenue and the number of orders
+func salesFigures(revenue decimal.Decimal, orders int) SalesFigures {
+	figures := SalesFigures{Revenue: revenue, OrderCount: orders, AverageTicket: decimal.Zero}
+	if orders > 0 {
+		figures.AverageTicket = revenue.Div(decimal.NewFromInt(int64(orders))).Round(2)
+	}
+	return figures
+}
@@ -0,0 +1,457 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// ReviewRepository abstracts menu item review storage
+type ReviewRepository interface {
+	List(ctx context.Context, filter models.ReviewFilter) ([]models.MenuItemReview, error)
+	FindByID(ctx context.Context, id int) (*models.MenuItemReview, error)
+	Create(ctx context.Context, review *models.MenuItemReview) error
+	Moderate(ctx context.Context, id int, status string, at time.Time) error
+	Delete(ctx context.Context, id int) error
+	RatingCounts(ctx context.Context, menuItemID int) (map[int]int, error)
+	CountFromIP(ctx context.Context, menuItemID int, clientIP string, since time.Time) (int, error)
+	ExistsForOrder(ctx context.Context, orderID string, menuItemID int) (bool, error)
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ ReviewRepository = (*models.ReviewQuery)(nil)
+
+// ReviewService defines business operations on menu item reviews
+type ReviewService interface {
+	CreateReview(ctx context.Context, menuItemID int, req CreateReviewRequest, clientIP string) (*ReviewResponse, error)
+	GetItemReviews(ctx context.Context, menuItemID int, opts ReviewListOptions) (*ItemReviewsResponse, error)
+	ListReviews(ctx context.Context, opts ReviewListOptions) ([]ReviewResponse, error)
+	ModerateReview(ctx context.Context, id int, req ModerateReviewRequest) (*ReviewResponse, error)
+	DeleteReview(ctx context.Context, id int) error
+}
+
+// Review errors
+var (
+	ErrReviewNotFound = errors.New("review not found")
+	ErrInvalidReview  = errors.New("invalid review")
+	// ErrReviewExists is returned when an order's review of a menu item was posted already
+	ErrReviewExists = errors.New("review already exists")
+)
+
+// Review limits
+const (
+	maxReviewCommentLength = 2000
+	maxReviewAuthorLength  = 100
+	defaultReviewPageSize  = 50
+	maxReviewPageSize      = 200
+)
+
+// Flags of the spam and abuse checks a review may trip
+const (
+	// ReviewFlagLink is a comment containing a web address
+	ReviewFlagLink = "link"
+	// ReviewFlagBlockedWord is a comment containing one of REVIEW_BLOCKED_WORDS
+	ReviewFlagBlockedWord = "blocked_word"
+	// ReviewFlagShouting is a comment written mostly in capitals
+	ReviewFlagShouting = "shouting"
+	// ReviewFlagRepeatedCharacters is a comment with a character repeated many times
+	// in a row, e.g. "!!!!!!!!"
+	ReviewFlagRepeatedCharacters = "repeated_characters"
+	// ReviewFlagRepeated is a review of a menu item reviewed from the same client IP
+	// in the day before
+	ReviewFlagRepeated = "repeated"
+)
+
+// ReviewModeration sets the checks flagging reviews for a manager
+type ReviewModeration struct {
+	// Words flagging the comments containing them, matched as whole words in any case
+	BlockedWords []string
+}
+
+// reviewModeration is the restaurant's review moderation
+var reviewModeration ReviewModeration
+
+// SetReviewModeration sets the checks flagging reviews for a manager. It must be
+// called before the server starts.
+func SetReviewModeration(moderation ReviewModeration) {
+	for i, word := range moderation.BlockedWords {
+		moderation.BlockedWords[i] = strings.ToLower(word)
+	}
+	reviewModeration = moderation
+}
+
+// CreateReviewRequest rates a menu item. With an OrderID, the order must include the
+// item, and each of its items can be reviewed once.
+type CreateReviewRequest struct {
+	Rating     int     `json:"rating" example:"5" minimum:"1" maximum:"5"`
+	Comment    *string `json:"comment,omitempty" example:"Best mansaf in town"`
+	AuthorName *string `json:"author_name,omitempty" example:"Omar"`
+	OrderID    *string `json:"order_id,omitempty" example:"0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"`
+}
+
+// ModerateReviewRequest approves or rejects a review
+type ModerateReviewRequest struct {
+	Status string `json:"status" enums:"approved,rejected" example:"approved"`
+}
+
+// ReviewListOptions selects reviews, newest first. Status and Flagged only apply to
+// the moderation list; an item's public reviews are the approved ones.
+type ReviewListOptions struct {
+	MenuItemID int
+	Status     string
+	Flagged    bool
+	Limit      int
+	Offset     int
+}
+
+// ReviewResponse represents the review data returned to clients. Flags and OrderID
+// are left out of a menu item's public reviews.
+type ReviewResponse struct {
+	ID         int     `json:"id" example:"12"`
+	MenuItemID int     `json:"menu_item_id" example:"3"`
+	MenuItem   string  `json:"menu_item,omitempty" example:"Mansaf"`
+	Rating     int     `json:"rating" example:"5"`
+	Comment    *string `json:"comment,omitempty" example:"Best mansaf in town"`
+	AuthorName *string `json:"author_name,omitempty" example:"Omar"`
+	OrderID    *string `json:"order_id,omitempty" example:"0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"`
+	// Whether the review references an order that included the item
+	Verified    bool       `json:"verified"`
+	Status      string     `json:"status" enums:"pending,approved,rejected" example:"approved"`
+	Flags       []string   `json:"flags,omitempty" example:"link"`
+	ModeratedAt *time.Time `json:"moderated_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ItemReviewsResponse is a menu item's approved reviews, newest first, with their
+// average rating and the number of reviews per rating from 1 to 5
+type ItemReviewsResponse struct {
+	MenuItemID    int              `json:"menu_item_id" example:"3"`
+	MenuItem      string           `json:"menu_item" example:"Mansaf"`
+	AverageRating float64          `json:"average_rating" example:"4.6"`
+	ReviewCount   int              `json:"review_count" example:"38"`
+	Ratings       map[int]int      `json:"ratings"`
+	Reviews       []ReviewResponse `json:"reviews"`
+}
+
+// reviewService handles business logic for menu item reviews
+type reviewService struct {
+	repo   ReviewRepository
+	items  MenuItemRepository
+	orders OrderRepository
+}
+
+// NewReviewService creates a new review service. Reviewed items are looked up in
+// items, and the orders reviews reference in orders.
+func NewReviewService(repo ReviewRepository, items MenuItemRepository, orders OrderRepository) ReviewService {
+	return &reviewService{repo: repo, items: items, orders: orders}
+}
+
+// CreateReview validates and stores a review of a menu item posted from clientIP.
+// Reviews tripping a spam or abuse check are flagged and wait for a manager as
+// pending; the others are approved right away.
+func (s *reviewService) CreateReview(ctx context.Context, menuItemID int, req CreateReviewRequest, clientIP string) (*ReviewResponse, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.CreateReview")
+	defer span.End()
+
+	req.Comment = trimmedOrNil(req.Comment)
+	req.AuthorName = trimmedOrNil(req.AuthorName)
+	req.OrderID = trimmedOrNil(req.OrderID)
+	switch {
+	case req.Rating < 1 || req.Rating > 5:
+		return nil, fmt.Errorf("%w: rating must be between 1 and 5", ErrInvalidReview)
+	case req.Comment != nil && len(*req.Comment) > maxReviewCommentLength:
+		return nil, fmt.Errorf("%w: comment must be at most %d characters", ErrInvalidReview, maxReviewCommentLength)
+	case req.AuthorName != nil && len(*req.AuthorName) > maxReviewAuthorLength:
+		return nil, fmt.Errorf("%w: author_name must be at most %d characters", ErrInvalidReview, maxReviewAuthorLength)
+	}
+
+	ctx = database.UsePrimary(ctx)
+	item, err := guard(func() (*models.MenuItem, error) { return s.items.FindByID(ctx, menuItemID) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to find menu item with ID %d: %w", menuItemID, err)
+	}
+	if req.OrderID != nil {
+		if err := s.checkOrder(ctx, *req.OrderID, item); err != nil {
+			return nil, err
+		}
+	}
+
+	review := &models.MenuItemReview{
+		MenuItemID: item.ID,
+		MenuItem:   item,
+		OrderID:    req.OrderID,
+		Rating:     req.Rating,
+		Comment:    req.Comment,
+		AuthorName: req.AuthorName,
+		Status:     models.ReviewStatusApproved,
+	}
+	if req.Comment != nil {
+		review.Flags = commentFlags(*req.Comment)
+	}
+	if clientIP != "" {
+		review.ClientIP = &clientIP
+		repeated, err := guard(func() (int, error) {
+			return s.repo.CountFromIP(ctx, item.ID, clientIP, time.Now().Add(-24*time.Hour))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up reviews of menu item %d: %w", item.ID, err)
+		}
+		if repeated > 0 {
+			review.Flags = append(review.Flags, ReviewFlagRepeated)
+		}
+	}
+	if len(review.Flags) > 0 {
+		review.Status = models.ReviewStatusPending
+	}
+
+	if err := guardExec(func() error { return s.repo.Create(ctx, review) }); err != nil {
+		return nil, fmt.Errorf("failed to create review of menu item %d: %w", item.ID, err)
+	}
+	return newReviewResponse(review), nil
+}
+
+// GetItemReviews returns a menu item's approved reviews with their rating summary
+func (s *reviewService) GetItemReviews(ctx context.Context, menuItemID int, opts ReviewListOptions) (*ItemReviewsResponse, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.GetItemReviews")
+	defer span.End()
+
+	item, err := guard(func() (*models.MenuItem, error) { return s.items.FindByID(ctx, menuItemID) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to find menu item with ID %d: %w", menuItemID, err)
+	}
+	counts, err := guard(func() (map[int]int, error) { return s.repo.RatingCounts(ctx, item.ID) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reviews of menu item %d: %w", item.ID, err)
+	}
+	opts.MenuItemID, opts.Status, opts.Flagged = item.ID, models.ReviewStatusApproved, false
+	reviews, err := s.list(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ItemReviewsResponse{MenuItemID: item.ID, MenuItem: item.Name, Ratings: make(map[int]int, 5), Reviews: reviews}
+	total := 0
+	for rating := 1; rating <= 5; rating++ {
+		response.Ratings[rating] = counts[rating]
+		response.ReviewCount += counts[rating]
+		total += rating * counts[rating]
+	}
+	if response.ReviewCount > 0 {
+		response.AverageRating = math.Round(float64(total)/float64(response.ReviewCount)*10) / 10
+	}
+	for i := range response.Reviews {
+		response.Reviews[i].OrderID = nil
+		response.Reviews[i].Flags = nil
+	}
+	return response, nil
+}
+
+// ListReviews returns reviews for moderation, newest first
+func (s *reviewService) ListReviews(ctx context.Context, opts ReviewListOptions) ([]ReviewResponse, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.ListReviews")
+	defer span.End()
+
+	if opts.Status != "" && !validReviewStatus(opts.Status, models.ReviewStatusPending) {
+		return nil, fmt.Errorf("%w: status must be one of pending, approved, rejected", ErrInvalidReview)
+	}
+	return s.list(ctx, opts)
+}
+
+// ModerateReview approves or rejects a review. Approved reviews are shown on their
+// menu item whatever their flags.
+func (s *reviewService) ModerateReview(ctx context.Context, id int, req ModerateReviewRequest) (*ReviewResponse, error) {
+	ctx, span := tracer.Start(ctx, "ReviewService.ModerateReview")
+	defer span.End()
+
+	if !validReviewStatus(req.Status) {
+		return nil, fmt.Errorf("%w: status must be approved or rejected", ErrInvalidReview)
+	}
+	ctx = database.UsePrimary(ctx)
+	review, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	err = guardExec(func() error { return s.repo.Moderate(ctx, id, req.Status, now) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReviewNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to moderate review %d: %w", id, err)
+	}
+	review.Status = req.Status
+	review.ModeratedAt = &now
+	return newReviewResponse(review), nil
+}
+
+// DeleteReview removes a review
+func (s *reviewService) DeleteReview(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "ReviewService.DeleteReview")
+	defer span.End()
+
+	err := guardExec(func() error { return s.repo.Delete(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrReviewNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete review %d: %w", id, err)
+	}
+	return nil
+}
+
+// list returns a page of the reviews matching opts
+func (s *reviewService) list(ctx context.Context, opts ReviewListOptions) ([]ReviewResponse, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultReviewPageSize
+	}
+	filter := models.ReviewFilter{
+		MenuItemID: opts.MenuItemID,
+		Status:     opts.Status,
+		Flagged:    opts.Flagged,
+		Limit:      min(limit, maxReviewPageSize),
+		Offset:     max(opts.Offset, 0),
+	}
+	reviews, err := guard(func() ([]models.MenuItemReview, error) { return s.repo.List(ctx, filter) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve reviews: %w", err)
+	}
+	responses := make([]ReviewResponse, len(reviews))
+	for i := range reviews {
+		responses[i] = *newReviewResponse(&reviews[i])
+	}
+	return responses, nil
+}
+
+// find loads a review by ID
+func (s *reviewService) find(ctx context.Context, id int) (*models.MenuItemReview, error) {
+	review, err := guard(func() (*models.MenuItemReview, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReviewNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find review %d: %w", id, err)
+	}
+	return review, nil
+}
+
+// checkOrder checks that an order included item and has no review of it yet
+func (s *reviewService) checkOrder(ctx context.Context, orderID string, item *models.MenuItem) error {
+	order, err := guard(func() (*models.Order, error) { return s.orders.FindByID(ctx, orderID) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: order %s does not exist", ErrInvalidReview, orderID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find order %s: %w", orderID, err)
+	}
+	if order.Status == models.OrderStatusCancelled {
+		return fmt.Errorf("%w: order %s was cancelled", ErrInvalidReview, orderID)
+	}
+	included := slices.ContainsFunc(order.Items, func(line models.OrderItem) bool {
+		return line.MenuItemID != nil && *line.MenuItemID == item.ID
+	})
+	if !included {
+		return fmt.Errorf("%w: order %s did not include %s", ErrInvalidReview, orderID, item.Name)
+	}
+
+	exists, err := guard(func() (bool, error) { return s.repo.ExistsForOrder(ctx, order.ID, item.ID) })
+	if err != nil {
+		return fmt.Errorf("failed to look up reviews of order %s: %w", orderID, err)
+	}
+	if exists {
+		return fmt.Errorf("%w: %s was reviewed for order %s already", ErrReviewExists, item.Name, orderID)
+	}
+	return nil
+}
+
+// validReviewStatus reports whether status is approved, rejected or one of also
+func validReviewStatus(status string, also ...string) bool {
+	return status == models.ReviewStatusApproved || status == models.ReviewStatusRejected || slices.Contains(also, status)
+}
+
+// commentFlags returns the spam and abuse checks a review comment trips
+func commentFlags(comment string) []string {
+	var flags []string
+	lower := strings.ToLower(comment)
+	if strings.Contains(lower, "http://") || strings.Contains(lower, "https://") || strings.Contains(lower, "www.") {
+		flags = append(flags, ReviewFlagLink)
+	}
+	words := strings.FieldsFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsNumber(r) })
+	for _, word := range words {
+		if slices.Contains(reviewModeration.BlockedWords, word) {
+			flags = append(flags, ReviewFlagBlockedWord)
+			break
+		}
+	}
+
+	letters, upper, run := 0, 0, 0
+	var last rune
+	repeated := false
+	for _, r := range comment {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+		if r == last && !unicode.IsSpace(r) {
+			run++
+		} else {
+			run = 1
+		}
+		last = r
+		// Six in a row is past any spelling ("Mmmmm" is fine)
+		if run >= 6 {
+			repeated = true
+		}
+	}
+	if letters >= 20 && upper*10 > letters*7 {
+		flags = append(flags, ReviewFlagShouting)
+	}
+	if repeated {
+		flags = append(flags, ReviewFlagRepeatedCharacters)
+	}
+	return flags
+}
+
+// trimmedOrNil trims s, returning nil when it is nil or blank
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// newReviewResponse converts a review, with its menu item, to its response
+func newReviewResponse(review *models.MenuItemReview) *ReviewResponse {
+	response := &ReviewResponse{
+		ID:          review.ID,
+		MenuItemID:  review.MenuItemID,
+		Rating:      review.Rating,
+		Comment:     review.Comment,
+		AuthorName:  review.AuthorName,
+		OrderID:     review.OrderID,
+		Verified:    review.OrderID != nil,
+		Status:      review.Status,
+		Flags:       review.Flags,
+		ModeratedAt: optionalLocalTime(review.ModeratedAt),
+		CreatedAt:   localTime(review.CreatedAt),
+	}
+	if review.MenuItem != nil {
+		response.MenuItem = review.MenuItem.Name
+	}
+	return response
+}
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// Tax modes of the tax_mode setting
+const (
+	// TaxModeExclusive adds tax to menu and order prices
+	TaxModeExclusive = "exclusive"
+	// TaxModeInclusive treats menu and order prices as including tax
+	TaxModeInclusive = "inclusive"
+)
+
+// Names of the stored settings
+const (
+	settingTimezone      = "timezone"
+	settingCurrency      = "currency"
+	settingTaxMode       = "tax_mode"
+	settingServiceCharge = "service_charge"
+	settingReceiptFooter = "receipt_footer"
+)
+
+// ErrInvalidSettings is returned for settings failing validation
+var ErrInvalidSettings = errors.New("invalid settings")
+
+// currencyPattern is the format of ISO 4217 currency codes
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Settings are the business settings of a restaurant. Settings that were never
+// changed through the API keep their defaults from the environment.
+type Settings struct {
+	// IANA timezone timestamps are returned in, and read in when they have no offset.
+	// The default restaurant's applies to every restaurant.
+	Timezone string `json:"timezone" example:"Asia/Amman"`
+	// ISO 4217 code of the currency prices are in
+	Currency string `json:"currency" example:"JOD"`
+	// Whether tax is added to prices (exclusive) or included in them (inclusive)
+	TaxMode string `json:"tax_mode" enums:"exclusive,inclusive" example:"exclusive"`
+	// Service charge percentage, for point-of-sale clients adding it to dine-in bills
+	ServiceCharge decimal.Decimal `json:"service_charge" swaggertype:"string" example:"10"`
+	// Text printed at the bottom of receipts
+	ReceiptFooter string `json:"receipt_footer" example:"Thank you for dining with us!"`
+
+	location *time.Location
+}
+
+// Location returns the restaurant's timezone
+func (s Settings) Location() *time.Location {
+	if s.location == nil {
+		return time.UTC
+	}
+	return s.location
+}
+
+// TaxIncluded reports whether prices include tax
+func (s Settings) TaxIncluded() bool {
+	return s.TaxMode == TaxModeInclusive
+}
+
+// SettingsPatch changes the settings it sets and leaves the others
+type SettingsPatch struct {
+	// Shared by every restaurant, so only the default restaurant may change it
+	Timezone      *string          `json:"timezone,omitempty" example:"Asia/Amman"`
+	Currency      *string          `json:"currency,omitempty" example:"JOD"`
+	TaxMode       *string          `json:"tax_mode,omitempty" enums:"exclusive,inclusive" example:"inclusive"`
+	ServiceCharge *decimal.Decimal `json:"service_charge,omitempty" swaggertype:"string" example:"12.5"`
+	ReceiptFooter *string          `json:"receipt_footer,omitempty" example:"Thank you for dining with us!"`
+	// Not a setting: every restaurant's business day starts at BUSINESS_DAY_START, and
+	// setting it is rejected rather than ignored
+	BusinessDayStart *string `json:"business_day_start,omitempty" swaggerignore:"true"`
+}
+
+// defaultSettings are the settings from the environment, used until they are changed
+var defaultSettings Settings
+
+// settings are the current settings of the restaurants that stored some, by
+// restaurant ID
+var settings atomic.Pointer[map[int]Settings]
+
+// SetDefaultSettings sets the settings from the environment and applies them until the
+// stored ones are loaded. It must be called before the server starts.
+func SetDefaultSettings(defaults Settings) {
+	loc, err := time.LoadLocation(defaults.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	defaults.location = loc
+	defaultSettings = defaults
+	applySettings(map[int]Settings{})
+}
+
+// CurrentSettings returns the current settings of the restaurant of ctx
+func CurrentSettings(ctx context.Context) Settings {
+	return RestaurantSettings(database.RestaurantID(ctx))
+}
+
+// RestaurantSettings returns the current settings of a restaurant, with the default
+// restaurant's timezone
+func RestaurantSettings(restaurant int) Settings {
+	current := *settings.Load()
+	restaurantSettings, ok := current[restaurant]
+	if !ok {
+		restaurantSettings = defaultSettings
+	}
+	zone, ok := current[database.DefaultRestaurantID]
+	if !ok {
+		zone = defaultSettings
+	}
+	restaurantSettings.Timezone, restaurantSettings.location = zone.Timezone, zone.location
+	return restaurantSettings
+}
+
+// applySettings makes the settings of each restaurant the current ones. The default
+// restaurant's timezone is the one timestamps are returned in.
+func applySettings(current map[int]Settings) {
+	settings.Store(&current)
+	SetTimezone(RestaurantSettings(database.DefaultRestaurantID).Location())
+}
+
+// SettingRepository abstracts settings storage. All returns the settings of every
+// restaurant, while Save stores those of the restaurant of ctx.
+type SettingRepository interface {
+	All(ctx context.Context) ([]models.Setting, error)
+	Save(ctx context.Context, settings []models.Setting) error
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ SettingRepository = (*models.SettingQuery)(nil)
+
+// SettingsService defines business operations on the settings of the restaurant of a
+// request
+type SettingsService interface {
+	GetSettings(ctx context.Context) (*Settings, error)
+	UpdateSettings(ctx context.Context, patch SettingsPatch) (*Settings, error)
+}
+
+// settingsService handles business logic for settings
+type settingsService struct {
+	repo SettingRepository
+}
+
+// NewSettingsService creates a new settings service
+func NewSettingsService(repo SettingRepository) SettingsService {
+	return &settingsService{repo: repo}
+}
+
+// GetSettings returns the stored settings over the defaults, and applies them so
+// changes made through another instance take effect here too
+func (s *settingsService) GetSettings(ctx context.Context) (*Settings, error) {
+	ctx, span := tracer.Start(ctx, "SettingsService.GetSettings")
+	defer span.End()
+
+	current, err := LoadSettings(ctx, s.repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve settings: %w", err)
+	}
+	return &current, nil
+}
+
+// UpdateSettings validates and stores the settings set in patch, and applies them.
+// Orders keep the tax mode they were placed with.
+func (s *settingsService) UpdateSettings(ctx context.Context, patch SettingsPatch) (*Settings, error) {
+	ctx, span := tracer.Start(ctx, "SettingsService.UpdateSettings")
+	defer span.End()
+
+	changed, err := patch.encode(database.RestaurantID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Save(ctx, changed) }); err != nil {
+		return nil, fmt.Errorf("failed to update settings: %w", err)
+	}
+
+	current, err := LoadSettings(ctx, s.repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve settings: %w", err)
+	}
+	return &current, nil
+}
+
+// encode validates the patch of a restaurant and returns the settings it changes,
+// encoded for storage
+func (p SettingsPatch) encode(restaurant int) ([]models.Setting, error) {
+	var changed []models.Setting
+	if p.BusinessDayStart != nil {
+		return nil, fmt.Errorf("%w: business_day_start is set for every restaurant by BUSINESS_DAY_START and can't be changed here", ErrInvalidSettings)
+	}
+	if p.Timezone != nil {
+		if restaurant != database.DefaultRestaurantID {
+			return nil, fmt.Errorf("%w: timezone can only be changed for the default restaurant, whose timezone every restaurant uses", ErrInvalidSettings)
+		}
+		name := strings.TrimSpace(*p.Timezone)
+		if _, err := time.LoadLocation(name); err != nil || name == "" {
+			return nil, fmt.Errorf("%w: timezone %q is not an IANA timezone such as Asia/Amman", ErrInvalidSettings, *p.Timezone)
+		}
+		changed = append(changed, models.Setting{Name: settingTimezone, Value: name})
+	}
+	if p.Currency != nil {
+		code := strings.ToUpper(strings.TrimSpace(*p.Currency))
+		if !currencyPattern.MatchString(code) {
+			return nil, fmt.Errorf("%w: currency must be a 3-letter ISO 4217 code such as JOD", ErrInvalidSettings)
+		}
+		changed = append(changed, models.Setting{Name: settingCurrency, Value: code})
+	}
+	if p.TaxMode != nil {
+		if *p.TaxMode != TaxModeExclusive && *p.TaxMode != TaxModeInclusive {
+			return nil, fmt.Errorf("%w: tax_mode must be %s or %s", ErrInvalidSettings, TaxModeExclusive, TaxModeInclusive)
+		}
+		changed = append(changed, models.Setting{Name: settingTaxMode, Value: *p.TaxMode})
+	}
+	if p.ServiceCharge != nil {
+		charge := *p.ServiceCharge
+		if charge.IsNegative() || charge.GreaterThan(decimal.NewFromInt(100)) || charge.Exponent() < -2 {
+			return nil, fmt.Errorf("%w: service_charge must be a percentage from 0 to 100 with at most 2 decimals", ErrInvalidSettings)
+		}
+		changed = append(changed, models.Setting{Name: settingServiceCharge, Value: charge.String()})
+	}
+	if p.ReceiptFooter != nil {
+		footer := strings.TrimSpace(*p.ReceiptFooter)
+		if len(footer) > 500 {
+			return nil, fmt.Errorf("%w: receipt_footer must be at most 500 characters", ErrInvalidSettings)
+		}
+		changed = append(changed, models.Setting{Name: settingReceiptFooter, Value: footer})
+	}
+	if len(changed) == 0 {
+		return nil, fmt.Errorf("%w: no settings to change", ErrInvalidSettings)
+	}
+	return changed, nil
+}
+
+// LoadSettings reads the stored settings of every restaurant, applies them over the
+// defaults and returns the result for the restaurant of ctx. Stored values this
+// instance can't use, such as a timezone missing from its timezone database, keep
+// their default.
+func LoadSettings(ctx context.Context, repo SettingRepository) (Settings, error) {
+	stored, err := guard(func() ([]models.Setting, error) { return repo.All(ctx) })
+	if err != nil {
+		return Settings{}, err
+	}
+
+	current := make(map[int]Settings)
+	for _, setting := range stored {
+		restaurant, ok := current[setting.RestaurantID]
+		if !ok {
+			restaurant = defaultSettings
+		}
+		if err := restaurant.set(setting.Name, setting.Value); err != nil {
+			slog.Warn("Ignoring stored setting",
+				slog.Int("restaurant_id", setting.RestaurantID),
+				slog.String("setting", setting.Name),
+				slog.String("error", err.Error()))
+		}
+		current[setting.RestaurantID] = restaurant
+	}
+	applySettings(current)
+	return CurrentSettings(ctx), nil
+}
+
+// set decodes a stored setting into s
+func (s *Settings) set(name, value string) error {
+	switch name {
+	case settingTimezone:
+		loc, err := time.LoadLocation(value)
+		if err != nil {
+			return err
+		}
+		s.Timezone, s.location = value, loc
+	case settingCurrency:
+		s.Currency = value
+	case settingTaxMode:
+		if value != TaxModeExclusive && value != TaxModeInclusive {
+			return fmt.Errorf("unknown tax mode %q", value)
+		}
+		s.TaxMode = value
+	case settingServiceCharge:
+		charge, err := decimal.NewFromString(value)
+		if err != nil {
+			return err
+		}
+		s.ServiceCharge = charge
+	case settingReceiptFooter:
+		s.ReceiptFooter = value
+	}
+	return nil
+}
+
+// WatchSettings reloads the stored settings every interval until ctx is done, so
+// changes made through another instance take effect on this one
+func WatchSettings(ctx context.Context, repo SettingRepository, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := LoadSettings(ctx, repo); err != nil {
+					slog.Warn("Failed to reload settings", slog.String("error", err.Error()))
+				}
+			}
+		}
+	}()
+}
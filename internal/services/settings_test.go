@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// memorySettings stores settings in memory, keyed by restaurant
+type memorySettings struct {
+	stored []models.Setting
+}
+
+func (m *memorySettings) All(context.Context) ([]models.Setting, error) {
+	return m.stored, nil
+}
+
+func (m *memorySettings) Save(ctx context.Context, changed []models.Setting) error {
+	for _, setting := range changed {
+		setting.RestaurantID = database.RestaurantID(ctx)
+		m.stored = append(m.stored, setting)
+	}
+	return nil
+}
+
+func TestTimezoneOfDefaultRestaurantAppliesToAll(t *testing.T) {
+	savedDefaults, savedSettings, savedZone := defaultSettings, settings.Load(), zone.Load()
+	t.Cleanup(func() {
+		defaultSettings = savedDefaults
+		settings.Store(savedSettings)
+		zone.Store(savedZone)
+	})
+	SetDefaultSettings(Settings{Timezone: "UTC", Currency: "JOD", TaxMode: TaxModeExclusive})
+
+	// A timezone stored for another restaurant before it was rejected is ignored
+	repo := &memorySettings{stored: []models.Setting{{RestaurantID: 2, Name: settingTimezone, Value: "America/New_York"}}}
+	service := NewSettingsService(repo)
+	first := database.WithRestaurant(context.Background(), database.DefaultRestaurantID)
+	second := database.WithRestaurant(context.Background(), 2)
+
+	amman := "Asia/Amman"
+	if _, err := service.UpdateSettings(second, SettingsPatch{Timezone: &amman}); !errors.Is(err, ErrInvalidSettings) {
+		t.Fatalf("UpdateSettings() for restaurant 2 = %v, want %v", err, ErrInvalidSettings)
+	}
+	if _, err := service.UpdateSettings(first, SettingsPatch{Timezone: &amman}); err != nil {
+		t.Fatalf("UpdateSettings() for the default restaurant: %v", err)
+	}
+
+	for _, ctx := range []context.Context{first, second} {
+		current, err := service.GetSettings(ctx)
+		if err != nil {
+			t.Fatalf("GetSettings(): %v", err)
+		}
+		if current.Timezone != amman || current.Location().String() != amman {
+			t.Fatalf("restaurant %d timezone = %s (%s), want %s",
+				database.RestaurantID(ctx), current.Timezone, current.Location(), amman)
+		}
+	}
+	if loc := timezone().String(); loc != amman {
+		t.Fatalf("timestamps in %s, want %s", loc, amman)
+	}
+}
+
+func TestBusinessDayStartIsRejected(t *testing.T) {
+	start := "04:00"
+	_, err := SettingsPatch{BusinessDayStart: &start}.encode(database.DefaultRestaurantID)
+	if !errors.Is(err, ErrInvalidSettings) {
+		t.Fatalf("encode() = %v, want %v", err, ErrInvalidSettings)
+	}
+}
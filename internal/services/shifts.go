@@ -0,0 +1,339 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// Shift errors
+var (
+	ErrShiftNotFound = errors.New("shift not found")
+	ErrInvalidShift  = errors.New("invalid shift")
+	// ErrShiftOverlap is returned when a staff member would work two shifts at once
+	ErrShiftOverlap = errors.New("shift overlaps another shift")
+)
+
+// Shift limits
+const (
+	// maxShiftLength is the longest shift, split shifts being scheduled as two
+	maxShiftLength = 16 * time.Hour
+	// maxShiftListDays is the longest period shifts are listed for at once
+	maxShiftListDays = 62
+)
+
+// ShiftListOptions selects the shifts starting from From until To, by default those
+// of the seven business days starting today, of one staff member when StaffID is set
+type ShiftListOptions struct {
+	From    time.Time
+	To      time.Time
+	StaffID *int
+}
+
+// ShiftRequest creates or replaces a shift
+type ShiftRequest struct {
+	StaffID int `json:"staff_id" example:"2"`
+	// Defaults to the staff member's role
+	Role     string    `json:"role,omitempty" example:"server"`
+	StartsAt time.Time `json:"starts_at" example:"2026-10-16T17:00:00+03:00"`
+	EndsAt   time.Time `json:"ends_at" example:"2026-10-16T23:30:00+03:00"`
+	Notes    *string   `json:"notes,omitempty" example:"Covers the terrace"`
+}
+
+// ShiftResponse represents the shift data returned to clients
+type ShiftResponse struct {
+	ID       int       `json:"id" example:"14"`
+	StaffID  int       `json:"staff_id" example:"2"`
+	Staff    string    `json:"staff" example:"Lina"`
+	Role     string    `json:"role" example:"server"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	// Length of the shift, to the hundredth of an hour
+	Hours     float64   `json:"hours" example:"6.5"`
+	Notes     *string   `json:"notes,omitempty" example:"Covers the terrace"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WeeklyScheduleResponse is the staff schedule of a week, Monday to Sunday in
+// business days
+type WeeklyScheduleResponse struct {
+	WeekStart string                  `json:"week_start" example:"2026-10-12"`
+	StartsAt  time.Time               `json:"starts_at"`
+	EndsAt    time.Time               `json:"ends_at"`
+	Days      []ScheduleDayResponse   `json:"days"`
+	Staff     []ScheduleStaffResponse `json:"staff"`
+	// Scheduled hours of the whole week
+	TotalHours float64 `json:"total_hours" example:"212.5"`
+}
+
+// ScheduleDayResponse is the shifts starting in a business day of the schedule
+type ScheduleDayResponse struct {
+	Date    string          `json:"date" example:"2026-10-12"`
+	Weekday string          `json:"weekday" example:"mon"`
+	Shifts  []ShiftResponse `json:"shifts"`
+	Hours   float64         `json:"hours" example:"31"`
+}
+
+// ScheduleStaffResponse is how much a staff member is scheduled in the week. Active
+// staff without shifts are listed too.
+type ScheduleStaffResponse struct {
+	StaffID int     `json:"staff_id" example:"2"`
+	Name    string  `json:"name" example:"Lina"`
+	Role    string  `json:"role" example:"server"`
+	Shifts  int     `json:"shifts" example:"5"`
+	Hours   float64 `json:"hours" example:"38.5"`
+}
+
+// ListShifts returns the shifts starting in a period, by start time
+func (s *staffService) ListShifts(ctx context.Context, opts ShiftListOptions) ([]ShiftResponse, error) {
+	ctx, span := tracer.Start(ctx, "StaffService.ListShifts")
+	defer span.End()
+
+	if opts.From.IsZero() {
+		opts.From = businessDay(time.Now())
+	}
+	if opts.To.IsZero() {
+		opts.To = StartOfBusinessDay(opts.From.AddDate(0, 0, 7))
+	}
+	switch {
+	case !opts.To.After(opts.From):
+		return nil, fmt.Errorf("%w: to must be after from", ErrInvalidShift)
+	case opts.To.Sub(opts.From) > maxShiftListDays*24*time.Hour:
+		return nil, fmt.Errorf("%w: shifts can be listed for at most %d days at once", ErrInvalidShift, maxShiftListDays)
+	}
+
+	shifts, err := guard(func() ([]models.Shift, error) { return s.repo.Shifts(ctx, opts.From, opts.To, opts.StaffID) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve shifts: %w", err)
+	}
+	responses := make([]ShiftResponse, len(shifts))
+	for i := range shifts {
+		responses[i] = *newShiftResponse(&shifts[i])
+	}
+	return responses, nil
+}
+
+// CreateShift validates and schedules a new shift
+func (s *staffService) CreateShift(ctx context.Context, req ShiftRequest) (*ShiftResponse, error) {
+	ctx, span := tracer.Start(ctx, "StaffService.CreateShift")
+	defer span.End()
+
+	ctx = database.UsePrimary(ctx)
+	shift := &models.Shift{}
+	if err := s.applyShift(ctx, shift, req); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.CreateShift(ctx, shift) }); err != nil {
+		return nil, fmt.Errorf("failed to create shift: %w", err)
+	}
+	return newShiftResponse(shift), nil
+}
+
+// UpdateShift replaces a shift, which may move it to another staff member
+func (s *staffService) UpdateShift(ctx context.Context, id int, req ShiftRequest) (*ShiftResponse, error) {
+	ctx, span := tracer.Start(ctx, "StaffService.UpdateShift")
+	defer span.End()
+
+	ctx = database.UsePrimary(ctx)
+	shift, err := s.findShift(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyShift(ctx, shift, req); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.UpdateShift(ctx, shift) }); err != nil {
+		return nil, fmt.Errorf("failed to update shift %d: %w", id, err)
+	}
+	return newShiftResponse(shift), nil
+}
+
+// DeleteShift removes a shift
+func (s *staffService) DeleteShift(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "StaffService.DeleteShift")
+	defer span.End()
+
+	if _, err := s.findShift(ctx, id); err != nil {
+		return err
+	}
+	if err := guardExec(func() error { return s.repo.DeleteShift(ctx, id) }); err != nil {
+		return fmt.Errorf("failed to delete shift %d: %w", id, err)
+	}
+	return nil
+}
+
+// WeeklySchedule returns the schedule of the week date falls in: its shifts by the
+// business day they start in, and the hours of each staff member
+func (s *staffService) WeeklySchedule(ctx context.Context, date time.Time) (*WeeklyScheduleResponse, error) {
+	ctx, span := tracer.Start(ctx, "StaffService.WeeklySchedule")
+	defer span.End()
+
+	day := businessDay(date)
+	sinceMonday := (int(day.Weekday()) + 6) % 7
+	start := StartOfBusinessDay(day.AddDate(0, 0, -sinceMonday))
+	end := StartOfBusinessDay(start.AddDate(0, 0, 7))
+
+	shifts, err := guard(func() ([]models.Shift, error) { return s.repo.Shifts(ctx, start, end, nil) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve shifts: %w", err)
+	}
+	staff, err := guard(func() ([]models.StaffMember, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve staff: %w", err)
+	}
+
+	schedule := &WeeklyScheduleResponse{
+		WeekStart: start.Format(time.DateOnly),
+		StartsAt:  localTime(start),
+		EndsAt:    localTime(end),
+		Days:      make([]ScheduleDayResponse, 7),
+		Staff:     []ScheduleStaffResponse{},
+	}
+	for i := range schedule.Days {
+		dayStart := StartOfBusinessDay(start.AddDate(0, 0, i))
+		schedule.Days[i] = ScheduleDayResponse{
+			Date:    dayStart.Format(time.DateOnly),
+			Weekday: weekdays[dayStart.Weekday()],
+			Shifts:  []ShiftResponse{},
+		}
+	}
+
+	totals := make(map[int]*ScheduleStaffResponse)
+	for i := range shifts {
+		shift := newShiftResponse(&shifts[i])
+		dayStart := businessDay(shifts[i].StartsAt)
+		index := int(dayStart.Sub(start).Round(24*time.Hour) / (24 * time.Hour))
+		if index < 0 || index >= len(schedule.Days) {
+			continue
+		}
+		schedule.Days[index].Shifts = append(schedule.Days[index].Shifts, *shift)
+		schedule.Days[index].Hours += shift.Hours
+		schedule.TotalHours += shift.Hours
+		total, ok := totals[shift.StaffID]
+		if !ok {
+			total = &ScheduleStaffResponse{StaffID: shift.StaffID, Name: shift.Staff}
+			totals[shift.StaffID] = total
+		}
+		total.Shifts++
+		total.Hours += shift.Hours
+	}
+	// Staff are listed by name, as the repository returns them
+	for _, member := range staff {
+		total, ok := totals[member.ID]
+		if !ok && !member.IsActive {
+			continue
+		}
+		if !ok {
+			total = &ScheduleStaffResponse{StaffID: member.ID}
+		}
+		total.Name, total.Role = member.Name, member.Role
+		total.Hours = roundHours(total.Hours)
+		schedule.Staff = append(schedule.Staff, *total)
+	}
+	for i := range schedule.Days {
+		schedule.Days[i].Hours = roundHours(schedule.Days[i].Hours)
+	}
+	schedule.TotalHours = roundHours(schedule.TotalHours)
+	return schedule, nil
+}
+
+// findShift loads a shift by ID
+func (s *staffService) findShift(ctx context.Context, id int) (*models.Shift, error) {
+	shift, err := guard(func() (*models.Shift, error) { return s.repo.FindShift(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrShiftNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find shift %d: %w", id, err)
+	}
+	return shift, nil
+}
+
+// applyShift validates req and copies it onto shift. The staff member must be active
+// and free for the whole shift.
+func (s *staffService) applyShift(ctx context.Context, shift *models.Shift, req ShiftRequest) error {
+	req.Role = strings.ToLower(strings.TrimSpace(req.Role))
+	if req.Notes != nil {
+		notes := strings.TrimSpace(*req.Notes)
+		req.Notes = &notes
+		if notes == "" {
+			req.Notes = nil
+		}
+	}
+	switch {
+	case req.StartsAt.IsZero() || req.EndsAt.IsZero():
+		return fmt.Errorf("%w: starts_at and ends_at are required", ErrInvalidShift)
+	case !req.EndsAt.After(req.StartsAt):
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidShift)
+	case req.EndsAt.Sub(req.StartsAt) > maxShiftLength:
+		return fmt.Errorf("%w: a shift must last at most %d hours", ErrInvalidShift, int(maxShiftLength.Hours()))
+	case len(req.Role) > maxStaffRoleLength:
+		return fmt.Errorf("%w: role must be at most %d characters", ErrInvalidShift, maxStaffRoleLength)
+	}
+
+	member, err := s.find(ctx, req.StaffID)
+	if errors.Is(err, ErrStaffNotFound) {
+		return fmt.Errorf("%w: staff member %d does not exist", ErrInvalidShift, req.StaffID)
+	}
+	if err != nil {
+		return err
+	}
+	if !member.IsActive {
+		return fmt.Errorf("%w: %s is inactive", ErrInvalidShift, member.Name)
+	}
+
+	overlapping, err := guard(func() ([]models.Shift, error) {
+		return s.repo.OverlappingShifts(ctx, member.ID, req.StartsAt, req.EndsAt, shift.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to look up the shifts of %s: %w", member.Name, err)
+	}
+	if len(overlapping) > 0 {
+		other := overlapping[0]
+		return fmt.Errorf("%w: %s works from %s to %s", ErrShiftOverlap, member.Name,
+			localTime(other.StartsAt).Format(time.RFC3339), localTime(other.EndsAt).Format(time.RFC3339))
+	}
+
+	if req.Role == "" {
+		req.Role = member.Role
+	}
+	shift.StaffID = member.ID
+	shift.Staff = member
+	shift.Role = req.Role
+	shift.StartsAt = req.StartsAt
+	shift.EndsAt = req.EndsAt
+	shift.Notes = req.Notes
+	return nil
+}
+
+// roundHours rounds a number of hours to the hundredth
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+// newShiftResponse converts a shift, with its staff member, to its response
+func newShiftResponse(shift *models.Shift) *ShiftResponse {
+	response := &ShiftResponse{
+		ID:        shift.ID,
+		StaffID:   shift.StaffID,
+		Role:      shift.Role,
+		StartsAt:  localTime(shift.StartsAt),
+		EndsAt:    localTime(shift.EndsAt),
+		Hours:     roundHours(shift.EndsAt.Sub(shift.StartsAt).Hours()),
+		Notes:     shift.Notes,
+		CreatedAt: localTime(shift.CreatedAt),
+		UpdatedAt: localTime(shift.UpdatedAt),
+	}
+	if shift.Staff != nil {
+		response.Staff = shift.Staff.Name
+	}
+	return response
+}
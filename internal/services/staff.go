@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// StaffRepository abstracts staff, shift and time punch storage
+type StaffRepository interface {
+	List(ctx context.Context) ([]models.StaffMember, error)
+	FindByID(ctx context.Context, id int) (*models.StaffMember, error)
+	Create(ctx context.Context, member *models.StaffMember) error
+	Update(ctx context.Context, member *models.StaffMember) error
+	Delete(ctx context.Context, id int) error
+	Shifts(ctx context.Context, from, to time.Time, staffID *int) ([]models.Shift, error)
+	FindShift(ctx context.Context, id int) (*models.Shift, error)
+	OverlappingShifts(ctx context.Context, staffID int, start, end time.Time, exceptID int) ([]models.Shift, error)
+	CreateShift(ctx context.Context, shift *models.Shift) error
+	UpdateShift(ctx context.Context, shift *models.Shift) error
+	DeleteShift(ctx context.Context, id int) error
+	OpenPunch(ctx context.Context, staffID int) (*models.TimePunch, error)
+	CreatePunch(ctx context.Context, punch *models.TimePunch) error
+	ClockOut(ctx context.Context, id int, at time.Time) error
+	Punches(ctx context.Context, from, to time.Time, staffID *int) ([]models.TimePunch, error)
+	HasPunches(ctx context.Context, staffID int) (bool, error)
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ StaffRepository = (*models.StaffQuery)(nil)
+
+// StaffService defines business operations on staff, their shifts and the time clock
+type StaffService interface {
+	ListStaff(ctx context.Context) ([]StaffResponse, error)
+	CreateStaff(ctx context.Context, req StaffRequest) (*StaffResponse, error)
+	UpdateStaff(ctx context.Context, id int, req StaffRequest) (*StaffResponse, error)
+	DeleteStaff(ctx context.Context, id int) error
+	ListShifts(ctx context.Context, opts ShiftListOptions) ([]ShiftResponse, error)
+	CreateShift(ctx context.Context, req ShiftRequest) (*ShiftResponse, error)
+	UpdateShift(ctx context.Context, id int, req ShiftRequest) (*ShiftResponse, error)
+	DeleteShift(ctx context.Context, id int) error
+	WeeklySchedule(ctx context.Context, date time.Time) (*WeeklyScheduleResponse, error)
+	ClockIn(ctx context.Context, staffID int, req ClockInRequest) (*TimePunchResponse, error)
+	ClockOut(ctx context.Context, staffID int) (*TimePunchResponse, error)
+	Timesheet(ctx context.Context, opts TimesheetOptions) (*TimesheetResponse, error)
+	PayrollExport(ctx context.Context, opts PayrollExportOptions) (*PayrollExport, error)
+}
+
+// Staff errors
+var (
+	ErrStaffNotFound = errors.New("staff member not found")
+	ErrInvalidStaff  = errors.New("invalid staff member")
+)
+
+// Staff limits, matching the staff table's columns
+const (
+	maxStaffNameLength  = 100
+	maxStaffRoleLength  = 50
+	maxStaffPhoneLength = 50
+)
+
+// StaffRequest creates or replaces a staff member
+type StaffRequest struct {
+	Name string `json:"name" example:"Lina"`
+	// Role they are scheduled in unless a shift says otherwise, e.g. server or cook
+	Role  string  `json:"role" example:"server"`
+	Phone *string `json:"phone,omitempty" example:"+962791234567"`
+	// Defaults to true; inactive staff aren't scheduled
+	IsActive *bool `json:"is_active,omitempty"`
+}
+
+// StaffResponse represents the staff member data returned to clients
+type StaffResponse struct {
+	ID        int       `json:"id" example:"2"`
+	Name      string    `json:"name" example:"Lina"`
+	Role      string    `json:"role" example:"server"`
+	Phone     *string   `json:"phone,omitempty" example:"+962791234567"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// staffService handles business logic for staff and their shifts
+type staffService struct {
+	repo StaffRepository
+}
+
+// NewStaffService creates a new staff service
+func NewStaffService(repo StaffRepository) StaffService {
+	return &staffService{repo: repo}
+}
+
+// ListStaff returns all staff by name
+func (s *staffService) ListStaff(ctx context.Context) ([]StaffResponse, error) {
+	ctx, span := tracer.Start(ctx, "StaffService.ListStaff")
+	defer span.End()
+
+	staff, err := guard(func() ([]models.StaffMember, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve staff: %w", err)
+	}
+	responses := make([]StaffResponse, len(staff))
+	for i := range staff {
+		responses[i] = *newStaffResponse(&staff[i])
+	}
+	return responses, nil
+}
+
+// CreateStaff validates and stores a new staff member
+func (s *staffService) CreateStaff(ctx context.Context, req StaffRequest) (*StaffResponse, error) {
+	ctx, span := tracer.Start(ctx, "StaffService.CreateStaff")
+	defer span.End()
+
+	member := &models.StaffMember{}
+	if err := applyStaff(member, req); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Create(ctx, member) }); err != nil {
+		return nil, fmt.Errorf("failed to create staff member: %w", err)
+	}
+	return newStaffResponse(member), nil
+}
+
+// UpdateStaff replaces a staff member. Their shifts keep the role they were
+// scheduled in.
+func (s *staffService) UpdateStaff(ctx context.Context, id int, req StaffRequest) (*StaffResponse, error) {
+	ctx, span := tracer.Start(ctx, "StaffService.UpdateStaff")
+	defer span.End()
+
+	member, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyStaff(member, req); err != nil {
+		return nil, err
+	}
+	if err := guardExec(func() error { return s.repo.Update(ctx, member) }); err != nil {
+		return nil, fmt.Errorf("failed to update staff member %d: %w", id, err)
+	}
+	return newStaffResponse(member), nil
+}
+
+// DeleteStaff removes a staff member and their shifts. Staff who clocked in can't be
+// deleted; staff who left are better made inactive, which keeps their past shifts.
+func (s *staffService) DeleteStaff(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "StaffService.DeleteStaff")
+	defer span.End()
+
+	member, err := s.find(ctx, id)
+	if err != nil {
+		return err
+	}
+	punched, err := guard(func() (bool, error) { return s.repo.HasPunches(ctx, id) })
+	if err != nil {
+		return fmt.Errorf("failed to look up the punches of %s: %w", member.Name, err)
+	}
+	if punched {
+		return fmt.Errorf("%w: make %s inactive instead", ErrStaffHasPunches, member.Name)
+	}
+	if err := guardExec(func() error { return s.repo.Delete(ctx, id) }); err != nil {
+		return fmt.Errorf("failed to delete staff member %d: %w", id, err)
+	}
+	return nil
+}
+
+// find loads a staff member by ID
+func (s *staffService) find(ctx context.Context, id int) (*models.StaffMember, error) {
+	member, err := guard(func() (*models.StaffMember, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrStaffNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find staff member %d: %w", id, err)
+	}
+	return member, nil
+}
+
+// applyStaff validates req and copies it onto member
+func applyStaff(member *models.StaffMember, req StaffRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Role = strings.ToLower(strings.TrimSpace(req.Role))
+	if req.Phone != nil {
+		phone := strings.TrimSpace(*req.Phone)
+		req.Phone = &phone
+		if phone == "" {
+			req.Phone = nil
+		}
+	}
+	switch {
+	case req.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidStaff)
+	case len(req.Name) > maxStaffNameLength:
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidStaff, maxStaffNameLength)
+	case req.Role == "":
+		return fmt.Errorf("%w: role is required", ErrInvalidStaff)
+	case len(req.Role) > maxStaffRoleLength:
+		return fmt.Errorf("%w: role must be at most %d characters", ErrInvalidStaff, maxStaffRoleLength)
+	case req.Phone != nil && len(*req.Phone) > maxStaffPhoneLength:
+		return fmt.Errorf("%w: phone must be at most %d characters", ErrInvalidStaff, maxStaffPhoneLength)
+	}
+	member.Name = req.Name
+	member.Role = req.Role
+	member.Phone = req.Phone
+	member.IsActive = req.IsActive == nil || *req.IsActive
+	return nil
+}
+
+// newStaffResponse converts a staff member to its response
+func newStaffResponse(member *models.StaffMember) *StaffResponse {
+	return &StaffResponse{
+		ID:        member.ID,
+		Name:      member.Name,
+		Role:      member.Role,
+		Phone:     member.Phone,
+		IsActive:  member.IsActive,
+		CreatedAt: localTime(member.CreatedAt),
+		UpdatedAt: localTime(member.UpdatedAt),
+	}
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/realtime"
+)
+
+// KitchenStations sets which kitchen station prepares each menu item and how long
+// orders take there
+type KitchenStations struct {
+	// Stations menu items can be routed to, in the order their tickets are listed
+	Stations []string
+	// Station of the items of each category that have no station of their own
+	Categories map[string]string
+	// Station preparing everything routed to no other station, including lines that
+	// are not on the menu
+	Default string
+	// Time to prepare menu items without a prep time of their own, and lines that are
+	// not on the menu
+	PrepTime time.Duration
+	// Time each order waiting at a station adds to the orders queued behind it
+	QueueDelay time.Duration
+}
+
+// kitchen is the restaurant's kitchen layout
+var kitchen = KitchenStations{
+	Stations:   []string{"grill", "fry", "bar"},
+	Default:    "kitchen",
+	PrepTime:   10 * time.Minute,
+	QueueDelay: 3 * time.Minute,
+}
+
+// SetKitchenStations sets the kitchen stations order lines are routed to. It must be
+// called before the server starts.
+func SetKitchenStations(stations KitchenStations) {
+	kitchen = stations
+}
+
+// valid reports whether station is one of the kitchen's stations
+func (k KitchenStations) valid(station string) bool {
+	return station == k.Default || slices.Contains(k.Stations, station)
+}
+
+// ValidStation reports whether station is one of the kitchen's stations
+func ValidStation(station string) bool {
+	return kitchen.valid(station)
+}
+
+// list returns the kitchen's stations, the default one last
+func (k KitchenStations) list() []string {
+	if slices.Contains(k.Stations, k.Default) {
+		return k.Stations
+	}
+	return append(slices.Clip(k.Stations), k.Default)
+}
+
+// stationFor returns the station preparing a menu item
+func (k KitchenStations) stationFor(item *models.MenuItem) string {
+	if item.Station != nil {
+		return *item.Station
+	}
+	if station, ok := k.Categories[item.Category]; ok {
+		return station
+	}
+	return k.Default
+}
+
+// prepMinutesFor returns the minutes a menu item takes to prepare
+func (k KitchenStations) prepMinutesFor(item *models.MenuItem) int {
+	if item.PrepMinutes != nil {
+		return *item.PrepMinutes
+	}
+	return int(k.PrepTime / time.Minute)
+}
+
+// maxPrepMinutes is the longest prep time a menu item may have
+const maxPrepMinutes = 600
+
+// Kitchen station errors
+var (
+	// ErrInvalidStation is returned for a station the kitchen doesn't have
+	ErrInvalidStation = errors.New("invalid station")
+	// ErrInvalidPrepTime is returned for a menu item prep time out of range
+	ErrInvalidPrepTime = errors.New("invalid prep time")
+)
+
+// stationProblem returns why station can't be set on a menu item, or "" if it can
+func stationProblem(station string) string {
+	if station != "" && !kitchen.valid(station) {
+		return fmt.Sprintf("station must be one of %s", strings.Join(kitchen.list(), ", "))
+	}
+	return ""
+}
+
+// Kitchen ticket events
+const (
+	EventTicketCreated = "ticket.created"
+)
+
+// KitchenTicketResponse is the part of an order a kitchen station prepares
+type KitchenTicketResponse struct {
+	OrderID      string                      `json:"order_id" example:"0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"`
+	OrderNumber  *int                        `json:"order_number,omitempty" example:"42"`
+	Station      string                      `json:"station" example:"grill"`
+	Channel      string                      `json:"channel" example:"dine_in"`
+	Table        *string                     `json:"table,omitempty" example:"12"`
+	CustomerName *string                     `json:"customer_name,omitempty"`
+	Notes        *string                     `json:"notes,omitempty"`
+	Items        []KitchenTicketItemResponse `json:"items"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	// When the order is expected to be ready, and when the station prepared its items
+	EstimatedReadyAt *time.Time `json:"estimated_ready_at,omitempty"`
+	PreparedAt       *time.Time `json:"prepared_at,omitempty"`
+}
+
+// KitchenTicketItemResponse is a line of a kitchen ticket
+type KitchenTicketItemResponse struct {
+	Name     string  `json:"name" example:"Mixed grill"`
+	Quantity int     `json:"quantity" example:"2"`
+	Notes    *string `json:"notes,omitempty" example:"No onions"`
+}
+
+// GetOrderTickets returns the kitchen tickets of an order, one per station with
+// items on the order, or only the ticket of station when it is not empty
+func (s *orderService) GetOrderTickets(ctx context.Context, id, station string) ([]KitchenTicketResponse, error) {
+	ctx, span := tracer.Start(ctx, "OrderService.GetOrderTickets")
+	defer span.End()
+
+	if station != "" && !kitchen.valid(station) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidStation, stationProblem(station))
+	}
+	order, err := s.GetOrderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets := kitchenTickets(order)
+	if station != "" {
+		tickets = slices.DeleteFunc(tickets, func(t KitchenTicketResponse) bool { return t.Station != station })
+	}
+	return tickets, nil
+}
+
+// kitchenTickets splits an order into a ticket per station, in the kitchen's station
+// order
+func kitchenTickets(order *OrderResponse) []KitchenTicketResponse {
+	stations := kitchen.list()
+	items := make(map[string][]KitchenTicketItemResponse, len(stations))
+	prepared := make(map[string]*time.Time, len(stations))
+	open := make(map[string]bool, len(stations))
+	for _, item := range order.Items {
+		station := ticketStation(item)
+		// A station prepares its lines of an order together
+		if item.PreparedAt == nil {
+			open[station] = true
+		} else {
+			prepared[station] = item.PreparedAt
+		}
+		items[station] = append(items[station], KitchenTicketItemResponse{Name: item.Name, Quantity: item.Quantity, Notes: item.Notes})
+	}
+
+	tickets := []KitchenTicketResponse{}
+	for _, station := range stations {
+		if len(items[station]) == 0 {
+			continue
+		}
+		tickets = append(tickets, KitchenTicketResponse{
+			OrderID:      order.ID,
+			OrderNumber:  order.Number,
+			Station:      station,
+			Channel:      order.Channel,
+			Table:        order.Table,
+			CustomerName: order.CustomerName,
+			Notes:        order.Notes,
+			Items:        items[station],
+			CreatedAt:    order.CreatedAt,
+
+			EstimatedReadyAt: order.EstimatedReadyAt,
+		})
+		if !open[station] {
+			tickets[len(tickets)-1].PreparedAt = prepared[station]
+		}
+	}
+	return tickets
+}
+
+// ticketStation returns the station whose ticket lists an order line. Lines routed to
+// a station that has since been removed go to the default one.
+func ticketStation(item OrderItemResponse) string {
+	if item.Station != nil && kitchen.valid(*item.Station) {
+		return *item.Station
+	}
+	return kitchen.Default
+}
+
+// publishTickets sends each station of the restaurant of ctx the ticket of a new
+// order. Only streams following that station, and those following every event,
+// receive it.
+func (s *orderService) publishTickets(ctx context.Context, order *OrderResponse) {
+	if s.events == nil {
+		return
+	}
+	for _, ticket := range kitchenTickets(order) {
+		s.events.Publish(realtime.Event{Type: EventTicketCreated, Station: ticket.Station, Data: ticket, Restaurant: database.RestaurantID(ctx)})
+	}
+}
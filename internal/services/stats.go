@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// MenuStatsRepository counts menu items
+type MenuStatsRepository interface {
+	CountByCategory(ctx context.Context) ([]models.CategoryCount, error)
+}
+
+// StatsService defines the interface for dashboard statistics
+type StatsService interface {
+	Dashboard(ctx context.Context) (*DashboardStats, error)
+}
+
+// DashboardStats are the figures of the admin UI landing page
+type DashboardStats struct {
+	Items      ItemStats       `json:"items"`
+	Categories []CategoryStats `json:"categories"`
+	Today      TodayStats      `json:"today"`
+}
+
+// ItemStats counts the active (non-deleted) menu items
+type ItemStats struct {
+	Active      int `json:"active" example:"42"`
+	Available   int `json:"available" example:"38"`
+	Unavailable int `json:"unavailable" example:"4"`
+}
+
+// CategoryStats counts the active menu items of a category
+type CategoryStats struct {
+	Category  string `json:"category" example:"main"`
+	Active    int    `json:"active" example:"12"`
+	Available int    `json:"available" example:"11"`
+}
+
+// TodayStats are the sales of the current business day
+type TodayStats struct {
+	Date string `json:"date" example:"2024-05-01"`
+	SalesFigures
+}
+
+// statsService handles business logic for dashboard statistics
+type statsService struct {
+	menu  MenuStatsRepository
+	sales SalesRepository
+}
+
+// NewStatsService creates a new dashboard statistics service
+func NewStatsService(menu MenuStatsRepository, sales SalesRepository) StatsService {
+	return &statsService{menu: menu, sales: sales}
+}
+
+// Dashboard counts the active menu items overall and per category, and sums the
+// orders of the current business day. Categories without items are listed with zero counts.
+func (s *statsService) Dashboard(ctx context.Context) (*DashboardStats, error) {
+	ctx, span := tracer.Start(ctx, "StatsService.Dashboard")
+	defer span.End()
+
+	counts, err := guard(func() ([]models.CategoryCount, error) { return s.menu.CountByCategory(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to count menu items: %w", err)
+	}
+
+	now := localTime(time.Now())
+	startOfDay := businessDay(now)
+	today, err := guard(func() (models.SalesRow, error) { return s.sales.Totals(ctx, startOfDay, now) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute today's sales: %w", err)
+	}
+
+	stats := &DashboardStats{
+		Categories: make([]CategoryStats, 0, len(ValidCategories)),
+		Today:      TodayStats{Date: startOfDay.Format(time.DateOnly), SalesFigures: salesFigures(today.Revenue, today.Orders)},
+	}
+	byCategory := make(map[string]models.CategoryCount, len(counts))
+	for _, count := range counts {
+		byCategory[count.Category] = count
+		stats.Items.Active += count.Total
+		stats.Items.Available += count.Available
+	}
+	stats.Items.Unavailable = stats.Items.Active - stats.Items.Available
+	for _, category := range slices.Sorted(maps.Keys(ValidCategories)) {
+		count := byCategory[category]
+		stats.Categories = append(stats.Categories, CategoryStats{Category: category, Active: count.Total, Available: count.Available})
+	}
+
+	return stats, nil
+}
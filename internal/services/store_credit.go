@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// StoreCreditRepository abstracts store credit storage
+type StoreCreditRepository interface {
+	FindAccount(ctx context.Context, customerPhone string) (*models.StoreCreditAccount, error)
+	RefundedToCredit(ctx context.Context, orderID string) (decimal.Decimal, error)
+	Credit(ctx context.Context, entry *models.StoreCreditEntry) error
+	Debit(ctx context.Context, entry *models.StoreCreditEntry, payment *models.OrderPayment) error
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ StoreCreditRepository = (*models.StoreCreditQuery)(nil)
+
+// StoreCreditService defines business operations on store credit
+type StoreCreditService interface {
+	GetStoreCredit(ctx context.Context, customerPhone string) (*StoreCreditResponse, error)
+	CreditStoreCredit(ctx context.Context, customerPhone string, req StoreCreditRequest) (*StoreCreditEntryResponse, error)
+	DebitStoreCredit(ctx context.Context, customerPhone string, req StoreCreditRequest) (*StoreCreditEntryResponse, error)
+}
+
+// Store credit errors
+var (
+	ErrInvalidStoreCredit = errors.New("invalid store credit entry")
+	// ErrStoreCreditTooLow is returned when debiting more than a customer's store credit
+	ErrStoreCreditTooLow = errors.New("store credit balance too low")
+)
+
+// StoreCreditRequest credits or debits a customer's store credit. Credits give a
+// Reason: refund, which needs the OrderID refunded, or goodwill. Debits correct a
+// balance; orders are paid with store credit through their payments instead.
+type StoreCreditRequest struct {
+	Amount  decimal.Decimal `json:"amount" swaggertype:"string" example:"10.00"`
+	Reason  string          `json:"reason,omitempty" enums:"refund,goodwill" example:"refund"`
+	OrderID *string         `json:"order_id,omitempty" example:"0b7e2f3c-6f7a-4a43-9f0e-5d1b2c3a4e5f"`
+	Note    *string         `json:"note,omitempty" example:"Cold pizza"`
+	// ID of the request making the change, recorded for auditing
+	RequestID string `json:"-"`
+}
+
+// StoreCreditResponse is a customer's store credit balance and its ledger, newest
+// first
+type StoreCreditResponse struct {
+	CustomerID string                     `json:"customer_id" example:"+962791234567"`
+	Balance    decimal.Decimal            `json:"balance" swaggertype:"string" example:"10.00"`
+	Entries    []StoreCreditEntryResponse `json:"entries"`
+}
+
+// StoreCreditEntryResponse is a change to a customer's store credit. Amount is
+// negative for debits; Balance is the balance after the change.
+type StoreCreditEntryResponse struct {
+	ID        int             `json:"id" example:"1"`
+	Type      string          `json:"type" enums:"credit,debit" example:"credit"`
+	Reason    string          `json:"reason" enums:"refund,goodwill,payment,adjustment" example:"refund"`
+	Amount    decimal.Decimal `json:"amount" swaggertype:"string" example:"10.00"`
+	Balance   decimal.Decimal `json:"balance" swaggertype:"string" example:"10.00"`
+	OrderID   *string         `json:"order_id,omitempty"`
+	Note      *string         `json:"note,omitempty"`
+	RequestID *string         `json:"request_id,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// storeCreditService handles business logic for store credit
+type storeCreditService struct {
+	repo   StoreCreditRepository
+	orders OrderRepository
+}
+
+// NewStoreCreditService creates a new store credit service. Refunds are checked against
+// the orders in orders.
+func NewStoreCreditService(repo StoreCreditRepository, orders OrderRepository) StoreCreditService {
+	return &storeCreditService{repo: repo, orders: orders}
+}
+
+// GetStoreCredit returns the store credit of a customer, told apart by phone number.
+// Customers who were never credited have none.
+func (s *storeCreditService) GetStoreCredit(ctx context.Context, customerPhone string) (*StoreCreditResponse, error) {
+	ctx, span := tracer.Start(ctx, "StoreCreditService.GetStoreCredit")
+	defer span.End()
+
+	customerPhone = strings.TrimSpace(customerPhone)
+	account, err := guard(func() (*models.StoreCreditAccount, error) { return s.repo.FindAccount(ctx, customerPhone) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return newStoreCreditResponse(customerPhone, nil), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find store credit of %s: %w", customerPhone, err)
+	}
+	return newStoreCreditResponse(customerPhone, account), nil
+}
+
+// newStoreCreditResponse converts the StoreCreditAccount model of a customer, nil when
+// they have none, to StoreCreditResponse
+func newStoreCreditResponse(customerPhone string, account *models.StoreCreditAccount) *StoreCreditResponse {
+	response := &StoreCreditResponse{CustomerID: customerPhone, Balance: decimal.Zero, Entries: []StoreCreditEntryResponse{}}
+	if account == nil {
+		return response
+	}
+
+	response.Balance = account.Balance
+	for i := range account.Entries {
+		response.Entries = append(response.Entries, *newStoreCreditEntryResponse(&account.Entries[i]))
+	}
+	return response
+}
+
+// CreditStoreCredit adds to a customer's store credit. A refund may return at most what
+// was paid for the customer's order, less what was already refunded to credit.
+func (s *storeCreditService) CreditStoreCredit(ctx context.Context, customerPhone string, req StoreCreditRequest) (*StoreCreditEntryResponse, error) {
+	ctx, span := tracer.Start(ctx, "StoreCreditService.CreditStoreCredit")
+	defer span.End()
+
+	entry, err := newStoreCreditEntry(customerPhone, req)
+	if err != nil {
+		return nil, err
+	}
+	entry.Type = models.StoreCreditCredit
+	switch req.Reason {
+	case models.StoreCreditRefund:
+		if req.OrderID == nil {
+			return nil, fmt.Errorf("%w: order_id is required for refunds", ErrInvalidStoreCredit)
+		}
+	case models.StoreCreditGoodwill:
+	default:
+		return nil, fmt.Errorf("%w: reason must be refund or goodwill", ErrInvalidStoreCredit)
+	}
+	entry.Reason = req.Reason
+
+	if req.OrderID != nil {
+		// Refundable amounts are checked right before they change
+		ctx := database.UsePrimary(ctx)
+		order, err := guard(func() (*models.Order, error) { return s.orders.FindByID(ctx, *req.OrderID) })
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find order %s: %w", *req.OrderID, err)
+		}
+		if order.CustomerPhone == nil || *order.CustomerPhone != entry.CustomerPhone {
+			return nil, fmt.Errorf("%w: order %s is not the customer's", ErrInvalidStoreCredit, order.ID)
+		}
+		if req.Reason == models.StoreCreditRefund {
+			refunded, err := guard(func() (decimal.Decimal, error) { return s.repo.RefundedToCredit(ctx, order.ID) })
+			if err != nil {
+				return nil, fmt.Errorf("failed to sum refunds of order %s: %w", order.ID, err)
+			}
+			if refundable := order.AmountPaid.Sub(refunded); entry.Amount.GreaterThan(refundable) {
+				return nil, fmt.Errorf("%w: at most %s of order %s can be refunded", ErrInvalidStoreCredit,
+					refundable.StringFixed(2), order.ID)
+			}
+		}
+	}
+
+	if err := guardExec(func() error { return s.repo.Credit(ctx, entry) }); err != nil {
+		return nil, fmt.Errorf("failed to credit store credit of %s: %w", entry.CustomerPhone, err)
+	}
+	return newStoreCreditEntryResponse(entry), nil
+}
+
+// DebitStoreCredit takes an adjustment off a customer's store credit
+func (s *storeCreditService) DebitStoreCredit(ctx context.Context, customerPhone string, req StoreCreditRequest) (*StoreCreditEntryResponse, error) {
+	ctx, span := tracer.Start(ctx, "StoreCreditService.DebitStoreCredit")
+	defer span.End()
+
+	entry, err := newStoreCreditEntry(customerPhone, req)
+	if err != nil {
+		return nil, err
+	}
+	if req.Reason != "" && req.Reason != models.StoreCreditAdjustment {
+		return nil, fmt.Errorf("%w: debits are adjustments; pay orders through their payments", ErrInvalidStoreCredit)
+	}
+	if req.OrderID != nil {
+		return nil, fmt.Errorf("%w: pay orders through their payments", ErrInvalidStoreCredit)
+	}
+	entry.Type = models.StoreCreditDebit
+	entry.Reason = models.StoreCreditAdjustment
+	entry.Amount = entry.Amount.Neg()
+
+	err = guardExec(func() error { return s.repo.Debit(ctx, entry, nil) })
+	if errors.Is(err, models.ErrStoreCreditBalance) {
+		return nil, fmt.Errorf("%w: %s has less than %s", ErrStoreCreditTooLow, entry.CustomerPhone, req.Amount.StringFixed(2))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to debit store credit of %s: %w", entry.CustomerPhone, err)
+	}
+	return newStoreCreditEntryResponse(entry), nil
+}
+
+// newStoreCreditEntry validates the parts of req shared by credits and debits
+func newStoreCreditEntry(customerPhone string, req StoreCreditRequest) (*models.StoreCreditEntry, error) {
+	customerPhone = strings.TrimSpace(customerPhone)
+	if customerPhone == "" || len(customerPhone) > 50 {
+		return nil, fmt.Errorf("%w: customer phone must be 1 to 50 characters", ErrInvalidStoreCredit)
+	}
+	if problem := amountProblem(req.Amount); problem != "" {
+		return nil, fmt.Errorf("%w: amount %s", ErrInvalidStoreCredit, problem)
+	}
+	entry := &models.StoreCreditEntry{
+		CustomerPhone: customerPhone,
+		Amount:        req.Amount,
+		OrderID:       req.OrderID,
+		Note:          req.Note,
+	}
+	if req.RequestID != "" {
+		entry.RequestID = &req.RequestID
+	}
+	return entry, nil
+}
+
+// newStoreCreditEntryResponse converts a store credit entry to its response DTO
+func newStoreCreditEntryResponse(entry *models.StoreCreditEntry) *StoreCreditEntryResponse {
+	return &StoreCreditEntryResponse{
+		ID:        entry.ID,
+		Type:      entry.Type,
+		Reason:    entry.Reason,
+		Amount:    entry.Amount,
+		Balance:   entry.Balance,
+		OrderID:   entry.OrderID,
+		Note:      entry.Note,
+		RequestID: entry.RequestID,
+		CreatedAt: localTime(entry.CreatedAt),
+	}
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+	"github.com/Zughayyar/agora-server/internal/realtime"
+)
+
+// GuestOrdering sets how guests order from the QR codes on the tables
+type GuestOrdering struct {
+	// How long a guest session lasts after the QR code is scanned
+	SessionTTL time.Duration
+	// Page of the guest ordering app the QR codes link to, given the table's token as
+	// ?table=; empty when the app's URL isn't known
+	OrderURL string
+}
+
+// guestOrdering is the restaurant's QR code table ordering
+var guestOrdering = GuestOrdering{SessionTTL: 2 * time.Hour}
+
+// SetGuestOrdering sets how guests order from the QR codes on the tables. It must be
+// called before the server starts.
+func SetGuestOrdering(ordering GuestOrdering) {
+	guestOrdering = ordering
+}
+
+// TableRepository abstracts dining table and guest session storage
+type TableRepository interface {
+	List(ctx context.Context) ([]models.DiningTable, error)
+	FindByID(ctx context.Context, id int) (*models.DiningTable, error)
+	FindByName(ctx context.Context, name string) (*models.DiningTable, error)
+	FindByToken(ctx context.Context, token string) (*models.DiningTable, error)
+	Create(ctx context.Context, table *models.DiningTable) error
+	Update(ctx context.Context, table *models.DiningTable) error
+	Delete(ctx context.Context, id int) error
+	CreateSession(ctx context.Context, session *models.GuestSession) error
+	FindSession(ctx context.Context, token string) (*models.GuestSession, error)
+	EndTableSessions(ctx context.Context, tableID int, now time.Time) error
+	SetStatus(ctx context.Context, id int, status string, at time.Time) error
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ TableRepository = (*models.DiningTableQuery)(nil)
+
+// TableService defines business operations on dining tables
+type TableService interface {
+	ListTables(ctx context.Context) ([]TableResponse, error)
+	CreateTable(ctx context.Context, req TableRequest) (*TableResponse, error)
+	UpdateTable(ctx context.Context, id int, req TableRequest) (*TableResponse, error)
+	DeleteTable(ctx context.Context, id int) error
+	RotateTableToken(ctx context.Context, id int) (*TableResponse, error)
+	UpdateTableStatus(ctx context.Context, id int, req TableStatusRequest) (*TableResponse, error)
+}
+
+// EventTableStatusUpdated is published when a table's status changes
+const EventTableStatusUpdated = "table.status_updated"
+
+// Dining table errors
+var (
+	ErrTableNotFound = errors.New("table not found")
+	ErrInvalidTable  = errors.New("invalid table")
+	// ErrTableExists is returned when another table has the same name
+	ErrTableExists = errors.New("table already exists")
+	// ErrInvalidTableStatus is returned for a status tables don't have
+	ErrInvalidTableStatus = errors.New("invalid table status")
+)
+
+// maxTableNameLength is the longest table name, as stored
+const maxTableNameLength = 50
+
+// TableRequest creates or renames a table
+type TableRequest struct {
+	Name string `json:"name" example:"12"`
+}
+
+// TableStatusRequest sets where a table is in its service
+type TableStatusRequest struct {
+	Status string `json:"status" example:"seated" enums:"free,seated,ordered,check_dropped,needs_cleaning"`
+}
+
+// TableResponse represents the dining table data returned to clients. Token is what
+// the table's QR code carries, and OrderURL the link to encode in it.
+type TableResponse struct {
+	ID              int       `json:"id" example:"3"`
+	Name            string    `json:"name" example:"12"`
+	Token           string    `json:"token" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	OrderURL        string    `json:"order_url,omitempty" example:"https://order.example.com/?table=9f86d081884c7d659a2feaa0c55ad015"`
+	Status          string    `json:"status" example:"seated"`
+	StatusChangedAt time.Time `json:"status_changed_at"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// TableStatusResponse is a table's new status, sent to real-time clients when it
+// changes
+type TableStatusResponse struct {
+	TableID         int       `json:"table_id" example:"3"`
+	Table           string    `json:"table" example:"12"`
+	Status          string    `json:"status" example:"seated"`
+	PreviousStatus  string    `json:"previous_status" example:"free"`
+	StatusChangedAt time.Time `json:"status_changed_at"`
+}
+
+// tableService handles business logic for dining tables
+type tableService struct {
+	repo   TableRepository
+	events EventPublisher
+}
+
+// NewTableService creates a new dining table service. Status changes are published to
+// events.
+func NewTableService(repo TableRepository, events EventPublisher) TableService {
+	return &tableService{repo: repo, events: events}
+}
+
+// ListTables returns all tables by name
+func (s *tableService) ListTables(ctx context.Context) ([]TableResponse, error) {
+	ctx, span := tracer.Start(ctx, "TableService.ListTables")
+	defer span.End()
+
+	tables, err := guard(func() ([]models.DiningTable, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tables: %w", err)
+	}
+	responses := make([]TableResponse, len(tables))
+	for i := range tables {
+		responses[i] = *newTableResponse(&tables[i])
+	}
+	return responses, nil
+}
+
+// CreateTable validates and stores a new table with a new QR code token
+func (s *tableService) CreateTable(ctx context.Context, req TableRequest) (*TableResponse, error) {
+	ctx, span := tracer.Start(ctx, "TableService.CreateTable")
+	defer span.End()
+
+	table := &models.DiningTable{}
+	if err := s.apply(ctx, table, req); err != nil {
+		return nil, err
+	}
+	token, err := newTableToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate table token: %w", err)
+	}
+	table.Token = token
+	table.Status = models.TableStatusFree
+	err = guardExec(func() error { return s.repo.Create(ctx, table) })
+	if errors.Is(err, models.ErrTableNameTaken) {
+		return nil, fmt.Errorf("%w: %q", ErrTableExists, table.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create table: %w", err)
+	}
+	return newTableResponse(table), nil
+}
+
+// UpdateTable renames a table. Its QR code stays valid; orders keep the name the
+// table had when they were placed.
+func (s *tableService) UpdateTable(ctx context.Context, id int, req TableRequest) (*TableResponse, error) {
+	ctx, span := tracer.Start(ctx, "TableService.UpdateTable")
+	defer span.End()
+
+	table, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, table, req); err != nil {
+		return nil, err
+	}
+	err = guardExec(func() error { return s.repo.Update(ctx, table) })
+	if errors.Is(err, models.ErrTableNameTaken) {
+		return nil, fmt.Errorf("%w: %q", ErrTableExists, table.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update table %d: %w", id, err)
+	}
+	return newTableResponse(table), nil
+}
+
+// DeleteTable removes a table and ends its guest sessions. Orders keep the table's
+// name.
+func (s *tableService) DeleteTable(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "TableService.DeleteTable")
+	defer span.End()
+
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	if err := guardExec(func() error { return s.repo.Delete(ctx, id) }); err != nil {
+		return fmt.Errorf("failed to delete table %d: %w", id, err)
+	}
+	return nil
+}
+
+// RotateTableToken gives a table a new QR code token, as when its code was copied.
+// The old code stops working and the table's open guest sessions end.
+func (s *tableService) RotateTableToken(ctx context.Context, id int) (*TableResponse, error) {
+	ctx, span := tracer.Start(ctx, "TableService.RotateTableToken")
+	defer span.End()
+
+	table, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	token, err := newTableToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate table token: %w", err)
+	}
+	table.Token = token
+	if err := guardExec(func() error { return s.repo.Update(ctx, table) }); err != nil {
+		return nil, fmt.Errorf("failed to update table %d: %w", id, err)
+	}
+	if err := guardExec(func() error { return s.repo.EndTableSessions(ctx, id, time.Now()) }); err != nil {
+		return nil, fmt.Errorf("failed to end the guest sessions of table %d: %w", id, err)
+	}
+	return newTableResponse(table), nil
+}
+
+// UpdateTableStatus sets where a table is in its service. Host stands may move a
+// table to any status, e.g. back to seated when a check was dropped by mistake; the
+// change is published to real-time clients.
+func (s *tableService) UpdateTableStatus(ctx context.Context, id int, req TableStatusRequest) (*TableResponse, error) {
+	ctx, span := tracer.Start(ctx, "TableService.UpdateTableStatus")
+	defer span.End()
+
+	if !validTableStatus(req.Status) {
+		return nil, fmt.Errorf("%w: status must be one of %s", ErrInvalidTableStatus, strings.Join(tableStatuses, ", "))
+	}
+	table, err := s.find(database.UsePrimary(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	if table.Status == req.Status {
+		return newTableResponse(table), nil
+	}
+
+	now := time.Now()
+	if err := guardExec(func() error { return s.repo.SetStatus(ctx, id, req.Status, now) }); err != nil {
+		return nil, fmt.Errorf("failed to update status of table %d: %w", id, err)
+	}
+	previous := table.Status
+	table.Status, table.StatusChangedAt, table.UpdatedAt = req.Status, now, now
+	if s.events != nil {
+		s.events.Publish(realtime.Event{Type: EventTableStatusUpdated, Restaurant: database.RestaurantID(ctx), Data: TableStatusResponse{
+			TableID:         table.ID,
+			Table:           table.Name,
+			Status:          table.Status,
+			PreviousStatus:  previous,
+			StatusChangedAt: localTime(now),
+		}})
+	}
+	return newTableResponse(table), nil
+}
+
+// tableStatuses are the statuses of a table, in the order a table goes through them
+var tableStatuses = []string{
+	models.TableStatusFree,
+	models.TableStatusSeated,
+	models.TableStatusOrdered,
+	models.TableStatusCheckDropped,
+	models.TableStatusNeedsCleaning,
+}
+
+// validTableStatus reports whether tables have a status
+func validTableStatus(status string) bool {
+	return slices.Contains(tableStatuses, status)
+}
+
+// find loads a table by ID
+func (s *tableService) find(ctx context.Context, id int) (*models.DiningTable, error) {
+	table, err := guard(func() (*models.DiningTable, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTableNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find table %d: %w", id, err)
+	}
+	return table, nil
+}
+
+// apply validates req and copies it onto table. No other table may have the name.
+func (s *tableService) apply(ctx context.Context, table *models.DiningTable, req TableRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	switch {
+	case req.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidTable)
+	case len(req.Name) > maxTableNameLength:
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidTable, maxTableNameLength)
+	}
+
+	other, err := guard(func() (*models.DiningTable, error) { return s.repo.FindByName(ctx, req.Name) })
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return fmt.Errorf("failed to look up table %q: %w", req.Name, err)
+	case other.ID != table.ID:
+		return fmt.Errorf("%w: %q", ErrTableExists, req.Name)
+	}
+
+	table.Name = req.Name
+	return nil
+}
+
+// newTableToken returns a random token for a table's QR code or a guest session
+func newTableToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// newTableResponse converts a table to its response, linking its QR code to the
+// guest ordering app when its URL is set
+func newTableResponse(table *models.DiningTable) *TableResponse {
+	response := &TableResponse{
+		ID:              table.ID,
+		Name:            table.Name,
+		Token:           table.Token,
+		Status:          table.Status,
+		StatusChangedAt: localTime(table.StatusChangedAt),
+		CreatedAt:       localTime(table.CreatedAt),
+		UpdatedAt:       localTime(table.UpdatedAt),
+	}
+	if guestOrdering.OrderURL != "" {
+		if u, err := url.Parse(guestOrdering.OrderURL); err == nil {
+			query := u.Query()
+			query.Set("table", table.Token)
+			u.RawQuery = query.Encode()
+			response.OrderURL = u.String()
+		}
+	}
+	return response
+}
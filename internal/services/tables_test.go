@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/circuitbreaker"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// racingTables is a table repository where another request takes every name between
+// the lookup and the insert
+type racingTables struct {
+	TableRepository
+}
+
+func (racingTables) FindByName(context.Context, string) (*models.DiningTable, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (racingTables) Create(context.Context, *models.DiningTable) error {
+	return models.ErrTableNameTaken
+}
+
+func TestCreateTableRaceReportsExistingName(t *testing.T) {
+	saved := dbBreaker
+	t.Cleanup(func() { dbBreaker = saved })
+	ConfigureCircuitBreaker(1, time.Minute)
+
+	service := NewTableService(racingTables{}, nil)
+	if _, err := service.CreateTable(context.Background(), TableRequest{Name: "12"}); !errors.Is(err, ErrTableExists) {
+		t.Fatalf("CreateTable() = %v, want %v", err, ErrTableExists)
+	}
+	if state := dbBreaker.State(); state != circuitbreaker.StateClosed {
+		t.Fatalf("breaker %s after a taken name, want closed", state)
+	}
+}
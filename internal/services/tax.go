@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// TaxRateRepository abstracts tax rate storage
+type TaxRateRepository interface {
+	List(ctx context.Context) ([]models.TaxRate, error)
+	FindByID(ctx context.Context, id int) (*models.TaxRate, error)
+	Create(ctx context.Context, rate *models.TaxRate) error
+	Update(ctx context.Context, rate *models.TaxRate) error
+	Delete(ctx context.Context, id int) error
+}
+
+// The Bun-backed query builder is the default repository implementation
+var _ TaxRateRepository = (*models.TaxRateQuery)(nil)
+
+// TaxRateService defines business operations on tax rates
+type TaxRateService interface {
+	ListTaxRates(ctx context.Context) ([]TaxRateResponse, error)
+	CreateTaxRate(ctx context.Context, req TaxRateRequest) (*TaxRateResponse, error)
+	UpdateTaxRate(ctx context.Context, id int, req TaxRateRequest) (*TaxRateResponse, error)
+	DeleteTaxRate(ctx context.Context, id int) error
+}
+
+// Tax rate errors
+var (
+	ErrTaxRateNotFound = errors.New("tax rate not found")
+	ErrInvalidTaxRate  = errors.New("invalid tax rate")
+	// ErrTaxRateExists is returned when the menu item, category or default already has a rate
+	ErrTaxRateExists = errors.New("tax rate already exists")
+)
+
+// TaxRateRequest creates or replaces a tax rate. Rate is a percentage. At most one of
+// Category and MenuItemID may be set; with neither, the rate is the default for items
+// without a more specific one.
+type TaxRateRequest struct {
+	Name       string          `json:"name" example:"VAT"`
+	Rate       decimal.Decimal `json:"rate" swaggertype:"string" example:"16"`
+	Category   *string         `json:"category,omitempty" example:"drink"`
+	MenuItemID *int            `json:"menu_item_id,omitempty"`
+}
+
+// TaxRateResponse represents the tax rate data returned to clients
+type TaxRateResponse struct {
+	ID         int             `json:"id" example:"1"`
+	Name       string          `json:"name" example:"VAT"`
+	Rate       decimal.Decimal `json:"rate" swaggertype:"string" example:"16"`
+	Category   *string         `json:"category,omitempty" example:"drink"`
+	MenuItemID *int            `json:"menu_item_id,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// taxRateService handles business logic for tax rates
+type taxRateService struct {
+	repo TaxRateRepository
+}
+
+// NewTaxRateService creates a new tax rate service
+func NewTaxRateService(repo TaxRateRepository) TaxRateService {
+	return &taxRateService{repo: repo}
+}
+
+// ListTaxRates returns all tax rates
+func (s *taxRateService) ListTaxRates(ctx context.Context) ([]TaxRateResponse, error) {
+	ctx, span := tracer.Start(ctx, "TaxRateService.ListTaxRates")
+	defer span.End()
+
+	rates, err := guard(func() ([]models.TaxRate, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tax rates: %w", err)
+	}
+	responses := make([]TaxRateResponse, len(rates))
+	for i := range rates {
+		responses[i] = *newTaxRateResponse(&rates[i])
+	}
+	return responses, nil
+}
+
+// CreateTaxRate validates and stores a new tax rate
+func (s *taxRateService) CreateTaxRate(ctx context.Context, req TaxRateRequest) (*TaxRateResponse, error) {
+	ctx, span := tracer.Start(ctx, "TaxRateService.CreateTaxRate")
+	defer span.End()
+
+	rate := &models.TaxRate{}
+	if err := s.apply(ctx, rate, req); err != nil {
+		return nil, err
+	}
+	err := guardExec(func() error { return s.repo.Create(ctx, rate) })
+	if errors.Is(err, models.ErrTaxRateScopeTaken) {
+		return nil, fmt.Errorf("%w: another rate already applies there", ErrTaxRateExists)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tax rate: %w", err)
+	}
+	return newTaxRateResponse(rate), nil
+}
+
+// UpdateTaxRate replaces a tax rate. Orders keep the tax they were charged.
+func (s *taxRateService) UpdateTaxRate(ctx context.Context, id int, req TaxRateRequest) (*TaxRateResponse, error) {
+	ctx, span := tracer.Start(ctx, "TaxRateService.UpdateTaxRate")
+	defer span.End()
+
+	rate, err := guard(func() (*models.TaxRate, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaxRateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tax rate %d: %w", id, err)
+	}
+	if err := s.apply(ctx, rate, req); err != nil {
+		return nil, err
+	}
+	err = guardExec(func() error { return s.repo.Update(ctx, rate) })
+	if errors.Is(err, models.ErrTaxRateScopeTaken) {
+		return nil, fmt.Errorf("%w: another rate already applies there", ErrTaxRateExists)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tax rate %d: %w", id, err)
+	}
+	return newTaxRateResponse(rate), nil
+}
+
+// DeleteTaxRate removes a tax rate. Orders keep the tax they were charged.
+func (s *taxRateService) DeleteTaxRate(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "TaxRateService.DeleteTaxRate")
+	defer span.End()
+
+	_, err := guard(func() (*models.TaxRate, error) { return s.repo.FindByID(ctx, id) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTaxRateNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find tax rate %d: %w", id, err)
+	}
+	if err := guardExec(func() error { return s.repo.Delete(ctx, id) }); err != nil {
+		return fmt.Errorf("failed to delete tax rate %d: %w", id, err)
+	}
+	return nil
+}
+
+// apply validates req and copies it onto rate. The menu item, category or default
+// the rate applies to must not have another rate.
+func (s *taxRateService) apply(ctx context.Context, rate *models.TaxRate, req TaxRateRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	switch {
+	case req.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidTaxRate)
+	case req.Rate.IsNegative() || req.Rate.GreaterThan(decimal.NewFromInt(100)):
+		return fmt.Errorf("%w: rate must be a percentage between 0 and 100", ErrInvalidTaxRate)
+	case req.Category != nil && req.MenuItemID != nil:
+		return fmt.Errorf("%w: set at most one of category and menu_item_id", ErrInvalidTaxRate)
+	case req.Category != nil && !ValidCategories[*req.Category]:
+		return fmt.Errorf("%w: category %s", ErrInvalidTaxRate, categoryList())
+	}
+
+	rates, err := guard(func() ([]models.TaxRate, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return fmt.Errorf("failed to retrieve tax rates: %w", err)
+	}
+	for _, other := range rates {
+		if other.ID != rate.ID && sameScope(other.Category, req.Category) && sameScope(other.MenuItemID, req.MenuItemID) {
+			return fmt.Errorf("%w: %q already applies there", ErrTaxRateExists, other.Name)
+		}
+	}
+
+	rate.Name = req.Name
+	rate.Rate = req.Rate
+	rate.Category = req.Category
+	rate.MenuItemID = req.MenuItemID
+	return nil
+}
+
+// sameScope reports whether two optional scope fields are equal
+func sameScope[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// categoryList describes the valid categories for validation errors
+func categoryList() string {
+	var categories []string
+	for category := range ValidCategories {
+		categories = append(categories, category)
+	}
+	slices.Sort(categories)
+	return "must be one of: " + strings.Join(categories, ", ")
+}
+
+// newTaxRateResponse converts a tax rate model to its response DTO
+func newTaxRateResponse(rate *models.TaxRate) *TaxRateResponse {
+	return &TaxRateResponse{
+		ID:         rate.ID,
+		Name:       rate.Name,
+		Rate:       rate.Rate,
+		Category:   rate.Category,
+		MenuItemID: rate.MenuItemID,
+		CreatedAt:  localTime(rate.CreatedAt),
+		UpdatedAt:  localTime(rate.UpdatedAt),
+	}
+}
+
+// taxRateFor picks the rate of an order line: the menu item's own rate, else its
+// category's, else the default rate. It returns nil when none applies.
+func taxRateFor(rates []models.TaxRate, menuItemID *int, category string) *models.TaxRate {
+	var byCategory, fallback *models.TaxRate
+	for i := range rates {
+		rate := &rates[i]
+		switch {
+		case rate.MenuItemID != nil:
+			if menuItemID != nil && *rate.MenuItemID == *menuItemID {
+				return rate
+			}
+		case rate.Category != nil:
+			if *rate.Category == category {
+				byCategory = rate
+			}
+		default:
+			fallback = rate
+		}
+	}
+	if byCategory != nil {
+		return byCategory
+	}
+	return fallback
+}
+
+// lineTax computes the tax of a line total at a percentage rate, rounded to cents.
+// When prices include tax, the tax is the part of the total above its net amount.
+func lineTax(total, rate decimal.Decimal, included bool) decimal.Decimal {
+	fraction := rate.Div(decimal.NewFromInt(100))
+	if included {
+		return total.Sub(total.Div(decimal.NewFromInt(1).Add(fraction))).Round(2)
+	}
+	return total.Mul(fraction).Round(2)
+}
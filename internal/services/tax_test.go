@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Zughayyar/agora-server/internal/circuitbreaker"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// racingTaxRates is a tax rate repository where another request adds a rate for the
+// same scope between the lookup and the insert
+type racingTaxRates struct {
+	TaxRateRepository
+}
+
+func (racingTaxRates) List(context.Context) ([]models.TaxRate, error) {
+	return nil, nil
+}
+
+func (racingTaxRates) Create(context.Context, *models.TaxRate) error {
+	return models.ErrTaxRateScopeTaken
+}
+
+func TestCreateTaxRateRaceReportsExistingRate(t *testing.T) {
+	saved := dbBreaker
+	t.Cleanup(func() { dbBreaker = saved })
+	ConfigureCircuitBreaker(1, time.Minute)
+
+	service := NewTaxRateService(racingTaxRates{})
+	req := TaxRateRequest{Name: "VAT", Rate: decimal.NewFromInt(16)}
+	if _, err := service.CreateTaxRate(context.Background(), req); !errors.Is(err, ErrTaxRateExists) {
+		t.Fatalf("CreateTaxRate() = %v, want %v", err, ErrTaxRateExists)
+	}
+	if state := dbBreaker.State(); state != circuitbreaker.StateClosed {
+		t.Fatalf("breaker %s after a taken scope, want closed", state)
+	}
+}
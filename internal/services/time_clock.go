@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Zughayyar/agora-server/internal/database"
+	"github.com/Zughayyar/agora-server/internal/database/models"
+)
+
+// TimeClock sets when staff may clock in and the pay periods timesheets cover
+type TimeClock struct {
+	// How long before a shift starts staff may clock in for it
+	EarlyClockIn time.Duration
+	// Length of a pay period in business days
+	PayPeriodDays int
+	// Date a pay period starts on, at midnight UTC; the others follow and precede it
+	PayPeriodStart time.Time
+	// Time worked in a business day or a week beyond which it is overtime; 0 counts
+	// no overtime by it
+	OvertimeDaily  time.Duration
+	OvertimeWeekly time.Duration
+}
+
+// timeClock is the restaurant's time clock
+var timeClock = TimeClock{
+	EarlyClockIn:   15 * time.Minute,
+	PayPeriodDays:  14,
+	PayPeriodStart: time.Date(2026, time.January, 5, 0, 0, 0, 0, time.UTC),
+	OvertimeWeekly: 40 * time.Hour,
+}
+
+// SetTimeClock sets when staff may clock in and the pay periods timesheets cover. It
+// must be called before the server starts.
+func SetTimeClock(clock TimeClock) {
+	timeClock = clock
+}
+
+// period returns the start of the pay period t falls in and the start of the next
+func (c TimeClock) period(t time.Time) (time.Time, time.Time) {
+	date := businessDate(businessDay(t))
+	offset := int(date.Sub(c.PayPeriodStart).Hours()/24) % c.PayPeriodDays
+	if offset < 0 {
+		offset += c.PayPeriodDays
+	}
+	first := date.AddDate(0, 0, -offset)
+	start := StartOfBusinessDay(time.Date(first.Year(), first.Month(), first.Day(), 12, 0, 0, 0, timezone()))
+	end := StartOfBusinessDay(time.Date(first.Year(), first.Month(), first.Day()+c.PayPeriodDays, 12, 0, 0, 0, timezone()))
+	return start, end
+}
+
+// Time clock errors
+var (
+	ErrAlreadyClockedIn = errors.New("already clocked in")
+	ErrNotClockedIn     = errors.New("not clocked in")
+	// ErrNoScheduledShift is returned when a staff member clocks in with no shift
+	// starting soon or under way
+	ErrNoScheduledShift = errors.New("no scheduled shift")
+	// ErrStaffHasPunches is returned when deleting a staff member who clocked in,
+	// whose punches are payroll records
+	ErrStaffHasPunches = errors.New("staff member has time punches")
+)
+
+// ClockInRequest clocks a staff member in
+type ClockInRequest struct {
+	// Clock in without a shift, e.g. when called in to help out; otherwise a shift of
+	// theirs must be under way or start within CLOCK_IN_EARLY_MINUTES
+	Unscheduled bool `json:"unscheduled,omitempty"`
+}
+
+// TimePunchResponse is a staff member's time on the clock
+type TimePunchResponse struct {
+	ID      int    `json:"id" example:"31"`
+	StaffID int    `json:"staff_id" example:"2"`
+	Staff   string `json:"staff" example:"Lina"`
+	// Shift clocked in for; unset when working unscheduled
+	ShiftID       *int       `json:"shift_id,omitempty" example:"14"`
+	ShiftStartsAt *time.Time `json:"shift_starts_at,omitempty"`
+	ShiftEndsAt   *time.Time `json:"shift_ends_at,omitempty"`
+	ClockInAt     time.Time  `json:"clock_in_at"`
+	// Unset while clocked in
+	ClockOutAt *time.Time `json:"clock_out_at,omitempty"`
+	// Time worked, to the hundredth of an hour; 0 while clocked in
+	Hours float64 `json:"hours" example:"6.75"`
+	// Minutes clocked in after the shift started
+	LateMinutes int `json:"late_minutes" example:"4"`
+	// Minutes clocked out after the shift ended
+	OvertimeMinutes int  `json:"overtime_minutes" example:"20"`
+	Unscheduled     bool `json:"unscheduled"`
+	ClockedIn       bool `json:"clocked_in"`
+}
+
+// TimesheetOptions selects the pay period Date falls in, by default the current one,
+// and one staff member when StaffID is set
+type TimesheetOptions struct {
+	Date    time.Time
+	StaffID *int
+}
+
+// TimesheetResponse is the time staff were scheduled and worked in a pay period
+type TimesheetResponse struct {
+	// First and last business day of the pay period
+	PeriodStart string                   `json:"period_start" example:"2026-10-05"`
+	PeriodEnd   string                   `json:"period_end" example:"2026-10-18"`
+	StartsAt    time.Time                `json:"starts_at"`
+	EndsAt      time.Time                `json:"ends_at"`
+	Staff       []TimesheetStaffResponse `json:"staff"`
+	// Hours of all staff in the period
+	ScheduledHours float64 `json:"scheduled_hours" example:"412"`
+	WorkedHours    float64 `json:"worked_hours" example:"405.25"`
+}
+
+// TimesheetStaffResponse is the time a staff member was scheduled and worked in a pay
+// period, with their punches
+type TimesheetStaffResponse struct {
+	StaffID int    `json:"staff_id" example:"2"`
+	Name    string `json:"name" example:"Lina"`
+	Role    string `json:"role" example:"server"`
+	// Hours of the shifts starting in the period
+	ScheduledHours float64 `json:"scheduled_hours" example:"76"`
+	// Hours of the punches clocked in and out in the period
+	WorkedHours float64 `json:"worked_hours" example:"78.5"`
+	// Part of WorkedHours worked without a shift
+	UnscheduledHours float64 `json:"unscheduled_hours" example:"4"`
+	// Punches clocked in after their shift started
+	LatePunches int                 `json:"late_punches" example:"1"`
+	ClockedIn   bool                `json:"clocked_in"`
+	Punches     []TimePunchResponse `json:"punches"`
+}
+
+// ClockIn clocks a staff member in for the shift of theirs under way or starting
+// soonest within the time clock's early clock-in window
+func (s *staffService) ClockIn(ctx context.Context, staffID int, req ClockInRequest) (*TimePunchResponse, error) {
+	ctx, span := tracer.Start(ctx, "StaffService.ClockIn")
+	defer span.End()
+
+	ctx = database.UsePrimary(ctx)
+	member, err := s.find(ctx, staffID)
+	if err != nil {
+		return nil, err
+	}
+	if !member.IsActive {
+		return nil, fmt.Errorf("%w: %s is inactive", ErrInvalidStaff, member.Name)
+	}
+	open, err := s.openPunch(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+	if open != nil {
+		return nil, fmt.Errorf("%w: %s has been clocked in since %s", ErrAlreadyClockedIn, member.Name,
+			localTime(open.ClockInAt).Format(time.RFC3339))
+	}
+
+	now := time.Now()
+	shifts, err := guard(func() ([]models.Shift, error) {
+		return s.repo.OverlappingShifts(ctx, member.ID, now, now.Add(timeClock.EarlyClockIn), 0)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the shifts of %s: %w", member.Name, err)
+	}
+	punch := &models.TimePunch{StaffID: member.ID, Staff: member, ClockInAt: now}
+	if len(shifts) > 0 {
+		punch.ShiftID = &shifts[0].ID
+		punch.Shift = &shifts[0]
+	} else if !req.Unscheduled {
+		return nil, fmt.Errorf("%w: %s has no shift under way or starting within %d minutes; clock in as unscheduled to work anyway",
+			ErrNoScheduledShift, member.Name, int(timeClock.EarlyClockIn/time.Minute))
+	}
+
+	if err := guardExec(func() error { return s.repo.CreatePunch(ctx, punch) }); err != nil {
+		return nil, fmt.Errorf("failed to clock in %s: %w", member.Name, err)
+	}
+	return newTimePunchResponse(punch), nil
+}
+
+// ClockOut clocks a staff member out of the punch they are clocked in on
+func (s *staffService) ClockOut(ctx context.Context, staffID int) (*TimePunchResponse, error) {
+	ctx, span := tracer.Start(ctx, "StaffService.ClockOut")
+	defer span.End()
+
+	ctx = database.UsePrimary(ctx)
+	member, err := s.find(ctx, staffID)
+	if err != nil {
+		return nil, err
+	}
+	punch, err := s.openPunch(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+	if punch == nil {
+		return nil, fmt.Errorf("%w: %s is not clocked in", ErrNotClockedIn, member.Name)
+	}
+
+	now := time.Now()
+	err = guardExec(func() error { return s.repo.ClockOut(ctx, punch.ID, now) })
+	if errors.Is(err, models.ErrPunchClosed) {
+		return nil, fmt.Errorf("%w: %s was clocked out meanwhile", ErrNotClockedIn, member.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to clock out %s: %w", member.Name, err)
+	}
+	punch.Staff = member
+	punch.ClockOutAt = &now
+	return newTimePunchResponse(punch), nil
+}
+
+// Timesheet returns the hours each staff member was scheduled and worked in a pay
+// period, with their punches. Staff are listed when they had a shift or punch in the
+// period, or when asked for by ID.
+func (s *staffService) Timesheet(ctx context.Context, opts TimesheetOptions) (*TimesheetResponse, error) {
+	ctx, span := tracer.Start(ctx, "StaffService.Timesheet")
+	defer span.End()
+
+	if opts.Date.IsZero() {
+		opts.Date = time.Now()
+	}
+	if opts.StaffID != nil {
+		if _, err := s.find(ctx, *opts.StaffID); err != nil {
+			return nil, err
+		}
+	}
+	start, end := timeClock.period(opts.Date)
+
+	shifts, err := guard(func() ([]models.Shift, error) { return s.repo.Shifts(ctx, start, end, opts.StaffID) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve shifts: %w", err)
+	}
+	punches, err := guard(func() ([]models.TimePunch, error) { return s.repo.Punches(ctx, start, end, opts.StaffID) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve punches: %w", err)
+	}
+	staff, err := guard(func() ([]models.StaffMember, error) { return s.repo.List(ctx) })
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve staff: %w", err)
+	}
+
+	sheets := make(map[int]*TimesheetStaffResponse)
+	sheet := func(staffID int) *TimesheetStaffResponse {
+		if _, ok := sheets[staffID]; !ok {
+			sheets[staffID] = &TimesheetStaffResponse{StaffID: staffID, Punches: []TimePunchResponse{}}
+		}
+		return sheets[staffID]
+	}
+	if opts.StaffID != nil {
+		sheet(*opts.StaffID)
+	}
+	for i := range shifts {
+		sheet(shifts[i].StaffID).ScheduledHours += shifts[i].EndsAt.Sub(shifts[i].StartsAt).Hours()
+	}
+	for i := range punches {
+		punch := newTimePunchResponse(&punches[i])
+		member := sheet(punch.StaffID)
+		member.Punches = append(member.Punches, *punch)
+		member.WorkedHours += punch.Hours
+		if punch.Unscheduled {
+			member.UnscheduledHours += punch.Hours
+		}
+		if punch.LateMinutes > 0 {
+			member.LatePunches++
+		}
+		if punch.ClockedIn {
+			member.ClockedIn = true
+		}
+	}
+
+	timesheet := &TimesheetResponse{
+		PeriodStart: start.Format(time.DateOnly),
+		PeriodEnd:   StartOfBusinessDay(end.AddDate(0, 0, -1)).Format(time.DateOnly),
+		StartsAt:    localTime(start),
+		EndsAt:      localTime(end),
+		Staff:       []TimesheetStaffResponse{},
+	}
+	// Staff are listed by name, as the repository returns them
+	for _, member := range staff {
+		total, ok := sheets[member.ID]
+		if !ok {
+			continue
+		}
+		total.Name, total.Role = member.Name, member.Role
+		timesheet.ScheduledHours += total.ScheduledHours
+		timesheet.WorkedHours += total.WorkedHours
+		total.ScheduledHours = roundHours(total.ScheduledHours)
+		total.WorkedHours = roundHours(total.WorkedHours)
+		total.UnscheduledHours = roundHours(total.UnscheduledHours)
+		timesheet.Staff = append(timesheet.Staff, *total)
+	}
+	timesheet.ScheduledHours = roundHours(timesheet.ScheduledHours)
+	timesheet.WorkedHours = roundHours(timesheet.WorkedHours)
+	return timesheet, nil
+}
+
+// openPunch returns the punch a staff member is clocked in on, or nil
+func (s *staffService) openPunch(ctx context.Context, member *models.StaffMember) (*models.TimePunch, error) {
+	punch, err := guard(func() (*models.TimePunch, error) { return s.repo.OpenPunch(ctx, member.ID) })
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up the punches of %s: %w", member.Name, err)
+	}
+	return punch, nil
+}
+
+// newTimePunchResponse converts a punch, with its staff member and shift, to its
+// response
+func newTimePunchResponse(punch *models.TimePunch) *TimePunchResponse {
+	response := &TimePunchResponse{
+		ID:          punch.ID,
+		StaffID:     punch.StaffID,
+		ShiftID:     punch.ShiftID,
+		ClockInAt:   localTime(punch.ClockInAt),
+		ClockOutAt:  optionalLocalTime(punch.ClockOutAt),
+		Unscheduled: punch.ShiftID == nil,
+		ClockedIn:   punch.ClockOutAt == nil,
+	}
+	if punch.Staff != nil {
+		response.Staff = punch.Staff.Name
+	}
+	if punch.ClockOutAt != nil {
+		response.Hours = roundHours(punch.ClockOutAt.Sub(punch.ClockInAt).Hours())
+	}
+	if punch.Shift != nil {
+		response.ShiftStartsAt = optionalLocalTime(&punch.Shift.StartsAt)
+		response.ShiftEndsAt = optionalLocalTime(&punch.Shift.EndsAt)
+		response.LateMinutes = max(0, int(punch.ClockInAt.Sub(punch.Shift.StartsAt)/time.Minute))
+		if punch.ClockOutAt != nil {
+			response.OvertimeMinutes = max(0, int(punch.ClockOutAt.Sub(punch.Shift.EndsAt)/time.Minute))
+		}
+	}
+	return response
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// zone is the restaurant's timezone, in which timestamps are returned to clients
+var zone atomic.Pointer[time.Location]
+
+// SetTimezone sets the restaurant's timezone. It can be changed while the server runs,
+// through the timezone setting.
+func SetTimezone(loc *time.Location) {
+	zone.Store(loc)
+}
+
+// timezone returns the restaurant's timezone, UTC until one is set
+func timezone() *time.Location {
+	if loc := zone.Load(); loc != nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// localTime returns t in the restaurant's timezone
+func localTime(t time.Time) time.Time {
+	return t.In(timezone())
+}
+
+// optionalLocalTime returns t in the restaurant's timezone, or nil when t is nil
+func optionalLocalTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	local := localTime(*t)
+	return &local
+}
+
+// businessDayStart is the time of day at which the restaurant's business day starts
+var businessDayStart time.Duration
+
+// SetBusinessDayStart sets the time of day, since midnight, at which the business day
+// starts. It must be called before the server starts.
+func SetBusinessDayStart(start time.Duration) {
+	businessDayStart = start
+}
+
+// StartOfBusinessDay returns the start of the business day dated as date is in the
+// restaurant's timezone; with the day starting at 4 AM, the business day of May 1st
+// runs from May 1st 04:00 to May 2nd 04:00
+func StartOfBusinessDay(date time.Time) time.Time {
+	date = localTime(date)
+	return time.Date(date.Year(), date.Month(), date.Day(),
+		int(businessDayStart/time.Hour), int(businessDayStart%time.Hour/time.Minute), 0, 0, timezone())
+}
+
+// businessDay returns the start of the business day t falls in
+func businessDay(t time.Time) time.Time {
+	start := StartOfBusinessDay(t)
+	if localTime(t).Before(start) {
+		return StartOfBusinessDay(start.AddDate(0, 0, -1))
+	}
+	return start
+}
+
+// businessDate returns the date of the business day starting at start, at midnight
+// UTC as DATE columns hold it
+func businessDate(start time.Time) time.Time {
+	start = localTime(start)
+	return time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ErrInvalidTimestamp is returned when a timestamp matches none of the accepted formats
+var ErrInvalidTimestamp = errors.New("invalid timestamp")
+
+// timestampLayouts are the accepted timestamp formats besides Unix seconds. Layouts
+// without a UTC offset are read in the restaurant's timezone.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// ParseTimestamp reads a timestamp in RFC 3339 (with or without fractional seconds),
+// as a date and time without offset, as a date, in RFC 1123 or as Unix seconds
+func ParseTimestamp(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0).In(timezone()), nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, value, timezone()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w %q: use RFC 3339, e.g. 2024-05-01T18:30:00+03:00", ErrInvalidTimestamp, value)
+}
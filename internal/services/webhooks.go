@@ -0,0 +1,185 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/Zughayyar/agora-server/internal/jobs"
+	"github.com/Zughayyar/agora-server/internal/logging"
+	"github.com/Zughayyar/agora-server/internal/realtime"
+	"github.com/Zughayyar/agora-server/internal/version"
+	"github.com/Zughayyar/agora-server/internal/webhooks"
+)
+
+// JobDeliverWebhook is the job kind that delivers an event to one webhook subscription
+const JobDeliverWebhook = "webhook.deliver"
+
+// webhookTimeout bounds a single delivery request
+const webhookTimeout = 10 * time.Second
+
+// WebhookEvent is the JSON body POSTed to webhook subscribers
+type WebhookEvent struct {
+	ID           string          `json:"id" example:"evt_5f1c0e2a9b7d4c3e"`
+	Type         string          `json:"type" example:"menu_item.updated"`
+	OccurredAt   time.Time       `json:"occurred_at"`
+	RestaurantID int             `json:"restaurant_id,omitempty" example:"1"`
+	Data         json.RawMessage `json:"data,omitempty"`
+}
+
+// webhookPayload is the job payload of a pending delivery
+type webhookPayload struct {
+	SubscriptionID int64        `json:"subscription_id"`
+	Event          WebhookEvent `json:"event"`
+}
+
+// WebhookDispatcher delivers change events to the webhook subscriptions that want
+// them. Each delivery is a job, so failed deliveries are retried with the queue's
+// backoff; every attempt is recorded in webhook_deliveries.
+type WebhookDispatcher struct {
+	db     *bun.DB
+	queue  *jobs.Queue
+	client *http.Client
+}
+
+// NewWebhookDispatcher registers the delivery job handler on queue
+func NewWebhookDispatcher(db *bun.DB, queue *jobs.Queue) *WebhookDispatcher {
+	d := &WebhookDispatcher{
+		db:     db,
+		queue:  queue,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+	queue.Register(JobDeliverWebhook, d.deliver)
+	return d
+}
+
+// Publish queues a delivery of event to every matching subscription. Failures are
+// logged: the change itself has already been committed.
+func (d *WebhookDispatcher) Publish(event realtime.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), outboxEnqueueTimeout)
+	defer cancel()
+	logger := logging.FromContext(ctx).With(slog.String("event", event.Type))
+
+	subs, err := webhooks.Matching(ctx, d.db, event.Type)
+	if err != nil {
+		logger.Error("Failed to load webhook subscriptions", slog.String("error", err.Error()))
+		return
+	}
+	if len(subs) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		logger.Error("Failed to encode webhook event", slog.String("error", err.Error()))
+		return
+	}
+	id, err := newEventID()
+	if err != nil {
+		logger.Error("Failed to create webhook event", slog.String("error", err.Error()))
+		return
+	}
+
+	webhookEvent := WebhookEvent{ID: id, Type: event.Type, OccurredAt: time.Now().UTC(), RestaurantID: event.Restaurant, Data: data}
+	for _, sub := range subs {
+		payload := webhookPayload{SubscriptionID: sub.ID, Event: webhookEvent}
+		if _, err := d.queue.Enqueue(ctx, JobDeliverWebhook, payload); err != nil {
+			logger.Error("Failed to queue webhook delivery",
+				slog.Int64("subscription_id", sub.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+// deliver is the job handler POSTing an event to a subscription
+func (d *WebhookDispatcher) deliver(ctx context.Context, job *jobs.Job) (any, error) {
+	var payload webhookPayload
+	if err := job.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	sub, err := webhooks.Get(ctx, d.db, payload.SubscriptionID)
+	if errors.Is(err, webhooks.ErrNotFound) || (err == nil && !sub.Active) {
+		// Unsubscribed since the event was queued
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(payload.Event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+
+	delivery := &webhooks.Delivery{
+		SubscriptionID: sub.ID,
+		EventID:        payload.Event.ID,
+		EventType:      payload.Event.Type,
+		Attempt:        job.Attempts,
+		CreatedAt:      time.Now(),
+	}
+	deliverErr := d.post(ctx, sub, payload.Event, body, delivery)
+	delivery.DurationMs = time.Since(delivery.CreatedAt).Milliseconds()
+	delivery.Succeeded = deliverErr == nil
+	if deliverErr != nil {
+		message := deliverErr.Error()
+		delivery.Error = &message
+	}
+
+	// Record the attempt even while shutting down
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if _, err := d.db.NewInsert().Model(delivery).Exec(saveCtx); err != nil {
+		logging.FromContext(ctx).Error("Failed to record webhook delivery", slog.String("error", err.Error()))
+	}
+	return nil, deliverErr
+}
+
+// post sends the signed request, failing unless the subscriber answers with 2xx
+func (d *WebhookDispatcher) post(ctx context.Context, sub *webhooks.Subscription, event WebhookEvent, body []byte, delivery *webhooks.Delivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "agora-server/"+version.Get().Version)
+	req.Header.Set("X-Agora-Event", event.Type)
+	req.Header.Set("X-Agora-Event-Id", event.ID)
+	req.Header.Set("X-Agora-Timestamp", fmt.Sprint(now.Unix()))
+	req.Header.Set("X-Agora-Signature", webhooks.Sign(sub.Secret, now, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain a bounded part of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	delivery.StatusCode = &resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("subscriber responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// newEventID returns a random event ID shared by all deliveries of an event
+func newEventID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "evt_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when an object does not exist
+var ErrNotFound = errors.New("object not found")
+
+// Object is an open stored file
+type Object interface {
+	io.ReadSeekCloser
+	ModTime() time.Time
+}
+
+// Storage keeps generated files (e.g. exports) until they are downloaded
+type Storage interface {
+	// Put stores the contents of r under key, replacing any existing object, and
+	// returns the number of bytes written. A failed Put leaves no partial object.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Open returns the object stored under key, or ErrNotFound
+	Open(ctx context.Context, key string) (Object, error)
+	// Delete removes the object stored under key; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// Local stores objects as files in a directory
+type Local struct {
+	dir string
+}
+
+// NewLocal creates a storage backend writing to dir, creating it if needed
+func NewLocal(dir string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &Local{dir: dir}, nil
+}
+
+// Put writes r to a temporary file and renames it into place once complete
+func (s *Local) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	n, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return n, fmt.Errorf("failed to write %s: %w", key, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return n, fmt.Errorf("failed to store %s: %w", key, err)
+	}
+	return n, nil
+}
+
+// Open opens the file stored under key
+func (s *Local) Open(_ context.Context, key string) (Object, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return localObject{File: f, modTime: info.ModTime()}, nil
+}
+
+// Delete removes the file stored under key
+func (s *Local) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path maps key to a file in the storage directory, rejecting keys that would escape it
+func (s *Local) path(key string) (string, error) {
+	if key == "" || strings.ContainsAny(key, `/\`) || strings.HasPrefix(key, ".") {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.dir, key), nil
+}
+
+// localObject is a file opened from Local storage
+type localObject struct {
+	*os.File
+	modTime time.Time
+}
+
+func (o localObject) ModTime() time.Time {
+	return o.modTime
+}
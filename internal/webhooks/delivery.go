@@ -0,0 +1,66 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Delivery is one attempt to deliver an event to a subscription
+type Delivery struct {
+	bun.BaseModel `bun:"table:webhook_deliveries,alias:wd" swaggerignore:"true"`
+
+	ID             int64     `bun:"id,pk,autoincrement" json:"id"`
+	SubscriptionID int64     `bun:"subscription_id,notnull" json:"subscription_id"`
+	EventID        string    `bun:"event_id,notnull" json:"event_id" example:"evt_5f1c0e2a9b7d4c3e"`
+	EventType      string    `bun:"event_type,notnull" json:"event_type" example:"menu_item.updated"`
+	Attempt        int       `bun:"attempt,notnull" json:"attempt"`
+	Succeeded      bool      `bun:"succeeded,notnull" json:"succeeded"`
+	StatusCode     *int      `bun:"status_code" json:"status_code,omitempty" example:"200"`
+	Error          *string   `bun:"error" json:"error,omitempty"`
+	DurationMs     int64     `bun:"duration_ms,notnull" json:"duration_ms"`
+	CreatedAt      time.Time `bun:"created_at,notnull" json:"created_at"`
+}
+
+// ListDeliveries returns the delivery attempts of a subscription, newest first
+func ListDeliveries(ctx context.Context, db bun.IDB, subscriptionID int64, limit int) ([]Delivery, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	var deliveries []Delivery
+	err := db.NewSelect().Model(&deliveries).
+		Where("subscription_id = ?", subscriptionID).
+		Order("id DESC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// PruneDeliveries deletes delivery attempts made before cutoff and returns how many
+// were removed
+func PruneDeliveries(ctx context.Context, db bun.IDB, cutoff time.Time) (int64, error) {
+	res, err := db.NewDelete().Model((*Delivery)(nil)).Where("created_at < ?", cutoff).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Sign computes the signature sent in the X-Agora-Signature header: the hex-encoded
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with the subscription's secret
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
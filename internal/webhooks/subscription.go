@@ -0,0 +1,150 @@
+// Package webhooks stores outbound webhook subscriptions and their delivery attempts
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Webhook errors
+var (
+	ErrNotFound            = errors.New("webhook subscription not found")
+	ErrInvalidSubscription = errors.New("invalid webhook subscription")
+)
+
+// Subscription is a URL receiving the events it subscribed to. Events are exact
+// event types (menu_item.created), prefixes ending in * (menu_item.*), or * for all.
+type Subscription struct {
+	bun.BaseModel `bun:"table:webhook_subscriptions,alias:ws" swaggerignore:"true"`
+
+	ID          int64     `bun:"id,pk,autoincrement" json:"id"`
+	URL         string    `bun:"url,notnull" json:"url" example:"https://example.com/hooks/agora"`
+	Events      []string  `bun:"events,notnull" json:"events" example:"menu_item.*"`
+	Description string    `bun:"description,notnull" json:"description,omitempty"`
+	Secret      string    `bun:"secret,notnull" json:"-"`
+	Active      bool      `bun:"active,notnull" json:"active"`
+	CreatedAt   time.Time `bun:"created_at,notnull" json:"created_at"`
+	UpdatedAt   time.Time `bun:"updated_at,notnull" json:"updated_at"`
+}
+
+// Matches reports whether the subscription wants events of the given type
+func (s *Subscription) Matches(eventType string) bool {
+	return slices.ContainsFunc(s.Events, func(pattern string) bool {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			return strings.HasPrefix(eventType, prefix)
+		}
+		return pattern == eventType
+	})
+}
+
+// CreateRequest describes a new subscription
+type CreateRequest struct {
+	URL         string   `json:"url" example:"https://example.com/hooks/agora"`
+	Events      []string `json:"events" example:"menu_item.*"`
+	Description string   `json:"description,omitempty"`
+}
+
+// validate checks the URL and event patterns
+func (r *CreateRequest) validate() error {
+	u, err := url.Parse(r.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidSubscription)
+	}
+	if len(r.Events) == 0 {
+		return fmt.Errorf("%w: events must list at least one event type", ErrInvalidSubscription)
+	}
+	for _, event := range r.Events {
+		if strings.TrimSpace(event) == "" || strings.Contains(strings.TrimSuffix(event, "*"), "*") {
+			return fmt.Errorf("%w: invalid event pattern %q", ErrInvalidSubscription, event)
+		}
+	}
+	return nil
+}
+
+// Create stores a new active subscription with a freshly generated signing secret
+func Create(ctx context.Context, db bun.IDB, req CreateRequest) (*Subscription, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sub := &Subscription{
+		URL:         req.URL,
+		Events:      req.Events,
+		Description: req.Description,
+		Secret:      secret,
+		Active:      true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if _, err := db.NewInsert().Model(sub).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// Get returns a subscription by ID
+func Get(ctx context.Context, db bun.IDB, id int64) (*Subscription, error) {
+	sub := new(Subscription)
+	err := db.NewSelect().Model(sub).Where("id = ?", id).Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// List returns all subscriptions, oldest first
+func List(ctx context.Context, db bun.IDB) ([]Subscription, error) {
+	var subs []Subscription
+	if err := db.NewSelect().Model(&subs).Order("id ASC").Scan(ctx); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// Matching returns the active subscriptions that want events of the given type
+func Matching(ctx context.Context, db bun.IDB, eventType string) ([]Subscription, error) {
+	var subs []Subscription
+	if err := db.NewSelect().Model(&subs).Where("active = ?", true).Scan(ctx); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(subs, func(s Subscription) bool { return !s.Matches(eventType) }), nil
+}
+
+// Delete removes a subscription along with its delivery history
+func Delete(ctx context.Context, db bun.IDB, id int64) error {
+	res, err := db.NewDelete().Model((*Subscription)(nil)).Where("id = ?", id).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// newSecret generates a random signing secret
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
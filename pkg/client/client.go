@@ -0,0 +1,228 @@
+// Package client is a typed Go client for the Agora REST API, for internal tools and
+// tests that would otherwise hand-roll HTTP calls.
+//
+//	c, err := client.New("http://localhost:3000")
+//	items, err := c.ListMenuItems(ctx, &client.ListMenuItemsOptions{Category: "main"})
+//
+// Requests that fail with a network error, 429, 502, 503 or 504 are retried with
+// exponential backoff, honoring Retry-After. POST requests are only retried when the
+// server refused them before doing anything (429 and 503).
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults for New
+const (
+	DefaultTimeout    = 30 * time.Second
+	DefaultMaxRetries = 3
+	DefaultMinBackoff = 200 * time.Millisecond
+	DefaultMaxBackoff = 5 * time.Second
+)
+
+// Client calls the Agora REST API. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	userAgent  string
+	adminToken string
+	restaurant int
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(c *http.Client) Option {
+	return func(cl *Client) { cl.httpClient = c }
+}
+
+// WithRetries sets how often a failed request is retried (0 disables retries) and
+// the bounds of the exponential backoff between attempts
+func WithRetries(maxRetries int, minBackoff, maxBackoff time.Duration) Option {
+	return func(cl *Client) {
+		cl.maxRetries, cl.minBackoff, cl.maxBackoff = maxRetries, minBackoff, maxBackoff
+	}
+}
+
+// WithUserAgent sets the User-Agent header of requests
+func WithUserAgent(userAgent string) Option {
+	return func(cl *Client) { cl.userAgent = userAgent }
+}
+
+// WithAdminToken sets the bearer token sent with requests, as required by the admin
+// endpoints
+func WithAdminToken(token string) Option {
+	return func(cl *Client) { cl.adminToken = token }
+}
+
+// WithRestaurant sets the restaurant requests are made for, sent as the
+// X-Restaurant-ID header; the server uses its default restaurant without it
+func WithRestaurant(id int) Option {
+	return func(cl *Client) { cl.restaurant = id }
+}
+
+// New creates a client for the server at baseURL, e.g. "http://localhost:3000"
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		userAgent:  "agora-client-go",
+		maxRetries: DefaultMaxRetries,
+		minBackoff: DefaultMinBackoff,
+		maxBackoff: DefaultMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// envelope is the v1 success response body
+type envelope struct {
+	Data    json.RawMessage `json:"data"`
+	Message string          `json:"message"`
+}
+
+// do sends a request with a JSON body (when body is non-nil) and decodes the data of
+// the response envelope into out (when out is non-nil)
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	u := c.baseURL.JoinPath(path)
+	u.RawQuery = query.Encode()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, u.String(), payload)
+		if err == nil && resp.StatusCode < 300 {
+			defer resp.Body.Close()
+			return decodeEnvelope(resp, out)
+		}
+
+		var wait time.Duration
+		if err == nil {
+			apiErr := newAPIError(resp)
+			if attempt >= c.maxRetries || !retryableStatus(method, resp.StatusCode) {
+				return apiErr
+			}
+			err, wait = apiErr, retryAfter(resp)
+		} else if ctx.Err() != nil || attempt >= c.maxRetries || method == http.MethodPost {
+			// A POST that failed in transit may have been applied
+			return err
+		}
+
+		if wait == 0 {
+			wait = c.backoff(attempt)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// send performs a single attempt
+func (c *Client) send(ctx context.Context, method, u string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.restaurant != 0 {
+		req.Header.Set("X-Restaurant-ID", strconv.Itoa(c.restaurant))
+	}
+	if c.adminToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.adminToken)
+	}
+	return c.httpClient.Do(req)
+}
+
+// decodeEnvelope decodes the data of a success response into out
+func decodeEnvelope(resp *http.Response, out any) error {
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if err := json.Unmarshal(env.Data, out); err != nil {
+		return fmt.Errorf("failed to decode response data: %w", err)
+	}
+	return nil
+}
+
+// retryableStatus reports whether a response with status can be retried. Only 429
+// and 503 guarantee that a POST had no effect.
+func retryableStatus(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return method != http.MethodPost
+	default:
+		return false
+	}
+}
+
+// retryAfter returns the delay requested by a Retry-After header in seconds, or 0
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// backoff returns the delay before the retry following attempt, with full jitter
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.maxBackoff
+	if attempt < 30 {
+		d = min(c.minBackoff<<attempt, c.maxBackoff)
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(d))) + time.Millisecond
+}
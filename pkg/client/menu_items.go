@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MenuItem is a menu item as returned by the API
+type MenuItem struct {
+	ID          int             `json:"id"`
+	Name        string          `json:"name"`
+	Description *string         `json:"description,omitempty"`
+	Price       decimal.Decimal `json:"price"`
+	Category    string          `json:"category"`
+	IsAvailable bool            `json:"is_available"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`
+}
+
+// CreateMenuItemRequest is the data of a new menu item. IsAvailable defaults to true.
+type CreateMenuItemRequest struct {
+	Name        string          `json:"name"`
+	Description *string         `json:"description,omitempty"`
+	Price       decimal.Decimal `json:"price"`
+	Category    string          `json:"category"`
+	IsAvailable *bool           `json:"is_available,omitempty"`
+}
+
+// UpdateMenuItemRequest changes the fields that are set
+type UpdateMenuItemRequest struct {
+	Name        *string          `json:"name,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	Price       *decimal.Decimal `json:"price,omitempty"`
+	Category    *string          `json:"category,omitempty"`
+	IsAvailable *bool            `json:"is_available,omitempty"`
+}
+
+// ListMenuItemsOptions filters ListMenuItems. The server applies one filter, in the
+// order Search, Category, AvailableOnly, IncludeDeleted.
+type ListMenuItemsOptions struct {
+	Search         string
+	Category       string
+	AvailableOnly  bool
+	IncludeDeleted bool
+	// Include lists related resources to embed
+	Include []string
+}
+
+// values encodes the options as query parameters
+func (o *ListMenuItemsOptions) values() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+	if o.Search != "" {
+		q.Set("search", o.Search)
+	}
+	if o.Category != "" {
+		q.Set("category", o.Category)
+	}
+	if o.AvailableOnly {
+		q.Set("available", "true")
+	}
+	if o.IncludeDeleted {
+		q.Set("include_deleted", "true")
+	}
+	if len(o.Include) > 0 {
+		q.Set("include", strings.Join(o.Include, ","))
+	}
+	return q
+}
+
+// ListMenuItems returns the menu items matching opts, which may be nil
+func (c *Client) ListMenuItems(ctx context.Context, opts *ListMenuItemsOptions) ([]MenuItem, error) {
+	var items []MenuItem
+	err := c.do(ctx, http.MethodGet, "/api/v1/items", opts.values(), nil, &items)
+	return items, err
+}
+
+// ListDeletedMenuItems returns the soft-deleted menu items
+func (c *Client) ListDeletedMenuItems(ctx context.Context) ([]MenuItem, error) {
+	var items []MenuItem
+	err := c.do(ctx, http.MethodGet, "/api/v1/items/deleted", nil, nil, &items)
+	return items, err
+}
+
+// GetMenuItem returns a menu item by ID; IsNotFound reports a missing item
+func (c *Client) GetMenuItem(ctx context.Context, id int) (*MenuItem, error) {
+	var item MenuItem
+	if err := c.do(ctx, http.MethodGet, menuItemPath(id), nil, nil, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CreateMenuItem creates a menu item
+func (c *Client) CreateMenuItem(ctx context.Context, req CreateMenuItemRequest) (*MenuItem, error) {
+	var item MenuItem
+	if err := c.do(ctx, http.MethodPost, "/api/v1/items", nil, req, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateMenuItem changes the fields of a menu item that are set in req
+func (c *Client) UpdateMenuItem(ctx context.Context, id int, req UpdateMenuItemRequest) (*MenuItem, error) {
+	var item MenuItem
+	if err := c.do(ctx, http.MethodPut, menuItemPath(id), nil, req, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// DeleteMenuItem soft-deletes a menu item, which can be restored
+func (c *Client) DeleteMenuItem(ctx context.Context, id int) error {
+	return c.do(ctx, http.MethodDelete, menuItemPath(id), nil, nil, nil)
+}
+
+// ForceDeleteMenuItem permanently deletes a menu item
+func (c *Client) ForceDeleteMenuItem(ctx context.Context, id int) error {
+	return c.do(ctx, http.MethodDelete, menuItemPath(id), url.Values{"force": {"true"}}, nil, nil)
+}
+
+// RestoreMenuItem restores a soft-deleted menu item
+func (c *Client) RestoreMenuItem(ctx context.Context, id int) (*MenuItem, error) {
+	var item MenuItem
+	if err := c.do(ctx, http.MethodPost, menuItemPath(id)+"/restore", nil, nil, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// menuItemPath returns the path of a menu item
+func menuItemPath(id int) string {
+	return "/api/v1/items/" + strconv.Itoa(id)
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Order is an order as returned by the API
+type Order struct {
+	ID            string          `json:"id"`
+	Source        string          `json:"source"`
+	ExternalID    *string         `json:"external_id,omitempty"`
+	Channel       string          `json:"channel"`
+	Status        string          `json:"status"`
+	Total         decimal.Decimal `json:"total"`
+	CustomerName  *string         `json:"customer_name,omitempty"`
+	CustomerPhone *string         `json:"customer_phone,omitempty"`
+	Notes         *string         `json:"notes,omitempty"`
+	Items         []OrderItem     `json:"items"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// OrderItem is a line of an order
+type OrderItem struct {
+	ID         int             `json:"id"`
+	MenuItemID *int            `json:"menu_item_id,omitempty"`
+	Name       string          `json:"name"`
+	Quantity   int             `json:"quantity"`
+	UnitPrice  decimal.Decimal `json:"unit_price"`
+	LineTotal  decimal.Decimal `json:"line_total"`
+	Notes      *string         `json:"notes,omitempty"`
+}
+
+// ListOrdersOptions filters and pages ListOrders
+type ListOrdersOptions struct {
+	Status string
+	Source string
+	// Limit is the page size; the server defaults to 50 and allows at most 200
+	Limit  int
+	Offset int
+}
+
+// values encodes the options as query parameters
+func (o *ListOrdersOptions) values() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+	if o.Status != "" {
+		q.Set("status", o.Status)
+	}
+	if o.Source != "" {
+		q.Set("source", o.Source)
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		q.Set("offset", strconv.Itoa(o.Offset))
+	}
+	return q
+}
+
+// ListOrders returns orders matching opts, which may be nil, newest first
+func (c *Client) ListOrders(ctx context.Context, opts *ListOrdersOptions) ([]Order, error) {
+	var orders []Order
+	err := c.do(ctx, http.MethodGet, "/api/v1/orders", opts.values(), nil, &orders)
+	return orders, err
+}
+
+// GetOrder returns an order by ID; IsNotFound reports a missing order
+func (c *Client) GetOrder(ctx context.Context, id string) (*Order, error) {
+	var order Order
+	if err := c.do(ctx, http.MethodGet, "/api/v1/orders/"+id, nil, nil, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}